@@ -137,3 +137,15 @@ func (cmd *Command) InitConfig(conf config.Config, path string) error {
 	cmd.Config = conf
 	return nil
 }
+
+// NewBuildInfo returns the build details of the command, the branch, the commit and the build time
+// are passed in its valid args
+func NewBuildInfo(cmd *cobra.Command) BuildInfo {
+	info := BuildInfo{Version: cmd.Version}
+	if len(cmd.ValidArgs) >= 3 {
+		info.Branch = cmd.ValidArgs[0]
+		info.Commit = cmd.ValidArgs[1]
+		info.Time = cmd.ValidArgs[2]
+	}
+	return info
+}
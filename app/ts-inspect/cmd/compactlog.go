@@ -0,0 +1,72 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/spf13/cobra"
+)
+
+var compactLogApply bool
+
+func init() {
+	compactLogCmd.Flags().BoolVar(&compactLogApply, "apply", false, "Recover the interrupted compactions as the store does on start.")
+	rootCmd.AddCommand(compactLogCmd)
+}
+
+var compactLogActions = map[int]string{
+	immutable.CompactLogCommit:   "commit, replace the old files by the new files",
+	immutable.CompactLogRollback: "rollback, restore the old files",
+	immutable.CompactLogInvalid:  "invalid, the files are incomplete",
+}
+
+var compactLogCmd = &cobra.Command{
+	Use:   "compactlog <shard dir>",
+	Short: "Inspect the compaction logs of a shard",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := cmd.OutOrStdout()
+		reports, err := immutable.InspectCompactLogs(args[0])
+		if err != nil {
+			return err
+		}
+
+		for _, r := range reports {
+			fmt.Fprintf(w, "log %s\n", r.File)
+			if r.Info.Name != "" {
+				fmt.Fprintf(w, "  measurement: %s, order: %v\n", r.Info.Name, r.Info.IsOrder)
+				fmt.Fprintf(w, "  old files:   %v\n", r.Info.OldFile)
+				fmt.Fprintf(w, "  new files:   %v\n", r.Info.NewFile)
+			}
+			if r.Err != nil {
+				fmt.Fprintf(w, "  error:       %v\n", r.Err)
+				continue
+			}
+			fmt.Fprintf(w, "  action:      %s\n", compactLogActions[r.Action])
+		}
+
+		if compactLogApply && len(reports) > 0 {
+			if err = immutable.RecoverCompactLogs(args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintln(w, "compaction logs are recovered")
+		}
+		return nil
+	},
+}
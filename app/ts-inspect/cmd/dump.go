@@ -0,0 +1,84 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/openGemini/openGemini/lib/record"
+	"github.com/spf13/cobra"
+)
+
+type dumpOptions struct {
+	sid     uint64
+	records bool
+}
+
+var dumpOpts dumpOptions
+
+func init() {
+	dumpCmd.Flags().Uint64Var(&dumpOpts.sid, "sid", 0, "Only dump the chunk of the series id.")
+	dumpCmd.Flags().BoolVar(&dumpOpts.records, "records", false, "Dump the decoded records of the chunks.")
+	rootCmd.AddCommand(dumpCmd)
+}
+
+var dumpCmd = &cobra.Command{
+	Use:   "dump <file>",
+	Short: "Dump the chunk metas and records of a TSSP file",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fi, err := immutable.OpenFileInspector(args[0])
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = fi.Close()
+		}()
+
+		w := cmd.OutOrStdout()
+		printFileStat(w, fi.Stat())
+		report, err := fi.Walk(func(cm *immutable.ChunkMeta, rec *record.Record) error {
+			if dumpOpts.sid != 0 && cm.GetSid() != dumpOpts.sid {
+				return nil
+			}
+			offset, size := cm.DataOffsetSize()
+			minTime, maxTime := cm.MinMaxTime()
+			fmt.Fprintf(w, "\nseries %d: offset %d, size %d, segments %d, rows %d, time [%s, %s]\n",
+				cm.GetSid(), offset, size, cm.SegmentCount(), rec.RowNums(), formatTime(minTime), formatTime(maxTime))
+			for i, field := range cm.Schema() {
+				fmt.Fprintf(w, "  column %d: %s %s\n", i, field.Name, record.ToInfluxqlTypes(field.Type))
+			}
+			for i := 0; i < cm.SegmentCount(); i++ {
+				minTime, maxTime = cm.SegmentTimeRange(i)
+				fmt.Fprintf(w, "  segment %d: [%s, %s]\n", i, formatTime(minTime), formatTime(maxTime))
+			}
+			if dumpOpts.records {
+				fmt.Fprintln(w, rec.String())
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(report.Errors) > 0 {
+			fmt.Fprintln(w)
+			printVerifyReport(w, report)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/spf13/cobra"
+)
+
+var repairOutDir string
+
+func init() {
+	repairCmd.Flags().StringVar(&repairOutDir, "out", "", "Dir of the rewritten file, the file is written to <out>/<measurement>/.")
+	_ = repairCmd.MarkFlagRequired("out")
+	rootCmd.AddCommand(repairCmd)
+}
+
+var repairCmd = &cobra.Command{
+	Use:   "repair <file> --out <dir>",
+	Short: "Rewrite a TSSP file without the unreadable chunks",
+	Long: `Rewrite a TSSP file without the unreadable chunks, and report the chunks that are lost.
+The original file is kept, replace it with the rewritten file while the store is stopped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := cmd.OutOrStdout()
+		report, err := immutable.RepairFile(args[0], repairOutDir)
+		if report != nil {
+			printVerifyReport(w, &report.VerifyReport)
+			fmt.Fprintf(w, "written chunks: %d\n", report.WrittenChunks)
+			if report.File != "" {
+				fmt.Fprintf(w, "written file:   %s\n", report.File)
+			}
+		}
+		return err
+	},
+}
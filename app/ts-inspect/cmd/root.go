@@ -0,0 +1,95 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/openGemini/openGemini/lib/fileops"
+	"github.com/spf13/cobra"
+)
+
+type rootOptions struct {
+	encryptionKeyDir string
+}
+
+var rootOpts rootOptions
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&rootOpts.encryptionKeyDir, "encryption-key-dir", "",
+		"The key directory of encrypted files, the encryption-key-dir of the store. A repaired file is encrypted with the greatest key id.")
+}
+
+var (
+	rootCmd = &cobra.Command{
+		Use:   "ts-inspect",
+		Short: "openGemini TSSP and WAL file inspection tool",
+		Long: `ts-inspect reads TSSP files offline. It dumps the file stats, chunk metas and records,
+verifies the crc and the decoding of every block, rewrites a damaged file without the
+unreadable chunks, inspects the compaction logs of a shard, and dumps the records of WAL files.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if rootOpts.encryptionKeyDir == "" {
+				return nil
+			}
+			provider, err := fileops.NewFileKeyProvider(rootOpts.encryptionKeyDir, "")
+			if err != nil {
+				return err
+			}
+			fileops.SetKeyProvider(provider)
+			return nil
+		},
+	}
+)
+
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func formatTime(t int64) string {
+	return fmt.Sprintf("%d(%s)", t, time.Unix(0, t).UTC().Format(time.RFC3339Nano))
+}
+
+func printFileStat(w io.Writer, stat immutable.FileStat) {
+	fmt.Fprintf(w, "file:          %s\n", stat.Name)
+	fmt.Fprintf(w, "measurement:   %s\n", stat.Measurement)
+	fmt.Fprintf(w, "version:       %d\n", stat.Version)
+	fmt.Fprintf(w, "file size:     %d\n", stat.FileSize)
+	fmt.Fprintf(w, "data size:     %d\n", stat.DataSize)
+	fmt.Fprintf(w, "meta size:     %d\n", stat.MetaSize)
+	fmt.Fprintf(w, "series:        %d [%d, %d]\n", stat.SeriesCount, stat.MinSid, stat.MaxSid)
+	fmt.Fprintf(w, "time:          [%s, %s]\n", formatTime(stat.MinTime), formatTime(stat.MaxTime))
+	fmt.Fprintf(w, "meta blocks:   %d\n", stat.MetaIndexCount)
+}
+
+func printVerifyReport(w io.Writer, report *immutable.VerifyReport) {
+	fmt.Fprintf(w, "chunks:        %d\n", report.Chunks)
+	fmt.Fprintf(w, "rows:          %d\n", report.Rows)
+	fmt.Fprintf(w, "crc skipped:   %d\n", report.CrcSkipped)
+	fmt.Fprintf(w, "bad chunks:    %d\n", len(report.Errors))
+	for _, e := range report.Errors {
+		if e.MetaBlock {
+			fmt.Fprintf(w, "  meta block %d (first series %d): %v\n", e.MetaIndex, e.Sid, e.Err)
+			continue
+		}
+		fmt.Fprintf(w, "  series %d in meta block %d: %v\n", e.Sid, e.MetaIndex, e.Err)
+	}
+}
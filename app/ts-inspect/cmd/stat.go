@@ -0,0 +1,45 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(statCmd)
+}
+
+var statCmd = &cobra.Command{
+	Use:   "stat <file>...",
+	Short: "Print the stats of TSSP files",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		for _, name := range args {
+			fi, err := immutable.OpenFileInspector(name)
+			if err != nil {
+				return err
+			}
+			printFileStat(cmd.OutOrStdout(), fi.Stat())
+			if err = fi.Close(); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(verifyCmd)
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify <file or dir>...",
+	Short: "Verify the crc and the decoding of every block of TSSP files",
+	Long: `Verify the crc and the decoding of every block of TSSP files.
+The TSSP files under a dir are verified recursively.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := cmd.OutOrStdout()
+		bad := 0
+		for _, arg := range args {
+			err := filepath.Walk(arg, func(name string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if info.IsDir() || !strings.HasSuffix(name, ".tssp") {
+					return nil
+				}
+
+				fmt.Fprintf(w, "verify %s\n", name)
+				fi, err := immutable.OpenFileInspector(name)
+				if err != nil {
+					bad++
+					fmt.Fprintf(w, "  open fail: %v\n", err)
+					return nil
+				}
+				report, err := fi.Verify()
+				_ = fi.Close()
+				if err != nil {
+					return err
+				}
+				printVerifyReport(w, report)
+				if len(report.Errors) > 0 {
+					bad++
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if bad > 0 {
+			return fmt.Errorf("%d damaged files found", bad)
+		}
+		return nil
+	},
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openGemini/openGemini/engine"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/spf13/cobra"
+)
+
+var walRecords bool
+
+func init() {
+	walCmd.Flags().BoolVar(&walRecords, "records", false, "print every record")
+	rootCmd.AddCommand(walCmd)
+}
+
+var walCmd = &cobra.Command{
+	Use:   "wal <file or dir>...",
+	Short: "Dump the records of WAL files and find the corrupt ones",
+	Long: `Dump the records of WAL files and find the corrupt ones, as the shard replays them.
+The WAL files under a dir are dumped recursively, including the quarantined files.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := cmd.OutOrStdout()
+		bad := 0
+		for _, arg := range args {
+			err := filepath.Walk(arg, func(name string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if info.IsDir() || !strings.HasSuffix(name, "."+engine.WALFileSuffixes) {
+					return nil
+				}
+
+				fmt.Fprintf(w, "wal %s\n", name)
+				corrupt, err := dumpWalFile(w, name)
+				if err != nil {
+					bad++
+					fmt.Fprintf(w, "  open fail: %v\n", err)
+					return nil
+				}
+				if corrupt > 0 {
+					bad++
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if bad > 0 {
+			return fmt.Errorf("%d damaged files found", bad)
+		}
+		return nil
+	},
+}
+
+func dumpWalFile(w io.Writer, name string) (int, error) {
+	r, err := engine.OpenWalReader(name)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	var rows []influx.Row
+	var tagPool []influx.Tag
+	var fieldPool []influx.Field
+	var indexOptionPool []influx.IndexOption
+	var indexKeyPool []byte
+	records, totalRows, corrupt := 0, 0, 0
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return corrupt, err
+		}
+
+		records++
+		if rec.Err != nil {
+			corrupt++
+			fmt.Fprintf(w, "  offset %d size %d: %v\n", rec.Offset, rec.Size, rec.Err)
+			continue
+		}
+
+		rows, tagPool, fieldPool, indexOptionPool, indexKeyPool, err = influx.FastUnmarshalMultiRows(rec.Data, rows[:0], tagPool[:0],
+			fieldPool[:0], indexOptionPool[:0], indexKeyPool[:0])
+		if err != nil {
+			corrupt++
+			fmt.Fprintf(w, "  offset %d size %d: unmarshal rows fail: %v\n", rec.Offset, rec.Size, err)
+			continue
+		}
+		totalRows += len(rows)
+		if walRecords {
+			fmt.Fprintf(w, "  offset %d size %d type %d rows %d\n", rec.Offset, rec.Size, rec.Type, len(rows))
+		}
+	}
+
+	fmt.Fprintf(w, "records:       %d\n", records)
+	fmt.Fprintf(w, "rows:          %d\n", totalRows)
+	fmt.Fprintf(w, "bad records:   %d\n", corrupt)
+	return corrupt, nil
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/influxdata/influxdb/cmd"
 	"github.com/openGemini/openGemini/app"
 	"github.com/openGemini/openGemini/app/ts-meta/run"
+	"github.com/openGemini/openGemini/app/ts-meta/schema"
 	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/lib/logger"
@@ -45,7 +46,9 @@ var (
 	pidPath  = flag.String("pidfile", "", "-pid=meta pid file path")
 )
 
-var versionUsage = `ts-meta -config=config_file_path -pidfile=pid_file_path`
+var versionUsage = `ts-meta -config=config_file_path -pidfile=pid_file_path
+ts-meta export-schema [-host=meta_http_addr] [-out=file] [-hashes]
+ts-meta import-schema [-host=meta_http_addr] [-file=file]`
 
 func usage() {
 	fmt.Println(versionUsage)
@@ -65,7 +68,7 @@ func main() {
 func doRun(args ...string) error {
 	errno.SetNode(errno.NodeMeta)
 
-	name, _ := cmd.ParseCommandName(args)
+	name, args := cmd.ParseCommandName(args)
 
 	switch name {
 	case "", "run":
@@ -112,6 +115,10 @@ func doRun(args ...string) error {
 		mainCmd.Logger.Info("Meta service received shutdown signal", zap.Any("signal", signal))
 		util.MustClose(mainCmd)
 		mainCmd.Logger.Info("Meta shutdown successfully!")
+	case "export-schema":
+		return schema.NewExportCommand().Run(args...)
+	case "import-schema":
+		return schema.NewImportCommand().Run(args...)
 	default:
 		return fmt.Errorf(`unknown command, usage:\n "%s"`+"\n\n", versionUsage)
 	}
@@ -0,0 +1,397 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/metaclient"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"go.uber.org/zap"
+)
+
+// adminApiPrefix is the path prefix of the versioned admin api, which shows the cluster topology and the events,
+// and performs the safe actions on the raft group of the meta nodes:
+//
+//	GET  /api/v1/nodes                        meta and data nodes with their gossip status
+//	GET  /api/v1/databases[?db=]              databases with their pt to node mapping
+//	GET  /api/v1/shardgroups?db=[&rp=]        shard groups of the retention policies
+//	GET  /api/v1/events                       in-flight assign and move events of the pts
+//	GET  /api/v1/raft                         raft peers, leader and log indexes of this node
+//	POST /api/v1/leader/transfer[?id=]        transfer the leadership to the meta node of id, or to any peer
+//	POST /api/v1/metanodes/remove?id=[&force] remove a dead meta node
+//	GET  /api/v1/schema[?hashes=true]         databases, measurements and users as json, without node ids,
+//	                                          the users are exported only if auth-enabled is set
+//	POST /api/v1/schema                       create the missing objects of a schema exported by GET
+//
+// If auth-enabled is set, the requests must carry the credentials of the admin user in the u and p params
+// or in the basic auth header, as the httpd service does. The POST requests change the cluster and the password
+// hashes of the users are secrets, so they are refused if auth-enabled is not set. The views are open without it,
+// so they leave out the users and their privileges.
+const adminApiPrefix = "/api/v1/"
+
+var errAdminCredentials = errors.New("unable to parse authentication credentials")
+var errAdminRequired = errors.New("admin privilege required")
+var errAdminAuthDisabled = errors.New("admin api actions require auth-enabled on the meta nodes")
+
+type adminResponse struct {
+	Ok     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+	Leader string `json:"leader,omitempty"`
+}
+
+type adminSchemaImport struct {
+	Ok           bool               `json:"ok"`
+	SkippedUsers []meta.SkippedUser `json:"skippedUsers,omitempty"`
+}
+
+type adminNode struct {
+	ID         uint64 `json:"id"`
+	Host       string `json:"host"`
+	RPCAddr    string `json:"rpcAddr"`
+	TCPHost    string `json:"tcpHost"`
+	Status     string `json:"status"`
+	GossipAddr string `json:"gossipAddr,omitempty"`
+
+	// meta nodes only
+	Leader bool `json:"leader,omitempty"`
+
+	// data nodes only
+	DiskStatus      string `json:"diskStatus,omitempty"`
+	Decommissioning bool   `json:"decommissioning,omitempty"`
+	Zone            string `json:"zone,omitempty"`
+	Rack            string `json:"rack,omitempty"`
+	Pts             int    `json:"pts"`
+}
+
+type adminNodes struct {
+	MetaNodes []adminNode `json:"metaNodes"`
+	DataNodes []adminNode `json:"dataNodes"`
+}
+
+type adminPt struct {
+	ID     uint32 `json:"id"`
+	Owner  uint64 `json:"owner"`
+	Status string `json:"status"`
+}
+
+type adminDatabase struct {
+	Name                   string    `json:"name"`
+	DefaultRetentionPolicy string    `json:"defaultRetentionPolicy"`
+	RetentionPolicies      []string  `json:"retentionPolicies"`
+	MarkDeleted            bool      `json:"markDeleted,omitempty"`
+	Pts                    []adminPt `json:"pts"`
+}
+
+type adminShard struct {
+	ID  uint64   `json:"id"`
+	Pts []uint32 `json:"pts"`
+	Min string   `json:"min,omitempty"`
+	Max string   `json:"max,omitempty"`
+}
+
+type adminShardGroup struct {
+	ID              uint64       `json:"id"`
+	RetentionPolicy string       `json:"retentionPolicy"`
+	StartTime       time.Time    `json:"startTime"`
+	EndTime         time.Time    `json:"endTime"`
+	Deleted         bool         `json:"deleted,omitempty"`
+	Shards          []adminShard `json:"shards"`
+}
+
+type adminEvent struct {
+	OpId     uint64 `json:"opId"`
+	Id       string `json:"id"`
+	Type     string `json:"type"`
+	Database string `json:"database"`
+	Pt       uint32 `json:"pt"`
+	Src      uint64 `json:"src"`
+	Dst      uint64 `json:"dst"`
+	State    string `json:"state"`
+	Bytes    int64  `json:"bytes"`
+	Error    string `json:"error,omitempty"`
+}
+
+type adminRaft struct {
+	raftStatus
+	DataIndex uint64 `json:"dataIndex"`
+}
+
+func (h *httpHandler) serveAdmin(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	if err := h.authorize(r); err != nil {
+		status := http.StatusUnauthorized
+		if err == errAdminRequired || err == errAdminAuthDisabled {
+			status = http.StatusForbidden
+		}
+		h.adminError(w, err, status)
+		return
+	}
+
+	var v interface{}
+	var err error
+	path := r.URL.Path[len(adminApiPrefix):]
+	switch r.Method + " " + path {
+	case "GET nodes":
+		v = adminNodesView(h.store.cloneData(), h.leaderHost())
+	case "GET databases":
+		v = adminDatabasesView(h.store.cloneData(), r.URL.Query().Get("db"))
+	case "GET shardgroups":
+		v, err = adminShardGroupsView(h.store.cloneData(), r.URL.Query().Get("db"), r.URL.Query().Get("rp"))
+	case "GET events":
+		v = adminEventsView(h.store.cloneData())
+	case "GET raft":
+		v, err = h.raftView()
+	case "POST leader/transfer":
+		err = h.transferLeadership(r)
+	case "POST metanodes/remove":
+		err = h.removeMetaNode(r)
+	case "GET schema":
+		v = h.exportSchema(r)
+	case "POST schema":
+		v, err = h.importSchema(r)
+	default:
+		h.adminError(w, errors.New("unknown admin api "+r.Method+" "+r.URL.Path), http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		h.adminError(w, err, adminErrorStatus(err))
+		return
+	}
+	if v == nil {
+		v = &adminResponse{Ok: true}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		h.adminError(w, err, http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(b)
+}
+
+// authorize checks the credentials of the request belong to the admin user if auth-enabled is set,
+// the requests changing the cluster or exporting the password hashes are refused if it is not set
+func (h *httpHandler) authorize(r *http.Request) error {
+	if !h.config.AuthEnabled {
+		if r.Method != http.MethodGet || exportsHashes(r) {
+			return errAdminAuthDisabled
+		}
+		return nil
+	}
+
+	q := r.URL.Query()
+	u, p := q.Get("u"), q.Get("p")
+	if u == "" || p == "" {
+		var ok bool
+		if u, p, ok = r.BasicAuth(); !ok {
+			return errAdminCredentials
+		}
+	}
+
+	user := h.store.user(u)
+	if user == nil || metaclient.CompareHashAndPlainPwd(user.Hash, p) != nil {
+		return meta.ErrAuthenticate
+	}
+	if !user.Admin {
+		return errAdminRequired
+	}
+	return nil
+}
+
+func (h *httpHandler) adminError(w http.ResponseWriter, err error, status int) {
+	h.logger.Error("admin api failed", zap.Error(err))
+	resp := &adminResponse{Error: err.Error()}
+	if errno.Equal(err, errno.MetaIsNotLeader) {
+		resp.Leader = h.leaderHost()
+	}
+	b, _ := json.Marshal(resp)
+	w.WriteHeader(status)
+	_, _ = w.Write(b)
+}
+
+func adminErrorStatus(err error) int {
+	switch {
+	case err == meta.ErrNodeNotFound || errno.Equal(err, errno.DatabaseNotFound):
+		return http.StatusNotFound
+	case errno.Equal(err, errno.MetaIsNotLeader) || err == ErrRaftNotOpen:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// leaderHost returns the http address of the leader, or an empty string if there is no leader
+func (h *httpHandler) leaderHost() string {
+	status, err := h.store.raftStatus()
+	if err != nil || status.Leader == "" {
+		return ""
+	}
+	return h.store.metaNodeHost(status.Leader)
+}
+
+func (h *httpHandler) raftView() (*adminRaft, error) {
+	status, err := h.store.raftStatus()
+	if err != nil {
+		return nil, err
+	}
+	return &adminRaft{raftStatus: *status, DataIndex: h.store.index()}, nil
+}
+
+func (h *httpHandler) transferLeadership(r *http.Request) error {
+	var id uint64
+	if s := r.URL.Query().Get("id"); s != "" {
+		var err error
+		if id, err = strconv.ParseUint(s, 10, 64); err != nil {
+			return err
+		}
+	}
+	h.logger.Info("transfer the leadership", zap.Uint64("to", id))
+	return h.store.transferLeadership(id)
+}
+
+func (h *httpHandler) removeMetaNode(r *http.Request) error {
+	q := r.URL.Query()
+	id, err := strconv.ParseUint(q.Get("id"), 10, 64)
+	if err != nil {
+		return err
+	}
+	force := q.Get("force") == "true"
+	h.logger.Info("remove the meta node", zap.Uint64("id", id), zap.Bool("force", force))
+	return h.store.removeMetaNode(id, force)
+}
+
+// exportsHashes returns true if the request asks for the password hashes of the users
+func exportsHashes(r *http.Request) bool {
+	return r.URL.Query().Get("hashes") == "true"
+}
+
+// exportSchema returns the schema of the cluster, the users are left out of the unauthenticated responses
+func (h *httpHandler) exportSchema(r *http.Request) *meta.ClusterSchema {
+	schema := h.store.cloneData().ExportSchema(exportsHashes(r))
+	if !h.config.AuthEnabled {
+		schema.Users = nil
+	}
+	return schema
+}
+
+func (h *httpHandler) importSchema(r *http.Request) (*adminSchemaImport, error) {
+	schema := &meta.ClusterSchema{}
+	if err := json.NewDecoder(r.Body).Decode(schema); err != nil {
+		return nil, err
+	}
+	h.logger.Info("import the schema", zap.Int("databases", len(schema.Databases)), zap.Int("users", len(schema.Users)))
+	skipped, err := h.store.importSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range skipped {
+		h.logger.Warn("skip the user of the schema", zap.String("user", u.Name), zap.String("reason", u.Reason))
+	}
+	return &adminSchemaImport{Ok: true, SkippedUsers: skipped}, nil
+}
+
+func adminNodesView(data *meta.Data, leaderHost string) *adminNodes {
+	v := &adminNodes{MetaNodes: []adminNode{}, DataNodes: []adminNode{}}
+	for _, n := range data.MetaNodes {
+		v.MetaNodes = append(v.MetaNodes, adminNode{ID: n.ID, Host: n.Host, RPCAddr: n.RPCAddr, TCPHost: n.TCPHost,
+			Status: n.Status.String(), GossipAddr: n.GossipAddr, Leader: n.Host == leaderHost})
+	}
+
+	pts := make(map[uint64]int)
+	for _, dbPts := range data.PtView {
+		for _, pt := range dbPts {
+			pts[pt.Owner.NodeID]++
+		}
+	}
+	for _, n := range data.DataNodes {
+		v.DataNodes = append(v.DataNodes, adminNode{ID: n.ID, Host: n.Host, RPCAddr: n.RPCAddr, TCPHost: n.TCPHost,
+			Status: n.Status.String(), GossipAddr: n.GossipAddr, DiskStatus: n.DiskStatus.String(),
+			Decommissioning: n.Decommissioning, Zone: n.Zone, Rack: n.Rack, Pts: pts[n.ID]})
+	}
+	return v
+}
+
+func adminDatabasesView(data *meta.Data, db string) []adminDatabase {
+	v := []adminDatabase{}
+	for name, dbi := range data.Databases {
+		if db != "" && name != db {
+			continue
+		}
+		d := adminDatabase{Name: name, DefaultRetentionPolicy: dbi.DefaultRetentionPolicy, MarkDeleted: dbi.MarkDeleted,
+			RetentionPolicies: []string{}, Pts: []adminPt{}}
+		for rp := range dbi.RetentionPolicies {
+			d.RetentionPolicies = append(d.RetentionPolicies, rp)
+		}
+		sort.Strings(d.RetentionPolicies)
+		for _, pt := range data.PtView[name] {
+			d.Pts = append(d.Pts, adminPt{ID: pt.PtId, Owner: pt.Owner.NodeID, Status: pt.Status.String()})
+		}
+		v = append(v, d)
+	}
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Name < v[j].Name
+	})
+	return v
+}
+
+func adminShardGroupsView(data *meta.Data, db, rp string) ([]adminShardGroup, error) {
+	dbi, err := data.GetDatabase(db)
+	if err != nil {
+		return nil, err
+	}
+
+	v := []adminShardGroup{}
+	for name, rpi := range dbi.RetentionPolicies {
+		if rp != "" && name != rp {
+			continue
+		}
+		for i := range rpi.ShardGroups {
+			sg := &rpi.ShardGroups[i]
+			g := adminShardGroup{ID: sg.ID, RetentionPolicy: name, StartTime: sg.StartTime, EndTime: sg.EndTime,
+				Deleted: sg.Deleted(), Shards: []adminShard{}}
+			for _, sh := range sg.Shards {
+				g.Shards = append(g.Shards, adminShard{ID: sh.ID, Pts: sh.Owners, Min: sh.Min, Max: sh.Max})
+			}
+			v = append(v, g)
+		}
+	}
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].ID < v[j].ID
+	})
+	return v, nil
+}
+
+func adminEventsView(data *meta.Data) []adminEvent {
+	v := []adminEvent{}
+	for _, e := range data.MigrateEvents {
+		pt := e.GetPtInfo()
+		v = append(v, adminEvent{OpId: e.GetOpId(), Id: e.GetEventId(), Type: meta.EventTypeName(e.GetEventType()),
+			Database: pt.Db, Pt: pt.Pti.PtId, Src: e.GetSrc(), Dst: e.GetDst(), State: e.StateName(),
+			Bytes: e.GetBytes(), Error: e.GetErr()})
+	}
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].OpId < v[j].OpId
+	})
+	return v
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	originql "github.com/influxdata/influxql"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
+	"github.com/openGemini/openGemini/open_src/golang.org/x/crypto/pbkdf2"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAdminStore struct {
+	IStore
+	data        *meta.Data
+	leader      bool
+	transferred []uint64
+	removed     []uint64
+}
+
+func (s *mockAdminStore) index() uint64 {
+	return s.data.Index
+}
+
+func (s *mockAdminStore) cloneData() *meta.Data {
+	return s.data.Clone()
+}
+
+func (s *mockAdminStore) user(name string) *meta.UserInfo {
+	return s.data.Clone().GetUser(name)
+}
+
+func (s *mockAdminStore) metaNodeHost(tcpHost string) string {
+	for _, n := range s.data.MetaNodes {
+		if n.TCPHost == tcpHost {
+			return n.Host
+		}
+	}
+	return ""
+}
+
+func (s *mockAdminStore) raftStatus() (*raftStatus, error) {
+	return &raftStatus{NodeID: 1, State: "Leader", Leader: "127.0.0.1:8088", LastLogIndex: 12, CommitIndex: 12,
+		AppliedIndex: 12, Peers: []string{"127.0.0.1:8088", "127.0.0.2:8088"}}, nil
+}
+
+func (s *mockAdminStore) transferLeadership(id uint64) error {
+	if !s.leader {
+		return errno.NewError(errno.MetaIsNotLeader)
+	}
+	s.transferred = append(s.transferred, id)
+	return nil
+}
+
+func (s *mockAdminStore) removeMetaNode(id uint64, force bool) error {
+	if id != 2 {
+		return meta.ErrNodeNotFound
+	}
+	s.removed = append(s.removed, id)
+	return nil
+}
+
+func (s *mockAdminStore) importSchema(schema *meta.ClusterSchema) ([]meta.SkippedUser, error) {
+	if !s.leader {
+		return nil, errno.NewError(errno.MetaIsNotLeader)
+	}
+	return s.data.ImportSchema(schema)
+}
+
+func newAdminTestData() *meta.Data {
+	data := &meta.Data{Index: 12, PtNumPerNode: 1}
+	data.MetaNodes = []meta.NodeInfo{
+		{ID: 1, Host: "127.0.0.1:8091", RPCAddr: "127.0.0.1:8092", TCPHost: "127.0.0.1:8088"},
+		{ID: 2, Host: "127.0.0.2:8091", RPCAddr: "127.0.0.2:8092", TCPHost: "127.0.0.2:8088"},
+	}
+	data.DataNodes = []meta.DataNode{
+		{NodeInfo: meta.NodeInfo{ID: 3, Host: "127.0.0.3:8400", TCPHost: "127.0.0.3:8401", Status: serf.StatusAlive}},
+		{NodeInfo: meta.NodeInfo{ID: 4, Host: "127.0.0.4:8400", TCPHost: "127.0.0.4:8401", Status: serf.StatusFailed},
+			DiskStatus: meta.DiskHigh},
+	}
+	data.Databases = map[string]*meta.DatabaseInfo{"db0": {
+		Name:                   "db0",
+		DefaultRetentionPolicy: "rp0",
+		RetentionPolicies: map[string]*meta.RetentionPolicyInfo{"rp0": {
+			Name: "rp0",
+			ShardGroups: []meta.ShardGroupInfo{{ID: 1, StartTime: time.Unix(0, 0), EndTime: time.Unix(3600, 0),
+				Shards: []meta.ShardInfo{{ID: 1, Owners: []uint32{0}}, {ID: 2, Owners: []uint32{1}}}}},
+		}},
+	}}
+	data.PtView = map[string]meta.DBPtInfos{"db0": {
+		{PtId: 0, Owner: meta.PtOwner{NodeID: 3}, Status: meta.Online},
+		{PtId: 1, Owner: meta.PtOwner{NodeID: 4}, Status: meta.Offline},
+	}}
+	pt := &meta.DbPtInfo{Db: "db0", Pti: &meta.PtInfo{PtId: 1, Owner: meta.PtOwner{NodeID: 4}, Status: meta.Offline}}
+	data.MigrateEvents = map[string]*meta.MigrateEventInfo{"db0$1": meta.NewMigrateEventInfo("db0$1", meta.MoveEventType, pt, 3)}
+	return data
+}
+
+func serveAdminRequest(h *httpHandler, method, url string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(method, url, nil))
+	return w
+}
+
+// newAdminAuthHandler returns a handler with auth-enabled set, whose admin user is admin with password Admin@123
+func newAdminAuthHandler(store *mockAdminStore) *httpHandler {
+	salt := make([]byte, 32)
+	hash := fmt.Sprintf("#Ver:002#%02X%02X", salt, pbkdf2.Key([]byte("Admin@123"), salt, 4096, 32, sha256.New))
+	store.data.Users = append(store.data.Users, meta.UserInfo{Name: "admin", Hash: hash, Admin: true},
+		meta.UserInfo{Name: "user", Hash: hash})
+	conf := config.NewMeta()
+	conf.AuthEnabled = true
+	return newHttpHandler(conf, store)
+}
+
+// serveAdminAction serves the request with the credentials of the admin user
+func serveAdminAction(h *httpHandler, method, url string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, body)
+	req.SetBasicAuth("admin", "Admin@123")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestAdminApi_Views(t *testing.T) {
+	store := &mockAdminStore{data: newAdminTestData(), leader: true}
+	h := newHttpHandler(config.NewMeta(), store)
+
+	w := serveAdminRequest(h, http.MethodGet, "/api/v1/nodes")
+	require.Equal(t, http.StatusOK, w.Code)
+	nodes := &adminNodes{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), nodes))
+	require.Equal(t, 2, len(nodes.MetaNodes))
+	assert.True(t, nodes.MetaNodes[0].Leader)
+	assert.False(t, nodes.MetaNodes[1].Leader)
+	require.Equal(t, 2, len(nodes.DataNodes))
+	assert.Equal(t, "alive", nodes.DataNodes[0].Status)
+	assert.Equal(t, "failed", nodes.DataNodes[1].Status)
+	assert.Equal(t, meta.DiskHigh.String(), nodes.DataNodes[1].DiskStatus)
+	assert.Equal(t, 1, nodes.DataNodes[1].Pts)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/databases")
+	require.Equal(t, http.StatusOK, w.Code)
+	var dbs []adminDatabase
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &dbs))
+	require.Equal(t, 1, len(dbs))
+	assert.Equal(t, []string{"rp0"}, dbs[0].RetentionPolicies)
+	assert.Equal(t, []adminPt{{ID: 0, Owner: 3, Status: "online"}, {ID: 1, Owner: 4, Status: "offline"}}, dbs[0].Pts)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/shardgroups?db=db0&rp=rp0")
+	require.Equal(t, http.StatusOK, w.Code)
+	var sgs []adminShardGroup
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &sgs))
+	require.Equal(t, 1, len(sgs))
+	assert.Equal(t, "rp0", sgs[0].RetentionPolicy)
+	assert.Equal(t, 2, len(sgs[0].Shards))
+	assert.Equal(t, []uint32{1}, sgs[0].Shards[1].Pts)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/shardgroups?db=db1")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/events")
+	require.Equal(t, http.StatusOK, w.Code)
+	var events []adminEvent
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &events))
+	require.Equal(t, 1, len(events))
+	assert.Equal(t, "move", events[0].Type)
+	assert.Equal(t, meta.MoveInit.String(), events[0].State)
+	assert.Equal(t, uint64(3), events[0].Dst)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/raft")
+	require.Equal(t, http.StatusOK, w.Code)
+	r := &adminRaft{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), r))
+	assert.Equal(t, uint64(1), r.NodeID)
+	assert.Equal(t, uint64(12), r.DataIndex)
+	assert.Equal(t, 2, len(r.Peers))
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/unknown")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAdminApi_Actions(t *testing.T) {
+	store := &mockAdminStore{data: newAdminTestData(), leader: true}
+	h := newAdminAuthHandler(store)
+
+	w := serveAdminAction(h, http.MethodPost, "/api/v1/leader/transfer?id=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []uint64{2}, store.transferred)
+
+	w = serveAdminAction(h, http.MethodPost, "/api/v1/metanodes/remove?id=2", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, []uint64{2}, store.removed)
+
+	w = serveAdminAction(h, http.MethodPost, "/api/v1/metanodes/remove?id=5", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = serveAdminAction(h, http.MethodPost, "/api/v1/metanodes/remove", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	store.leader = false
+	w = serveAdminAction(h, http.MethodPost, "/api/v1/leader/transfer", nil)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	resp := &adminResponse{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal(t, "127.0.0.1:8091", resp.Leader)
+}
+
+func TestAdminApi_Schema(t *testing.T) {
+	store := &mockAdminStore{data: newAdminTestData(), leader: true}
+	h := newAdminAuthHandler(store)
+
+	w := serveAdminAction(h, http.MethodGet, "/api/v1/schema", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	schema := &meta.ClusterSchema{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), schema))
+	require.Equal(t, 1, len(schema.Databases))
+	assert.Equal(t, "rp0", schema.Databases[0].DefaultRetentionPolicy)
+	assert.NotContains(t, w.Body.String(), "127.0.0.3")
+	require.Equal(t, 2, len(schema.Users))
+	assert.Empty(t, schema.Users[0].Hash)
+
+	w = serveAdminAction(h, http.MethodGet, "/api/v1/schema?hashes=true", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	schema = &meta.ClusterSchema{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), schema))
+	assert.Equal(t, store.data.Users[0].Hash, schema.Users[0].Hash)
+
+	// the admin user of another cluster is skipped and reported
+	schema.Databases[0].Name = "db1"
+	schema.Databases[0].RetentionPolicies[0].ReplicaN = 1
+	schema.Users[0].Name = "root"
+	store.data.ClusterPtNum = 2
+	b, err := json.Marshal(schema)
+	require.NoError(t, err)
+	w = serveAdminAction(h, http.MethodPost, "/api/v1/schema", bytes.NewReader(b))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotNil(t, store.data.Database("db1"))
+	assert.Nil(t, store.data.GetUser("root"))
+	resp := &adminSchemaImport{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal(t, []meta.SkippedUser{{Name: "root", Reason: "the cluster has an admin user already"}}, resp.SkippedUsers)
+
+	w = serveAdminAction(h, http.MethodPost, "/api/v1/schema", strings.NewReader("{"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	store.leader = false
+	w = serveAdminAction(h, http.MethodPost, "/api/v1/schema", bytes.NewReader(b))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestAdminApi_Auth(t *testing.T) {
+	store := &mockAdminStore{data: newAdminTestData(), leader: true}
+	h := newAdminAuthHandler(store)
+
+	w := serveAdminRequest(h, http.MethodGet, "/api/v1/nodes")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/nodes?u=admin&p=Admin@12")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/nodes?u=user&p=Admin@123")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/nodes?u=admin&p=Admin@123")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serveAdminAction(h, http.MethodGet, "/api/v1/events", nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// the actions need the admin user as the views do
+	for _, url := range []string{"/api/v1/leader/transfer?id=2", "/api/v1/metanodes/remove?id=2", "/api/v1/schema?db=db0"} {
+		w = serveAdminRequest(h, http.MethodPost, url)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		w = serveAdminRequest(h, http.MethodPost, url+"&u=user&p=Admin@123")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	}
+	assert.Empty(t, store.transferred)
+	assert.Empty(t, store.removed)
+}
+
+func TestAdminApi_ActionsWithoutAuth(t *testing.T) {
+	store := &mockAdminStore{data: newAdminTestData(), leader: true}
+	store.data.Users = append(store.data.Users, meta.UserInfo{Name: "admin", Admin: true},
+		meta.UserInfo{Name: "reader", Privileges: map[string]originql.Privilege{"db0": originql.ReadPrivilege}})
+	h := newHttpHandler(config.NewMeta(), store)
+
+	// the views are open, but nothing is changed if auth-enabled is not set
+	w := serveAdminRequest(h, http.MethodGet, "/api/v1/nodes")
+	assert.Equal(t, http.StatusOK, w.Code)
+	for _, url := range []string{"/api/v1/leader/transfer", "/api/v1/metanodes/remove?id=2", "/api/v1/schema"} {
+		w = serveAdminRequest(h, http.MethodPost, url)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	}
+	// the password hashes are not exported either
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/schema?hashes=true")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	// the users and their privileges are left out of the views
+	w = serveAdminRequest(h, http.MethodGet, "/api/v1/schema")
+	assert.Equal(t, http.StatusOK, w.Code)
+	schema := &meta.ClusterSchema{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), schema))
+	assert.Equal(t, 1, len(schema.Databases))
+	assert.Empty(t, schema.Users)
+	assert.NotContains(t, w.Body.String(), "admin")
+	assert.NotContains(t, w.Body.String(), "reader")
+	assert.Empty(t, store.transferred)
+	assert.Empty(t, store.removed)
+}
+
+func TestStore_RemoveMetaNode(t *testing.T) {
+	mms, err := NewMockMetaService(t.TempDir(), "127.0.0.1")
+	require.NoError(t, err)
+	defer mms.close()
+	require.NoError(t, mms.service.Open())
+
+	s := globalService.store
+	status, err := s.raftStatus()
+	require.NoError(t, err)
+	assert.Equal(t, "Leader", status.State)
+	self := status.NodeID
+	require.NotEqual(t, uint64(0), self)
+
+	assert.EqualError(t, s.removeMetaNode(self, true), fmt.Sprintf("meta node %d is the leader, transfer the leadership first", self))
+	assert.Equal(t, meta.ErrNodeNotFound, s.removeMetaNode(100, false))
+	assert.Equal(t, meta.ErrNodeNotFound, s.transferLeadership(100))
+
+	// a meta node which never started is unreachable
+	require.NoError(t, s.createMetaNode("127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:3"))
+	var dead uint64
+	for _, n := range s.GetData().MetaNodes {
+		if n.TCPHost == "127.0.0.1:3" {
+			dead = n.ID
+		}
+	}
+	require.NoError(t, s.removeMetaNode(dead, false))
+	assert.Nil(t, s.metaNode(dead))
+}
+
+func TestStore_ImportSchema(t *testing.T) {
+	mms, err := NewMockMetaService(t.TempDir(), "127.0.0.1")
+	require.NoError(t, err)
+	defer mms.close()
+	require.NoError(t, mms.service.Open())
+
+	s := globalService.store
+	_, err = s.createDataNode("127.0.0.1:8400", "127.0.0.1:8401", "", "")
+	require.NoError(t, err)
+
+	schema := &meta.ClusterSchema{Version: meta.SchemaVersion, Databases: []meta.DatabaseSchema{{
+		Name: "db0", DefaultRetentionPolicy: "rp0",
+		RetentionPolicies: []meta.RetentionPolicySchema{{Name: "rp0", ReplicaN: 1, Duration: "0s",
+			Measurements: []meta.MeasurementSchema{{Name: "cpu", Fields: map[string]string{"value": "float"}}}}},
+	}}}
+	_, err = s.importSchema(schema)
+	require.NoError(t, err)
+	_, err = s.importSchema(schema)
+	require.NoError(t, err)
+	mst, err := s.GetData().Measurement("db0", "rp0", "cpu")
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(mst.Schema))
+
+	// the cluster has an admin user, the one of the next schema is skipped
+	schema.Users = []meta.UserSchema{{Name: "root", Hash: "hash0", Admin: true}}
+	skipped, err := s.importSchema(schema)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+	schema.Users = []meta.UserSchema{{Name: "admin", Hash: "hash1", Admin: true}, {Name: "reader", Hash: "hash2"}}
+	skipped, err = s.importSchema(schema)
+	require.NoError(t, err)
+	assert.Equal(t, []meta.SkippedUser{{Name: "admin", Reason: "the cluster has an admin user already"}}, skipped)
+	assert.Nil(t, s.GetData().GetUser("admin"))
+	assert.NotNil(t, s.GetData().GetUser("reader"))
+
+	schema.Databases[0].RetentionPolicies[0].Measurements[0].Fields["value"] = "string"
+	_, err = s.importSchema(schema)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), meta.ErrFieldTypeConflict.Error())
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/logger"
+	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	mproto "github.com/openGemini/openGemini/open_src/influx/meta/proto"
+	"go.uber.org/zap"
+)
+
+// ptLoads keeps the disk size of the pts reported by the store nodes, it is the load of the pts
+type ptLoads struct {
+	mu    sync.RWMutex
+	sizes map[string]map[uint32]uint64 // db -> pt -> disk size in bytes
+}
+
+func (l *ptLoads) update(db string, pt uint32, size uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.sizes == nil {
+		l.sizes = make(map[string]map[uint32]uint64)
+	}
+	if l.sizes[db] == nil {
+		l.sizes[db] = make(map[uint32]uint64)
+	}
+	l.sizes[db][pt] = size
+}
+
+func (l *ptLoads) get(db string, pt uint32) uint64 {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.sizes[db][pt]
+}
+
+// balanceView is the state of the cluster the balancer plans the moves from
+type balanceView struct {
+	nodes  meta.DataNodeInfos
+	ptView map[string]meta.DBPtInfos
+	events map[string]*meta.MigrateEventInfo
+	loads  func(db string, pt uint32) uint64
+	paused bool
+	dryRun bool
+}
+
+type balanceMove struct {
+	db     string
+	pt     uint32
+	src    uint64
+	dst    uint64
+	reason string
+}
+
+type balancePt struct {
+	db   string
+	pt   uint32
+	load uint64
+}
+
+type balanceNode struct {
+	id       uint64
+	target   bool // pts can be moved to the node
+	load     uint64
+	count    int
+	dbCounts map[string]int
+	zone     string
+	zoneDbs  map[string]int // pt count of the databases in the zone, shared by the nodes of the zone
+	pts      []balancePt    // the pts which can be moved
+}
+
+func (n *balanceNode) remove(p balancePt) {
+	for i := range n.pts {
+		if n.pts[i].db == p.db && n.pts[i].pt == p.pt {
+			n.pts = append(n.pts[:i], n.pts[i+1:]...)
+			break
+		}
+	}
+	n.count--
+	n.load -= p.load
+	n.dbCounts[p.db]--
+	n.zoneDbs[p.db]--
+}
+
+func (n *balanceNode) add(p balancePt) {
+	n.count++
+	n.load += p.load
+	n.dbCounts[p.db]++
+	n.zoneDbs[p.db]++
+}
+
+// zoneSpread reports whether the pts of the db stay spread across the zones after moving the pt to the dest
+func zoneSpread(src, dst *balanceNode, p balancePt) bool {
+	return src.zone == dst.zone || src.zoneDbs[p.db] > dst.zoneDbs[p.db]
+}
+
+func absDiff(a, b uint64) uint64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// planBalance returns the moves evening out the pt count, and then the disk usage, of the data nodes.
+// Nothing is moved while a node is not alive or a pt is being assigned, the pending moves count
+// against maxMoves so that the moves are rate limited.
+func planBalance(v *balanceView, maxMoves int, diskFactor float64) []balanceMove {
+	budget := maxMoves
+	busy := make(map[string]bool, len(v.events))
+	for id, e := range v.events {
+		busy[id] = true
+		if e.GetEventType() != meta.MoveEventType {
+			return nil
+		}
+		if meta.MoveState(e.GetCurrentState()) != meta.MoveFailed {
+			budget--
+		}
+	}
+	if budget <= 0 {
+		return nil
+	}
+
+	nodes := make(map[uint64]*balanceNode, len(v.nodes))
+	zones := make(map[string]map[string]int)
+	for i := range v.nodes {
+		n := &v.nodes[i]
+		if n.Status != serf.StatusAlive {
+			return nil
+		}
+		if n.Decommissioning {
+			continue
+		}
+		if zones[n.Zone] == nil {
+			zones[n.Zone] = make(map[string]int)
+		}
+		nodes[n.ID] = &balanceNode{id: n.ID, target: n.DiskStatus < meta.DiskHigh, dbCounts: make(map[string]int),
+			zone: n.Zone, zoneDbs: zones[n.Zone]}
+	}
+	if len(nodes) < 2 {
+		return nil
+	}
+
+	dbs := make([]string, 0, len(v.ptView))
+	for db := range v.ptView {
+		dbs = append(dbs, db)
+	}
+	sort.Strings(dbs)
+	var total uint64
+	for _, db := range dbs {
+		for _, pt := range v.ptView[db] {
+			n := nodes[pt.Owner.NodeID]
+			if n == nil {
+				continue
+			}
+			p := balancePt{db: db, pt: pt.PtId, load: v.loads(db, pt.PtId)}
+			n.add(p)
+			total += p.load
+			dbPt := &meta.DbPtInfo{Db: db, Pti: &pt}
+			if pt.Status == meta.Online && !busy[dbPt.String()] {
+				n.pts = append(n.pts, p)
+			}
+		}
+	}
+
+	sorted := make([]*balanceNode, 0, len(nodes))
+	for _, n := range nodes {
+		sorted = append(sorted, n)
+	}
+	avgLoad := float64(total) / float64(len(sorted))
+
+	var moves []balanceMove
+	for len(moves) < budget {
+		planned := pickBalanceMoves(sorted, avgLoad, diskFactor, budget-len(moves))
+		if len(planned) == 0 {
+			break
+		}
+		moves = append(moves, planned...)
+	}
+	return moves
+}
+
+// pickBalanceMoves picks a move from the node with the most pts to the node with the fewest pts,
+// or, when the pt count is even, the moves making the load of the most and the least loaded nodes closer.
+// The load moves keep the pts of every db spread across the zones
+func pickBalanceMoves(nodes []*balanceNode, avgLoad, diskFactor float64, budget int) []balanceMove {
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].count != nodes[j].count {
+			return nodes[i].count > nodes[j].count
+		}
+		if nodes[i].load != nodes[j].load {
+			return nodes[i].load > nodes[j].load
+		}
+		return nodes[i].id < nodes[j].id
+	})
+	src := nodes[0]
+	var dst *balanceNode
+	for i := len(nodes) - 1; i > 0; i-- {
+		if nodes[i].target {
+			dst = nodes[i]
+			break
+		}
+	}
+	if dst != nil && src.count-dst.count >= 2 {
+		if p, ok := pickCountMove(src, dst); ok {
+			return []balanceMove{moveBalancePt(src, dst, p, "pt count")}
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].load != nodes[j].load {
+			return nodes[i].load > nodes[j].load
+		}
+		return nodes[i].id < nodes[j].id
+	})
+	src, dst = nodes[0], nil
+	for i := len(nodes) - 1; i > 0; i-- {
+		if nodes[i].target {
+			dst = nodes[i]
+			break
+		}
+	}
+	if dst == nil || avgLoad == 0 || float64(src.load-dst.load) <= diskFactor*avgLoad {
+		return nil
+	}
+
+	diff := src.load - dst.load
+	switch {
+	case src.count == dst.count+1:
+		// moving a pt smaller than the difference makes the load closer
+		best, found := balancePt{}, false
+		for _, p := range src.pts {
+			if !zoneSpread(src, dst, p) {
+				continue
+			}
+			if p.load > 0 && p.load < diff && (!found || absDiff(diff, 2*p.load) < absDiff(diff, 2*best.load)) {
+				best, found = p, true
+			}
+		}
+		if found {
+			return []balanceMove{moveBalancePt(src, dst, best, "load")}
+		}
+	case src.count == dst.count && budget >= 2:
+		// the pt count is kept by swapping a larger pt of the source for a smaller pt of the dest
+		var a, b balancePt
+		found := false
+		for _, pa := range src.pts {
+			for _, pb := range dst.pts {
+				if pa.load <= pb.load || pa.load-pb.load >= diff {
+					continue
+				}
+				if pa.db != pb.db && (!zoneSpread(src, dst, pa) || !zoneSpread(dst, src, pb)) {
+					continue
+				}
+				d := absDiff(diff, 2*(pa.load-pb.load))
+				if !found || d < absDiff(diff, 2*(a.load-b.load)) {
+					a, b, found = pa, pb, true
+				}
+			}
+		}
+		if found {
+			return []balanceMove{moveBalancePt(src, dst, a, "load"), moveBalancePt(dst, src, b, "load")}
+		}
+	}
+	return nil
+}
+
+// pickCountMove picks the pt of a db having more pts on the source than on the dest, and in the zone of
+// the source than in the zone of the dest, so that the pts of the db stay spread. The pt keeping the zones
+// spread is preferred, and then the pt making the load of the two nodes closest
+func pickCountMove(src, dst *balanceNode) (balancePt, bool) {
+	after := func(p balancePt) uint64 {
+		return absDiff(src.load-p.load, dst.load+p.load)
+	}
+	var best balancePt
+	found, bestZone, bestSpread := false, false, false
+	for _, p := range src.pts {
+		zone := zoneSpread(src, dst, p)
+		spread := src.dbCounts[p.db] > dst.dbCounts[p.db]
+		better := !found || (zone && !bestZone)
+		if zone == bestZone {
+			better = better || (spread && !bestSpread) || (spread == bestSpread && after(p) < after(best))
+		}
+		if better {
+			best, found, bestZone, bestSpread = p, true, zone, spread
+		}
+	}
+	return best, found
+}
+
+func moveBalancePt(src, dst *balanceNode, p balancePt, reason string) balanceMove {
+	src.remove(p)
+	dst.add(p)
+	return balanceMove{db: p.db, pt: p.pt, src: src.id, dst: dst.id, reason: reason}
+}
+
+// checkBalance plans the moves of the pts on the leader periodically, the moves are made by the move events
+func (cm *ClusterManager) checkBalance(stop chan struct{}) {
+	defer cm.balanceWg.Done()
+	conf := cm.store.metaConfig()
+	if conf == nil || !conf.RebalanceEnabled {
+		return
+	}
+
+	ticker := time.NewTicker(time.Duration(conf.RebalanceInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-cm.closing:
+			return
+		case <-ticker.C:
+			cm.balance(conf)
+		}
+	}
+}
+
+func (cm *ClusterManager) balance(conf *config.Meta) {
+	v := cm.store.balanceView()
+	if v.paused {
+		return
+	}
+
+	dryRun := conf.RebalanceDryRun || v.dryRun
+	moves := planBalance(v, conf.RebalanceMaxMoves, conf.RebalanceDiskFactor)
+	log := logger.NewLogger(errno.ModuleHA)
+	for _, m := range moves {
+		fields := []zap.Field{zap.String("db", m.db), zap.Uint32("pt", m.pt), zap.Uint64("src", m.src),
+			zap.Uint64("dst", m.dst), zap.String("reason", m.reason)}
+		if dryRun {
+			log.Info("balancer plans to move pt", fields...)
+			continue
+		}
+		if err := cm.store.migratePt(m.db, m.pt, m.dst); err != nil {
+			log.Warn("balancer failed to move pt", append(fields, zap.Error(err))...)
+			return
+		}
+		log.Info("balancer moves pt", fields...)
+	}
+}
+
+func (s *Store) metaConfig() *config.Meta {
+	return s.config
+}
+
+func (s *Store) balanceView() *balanceView {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return &balanceView{
+		nodes:  s.data.CloneDataNodes(),
+		ptView: s.data.CloneDBPtView(),
+		events: s.data.CloneMigrateEvents(),
+		loads:  s.ptLoads.get,
+		paused: s.data.BalancerPaused,
+		dryRun: s.data.BalancerDryRun,
+	}
+}
+
+func (s *Store) migratePt(db string, pt uint32, nodeID uint64) error {
+	val := &mproto.MigratePtCommand{
+		Database: proto.String(db),
+		PtId:     proto.Uint32(pt),
+		NodeID:   proto.Uint64(nodeID),
+	}
+	t := mproto.Command_MigratePtCommand
+	cmd := &mproto.Command{Type: &t}
+	if err := proto.SetExtension(cmd, mproto.E_MigratePtCommand_Command, val); err != nil {
+		panic(err)
+	}
+	return s.ApplyCmd(cmd)
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func newBalanceView(owners map[uint64][]uint64, loads map[uint32]uint64) *balanceView {
+	v := &balanceView{ptView: map[string]meta.DBPtInfos{}, loads: func(db string, pt uint32) uint64 {
+		return loads[pt]
+	}}
+	var pt uint32
+	for id := uint64(1); id <= uint64(len(owners)); id++ {
+		v.nodes = append(v.nodes, meta.DataNode{NodeInfo: meta.NodeInfo{ID: id, Status: serf.StatusAlive}})
+		for range owners[id] {
+			v.ptView["db0"] = append(v.ptView["db0"], meta.PtInfo{PtId: pt, Status: meta.Online, Owner: meta.PtOwner{NodeID: id}})
+			pt++
+		}
+	}
+	return v
+}
+
+func TestPlanBalance_PtCount(t *testing.T) {
+	// node 3 is new, it owns no pt
+	v := newBalanceView(map[uint64][]uint64{1: {0, 0, 0}, 2: {0, 0, 0}, 3: {}}, nil)
+	moves := planBalance(v, 10, 0.2)
+	require.Equal(t, 2, len(moves))
+	srcs := map[uint64]bool{}
+	for _, m := range moves {
+		require.Equal(t, uint64(3), m.dst)
+		require.Equal(t, "pt count", m.reason)
+		srcs[m.src] = true
+	}
+	require.Equal(t, map[uint64]bool{1: true, 2: true}, srcs)
+
+	// the moves are rate limited, the pending moves count against the limit
+	require.Equal(t, 1, len(planBalance(v, 1, 0.2)))
+	pt := v.ptView["db0"][0]
+	e := meta.NewMigrateEventInfo((&meta.DbPtInfo{Db: "db0", Pti: &pt}).String(), meta.MoveEventType, &meta.DbPtInfo{Db: "db0", Pti: &pt}, 3)
+	v.events = map[string]*meta.MigrateEventInfo{e.GetEventId(): e}
+	require.Empty(t, planBalance(v, 1, 0.2))
+	require.Equal(t, 1, len(planBalance(v, 2, 0.2)))
+
+	// a failed move does not count, but its pt is not moved again until the failed move expires
+	e.SetCurrentState(int(meta.MoveFailed))
+	for _, m := range planBalance(v, 10, 0.2) {
+		require.NotEqual(t, pt.PtId, m.pt)
+	}
+
+	// nothing is moved while the pts are being assigned
+	v.events = map[string]*meta.MigrateEventInfo{e.GetEventId(): meta.NewMigrateEventInfo(e.GetEventId(), int(Assign), e.GetPtInfo(), 3)}
+	require.Empty(t, planBalance(v, 10, 0.2))
+	v.events = nil
+
+	// nothing is moved while a node is not alive
+	v.nodes[1].Status = serf.StatusFailed
+	require.Empty(t, planBalance(v, 10, 0.2))
+	v.nodes[1].Status = serf.StatusAlive
+
+	// the full and the decommissioning nodes are not targets
+	v.nodes[2].DiskStatus = meta.DiskHigh
+	require.Empty(t, planBalance(v, 10, 0.2))
+	v.nodes[2].DiskStatus = meta.DiskNormal
+	v.nodes[2].Decommissioning = true
+	require.Empty(t, planBalance(v, 10, 0.2))
+}
+
+func TestPlanBalance_Load(t *testing.T) {
+	// the pt count is even, the larger pt of node 1 is swapped for a smaller pt of node 2
+	v := newBalanceView(map[uint64][]uint64{1: {0, 0}, 2: {0, 0}}, map[uint32]uint64{0: 60, 1: 40, 2: 10, 3: 10})
+	moves := planBalance(v, 2, 0.2)
+	require.Equal(t, 2, len(moves))
+	require.Equal(t, balanceMove{db: "db0", pt: 0, src: 1, dst: 2, reason: "load"}, moves[0])
+	require.Equal(t, uint64(2), moves[1].src)
+	require.Equal(t, uint64(1), moves[1].dst)
+	require.Empty(t, planBalance(v, 1, 0.2))
+
+	// the difference is tolerated
+	require.Empty(t, planBalance(v, 2, 2))
+
+	// node 1 owns one more pt, a pt is moved without making node 2 the most loaded
+	v = newBalanceView(map[uint64][]uint64{1: {0, 0, 0}, 2: {0, 0}}, map[uint32]uint64{0: 60, 1: 40, 2: 30, 3: 10, 4: 10})
+	moves = planBalance(v, 2, 0.2)
+	require.Equal(t, []balanceMove{{db: "db0", pt: 0, src: 1, dst: 2, reason: "load"}}, moves)
+}
+
+func TestPlanBalance_Zone(t *testing.T) {
+	// node 1 is in zone a, nodes 2 and 3 are in zone b
+	v := &balanceView{loads: func(db string, pt uint32) uint64 { return 0 }}
+	for id, zone := range []string{"a", "b", "b"} {
+		v.nodes = append(v.nodes, meta.DataNode{NodeInfo: meta.NodeInfo{ID: uint64(id + 1), Status: serf.StatusAlive}, Zone: zone})
+	}
+	v.ptView = map[string]meta.DBPtInfos{}
+	for db, owners := range map[string][]uint64{"db0": {1, 3, 3}, "db1": {1, 2, 1}} {
+		for pt, id := range owners {
+			v.ptView[db] = append(v.ptView[db], meta.PtInfo{PtId: uint32(pt), Status: meta.Online, Owner: meta.PtOwner{NodeID: id}})
+		}
+	}
+	// moving the pt of db0 to node 2 would leave zone a with one pt of db0 against three in zone b
+	moves := planBalance(v, 1, 0.2)
+	require.Equal(t, 1, len(moves))
+	require.Equal(t, "db1", moves[0].db)
+	require.Equal(t, uint64(1), moves[0].src)
+	require.Equal(t, uint64(2), moves[0].dst)
+
+	// node 1 owns one more pt, but moving a pt to zone b would put more pts of db0 in zone b
+	v = newBalanceView(map[uint64][]uint64{1: {0, 0}, 2: {0}, 3: {0}}, map[uint32]uint64{0: 60, 1: 40, 2: 10, 3: 10})
+	require.Equal(t, 1, len(planBalance(v, 1, 0.2)))
+	v.nodes[0].Zone, v.nodes[1].Zone, v.nodes[2].Zone = "a", "b", "b"
+	require.Empty(t, planBalance(v, 1, 0.2))
+}
+
+type mockBalanceStore struct {
+	storeInterface
+	conf  *config.Meta
+	view  *balanceView
+	moved []uint32
+}
+
+func (s *mockBalanceStore) metaConfig() *config.Meta {
+	return s.conf
+}
+
+func (s *mockBalanceStore) balanceView() *balanceView {
+	return s.view
+}
+
+func (s *mockBalanceStore) migratePt(db string, pt uint32, nodeID uint64) error {
+	s.moved = append(s.moved, pt)
+	return nil
+}
+
+func TestClusterManager_Balance(t *testing.T) {
+	conf := config.NewMeta()
+	store := &mockBalanceStore{conf: conf}
+	cm := &ClusterManager{store: store}
+
+	store.view = newBalanceView(map[uint64][]uint64{1: {0, 0, 0}, 2: {}}, nil)
+	store.view.dryRun = true
+	cm.balance(conf)
+	require.Empty(t, store.moved)
+
+	store.view.dryRun = false
+	store.view.paused = true
+	cm.balance(conf)
+	require.Empty(t, store.moved)
+
+	store.view.paused = false
+	cm.balance(conf)
+	require.Equal(t, 1, len(store.moved))
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/raft"
+	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/lib/logger"
 	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
@@ -32,6 +33,9 @@ import (
 type storeInterface interface {
 	updateNodeStatus(id uint64, status int32, lTime uint64, gossipAddr string) error
 	dataNodes() meta.DataNodeInfos
+	metaConfig() *config.Meta
+	balanceView() *balanceView
+	migratePt(db string, pt uint32, nodeID uint64) error
 }
 
 type ClusterManager struct {
@@ -47,6 +51,7 @@ type ClusterManager struct {
 	stop         chan struct{}                // used for meta leader step down and do not process any event
 	eventWg      sync.WaitGroup
 	memberIds    map[uint64]struct{} // alive members
+	balanceWg    sync.WaitGroup
 }
 
 func NewClusterManager(store storeInterface) *ClusterManager {
@@ -98,6 +103,8 @@ func (cm *ClusterManager) Start() {
 	cm.wg.Add(1)
 	go cm.checkEvents()
 	cm.stop = make(chan struct{})
+	cm.balanceWg.Add(1)
+	go cm.checkBalance(cm.stop)
 }
 
 func (cm *ClusterManager) Stop() {
@@ -141,6 +148,7 @@ func (cm *ClusterManager) Close() {
 	}
 	cm.wg.Wait()
 	cm.eventWg.Wait()
+	cm.balanceWg.Wait()
 	cm.retryEventCh = nil
 	cm.eventCh = nil
 }
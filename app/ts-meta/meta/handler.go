@@ -44,6 +44,15 @@ type IStore interface {
 	showDebugInfo(witch string) ([]byte, error)
 	GetData() *meta.Data //get the Data in the store
 	IsLeader() bool
+
+	// used by the admin api
+	cloneData() *meta.Data
+	user(name string) *meta.UserInfo
+	metaNodeHost(tcpHost string) string
+	raftStatus() (*raftStatus, error)
+	transferLeadership(id uint64) error
+	removeMetaNode(id uint64, force bool) error
+	importSchema(schema *meta.ClusterSchema) ([]meta.SkippedUser, error)
 }
 
 var httpScheme = map[bool]string{
@@ -89,6 +98,11 @@ func (h *httpHandler) WrapHandler(hf http.HandlerFunc) http.Handler {
 
 // ServeHTTP responds to HTTP request to the handler.
 func (h *httpHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, adminApiPrefix) {
+		h.WrapHandler(h.serveAdmin).ServeHTTP(w, r)
+		return
+	}
+
 	switch r.Method {
 	case "GET":
 		switch r.URL.Path {
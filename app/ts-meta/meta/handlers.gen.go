@@ -44,6 +44,8 @@ func New(typ uint8) RPCHandler {
 		return &Report{}
 	case message.GetShardInfoRequestMessage:
 		return &GetShardInfo{}
+	case message.StatsRequestMessage:
+		return &Stats{}
 	default:
 		return nil
 	}
@@ -200,3 +202,22 @@ func (h *GetShardInfo) SetRequestMsg(data transport.Codec) error {
 func (h *GetShardInfo) Instance() RPCHandler {
 	return &GetShardInfo{}
 }
+
+type Stats struct {
+	BaseHandler
+
+	req *message.StatsRequest
+}
+
+func (h *Stats) SetRequestMsg(data transport.Codec) error {
+	msg, ok := data.(*message.StatsRequest)
+	if !ok {
+		return executor.NewInvalidTypeError("*message.StatsRequest", data)
+	}
+	h.req = msg
+	return nil
+}
+
+func (h *Stats) Instance() RPCHandler {
+	return &Stats{}
+}
@@ -18,6 +18,7 @@ package meta
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -25,6 +26,8 @@ import (
 	"github.com/openGemini/openGemini/app/ts-meta/meta/message"
 	"github.com/openGemini/openGemini/engine/executor/spdy/transport"
 	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/statisticsPusher"
+	"github.com/openGemini/openGemini/lib/syscontrol"
 	"github.com/openGemini/openGemini/open_src/influx/meta"
 	"go.uber.org/zap"
 )
@@ -54,7 +57,7 @@ func (h *CreateNode) Process() (transport.Codec, error) {
 
 	httpAddr := h.req.WriteHost
 	tcpAddr := h.req.QueryHost
-	b, err := h.store.createDataNode(httpAddr, tcpAddr)
+	b, err := h.store.createDataNode(httpAddr, tcpAddr, h.req.Zone, h.req.Rack)
 	if err != nil {
 		rsp.Err = err.Error()
 		return rsp, nil
@@ -215,3 +218,21 @@ func (h *GetShardInfo) Process() (transport.Codec, error) {
 	rsp.Data = b
 	return rsp, nil
 }
+
+func (h *Stats) Process() (transport.Codec, error) {
+	rsp := &message.StatsResponse{}
+
+	var err error
+	switch h.req.Mod {
+	case syscontrol.Stats:
+		rsp.Data, err = statisticsPusher.CollectStatistics(nil)
+	case syscontrol.Diagnostics:
+		rsp.Data = statisticsPusher.CollectDiagnostics(nil)
+	default:
+		err = fmt.Errorf("unknown stats mod %s", h.req.Mod)
+	}
+	if err != nil {
+		rsp.Err = err.Error()
+	}
+	return rsp, nil
+}
@@ -25,6 +25,12 @@ func (o *CreateNodeRequest) Marshal(buf []byte) ([]byte, error) {
 	var err error
 	buf = codec.AppendString(buf, o.WriteHost)
 	buf = codec.AppendString(buf, o.QueryHost)
+	if o.Supports(codec.FeatureNodeTopology) {
+		buf = codec.AppendString(buf, o.Zone)
+	}
+	if o.Supports(codec.FeatureNodeTopology) {
+		buf = codec.AppendString(buf, o.Rack)
+	}
 
 	return buf, err
 }
@@ -37,6 +43,12 @@ func (o *CreateNodeRequest) Unmarshal(buf []byte) error {
 	dec := codec.NewBinaryDecoder(buf)
 	o.WriteHost = dec.String()
 	o.QueryHost = dec.String()
+	if dec.RemainSize() > 0 {
+		o.Zone = dec.String()
+	}
+	if dec.RemainSize() > 0 {
+		o.Rack = dec.String()
+	}
 
 	return err
 }
@@ -45,6 +57,12 @@ func (o *CreateNodeRequest) Size() int {
 	size := 0
 	size += codec.SizeOfString(o.WriteHost)
 	size += codec.SizeOfString(o.QueryHost)
+	if o.Supports(codec.FeatureNodeTopology) {
+		size += codec.SizeOfString(o.Zone)
+	}
+	if o.Supports(codec.FeatureNodeTopology) {
+		size += codec.SizeOfString(o.Rack)
+	}
 
 	return size
 }
@@ -431,6 +449,67 @@ func (o *SnapshotResponse) Instance() transport.Codec {
 	return &SnapshotResponse{}
 }
 
+func (o *StatsRequest) Marshal(buf []byte) ([]byte, error) {
+	var err error
+	buf = codec.AppendString(buf, o.Mod)
+
+	return buf, err
+}
+
+func (o *StatsRequest) Unmarshal(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	dec := codec.NewBinaryDecoder(buf)
+	o.Mod = dec.String()
+
+	return err
+}
+
+func (o *StatsRequest) Size() int {
+	size := 0
+	size += codec.SizeOfString(o.Mod)
+
+	return size
+}
+
+func (o *StatsRequest) Instance() transport.Codec {
+	return &StatsRequest{}
+}
+
+func (o *StatsResponse) Marshal(buf []byte) ([]byte, error) {
+	var err error
+	buf = codec.AppendBytes(buf, o.Data)
+	buf = codec.AppendString(buf, o.Err)
+
+	return buf, err
+}
+
+func (o *StatsResponse) Unmarshal(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	dec := codec.NewBinaryDecoder(buf)
+	o.Data = dec.Bytes()
+	o.Err = dec.String()
+
+	return err
+}
+
+func (o *StatsResponse) Size() int {
+	size := 0
+	size += codec.SizeOfByteSlice(o.Data)
+	size += codec.SizeOfString(o.Err)
+
+	return size
+}
+
+func (o *StatsResponse) Instance() transport.Codec {
+	return &StatsResponse{}
+}
+
 func (o *UpdateRequest) Marshal(buf []byte) ([]byte, error) {
 	var err error
 	buf = codec.AppendBytes(buf, o.Body)
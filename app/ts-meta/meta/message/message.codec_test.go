@@ -46,6 +46,8 @@ func __TestMetaMessage(t *testing.T) {
 	createNodeRequest := message.CreateNodeRequest{
 		WriteHost: "127.0.0.1",
 		QueryHost: "127.0.0.1",
+		Zone:      "zone-a",
+		Rack:      "rack-1",
 	}
 	createNodeResponse := message.CreateNodeResponse{
 		Data: []byte{1, 2, 3},
@@ -109,6 +111,15 @@ func __TestMetaMessage(t *testing.T) {
 		Err:  "err",
 	}
 	objs = append(objs, getShardInfoRequest, getShardInfoResponse)
+
+	statsRequest := message.StatsRequest{
+		Mod: "stats",
+	}
+	statsResponse := message.StatsResponse{
+		Data: []byte{1, 2, 3},
+		Err:  "err",
+	}
+	objs = append(objs, statsRequest, statsResponse)
 	g := gen.NewCodecGen("message")
 	for _, obj := range objs {
 		g.Gen(obj)
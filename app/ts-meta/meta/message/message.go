@@ -64,6 +64,14 @@ func (m *MetaMessage) Unmarshal(buf []byte) error {
 	return m.data.Unmarshal(buf[1:])
 }
 
+// SetWireVersion passes the wire version negotiated with the peer on to
+// the wrapped message, so optional fields are only sent when understood.
+func (m *MetaMessage) SetWireVersion(v uint16) {
+	if vc, ok := m.data.(transport.VersionedCodec); ok {
+		vc.SetWireVersion(v)
+	}
+}
+
 func (m *MetaMessage) Data() transport.Codec {
 	return m.data
 }
@@ -92,8 +100,12 @@ type PeersResponse struct {
 }
 
 type CreateNodeRequest struct {
+	codec.Versioned
+
 	WriteHost string
 	QueryHost string
+	Zone      string `codec:"feature=FeatureNodeTopology"`
+	Rack      string `codec:"feature=FeatureNodeTopology"`
 }
 
 type CreateNodeResponse struct {
@@ -159,3 +171,14 @@ type GetShardInfoResponse struct {
 	Data []byte
 	Err  string
 }
+
+// StatsRequest asks a meta node for its statistics or its diagnostics, Mod is stats or diagnostics
+type StatsRequest struct {
+	Mod string
+}
+
+// StatsResponse carries the statistics or the diagnostics of the meta node in line protocol
+type StatsResponse struct {
+	Data []byte
+	Err  string
+}
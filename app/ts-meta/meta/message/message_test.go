@@ -0,0 +1,155 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package message_test
+
+import (
+	"testing"
+
+	"github.com/openGemini/openGemini/app/ts-meta/meta/message"
+	"github.com/openGemini/openGemini/lib/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNodeRequest_WireVersion(t *testing.T) {
+	req := &message.CreateNodeRequest{
+		WriteHost: "127.0.0.1:8400",
+		QueryHost: "127.0.0.1:8401",
+		Zone:      "zone-a",
+		Rack:      "rack-1",
+	}
+
+	// sent to a node which understands the topology fields
+	req.SetWireVersion(uint16(codec.Version1))
+	buf, err := req.Marshal(nil)
+	require.NoError(t, err)
+	assert.Equal(t, req.Size(), len(buf))
+
+	got := &message.CreateNodeRequest{}
+	require.NoError(t, got.Unmarshal(buf))
+	assert.Equal(t, "zone-a", got.Zone)
+	assert.Equal(t, "rack-1", got.Rack)
+
+	// sent to a node which is not upgraded yet
+	req.SetWireVersion(uint16(codec.VersionLegacy))
+	legacy, err := req.Marshal(nil)
+	require.NoError(t, err)
+	assert.Equal(t, req.Size(), len(legacy))
+	assert.Less(t, len(legacy), len(buf))
+
+	// received from a node which is not upgraded yet
+	got = &message.CreateNodeRequest{}
+	require.NoError(t, got.Unmarshal(legacy))
+	assert.Equal(t, "127.0.0.1:8400", got.WriteHost)
+	assert.Equal(t, "127.0.0.1:8401", got.QueryHost)
+	assert.Equal(t, "", got.Zone)
+	assert.Equal(t, "", got.Rack)
+}
+
+// baselineCreateNodeRequest is CreateNodeRequest with the codec generated before the topology fields were added,
+// which is the format of the nodes without version negotiation.
+type baselineCreateNodeRequest struct {
+	WriteHost string
+	QueryHost string
+}
+
+func (o *baselineCreateNodeRequest) Marshal(buf []byte) ([]byte, error) {
+	var err error
+	buf = codec.AppendString(buf, o.WriteHost)
+	buf = codec.AppendString(buf, o.QueryHost)
+
+	return buf, err
+}
+
+func (o *baselineCreateNodeRequest) Unmarshal(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	dec := codec.NewBinaryDecoder(buf)
+	o.WriteHost = dec.String()
+	o.QueryHost = dec.String()
+
+	return err
+}
+
+func (o *baselineCreateNodeRequest) Size() int {
+	size := 0
+	size += codec.SizeOfString(o.WriteHost)
+	size += codec.SizeOfString(o.QueryHost)
+
+	return size
+}
+
+func TestCreateNodeRequest_MixedVersion(t *testing.T) {
+	req := &message.CreateNodeRequest{
+		WriteHost: "127.0.0.1:8400",
+		QueryHost: "127.0.0.1:8401",
+		Zone:      "zone-a",
+		Rack:      "rack-1",
+	}
+	baseline := &baselineCreateNodeRequest{WriteHost: req.WriteHost, QueryHost: req.QueryHost}
+	baselineBuf, err := baseline.Marshal(nil)
+	require.NoError(t, err)
+
+	// an upgraded store node sends the request to a meta node which is not upgraded yet
+	msg := message.NewMetaMessage(message.CreateNodeRequestMessage, req)
+	msg.SetWireVersion(uint16(codec.VersionLegacy))
+	buf, err := msg.Marshal(nil)
+	require.NoError(t, err)
+	require.Equal(t, message.CreateNodeRequestMessage, buf[0])
+	assert.Equal(t, baselineBuf, buf[1:])
+	got := &baselineCreateNodeRequest{}
+	require.NoError(t, got.Unmarshal(buf[1:]))
+	assert.Equal(t, baseline, got)
+	assert.Equal(t, got.Size(), msg.Size())
+
+	// a store node which is not upgraded yet sends the request to an upgraded meta node
+	buf, err = baseline.Marshal([]byte{message.CreateNodeRequestMessage})
+	require.NoError(t, err)
+	other := &message.MetaMessage{}
+	require.NoError(t, other.Unmarshal(buf))
+	newReq, ok := other.Data().(*message.CreateNodeRequest)
+	require.True(t, ok)
+	assert.Equal(t, req.WriteHost, newReq.WriteHost)
+	assert.Equal(t, req.QueryHost, newReq.QueryHost)
+	assert.Equal(t, "", newReq.Zone)
+	assert.Equal(t, "", newReq.Rack)
+
+	// the topology fields follow the baseline fields, so the baseline decoder still reads the hosts of version 1
+	req.SetWireVersion(uint16(codec.Version1))
+	buf, err = req.Marshal(nil)
+	require.NoError(t, err)
+	got = &baselineCreateNodeRequest{}
+	require.NoError(t, got.Unmarshal(buf))
+	assert.Equal(t, baseline, got)
+}
+
+func TestMetaMessage_SetWireVersion(t *testing.T) {
+	req := &message.CreateNodeRequest{WriteHost: "w", QueryHost: "q", Zone: "z", Rack: "r"}
+	msg := message.NewMetaMessage(message.CreateNodeRequestMessage, req)
+	size := msg.Size()
+
+	msg.SetWireVersion(uint16(codec.VersionLegacy))
+	assert.Less(t, msg.Size(), size)
+
+	// messages without optional fields are not affected
+	ping := message.NewMetaMessage(message.PingRequestMessage, &message.PingRequest{All: 1})
+	size = ping.Size()
+	ping.SetWireVersion(uint16(codec.VersionLegacy))
+	assert.Equal(t, size, ping.Size())
+}
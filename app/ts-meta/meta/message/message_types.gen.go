@@ -50,6 +50,9 @@ const (
 
 	GetShardInfoRequestMessage
 	GetShardInfoResponseMessage
+
+	StatsRequestMessage
+	StatsResponseMessage
 )
 
 func NewMessage(typ uint8) transport.Codec {
@@ -86,6 +89,10 @@ func NewMessage(typ uint8) transport.Codec {
 		return &GetShardInfoRequest{}
 	case GetShardInfoResponseMessage:
 		return &GetShardInfoResponse{}
+	case StatsRequestMessage:
+		return &StatsRequest{}
+	case StatsResponseMessage:
+		return &StatsResponse{}
 	default:
 		return nil
 	}
@@ -109,6 +116,8 @@ func GetResponseMessageType(typ uint8) uint8 {
 		return ReportResponseMessage
 	case GetShardInfoRequestMessage:
 		return GetShardInfoResponseMessage
+	case StatsRequestMessage:
+		return StatsResponseMessage
 	default:
 		return UnknownMessage
 	}
@@ -0,0 +1,397 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	mproto "github.com/openGemini/openGemini/open_src/influx/meta/proto"
+	"go.uber.org/zap"
+)
+
+// the bytes copied by a move are persisted at most once per interval, SHOW EVENTS reads them
+const moveProgressInterval = 10 * time.Second
+
+// a failed move is shown by SHOW EVENTS and keeps the balancer from moving its pt again until it expires
+var moveFailedExpiry = time.Hour
+
+// moveDriver drives the events moving pts between data nodes on the leader. The state of an event is
+// persisted before the next step is taken, so a new leader continues the moves of the old one.
+type moveDriver struct {
+	s        *Store
+	lastSave map[string]time.Time
+	// when the failed moves were first seen by this leader
+	failedAt map[string]time.Time
+	// the last error of the decommissioning nodes whose pts can not be moved
+	blocked map[uint64]string
+}
+
+func (s *Store) checkMoveEvents() {
+	defer s.deleteWg.Done()
+	d := &moveDriver{s: s, lastSave: make(map[string]time.Time), failedAt: make(map[string]time.Time),
+		blocked: make(map[uint64]string)}
+	for {
+		select {
+		case <-s.closing:
+			return
+		case <-s.stepDown:
+			return
+		default:
+		}
+
+		for _, e := range d.events() {
+			d.step(e)
+		}
+		d.expireFailedEvents()
+		d.removeDecommissionedNodes()
+		time.Sleep(checkInterval)
+	}
+}
+
+func (d *moveDriver) events() []*meta.MigrateEventInfo {
+	d.s.mu.RLock()
+	defer d.s.mu.RUnlock()
+	var events []*meta.MigrateEventInfo
+	for _, e := range d.s.data.MigrateEvents {
+		if e.GetEventType() == meta.MoveEventType && meta.MoveState(e.GetCurrentState()) != meta.MoveFailed {
+			events = append(events, e.Clone())
+		}
+	}
+	return events
+}
+
+// expireFailedEvents removes the failed moves which failed longer than moveFailedExpiry ago
+func (d *moveDriver) expireFailedEvents() {
+	d.s.mu.RLock()
+	failed := make(map[string]uint64)
+	for id, e := range d.s.data.MigrateEvents {
+		if e.GetEventType() == meta.MoveEventType && meta.MoveState(e.GetCurrentState()) == meta.MoveFailed {
+			failed[id] = e.GetOpId()
+		}
+	}
+	d.s.mu.RUnlock()
+
+	for id := range d.failedAt {
+		if _, ok := failed[id]; !ok {
+			delete(d.failedAt, id)
+		}
+	}
+	now := time.Now()
+	for id, opId := range failed {
+		at, ok := d.failedAt[id]
+		if !ok {
+			d.failedAt[id] = now
+			continue
+		}
+		if now.Sub(at) < moveFailedExpiry {
+			continue
+		}
+		// a new move of the pt may replace the failed one meanwhile, it is kept
+		if err := d.s.removeEventOp(id, opId); err != nil {
+			d.s.Logger.Warn("remove the failed move failed", zap.String("event", id), zap.Error(err))
+			continue
+		}
+		delete(d.failedAt, id)
+		d.s.Logger.Info("failed move expired", zap.String("event", id))
+	}
+}
+
+// step takes the next step of the move, the files are copied while the source still serves the pt,
+// then the pt is offloaded from the source, the changed files are copied and the owner is switched
+func (d *moveDriver) step(e *meta.MigrateEventInfo) {
+	state := meta.MoveState(e.GetCurrentState())
+	var next meta.MoveState
+	var err error
+	switch state {
+	case meta.MoveInit:
+		_, err = d.send(e.GetDst(), e, netstorage.MigrateFetch)
+		next = meta.MovePreCopy
+	case meta.MovePreCopy, meta.MoveFinalSync:
+		var done bool
+		done, err = d.pollFetch(e, state == meta.MoveFinalSync)
+		if !done && err == nil {
+			return
+		}
+		next = meta.MoveOffload
+		if state == meta.MoveFinalSync {
+			next = meta.MoveCutover
+		}
+	case meta.MoveOffload:
+		err = d.updatePt(e, e.GetSrc(), meta.Offline)
+		if err == nil {
+			_, err = d.send(e.GetSrc(), e, netstorage.MigrateOffload)
+		}
+		if err == nil {
+			_, err = d.send(e.GetDst(), e, netstorage.MigrateFetchFinal)
+		}
+		next = meta.MoveFinalSync
+	case meta.MoveCutover:
+		// the pt is served by the dest node from now on, a failure is retried
+		if err = d.updatePt(e, e.GetDst(), meta.Online); err != nil {
+			d.s.Logger.Warn("switch the owner of the moved pt failed", zap.String("event", e.GetEventId()), zap.Error(err))
+			return
+		}
+		next = meta.MoveClean
+	case meta.MoveClean:
+		if d.nodeExists(e.GetSrc()) {
+			if _, err = d.send(e.GetSrc(), e, netstorage.MigrateDrop); err != nil {
+				d.s.Logger.Warn("drop the moved pt failed", zap.String("event", e.GetEventId()), zap.Error(err))
+				return
+			}
+		}
+		if err = d.s.removeEvent(e.GetEventId()); err == nil {
+			delete(d.lastSave, e.GetEventId())
+			d.s.Logger.Info("pt moved", zap.String("event", e.GetEventId()), zap.Uint64("src", e.GetSrc()),
+				zap.Uint64("dst", e.GetDst()), zap.Int64("bytes", e.GetBytes()))
+		}
+		return
+	case meta.MoveRollback:
+		if err = d.rollback(e); err != nil {
+			d.s.Logger.Warn("rollback the move failed", zap.String("event", e.GetEventId()), zap.Error(err))
+			return
+		}
+		d.transit(e, meta.MoveFailed)
+		return
+	default:
+		return
+	}
+
+	if err != nil {
+		d.s.Logger.Error("move pt failed", zap.String("event", e.GetEventId()),
+			zap.Stringer("state", state), zap.Error(err))
+		e.SetErr(err.Error())
+		next = meta.MoveRollback
+	}
+	d.transit(e, next)
+}
+
+// pollFetch returns whether the dest node has fetched the files of the pt, the fetching is started
+// again if the dest node has lost it, for example when the node is restarted
+func (d *moveDriver) pollFetch(e *meta.MigrateEventInfo, final bool) (bool, error) {
+	resp, err := d.send(e.GetDst(), e, netstorage.MigrateStatus)
+	if err != nil {
+		return false, err
+	}
+
+	e.SetBytes(resp.GetBytes())
+	switch resp.GetMigrateState() {
+	case netstorage.MigrateDone:
+		return true, nil
+	case netstorage.MigrateIdle:
+		phase := netstorage.MigrateFetch
+		if final {
+			phase = netstorage.MigrateFetchFinal
+		}
+		_, err = d.send(e.GetDst(), e, phase)
+		return false, err
+	case netstorage.MigrateFailed:
+		return false, errors.New(resp.GetErr())
+	}
+
+	if time.Since(d.lastSave[e.GetEventId()]) >= moveProgressInterval {
+		d.save(e)
+	}
+	return false, nil
+}
+
+// rollback drops the copied files on the dest node and serves the pt on the source node again
+func (d *moveDriver) rollback(e *meta.MigrateEventInfo) error {
+	if d.nodeExists(e.GetDst()) {
+		if _, err := d.send(e.GetDst(), e, netstorage.MigrateDrop); err != nil {
+			return err
+		}
+	}
+
+	pt := d.currentPt(e)
+	if pt == nil || (pt.Owner.NodeID == e.GetSrc() && pt.Status == meta.Online) {
+		return nil
+	}
+	if _, err := d.send(e.GetSrc(), e, netstorage.MigrateLoad); err != nil {
+		return err
+	}
+	return d.updatePt(e, e.GetSrc(), meta.Online)
+}
+
+func (d *moveDriver) transit(e *meta.MigrateEventInfo, next meta.MoveState) {
+	e.SetPreState(e.GetCurrentState())
+	e.SetCurrentState(int(next))
+	d.save(e)
+}
+
+func (d *moveDriver) save(e *meta.MigrateEventInfo) {
+	if err := d.s.updateMoveEvent(e); err != nil {
+		d.s.Logger.Warn("update move event failed", zap.String("event", e.GetEventId()), zap.Error(err))
+		return
+	}
+	d.lastSave[e.GetEventId()] = time.Now()
+}
+
+func (d *moveDriver) send(nodeID uint64, e *meta.MigrateEventInfo, phase netstorage.MigratePtPhase) (*netstorage.MigratePtResponse, error) {
+	d.s.mu.RLock()
+	node := d.s.data.DataNode(nodeID)
+	d.s.mu.RUnlock()
+	if node == nil {
+		return nil, fmt.Errorf("data node %d not found", nodeID)
+	}
+
+	pt := e.GetPtInfo()
+	req := &netstorage.MigratePtRequest{}
+	req.Db = proto.String(pt.Db)
+	req.Pt = proto.Uint32(pt.Pti.PtId)
+	req.Phase = proto.Int32(int32(phase))
+	req.Src = proto.Uint64(e.GetSrc())
+	return d.s.NetStore.MigratePt(node, req)
+}
+
+func (d *moveDriver) nodeExists(nodeID uint64) bool {
+	d.s.mu.RLock()
+	defer d.s.mu.RUnlock()
+	return d.s.data.DataNode(nodeID) != nil
+}
+
+func (d *moveDriver) currentPt(e *meta.MigrateEventInfo) *meta.PtInfo {
+	pt := e.GetPtInfo()
+	d.s.mu.RLock()
+	defer d.s.mu.RUnlock()
+	pts := d.s.data.PtView[pt.Db]
+	if int(pt.Pti.PtId) >= len(pts) {
+		return nil
+	}
+	cur := pts[pt.Pti.PtId]
+	return &cur
+}
+
+func (d *moveDriver) updatePt(e *meta.MigrateEventInfo, owner uint64, status meta.PtStatus) error {
+	pt := d.currentPt(e)
+	if pt == nil {
+		return fmt.Errorf("pt of event %s not found", e.GetEventId())
+	}
+	if pt.Owner.NodeID == owner && pt.Status == status {
+		return nil
+	}
+	return d.s.updatePtInfo(e.GetPtInfo().Db, pt, owner, status)
+}
+
+// removeDecommissionedNodes deletes the decommissioning nodes all pts of which are moved. The pts
+// left without a move, after a failed move expires, are moved again, and a node whose pts can not
+// be moved is reported as blocked.
+func (d *moveDriver) removeDecommissionedNodes() {
+	var ids, pending []uint64
+	d.s.mu.RLock()
+	for i := range d.s.data.DataNodes {
+		n := &d.s.data.DataNodes[i]
+		if !n.Decommissioning {
+			continue
+		}
+		pts := d.s.data.GetPtsByNodeId(n.ID)
+		if len(pts) == 0 {
+			ids = append(ids, n.ID)
+		} else if !d.s.data.HasMoveEventsOf(n.ID) {
+			pending = append(pending, n.ID)
+		}
+	}
+	d.s.mu.RUnlock()
+
+	for id := range d.blocked {
+		if !containsNode(pending, id) {
+			delete(d.blocked, id)
+		}
+	}
+	for _, id := range pending {
+		err := d.s.decommissionDataNode(id)
+		if err == nil {
+			delete(d.blocked, id)
+			d.s.Logger.Info("move the left pts of the decommissioned data node", zap.Uint64("node", id))
+			continue
+		}
+		if d.blocked[id] != err.Error() {
+			d.blocked[id] = err.Error()
+			d.s.Logger.Warn("decommission of data node is blocked", zap.Uint64("node", id), zap.Error(err))
+		}
+	}
+
+	for _, id := range ids {
+		if err := d.s.deleteDataNode(id); err != nil {
+			d.s.Logger.Warn("delete decommissioned data node failed", zap.Uint64("node", id), zap.Error(err))
+			continue
+		}
+		d.s.Logger.Info("decommissioned data node deleted", zap.Uint64("node", id))
+	}
+}
+
+func containsNode(ids []uint64, id uint64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) updateMoveEvent(e *meta.MigrateEventInfo) error {
+	val := &mproto.UpdateEventCommand{
+		EventInfo: e.Marshal(),
+	}
+	t := mproto.Command_UpdateEventCommand
+	cmd := &mproto.Command{Type: &t}
+	if err := proto.SetExtension(cmd, mproto.E_UpdateEventCommand_Command, val); err != nil {
+		panic(err)
+	}
+	return s.ApplyCmd(cmd)
+}
+
+func (s *Store) removeEventOp(eventId string, opId uint64) error {
+	val := &mproto.RemoveEventCommand{
+		EventId: proto.String(eventId),
+		OpId:    proto.Uint64(opId),
+	}
+	t := mproto.Command_RemoveEventCommand
+	cmd := &mproto.Command{Type: &t}
+	if err := proto.SetExtension(cmd, mproto.E_RemoveEventCommand_Command, val); err != nil {
+		panic(err)
+	}
+	return s.ApplyCmd(cmd)
+}
+
+func (s *Store) decommissionDataNode(id uint64) error {
+	val := &mproto.DecommissionDataNodeCommand{
+		ID: proto.Uint64(id),
+	}
+	t := mproto.Command_DecommissionDataNodeCommand
+	cmd := &mproto.Command{Type: &t}
+	if err := proto.SetExtension(cmd, mproto.E_DecommissionDataNodeCommand_Command, val); err != nil {
+		panic(err)
+	}
+	return s.ApplyCmd(cmd)
+}
+
+func (s *Store) deleteDataNode(id uint64) error {
+	val := &mproto.DeleteDataNodeCommand{
+		ID: proto.Uint64(id),
+	}
+	t := mproto.Command_DeleteDataNodeCommand
+	cmd := &mproto.Command{Type: &t}
+	if err := proto.SetExtension(cmd, mproto.E_DeleteDataNodeCommand_Command, val); err != nil {
+		panic(err)
+	}
+	return s.ApplyCmd(cmd)
+}
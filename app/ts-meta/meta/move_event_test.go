@@ -0,0 +1,222 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	proto2 "github.com/openGemini/openGemini/open_src/influx/meta/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockMoveNetStore struct {
+	mu       sync.Mutex
+	phases   []string
+	fetchErr string
+}
+
+func (s *mockMoveNetStore) GetShardSplitPoints(node *meta.DataNode, database string, pt uint32,
+	shardId uint64, idxes []int64) ([]string, error) {
+	return nil, nil
+}
+
+func (s *mockMoveNetStore) DeleteDatabase(node *meta.DataNode, database string, pt uint32) error {
+	return nil
+}
+
+func (s *mockMoveNetStore) DeleteRetentionPolicy(node *meta.DataNode, db string, rp string, pt uint32) error {
+	return nil
+}
+
+func (s *mockMoveNetStore) DeleteMeasurement(node *meta.DataNode, db string, rp string, name string, shardIds []uint64) error {
+	return nil
+}
+
+func (s *mockMoveNetStore) MigratePt(node *meta.DataNode, req *netstorage.MigratePtRequest) (*netstorage.MigratePtResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.phases = append(s.phases, fmt.Sprintf("%d:%d", node.ID, req.GetPhase()))
+	resp := &netstorage.MigratePtResponse{}
+	resp.State = proto.Int32(int32(netstorage.MigrateDone))
+	resp.Bytes = proto.Int64(100)
+	if s.fetchErr != "" && req.GetMigratePhase() == netstorage.MigrateStatus {
+		resp.State = proto.Int32(int32(netstorage.MigrateFailed))
+		resp.Err = proto.String(s.fetchErr)
+	}
+	return resp, nil
+}
+
+func (s *mockMoveNetStore) getPhases() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.phases...)
+}
+
+func generateMigratePtCmd(db string, pt uint32, nodeID uint64) *proto2.Command {
+	val := &proto2.MigratePtCommand{
+		Database: proto.String(db),
+		PtId:     proto.Uint32(pt),
+		NodeID:   proto.Uint64(nodeID),
+	}
+	t1 := proto2.Command_MigratePtCommand
+	cmd := &proto2.Command{Type: &t1}
+	if err := proto.SetExtension(cmd, proto2.E_MigratePtCommand_Command, val); err != nil {
+		panic(err)
+	}
+	return cmd
+}
+
+func openMoveService(t *testing.T, netStore *mockMoveNetStore) (*MockMetaService, string) {
+	mms, err := NewMockMetaService(t.TempDir(), "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = mms.service.Open(); err != nil {
+		t.Fatal(err)
+	}
+	s := globalService.store
+	s.NetStore = netStore
+	for i, addr := range []string{"127.0.0.1:8400", "127.0.0.2:8400"} {
+		if err = s.ApplyCmd(GenerateCreateDataNodeCmd(addr, addr)); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.updateNodeStatus(uint64(i+2), int32(serf.StatusAlive), 2, addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db := "db0"
+	if err = s.ApplyCmd(GenerateCreateDatabaseCmd(db)); err != nil {
+		t.Fatal(err)
+	}
+	return mms, db
+}
+
+func waitMoveEvent(t *testing.T, eventId string, done func(e *meta.MigrateEventInfo) bool) {
+	for i := 0; i < 100; i++ {
+		globalService.store.mu.RLock()
+		e := globalService.store.data.MigrateEvents[eventId]
+		ok := done(e)
+		globalService.store.mu.RUnlock()
+		if ok {
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("move event %s is not finished", eventId)
+}
+
+func TestMoveEvent(t *testing.T) {
+	netStore := &mockMoveNetStore{}
+	mms, db := openMoveService(t, netStore)
+	defer mms.close()
+
+	s := globalService.store
+	pt := s.GetData().PtView[db][0]
+	src := pt.Owner.NodeID
+	dst := uint64(5) - src
+	if err := s.ApplyCmd(generateMigratePtCmd(db, 0, dst)); err != nil {
+		t.Fatal(err)
+	}
+	assert.EqualError(t, s.ApplyCmd(generateMigratePtCmd(db, 0, dst)), meta.ErrPtMoving.Error())
+
+	eventId := (&meta.DbPtInfo{Db: db, Pti: &pt}).String()
+	waitMoveEvent(t, eventId, func(e *meta.MigrateEventInfo) bool {
+		return e == nil
+	})
+
+	data := s.GetData()
+	assert.Equal(t, dst, data.PtView[db][0].Owner.NodeID)
+	assert.Equal(t, meta.Online, data.PtView[db][0].Status)
+	assert.Equal(t, []string{
+		fmt.Sprintf("%d:%d", dst, netstorage.MigrateFetch),
+		fmt.Sprintf("%d:%d", dst, netstorage.MigrateStatus),
+		fmt.Sprintf("%d:%d", src, netstorage.MigrateOffload),
+		fmt.Sprintf("%d:%d", dst, netstorage.MigrateFetchFinal),
+		fmt.Sprintf("%d:%d", dst, netstorage.MigrateStatus),
+		fmt.Sprintf("%d:%d", src, netstorage.MigrateDrop),
+	}, netStore.getPhases())
+}
+
+func TestMoveEventRollback(t *testing.T) {
+	netStore := &mockMoveNetStore{fetchErr: "disk full"}
+	mms, db := openMoveService(t, netStore)
+	defer mms.close()
+
+	s := globalService.store
+	pt := s.GetData().PtView[db][0]
+	src := pt.Owner.NodeID
+	dst := uint64(5) - src
+	if err := s.ApplyCmd(generateMigratePtCmd(db, 0, dst)); err != nil {
+		t.Fatal(err)
+	}
+
+	eventId := (&meta.DbPtInfo{Db: db, Pti: &pt}).String()
+	waitMoveEvent(t, eventId, func(e *meta.MigrateEventInfo) bool {
+		return meta.MoveState(e.GetCurrentState()) == meta.MoveFailed
+	})
+
+	data := s.GetData()
+	assert.Equal(t, "disk full", data.MigrateEvents[eventId].GetErr())
+	assert.Equal(t, src, data.PtView[db][0].Owner.NodeID)
+	assert.Equal(t, meta.Online, data.PtView[db][0].Status)
+	assert.Contains(t, netStore.getPhases(), fmt.Sprintf("%d:%d", dst, netstorage.MigrateDrop))
+
+	// a failed move is replaced by a new one
+	netStore.mu.Lock()
+	netStore.fetchErr = ""
+	netStore.mu.Unlock()
+	if err := s.ApplyCmd(generateMigratePtCmd(db, 0, dst)); err != nil {
+		t.Fatal(err)
+	}
+	waitMoveEvent(t, eventId, func(e *meta.MigrateEventInfo) bool {
+		return e == nil
+	})
+	assert.Equal(t, dst, s.GetData().PtView[db][0].Owner.NodeID)
+}
+
+func TestMoveEventFailedExpires(t *testing.T) {
+	defer func(d time.Duration) {
+		moveFailedExpiry = d
+	}(moveFailedExpiry)
+	moveFailedExpiry = 0
+
+	netStore := &mockMoveNetStore{fetchErr: "disk full"}
+	mms, db := openMoveService(t, netStore)
+	defer mms.close()
+
+	s := globalService.store
+	pt := s.GetData().PtView[db][0]
+	src := pt.Owner.NodeID
+	if err := s.ApplyCmd(generateMigratePtCmd(db, 0, uint64(5)-src)); err != nil {
+		t.Fatal(err)
+	}
+
+	// the failed move is removed, and its pt stays on the source
+	eventId := (&meta.DbPtInfo{Db: db, Pti: &pt}).String()
+	waitMoveEvent(t, eventId, func(e *meta.MigrateEventInfo) bool {
+		return e == nil
+	})
+	assert.Equal(t, src, s.GetData().PtView[db][0].Owner.NodeID)
+	assert.Equal(t, meta.Online, s.GetData().PtView[db][0].Status)
+}
@@ -35,7 +35,7 @@ import (
 type MetaStoreInterface interface {
 	leader() string
 	peers() []string
-	createDataNode(httpAddr, tcpAddr string) ([]byte, error)
+	createDataNode(httpAddr, tcpAddr, zone, rack string) ([]byte, error)
 	afterIndex(index uint64) <-chan struct{}
 	getSnapshot() []byte
 	isCandidate() bool
@@ -18,6 +18,7 @@ package meta
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	ast "github.com/influxdata/influxdb/pkg/testing/assert"
@@ -26,6 +27,8 @@ import (
 	"github.com/openGemini/openGemini/engine/executor/spdy/transport"
 	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/metaclient"
+	"github.com/openGemini/openGemini/lib/statisticsPusher"
+	"github.com/openGemini/openGemini/lib/syscontrol"
 	"github.com/openGemini/openGemini/open_src/influx/meta"
 )
 
@@ -74,7 +77,7 @@ func (s *MockRPCStore) peers() []string {
 	return []string{address}
 }
 
-func (s *MockRPCStore) createDataNode(httpAddr, tcpAddr string) ([]byte, error) {
+func (s *MockRPCStore) createDataNode(httpAddr, tcpAddr, zone, rack string) ([]byte, error) {
 	nodeStartInfo := meta.NodeStartInfo{}
 	nodeStartInfo.NodeId = 1
 	nodeStartInfo.PtIds = []uint32{2}
@@ -217,3 +220,22 @@ func Test_Join(t *testing.T) {
 	ast.Equal(t, uint64(0), callback.NodeInfo.ID)
 	ast.Equal(t, "", callback.NodeInfo.Host)
 }
+
+func Test_Stats(t *testing.T) {
+	server := startServer()
+	defer server.Stop()
+
+	statisticsPusher.InitDiagnostics(map[string]string{"hostname": address, "app": "ts-meta"},
+		statisticsPusher.BuildInfo{Version: "v1.0.0"}, nil)
+	callback := &metaclient.StatsCallback{}
+	msg := message.NewMetaMessage(message.StatsRequestMessage, &message.StatsRequest{Mod: syscontrol.Diagnostics})
+	if err := sendTestMsg(msg, callback); err != nil {
+		t.Fatalf("send msg error: %s", err)
+	}
+	ast.Equal(t, true, strings.Contains(string(callback.Data), "Version=\"v1.0.0\""))
+
+	msg = message.NewMetaMessage(message.StatsRequestMessage, &message.StatsRequest{Mod: "unknown"})
+	if err := sendTestMsg(msg, &metaclient.StatsCallback{}); err == nil {
+		t.Fatal("expect an error for the unknown mod")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/hashicorp/raft"
@@ -45,6 +46,9 @@ type raftWrapper struct {
 	snapStore   raft.SnapshotStore
 	stableStore raft.StableStore
 	notifyCh    chan bool
+
+	// the node has raft logs or snapshots, it is a member of the cluster before
+	hasState bool
 }
 
 func newRaftWrapper(s *Store, ln net.Listener, peers []string) (*raftWrapper, error) {
@@ -67,6 +71,7 @@ func newRaftWrapper(s *Store, ln net.Listener, peers []string) (*raftWrapper, er
 	}
 
 	hasExistState, _ := raft.HasExistingState(rw.logStore, rw.stableStore, rw.snapStore)
+	rw.hasState = hasExistState
 	if hasExistState {
 		err = raft.RecoverCluster(raftConf, (*storeFSM)(s), rw.logStore, rw.stableStore, rw.snapStore, trans, configuration)
 		if err != nil {
@@ -200,6 +205,7 @@ func (r *raftWrapper) apply(b []byte) error {
 	panic(fmt.Sprintf("unexpected response: %#v", resp))
 }
 
+// addServer adds the server of addr as a voter, a learner is promoted to a voter
 func (r *raftWrapper) addServer(addr string) error {
 	if r == nil || r.raft == nil {
 		return ErrRaftNotOpen
@@ -208,6 +214,108 @@ func (r *raftWrapper) addServer(addr string) error {
 	return future.Error()
 }
 
+// addLearner adds the server of addr as a nonvoter, the logs are replicated to it but it is not counted in the quorum
+func (r *raftWrapper) addLearner(addr string) error {
+	if r == nil || r.raft == nil {
+		return ErrRaftNotOpen
+	}
+	future := r.raft.AddNonvoter(raft.ServerID(addr), raft.ServerAddress(addr), 0, 0)
+	return future.Error()
+}
+
+// suffrage returns the suffrage of the server of addr, ok is false if the server is not in the raft configuration
+func (r *raftWrapper) suffrage(addr string) (raft.ServerSuffrage, bool, error) {
+	if r == nil || r.raft == nil {
+		return 0, false, ErrRaftNotOpen
+	}
+	future := r.raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return 0, false, err
+	}
+	for _, server := range future.Configuration().Servers {
+		if string(server.Address) == addr {
+			return server.Suffrage, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func (r *raftWrapper) learners() ([]string, error) {
+	future := r.raft.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return nil, err
+	}
+	var learners []string
+	for _, server := range future.Configuration().Servers {
+		if server.Suffrage == raft.Nonvoter {
+			learners = append(learners, string(server.Address))
+		}
+	}
+	return learners, nil
+}
+
+// raftStatus is the raft state of a meta node shown by the admin api
+type raftStatus struct {
+	NodeID       uint64   `json:"nodeId"`
+	State        string   `json:"state"`
+	Leader       string   `json:"leader"`
+	Term         uint64   `json:"term"`
+	LastLogIndex uint64   `json:"lastLogIndex"`
+	CommitIndex  uint64   `json:"commitIndex"`
+	AppliedIndex uint64   `json:"appliedIndex"`
+	Peers        []string `json:"peers"`
+	Learners     []string `json:"learners,omitempty"`
+}
+
+func (r *raftWrapper) status() (*raftStatus, error) {
+	if r == nil || r.raft == nil {
+		return nil, ErrRaftNotOpen
+	}
+	peers, err := r.peers()
+	if err != nil {
+		return nil, err
+	}
+	learners, err := r.learners()
+	if err != nil {
+		return nil, err
+	}
+	stats := r.raft.Stats()
+	term, _ := strconv.ParseUint(stats["term"], 10, 64)
+	commitIndex, _ := strconv.ParseUint(stats["commit_index"], 10, 64)
+	return &raftStatus{
+		State:        r.raft.State().String(),
+		Leader:       string(r.raft.Leader()),
+		Term:         term,
+		LastLogIndex: r.raft.LastIndex(),
+		CommitIndex:  commitIndex,
+		AppliedIndex: r.raft.AppliedIndex(),
+		Peers:        peers,
+		Learners:     learners,
+	}, nil
+}
+
+// transferLeadership hands the leadership over to the peer of addr, or to the most up to date peer if addr is empty
+func (r *raftWrapper) transferLeadership(addr string) error {
+	if r == nil || r.raft == nil {
+		return ErrRaftNotOpen
+	}
+	if !r.isLeader() {
+		return errno.NewError(errno.MetaIsNotLeader)
+	}
+	if addr == "" {
+		return r.raft.LeadershipTransfer().Error()
+	}
+	return r.raft.LeadershipTransferToServer(raft.ServerID(addr), raft.ServerAddress(addr)).Error()
+}
+
+func (r *raftWrapper) removeServer(addr string) error {
+	if r == nil || r.raft == nil {
+		return ErrRaftNotOpen
+	}
+	future := r.raft.RemoveServer(raft.ServerID(addr), 0, 0)
+	return future.Error()
+}
+
 func (r *raftWrapper) showDebugInfo(witch string) ([]byte, error) {
 	if r == nil || r.raft == nil {
 		return nil, ErrRaftNotOpen
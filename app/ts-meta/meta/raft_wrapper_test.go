@@ -18,11 +18,15 @@ package meta
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/raft"
 	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/metaclient"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRaftWrapperApply(t *testing.T) {
@@ -50,3 +54,90 @@ func TestBootFirst(t *testing.T) {
 	c.JoinPeers = []string{c.Domain + ":8400"}
 	assert.True(t, bootFirst(c))
 }
+
+func TestRaftWrapperTransferLeadership(t *testing.T) {
+	var r *raftWrapper
+	_, err := r.status()
+	assert.Equal(t, ErrRaftNotOpen, err)
+	assert.Equal(t, ErrRaftNotOpen, r.transferLeadership(""))
+
+	c := raft.MakeCluster(3, t, nil)
+	defer c.Close()
+	r = &raftWrapper{raft: c.Followers()[0]}
+	assert.True(t, errno.Equal(r.transferLeadership(""), errno.MetaIsNotLeader))
+
+	leader := c.Leader()
+	r = &raftWrapper{raft: leader}
+	status, err := r.status()
+	assert.NoError(t, err)
+	assert.Equal(t, "Leader", status.State)
+	assert.Equal(t, 3, len(status.Peers))
+
+	assert.NoError(t, r.transferLeadership(""))
+	assert.Eventually(t, func() bool {
+		return !r.isLeader()
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStore_JoinAsLearner(t *testing.T) {
+	mms, err := NewMockMetaService(t.TempDir(), "127.0.0.1")
+	require.NoError(t, err)
+	defer mms.close()
+	require.NoError(t, mms.service.Open())
+	leader := mms.service.store
+	require.NoError(t, leader.ApplyCmd(GenerateCreateDataNodeCmd("127.0.0.1:8400", "127.0.0.1:8401")))
+	require.NoError(t, leader.ApplyCmd(GenerateCreateDatabaseCmd("db0")))
+
+	// the new meta node lists the running meta node in its meta-join only
+	c, err := NewMetaConfig(t.TempDir(), "127.0.0.2")
+	require.NoError(t, err)
+	c.JoinPeers = []string{"127.0.0.1:9092"}
+	learner := &MockMetaService{service: NewService(c, nil)}
+	learner.service.Node = metaclient.NewNode(c.Dir)
+	learner.ln, learner.service.RaftListener, err = MakeRaftListen(c)
+	require.NoError(t, err)
+	defer learner.close()
+	require.NoError(t, learner.service.Open())
+
+	store := learner.service.store
+	assert.True(t, store.joining())
+	assert.NotNil(t, store.GetData().Database("db0"))
+	assert.NotEqual(t, uint64(0), store.Node.ID)
+
+	suffrage, member, err := leader.raft.suffrage("127.0.0.2:9088")
+	require.NoError(t, err)
+	assert.True(t, member)
+	assert.Equal(t, raft.Voter, suffrage)
+	assert.Equal(t, 2, len(leader.GetData().MetaNodes))
+	assert.Equal(t, store.Node.ID, leader.GetData().MetaNodes[1].ID)
+	assert.Equal(t, "127.0.0.2:9088", leader.GetData().MetaNodes[1].TCPHost)
+
+	// remove the new meta node while it is alive
+	require.NoError(t, leader.removeMetaNode(store.Node.ID, true))
+	_, member, err = leader.raft.suffrage("127.0.0.2:9088")
+	require.NoError(t, err)
+	assert.False(t, member)
+	assert.Equal(t, 1, len(leader.GetData().MetaNodes))
+}
+
+func TestStore_JoinLearnerIsNotMetaNode(t *testing.T) {
+	mms, err := NewMockMetaService(t.TempDir(), "127.0.0.1")
+	require.NoError(t, err)
+	defer mms.close()
+	require.NoError(t, mms.service.Open())
+	leader := mms.service.store
+
+	// the learner is in the raft configuration, but it is not a meta node until it is promoted
+	n := &meta.NodeInfo{Host: "127.0.0.3:9091", RPCAddr: "127.0.0.3:9092", TCPHost: "127.0.0.3:9088"}
+	node, err := leader.Join(n)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), node.ID)
+	suffrage, member, err := leader.raft.suffrage(n.TCPHost)
+	require.NoError(t, err)
+	assert.True(t, member)
+	assert.Equal(t, raft.Nonvoter, suffrage)
+	status, err := leader.raftStatus()
+	require.NoError(t, err)
+	assert.Equal(t, []string{n.TCPHost}, status.Learners)
+	assert.Equal(t, 1, len(leader.GetData().MetaNodes))
+}
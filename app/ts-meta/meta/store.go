@@ -36,6 +36,7 @@ import (
 	mclient "github.com/openGemini/openGemini/lib/metaclient"
 	"github.com/openGemini/openGemini/lib/netstorage"
 	"github.com/openGemini/openGemini/lib/rand"
+	"github.com/openGemini/openGemini/lib/util"
 	stat "github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
 	"github.com/openGemini/openGemini/open_src/influx/meta"
 	mproto "github.com/openGemini/openGemini/open_src/influx/meta/proto"
@@ -63,6 +64,9 @@ const (
 // Raft configuration.
 const (
 	raftListenerStartupTimeout = time.Second
+
+	// a meta node is regarded as dead if its raft address can not be connected in the timeout
+	metaNodeDialTimeout = time.Second
 )
 
 type ShardStat struct {
@@ -253,6 +257,83 @@ func (rpi *rpInfo) checkReSharding(ptNum int, imbalanceThreshold float64) int64
 	return maxTime
 }
 
+// checkHashReSharding returns the split time and the number of shards each shard of the newest shard group
+// should be split into. A shard is split when its row count exceeds splitThreshold or its load exceeds the
+// average load by imbalanceThreshold. Stores only report the newest shard of a retention policy on each pt,
+// so shards that have not been reported are never split. The shard group is marked as being split only when
+// a split is returned, resetReSharding must be called once the split is done.
+func (rpi *rpInfo) checkHashReSharding(ptNum int, splitThreshold uint64, imbalanceThreshold float64) (int64, []uint32) {
+	rpi.mu.Lock()
+	defer rpi.mu.Unlock()
+	if rpi.reSharding || len(rpi.shardStat) == 0 || ptNum == 0 {
+		return 0, nil
+	}
+
+	var maxTime int64
+	var minReportTime, maxReportTime time.Time
+	var reportedShards int
+	var rowCount uint64
+	var seriesCount int32
+	reportedPts := make(map[uint32]struct{}, ptNum)
+	for i := range rpi.shardStat {
+		if rpi.shardStat[i].ownerPT == math.MaxUint32 {
+			continue
+		}
+		reportedShards++
+		if len(reportedPts) == 0 || rpi.shardStat[i].reportTime.Before(minReportTime) {
+			minReportTime = rpi.shardStat[i].reportTime
+		}
+		if len(reportedPts) == 0 || rpi.shardStat[i].reportTime.After(maxReportTime) {
+			maxReportTime = rpi.shardStat[i].reportTime
+		}
+		reportedPts[rpi.shardStat[i].ownerPT] = struct{}{}
+		if rpi.shardStat[i].maxTime > maxTime {
+			maxTime = rpi.shardStat[i].maxTime
+		}
+		seriesCount += rpi.shardStat[i].seriesCount
+		rowCount += rpi.shardStat[i].shardSize
+	}
+
+	// haven't receive total load from store
+	if len(reportedPts) < ptNum && len(reportedPts) < len(rpi.shardStat) {
+		return 0, nil
+	}
+
+	if maxReportTime.Sub(minReportTime) > reportTimeSpan {
+		return 0, nil
+	}
+
+	avgLoad := float64(rowCount) / float64(reportedShards)
+	splits := make([]uint32, len(rpi.shardStat))
+	needSplit := false
+	for i := range rpi.shardStat {
+		splits[i] = 1
+		if rpi.shardStat[i].ownerPT == math.MaxUint32 {
+			continue
+		}
+		load := float64(rpi.shardStat[i].shardSize)
+		n := 1.0
+		if rpi.shardStat[i].shardSize > splitThreshold {
+			n = math.Ceil(load / float64(splitThreshold))
+		}
+		if load > (1+imbalanceThreshold)*avgLoad {
+			n = math.Max(n, math.Ceil(load/avgLoad))
+		}
+		n = math.Min(n, float64(ptNum))
+		if n > 1 {
+			splits[i] = uint32(n)
+			needSplit = true
+		}
+	}
+
+	if !needSplit {
+		return 0, nil
+	}
+	rpi.currentRowCount, rpi.currentSeriesCount = rowCount, seriesCount
+	rpi.reSharding = true
+	return maxTime, splits
+}
+
 func (rpi *rpInfo) getSplitVectorByRowCount(db string, shardNum int, data *meta.Data) []string {
 	splitPoints := make([]string, shardNum-1)
 	keyCount := int(rpi.currentRowCount)/shardNum + 1
@@ -351,10 +432,12 @@ type Store struct {
 		DeleteDatabase(node *meta.DataNode, database string, pt uint32) error
 		DeleteRetentionPolicy(node *meta.DataNode, db string, rp string, pt uint32) error
 		DeleteMeasurement(node *meta.DataNode, db string, rp string, name string, shardIds []uint64) error
+		MigratePt(node *meta.DataNode, req *netstorage.MigratePtRequest) (*netstorage.MigratePtResponse, error)
 	}
 
 	statMu       sync.RWMutex
 	dbStatistics map[string]*dbInfo
+	ptLoads      ptLoads
 
 	cacheMu          sync.RWMutex
 	cacheData        *meta.Data
@@ -402,10 +485,11 @@ func (s *Store) checkLeaderChanged() {
 					s.cm.Start()
 				}
 
-				s.deleteWg.Add(3)
+				s.deleteWg.Add(4)
 				go s.checkDatabaseDelete()
 				go s.checkRpDelete()
 				go s.checkMeasurementDelete()
+				go s.checkMoveEvents()
 				continue
 			}
 
@@ -453,12 +537,18 @@ func (s *Store) Open(raftln net.Listener) error {
 		return err
 	}
 
-	if err = s.waitForLeader(); err != nil {
-		return err
-	}
+	if s.joining() {
+		if err = s.joinAsLearner(c); err != nil {
+			return err
+		}
+	} else {
+		if err = s.waitForLeader(); err != nil {
+			return err
+		}
 
-	if err = s.joinMetaServer(c); err != nil {
-		return err
+		if err = s.joinMetaServer(c); err != nil {
+			return err
+		}
 	}
 
 	s.wg.Add(2)
@@ -493,6 +583,48 @@ func (s *Store) joinMetaServer(c *mclient.Client) error {
 	return nil
 }
 
+// joining returns true if the store is a new meta node joining a running cluster. The meta-join of such a node
+// lists the meta nodes in the cluster but not itself, and it has no raft state.
+func (s *Store) joining() bool {
+	return !s.raft.hasState && !mclient.Peers(s.config.JoinPeers).Contains(s.config.CombineDomain(s.rpcAddr))
+}
+
+// joinAsLearner joins a running cluster as a learner, which is promoted to a voter after its meta data catches up
+// with the cluster, so a lagging node is never counted in the quorum
+func (s *Store) joinAsLearner(c *mclient.Client) error {
+	addr := s.config.CombineDomain(s.httpAddr)
+	rpcAddr := s.config.CombineDomain(s.rpcAddr)
+	raftAddr := s.config.CombineDomain(s.raftAddr)
+
+	s.Logger.Info("join the meta cluster as a learner", zap.String("raftAddr", raftAddr))
+	if _, err := c.JoinMetaServer(addr, rpcAddr, raftAddr); err != nil {
+		return err
+	}
+	if err := s.waitForLeader(); err != nil {
+		return err
+	}
+
+	index := c.MetaIndex()
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for s.index() < index {
+		select {
+		case <-s.closing:
+			return errors.New("closing")
+		case <-ticker.C:
+		}
+	}
+
+	s.Logger.Info("the learner caught up with the cluster, promote it to a voter", zap.Uint64("index", index))
+	n, err := c.JoinMetaServer(addr, rpcAddr, raftAddr)
+	if err != nil {
+		return err
+	}
+	s.Node.ID = n.ID
+	s.Node.Clock = n.LTime
+	return nil
+}
+
 func (s *Store) makeClient() *mclient.Client {
 	c := mclient.NewClient(s.config.Dir, s.config.RetentionAutoCreate, s.config.MaxConcurrentWriteLimit)
 	c.SetMetaServers(s.config.JoinPeers)
@@ -999,8 +1131,23 @@ func (s *Store) apply(b []byte) error {
 	return s.raft.apply(b)
 }
 
+// Join adds the meta node to the raft group. A node which is not in the raft configuration is added as a learner,
+// it joins again to be promoted to a voter after catching up with the cluster. The meta node is created on the
+// promotion, so a learner is not a meta node of the cluster and gets no node id.
 func (s *Store) Join(n *meta.NodeInfo) (*meta.NodeInfo, error) {
-	if err := s.raft.addServer(n.TCPHost); err != nil {
+	_, member, err := s.raft.suffrage(n.TCPHost)
+	if err != nil {
+		return nil, err
+	}
+	if !member {
+		s.Logger.Info("add the meta node as a learner", zap.String("raftAddr", n.TCPHost))
+		if err = s.raft.addLearner(n.TCPHost); err != nil {
+			return nil, err
+		}
+		return &meta.NodeInfo{Host: n.Host, RPCAddr: n.RPCAddr, TCPHost: n.TCPHost}, nil
+	}
+
+	if err = s.raft.addServer(n.TCPHost); err != nil {
 		return nil, err
 	}
 
@@ -1085,13 +1232,159 @@ func (s *Store) userSnapshot(version uint32) error {
 	return s.raft.UserSnapshot()
 }
 
-func (s *Store) reSharding(db string, rp string, sgId uint64, splitTime int64, shardBounds []string) error {
+// cloneData returns a copy of the meta data which is safe to read without holding the lock
+func (s *Store) cloneData() *meta.Data {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data.Clone()
+}
+
+// user returns a copy of the user of name, or nil if there is no such user
+func (s *Store) user(name string) *meta.UserInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	u := s.data.GetUser(name)
+	if u == nil {
+		return nil
+	}
+	c := *u
+	return &c
+}
+
+// metaNodeHost returns the http address of the meta node whose raft address is tcpHost
+func (s *Store) metaNodeHost(tcpHost string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, n := range s.data.MetaNodes {
+		if n.TCPHost == tcpHost {
+			return n.Host
+		}
+	}
+	return ""
+}
+
+func (s *Store) raftStatus() (*raftStatus, error) {
+	s.mu.RLock()
+	r := s.raft
+	var id uint64
+	raftAddr := s.config.CombineDomain(s.raftAddr)
+	for i := range s.data.MetaNodes {
+		if s.data.MetaNodes[i].TCPHost == raftAddr {
+			id = s.data.MetaNodes[i].ID
+		}
+	}
+	s.mu.RUnlock()
+
+	status, err := r.status()
+	if err != nil {
+		return nil, err
+	}
+	status.NodeID = id
+	return status, nil
+}
+
+// metaNode returns the copy of the meta node of id, or nil if the node is not found
+func (s *Store) metaNode(id uint64) *meta.NodeInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for i := range s.data.MetaNodes {
+		if s.data.MetaNodes[i].ID == id {
+			n := s.data.MetaNodes[i]
+			return &n
+		}
+	}
+	return nil
+}
+
+// transferLeadership hands the leadership over to the meta node of id, or to any up to date meta node if id is 0
+func (s *Store) transferLeadership(id uint64) error {
+	addr := ""
+	if id != 0 {
+		n := s.metaNode(id)
+		if n == nil {
+			return meta.ErrNodeNotFound
+		}
+		addr = n.TCPHost
+	}
+
+	s.mu.RLock()
+	r := s.raft
+	s.mu.RUnlock()
+	return r.transferLeadership(addr)
+}
+
+// removeMetaNode removes a dead meta node from the raft peers and the meta data. The node must be unreachable
+// unless force is set, the leader can not be removed.
+func (s *Store) removeMetaNode(id uint64, force bool) error {
+	if !s.IsLeader() {
+		return errno.NewError(errno.MetaIsNotLeader)
+	}
+	n := s.metaNode(id)
+	if n == nil {
+		return meta.ErrNodeNotFound
+	}
+	if n.TCPHost == s.leader() {
+		return fmt.Errorf("meta node %d is the leader, transfer the leadership first", id)
+	}
+	if !force {
+		if conn, err := net.DialTimeout("tcp", n.TCPHost, metaNodeDialTimeout); err == nil {
+			util.MustClose(conn)
+			return fmt.Errorf("meta node %d is still reachable at %s", id, n.TCPHost)
+		}
+	}
+
+	s.mu.RLock()
+	r := s.raft
+	s.mu.RUnlock()
+	if err := r.removeServer(n.TCPHost); err != nil {
+		return err
+	}
+
+	val := &mproto.DeleteMetaNodeCommand{ID: proto.Uint64(id)}
+	t := mproto.Command_DeleteMetaNodeCommand
+	cmd := &mproto.Command{Type: &t}
+	if err := proto.SetExtension(cmd, mproto.E_DeleteMetaNodeCommand_Command, val); err != nil {
+		panic(err)
+	}
+	return s.ApplyCmd(cmd)
+}
+
+// importSchema creates the missing databases, measurements and users of a schema exported from another cluster,
+// and returns the users which are skipped
+func (s *Store) importSchema(schema *meta.ClusterSchema) ([]meta.SkippedUser, error) {
+	if !s.IsLeader() {
+		return nil, errno.NewError(errno.MetaIsNotLeader)
+	}
+	// the fsm does not return the skipped users, so the schema is imported into a copy of the data first
+	skipped, err := s.cloneData().ImportSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	val := &mproto.ImportSchemaCommand{Schema: b}
+	t := mproto.Command_ImportSchemaCommand
+	cmd := &mproto.Command{Type: &t}
+	if err := proto.SetExtension(cmd, mproto.E_ImportSchemaCommand_Command, val); err != nil {
+		panic(err)
+	}
+	if err = s.ApplyCmd(cmd); err != nil {
+		return nil, err
+	}
+	return skipped, nil
+}
+
+func (s *Store) reSharding(db string, rp string, sgId uint64, splitTime int64, shardBounds []string, hashSplits []uint32) error {
 	val := &mproto.ReShardingCommand{
 		Database:     proto.String(db),
 		RpName:       proto.String(rp),
 		ShardGroupID: proto.Uint64(sgId),
 		SplitTime:    proto.Int64(splitTime),
-		ShardBounds:  shardBounds}
+		ShardBounds:  shardBounds,
+		HashSplits:   hashSplits}
 	t := mproto.Command_ReShardingCommand
 	cmd := &mproto.Command{Type: &t}
 	if err := proto.SetExtension(cmd, mproto.E_ReShardingCommand_Command, val); err != nil {
@@ -1142,6 +1435,10 @@ func (s *Store) UpdateLoad(b []byte) error {
 	for i := range v.GetDBPTStat() {
 		db := v.GetDBPTStat()[i].GetDB()
 		dbinfo := s.getDbInfo(db)
+		// the stores of the old versions do not report the disk size
+		if v.GetDBPTStat()[i].DiskSize != nil {
+			s.ptLoads.update(db, v.GetDBPTStat()[i].GetPtID(), v.GetDBPTStat()[i].GetDiskSize())
+		}
 
 		if len(v.GetDBPTStat()[i].GetRpStats()) == 0 {
 			ptId := v.GetDBPTStat()[i].GetPtID()
@@ -1165,6 +1462,23 @@ func (s *Store) UpdateLoad(b []byte) error {
 
 			shardId := rpStat.GetShardStats().GetShardID()
 			shardSize := rpinfo.updateShardStat(shardId, v.GetDBPTStat()[i].GetPtID(), rpStat.GetShardStats())
+			rpi, err := s.cacheData.RetentionPolicy(db, rpStat.GetRpName())
+			if err == nil && rpi.HashSharding() {
+				// the hash shards are split when they are too large or imbalanced, so the load is checked
+				// whatever the size of the reported shard is
+				splitTime, splits := rpinfo.checkHashReSharding(len(s.cacheData.PtView[db]), s.config.SplitRowThreshold, s.config.ImbalanceFactor)
+				if splitTime == 0 {
+					continue
+				}
+				reShardingNum++
+				go func(rpinfo *rpInfo, db, rp string, sgId uint64) {
+					err := s.reSharding(db, rp, sgId, splitTime, nil, splits)
+					rpinfo.resetReSharding(err)
+					res <- &reShardingRes{rp: rp, err: err}
+				}(rpinfo, db, rpStat.GetRpName(), sgInfo.ID)
+				continue
+			}
+
 			if shardSize > s.config.SplitRowThreshold {
 				reShardingNum++
 				go func(rpinfo *rpInfo, db, rp string, sgId uint64) {
@@ -1187,7 +1501,7 @@ func (s *Store) UpdateLoad(b []byte) error {
 						res <- &reShardingRes{rp: rp, err: err}
 						return
 					}
-					err = s.reSharding(db, rp, sgId, splitTime, splitPoints)
+					err = s.reSharding(db, rp, sgId, splitTime, splitPoints, nil)
 					res <- &reShardingRes{rp: rp, err: err}
 				}(rpinfo, db, rpStat.GetRpName(), sgInfo.ID)
 			}
@@ -1204,10 +1518,12 @@ func (s *Store) UpdateLoad(b []byte) error {
 	return nil
 }
 
-func (s *Store) createDataNode(writeHost, queryHost string) ([]byte, error) {
+func (s *Store) createDataNode(writeHost, queryHost, zone, rack string) ([]byte, error) {
 	val := &mproto.CreateDataNodeCommand{
 		HTTPAddr: proto.String(writeHost),
 		TCPAddr:  proto.String(queryHost),
+		Zone:     proto.String(zone),
+		Rack:     proto.String(rack),
 	}
 
 	t := mproto.Command_CreateDataNodeCommand
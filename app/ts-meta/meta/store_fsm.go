@@ -17,6 +17,7 @@ limitations under the License.
 package meta
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -138,6 +139,16 @@ func (fsm *storeFSM) executeCmd(cmd proto2.Command) interface{} {
 		return fsm.applyCreateDataNodeCommand(&cmd)
 	case proto2.Command_DeleteDataNodeCommand:
 		return fsm.applyDeleteDataNodeCommand(&cmd)
+	case proto2.Command_DecommissionDataNodeCommand:
+		return fsm.applyDecommissionDataNodeCommand(&cmd)
+	case proto2.Command_SetBalancerCommand:
+		return fsm.applySetBalancerCommand(&cmd)
+	case proto2.Command_SetReplicationPausedCommand:
+		return fsm.applySetReplicationPausedCommand(&cmd)
+	case proto2.Command_ImportSchemaCommand:
+		return fsm.applyImportSchemaCommand(&cmd)
+	case proto2.Command_MigratePtCommand:
+		return fsm.applyMigratePtCommand(&cmd)
 	case proto2.Command_MarkDatabaseDeleteCommand:
 		return fsm.applyMarkDatabaseDeleteCommand(&cmd)
 	case proto2.Command_UpdateShardOwnerCommand:
@@ -152,6 +163,8 @@ func (fsm *storeFSM) executeCmd(cmd proto2.Command) interface{} {
 		return fsm.applyUpdateSchemaCommand(&cmd)
 	case proto2.Command_AlterShardKeyCmd:
 		return fsm.applyAlterShardKeyCommand(&cmd)
+	case proto2.Command_AlterMeasurementTTLCommand:
+		return fsm.applyAlterMeasurementTTLCommand(&cmd)
 	case proto2.Command_PruneGroupsCommand:
 		return fsm.applyPruneGroupsCommand(&cmd)
 	case proto2.Command_MarkMeasurementDeleteCommand:
@@ -164,6 +177,8 @@ func (fsm *storeFSM) executeCmd(cmd proto2.Command) interface{} {
 		return fsm.applyUpdateShardInfoTierCommand(&cmd)
 	case proto2.Command_UpdateNodeStatusCommand:
 		return fsm.applyUpdateNodeStatusCommand(&cmd)
+	case proto2.Command_UpdateNodeDiskStatusCommand:
+		return fsm.applyUpdateNodeDiskStatusCommand(&cmd)
 	case proto2.Command_CreateEventCommand:
 		return fsm.applyCreateEventCommand(&cmd)
 	case proto2.Command_UpdateEventCommand:
@@ -186,6 +201,7 @@ func (fsm *storeFSM) applyReShardingCommand(cmd *proto2.Command) interface{} {
 		ShardGroupID: v.GetShardGroupID(),
 		SplitTime:    v.GetSplitTime(),
 		Bounds:       v.GetShardBounds(),
+		HashSplits:   v.GetHashSplits(),
 	}
 	return fsm.data.ReSharding(info)
 }
@@ -270,7 +286,22 @@ func (fsm *storeFSM) applyDropDatabaseCommand(cmd *proto2.Command) interface{} {
 func (fsm *storeFSM) applyCreateMeasurementCommand(cmd *proto2.Command) interface{} {
 	ext, _ := proto.GetExtension(cmd, proto2.E_CreateMeasurementCommand_Command)
 	v := ext.(*proto2.CreateMeasurementCommand)
-	return fsm.data.CreateMeasurement(v.GetDBName(), v.GetRpName(), v.GetName(), v.GetSki(), v.GetIR())
+	if err := meta2.ValidFloatCodec(v.GetFloatCodec()); err != nil {
+		return err
+	}
+	if err := fsm.data.CreateMeasurement(v.GetDBName(), v.GetRpName(), v.GetName(), v.GetSki(), v.GetIR()); err != nil {
+		return err
+	}
+	if v.GetFloatCodec() == "" {
+		return nil
+	}
+	return fsm.data.SetMeasurementFloatCodec(v.GetDBName(), v.GetRpName(), v.GetName(), v.GetFloatCodec())
+}
+
+func (fsm *storeFSM) applyAlterMeasurementTTLCommand(cmd *proto2.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, proto2.E_AlterMeasurementTTLCommand_Command)
+	v := ext.(*proto2.AlterMeasurementTTLCommand)
+	return fsm.data.SetMeasurementTTL(v.GetDBName(), v.GetRpName(), v.GetName(), time.Duration(v.GetTTL()))
 }
 
 func (fsm *storeFSM) applyCreateRetentionPolicyCommand(cmd *proto2.Command) interface{} {
@@ -453,10 +484,11 @@ func (fsm *storeFSM) applyCreateDataNodeCommand(cmd *proto2.Command) interface{}
 
 	dataNode := fsm.data.DataNodeByHttpHost(v.GetHTTPAddr())
 	if dataNode != nil {
-		return nil
+		// the node restarts, it may have been moved to another zone or rack
+		return fsm.data.SetDataNodeTopology(dataNode.ID, v.GetZone(), v.GetRack())
 	}
 
-	err, _ := fsm.data.CreateDataNode(v.GetHTTPAddr(), v.GetTCPAddr())
+	err, _ := fsm.data.CreateDataNode(v.GetHTTPAddr(), v.GetTCPAddr(), v.GetZone(), v.GetRack())
 	return err
 }
 
@@ -466,6 +498,43 @@ func (fsm *storeFSM) applyDeleteDataNodeCommand(cmd *proto2.Command) interface{}
 	return fsm.data.DeleteDataNode(v.GetID())
 }
 
+func (fsm *storeFSM) applyDecommissionDataNodeCommand(cmd *proto2.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, proto2.E_DecommissionDataNodeCommand_Command)
+	v := ext.(*proto2.DecommissionDataNodeCommand)
+	return fsm.data.DecommissionDataNode(v.GetID())
+}
+
+func (fsm *storeFSM) applyMigratePtCommand(cmd *proto2.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, proto2.E_MigratePtCommand_Command)
+	v := ext.(*proto2.MigratePtCommand)
+	return fsm.data.MigratePt(v.GetDatabase(), v.GetPtId(), v.GetNodeID())
+}
+
+func (fsm *storeFSM) applySetBalancerCommand(cmd *proto2.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, proto2.E_SetBalancerCommand_Command)
+	v := ext.(*proto2.SetBalancerCommand)
+	fsm.data.SetBalancer(v.GetPaused(), v.GetDryRun())
+	return nil
+}
+
+func (fsm *storeFSM) applySetReplicationPausedCommand(cmd *proto2.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, proto2.E_SetReplicationPausedCommand_Command)
+	v := ext.(*proto2.SetReplicationPausedCommand)
+	fsm.data.SetReplicationPaused(v.GetPaused())
+	return nil
+}
+
+func (fsm *storeFSM) applyImportSchemaCommand(cmd *proto2.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, proto2.E_ImportSchemaCommand_Command)
+	v := ext.(*proto2.ImportSchemaCommand)
+	schema := &meta2.ClusterSchema{}
+	if err := json.Unmarshal(v.GetSchema(), schema); err != nil {
+		return err
+	}
+	_, err := fsm.data.ImportSchema(schema)
+	return err
+}
+
 func (fsm *storeFSM) Snapshot() (raft.FSMSnapshot, error) {
 	s := (*Store)(fsm)
 	s.mu.Lock()
@@ -492,6 +561,12 @@ func (fsm *storeFSM) applyUpdateNodeStatusCommand(cmd *proto2.Command) interface
 	return fsm.data.UpdateNodeStatus(v.GetID(), v.GetStatus(), v.GetLtime(), v.GetGossipAddr())
 }
 
+func (fsm *storeFSM) applyUpdateNodeDiskStatusCommand(cmd *proto2.Command) interface{} {
+	ext, _ := proto.GetExtension(cmd, proto2.E_UpdateNodeDiskStatusCommand_Command)
+	v := ext.(*proto2.UpdateNodeDiskStatusCommand)
+	return fsm.data.UpdateNodeDiskStatus(v.GetID(), v.GetDiskStatus())
+}
+
 func (fsm *storeFSM) applyCreateEventCommand(cmd *proto2.Command) interface{} {
 	ext, _ := proto.GetExtension(cmd, proto2.E_CreateEventCommand_Command)
 	v := ext.(*proto2.CreateEventCommand)
@@ -514,6 +589,9 @@ func (fsm *storeFSM) applyUpdatePtInfoCommand(cmd *proto2.Command) interface{} {
 func (fsm *storeFSM) applyRemoveEvent(cmd *proto2.Command) interface{} {
 	ext, _ := proto.GetExtension(cmd, proto2.E_RemoveEventCommand_Command)
 	v := ext.(*proto2.RemoveEventCommand)
+	if v.OpId != nil {
+		return fsm.data.RemoveEventOp(v.GetEventId(), v.GetOpId())
+	}
 	return fsm.data.RemoveEventInfo(v.GetEventId())
 }
 
@@ -18,6 +18,8 @@ package meta
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 	"testing"
 	"time"
 
@@ -46,3 +48,57 @@ func TestCheckLeaderChanged(t *testing.T) {
 	close(s.closing)
 	s.wg.Wait()
 }
+
+func TestCheckHashReSharding(t *testing.T) {
+	now := time.Now()
+	rpi := &rpInfo{}
+	rpi.shardStat = []*ShardStat{
+		{id: 1, ownerPT: 0, shardSize: 250, maxTime: 10, reportTime: now},
+		{id: 2, ownerPT: 1, shardSize: 150, maxTime: 20, reportTime: now},
+		{id: 3, ownerPT: 2, shardSize: 100, maxTime: 15, reportTime: now},
+		{id: 4, ownerPT: math.MaxUint32, shardSize: 100, reportTime: now},
+	}
+
+	// pt 3 has not reported its load
+	if splitTime, splits := rpi.checkHashReSharding(4, 100, 0.5); splitTime != 0 || splits != nil {
+		t.Fatalf("got split time %d splits %v, exp no split", splitTime, splits)
+	}
+	rpi.resetReSharding(nil)
+
+	rpi.shardStat[3].ownerPT = 3
+	splitTime, splits := rpi.checkHashReSharding(4, 100, 0.5)
+	if splitTime != 20 || !reflect.DeepEqual(splits, []uint32{3, 2, 1, 1}) {
+		t.Fatalf("got split time %d splits %v, exp 20 and [3 2 1 1]", splitTime, splits)
+	}
+	// the shard group is being split
+	if splitTime, _ = rpi.checkHashReSharding(4, 100, 0.5); splitTime != 0 {
+		t.Fatalf("got split time %d, exp 0", splitTime)
+	}
+	rpi.resetReSharding(nil)
+
+	// skewed shard is split even if it doesn't exceed the row threshold
+	for i := range rpi.shardStat {
+		rpi.shardStat[i].shardSize = 10
+	}
+	rpi.shardStat[0].shardSize = 90
+	if _, splits = rpi.checkHashReSharding(4, 100, 0.5); !reflect.DeepEqual(splits, []uint32{3, 1, 1, 1}) {
+		t.Fatalf("got splits %v, exp [3 1 1 1]", splits)
+	}
+	rpi.resetReSharding(nil)
+
+	// the number of split shards doesn't exceed the number of pts
+	rpi.shardStat[0].shardSize = 10000
+	if _, splits = rpi.checkHashReSharding(4, 100, 0.5); !reflect.DeepEqual(splits, []uint32{4, 1, 1, 1}) {
+		t.Fatalf("got splits %v, exp [4 1 1 1]", splits)
+	}
+	rpi.resetReSharding(nil)
+
+	// the balanced shards below the row threshold are not split and leave no state behind
+	rpi.shardStat[0].shardSize = 10
+	if splitTime, splits = rpi.checkHashReSharding(4, 100, 0.5); splitTime != 0 || splits != nil {
+		t.Fatalf("got split time %d splits %v, exp no split", splitTime, splits)
+	}
+	if rpi.reSharding || rpi.currentRowCount != 0 {
+		t.Fatalf("got reSharding %t row count %d, exp false and 0", rpi.reSharding, rpi.currentRowCount)
+	}
+}
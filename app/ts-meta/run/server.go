@@ -90,6 +90,7 @@ func NewServer(conf config.Config, cmd *cobra.Command, logger *Logger.Logger) (a
 
 	runtime.SetBlockProfileRate(int(1 * time.Second))
 	runtime.SetMutexProfileFraction(1)
+	s.initDiagnostics()
 	s.initStatisticsPusher()
 	return s, nil
 }
@@ -182,11 +183,23 @@ type Service interface {
 	Close() error
 }
 
-func (s *Server) initStatisticsPusher() {
-	if !s.config.Monitor.StoreEnabled {
-		return
+func (s *Server) initDiagnostics() {
+	appName := "ts-meta"
+	if app.IsSingle() {
+		appName = "ts-server"
 	}
+	tags := map[string]string{"hostname": s.BindAddress, "app": appName}
+	statisticsPusher.InitDiagnostics(tags, statisticsPusher.BuildInfo(app.NewBuildInfo(s.cmd)), map[string]interface{}{
+		"meta.dir":               s.config.Meta.Dir,
+		"meta.bind-address":      s.config.Meta.BindAddress,
+		"meta.http-bind-address": s.config.Meta.HTTPBindAddress,
+		"meta.rpc-bind-address":  s.config.Meta.RPCBindAddress,
+		"monitor.store-enabled":  strconv.FormatBool(s.config.Monitor.StoreEnabled),
+		"monitor.pushers":        s.config.Monitor.Pushers,
+	})
+}
 
+func (s *Server) initStatisticsPusher() {
 	appName := "ts-sql"
 	if app.IsSingle() {
 		appName = "ts-server"
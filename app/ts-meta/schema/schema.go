@@ -0,0 +1,228 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schema implements the export-schema and import-schema commands of ts-meta, which copy the
+// databases, measurements and users of a cluster to another one through the admin api of the meta nodes.
+package schema
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+)
+
+const schemaPath = "/api/v1/schema"
+
+var errImportAdminRequired = errors.New("import schema: -username is required, the meta nodes import the schema only for " +
+	"their admin user and only if auth-enabled is set")
+
+// client sends the requests to the admin api of a meta node
+type client struct {
+	host     string
+	username string
+	password string
+	ssl      bool
+	unsafe   bool
+}
+
+func (c *client) addFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.host, "host", "127.0.0.1:8091", "the http address of a meta node")
+	fs.StringVar(&c.username, "username", "", "the admin user if auth-enabled is set on the meta nodes")
+	fs.StringVar(&c.password, "password", "", "the password of the admin user")
+	fs.BoolVar(&c.ssl, "ssl", false, "use https to connect to the meta node")
+	fs.BoolVar(&c.unsafe, "unsafeSsl", false, "do not verify the certificate of the meta node")
+}
+
+type adminResponse struct {
+	Error  string `json:"error"`
+	Leader string `json:"leader"`
+}
+
+type importResponse struct {
+	SkippedUsers []struct {
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	} `json:"skippedUsers"`
+}
+
+// do sends the request to host, the body of a response with a status other than 200 is returned as the error
+func (c *client) do(method, host, query string, body []byte) ([]byte, *adminResponse, error) {
+	scheme := "http"
+	if c.ssl {
+		scheme = "https"
+	}
+	url := scheme + "://" + host + schemaPath
+	if query != "" {
+		url += "?" + query
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	hc := &http.Client{Timeout: time.Minute}
+	if c.unsafe {
+		hc.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return b, nil, nil
+	}
+
+	ar := &adminResponse{}
+	if json.Unmarshal(b, ar) != nil || ar.Error == "" {
+		ar.Error = resp.Status
+	}
+	return nil, ar, errors.New(ar.Error)
+}
+
+// ExportCommand writes the schema of a cluster as json.
+type ExportCommand struct {
+	Stdout io.Writer
+	Stderr io.Writer
+
+	client client
+	out    string
+	hashes bool
+}
+
+// NewExportCommand returns a new instance of ExportCommand.
+func NewExportCommand() *ExportCommand {
+	return &ExportCommand{Stdout: os.Stdout, Stderr: os.Stderr}
+}
+
+// Run executes the command.
+func (cmd *ExportCommand) Run(args ...string) error {
+	fs := flag.NewFlagSet("export-schema", flag.ContinueOnError)
+	fs.SetOutput(cmd.Stderr)
+	cmd.client.addFlags(fs)
+	fs.StringVar(&cmd.out, "out", "", "the file to write the schema to, the standard output if empty")
+	fs.BoolVar(&cmd.hashes, "hashes", false, "export the password hashes of the users, auth-enabled must be set on the meta nodes")
+	fs.Usage = func() {
+		fmt.Fprintln(cmd.Stderr, "Exports the databases, measurements and users of the cluster as json.\n"+
+			"The users are exported only if auth-enabled is set on the meta nodes.\n\nUsage: ts-meta export-schema [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query := ""
+	if cmd.hashes {
+		query = "hashes=true"
+	}
+	b, _, err := cmd.client.do(http.MethodGet, cmd.client.host, query, nil)
+	if err != nil {
+		return fmt.Errorf("export schema: %s", err)
+	}
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, b, "", "  "); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+
+	if cmd.out == "" {
+		_, err = cmd.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return ioutil.WriteFile(cmd.out, buf.Bytes(), 0640)
+}
+
+// ImportCommand creates the missing objects of a schema written by ExportCommand. Importing the same schema
+// again changes nothing, the pts of the new databases are placed on the data nodes of the target cluster.
+// The users without a password hash and the admin user of another cluster are skipped and reported.
+// The meta nodes change the cluster only for the admin user, so auth-enabled must be set on them.
+type ImportCommand struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	client client
+	file   string
+}
+
+// NewImportCommand returns a new instance of ImportCommand.
+func NewImportCommand() *ImportCommand {
+	return &ImportCommand{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+}
+
+// Run executes the command.
+func (cmd *ImportCommand) Run(args ...string) error {
+	fs := flag.NewFlagSet("import-schema", flag.ContinueOnError)
+	fs.SetOutput(cmd.Stderr)
+	cmd.client.addFlags(fs)
+	fs.StringVar(&cmd.file, "file", "", "the file to read the schema from, the standard input if empty")
+	fs.Usage = func() {
+		fmt.Fprintln(cmd.Stderr, "Creates the missing databases, measurements and users of an exported schema.\n"+
+			"auth-enabled must be set on the meta nodes, the schema is imported by their admin user.\n\nUsage: ts-meta import-schema -username <admin> -password <password> [flags]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if cmd.client.username == "" {
+		return errImportAdminRequired
+	}
+
+	var b []byte
+	var err error
+	if cmd.file == "" {
+		b, err = ioutil.ReadAll(cmd.Stdin)
+	} else {
+		b, err = ioutil.ReadFile(cmd.file)
+	}
+	if err != nil {
+		return err
+	}
+
+	resp, ar, err := cmd.client.do(http.MethodPost, cmd.client.host, "", b)
+	if err != nil && ar != nil && ar.Leader != "" {
+		// only the leader applies the schema
+		resp, _, err = cmd.client.do(http.MethodPost, ar.Leader, "", b)
+	}
+	if err != nil {
+		return fmt.Errorf("import schema: %s", err)
+	}
+
+	result := &importResponse{}
+	if err = json.Unmarshal(resp, result); err != nil {
+		return fmt.Errorf("import schema: %s", err)
+	}
+	for _, u := range result.SkippedUsers {
+		fmt.Fprintf(cmd.Stdout, "user %s skipped: %s\n", u.Name, u.Reason)
+	}
+	fmt.Fprintln(cmd.Stdout, "schema imported")
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportCommand(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok || u != "admin" || p != "Admin@123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"authentication failed"}`))
+			return
+		}
+		assert.Equal(t, schemaPath, r.URL.Path)
+		if r.URL.Query().Get("hashes") == "true" {
+			_, _ = w.Write([]byte(`{"version":1,"databases":[],"users":[{"name":"admin","hash":"hash0","admin":true}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"version":1,"databases":[{"name":"db0","retentionPolicies":[]}]}`))
+	}))
+	defer ts.Close()
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	cmd := NewExportCommand()
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	require.NoError(t, cmd.Run("-host", host, "-username", "admin", "-password", "Admin@123"))
+	assert.Contains(t, stdout.String(), "\n  \"databases\": [\n")
+
+	out := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, NewExportCommand().Run("-host", host, "-username", "admin", "-password", "Admin@123", "-out", out))
+	b, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, stdout.String(), string(b))
+
+	stdout.Reset()
+	require.NoError(t, cmd.Run("-host", host, "-username", "admin", "-password", "Admin@123", "-hashes"))
+	assert.Contains(t, stdout.String(), `"hash": "hash0"`)
+
+	err = NewExportCommand().Run("-host", host)
+	assert.EqualError(t, err, "export schema: authentication failed")
+}
+
+func TestImportCommand(t *testing.T) {
+	var imported []string
+	leader := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		imported = append(imported, string(b))
+		_, _ = w.Write([]byte(`{"ok":true,"skippedUsers":[{"name":"admin","reason":"the cluster has an admin user already"}]}`))
+	}))
+	defer leader.Close()
+	follower := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"ok":false,"error":"node is not the leader","leader":"` + strings.TrimPrefix(leader.URL, "http://") + `"}`))
+	}))
+	defer follower.Close()
+
+	schema := `{"version":1,"databases":[]}`
+	cmd := NewImportCommand()
+	cmd.Stdin = strings.NewReader(schema)
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	require.NoError(t, cmd.Run("-host", strings.TrimPrefix(follower.URL, "http://"), "-username", "admin", "-password", "Admin@123"))
+	assert.Equal(t, []string{schema}, imported)
+	assert.Equal(t, "user admin skipped: the cluster has an admin user already\nschema imported\n", stdout.String())
+
+	cmd = NewImportCommand()
+	cmd.Stdout = &bytes.Buffer{}
+	assert.Error(t, cmd.Run("-host", strings.TrimPrefix(leader.URL, "http://"), "-username", "admin", "-password", "Admin@123",
+		"-file", filepath.Join(t.TempDir(), "none.json")))
+
+	// nothing is sent without the admin user, the meta nodes refuse it
+	cmd = NewImportCommand()
+	cmd.Stdin = strings.NewReader(schema)
+	assert.Equal(t, errImportAdminRequired, cmd.Run("-host", strings.TrimPrefix(leader.URL, "http://")))
+	assert.Equal(t, 1, len(imported))
+}
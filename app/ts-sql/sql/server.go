@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,10 +41,13 @@ import (
 	stat "github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
 	"github.com/openGemini/openGemini/lib/syscontrol"
 	"github.com/openGemini/openGemini/lib/util"
+	"github.com/openGemini/openGemini/lib/workload"
 	coordinator2 "github.com/openGemini/openGemini/open_src/influx/coordinator"
 	"github.com/openGemini/openGemini/open_src/influx/httpd"
 	"github.com/openGemini/openGemini/open_src/influx/query"
 	"github.com/openGemini/openGemini/services/castor"
+	"github.com/openGemini/openGemini/services/replication"
+	"github.com/openGemini/openGemini/services/slowquery"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 )
@@ -72,7 +76,9 @@ type Server struct {
 
 	config *config.TSSql
 
-	castorService *castor.Service
+	castorService      *castor.Service
+	slowQueryService   *slowquery.Service
+	replicationService *replication.Service
 }
 
 // updateTLSConfig stores with into the tls config pointed at by into but only if with is not nil
@@ -157,15 +163,36 @@ func NewServer(conf config.Config, cmd *cobra.Command, logger *Logger.Logger) (a
 		RetentionPolicyLimit:    c.Coordinator.RetentionPolicyLimit,
 		StmtExecLogger:          Logger.NewLogger(errno.ModuleQueryEngine).With(zap.String("query", "StatementExecutor")),
 	}
+	if c.SlowQuery.Enabled {
+		s.slowQueryService = slowquery.NewService(c.SlowQuery)
+		s.slowQueryService.MetaClient = s.MetaClient
+		s.slowQueryService.PointsWriter = s.PointsWriter
+		s.slowQueryService.Hostname = c.HTTP.BindAddress
+		s.httpService.Handler.SlowQueryRecorder = s.slowQueryService
+
+		stmtExecutor := s.QueryExecutor.StatementExecutor.(*coordinator2.StatementExecutor)
+		stmtExecutor.SlowQueryDatabase = c.SlowQuery.Database
+		stmtExecutor.SlowQueryRetentionPolicy = c.SlowQuery.RetentionPolicy
+	}
+	if c.Replication.Enabled {
+		s.replicationService = replication.NewService(c.Replication)
+		s.PointsWriter.Replicator = s.replicationService
+
+		stmtExecutor := s.QueryExecutor.StatementExecutor.(*coordinator2.StatementExecutor)
+		stmtExecutor.ReplicationEnabled = true
+	}
 	s.QueryExecutor.TaskManager.QueryTimeout = time.Duration(c.Coordinator.QueryTimeout)
 	s.QueryExecutor.TaskManager.LogQueriesAfter = time.Duration(c.Coordinator.LogQueriesAfter)
 	s.QueryExecutor.TaskManager.MaxConcurrentQueries = c.Coordinator.MaxConcurrentQueries
 	s.httpService.Handler.QueryExecutor = s.QueryExecutor
 	s.httpService.Handler.ExtSysCtrl = s.TSDBStore
 
+	s.initDiagnostics()
 	s.initStatisticsPusher()
 	syscontrol.SetQueryParallel(int64(c.HTTP.ChunkReaderParallel))
 	executor.SetPipelineExecutorResourceManagerParas(int64(c.Common.MemoryLimitSize), time.Duration(c.Common.MemoryWaitTime))
+	executor.SetSpillParas(c.Common.SpillDir, int64(c.Common.SpillThreshold))
+	workload.Init(c.Workload, executor.GetPipelineExecutorMemoryLimit())
 
 	machine.InitMachineID(c.HTTP.BindAddress)
 
@@ -198,6 +225,14 @@ func (s *Server) Open() error {
 	s.PointsWriter.MetaClient = s.MetaClient
 	s.httpService.Handler.MetaClient = s.MetaClient
 
+	// the replication queue must be opened before the http service accepts writes
+	if s.replicationService != nil {
+		s.replicationService.MetaClient = s.MetaClient
+		if err := s.replicationService.Open(); err != nil {
+			return err
+		}
+	}
+
 	if err := s.httpService.Open(); err != nil {
 		return err
 	}
@@ -207,6 +242,13 @@ func (s *Server) Open() error {
 	if err := s.castorService.Open(); err != nil {
 		return err
 	}
+
+	if s.slowQueryService != nil {
+		if err := s.slowQueryService.Open(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -224,6 +266,14 @@ func (s *Server) Close() error {
 		util.MustClose(s.httpService)
 	}
 
+	if s.slowQueryService != nil {
+		util.MustClose(s.slowQueryService)
+	}
+
+	if s.replicationService != nil {
+		util.MustClose(s.replicationService)
+	}
+
 	if s.QueryExecutor != nil {
 		util.MustClose(s.QueryExecutor)
 	}
@@ -260,11 +310,23 @@ type Service interface {
 	Close() error
 }
 
-func (s *Server) initStatisticsPusher() {
-	if !s.config.Monitor.StoreEnabled {
-		return
+func (s *Server) initDiagnostics() {
+	appName := "ts-sql"
+	if app.IsSingle() {
+		appName = "ts-server"
 	}
+	tags := map[string]string{"hostname": s.config.HTTP.BindAddress, "app": appName}
+	statisticsPusher.InitDiagnostics(tags, statisticsPusher.BuildInfo(app.NewBuildInfo(s.cmd)), map[string]interface{}{
+		"http.bind-address":                  s.config.HTTP.BindAddress,
+		"http.auth-enabled":                  strconv.FormatBool(s.config.HTTP.AuthEnabled),
+		"coordinator.query-timeout":          time.Duration(s.config.Coordinator.QueryTimeout).String(),
+		"coordinator.max-concurrent-queries": int64(s.config.Coordinator.MaxConcurrentQueries),
+		"monitor.store-enabled":              strconv.FormatBool(s.config.Monitor.StoreEnabled),
+		"monitor.pushers":                    s.config.Monitor.Pushers,
+	})
+}
 
+func (s *Server) initStatisticsPusher() {
 	appName := "ts-sql"
 	if app.IsSingle() {
 		appName = "ts-server"
@@ -289,6 +351,7 @@ func (s *Server) initStatisticsPusher() {
 	stat.NewMetaStatistics().Init(globalTags)
 	stat.InitExecutorStatistics(globalTags)
 	stat.NewErrnoStat().Init(globalTags)
+	stat.NewReplicationStatistics().Init(globalTags)
 
 	s.statisticsPusher.Register(
 		stat.CollectHandlerStatistics,
@@ -299,5 +362,8 @@ func (s *Server) initStatisticsPusher() {
 		stat.CollectExecutorStatistics,
 		stat.NewErrnoStat().Collect,
 	)
+	if s.replicationService != nil {
+		s.statisticsPusher.Register(stat.NewReplicationStatistics().Collect)
+	}
 	s.statisticsPusher.Start()
 }
@@ -37,6 +37,7 @@ import (
 	"github.com/openGemini/openGemini/lib/netstorage"
 	"github.com/openGemini/openGemini/lib/statisticsPusher"
 	stat "github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+	"github.com/openGemini/openGemini/lib/workload"
 	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -101,6 +102,8 @@ func NewServer(c config.Config, cmd *cobra.Command, logger *Logger.Logger) (app.
 	s.node = node
 
 	executor.SetPipelineExecutorResourceManagerParas(int64(conf.Common.MemoryLimitSize), time.Duration(conf.Common.MemoryWaitTime))
+	executor.SetSpillParas(conf.Common.SpillDir, int64(conf.Common.SpillThreshold))
+	workload.Init(conf.Workload, executor.GetPipelineExecutorMemoryLimit())
 
 	return s, nil
 }
@@ -126,6 +129,8 @@ func (s *Server) Open() error {
 	storageNodeInfo := metaclient.StorageNodeInfo{
 		InsertAddr: s.config.Data.InsertAddr(),
 		SelectAddr: s.config.Data.SelectAddr(),
+		Zone:       s.config.Data.Zone,
+		Rack:       s.config.Data.Rack,
 	}
 	_ = metaclient.NewClient(s.metaPath, false, 20)
 	commHttpHandler := httpserver.NewHandler(s.config.HTTPD.AuthEnabled, "")
@@ -149,6 +154,7 @@ func (s *Server) Open() error {
 
 	log.Info("start verify status")
 	go s.storage.ReportLoad()
+	go s.storage.ReportDiskStatus()
 
 	fmt.Printf("successfully opened storage %q in %.3f seconds\n", s.storageDataPath, time.Since(startTime).Seconds())
 
@@ -165,6 +171,7 @@ func (s *Server) Open() error {
 		conf := s.config.Gossip.BuildSerf(s.config.Logging, config.AppStore, strconv.Itoa(int(nid)), nil)
 		s.serfInstance, err = app.CreateSerfInstance(conf, clock, s.config.Gossip.Members, nil)
 	}
+	s.initDiagnostics()
 	s.initStatisticsPusher()
 	return err
 }
@@ -191,11 +198,24 @@ func (s *Server) Close() error {
 	return nil
 }
 
-func (s *Server) initStatisticsPusher() {
-	if !s.config.Monitor.StoreEnabled {
-		return
+func (s *Server) initDiagnostics() {
+	appName := "ts-store"
+	if app.IsSingle() {
+		appName = "ts-server"
 	}
+	tags := map[string]string{"hostname": s.selectAddr, "app": appName}
+	statisticsPusher.InitDiagnostics(tags, statisticsPusher.BuildInfo(app.NewBuildInfo(s.cmd)), map[string]interface{}{
+		"data.store-ingest-addr": s.ingestAddr,
+		"data.store-select-addr": s.selectAddr,
+		"data.store-data-dir":    s.config.Data.DataDir,
+		"data.store-wal-dir":     s.config.Data.WALDir,
+		"data.store-meta-dir":    s.config.Data.MetaDir,
+		"monitor.store-enabled":  strconv.FormatBool(s.config.Monitor.StoreEnabled),
+		"monitor.pushers":        s.config.Monitor.Pushers,
+	})
+}
 
+func (s *Server) initStatisticsPusher() {
 	appName := "ts-store"
 	if app.IsSingle() {
 		appName = "ts-server"
@@ -222,6 +242,7 @@ func (s *Server) initStatisticsPusher() {
 	stat.InitExecutorStatistics(globalTags)
 	stat.InitFileStatistics(globalTags)
 	stat.NewErrnoStat().Init(globalTags)
+	stat.NewWalStatistics().Init(globalTags)
 
 	s.statisticsPusher.Register(
 		stat.CollectPerfStatistics,
@@ -234,7 +255,16 @@ func (s *Server) initStatisticsPusher() {
 		stat.NewCompactStatistics().Collect,
 		stat.CollectEngineStatStatistics,
 		stat.CollectExecutorStatistics,
-		s.storage.GetEngine().Statistics,
-		stat.NewErrnoStat().Collect)
+		s.collectEngineStatistics,
+		stat.NewErrnoStat().Collect,
+		stat.NewWalStatistics().Collect)
 	s.statisticsPusher.Start()
 }
+
+// collectEngineStatistics collects the statistics of the engine once the storage is open
+func (s *Server) collectEngineStatistics(buffer []byte) ([]byte, error) {
+	if s.storage == nil {
+		return buffer, nil
+	}
+	return s.storage.GetEngine().Statistics(buffer)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"go.uber.org/zap"
+)
+
+// ptMigration is the fetching of a pt moved to the node, meta polls its state
+type ptMigration struct {
+	state   netstorage.MigratePtState
+	fetched int64
+	err     error
+}
+
+func migrationKey(db string, pt uint32) string {
+	return fmt.Sprintf("%s/%d", db, pt)
+}
+
+// MigratePt executes a step of moving a pt from one node to another, it returns the state of the fetching
+// and the number of bytes fetched. The fetching runs in the background, the state is polled by meta.
+func (s *Storage) MigratePt(req *netstorage.MigratePtRequest) (netstorage.MigratePtState, int64, error) {
+	db, pt := req.GetDb(), req.GetPt()
+	s.log.Info("migrate pt", zap.String("db", db), zap.Uint32("pt", pt),
+		zap.Int32("phase", req.GetPhase()), zap.Uint64("src", req.GetSrc()))
+
+	switch req.GetMigratePhase() {
+	case netstorage.MigrateFetch, netstorage.MigrateFetchFinal:
+		return s.startFetchPt(db, pt, req.GetSrc(), req.GetMigratePhase() == netstorage.MigrateFetchFinal)
+	case netstorage.MigrateStatus:
+		return s.fetchPtStatus(db, pt)
+	case netstorage.MigrateOffload:
+		return netstorage.MigrateDone, 0, s.engine.OffloadPt(db, pt)
+	case netstorage.MigrateLoad:
+		return netstorage.MigrateDone, 0, s.engine.LoadPt(db, pt, s.metaClient.PtDurationInfos(pt))
+	case netstorage.MigrateDrop:
+		s.migrateMu.Lock()
+		m := s.migrations[migrationKey(db, pt)]
+		if m != nil && m.state == netstorage.MigrateRunning {
+			s.migrateMu.Unlock()
+			return netstorage.MigrateRunning, 0, fmt.Errorf("pt %s is being fetched", migrationKey(db, pt))
+		}
+		delete(s.migrations, migrationKey(db, pt))
+		s.migrateMu.Unlock()
+		return netstorage.MigrateDone, 0, s.engine.DropPt(db, pt)
+	default:
+		return netstorage.MigrateIdle, 0, fmt.Errorf("unknown migrate phase %d", req.GetPhase())
+	}
+}
+
+func (s *Storage) startFetchPt(db string, pt uint32, src uint64, final bool) (netstorage.MigratePtState, int64, error) {
+	key := migrationKey(db, pt)
+	s.migrateMu.Lock()
+	defer s.migrateMu.Unlock()
+	if m := s.migrations[key]; m != nil && m.state == netstorage.MigrateRunning {
+		return m.state, atomic.LoadInt64(&m.fetched), nil
+	}
+
+	m := &ptMigration{state: netstorage.MigrateRunning}
+	s.migrations[key] = m
+	go func() {
+		err := s.engine.FetchPt(db, pt, netstorage.NewPtFileSource(s.netStore, src), final, &m.fetched)
+		if err == nil && final {
+			err = s.engine.LoadPt(db, pt, s.metaClient.PtDurationInfos(pt))
+			if err == nil {
+				s.metaClient.AddPt(pt)
+			}
+		}
+
+		s.migrateMu.Lock()
+		defer s.migrateMu.Unlock()
+		m.state, m.err = netstorage.MigrateDone, err
+		if err != nil {
+			m.state = netstorage.MigrateFailed
+			s.log.Error("fetch pt failed", zap.String("db", db), zap.Uint32("pt", pt), zap.Error(err))
+		}
+	}()
+	return m.state, 0, nil
+}
+
+func (s *Storage) fetchPtStatus(db string, pt uint32) (netstorage.MigratePtState, int64, error) {
+	s.migrateMu.Lock()
+	defer s.migrateMu.Unlock()
+	m := s.migrations[migrationKey(db, pt)]
+	if m == nil {
+		return netstorage.MigrateIdle, 0, nil
+	}
+	return m.state, atomic.LoadInt64(&m.fetched), m.err
+}
+
+func (s *Storage) PtFiles(db string, pt uint32) ([]netstorage.PtFile, error) {
+	return s.engine.PtFiles(db, pt)
+}
+
+func (s *Storage) ReadPtFile(db string, pt uint32, name string, offset int64, size int) ([]byte, error) {
+	return s.engine.ReadPtFile(db, pt, name, offset, size)
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -34,8 +35,10 @@ import (
 	"github.com/openGemini/openGemini/lib/logger"
 	"github.com/openGemini/openGemini/lib/metaclient"
 	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/lib/statisticsPusher"
 	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
 	"github.com/openGemini/openGemini/lib/stringinterner"
+	"github.com/openGemini/openGemini/lib/syscontrol"
 	"github.com/openGemini/openGemini/lib/util"
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 	"github.com/openGemini/openGemini/open_src/influx/meta"
@@ -64,7 +67,10 @@ type StoreEngine interface {
 	SeriesKeys(string, []uint32, []string, influxql.Expr) ([]string, error)
 	TagValues(string, []uint32, map[string][][]byte, influxql.Expr) (netstorage.TablesTagSets, error)
 	TagValuesCardinality(string, []uint32, map[string][][]byte, influxql.Expr) (map[string]uint64, error)
-	SendSysCtrlOnNode(*netstorage.SysCtrlRequest) error
+	SendSysCtrlOnNode(*netstorage.SysCtrlRequest) (map[string]string, error)
+	MigratePt(*netstorage.MigratePtRequest) (netstorage.MigratePtState, int64, error)
+	PtFiles(string, uint32) ([]netstorage.PtFile, error)
+	ReadPtFile(string, uint32, string, int64, int) ([]byte, error)
 }
 
 type Storage struct {
@@ -81,8 +87,15 @@ type Storage struct {
 
 	log     *logger.Logger
 	loadCtx *metaclient.LoadCtx
+	// the newest disk status of the engine to report to meta
+	diskStatus chan meta.DiskStatus
 
 	WriteLimit limiter.Fixed
+
+	// the pts being moved to the node, they are fetched from the nodes they are moved from
+	migrateMu  sync.Mutex
+	migrations map[string]*ptMigration
+	netStore   netstorage.Storage
 }
 
 func (s *Storage) GetPath() string {
@@ -150,10 +163,47 @@ func OpenStorage(path string, node *metaclient.Node, cli *metaclient.Client, con
 	opt.CacheMetaBlock = conf.Data.CacheMetaBlock
 	opt.EnableMmapRead = conf.Data.EnableMmapRead
 	opt.ReadCacheLimit = conf.Data.ReadCacheLimit
+	opt.DataDirs = conf.Data.DataDirs
+	opt.WalDirs = conf.Data.WALDirs
 	opt.WalSyncInterval = time.Duration(conf.Data.WalSyncInterval)
 	opt.WalEnabled = conf.Data.WalEnabled
 	opt.WalReplayParallel = conf.Data.WalReplayParallel
+	opt.WalSyncMode = conf.Data.WalSyncMode
+	opt.WalRecordCrc = conf.Data.WalRecordCrc
+	opt.WalSyncModes = conf.Data.WalSyncModes
 	opt.CompactionMethod = conf.Data.CompactionMethod
+	opt.LastValueCache = conf.Data.LastValueCache
+	opt.FloatCodec = conf.Data.FloatCodec
+	opt.StringDictionary = conf.Data.StringDictionary
+	opt.MeasurementFloatCodec = func(db, rp, mst string) (string, bool) {
+		msti, err := cli.Measurement(db, rp, mst)
+		if err != nil {
+			return "", false
+		}
+		return msti.FloatCodec, true
+	}
+	opt.MeasurementTTL = func(db, rp, mst string) time.Duration {
+		msti, err := cli.Measurement(db, rp, mst)
+		if err != nil {
+			return 0
+		}
+		return msti.TTL
+	}
+	opt.DiskLowWatermark = conf.Data.DiskLowWatermark
+	opt.DiskHighWatermark = conf.Data.DiskHighWatermark
+	opt.DiskFloodWatermark = conf.Data.DiskFloodWatermark
+	opt.DiskCheckInterval = time.Duration(conf.Data.DiskCheckInterval)
+	opt.EncryptionKeyDir = conf.Data.EncryptionKeyDir
+	opt.EncryptionKeyID = conf.Data.EncryptionKeyID
+	diskStatus := make(chan meta.DiskStatus, 1)
+	opt.DiskStatusChanged = func(status meta.DiskStatus) {
+		// only the newest status is kept
+		select {
+		case <-diskStatus:
+		default:
+		}
+		diskStatus <- status
+	}
 
 	eng, err := newEngineFn(conf.Data.DataDir, conf.Data.WALDir, opt, &loadCtx)
 	if err != nil {
@@ -171,7 +221,10 @@ func OpenStorage(path string, node *metaclient.Node, cli *metaclient.Client, con
 		engine:     eng,
 		stop:       make(chan struct{}),
 		loadCtx:    &loadCtx,
+		diskStatus: diskStatus,
 		WriteLimit: limiter.NewFixed(conf.Data.WriteConcurrentLimit),
+		migrations: make(map[string]*ptMigration),
+		netStore:   netstorage.NewNetStorage(cli),
 	}
 
 	s.log = logger.NewLogger(errno.ModuleStorageEngine)
@@ -270,6 +323,29 @@ func (s *Storage) ReportLoad() {
 	}
 }
 
+// ReportDiskStatus reports the disk status of the node to meta, so that the new
+// pts are not assigned to it when the disk is above the high watermark
+func (s *Storage) ReportDiskStatus() {
+	var status meta.DiskStatus
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-s.stop:
+			return
+		case status = <-s.diskStatus:
+		case <-retry:
+		}
+
+		retry = nil
+		if err := s.metaClient.UpdateNodeDiskStatus(status); err != nil {
+			s.log.Warn("report disk status failed", zap.Stringer("status", status), zap.Error(err))
+			retry = time.After(time.Second)
+			continue
+		}
+		s.log.Info("report disk status", zap.Stringer("status", status))
+	}
+}
+
 func (s *Storage) MustClose() {
 	// Close services to allow any inflight requests to complete
 	// and prevent new requests from being accepted.
@@ -335,8 +411,19 @@ func (s *Storage) SeriesCardinality(db string, ptIDs []uint32, measurements []st
 	return s.engine.SeriesCardinality(db, ptIDs, ms, condition)
 }
 
-func (s *Storage) SendSysCtrlOnNode(req *netstorage.SysCtrlRequest) error {
-	return s.engine.SysCtrl(req)
+func (s *Storage) SendSysCtrlOnNode(req *netstorage.SysCtrlRequest) (map[string]string, error) {
+	switch req.Mod() {
+	case syscontrol.Stats:
+		buf, err := statisticsPusher.CollectStatistics(nil)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{syscontrol.Stats: string(buf)}, nil
+	case syscontrol.Diagnostics:
+		return map[string]string{syscontrol.Diagnostics: string(statisticsPusher.CollectDiagnostics(nil))}, nil
+	default:
+		return s.engine.SysCtrl(req)
+	}
 }
 
 func (s *Storage) SeriesExactCardinality(db string, ptIDs []uint32, measurements []string, condition influxql.Expr) (map[string]uint64, error) {
@@ -42,6 +42,12 @@ func newHandler(typ uint8) RPCHandler {
 		return &GetShardSplitPoints{}
 	case netstorage.DeleteRequestMessage:
 		return &Delete{}
+	case netstorage.MigratePtRequestMessage:
+		return &MigratePt{}
+	case netstorage.PtFilesRequestMessage:
+		return &PtFiles{}
+	case netstorage.ReadPtFileRequestMessage:
+		return &ReadPtFile{}
 	case netstorage.CreateDataBaseRequestMessage:
 		return &CreateDataBase{}
 	default:
@@ -168,6 +174,57 @@ func (h *Delete) SetMessage(msg codec.BinaryCodec) error {
 	return nil
 }
 
+type MigratePt struct {
+	BaseHandler
+
+	req *netstorage.MigratePtRequest
+	rsp *netstorage.MigratePtResponse
+}
+
+func (h *MigratePt) SetMessage(msg codec.BinaryCodec) error {
+	h.rsp = &netstorage.MigratePtResponse{}
+	req, ok := msg.(*netstorage.MigratePtRequest)
+	if !ok {
+		return executor.NewInvalidTypeError("*netstorage.MigratePtRequest", msg)
+	}
+	h.req = req
+	return nil
+}
+
+type PtFiles struct {
+	BaseHandler
+
+	req *netstorage.PtFilesRequest
+	rsp *netstorage.PtFilesResponse
+}
+
+func (h *PtFiles) SetMessage(msg codec.BinaryCodec) error {
+	h.rsp = &netstorage.PtFilesResponse{}
+	req, ok := msg.(*netstorage.PtFilesRequest)
+	if !ok {
+		return executor.NewInvalidTypeError("*netstorage.PtFilesRequest", msg)
+	}
+	h.req = req
+	return nil
+}
+
+type ReadPtFile struct {
+	BaseHandler
+
+	req *netstorage.ReadPtFileRequest
+	rsp *netstorage.ReadPtFileResponse
+}
+
+func (h *ReadPtFile) SetMessage(msg codec.BinaryCodec) error {
+	h.rsp = &netstorage.ReadPtFileResponse{}
+	req, ok := msg.(*netstorage.ReadPtFileRequest)
+	if !ok {
+		return executor.NewInvalidTypeError("*netstorage.ReadPtFileRequest", msg)
+	}
+	h.req = req
+	return nil
+}
+
 type CreateDataBase struct {
 	BaseHandler
 
@@ -33,6 +33,34 @@ func (h *Delete) Process() (codec.BinaryCodec, error) {
 	return h.rsp, nil
 }
 
+func (h *MigratePt) Process() (codec.BinaryCodec, error) {
+	state, fetched, err := h.store.MigratePt(h.req)
+	h.rsp.State = proto.Int32(int32(state))
+	h.rsp.Bytes = proto.Int64(fetched)
+	if err != nil {
+		h.rsp.Err = proto.String(err.Error())
+	}
+	return h.rsp, nil
+}
+
+func (h *PtFiles) Process() (codec.BinaryCodec, error) {
+	files, err := h.store.PtFiles(h.req.GetDb(), h.req.GetPt())
+	h.rsp.SetPtFiles(files)
+	if err != nil {
+		h.rsp.Err = proto.String(err.Error())
+	}
+	return h.rsp, nil
+}
+
+func (h *ReadPtFile) Process() (codec.BinaryCodec, error) {
+	var err error
+	h.rsp.Data, err = h.store.ReadPtFile(h.req.GetDb(), h.req.GetPt(), h.req.GetName(), h.req.GetOffset(), int(h.req.GetLength()))
+	if err != nil {
+		h.rsp.Err = proto.String(err.Error())
+	}
+	return h.rsp, nil
+}
+
 func (h *GetShardSplitPoints) Process() (codec.BinaryCodec, error) {
 	logger.GetLogger().Info("GetShardSplitPoints",
 		zap.Any("db", h.req.GetDB()),
@@ -79,8 +79,8 @@ func (s *MockStoreEngine) TagValuesCardinality(db string, ptIDs []uint32, tagKey
 	return nil, nil
 }
 
-func (s *MockStoreEngine) SendSysCtrlOnNode(req *netstorage.SysCtrlRequest) error {
-	return nil
+func (s *MockStoreEngine) SendSysCtrlOnNode(req *netstorage.SysCtrlRequest) (map[string]string, error) {
+	return nil, nil
 }
 
 type DummySeriesTransform struct {
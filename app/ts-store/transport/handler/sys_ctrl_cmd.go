@@ -32,13 +32,15 @@ type SysCtrlCmd struct {
 
 func (h *SysCtrlCmd) Process() error {
 	rsp := &netstorage.SysCtrlResponse{}
-	ret := make(map[string]string)
 
 	logger.GetLogger().Info("SysCtrlRequestMessage", zap.String("cmd", h.req.Mod()))
-	err := h.store.SendSysCtrlOnNode(h.req)
+	ret, err := h.store.SendSysCtrlOnNode(h.req)
 	if err != nil {
 		rsp.SetErr(err.Error())
 	}
+	if ret == nil {
+		ret = make(map[string]string)
+	}
 	rsp.SetResult(ret)
 	return h.w.Response(rsp, true)
 }
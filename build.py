@@ -29,6 +29,7 @@ targets = {
     'ts-server' : './app/ts-server',
     'ts-monitor' : './app/ts-monitor',
     'ts-cli' : './app/ts-cli',
+    'ts-inspect' : './app/ts-inspect',
 }
 
 supported_builds = {
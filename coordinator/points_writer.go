@@ -54,7 +54,7 @@ type PointsWriter struct {
 		DBPtView(database string) (meta2.DBPtInfos, error)
 		Measurement(database string, rpName string, mstName string) (*meta2.MeasurementInfo, error)
 		UpdateSchema(database string, retentionPolicy string, mst string, fieldToCreate []*proto2.FieldSchema) error
-		CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation) (*meta2.MeasurementInfo, error)
+		CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation, floatCodec string) (*meta2.MeasurementInfo, error)
 		GetAliveShards(database string, sgi *meta2.ShardGroupInfo) []int
 	}
 
@@ -62,6 +62,11 @@ type PointsWriter struct {
 		WriteRows(nodeID uint64, database, rp string, pt uint32, shard uint64, rows *[]influx.Row, timeout time.Duration) error
 	}
 
+	// Replicator queues the accepted rows to be replicated to a remote cluster, it is nil if replication is disabled
+	Replicator interface {
+		Replicate(database, retentionPolicy string, rows []influx.Row) error
+	}
+
 	logger *logger.Logger
 }
 
@@ -239,6 +244,53 @@ func (w *PointsWriter) updateSchemaIfNeeded(database, rp string, r *influx.Row,
 }
 
 func (w *PointsWriter) WritePointRows(database, retentionPolicy string, rows []influx.Row) error {
+	if w.Replicator == nil {
+		return w.writePointRows(database, retentionPolicy, rows, nil)
+	}
+	var dropped []int
+	err := w.writePointRows(database, retentionPolicy, rows, &dropped)
+	if _, ok := err.(netstorage.PartialWriteError); err == nil || ok {
+		if rerr := w.replicate(database, retentionPolicy, acceptedRows(rows, dropped)); err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+// WriteReplicatedPointRows writes the rows replicated from another cluster, they are not replicated again.
+func (w *PointsWriter) WriteReplicatedPointRows(database, retentionPolicy string, rows []influx.Row) error {
+	return w.writePointRows(database, retentionPolicy, rows, nil)
+}
+
+// acceptedRows returns the rows except the dropped ones, the indexes of which are in ascending order
+func acceptedRows(rows []influx.Row, dropped []int) []influx.Row {
+	if len(dropped) == 0 {
+		return rows
+	}
+	accepted := make([]influx.Row, 0, len(rows)-len(dropped))
+	for i := range rows {
+		if len(dropped) > 0 && dropped[0] == i {
+			dropped = dropped[1:]
+			continue
+		}
+		accepted = append(accepted, rows[i])
+	}
+	return accepted
+}
+
+func (w *PointsWriter) replicate(database, retentionPolicy string, rows []influx.Row) error {
+	if retentionPolicy == "" {
+		db, err := w.MetaClient.Database(database)
+		if err != nil {
+			return err
+		}
+		retentionPolicy = db.DefaultRetentionPolicy
+	}
+	return w.Replicator.Replicate(database, retentionPolicy, rows)
+}
+
+// writePointRows writes the rows, the indexes of the dropped rows are appended to droppedRows if it is not nil
+func (w *PointsWriter) writePointRows(database, retentionPolicy string, rows []influx.Row, droppedRows *[]int) error {
 	// check db and rp validation
 	db, err := w.MetaClient.Database(database)
 	if err != nil {
@@ -267,6 +319,12 @@ func (w *PointsWriter) WritePointRows(database, retentionPolicy string, rows []i
 	isDropRow := false
 	var partialErr error
 	var dropped int
+	drop := func(i int) {
+		dropped++
+		if droppedRows != nil {
+			*droppedRows = append(*droppedRows, i)
+		}
+	}
 
 	//validate, map and push point to bach transport buffer
 	for i := range rows {
@@ -275,6 +333,9 @@ func (w *PointsWriter) WritePointRows(database, retentionPolicy string, rows []i
 
 		if err := checkFields(r.Fields); err != nil {
 			partialErr = err
+			if droppedRows != nil {
+				*droppedRows = append(*droppedRows, i)
+			}
 			continue
 		}
 
@@ -283,7 +344,7 @@ func (w *PointsWriter) WritePointRows(database, retentionPolicy string, rows []i
 			errInfo := errno.NewError(errno.WritePointOutOfRP)
 			w.logger.Error("write failed", zap.Error(errInfo))
 			partialErr = errInfo
-			dropped++
+			drop(i)
 			continue
 		}
 
@@ -291,7 +352,7 @@ func (w *PointsWriter) WritePointRows(database, retentionPolicy string, rows []i
 		mst, err := w.MetaClient.Measurement(database, retentionPolicy, r.Name)
 		if err == meta2.ErrMeasurementNotFound {
 			ski := &meta2.ShardKeyInfo{ShardKey: nil, Type: influxql.HASH}
-			mst, err = w.MetaClient.CreateMeasurement(database, retentionPolicy, r.Name, ski, nil)
+			mst, err = w.MetaClient.CreateMeasurement(database, retentionPolicy, r.Name, ski, nil, "")
 			if err != nil {
 				return err
 			}
@@ -304,7 +365,7 @@ func (w *PointsWriter) WritePointRows(database, retentionPolicy string, rows []i
 			if strings.Contains(err.Error(), "field type conflict") {
 				partialErr = err
 				if isDropRow {
-					dropped++
+					drop(i)
 					continue
 				}
 			} else {
@@ -338,14 +399,14 @@ func (w *PointsWriter) WritePointRows(database, retentionPolicy string, rows []i
 				return err
 			}
 			partialErr = err
-			dropped++
+			drop(i)
 			continue
 		}
 
 		if len(r.ShardKey) > MaxShardKey {
 			partialErr = errno.NewError(errno.WritePointShardKeyTooLarge)
 			w.logger.Error("write failed", zap.Error(partialErr))
-			dropped++
+			drop(i)
 			continue
 		}
 
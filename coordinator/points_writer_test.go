@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/netstorage"
 	meta2 "github.com/openGemini/openGemini/open_src/influx/meta"
 	proto2 "github.com/openGemini/openGemini/open_src/influx/meta/proto"
 	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
@@ -39,7 +40,7 @@ type MockMetaClient struct {
 	DBPtViewFn          func(database string) (meta2.DBPtInfos, error)
 	MeasurementFn       func(database string, rpName string, mstName string) (*meta2.MeasurementInfo, error)
 	UpdateSchemaFn      func(database string, retentionPolicy string, mst string, fieldToCreate []*proto2.FieldSchema) error
-	CreateMeasurementFn func(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation) (*meta2.MeasurementInfo, error)
+	CreateMeasurementFn func(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation, floatCodec string) (*meta2.MeasurementInfo, error)
 	GetAliveShardsFn    func(database string, sgi *meta2.ShardGroupInfo) []int
 }
 
@@ -67,8 +68,8 @@ func (mmc *MockMetaClient) UpdateSchema(database string, retentionPolicy string,
 	return mmc.UpdateSchemaFn(database, retentionPolicy, mst, fieldToCreate)
 }
 
-func (mmc *MockMetaClient) CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation) (*meta2.MeasurementInfo, error) {
-	return mmc.CreateMeasurementFn(database, retentionPolicy, mst, shardKey, indexR)
+func (mmc *MockMetaClient) CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation, floatCodec string) (*meta2.MeasurementInfo, error) {
+	return mmc.CreateMeasurementFn(database, retentionPolicy, mst, shardKey, indexR, floatCodec)
 }
 
 func (mmc *MockMetaClient) GetAliveShards(database string, sgi *meta2.ShardGroupInfo) []int {
@@ -96,7 +97,7 @@ func NewMockMetaClient() *MockMetaClient {
 	}
 
 	msti := NewMeasurement("mst0")
-	mc.CreateMeasurementFn = func(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation) (*meta2.MeasurementInfo, error) {
+	mc.CreateMeasurementFn = func(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation, floatCodec string) (*meta2.MeasurementInfo, error) {
 		return msti, nil
 	}
 	mc.MeasurementFn = func(database string, rpName string, mstName string) (*meta2.MeasurementInfo, error) {
@@ -184,6 +185,59 @@ func TestPointsWriter_WritePointRows(t *testing.T) {
 	}
 }
 
+type mockReplicator struct {
+	rps  []string
+	rows int
+	err  error
+}
+
+func (r *mockReplicator) Replicate(database, retentionPolicy string, rows []influx.Row) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.rps = append(r.rps, database+"."+retentionPolicy)
+	r.rows += len(rows)
+	return nil
+}
+
+func TestPointsWriter_Replicate(t *testing.T) {
+	replicator := &mockReplicator{}
+	store := NewMockNetStore()
+	pw := NewPointsWriter(time.Second)
+	pw.MetaClient = NewMockMetaClient()
+	pw.TSDBStore = store
+	pw.Replicator = replicator
+
+	rows := generateRows()
+	assert.NoError(t, pw.WritePointRows("db0", "rp0", rows))
+	assert.Equal(t, []string{"db0.rp0"}, replicator.rps)
+	assert.Equal(t, len(rows), replicator.rows)
+
+	// the rows replicated from another cluster are not replicated again
+	assert.NoError(t, pw.WriteReplicatedPointRows("db0", "rp0", generateRows()))
+	assert.Equal(t, 1, len(replicator.rps))
+
+	// the write fails if the rows can not be queued for replication, so that the client retries it
+	replicator.err = fmt.Errorf("replication queue is full")
+	assert.EqualError(t, pw.WritePointRows("db0", "rp0", generateRows()), "replication queue is full")
+	replicator.err = nil
+
+	// only the accepted rows of a partial write are replicated
+	rows = generateRows()
+	rows[0].Timestamp = time.Now().Add(-2 * time.Hour).UnixNano()
+	replicator.rows = 0
+	_, ok := pw.WritePointRows("db0", "rp0", rows).(netstorage.PartialWriteError)
+	assert.True(t, ok)
+	assert.Equal(t, len(rows)-1, replicator.rows)
+
+	// the rows are not replicated if the write fails
+	store.WriteRowsFn = func(nodeID uint64, database, rp string, pt uint32, shard uint64, rows *[]influx.Row, timeout time.Duration) error {
+		return fmt.Errorf("write failed")
+	}
+	assert.Error(t, pw.WritePointRows("db0", "rp0", generateRows()))
+	assert.Equal(t, 2, len(replicator.rps))
+}
+
 func TestPointsWriter_updateSchemaIfNeeded(t *testing.T) {
 	mi := &meta2.MeasurementInfo{
 		Name:      "mst",
@@ -334,9 +334,10 @@ func (csm *ClusterShardMapping) MapTypeBatch(m *influxql.Measurement, fields map
 }
 
 func (csm *ClusterShardMapping) CreateLogicalPlan(ctx context.Context, sources influxql.Sources, schema hybridqp.Catalog) (hybridqp.QueryNode, error) {
+	var qDuration *statistics.SQLSlowQueryStatistics
 	ctxValue := ctx.Value(query.QueryDurationKey)
 	if ctxValue != nil {
-		qDuration := ctxValue.(*statistics.SQLSlowQueryStatistics)
+		qDuration = ctxValue.(*statistics.SQLSlowQueryStatistics)
 		if qDuration != nil {
 			schema.Options().(*query.ProcessorOptions).Query = qDuration.Query
 			start := time.Now()
@@ -378,6 +379,7 @@ func (csm *ClusterShardMapping) CreateLogicalPlan(ctx context.Context, sources i
 				srcs = append(srcs, clone)
 			}
 			for pId, sIds := range shardIDsByDBPT {
+				qDuration.AddShards(len(sIds))
 				nodeID := ptView[pId].Owner.NodeID
 				if _, ok := shardsMapByNode[nodeID]; !ok {
 					shardIDsByPtID := make(map[uint32][]uint64)
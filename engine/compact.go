@@ -17,6 +17,7 @@ limitations under the License.
 package engine
 
 import (
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -49,6 +50,9 @@ type Compactor struct {
 	mu sync.RWMutex
 	wg sync.WaitGroup
 
+	// the compactions and merges are paused if not 0, the expired files are still dropped
+	paused int32
+
 	sources                  map[uint64]*shard
 	compactShards            []*shard
 	outOfOrderMergeNumberMin int
@@ -132,6 +136,11 @@ func (c *Compactor) compact() {
 			if !sh.immTables.CompactionEnabled() {
 				return
 			}
+			sh.immTables.DropExpiredFiles(id)
+			if c.Paused() {
+				// dropping the expired files frees disk space, the compactions need extra space
+				continue
+			}
 			nowTime := fasttime.UnixTimestamp()
 			lastWrite := sh.LastWriteTime()
 			d := nowTime - lastWrite
@@ -156,11 +165,31 @@ func (c *Compactor) run() {
 	tm := time.NewTicker(time.Second * 30)
 	defer tm.Stop()
 	for range tm.C {
-		c.merger()
+		if !c.Paused() {
+			c.merger()
+		}
 		c.compact()
 	}
 }
 
+// Pause stops starting new compactions and merges, which write the new files before the old ones
+// are removed. The expired files are still dropped, and the running compactions are not interrupted
+func (c *Compactor) Pause() {
+	if atomic.CompareAndSwapInt32(&c.paused, 0, 1) {
+		log.Info("compaction paused")
+	}
+}
+
+func (c *Compactor) Resume() {
+	if atomic.CompareAndSwapInt32(&c.paused, 1, 0) {
+		log.Info("compaction resumed")
+	}
+}
+
+func (c *Compactor) Paused() bool {
+	return atomic.LoadInt32(&c.paused) != 0
+}
+
 func (c *Compactor) ShardCompactionSwitch(shid uint64, en bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -228,6 +257,54 @@ func (c *Compactor) SetSnapshotColdDuration(d time.Duration) {
 	}
 }
 
+func (c *Compactor) matchShards(match func(sh *shard) bool) []*shard {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	shards := make([]*shard, 0, len(c.sources))
+	for _, sh := range c.sources {
+		if match == nil || match(sh) {
+			shards = append(shards, sh)
+		}
+	}
+	return shards
+}
+
+// CompactShards schedules the compactions and merges of the measurement, or of all measurements if mst is empty,
+// on the matched shards. The compactions run in the background, the number of matched shards is returned.
+func (c *Compactor) CompactShards(match func(sh *shard) bool, mst string, full bool) int {
+	shards := c.matchShards(match)
+	for _, sh := range shards {
+		go func(sh *shard) {
+			id := sh.GetID()
+			if err := sh.immTables.Compact(id, mst, full); err != nil {
+				log.Error("manual compact error", zap.Uint64("shid", id), zap.String("mst", mst), zap.Error(err))
+			}
+		}(sh)
+	}
+	return len(shards)
+}
+
+// CancelCompactions cancels the queued and running compactions and merges of the matched shards
+func (c *Compactor) CancelCompactions(match func(sh *shard) bool, mst string) int {
+	n := 0
+	for _, sh := range c.matchShards(match) {
+		n += sh.immTables.CancelCompactions(mst)
+	}
+	return n
+}
+
+func (c *Compactor) CompactionTasks() []immutable.CompactionTask {
+	var tasks []immutable.CompactionTask
+	for _, sh := range c.matchShards(nil) {
+		tasks = append(tasks, sh.immTables.CompactionTasks()...)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID < tasks[j].ID
+	})
+	return tasks
+}
+
 func (c *Compactor) statOutOfOrderFiles() {
 	total := 0
 	c.mu.RLock()
@@ -0,0 +1,207 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/lib/sysinfo"
+	meta2 "github.com/openGemini/openGemini/open_src/influx/meta"
+	"go.uber.org/zap"
+)
+
+// the status before the first check, so that the first status is always reported
+const diskStatusUnknown = meta2.DiskStatus(-1)
+
+// diskUsagePercent returns the used percent of the disk of the path
+var diskUsagePercent = func(path string) (float64, error) {
+	total, avail, err := sysinfo.DiskUsage(path)
+	if err != nil || total == 0 {
+		return 0, err
+	}
+	return float64(total-avail) * 100 / float64(total), nil
+}
+
+// diskWatermark checks the usage of the data and WAL disks against the watermarks.
+// The node accepts no new shards above the high watermark, and is readonly above the
+// flood watermark until the usage falls below the high watermark again
+type diskWatermark struct {
+	low, high, flood float64
+	interval         time.Duration
+
+	mu     sync.RWMutex
+	status meta2.DiskStatus
+	// the usage and the path of the fullest disk
+	usage float64
+	path  string
+}
+
+func newDiskWatermark(opt netstorage.EngineOptions) *diskWatermark {
+	if opt.DiskCheckInterval <= 0 {
+		return nil
+	}
+	return &diskWatermark{
+		low:      opt.DiskLowWatermark,
+		high:     opt.DiskHighWatermark,
+		flood:    opt.DiskFloodWatermark,
+		interval: opt.DiskCheckInterval,
+		status:   diskStatusUnknown,
+	}
+}
+
+// level returns the status of the usage, the flood status is kept until the usage is below high,
+// and the high status until the usage is below low
+func (w *diskWatermark) level(usage float64, cur meta2.DiskStatus) meta2.DiskStatus {
+	switch {
+	case usage >= w.flood:
+		return meta2.DiskFlood
+	case usage >= w.high:
+		if cur == meta2.DiskFlood {
+			return meta2.DiskFlood
+		}
+		return meta2.DiskHigh
+	case usage >= w.low:
+		if cur >= meta2.DiskHigh {
+			return meta2.DiskHigh
+		}
+		return meta2.DiskLow
+	default:
+		return meta2.DiskNormal
+	}
+}
+
+// check updates the status with the fullest disk of the paths, and returns the previous one
+func (w *diskWatermark) check(paths []string) (meta2.DiskStatus, meta2.DiskStatus) {
+	usage, fullest := -1.0, ""
+	for _, p := range paths {
+		u, err := diskUsagePercent(p)
+		if err != nil {
+			log.Warn("get disk usage failed", zap.String("path", p), zap.Error(err))
+			continue
+		}
+		if u > usage {
+			usage, fullest = u, p
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.status
+	if usage < 0 {
+		return prev, prev
+	}
+	w.usage, w.path = usage, fullest
+	w.status = w.level(usage, prev)
+	return w.status, prev
+}
+
+func (w *diskWatermark) Status() meta2.DiskStatus {
+	if w == nil {
+		return meta2.DiskNormal
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.status == diskStatusUnknown {
+		return meta2.DiskNormal
+	}
+	return w.status
+}
+
+// checkWrite returns an error if the node is above the flood watermark
+func (w *diskWatermark) checkWrite() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.status == meta2.DiskFlood {
+		return errno.NewError(errno.ErrDiskFlood, w.usage, w.path)
+	}
+	return nil
+}
+
+// checkCreateShard returns an error if the node is above the high watermark
+func (w *diskWatermark) checkCreateShard() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.status >= meta2.DiskHigh {
+		return errno.NewError(errno.ErrDiskHighWatermark, w.usage, w.path)
+	}
+	return nil
+}
+
+func (e *Engine) diskPaths() []string {
+	if e.dirs == nil {
+		return []string{e.dataPath, e.walPath}
+	}
+	return append(storeDirRoots(e.dirs.data), storeDirRoots(e.dirs.wal)...)
+}
+
+func (e *Engine) startDiskWatermark() {
+	if e.watermark == nil {
+		return
+	}
+	e.checkDiskWatermark()
+	go func() {
+		tm := time.NewTicker(e.watermark.interval)
+		defer tm.Stop()
+		for {
+			select {
+			case <-e.closed.Signal():
+				return
+			case <-tm.C:
+				e.checkDiskWatermark()
+			}
+		}
+	}()
+}
+
+// checkDiskWatermark pauses the compactions and merges above the flood watermark, they need
+// extra space before the old files are removed, and reports the changed status
+func (e *Engine) checkDiskWatermark() {
+	status, prev := e.watermark.check(e.diskPaths())
+	if status == prev {
+		return
+	}
+
+	w := e.watermark
+	w.mu.RLock()
+	usage, path := w.usage, w.path
+	w.mu.RUnlock()
+	fields := []zap.Field{zap.String("path", path), zap.Float64("usage", usage),
+		zap.Stringer("from", prev), zap.Stringer("to", status)}
+	if status >= meta2.DiskHigh {
+		e.log.Warn("disk status changed", fields...)
+	} else {
+		e.log.Info("disk status changed", fields...)
+	}
+
+	if status == meta2.DiskFlood {
+		compWorker.Pause()
+	} else if prev == meta2.DiskFlood {
+		compWorker.Resume()
+	}
+	if e.engOpt.DiskStatusChanged != nil {
+		e.engOpt.DiskStatusChanged(status)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_DiskWatermark(t *testing.T) {
+	usage := 50.0
+	defer func(fn func(string) (float64, error)) {
+		diskUsagePercent = fn
+	}(diskUsagePercent)
+	diskUsagePercent = func(string) (float64, error) {
+		return usage, nil
+	}
+
+	e := newMultiDirsEngine(t.TempDir())
+	defer e.closed.Close()
+	var reported []meta.DiskStatus
+	e.engOpt.DiskLowWatermark, e.engOpt.DiskHighWatermark, e.engOpt.DiskFloodWatermark = 85, 90, 95
+	e.engOpt.DiskCheckInterval = time.Hour
+	e.engOpt.DiskStatusChanged = func(status meta.DiskStatus) {
+		reported = append(reported, status)
+	}
+	e.watermark = newDiskWatermark(e.engOpt)
+	require.NoError(t, e.openStoreDirs())
+	e.startDiskWatermark()
+	require.Equal(t, []meta.DiskStatus{meta.DiskNormal}, reported)
+
+	steps := []struct {
+		usage  float64
+		status meta.DiskStatus
+	}{
+		{92, meta.DiskHigh},
+		{97, meta.DiskFlood},
+		// the flood status is kept until the usage is below the high watermark
+		{92, meta.DiskFlood},
+		// the high status is kept until the usage is below low
+		{87, meta.DiskHigh},
+		{80, meta.DiskNormal},
+		{87, meta.DiskLow},
+	}
+	for _, step := range steps {
+		usage = step.usage
+		e.checkDiskWatermark()
+		require.Equal(t, step.status, e.watermark.Status(), "usage %v", usage)
+		require.Equal(t, step.status, reported[len(reported)-1])
+
+		err := e.CreateShard(defaultDb, defaultRp, defaultPtId, 1, getTimeRangeInfoOfShard(1))
+		require.Equal(t, step.status >= meta.DiskHigh, errno.Equal(err, errno.ErrDiskHighWatermark), "usage %v", usage)
+		err = e.WriteRows(defaultDb, defaultRp, defaultPtId, 1, nil, nil)
+		require.Equal(t, step.status == meta.DiskFlood, errno.Equal(err, errno.ErrDiskFlood), "usage %v", usage)
+		require.Equal(t, step.status == meta.DiskFlood, compWorker.Paused())
+
+		req := &netstorage.SysCtrlRequest{}
+		req.SetMod(compact)
+		req.SetParam(map[string]string{"shid": "1000"})
+		err = e.processReq(req)
+		require.Equal(t, step.status == meta.DiskFlood, errno.Equal(err, errno.ErrDiskFlood), "usage %v", usage)
+	}
+	require.Equal(t, []meta.DiskStatus{meta.DiskNormal, meta.DiskHigh, meta.DiskFlood, meta.DiskHigh,
+		meta.DiskNormal, meta.DiskLow}, reported)
+}
+
+func TestDiskWatermark_Level(t *testing.T) {
+	w := &diskWatermark{low: 85, high: 90, flood: 95}
+	cases := []struct {
+		usage float64
+		cur   meta.DiskStatus
+		want  meta.DiskStatus
+	}{
+		{96, diskStatusUnknown, meta.DiskFlood},
+		{95, meta.DiskNormal, meta.DiskFlood},
+		{92, meta.DiskFlood, meta.DiskFlood},
+		{90, meta.DiskFlood, meta.DiskFlood},
+		{89.9, meta.DiskFlood, meta.DiskHigh},
+		{92, meta.DiskNormal, meta.DiskHigh},
+		{87, meta.DiskHigh, meta.DiskHigh},
+		{87, meta.DiskNormal, meta.DiskLow},
+		{80, meta.DiskFlood, meta.DiskNormal},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.want, w.level(c.usage, c.cur), "usage %v from %v", c.usage, c.cur)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/fileops"
+	"github.com/stretchr/testify/require"
+)
+
+func setEncryptionKey(t *testing.T, dir, id string) {
+	key := hex.EncodeToString([]byte(id + "-0123456789abcdef")[:16])
+	require.NoError(t, os.WriteFile(filepath.Join(dir, id+fileops.EncryptionKeySuffix), []byte(key), 0600))
+	provider, err := fileops.NewFileKeyProvider(dir, "")
+	require.NoError(t, err)
+	fileops.SetKeyProvider(provider)
+}
+
+func TestShard_EncryptionAtRest(t *testing.T) {
+	defer fileops.SetKeyProvider(nil)
+	keyDir := t.TempDir()
+	setEncryptionKey(t, keyDir, "k1")
+
+	testDir := t.TempDir()
+	sh, err := createShard(defaultDb, defaultRp, defaultPtId, testDir)
+	require.NoError(t, err)
+	rows, _, _ := GenDataRecord([]string{defaultMeasurementName}, 10, 100, time.Second, time.Now(), false, true, false)
+	require.NoError(t, writeData(sh, rows, true))
+	require.NoError(t, writeData(sh, rows[:1], false))
+	require.NoError(t, closeShard(sh))
+
+	checkEncrypted := func(pattern string) {
+		files, err := filepath.Glob(filepath.Join(testDir, pattern))
+		require.NoError(t, err)
+		require.NotEmpty(t, files, pattern)
+		for _, name := range files {
+			fd, err := fileops.Open(name)
+			require.NoError(t, err)
+			fd, err = fileops.DecryptFile(fd)
+			require.NoError(t, err)
+			require.Equal(t, "k1", fileops.EncryptionKeyID(fd), name)
+			require.NoError(t, fd.Close())
+		}
+	}
+	checkEncrypted("data/tssp/" + defaultMeasurementName + "/*.tssp")
+	checkEncrypted("wal/*/*.wal")
+
+	// the parts of the mergeset index hold the series keys
+	indexParts := 0
+	require.NoError(t, filepath.Walk(filepath.Join(testDir, defaultDb, "index"), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.Name() != "metadata.json" {
+			return err
+		}
+		for _, name := range []string{"metaindex.bin", "index.bin", "items.bin", "lens.bin", "metadata.json"} {
+			fd, err := fileops.Open(filepath.Join(filepath.Dir(path), name))
+			require.NoError(t, err)
+			fd, err = fileops.DecryptFile(fd)
+			require.NoError(t, err)
+			require.Equal(t, "k1", fileops.EncryptionKeyID(fd), fd.Name())
+			require.NoError(t, fd.Close())
+		}
+		indexParts++
+		return nil
+	}))
+	require.NotZero(t, indexParts)
+
+	// the shard can not be opened without its key
+	otherDir := t.TempDir()
+	setEncryptionKey(t, otherDir, "k2")
+	_, err = createShard(defaultDb, defaultRp, defaultPtId, testDir)
+	require.True(t, errno.Equal(err, errno.EncryptionKeyNotFound), "%v", err)
+	require.Contains(t, err.Error(), `"k1"`)
+
+	setEncryptionKey(t, keyDir, "k2")
+	sh, err = createShard(defaultDb, defaultRp, defaultPtId, testDir)
+	require.NoError(t, err)
+	defer closeShard(sh)
+
+	files := sh.immTables.GetFilesRef(defaultMeasurementName, true)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		fi, err := immutable.OpenFileInspector(f.Path())
+		require.NoError(t, err)
+		report, err := fi.Verify()
+		require.NoError(t, err)
+		require.Empty(t, report.Errors)
+		require.Equal(t, 10, report.Chunks)
+		require.Equal(t, int64(1000), report.Rows)
+		require.NoError(t, fi.Close())
+	}
+	immutable.UnrefFiles(files...)
+
+	// new files are encrypted with the current key
+	require.NoError(t, writeData(sh, rows, true))
+	keys := make(map[string]int)
+	files = append(sh.immTables.GetFilesRef(defaultMeasurementName, true), sh.immTables.GetFilesRef(defaultMeasurementName, false)...)
+	for _, f := range files {
+		fd, err := fileops.Open(f.Path())
+		require.NoError(t, err)
+		fd, err = fileops.DecryptFile(fd)
+		require.NoError(t, err)
+		keys[fileops.EncryptionKeyID(fd)]++
+		require.NoError(t, fd.Close())
+	}
+	immutable.UnrefFiles(files...)
+	require.Equal(t, 1, keys["k1"])
+	require.NotZero(t, keys["k2"])
+}
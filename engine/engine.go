@@ -67,8 +67,11 @@ type Engine struct {
 	closed   *interruptsignal.InterruptSignal
 	dataPath string
 	walPath  string
+	dirs     *storeDirs
 	ReadOnly bool
 
+	watermark *diskWatermark
+
 	engOpt       netstorage.EngineOptions
 	DBPartitions map[string]map[uint32]*DBPTInfo
 
@@ -112,6 +115,8 @@ func NewEngine(dataPath, walPath string, options netstorage.EngineOptions, ctx *
 		closed:       interruptsignal.NewInterruptSignal(),
 		dataPath:     dataPath,
 		walPath:      walPath,
+		dirs:         newStoreDirs(dataPath, walPath, options),
+		watermark:    newDiskWatermark(options),
 		engOpt:       options,
 		DBPartitions: make(map[string]map[uint32]*DBPTInfo, 64),
 		loadCtx:      ctx,
@@ -132,8 +137,18 @@ func NewEngine(dataPath, walPath string, options netstorage.EngineOptions, ctx *
 	immutable.SetCompactLimit(options.CompactThroughput, options.CompactThroughputBurst)
 	immutable.SetSnapshotLimit(options.SnapshotThroughput, options.SnapshotThroughputBurst)
 	immutable.SegMergeFlag(int32(options.CompactionMethod))
+	immutable.SetFloatCodec(options.FloatCodec)
+	immutable.SetStringDictionary(options.StringDictionary)
 	immutable.Init()
 
+	if options.EncryptionKeyDir != "" {
+		provider, err := fileops.NewFileKeyProvider(options.EncryptionKeyDir, options.EncryptionKeyID)
+		if err != nil {
+			return nil, err
+		}
+		fileops.SetKeyProvider(provider)
+	}
+
 	return eng, nil
 }
 
@@ -158,6 +173,12 @@ func (e *Engine) Open(ptIds []uint32, durationInfos map[uint64]*meta2.ShardDurat
 		return err
 	}
 
+	if err := e.openStoreDirs(); err != nil {
+		atomic.AddInt64(&stat.EngineStat.OpenErrors, 1)
+		return err
+	}
+	e.startDiskWatermark()
+
 	err := e.loadShards(ptIds, durationInfos)
 	if err != nil {
 		atomic.AddInt64(&stat.EngineStat.OpenErrors, 1)
@@ -167,6 +188,19 @@ func (e *Engine) Open(ptIds []uint32, durationInfos map[uint64]*meta2.ShardDurat
 	return nil
 }
 
+func (e *Engine) openStoreDirs() error {
+	if e.dirs == nil {
+		return nil
+	}
+	if err := e.dirs.open(); err != nil {
+		return err
+	}
+	if err := e.dirs.recoverMoves(); err != nil {
+		return err
+	}
+	return e.dirs.rebalance()
+}
+
 func (e *Engine) loadShards(ptIds []uint32, durationInfos map[uint64]*meta2.ShardDurationInfo) error {
 	dataPath := path.Join(e.dataPath, DataDirectory)
 	_, err := fileops.Stat(dataPath)
@@ -396,6 +430,8 @@ func (e *Engine) DeleteShard(db string, ptId uint32, shardID uint64) error {
 		return err
 	}
 
+	e.dirs.release(sh.DataPath(), sh.WalPath())
+
 	lock := fileops.FileLockOption("")
 	// remove shard's wal&data on-disk, index data will not delete right now
 	if err := fileops.RemoveAll(sh.DataPath(), lock); err != nil {
@@ -563,10 +599,13 @@ func (e *Engine) checkReadonly() error {
 	if e.ReadOnly {
 		return errno.NewError(errno.ErrWriteReadonly)
 	}
-	return nil
+	return e.watermark.checkWrite()
 }
 
 func (e *Engine) CreateShard(db, rp string, ptId uint32, shardID uint64, timeRangeInfo *meta2.ShardTimeRangeInfo) error {
+	if err := e.watermark.checkCreateShard(); err != nil {
+		return err
+	}
 	e.mu.RLock()
 	if err := e.checkAndAddRefPTNoLock(db, ptId); err != nil {
 		e.mu.RUnlock()
@@ -648,6 +687,7 @@ func (e *Engine) CreateDBPT(db string, pt uint32) {
 	ptPath := path.Join(e.dataPath, DataDirectory, db, strconv.Itoa(int(pt)))
 	walPath := path.Join(e.walPath, WalDirectory, db, strconv.Itoa(int(pt)))
 	dbPTInfo := NewDBPTInfo(db, pt, ptPath, walPath, e.loadCtx)
+	dbPTInfo.dirs = e.dirs
 	e.addDBPTInfo(dbPTInfo)
 	dbPTInfo.SetOption(e.engOpt)
 	dbPTInfo.enableReportShardLoad()
@@ -745,7 +785,10 @@ func (e *Engine) DeleteDatabase(db string, ptId uint32) (err error) {
 		e.mu.RUnlock()
 		dataPath := path.Join(e.dataPath, DataDirectory, db, strconv.Itoa(int(ptId)))
 		walPath := path.Join(e.walPath, WalDirectory, db, strconv.Itoa(int(ptId)))
-		return deleteDataAndWalPath(dataPath, walPath)
+		if err := deleteDataAndWalPath(dataPath, walPath); err != nil {
+			return err
+		}
+		return e.dirs.removeAll(path.Join(db, strconv.Itoa(int(ptId))))
 	}
 
 	for ptId, dbPTInfo := range dbInfo {
@@ -773,7 +816,11 @@ func (e *Engine) DeleteDatabase(db string, ptId uint32) (err error) {
 		return err
 	}
 	for ptId := range dbInfo {
-		if err = deleteDataAndWalPath(dbInfo[ptId].path, dbInfo[ptId].walPath); err != nil {
+		err = deleteDataAndWalPath(dbInfo[ptId].path, dbInfo[ptId].walPath)
+		if err == nil {
+			err = e.dirs.removeAll(path.Join(db, strconv.Itoa(int(ptId))))
+		}
+		if err != nil {
 			e.unMarkOffloadInLock(db)
 			e.mu.RUnlock()
 			atomic.AddInt64(&stat.EngineStat.DropDatabaseErrs, 1)
@@ -821,6 +868,7 @@ func (e *Engine) deleteShardsAndIndexes(db string) error {
 				dbPTInfo.mu.Unlock()
 				return err
 			}
+			e.dirs.release(shard.DataPath(), shard.WalPath())
 			delete(dbPTInfo.shards, id)
 		}
 
@@ -867,7 +915,11 @@ func (e *Engine) DropRetentionPolicy(db string, rp string, ptId uint32) error {
 		e.mu.RUnlock()
 		dataPath := path.Join(e.dataPath, DataDirectory, db, strconv.Itoa(int(ptId)), rp)
 		walPath := path.Join(e.walPath, WalDirectory, db, strconv.Itoa(int(ptId)), rp)
-		if err := deleteDataAndWalPath(dataPath, walPath); err != nil {
+		err := deleteDataAndWalPath(dataPath, walPath)
+		if err == nil {
+			err = e.dirs.removeAll(path.Join(db, strconv.Itoa(int(ptId)), rp))
+		}
+		if err != nil {
 			atomic.AddInt64(&stat.EngineStat.DropRPErrs, 1)
 			return err
 		}
@@ -900,6 +952,7 @@ func (e *Engine) DropRetentionPolicy(db string, rp string, ptId uint32) error {
 		if err := sh.Close(); err != nil {
 			return err
 		}
+		e.dirs.release(sh.DataPath(), sh.WalPath())
 
 		dbPTInfo.mu.Lock()
 		delete(dbPTInfo.indexBuilder, indexID)
@@ -915,7 +968,11 @@ func (e *Engine) DropRetentionPolicy(db string, rp string, ptId uint32) error {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 	for pt := range e.DBPartitions[db] {
-		if err = deleteDataAndWalPath(filepath.Join(e.DBPartitions[db][pt].path, rp), filepath.Join(e.DBPartitions[db][pt].walPath, rp)); err != nil {
+		err = deleteDataAndWalPath(filepath.Join(e.DBPartitions[db][pt].path, rp), filepath.Join(e.DBPartitions[db][pt].walPath, rp))
+		if err == nil {
+			err = e.dirs.removeAll(path.Join(db, strconv.Itoa(int(pt)), rp))
+		}
+		if err != nil {
 			atomic.AddInt64(&stat.EngineStat.DropRPErrs, 1)
 			return err
 		}
@@ -1420,8 +1477,11 @@ func (e *Engine) unrefDBPTNoLock(database string, ptID uint32) {
 	dbPT[ptID].unref()
 }
 
-func (e *Engine) SysCtrl(req *netstorage.SysCtrlRequest) error {
-	return e.processReq(req)
+func (e *Engine) SysCtrl(req *netstorage.SysCtrlRequest) (map[string]string, error) {
+	if req.Mod() == compactions {
+		return compactionsResult()
+	}
+	return nil, e.processReq(req)
 }
 
 func (e *Engine) Statistics(buffer []byte) ([]byte, error) {
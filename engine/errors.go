@@ -27,4 +27,7 @@ var (
 	ErrShardClosed   = fmt.Errorf("shard closed")
 	ErrIndexNotFound = fmt.Errorf("index not found")
 	ErrInvalidDir    = fmt.Errorf("shard or index dir not valid")
+	ErrPTOpened      = fmt.Errorf("partition is opened")
+	ErrInvalidPtFile = fmt.Errorf("invalid partition file name")
+	ErrInvalidPtRead = fmt.Errorf("invalid partition file read")
 )
@@ -593,3 +593,34 @@ func TestGetInnerDimensions(t *testing.T) {
 	result := getInnerDimensions(out, in)
 	assert.Equal(t, []string{"A", "B", "D", "C"}, result)
 }
+
+func TestManageMemResource_Spill(t *testing.T) {
+	defer func() {
+		mem, _ := memory.SysMem()
+		pipelineExecutorResourceManager.SetManagerParas(mem, time.Second)
+		SetSpillParas("", 0)
+	}()
+	pipelineExecutorResourceManager.Reset()
+	pipelineExecutorResourceManager.SetManagerParas(2000, 10*time.Millisecond)
+	SetSpillParas(t.TempDir(), 1000)
+
+	// no transform of the plan spills, the memory limit still applies
+	exec := PipelineExecutorGen()
+	err := pipelineExecutorResourceManager.ManageMemResource(exec)
+	assert.EqualError(t, err, errno.NewError(errno.BucketLacks).Error())
+
+	// ORDER BY over GROUP BY time spills, the query is admitted with the spill threshold
+	exec = PipelineExecutorGen()
+	opt := query.ProcessorOptions{
+		Interval:   hybridqp.Interval{Duration: 10 * time.Nanosecond},
+		Dimensions: []string{"host"},
+		Ascending:  true,
+		ChunkSize:  100,
+	}
+	orderBy := NewOrderByTransform(buildRowDataType(), buildRowDataType(), nil, opt, []string{"host"})
+	assert.True(t, orderBy.Spillable())
+	exec.processors = append(exec.processors, orderBy)
+	assert.NoError(t, pipelineExecutorResourceManager.ManageMemResource(exec))
+	assert.Equal(t, int64(1000), exec.info.MemoryOccupation)
+	pipelineExecutorResourceManager.ReleaseMem(exec)
+}
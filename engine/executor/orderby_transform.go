@@ -87,6 +87,8 @@ type OrderByTransform struct {
 	transferHelper  func()
 	CoProcessor     CoProcessor
 	heapItems       *heapOrderByItems
+	sorter          *ChunkSorter
+	spillErr        error
 }
 
 func NewOrderByTransform(inRowDataType hybridqp.RowDataType, outRowDataType hybridqp.RowDataType, ops []hybridqp.ExprOptions, opt query.ProcessorOptions, dimensions []string) *OrderByTransform {
@@ -105,6 +107,9 @@ func NewOrderByTransform(inRowDataType hybridqp.RowDataType, outRowDataType hybr
 	}
 	if opt.Interval.IsZero() {
 		trans.transferHelper = trans.transferFast
+	} else if dir, threshold := GetSpillParas(); threshold > 0 {
+		trans.sorter = NewChunkSorter(outRowDataType, dir, threshold, opt.ChunkSize, dimensions, opt.Ascending, opt.Window)
+		trans.transferHelper = trans.transferSpill
 	} else {
 		trans.transferHelper = trans.transferGroupByTime
 		trans.ResultChunkPool = NewCircularChunkPool(CircularChunkNum, NewChunkBuilder(outRowDataType))
@@ -114,6 +119,11 @@ func NewOrderByTransform(inRowDataType hybridqp.RowDataType, outRowDataType hybr
 	return trans
 }
 
+// Spillable returns whether the transform spills its input, only ORDER BY over GROUP BY time does
+func (trans *OrderByTransform) Spillable() bool {
+	return trans.sorter != nil
+}
+
 type OrderByTransformCreator struct {
 }
 
@@ -179,7 +189,7 @@ func (trans *OrderByTransform) Work(ctx context.Context) error {
 	go runnable()
 	trans.transferHelper()
 	wg.Wait()
-	return nil
+	return trans.spillErr
 }
 
 func (trans *OrderByTransform) closeChunkChannel() {
@@ -258,6 +268,28 @@ func (trans *OrderByTransform) transferGroupByTime() {
 	}
 }
 
+// transferSpill sorts the whole input by group and time window with a ChunkSorter,
+// which writes sorted runs to disk once the buffered chunks exceed the spill threshold.
+func (trans *OrderByTransform) transferSpill() {
+	defer trans.sorter.Close()
+	for chunk := range trans.currChunk {
+		// keep draining the input after an error so that the reading goroutine can exit
+		if trans.spillErr == nil {
+			trans.spillErr = trans.sorter.Add(chunk)
+		}
+	}
+	if trans.spillErr != nil {
+		return
+	}
+
+	trans.spillErr = trans.sorter.Finish(func(c Chunk) error {
+		trans.resultChunk = c
+		trans.IntervalIndexReGen()
+		trans.SendChunk()
+		return nil
+	})
+}
+
 func (trans *OrderByTransform) TagAndTagIndexHandler() {
 	if len(trans.resultChunk.Tags()) == 0 ||
 		!bytes.Equal(trans.resultChunk.Tags()[len(trans.resultChunk.Tags())-1].Subset(trans.dimensions), trans.currTags[0].Subset(trans.dimensions)) {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/openGemini/openGemini/engine/executor/spdy"
 	"github.com/openGemini/openGemini/engine/hybridqp"
@@ -58,6 +59,10 @@ type PipelineExecutor struct {
 
 	info *PipelineExecutorInfo
 
+	// stageDurations is the time each kind of processor took to finish its work, in nanoseconds
+	stageMu        sync.Mutex
+	stageDurations map[string]int64
+
 	RunTimeStats  *statistics.StatisticTimer
 	WaitTimeStats *statistics.StatisticTimer
 }
@@ -172,6 +177,42 @@ func (exec *PipelineExecutor) Release() {
 	}
 }
 
+// addStageDuration keeps the longest duration of the processors with the same name,
+// the processors of a stage run in parallel.
+func (exec *PipelineExecutor) addStageDuration(name string, d time.Duration) {
+	exec.stageMu.Lock()
+	defer exec.stageMu.Unlock()
+
+	if exec.stageDurations == nil {
+		exec.stageDurations = make(map[string]int64)
+	}
+	if d.Nanoseconds() > exec.stageDurations[name] {
+		exec.stageDurations[name] = d.Nanoseconds()
+	}
+}
+
+// StageDurations returns the time each stage of the pipeline took in nanoseconds, keyed by the processor name.
+func (exec *PipelineExecutor) StageDurations() map[string]int64 {
+	exec.stageMu.Lock()
+	defer exec.stageMu.Unlock()
+
+	durations := make(map[string]int64, len(exec.stageDurations))
+	for name, d := range exec.stageDurations {
+		durations[name] = d
+	}
+	return durations
+}
+
+// spillable returns whether a transform of the pipeline spills its input to disk
+func (exec *PipelineExecutor) spillable() bool {
+	for _, p := range exec.processors {
+		if s, ok := p.(SpillableTransform); ok && s.Spillable() {
+			return true
+		}
+	}
+	return false
+}
+
 func (exec *PipelineExecutor) ExecuteExecutor(ctx context.Context) error {
 	if err := pipelineExecutorResourceManager.ManageMemResource(exec); err != nil {
 		statistics.ExecutorStat.ExecTimeout.Increase()
@@ -226,6 +267,7 @@ func (exec *PipelineExecutor) Execute(ctx context.Context) error {
 	for _, p := range exec.processors {
 		work := func(processor Processor) {
 			var err error
+			begin := time.Now()
 
 			defer func() {
 				if e := recover(); e != nil {
@@ -245,6 +287,7 @@ func (exec *PipelineExecutor) Execute(ctx context.Context) error {
 						zap.Bool("crashed", exec.crashed))
 				}
 
+				exec.addStageDuration(processor.Name(), time.Since(begin))
 				processor.FinishSpan()
 				wg.Done()
 			}()
@@ -23,6 +23,7 @@ import (
 	"github.com/openGemini/openGemini/lib/bucket"
 	"github.com/openGemini/openGemini/lib/memory"
 	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+	"github.com/openGemini/openGemini/lib/workload"
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 )
 
@@ -58,9 +59,21 @@ func (p *PipelineExecutorManager) ManageMemResource(exec *PipelineExecutor) erro
 	defer exec.WaitTimeStats.End()
 
 	MemoryEstimator(exec)
+	if _, threshold := GetSpillParas(); threshold > 0 && exec.info.MemoryOccupation > p.memBucket.GetTotalResource() && exec.spillable() {
+		// the transform holding its whole input spills to disk once it exceeds the threshold,
+		// so the query is admitted with the spill budget instead of being rejected
+		exec.info.MemoryOccupation = threshold
+		if total := p.memBucket.GetTotalResource(); threshold > total {
+			exec.info.MemoryOccupation = total
+		}
+	}
 	if e := p.memBucket.GetResource(exec.info.MemoryOccupation); e != nil {
 		return e
 	}
+	if e := workloadGroup(exec).AcquireMemory(exec.info.MemoryOccupation); e != nil {
+		p.memBucket.ReleaseResource(exec.info.MemoryOccupation)
+		return e
+	}
 	defer p.UpdateAccumulator(exec)
 	return nil
 }
@@ -86,6 +99,11 @@ func SetPipelineExecutorResourceManagerParas(TotalRes int64, timeout time.Durati
 	pipelineExecutorResourceManager.SetManagerParas(TotalRes, timeout)
 }
 
+// GetPipelineExecutorMemoryLimit returns the memory which all pipeline executors may use
+func GetPipelineExecutorMemoryLimit() int64 {
+	return pipelineExecutorResourceManager.memBucket.GetTotalResource()
+}
+
 func (p *PipelineExecutorManager) Reset() {
 	p.memBucket.Reset()
 }
@@ -96,9 +114,14 @@ func (p *PipelineExecutorManager) UpdateAccumulator(exec *PipelineExecutor) {
 }
 
 func (p *PipelineExecutorManager) ReleaseMem(exec *PipelineExecutor) {
+	workloadGroup(exec).ReleaseMemory(exec.info.MemoryOccupation)
 	p.memBucket.ReleaseResource(exec.info.MemoryOccupation)
 }
 
+func workloadGroup(exec *PipelineExecutor) *workload.Group {
+	return workload.GetManager().Group(exec.root.node.Schema().Options().GetWorkloadGroup())
+}
+
 func MemoryEstimator(exec *PipelineExecutor) {
 	var memCost int64
 	var visit func(v *TransformVertex)
@@ -235,6 +235,16 @@ func TestExecutePipeline(t *testing.T) {
 	executor := executor.NewPipelineExecutor(processors)
 	executor.Execute(context.Background())
 	executor.Release()
+
+	stages := executor.StageDurations()
+	if len(stages) != 2 {
+		t.Fatalf("expect the durations of 2 stages, got %v", stages)
+	}
+	for _, p := range processors {
+		if _, ok := stages[p.Name()]; !ok {
+			t.Errorf("no duration of the stage %s", p.Name())
+		}
+	}
 }
 
 func TestAbortPipeline(t *testing.T) {
@@ -0,0 +1,181 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdy
+
+import (
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/pierrec/lz4/v4"
+)
+
+// compression algorithm id, carried in the SYN/ACK flags so that both peers agree on one algorithm per session
+const (
+	CompressNone uint16 = iota
+	CompressSnappy
+	CompressZstd
+	CompressLz4
+	compressEnd
+)
+
+const (
+	compressAlgoShift        = 8
+	compressAlgoMask  uint16 = 0x0f << compressAlgoShift
+
+	// compressed frame: [raw length: 4 bytes][compressed data]
+	compressedHeaderSize = 4
+)
+
+var compressNames = map[string]uint16{
+	"":                        CompressNone,
+	config.SpdyCompressNone:   CompressNone,
+	config.SpdyCompressSnappy: CompressSnappy,
+	config.SpdyCompressZstd:   CompressZstd,
+	config.SpdyCompressLz4:    CompressLz4,
+}
+
+func CompressAlgorithm(name string) uint16 {
+	return compressNames[name]
+}
+
+func encodeCompressAlgo(flags uint16, algo uint16) uint16 {
+	return flags | (algo<<compressAlgoShift)&compressAlgoMask
+}
+
+func decodeCompressAlgo(flags uint16) uint16 {
+	return (flags & compressAlgoMask) >> compressAlgoShift
+}
+
+// Compressor compresses and decompresses the payload of data frames.
+// Both methods append the result to dst.
+type Compressor interface {
+	Compress(dst []byte, src []byte) []byte
+	Decompress(dst []byte, src []byte, rawLen int) ([]byte, error)
+}
+
+func NewCompressor(algo uint16) Compressor {
+	switch algo {
+	case CompressSnappy:
+		return snappyCompressor{}
+	case CompressZstd:
+		return zstdCompressor{}
+	case CompressLz4:
+		return lz4Compressor{}
+	default:
+		return nil
+	}
+}
+
+func grow(dst []byte, n int) []byte {
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+	b := make([]byte, len(dst), len(dst)+n)
+	copy(b, dst)
+	return b
+}
+
+type snappyCompressor struct{}
+
+func (snappyCompressor) Compress(dst []byte, src []byte) []byte {
+	n := len(dst)
+	dst = grow(dst, snappy.MaxEncodedLen(len(src)))
+	enc := snappy.Encode(dst[n:cap(dst)], src)
+	return dst[:n+len(enc)]
+}
+
+func (snappyCompressor) Decompress(dst []byte, src []byte, rawLen int) ([]byte, error) {
+	n := len(dst)
+	dst = grow(dst, rawLen)
+	dec, err := snappy.Decode(dst[n:n+rawLen], src)
+	if err != nil {
+		return dst, err
+	}
+	return dst[:n+len(dec)], nil
+}
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+type zstdCompressor struct{}
+
+func (zstdCompressor) Compress(dst []byte, src []byte) []byte {
+	return zstdEncoder.EncodeAll(src, dst)
+}
+
+func (zstdCompressor) Decompress(dst []byte, src []byte, rawLen int) ([]byte, error) {
+	return zstdDecoder.DecodeAll(src, grow(dst, rawLen))
+}
+
+type lz4Compressor struct{}
+
+func (lz4Compressor) Compress(dst []byte, src []byte) []byte {
+	n := len(dst)
+	dst = grow(dst, lz4.CompressBlockBound(len(src)))
+	size, err := lz4.CompressBlock(src, dst[n:cap(dst)], nil)
+	if err != nil || size == 0 {
+		// incompressible, the caller falls back to the raw frame
+		return dst[:n]
+	}
+	return dst[:n+size]
+}
+
+func (lz4Compressor) Decompress(dst []byte, src []byte, rawLen int) ([]byte, error) {
+	n := len(dst)
+	dst = grow(dst, rawLen)
+	size, err := lz4.UncompressBlock(src, dst[n:n+rawLen])
+	if err != nil {
+		return dst, err
+	}
+	return dst[:n+size], nil
+}
+
+// compressFrame compresses data into a new buffer allocated from the connection.
+// ok is false if the compressed frame is not smaller than the raw one.
+func compressFrame(conn *MultiplexedConnection, c Compressor, data []byte) ([]byte, bool) {
+	buf := conn.AllocData(compressedHeaderSize)
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	buf = c.Compress(buf, data)
+	if len(buf) <= compressedHeaderSize || len(buf) >= len(data) {
+		conn.FreeData(buf)
+		return nil, false
+	}
+	return buf, true
+}
+
+func decompressFrame(conn *MultiplexedConnection, c Compressor, data []byte) ([]byte, error) {
+	if c == nil || len(data) < compressedHeaderSize {
+		return nil, errno.NewError(errno.DecompressFailed, "invalid compressed frame")
+	}
+	rawLen := int(binary.BigEndian.Uint32(data))
+	buf := conn.AllocData(0)
+	buf, err := c.Decompress(buf, data[compressedHeaderSize:], rawLen)
+	if err != nil {
+		conn.FreeData(buf)
+		return nil, errno.NewError(errno.DecompressFailed, err)
+	}
+	if len(buf) != rawLen {
+		conn.FreeData(buf)
+		return nil, errno.NewError(errno.DecompressFailed, "raw length mismatch")
+	}
+	return buf, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdy
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressorRoundTrip(t *testing.T) {
+	raw := bytes.Repeat([]byte("openGemini spdy compression "), 256)
+
+	for _, algo := range []uint16{CompressSnappy, CompressZstd, CompressLz4} {
+		c := NewCompressor(algo)
+		require.NotNil(t, c)
+
+		compressed := c.Compress([]byte{1, 2}, raw)
+		assert.Equal(t, []byte{1, 2}, compressed[:2])
+		assert.Less(t, len(compressed), len(raw))
+
+		got, err := c.Decompress(nil, compressed[2:], len(raw))
+		require.NoError(t, err)
+		assert.Equal(t, raw, got)
+
+		_, err = c.Decompress(nil, []byte{0xff, 0xff, 0xff}, len(raw))
+		assert.Error(t, err)
+	}
+
+	assert.Nil(t, NewCompressor(CompressNone))
+}
+
+func TestCompressAlgoFlags(t *testing.T) {
+	flags := encodeCompressAlgo(SYN_FLAG, CompressZstd)
+	assert.True(t, Flags(flags).has(SYN_FLAG))
+	assert.Equal(t, CompressZstd, decodeCompressAlgo(flags))
+	assert.Equal(t, CompressNone, decodeCompressAlgo(ACK_FLAG))
+	assert.Equal(t, CompressLz4, CompressAlgorithm(config.SpdyCompressLz4))
+	assert.Equal(t, CompressNone, CompressAlgorithm(""))
+}
+
+func newCompressionPair(t *testing.T, clientAlgo, serverAlgo string) (*MultiplexedSession, *MultiplexedSession, func()) {
+	clientCfg := config.NewSpdy()
+	clientCfg.CompressionAlgorithm = clientAlgo
+	clientCfg.CompressionThreshold = 64
+	serverCfg := config.NewSpdy()
+	serverCfg.CompressionAlgorithm = serverAlgo
+	serverCfg.CompressionThreshold = 64
+
+	c1, c2 := net.Pipe()
+	client := NewMultiplexedConnection(clientCfg, c1, true)
+	server := NewMultiplexedConnection(serverCfg, c2, false)
+	go func() {
+		HandleError(client.ListenAndServed())
+	}()
+	go func() {
+		HandleError(server.ListenAndServed())
+	}()
+
+	cs, err := client.OpenSession()()
+	require.NoError(t, err)
+	ss, err := server.AcceptSession()()
+	require.NoError(t, err)
+
+	return cs, ss, func() {
+		HandleError(client.Close())
+		HandleError(server.Close())
+	}
+}
+
+func TestSessionCompression(t *testing.T) {
+	cs, ss, closeFn := newCompressionPair(t, config.SpdyCompressSnappy, config.SpdyCompressZstd)
+	defer closeFn()
+
+	assert.Equal(t, CompressSnappy, cs.compressAlgo)
+	assert.Equal(t, CompressSnappy, ss.compressAlgo)
+
+	raw := bytes.Repeat([]byte("compressible payload "), 128)
+	small := []byte("small")
+	for _, data := range [][]byte{raw, small} {
+		buf := cs.conn.AllocData(len(data))
+		copy(buf, data)
+		require.NoError(t, cs.Send(buf))
+
+		got, err := ss.Select()
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+	}
+}
+
+func TestSessionCompressionDisabledByPeer(t *testing.T) {
+	cs, ss, closeFn := newCompressionPair(t, config.SpdyCompressLz4, config.SpdyCompressNone)
+	defer closeFn()
+
+	assert.Nil(t, cs.compressor)
+	assert.Nil(t, ss.compressor)
+
+	raw := bytes.Repeat([]byte("raw payload "), 128)
+	buf := cs.conn.AllocData(len(raw))
+	copy(buf, raw)
+	require.NoError(t, cs.Send(buf))
+
+	got, err := ss.Select()
+	require.NoError(t, err)
+	assert.Equal(t, raw, got)
+}
@@ -64,6 +64,7 @@ const (
 	FIN_FLAG
 	RST_FLAG
 	DATA_ACK_FLAG
+	COMPRESSED_FLAG
 )
 
 type header []byte
@@ -24,6 +24,7 @@ import (
 	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/lib/logger"
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
 	"go.uber.org/zap"
 )
 
@@ -63,6 +64,13 @@ type MultiplexedSession struct {
 	ackRecvSig    chan struct{}
 	selectTimeout time.Duration
 
+	compressAlgo      uint16
+	compressor        Compressor
+	compressThreshold int
+
+	// wireVersion is the lower one of the versions announced by both peers, 0 until the session is established
+	wireVersion uint16
+
 	closed    chan struct{}
 	closeOnce sync.Once
 	onClose   []func()
@@ -80,6 +88,8 @@ func NewMultiplexedSession(cfg config.Spdy, conn *MultiplexedConnection, id uint
 		ackRecvSig:    nil,
 		selectTimeout: cfg.GetSessionSelectTimeout(),
 		closed:        make(chan struct{}),
+
+		compressThreshold: int(cfg.CompressionThreshold),
 	}
 
 	session.dataAck = NewDataACK(func() {
@@ -99,6 +109,29 @@ func (s *MultiplexedSession) DisableDataACK() {
 	s.dataAck.Disable()
 }
 
+// WireVersion returns the wire format version negotiated with the peer, the messages sent on the session
+// must not use the features of a newer version.
+func (s *MultiplexedSession) WireVersion() uint16 {
+	return s.wireVersion
+}
+
+func (s *MultiplexedSession) setWireVersion(peer uint16) {
+	s.wireVersion = announcedWireVersion(s.cfg)
+	if peer < s.wireVersion {
+		s.wireVersion = peer
+	}
+}
+
+// setCompression applies the compression algorithm negotiated with the peer.
+// Unknown algorithms are ignored and the session falls back to raw frames.
+func (s *MultiplexedSession) setCompression(algo uint16) {
+	if algo == CompressNone || algo >= compressEnd {
+		return
+	}
+	s.compressAlgo = algo
+	s.compressor = NewCompressor(algo)
+}
+
 func (s *MultiplexedSession) initFSM() {
 	initState := NewFSMState(INIT_STATE, nil, nil)
 	synSentState := NewFSMState(SYN_SENT_STATE, nil, nil)
@@ -220,7 +253,15 @@ func (s *MultiplexedSession) handle(flags Flags, data []byte) error {
 		return nil
 	}
 
+	if flags == Flags(COMPRESSED_FLAG) {
+		return s.recvCompressedData(data)
+	}
+
 	if flags.has(SYN_FLAG) {
+		s.setWireVersion(decodeWireVersion(uint16(flags)))
+		if CompressAlgorithm(s.cfg.CompressionAlgorithm) != CompressNone {
+			s.setCompression(decodeCompressAlgo(uint16(flags)))
+		}
 		if err := s.RecvSyn(); err != nil {
 			return err
 		}
@@ -234,6 +275,10 @@ func (s *MultiplexedSession) handle(flags Flags, data []byte) error {
 	}
 
 	if flags.has(ACK_FLAG) {
+		s.setWireVersion(decodeWireVersion(uint16(flags)))
+		if algo := decodeCompressAlgo(uint16(flags)); algo == CompressAlgorithm(s.cfg.CompressionAlgorithm) {
+			s.setCompression(algo)
+		}
 		if err := s.RecvAck(); err != nil {
 			return err
 		}
@@ -275,6 +320,17 @@ func (s *MultiplexedSession) sendDataInternal(flags uint16, data []byte) error {
 	return nil
 }
 
+func (s *MultiplexedSession) recvCompressedData(data []byte) error {
+	start := time.Now()
+	raw, err := decompressFrame(s.conn, s.compressor, data)
+	s.conn.FreeData(data)
+	if err != nil {
+		return err
+	}
+	statistics.SpdyCompressionStat.AddDecompressed(time.Since(start))
+	return s.RecvData(raw)
+}
+
 func (s *MultiplexedSession) recvDataInternal(data []byte) error {
 	if len(data) == 0 {
 		return nil
@@ -295,6 +351,8 @@ func (s *MultiplexedSession) SendSyn(data []byte, ackRecvSig chan struct{}) erro
 func (s *MultiplexedSession) sendSyn(event event, transition *FSMTransition, data []byte) error {
 	var flags uint16
 	flags |= SYN_FLAG
+	flags = encodeCompressAlgo(flags, CompressAlgorithm(s.cfg.CompressionAlgorithm))
+	flags = encodeWireVersion(flags, announcedWireVersion(s.cfg))
 	if err := s.sendDataInternal(flags, data); err != nil {
 		return err
 	}
@@ -322,6 +380,8 @@ func (s *MultiplexedSession) SendAck(data []byte) error {
 func (s *MultiplexedSession) sendAck(event event, transition *FSMTransition, data []byte) error {
 	var flags uint16
 	flags |= ACK_FLAG
+	flags = encodeCompressAlgo(flags, s.compressAlgo)
+	flags = encodeWireVersion(flags, announcedWireVersion(s.cfg))
 	if err := s.sendDataInternal(flags, data); err != nil {
 		return err
 	}
@@ -355,7 +415,20 @@ func (s *MultiplexedSession) Send(data []byte) error {
 }
 
 func (s *MultiplexedSession) sendData(event event, transition *FSMTransition, data []byte) error {
-	if err := s.sendDataInternal(0, data); err != nil {
+	var flags uint16
+	if s.compressor != nil && len(data) >= s.compressThreshold {
+		start := time.Now()
+		if buf, ok := compressFrame(s.conn, s.compressor, data); ok {
+			statistics.SpdyCompressionStat.AddCompressed(len(data), len(buf), time.Since(start))
+			s.conn.FreeData(data)
+			data = buf
+			flags |= COMPRESSED_FLAG
+		} else {
+			statistics.SpdyCompressionStat.AddSkipped(time.Since(start))
+		}
+	}
+
+	if err := s.sendDataInternal(flags, data); err != nil {
 		return err
 	}
 	return nil
@@ -55,6 +55,13 @@ func (m *Message) SetHandler(h MessageNewHandler) {
 	m.handler = h
 }
 
+// SetWireVersion passes the version negotiated by the session to the data of the message
+func (m *Message) SetWireVersion(v uint16) {
+	if vc, ok := m.data.(transport.VersionedCodec); ok {
+		vc.SetWireVersion(v)
+	}
+}
+
 func (m *Message) Type() uint8 {
 	return m.typ
 }
@@ -19,6 +19,7 @@ package transport
 import (
 	"reflect"
 
+	"github.com/openGemini/openGemini/engine/executor/spdy"
 	"github.com/openGemini/openGemini/lib/errno"
 )
 
@@ -29,6 +30,18 @@ type Codec interface {
 	Instance() Codec
 }
 
+// VersionedCodec is implemented by the codecs which leave out the optional fields a peer does not understand.
+// The wire version negotiated by the session is set before the codec is encoded.
+type VersionedCodec interface {
+	SetWireVersion(v uint16)
+}
+
+func setWireVersion(c Codec, session *spdy.MultiplexedSession) {
+	if vc, ok := c.(VersionedCodec); ok && session != nil {
+		vc.SetWireVersion(session.WireVersion())
+	}
+}
+
 func ConvertToCodec(i interface{}) (Codec, error) {
 	ret, ok := i.(Codec)
 	if ret == nil || !ok {
@@ -84,6 +84,28 @@ func (m *NodeManager) Add(nodeID uint64, address string) {
 	m.nodes[nodeID].setStatisticsJob(m.job)
 }
 
+// Reset adds the node, or replaces it if its address is changed. The connections to the old address are closed.
+func (m *NodeManager) Reset(nodeID uint64, address string) {
+	m.mu.Lock()
+	old, ok := m.nodes[nodeID]
+	if ok && old != nil && old.address == address {
+		m.mu.Unlock()
+		return
+	}
+
+	m.nodes[nodeID] = &Node{
+		nodeID:  nodeID,
+		address: address,
+		pools:   make([]*spdy.MultiplexedSessionPool, spdy.ConnPoolSize()),
+	}
+	m.nodes[nodeID].setStatisticsJob(m.job)
+	m.mu.Unlock()
+
+	if ok && old != nil {
+		old.Close()
+	}
+}
+
 func (m *NodeManager) Get(nodeID uint64) *Node {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
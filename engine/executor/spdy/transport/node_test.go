@@ -100,3 +100,18 @@ func TestStat(t *testing.T) {
 	InitStatistics(AppStore)
 	assert.Equal(t, uint16(statistics.Store2Meta)<<8, NewMetaNodeManager().job.Key())
 }
+
+func TestNodeManagerReset(t *testing.T) {
+	m := &NodeManager{nodes: make(map[uint64]*Node)}
+	m.Add(1, "127.0.0.1:8092")
+	node := m.Get(1)
+
+	m.Reset(1, "127.0.0.1:8092")
+	assert.Equal(t, node, m.Get(1))
+
+	m.Reset(1, "127.0.0.2:8092")
+	assert.Equal(t, "127.0.0.2:8092", m.Get(1).address)
+
+	m.Reset(2, "127.0.0.3:8092")
+	assert.Equal(t, "127.0.0.3:8092", m.Get(2).address)
+}
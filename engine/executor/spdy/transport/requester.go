@@ -50,6 +50,7 @@ func (req *Requester) Encode(dst []byte, data interface{}) ([]byte, error) {
 		return nil, err
 	}
 
+	setWireVersion(codec, req.Session())
 	dst = bufferResize(dst, codec.Size())
 	return codec.Marshal(dst)
 }
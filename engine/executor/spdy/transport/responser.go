@@ -56,6 +56,7 @@ func (rsp *Responser) Encode(dst []byte, data interface{}) ([]byte, error) {
 		return nil, err
 	}
 
+	setWireVersion(codec, rsp.Session())
 	dst = bufferResize(dst, codec.Size())
 	return codec.Marshal(dst)
 }
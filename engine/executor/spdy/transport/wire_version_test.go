@@ -0,0 +1,251 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package transport_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/app/ts-meta/meta/message"
+	"github.com/openGemini/openGemini/engine/executor/spdy"
+	"github.com/openGemini/openGemini/engine/executor/spdy/transport"
+	"github.com/openGemini/openGemini/lib/codec"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// VersionedMessage is encoded like the generated codecs, Zone is an optional field of FeatureNodeTopology
+type VersionedMessage struct {
+	codec.Versioned
+
+	Host string
+	Zone string
+}
+
+func (m *VersionedMessage) Marshal(buf []byte) ([]byte, error) {
+	buf = codec.AppendString(buf, m.Host)
+	if m.Supports(codec.FeatureNodeTopology) {
+		buf = codec.AppendString(buf, m.Zone)
+	}
+	return buf, nil
+}
+
+func (m *VersionedMessage) Unmarshal(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	dec := codec.NewBinaryDecoder(buf)
+	m.Host = dec.String()
+	if dec.RemainSize() > 0 {
+		m.Zone = dec.String()
+	}
+	return nil
+}
+
+func (m *VersionedMessage) Size() int {
+	size := codec.SizeOfString(m.Host)
+	if m.Supports(codec.FeatureNodeTopology) {
+		size += codec.SizeOfString(m.Zone)
+	}
+	return size
+}
+
+func (m *VersionedMessage) Instance() transport.Codec {
+	return &VersionedMessage{}
+}
+
+// versionedEchoServer replies with the fields it decoded from the request
+type versionedEchoServer struct{}
+
+func (s *versionedEchoServer) Abort() {}
+
+func (s *versionedEchoServer) Handle(w spdy.Responser, data interface{}) error {
+	msg, ok := data.(*VersionedMessage)
+	if !ok {
+		return fmt.Errorf("invalid data type, exp: *VersionedMessage; got: %s", reflect.TypeOf(data))
+	}
+	return w.Response(&VersionedMessage{Host: msg.Host, Zone: msg.Zone}, true)
+}
+
+// createNodeEchoServer replies with the CreateNodeRequest it decoded, like a meta node of the other version
+type createNodeEchoServer struct{}
+
+func (s *createNodeEchoServer) Abort() {}
+
+func (s *createNodeEchoServer) Handle(w spdy.Responser, data interface{}) error {
+	msg, ok := data.(*message.MetaMessage)
+	if !ok {
+		return fmt.Errorf("invalid data type, exp: *message.MetaMessage; got: %s", reflect.TypeOf(data))
+	}
+	req, ok := msg.Data().(*message.CreateNodeRequest)
+	if !ok {
+		return fmt.Errorf("invalid data type, exp: *message.CreateNodeRequest; got: %s", reflect.TypeOf(msg.Data()))
+	}
+	rsp := &message.CreateNodeRequest{WriteHost: req.WriteHost, QueryHost: req.QueryHost, Zone: req.Zone, Rack: req.Rack}
+	return w.Response(message.NewMetaMessage(message.CreateNodeRequestMessage, rsp), true)
+}
+
+type versionedEchoClient struct {
+	codec transport.Codec
+	rsp   interface{}
+}
+
+func (c *versionedEchoClient) Handle(data interface{}) error {
+	c.rsp = data
+	return nil
+}
+
+func (c *versionedEchoClient) GetCodec() transport.Codec {
+	return c.codec
+}
+
+type versionNode struct {
+	address string
+	cfg     config.Spdy
+	server  *spdy.RRCServer
+}
+
+func (n *versionNode) start(t *testing.T, wireVersion int) {
+	n.cfg = config.NewSpdy()
+	n.cfg.WireVersion = wireVersion
+	n.server = spdy.NewRRCServer(n.cfg, "tcp", n.address)
+	n.server.RegisterEHF(transport.NewEventHandlerFactory(spdy.SelectRequest, &versionedEchoServer{}, &VersionedMessage{}))
+	n.server.RegisterEHF(transport.NewEventHandlerFactory(spdy.MetaRequest, &createNodeEchoServer{}, &message.MetaMessage{}))
+	require.NoError(t, n.server.Start())
+}
+
+// upgrade restarts the node with the newest wire version, like a rolling upgrade of its binary
+func (n *versionNode) upgrade(t *testing.T) {
+	n.server.Stop()
+	time.Sleep(100 * time.Millisecond)
+	n.start(t, config.DefaultWireVersion)
+}
+
+var versionSequence uint64
+
+// send sends a request from the node to the peer and returns the echoed response
+func (n *versionNode) send(t *testing.T, peer *versionNode, req *VersionedMessage) *VersionedMessage {
+	rsp := n.request(t, peer, spdy.SelectRequest, req, &VersionedMessage{})
+	return rsp.(*VersionedMessage)
+}
+
+// createNode sends a CreateNodeRequest from the node to the peer and returns the echoed request
+func (n *versionNode) createNode(t *testing.T, peer *versionNode, req *message.CreateNodeRequest) *message.CreateNodeRequest {
+	rsp := n.request(t, peer, spdy.MetaRequest, message.NewMetaMessage(message.CreateNodeRequestMessage, req), &message.MetaMessage{})
+	msg, ok := rsp.(*message.MetaMessage)
+	require.True(t, ok)
+	echo, ok := msg.Data().(*message.CreateNodeRequest)
+	require.True(t, ok)
+	return echo
+}
+
+func (n *versionNode) request(t *testing.T, peer *versionNode, typ uint8, req transport.Codec, rspCodec transport.Codec) interface{} {
+	pool := spdy.NewMultiplexedSessionPool(n.cfg, "tcp", peer.address)
+	require.NoError(t, pool.Dial())
+	defer pool.Close()
+
+	session, err := pool.Get()
+	require.NoError(t, err)
+	session.SetTimeout(5 * time.Second)
+
+	client := &versionedEchoClient{codec: rspCodec}
+	requester := transport.NewRequester(session, typ, atomic.AddUint64(&versionSequence, 1), nil)
+	responser := requester.WarpResponser()
+	responser.(*transport.Responser).SetCallback(client)
+
+	require.NoError(t, requester.Request(req))
+	require.NoError(t, responser.Apply())
+	require.NotNil(t, client.rsp)
+	return client.rsp
+}
+
+func TestWireVersion_RollingUpgrade(t *testing.T) {
+	nodes := []*versionNode{
+		{address: "127.0.0.12:18501"},
+		{address: "127.0.0.12:18502"},
+		{address: "127.0.0.12:18503"},
+	}
+	for _, n := range nodes {
+		n.start(t, config.LegacyWireVersion)
+	}
+	defer func() {
+		for _, n := range nodes {
+			n.server.Stop()
+		}
+	}()
+
+	upgraded := make([]bool, len(nodes))
+	check := func() {
+		for i, from := range nodes {
+			for j, to := range nodes {
+				if i == j {
+					continue
+				}
+				rsp := from.send(t, to, &VersionedMessage{Host: from.address, Zone: "zone-a"})
+				assert.Equal(t, from.address, rsp.Host)
+				// the optional field only travels between two upgraded nodes
+				expect := ""
+				if upgraded[i] && upgraded[j] {
+					expect = "zone-a"
+				}
+				assert.Equal(t, expect, rsp.Zone, "from %s to %s", from.address, to.address)
+			}
+		}
+	}
+
+	check()
+	for i, n := range nodes {
+		n.upgrade(t)
+		upgraded[i] = true
+		check()
+	}
+}
+
+func TestWireVersion_CreateNodeRequest(t *testing.T) {
+	legacy := &versionNode{address: "127.0.0.12:18504"}
+	current := &versionNode{address: "127.0.0.12:18505"}
+	legacy.start(t, config.LegacyWireVersion)
+	current.start(t, config.DefaultWireVersion)
+	defer func() {
+		legacy.server.Stop()
+		current.server.Stop()
+	}()
+
+	newRequest := func() *message.CreateNodeRequest {
+		return &message.CreateNodeRequest{WriteHost: "127.0.0.1:8400", QueryHost: "127.0.0.1:8401", Zone: "zone-a", Rack: "rack-1"}
+	}
+	for _, c := range []struct {
+		from, to *versionNode
+		zone     string
+		rack     string
+	}{
+		// the topology of the node is left out on the sessions with a legacy peer, in both directions
+		{from: legacy, to: current},
+		{from: current, to: legacy},
+		{from: current, to: current, zone: "zone-a", rack: "rack-1"},
+	} {
+		rsp := c.from.createNode(t, c.to, newRequest())
+		assert.Equal(t, "127.0.0.1:8400", rsp.WriteHost, "from %s to %s", c.from.address, c.to.address)
+		assert.Equal(t, "127.0.0.1:8401", rsp.QueryHost, "from %s to %s", c.from.address, c.to.address)
+		assert.Equal(t, c.zone, rsp.Zone, "from %s to %s", c.from.address, c.to.address)
+		assert.Equal(t, c.rack, rsp.Rack, "from %s to %s", c.from.address, c.to.address)
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdy
+
+import "github.com/openGemini/openGemini/lib/config"
+
+// WireVersion is the newest wire format version of the messages, the features of the versions are listed by
+// codec.Version. The peers announce their versions in the flags of the SYN and ACK frames and a session uses the
+// lower one, so the messages sent to a node which is not upgraded yet keep the old format.
+// The nodes without version negotiation announce 0.
+const WireVersion uint16 = 1
+
+const (
+	wireVersionShift        = 12
+	wireVersionMask  uint16 = 0x0f << wireVersionShift
+)
+
+func encodeWireVersion(flags uint16, v uint16) uint16 {
+	return flags | (v<<wireVersionShift)&wireVersionMask
+}
+
+func decodeWireVersion(flags uint16) uint16 {
+	return (flags & wireVersionMask) >> wireVersionShift
+}
+
+// announcedWireVersion returns the version announced to the peers, wire-version caps it
+func announcedWireVersion(cfg config.Spdy) uint16 {
+	if cfg.WireVersion < 0 {
+		return 0
+	}
+	if cfg.WireVersion == 0 || cfg.WireVersion > int(WireVersion) {
+		return WireVersion
+	}
+	return uint16(cfg.WireVersion)
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spdy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireVersionFlags(t *testing.T) {
+	flags := encodeCompressAlgo(SYN_FLAG, CompressZstd)
+	flags = encodeWireVersion(flags, WireVersion)
+	assert.True(t, Flags(flags).has(SYN_FLAG))
+	assert.Equal(t, CompressZstd, decodeCompressAlgo(flags))
+	assert.Equal(t, WireVersion, decodeWireVersion(flags))
+	assert.Equal(t, uint16(0), decodeWireVersion(ACK_FLAG))
+
+	cfg := config.NewSpdy()
+	assert.Equal(t, WireVersion, announcedWireVersion(cfg))
+	cfg.WireVersion = config.LegacyWireVersion
+	assert.Equal(t, uint16(0), announcedWireVersion(cfg))
+	cfg.WireVersion = 1
+	assert.Equal(t, uint16(1), announcedWireVersion(cfg))
+	cfg.WireVersion = int(WireVersion) + 1
+	assert.Equal(t, WireVersion, announcedWireVersion(cfg))
+}
+
+func newVersionPair(t *testing.T, clientVersion, serverVersion int) (*MultiplexedSession, *MultiplexedSession, func()) {
+	clientCfg := config.NewSpdy()
+	clientCfg.WireVersion = clientVersion
+	serverCfg := config.NewSpdy()
+	serverCfg.WireVersion = serverVersion
+
+	c1, c2 := net.Pipe()
+	client := NewMultiplexedConnection(clientCfg, c1, true)
+	server := NewMultiplexedConnection(serverCfg, c2, false)
+	go func() {
+		HandleError(client.ListenAndServed())
+	}()
+	go func() {
+		HandleError(server.ListenAndServed())
+	}()
+
+	cs, err := client.OpenSession()()
+	require.NoError(t, err)
+	ss, err := server.AcceptSession()()
+	require.NoError(t, err)
+
+	return cs, ss, func() {
+		HandleError(client.Close())
+		HandleError(server.Close())
+	}
+}
+
+func TestSessionWireVersion(t *testing.T) {
+	cases := []struct {
+		client, server int
+		expect         uint16
+	}{
+		{config.DefaultWireVersion, config.DefaultWireVersion, WireVersion},
+		{config.LegacyWireVersion, config.DefaultWireVersion, 0},
+		{config.DefaultWireVersion, config.LegacyWireVersion, 0},
+		{config.LegacyWireVersion, config.LegacyWireVersion, 0},
+	}
+
+	for _, c := range cases {
+		cs, ss, closeFn := newVersionPair(t, c.client, c.server)
+		assert.Equal(t, c.expect, cs.WireVersion())
+		assert.Equal(t, c.expect, ss.WireVersion())
+
+		data := []byte("versioned payload")
+		buf := cs.conn.AllocData(len(data))
+		copy(buf, data)
+		require.NoError(t, cs.Send(buf))
+		got, err := ss.Select()
+		require.NoError(t, err)
+		assert.Equal(t, data, got)
+		closeFn()
+	}
+}
@@ -0,0 +1,462 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/openGemini/openGemini/engine/hybridqp"
+	"github.com/openGemini/openGemini/lib/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	spillFilePattern = "spill-*.run"
+	spillDirName     = "openGemini-spill"
+)
+
+// SpillableTransform is implemented by the transforms which write their input to disk
+// once it exceeds the spill threshold.
+type SpillableTransform interface {
+	Spillable() bool
+}
+
+type spillParas struct {
+	mu        sync.RWMutex
+	dir       string
+	threshold int64
+}
+
+var spillConf = &spillParas{}
+
+// SetSpillParas enables spilling of transforms which have to hold their whole input,
+// such as ORDER BY over GROUP BY time, once the memory of one transform exceeds threshold.
+// A threshold of 0 disables spilling.
+//
+// Only OrderByTransform over GROUP BY time spills. The group by, aggregate and sort append transforms
+// stream their inputs and hold at most one chunk per input, and the subqueries are executed by
+// these transforms, so their memory is bounded by the chunk size and they are still admitted by
+// executor-memory-size-limit.
+func SetSpillParas(dir string, threshold int64) {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), spillDirName)
+	}
+	spillConf.mu.Lock()
+	defer spillConf.mu.Unlock()
+	spillConf.dir = dir
+	spillConf.threshold = threshold
+}
+
+func GetSpillParas() (string, int64) {
+	spillConf.mu.RLock()
+	defer spillConf.mu.RUnlock()
+	return spillConf.dir, spillConf.threshold
+}
+
+func SpillEnabled() bool {
+	_, threshold := GetSpillParas()
+	return threshold > 0
+}
+
+// ChunkRunWriter writes a sorted run of chunks to a temporary file, encoded with the chunk codec.
+// Every chunk is prefixed by the length of its encoding.
+type ChunkRunWriter struct {
+	file *os.File
+	w    *bufio.Writer
+	buf  []byte
+}
+
+func NewChunkRunWriter(dir string) (*ChunkRunWriter, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(dir, spillFilePattern)
+	if err != nil {
+		return nil, err
+	}
+	return &ChunkRunWriter{file: file, w: bufio.NewWriter(file)}, nil
+}
+
+func (w *ChunkRunWriter) Write(c Chunk) error {
+	var err error
+	w.buf = append(w.buf[:0], 0, 0, 0, 0)
+	w.buf, err = c.Marshal(w.buf)
+	if err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(w.buf, uint32(len(w.buf)-4))
+	_, err = w.w.Write(w.buf)
+	return err
+}
+
+// Finish flushes the run and returns a reader positioned at its first chunk
+func (w *ChunkRunWriter) Finish(rt hybridqp.RowDataType) (*ChunkRunReader, error) {
+	if err := w.w.Flush(); err != nil {
+		w.Abort()
+		return nil, err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		w.Abort()
+		return nil, err
+	}
+	return &ChunkRunReader{file: w.file, r: bufio.NewReader(w.file), rt: rt}, nil
+}
+
+func (w *ChunkRunWriter) Abort() {
+	removeSpillFile(w.file)
+}
+
+type ChunkRunReader struct {
+	file *os.File
+	r    *bufio.Reader
+	rt   hybridqp.RowDataType
+	hdr  [4]byte
+}
+
+// Next returns the next chunk of the run, or io.EOF if the run is exhausted
+func (r *ChunkRunReader) Next() (Chunk, error) {
+	if _, err := io.ReadFull(r.r, r.hdr[:]); err != nil {
+		return nil, err
+	}
+	// the decoded chunk refers to buf, so it can not be reused
+	buf := make([]byte, binary.BigEndian.Uint32(r.hdr[:]))
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	c := &ChunkImpl{}
+	if err := c.Unmarshal(buf); err != nil {
+		return nil, err
+	}
+	c.SetRowDataType(r.rt)
+	return c, nil
+}
+
+func (r *ChunkRunReader) Close() {
+	removeSpillFile(r.file)
+}
+
+func removeSpillFile(file *os.File) {
+	name := file.Name()
+	if err := file.Close(); err != nil {
+		logger.GetLogger().Error("failed to close spill file", zap.String("file", name), zap.Error(err))
+	}
+	if err := os.Remove(name); err != nil {
+		logger.GetLogger().Error("failed to remove spill file", zap.String("file", name), zap.Error(err))
+	}
+}
+
+type sortRow struct {
+	chunk int
+	row   int
+	group int
+	time  int64
+}
+
+type sortGroup struct {
+	tags ChunkTags
+	key  []byte
+}
+
+// ChunkSorter sorts rows by group and time. Rows are kept in memory until their size
+// exceeds the threshold, then they are sorted and written to disk as a run.
+// Finish merges the runs back and emits chunks of at most chunkSize rows.
+type ChunkSorter struct {
+	rt         hybridqp.RowDataType
+	dir        string
+	threshold  int64
+	chunkSize  int
+	dimensions []string
+	ascending  bool
+	window     func(int64) (int64, int64)
+
+	chunks  []Chunk
+	memSize int64
+	runs    []*ChunkRunReader
+
+	builder     *ChunkBuilder
+	coProcessor CoProcessor
+	out         Chunk
+	lastKey     []byte
+}
+
+func NewChunkSorter(rt hybridqp.RowDataType, dir string, threshold int64, chunkSize int, dimensions []string,
+	ascending bool, window func(int64) (int64, int64)) *ChunkSorter {
+	return &ChunkSorter{
+		rt:          rt,
+		dir:         dir,
+		threshold:   threshold,
+		chunkSize:   chunkSize,
+		dimensions:  dimensions,
+		ascending:   ascending,
+		window:      window,
+		builder:     NewChunkBuilder(rt),
+		coProcessor: FixedColumnsIteratorHelper(rt),
+	}
+}
+
+func (s *ChunkSorter) Add(c Chunk) error {
+	if c == nil || c.Len() == 0 {
+		return nil
+	}
+	// chunks of the upstream transforms are reused from a pool, so they have to be copied
+	c = c.Clone()
+	s.chunks = append(s.chunks, c)
+	s.memSize += int64(c.Size())
+	if s.memSize < s.threshold {
+		return nil
+	}
+	return s.spill()
+}
+
+func (s *ChunkSorter) NumOfRuns() int {
+	return len(s.runs)
+}
+
+func (s *ChunkSorter) sortTime(t int64) int64 {
+	if s.window == nil {
+		return t
+	}
+	start, _ := s.window(t)
+	return start
+}
+
+func (s *ChunkSorter) less(k1 []byte, t1 int64, k2 []byte, t2 int64) bool {
+	if c := bytes.Compare(k1, k2); c != 0 {
+		return c < 0
+	}
+	if s.ascending {
+		return t1 < t2
+	}
+	return t1 > t2
+}
+
+// sortBuffered sorts the buffered rows in memory and passes them to emit in order
+func (s *ChunkSorter) sortBuffered(emit func(Chunk) error) error {
+	var rows []sortRow
+	var groups []sortGroup
+	for ci, c := range s.chunks {
+		tagIndex := c.TagIndex()
+		for gi, tags := range c.Tags() {
+			t := tags.KeepKeys(s.dimensions)
+			groups = append(groups, sortGroup{tags: *t, key: t.Subset(s.dimensions)})
+			end := c.Len()
+			if gi < len(tagIndex)-1 {
+				end = tagIndex[gi+1]
+			}
+			for row := tagIndex[gi]; row < end; row++ {
+				rows = append(rows, sortRow{chunk: ci, row: row, group: len(groups) - 1, time: s.sortTime(c.TimeByIndex(row))})
+			}
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return s.less(groups[rows[i].group].key, rows[i].time, groups[rows[j].group].key, rows[j].time)
+	})
+
+	for i := range rows {
+		g := &groups[rows[i].group]
+		if err := s.appendRow(s.chunks[rows[i].chunk], rows[i].row, &g.tags, g.key, emit); err != nil {
+			return err
+		}
+	}
+	s.chunks = s.chunks[:0]
+	s.memSize = 0
+	return s.flush(emit)
+}
+
+func (s *ChunkSorter) appendRow(src Chunk, row int, tags *ChunkTags, key []byte, emit func(Chunk) error) error {
+	if s.out == nil {
+		s.out = s.builder.NewChunk(src.Name())
+	}
+	if s.out.Len() == 0 || !bytes.Equal(s.lastKey, key) {
+		s.out.AppendTagsAndIndex(*tags, s.out.Len())
+		s.lastKey = key
+	}
+	s.coProcessor.WorkOnChunk(src, s.out, &IteratorParams{
+		start:    row,
+		end:      row + 1,
+		chunkLen: s.out.Len(),
+	})
+	s.out.AppendTime(src.TimeByIndex(row))
+
+	if s.out.Len() >= s.chunkSize {
+		return s.flush(emit)
+	}
+	return nil
+}
+
+func (s *ChunkSorter) flush(emit func(Chunk) error) error {
+	if s.out == nil || s.out.Len() == 0 {
+		return nil
+	}
+	out := s.out
+	s.out = nil
+	s.lastKey = nil
+	return emit(out)
+}
+
+func (s *ChunkSorter) spill() error {
+	w, err := NewChunkRunWriter(s.dir)
+	if err != nil {
+		return err
+	}
+	if err = s.sortBuffered(w.Write); err != nil {
+		w.Abort()
+		return err
+	}
+	r, err := w.Finish(s.rt)
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, r)
+	return nil
+}
+
+// Finish emits all added rows in order. Without any spilled run the rows are sorted in memory.
+func (s *ChunkSorter) Finish(emit func(Chunk) error) error {
+	if len(s.runs) == 0 {
+		return s.sortBuffered(emit)
+	}
+	if len(s.chunks) > 0 {
+		if err := s.spill(); err != nil {
+			return err
+		}
+	}
+	return s.merge(emit)
+}
+
+func (s *ChunkSorter) merge(emit func(Chunk) error) error {
+	h := &runCursors{sorter: s}
+	for i, r := range s.runs {
+		cur := &runCursor{reader: r, run: i}
+		ok, err := cur.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			h.items = append(h.items, cur)
+		}
+	}
+	heap.Init(h)
+
+	for h.Len() > 0 {
+		cur := h.items[0]
+		g := cur.group()
+		if err := s.appendRow(cur.chunk, cur.row, g, cur.key, emit); err != nil {
+			return err
+		}
+		ok, err := cur.next()
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(h, 0)
+		} else {
+			heap.Pop(h)
+		}
+	}
+	return s.flush(emit)
+}
+
+// Close removes all spilled runs
+func (s *ChunkSorter) Close() {
+	for _, r := range s.runs {
+		r.Close()
+	}
+	s.runs = nil
+	s.chunks = nil
+}
+
+type runCursor struct {
+	reader *ChunkRunReader
+	run    int
+	chunk  Chunk
+	row    int
+	tag    int
+	key    []byte
+	time   int64
+}
+
+// next moves the cursor to the next row of the run, loading the next chunk if required
+func (c *runCursor) next() (bool, error) {
+	if c.chunk != nil {
+		c.row++
+	}
+	for c.chunk == nil || c.row >= c.chunk.Len() {
+		chunk, err := c.reader.Next()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		c.chunk, c.row, c.tag = chunk, 0, 0
+	}
+
+	tagIndex := c.chunk.TagIndex()
+	for c.tag < len(tagIndex)-1 && tagIndex[c.tag+1] <= c.row {
+		c.tag++
+	}
+	c.key = c.chunk.Tags()[c.tag].Subset(nil)
+	c.time = c.chunk.TimeByIndex(c.row)
+	return true, nil
+}
+
+func (c *runCursor) group() *ChunkTags {
+	return &c.chunk.Tags()[c.tag]
+}
+
+type runCursors struct {
+	sorter *ChunkSorter
+	items  []*runCursor
+}
+
+func (h *runCursors) Len() int { return len(h.items) }
+
+func (h *runCursors) Less(i, j int) bool {
+	x, y := h.items[i], h.items[j]
+	tx, ty := h.sorter.sortTime(x.time), h.sorter.sortTime(y.time)
+	if bytes.Equal(x.key, y.key) && tx == ty {
+		return x.run < y.run
+	}
+	return h.sorter.less(x.key, tx, y.key, ty)
+}
+
+func (h *runCursors) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *runCursors) Push(x interface{}) {
+	h.items = append(h.items, x.(*runCursor))
+}
+
+func (h *runCursors) Pop() interface{} {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[0 : n-1]
+	return item
+}
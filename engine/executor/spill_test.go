@@ -0,0 +1,129 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executor_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/openGemini/openGemini/engine/executor"
+	"github.com/openGemini/openGemini/engine/hybridqp"
+	"github.com/openGemini/openGemini/open_src/influx/influxql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildSpillChunks(rt hybridqp.RowDataType) []executor.Chunk {
+	builder := executor.NewChunkBuilder(rt)
+	var chunks []executor.Chunk
+	for i := 0; i < 4; i++ {
+		c := builder.NewChunk("mst")
+		c.AppendTagsAndIndex(*ParseChunkTags("host=b"), 0)
+		c.AppendTagsAndIndex(*ParseChunkTags("host=a"), 3)
+		for j := 0; j < 6; j++ {
+			ts := int64((4-i)*10 + j)
+			c.AppendTime(ts)
+			c.Column(0).AppendFloatValues(float64(ts))
+			c.Column(0).AppendNilsV2(true)
+		}
+		chunks = append(chunks, c)
+	}
+	return chunks
+}
+
+func sortChunks(t *testing.T, threshold int64) ([]executor.Chunk, int) {
+	rt := hybridqp.NewRowDataTypeImpl(influxql.VarRef{Val: "value", Type: influxql.Float})
+	sorter := executor.NewChunkSorter(rt, t.TempDir(), threshold, 5, []string{"host"}, true, nil)
+	defer sorter.Close()
+
+	for _, c := range buildSpillChunks(rt) {
+		require.NoError(t, sorter.Add(c))
+	}
+	runs := sorter.NumOfRuns()
+
+	var out []executor.Chunk
+	require.NoError(t, sorter.Finish(func(c executor.Chunk) error {
+		out = append(out, c)
+		return nil
+	}))
+	return out, runs
+}
+
+func TestChunkSorter(t *testing.T) {
+	inMemory, runs := sortChunks(t, 1<<30)
+	assert.Equal(t, 0, runs)
+	spilled, runs := sortChunks(t, 1)
+	assert.Equal(t, 4, runs)
+
+	check := func(chunks []executor.Chunk) {
+		var host []string
+		var times []int64
+		for _, c := range chunks {
+			assert.LessOrEqual(t, c.Len(), 5)
+			for i, tag := range c.Tags() {
+				end := c.Len()
+				if i < len(c.TagIndex())-1 {
+					end = c.TagIndex()[i+1]
+				}
+				for j := c.TagIndex()[i]; j < end; j++ {
+					host = append(host, string(tag.Subset(nil)))
+					times = append(times, c.TimeByIndex(j))
+					assert.Equal(t, float64(c.TimeByIndex(j)), c.Column(0).FloatValue(j))
+				}
+			}
+		}
+		require.Len(t, times, 24)
+		for i := 1; i < len(times); i++ {
+			if host[i] == host[i-1] {
+				assert.Less(t, times[i-1], times[i])
+			} else {
+				assert.Less(t, host[i-1], host[i])
+			}
+		}
+	}
+	check(inMemory)
+	check(spilled)
+}
+
+func TestChunkRunWriter(t *testing.T) {
+	dir := t.TempDir()
+	rt := hybridqp.NewRowDataTypeImpl(influxql.VarRef{Val: "value", Type: influxql.Float})
+	chunks := buildSpillChunks(rt)
+
+	w, err := executor.NewChunkRunWriter(dir)
+	require.NoError(t, err)
+	for _, c := range chunks {
+		require.NoError(t, w.Write(c))
+	}
+	r, err := w.Finish(rt)
+	require.NoError(t, err)
+
+	for _, exp := range chunks {
+		got, err := r.Next()
+		require.NoError(t, err)
+		assert.Equal(t, exp.Time(), got.Time())
+		assert.Equal(t, exp.Column(0).FloatValues(), got.Column(0).FloatValues())
+		assert.Equal(t, rt, got.RowDataType())
+	}
+	_, err = r.Next()
+	assert.Error(t, err)
+
+	r.Close()
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
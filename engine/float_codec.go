@@ -0,0 +1,73 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"sync"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+)
+
+type floatCodecEntry struct {
+	codec int
+	set   bool
+}
+
+// floatCodecCache keeps the float codec set by CREATE MEASUREMENT, which can not be altered afterwards,
+// so that flushes and compactions do not look up the measurement in the meta data every time.
+// Measurements unknown to the node are not cached, their codec is looked up again on the next use.
+type floatCodecCache struct {
+	mu     sync.RWMutex
+	codecs map[string]floatCodecEntry
+	lookup func(mst string) (string, bool)
+}
+
+func newFloatCodecCache(lookup func(mst string) (string, bool)) *floatCodecCache {
+	return &floatCodecCache{codecs: make(map[string]floatCodecEntry), lookup: lookup}
+}
+
+// get returns the float codec set by the measurement, false if it sets none
+func (c *floatCodecCache) get(mst string) (int, bool) {
+	c.mu.RLock()
+	e, ok := c.codecs[mst]
+	c.mu.RUnlock()
+	if ok {
+		return e.codec, e.set
+	}
+
+	name, ok := c.lookup(mst)
+	if !ok {
+		return 0, false
+	}
+	if name != "" {
+		e.codec, e.set = immutable.ParseFloatCodec(name)
+	}
+	c.mu.Lock()
+	c.codecs[mst] = e
+	c.mu.Unlock()
+	return e.codec, e.set
+}
+
+// drop forgets the codec of the measurement, which may be created again with another codec
+func (c *floatCodecCache) drop(mst string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.codecs, mst)
+	c.mu.Unlock()
+}
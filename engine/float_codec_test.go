@@ -0,0 +1,59 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloatCodecCache(t *testing.T) {
+	lookups := 0
+	codecs := map[string]string{"cpu": "chimp", "mem": ""}
+	c := newFloatCodecCache(func(mst string) (string, bool) {
+		lookups++
+		codec, ok := codecs[mst]
+		return codec, ok
+	})
+
+	for i := 0; i < 3; i++ {
+		codec, ok := c.get("cpu")
+		require.True(t, ok)
+		require.Equal(t, immutable.FloatCodecChimp, codec)
+		_, ok = c.get("mem")
+		require.False(t, ok)
+	}
+	require.Equal(t, 2, lookups)
+
+	// unknown measurements are looked up again
+	_, ok := c.get("disk")
+	require.False(t, ok)
+	codecs["disk"] = "alp"
+	codec, ok := c.get("disk")
+	require.True(t, ok)
+	require.Equal(t, immutable.FloatCodecALP, codec)
+	require.Equal(t, 4, lookups)
+
+	codecs["cpu"] = "gorilla"
+	c.drop("cpu")
+	codec, ok = c.get("cpu")
+	require.True(t, ok)
+	require.Equal(t, immutable.FloatCodecGorilla, codec)
+	require.Equal(t, 5, lookups)
+}
@@ -75,3 +75,24 @@ func TestIsSpecificSeriesQuery(t *testing.T) {
 	assert.Equal(t, true, hybridqp.IsSpecificSeriesQuery(selectStmt))
 
 }
+
+func TestWorkloadGroupHint(t *testing.T) {
+	sql := `select /*+ workload_group=realtime full_series */ value from cpu where (host = 'server01')`
+	parser := influxql.NewParser(strings.NewReader(sql))
+	yaccParser := yacc.NewYyParser(parser.GetScanner())
+	yaccParser.ParseTokens()
+	query, err := yaccParser.GetQuery()
+	if err != nil {
+		t.Fatal("parse error", err.Error())
+	}
+
+	selectStmt, ok := query.Statements[0].(*influxql.SelectStatement)
+	if !ok {
+		t.Fatal("stmt is not influxql.SelectStatement")
+	}
+	assert.Equal(t, "realtime", selectStmt.Hints.WorkloadGroup())
+	assert.Equal(t, true, hybridqp.IsFullSeriesQuery(selectStmt))
+
+	assert.Equal(t, false, influxql.IsSupportHint(influxql.WorkloadGroupHint))
+	assert.Equal(t, "", influxql.Hints{}.WorkloadGroup())
+}
@@ -37,6 +37,7 @@ type Options interface {
 	GetStartTime() int64
 	GetEndTime() int64
 	GetMaxParallel() int
+	GetWorkloadGroup() string
 	Window(t int64) (start, end int64)
 	GetGroupBy() map[string]struct{}
 	IsGroupByAllDims() bool
@@ -139,6 +139,7 @@ type ChunkIterator struct {
 type ChunkIterators struct {
 	closed       chan struct{}
 	dropping     *int64
+	task         *CompactionTask
 	name         string
 	itrs         []*ChunkIterator
 	id           uint64
@@ -186,7 +187,7 @@ func (c *ChunkIterators) Close() {
 }
 
 func (c *ChunkIterators) stopCompact() bool {
-	if atomic.LoadInt64(c.dropping) > 0 {
+	if atomic.LoadInt64(c.dropping) > 0 || c.task.Cancelled() {
 		return true
 	}
 
@@ -378,12 +379,22 @@ func (m *MmsTables) unrefMmsTable(orderWg, outOfOrderWg *sync.WaitGroup) {
 }
 
 func (m *MmsTables) LevelCompact(level uint16, shid uint64) error {
-	plans := m.LevelPlan(level)
+	return m.levelCompact(m.LevelPlan(level), shid)
+}
+
+func (m *MmsTables) levelCompact(plans []*CompactGroup, shid uint64) error {
+	for _, plan := range plans {
+		plan.shardId = shid
+		plan.task = m.queueCompaction(CompactionTypeLevel, plan)
+	}
+
 	for len(plans) > 0 {
 		plan := plans[0]
-		plan.shardId = shid
 		select {
 		case <-m.closed:
+			for _, p := range plans {
+				m.tasks.done(p.task)
+			}
 			return ErrCompStopped
 		case compLimiter <- struct{}{}:
 			m.wg.Add(1)
@@ -398,9 +409,14 @@ func (m *MmsTables) LevelCompact(level uint16, shid uint64) error {
 					m.unrefMmsTable(orderWg, inorderWg)
 					compLimiter.Release()
 					m.CompactDone(group.group)
+					m.tasks.done(group.task)
 					group.release()
 				}()
 
+				if !m.tasks.start(group.task) {
+					return
+				}
+
 				fi, err := m.NewFileIterators(group)
 				if err != nil {
 					log.Error(err.Error())
@@ -430,6 +446,7 @@ func (m *MmsTables) NewChunkIterators(group FilesInfo) (*ChunkIterators, error)
 	compItrs := &ChunkIterators{
 		closed:   m.closed,
 		dropping: group.dropping,
+		task:     group.task,
 		name:     group.name,
 		itrs:     make([]*ChunkIterator, 0, len(group.compIts)),
 		merged:   &record.Record{},
@@ -485,7 +502,13 @@ func (m *MmsTables) compactToLevel(group FilesInfo, full bool) error {
 	}
 
 	compItrs.Close()
-	if err = m.ReplaceFiles(group.name, group.oldFiles, newFiles, true, lcLog); err != nil {
+	if len(newFiles) == 0 {
+		// all chunks of the old files are expired
+		err = m.removeFiles(group.name, group.oldFiles, true)
+	} else {
+		err = m.ReplaceFiles(group.name, group.oldFiles, newFiles, true, lcLog)
+	}
+	if err != nil {
 		lcLog.Error("replace compacted file error", zap.Error(err))
 		return err
 	}
@@ -752,34 +775,67 @@ func readCompactLogFile(name string, info *CompactedFileInfo) error {
 	return nil
 }
 
-func processLog(shardDir string, info *CompactedFileInfo) error {
-	mmDir := filepath.Join(shardDir, TsspDirName, info.Name)
-	dirs, err := fileops.ReadDir(mmDir)
-	if err != nil {
-		log.Error("read dir fail", zap.String("path", mmDir), zap.Error(err))
-		return err
+const (
+	// all new files are written, the old files are replaced by them
+	CompactLogCommit = iota
+	// some new files are missing, the old files are restored
+	CompactLogRollback
+	// neither the new files nor the old files are complete
+	CompactLogInvalid
+)
+
+func newFileExist(dirs []os.FileInfo, newFile string) bool {
+	normalName := newFile[:len(newFile)-len(tmpTsspFileSuffix)]
+	for i := range dirs {
+		name := dirs[i].Name()
+		if name == normalName || newFile == name {
+			return true
+		}
 	}
+	return false
+}
 
-	newFileExist := func(newFile string) bool {
-		normalName := newFile[:len(newFile)-len(tmpTsspFileSuffix)]
-		for i := range dirs {
-			name := dirs[i].Name()
-			if name == normalName || newFile == name {
-				return true
-			}
+func oldFileExist(dirs []os.FileInfo, oldFile string) bool {
+	for i := range dirs {
+		name := dirs[i].Name()
+		tmp := oldFile + tmpTsspFileSuffix
+		if name == oldFile || tmp == name {
+			return true
 		}
-		return false
 	}
+	return false
+}
 
-	oldFileExist := func(oldFile string) bool {
-		for i := range dirs {
-			name := dirs[i].Name()
-			tmp := oldFile + tmpTsspFileSuffix
-			if name == oldFile || tmp == name {
-				return true
-			}
+// compactLogAction judges how the interrupted compaction of the log is recovered, by the files in the measurement dir
+func compactLogAction(dirs []os.FileInfo, info *CompactedFileInfo) int {
+	n := 0
+	for i := range info.NewFile {
+		if newFileExist(dirs, info.NewFile[i]) {
+			n++
 		}
-		return false
+	}
+	if n == len(info.NewFile) {
+		return CompactLogCommit
+	}
+
+	count := 0
+	for i := range info.OldFile {
+		if oldFileExist(dirs, info.OldFile[i]) {
+			count++
+		}
+	}
+	if count == len(info.OldFile) {
+		return CompactLogRollback
+	}
+	return CompactLogInvalid
+}
+
+func processLog(shardDir string, info *CompactedFileInfo) error {
+	mmDir := filepath.Join(shardDir, TsspDirName, info.Name)
+	dirs, err := fileops.ReadDir(mmDir)
+	if err != nil {
+		log.Error("read dir fail", zap.String("path", mmDir), zap.Error(err))
+		return err
 	}
 
 	renameFile := func(nameInLog string) error {
@@ -796,32 +852,16 @@ func processLog(shardDir string, info *CompactedFileInfo) error {
 		return nil
 	}
 
-	n := 0
-	for i := range info.NewFile {
-		if newFileExist(info.NewFile[i]) {
-			n++
-		}
-	}
-
-	if n != len(info.NewFile) {
-		count := 0
+	switch compactLogAction(dirs, info) {
+	case CompactLogRollback:
 		for i := range info.OldFile {
-			if oldFileExist(info.OldFile[i]) {
-				count++
-			}
-		}
-
-		// all old file exist
-		if count == len(info.OldFile) {
-			for i := range info.OldFile {
-				oName := info.OldFile[i]
-				if err := renameFile(oName + tmpTsspFileSuffix); err != nil {
-					return err
-				}
+			oName := info.OldFile[i]
+			if err := renameFile(oName + tmpTsspFileSuffix); err != nil {
+				return err
 			}
-			return nil
 		}
-
+		return nil
+	case CompactLogInvalid:
 		err = fmt.Errorf("invalid compact log file, name:%v, oldFiles:%v, newFiles:%v, order:%v, dirs:%v",
 			info.Name, info.OldFile, info.NewFile, info.IsOrder, dirs)
 		log.Error(err.Error())
@@ -839,7 +879,7 @@ func processLog(shardDir string, info *CompactedFileInfo) error {
 	// delete all old files
 	for i := range info.OldFile {
 		oldName := info.OldFile[i]
-		if oldFileExist(oldName) {
+		if oldFileExist(dirs, oldName) {
 			fName := filepath.Join(mmDir, oldName)
 			if _, err := fileops.Stat(fName); os.IsNotExist(err) {
 				continue
@@ -887,7 +927,10 @@ func procCompactLog(shardDir string, logDir string) error {
 }
 
 func (m *MmsTables) MergeOutOfOrder(shId uint64) error {
-	outs := m.getOutOfOrderFiles(maxCompactor)
+	return m.mergeOutOfOrder(shId, m.getOutOfOrderFiles(maxCompactor, ""))
+}
+
+func (m *MmsTables) mergeOutOfOrder(shId uint64, outs map[string]*OutOfOrderMergeContext) error {
 	for mn, files := range outs {
 		if len(files.Seqs) == 0 {
 			continue
@@ -897,8 +940,10 @@ func (m *MmsTables) MergeOutOfOrder(shId uint64) error {
 			continue
 		}
 
+		files.task = m.queueMerge(shId, mn, files)
 		select {
 		case <-m.closed:
+			m.tasks.done(files.task)
 			log.Warn("shard closed", zap.Uint64("id", shId))
 			return fmt.Errorf("store closed, shard id: %v", shId)
 		case compLimiter <- struct{}{}:
@@ -913,10 +958,15 @@ func (m *MmsTables) MergeOutOfOrder(shId uint64) error {
 					m.wg.Done()
 					compLimiter.Release()
 					m.inMerge.Del(name)
+					m.tasks.done(ctx.task)
 					logEnd()
 					ctx.Release()
 				}()
 
+				if !m.tasks.start(ctx.task) {
+					return
+				}
+
 				if m.compactRecovery {
 					defer MergeRecovery(m.path, name, ctx)
 				}
@@ -929,10 +979,10 @@ func (m *MmsTables) MergeOutOfOrder(shId uint64) error {
 	return nil
 }
 
-func (m *MmsTables) cancelFun(dropping *int64) func() bool {
+func (m *MmsTables) cancelFun(dropping *int64, task *CompactionTask) func() bool {
 	cancelFun := func() bool {
 		closing := m.closed
-		if atomic.LoadInt64(dropping) > 0 {
+		if atomic.LoadInt64(dropping) > 0 || task.Cancelled() {
 			return true
 		}
 
@@ -1011,7 +1061,7 @@ func (m *MmsTables) mergeOutOfOrderFiles(logger *Log.Logger, ctx *OutOfOrderMerg
 		return
 	}
 
-	hlp := NewMergeHelper(logger, m.Tier(), ctx.mstName, m.path, m.cancelFun(&fs.closing))
+	hlp := NewMergeHelper(logger, m.Tier(), ctx.mstName, m.path, m.cancelFun(&fs.closing, ctx.task))
 	hlp.Conf = m.Conf
 	hlp.stat = statistics.NewMergeStatItem(ctx.mstName, ctx.shId)
 	if !hlp.ReadWaitMergedRecords(unorders) {
@@ -1095,12 +1145,15 @@ func (m *MmsTables) replaceMergedFiles(name string, logger *Log.Logger, old []TS
 	return m.ReplaceFiles(name, old, new, true, logger)
 }
 
-func (m *MmsTables) getOutOfOrderFiles(n int) map[string]*OutOfOrderMergeContext {
+func (m *MmsTables) getOutOfOrderFiles(n int, name string) map[string]*OutOfOrderMergeContext {
 	ret := make(map[string]*OutOfOrderMergeContext, n)
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
 	for k, v := range m.OutOfOrder {
+		if name != "" && k != name {
+			continue
+		}
 		v.lock.RLock()
 		if v.Len() == 0 {
 			v.lock.RUnlock()
@@ -1288,7 +1341,10 @@ func (m *MmsTables) mmsFiles(n int64) []*CompactGroup {
 	if !m.CompactionEnabled() {
 		return nil
 	}
+	return m.fullPlan(n, "")
+}
 
+func (m *MmsTables) fullPlan(n int64, name string) []*CompactGroup {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 	groups := make([]*CompactGroup, 0, n)
@@ -1297,6 +1353,10 @@ func (m *MmsTables) mmsFiles(n int64) []*CompactGroup {
 			return nil
 		}
 
+		if name != "" && k != name {
+			continue
+		}
+
 		if v.fullCompacted() {
 			continue
 		}
@@ -1361,15 +1421,21 @@ func (m *MmsTables) FullCompact(shid uint64) error {
 		return nil
 	}
 
-	plans := m.mmsFiles(n)
-	if len(plans) == 0 {
-		return nil
-	}
+	return m.fullCompact(m.mmsFiles(n), shid)
+}
 
+func (m *MmsTables) fullCompact(plans []*CompactGroup, shid uint64) error {
 	for _, plan := range plans {
 		plan.shardId = shid
+		plan.task = m.queueCompaction(CompactionTypeFull, plan)
+	}
+
+	for i, plan := range plans {
 		select {
 		case <-m.closed:
+			for _, p := range plans[i:] {
+				m.tasks.done(p.task)
+			}
 			return ErrCompStopped
 		case compLimiter <- struct{}{}:
 			m.wg.Add(1)
@@ -1386,8 +1452,13 @@ func (m *MmsTables) FullCompact(shid uint64) error {
 					m.unrefMmsTable(orderWg, inorderWg)
 					atomic.AddInt64(&fullCompactingCount, -1)
 					m.CompactDone(group.group)
+					m.tasks.done(group.task)
 				}()
 
+				if !m.tasks.start(group.task) {
+					return
+				}
+
 				fi, err := m.NewFileIterators(group)
 				if err != nil {
 					log.Error(err.Error())
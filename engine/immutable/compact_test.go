@@ -498,6 +498,99 @@ func mustTouchFiles(path string, files []string) {
 	}
 }
 
+func TestMmsTables_LevelCompact_MeasurementTTL(t *testing.T) {
+	defer SegMergeFlag(AutoCompact)
+	for _, flag := range []int32{NonStreamingCompact, StreamingCompact} {
+		SegMergeFlag(flag)
+		testCompDir := t.TempDir()
+		readcache.GetReadCacheIns().Purge()
+
+		conf := NewConfig()
+		conf.maxRowsPerSegment = 100
+		conf.SetTTLResolver(func(mst string) time.Duration {
+			if mst == "debug" || mst == "expired" {
+				return time.Hour
+			}
+			return 0
+		})
+		tier := uint64(meta.Hot)
+		store := NewTableStore(testCompDir, &tier, true, conf)
+		store.CompactionEnable()
+
+		// series 1 only has expired rows, series 2 has recent rows
+		expiredTime, recentTime := testTimeStart, time.Now().Add(-time.Minute*10)
+		startValue := 1.1
+		addFile := func(name string, sids ...uint64) {
+			fileName := NewTSSPFileName(store.NextSequence(), 0, 0, 0, true)
+			msb := AllocMsBuilder(store.path, name, conf, len(sids), fileName, store.Tier(), nil, 2)
+			for _, sid := range sids {
+				tm := &recentTime
+				if sid == 1 {
+					tm = &expiredTime
+				}
+				_, data := genTestData(sid, 1, 10, &startValue, tm)
+				if err := msb.WriteData(sid, data[sid]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			store.AddTable(msb, true, false)
+		}
+
+		for i := 0; i < LeveLMinGroupFiles[0]; i++ {
+			addFile("debug", 1, 2)
+			addFile("expired", 1)
+			addFile("business", 1, 2)
+		}
+
+		if err := store.LevelCompact(0, 1); err != nil {
+			t.Fatal(err)
+		}
+		store.Wait()
+
+		contains := func(f TSSPFile, sid uint64) bool {
+			ok, err := f.Contains(sid)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return ok
+		}
+
+		files := store.Order["debug"]
+		if files.Len() != 1 {
+			t.Fatalf("exp 1 file after compact, but:%v", files.Len())
+		}
+		if f := files.files[0]; contains(f, 1) || !contains(f, 2) {
+			t.Fatalf("the expired chunks are not dropped by compaction, flag: %d", flag)
+		}
+
+		// the measurements without ttl keep all chunks
+		files = store.Order["business"]
+		if files.Len() != 1 || !contains(files.files[0], 1) || !contains(files.files[0], 2) {
+			t.Fatalf("the chunks of the measurement without ttl are dropped, flag: %d", flag)
+		}
+
+		// all chunks of the files are expired
+		if n := store.Order["expired"].Len(); n != 0 {
+			t.Fatalf("exp all expired files dropped by compaction, but %d left, flag: %d", n, flag)
+		}
+
+		// the expired files are dropped without compaction
+		addFile("debug", 1)
+		addFile("business", 1)
+		store.DropExpiredFiles(1)
+		if n := store.Order["debug"].Len(); n != 1 {
+			t.Fatalf("exp 1 file after dropping expired files, but:%v", n)
+		}
+		if n := store.Order["business"].Len(); n != 2 {
+			t.Fatalf("exp 2 files of the measurement without ttl, but:%v", n)
+		}
+
+		if err := store.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func TestCompactLog_Validate(t *testing.T) {
 	testCompDir := t.TempDir()
 	defer fileops.RemoveAll(testCompDir)
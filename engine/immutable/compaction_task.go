@@ -0,0 +1,191 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	CompactionTypeLevel = "level"
+	CompactionTypeFull  = "full"
+	CompactionTypeMerge = "merge"
+)
+
+var compactionTaskSeq uint64
+
+// CompactionTask describes a queued or running compaction or out-of-order merge of one measurement
+type CompactionTask struct {
+	ID      uint64
+	ShardID uint64
+	Name    string
+	Type    string
+	Level   uint16
+	Files   int
+	Size    int64
+	Queued  time.Time
+	Started time.Time // zero while the task waits for a free compactor
+
+	cancelled int32
+}
+
+func (t *CompactionTask) Running() bool {
+	return !t.Started.IsZero()
+}
+
+// Cancelled is safe to call on a nil task, the compactions scheduled without tracking are never cancelled
+func (t *CompactionTask) Cancelled() bool {
+	return t != nil && atomic.LoadInt32(&t.cancelled) == 1
+}
+
+func (t *CompactionTask) cancel() {
+	atomic.StoreInt32(&t.cancelled, 1)
+}
+
+type compactionTasks struct {
+	mu    sync.RWMutex
+	tasks map[uint64]*CompactionTask
+}
+
+func (ct *compactionTasks) add(t *CompactionTask) *CompactionTask {
+	t.ID = atomic.AddUint64(&compactionTaskSeq, 1)
+	t.Queued = time.Now()
+
+	ct.mu.Lock()
+	if ct.tasks == nil {
+		ct.tasks = make(map[uint64]*CompactionTask)
+	}
+	ct.tasks[t.ID] = t
+	ct.mu.Unlock()
+	return t
+}
+
+// start marks the task running, false means it was cancelled while queued
+func (ct *compactionTasks) start(t *CompactionTask) bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if t.Cancelled() {
+		return false
+	}
+	t.Started = time.Now()
+	return true
+}
+
+func (ct *compactionTasks) done(t *CompactionTask) {
+	if t == nil {
+		return
+	}
+	ct.mu.Lock()
+	delete(ct.tasks, t.ID)
+	ct.mu.Unlock()
+}
+
+func (m *MmsTables) queueCompaction(typ string, group *CompactGroup) *CompactionTask {
+	t := &CompactionTask{
+		ShardID: group.shardId,
+		Name:    group.name,
+		Type:    typ,
+		Level:   group.toLevel - 1,
+		Files:   len(group.group),
+	}
+	for _, fn := range group.group {
+		f := m.File(group.name, fn, true)
+		if f == nil {
+			continue
+		}
+		t.Size += f.FileSize()
+		UnrefFiles(f)
+	}
+	return m.tasks.add(t)
+}
+
+func (m *MmsTables) queueMerge(shid uint64, name string, ctx *OutOfOrderMergeContext) *CompactionTask {
+	return m.tasks.add(&CompactionTask{
+		ShardID: shid,
+		Name:    name,
+		Type:    CompactionTypeMerge,
+		Files:   len(ctx.Seqs),
+		Size:    ctx.size,
+	})
+}
+
+// CompactionTasks returns a copy of the queued and running compactions and merges ordered by id
+func (m *MmsTables) CompactionTasks() []CompactionTask {
+	m.tasks.mu.RLock()
+	ret := make([]CompactionTask, 0, len(m.tasks.tasks))
+	for _, t := range m.tasks.tasks {
+		ret = append(ret, CompactionTask{
+			ID:      t.ID,
+			ShardID: t.ShardID,
+			Name:    t.Name,
+			Type:    t.Type,
+			Level:   t.Level,
+			Files:   t.Files,
+			Size:    t.Size,
+			Queued:  t.Queued,
+			Started: t.Started,
+		})
+	}
+	m.tasks.mu.RUnlock()
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ID < ret[j].ID
+	})
+	return ret
+}
+
+// CancelCompactions cancels the compactions and merges of the measurement, or of all measurements if name is empty.
+// The queued tasks are skipped and the running ones stop at their next check, the old files are kept.
+func (m *MmsTables) CancelCompactions(name string) int {
+	n := 0
+	m.tasks.mu.Lock()
+	for _, t := range m.tasks.tasks {
+		if name != "" && t.Name != name {
+			continue
+		}
+		t.cancel()
+		n++
+	}
+	m.tasks.mu.Unlock()
+	return n
+}
+
+// Compact schedules the level compactions, or a full compaction, and the out-of-order merge of the measurement,
+// or of all measurements if name is empty. Unlike the background compactions it ignores the compaction switches.
+func (m *MmsTables) Compact(shid uint64, name string, full bool) error {
+	outs := m.getOutOfOrderFiles(maxCompactor, name)
+	if err := m.mergeOutOfOrder(shid, outs); err != nil {
+		return err
+	}
+
+	if full {
+		m.mu.RLock()
+		n := int64(len(m.Order))
+		m.mu.RUnlock()
+		return m.fullCompact(m.fullPlan(n, name), shid)
+	}
+
+	for _, level := range LevelCompactRule {
+		if err := m.levelCompact(m.levelPlan(level, name), shid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/engine/immutable/readcache"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+)
+
+func TestMmsTables_CompactionTasks(t *testing.T) {
+	testCompDir := t.TempDir()
+	readcache.GetReadCacheIns().Purge()
+
+	conf := NewConfig()
+	tier := uint64(meta.Hot)
+	store := NewTableStore(testCompDir, &tier, true, conf)
+	defer store.Close()
+	// the manual compactions ignore the compaction switch
+	store.CompactionDisable()
+
+	startTime := time.Now().Add(-time.Hour)
+	startValue := 1.1
+	addFile := func(name string) {
+		fileName := NewTSSPFileName(store.NextSequence(), 0, 0, 0, true)
+		msb := AllocMsBuilder(store.path, name, conf, 1, fileName, store.Tier(), nil, 2)
+		_, data := genTestData(1, 1, 10, &startValue, &startTime)
+		if err := msb.WriteData(1, data[1]); err != nil {
+			t.Fatal(err)
+		}
+		store.AddTable(msb, true, false)
+	}
+	for i := 0; i < LeveLMinGroupFiles[0]; i++ {
+		addFile("cpu")
+		addFile("mem")
+	}
+
+	plans := store.levelPlan(0, "mem")
+	if len(plans) != 1 {
+		t.Fatalf("exp 1 plan of mem, but: %d", len(plans))
+	}
+	plans[0].shardId = 1
+	task := store.queueCompaction(CompactionTypeLevel, plans[0])
+	tasks := store.CompactionTasks()
+	if len(tasks) != 1 {
+		t.Fatalf("exp 1 queued task, but: %d", len(tasks))
+	}
+	if tk := tasks[0]; tk.ShardID != 1 || tk.Name != "mem" || tk.Type != CompactionTypeLevel || tk.Level != 0 ||
+		tk.Files != LeveLMinGroupFiles[0] || tk.Size <= 0 || tk.Running() {
+		t.Fatalf("unexpected task: %+v", tk)
+	}
+
+	if n := store.CancelCompactions("cpu"); n != 0 {
+		t.Fatalf("exp no task of cpu cancelled, but: %d", n)
+	}
+	if n := store.CancelCompactions(""); n != 1 {
+		t.Fatalf("exp 1 task cancelled, but: %d", n)
+	}
+	if store.tasks.start(task) {
+		t.Fatalf("the cancelled task is started")
+	}
+	store.tasks.done(task)
+	store.CompactDone(plans[0].group)
+	plans[0].release()
+	if n := len(store.CompactionTasks()); n != 0 {
+		t.Fatalf("exp no task left, but: %d", n)
+	}
+
+	if err := store.Compact(1, "cpu", false); err != nil {
+		t.Fatal(err)
+	}
+	store.Wait()
+	if n := store.Order["cpu"].Len(); n != 1 {
+		t.Fatalf("exp 1 file of cpu after compact, but: %d", n)
+	}
+	if n := store.Order["mem"].Len(); n != LeveLMinGroupFiles[0] {
+		t.Fatalf("exp the files of mem untouched, but: %d", n)
+	}
+
+	if err := store.Compact(1, "", true); err != nil {
+		t.Fatal(err)
+	}
+	store.Wait()
+	if n := store.Order["mem"].Len(); n != 1 {
+		t.Fatalf("exp 1 file of mem after full compact, but: %d", n)
+	}
+	if n := len(store.CompactionTasks()); n != 0 {
+		t.Fatalf("exp no task left, but: %d", n)
+	}
+}
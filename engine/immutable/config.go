@@ -18,7 +18,9 @@ package immutable
 
 import (
 	"math"
+	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/openGemini/openGemini/lib/config"
 	"go.uber.org/zap"
@@ -29,6 +31,11 @@ const (
 	DefaultMaxChunkMetaItemSize  = 256 * 1024
 	DefaultMaxChunkMetaItemCount = 512
 
+	FloatCodecAuto    = floatCompressedAuto
+	FloatCodecGorilla = floatCompressedGorilla
+	FloatCodecChimp   = floatCompressedChimp
+	FloatCodecALP     = floatCompressedALP
+
 	NonStreamingCompact = 2
 	StreamingCompact    = 1
 	AutoCompact         = 0
@@ -41,6 +48,8 @@ var (
 	cacheMetaData     int32 = 0
 	maxTSSPFileSize   int64 = defaultFileSizeLimit
 	streamingCompact  int32 = AutoCompact
+	floatCodec        int32 = FloatCodecGorilla
+	stringDictEnabled int32 = 0
 )
 
 func SetMaxRowsPerSegment(maxRowsPerSegmentLimit int) {
@@ -75,6 +84,12 @@ type Config struct {
 	cacheDataBlock bool
 	// Whether to cache meta blocks in hot shard
 	cacheMetaData bool
+	// float codec of the measurements that do not set one
+	floatCodec int
+	// returns the float codec set by a measurement
+	floatCodecOf func(mst string) (int, bool)
+	// returns the ttl set by a measurement
+	ttlOf func(mst string) time.Duration
 }
 
 func NewConfig() *Config {
@@ -87,10 +102,52 @@ func NewConfig() *Config {
 		fileSizeLimit:         atomic.LoadInt64(&maxTSSPFileSize),
 		cacheDataBlock:        atomic.LoadInt32(&cacheDataBlock) > 0,
 		cacheMetaData:         atomic.LoadInt32(&cacheMetaData) > 0,
+		floatCodec:            FloatCodec(),
 	}
 	return c
 }
 
+func (c *Config) SetFloatCodecResolver(fn func(mst string) (int, bool)) {
+	c.floatCodecOf = fn
+}
+
+// MeasurementFloatCodec returns the float codec set by the measurement
+func (c *Config) MeasurementFloatCodec(mst string) (int, bool) {
+	if c.floatCodecOf == nil {
+		return 0, false
+	}
+	return c.floatCodecOf(mst)
+}
+
+// FloatCodec returns the float codec used to write the measurement
+func (c *Config) FloatCodec(mst string) int {
+	if codec, ok := c.MeasurementFloatCodec(mst); ok {
+		return codec
+	}
+	return c.floatCodec
+}
+
+func (c *Config) SetTTLResolver(fn func(mst string) time.Duration) {
+	c.ttlOf = fn
+}
+
+// MeasurementTTL returns how long the rows of the measurement are kept, zero means as long as the retention policy
+func (c *Config) MeasurementTTL(mst string) time.Duration {
+	if c.ttlOf == nil {
+		return 0
+	}
+	return c.ttlOf(mst)
+}
+
+// ExpireTime returns the time before which the rows of the measurement are expired, zero if the measurement sets no ttl
+func (c *Config) ExpireTime(mst string) int64 {
+	ttl := c.MeasurementTTL(mst)
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(-ttl).UnixNano()
+}
+
 func (c *Config) SetMaxRowsPerSegment(maxRowsPerSegmentLimit int) {
 	n := maxRowsPerSegmentLimit / 8
 	if maxRowsPerSegmentLimit%8 > 0 {
@@ -152,3 +209,46 @@ func SegMergeFlag(v int32) {
 func MergeFlag() int32 {
 	return atomic.LoadInt32(&streamingCompact)
 }
+
+// ParseFloatCodec returns the float codec of the name, the empty name means gorilla
+func ParseFloatCodec(name string) (int, bool) {
+	switch strings.ToLower(name) {
+	case config.FloatCodecAuto:
+		return FloatCodecAuto, true
+	case "", config.FloatCodecGorilla:
+		return FloatCodecGorilla, true
+	case config.FloatCodecChimp:
+		return FloatCodecChimp, true
+	case config.FloatCodecALP:
+		return FloatCodecALP, true
+	default:
+		return 0, false
+	}
+}
+
+func SetFloatCodec(name string) {
+	codec, ok := ParseFloatCodec(name)
+	if !ok {
+		log.Warn("unknown float codec, use gorilla", zap.String("codec", name))
+		codec = FloatCodecGorilla
+	}
+	atomic.StoreInt32(&floatCodec, int32(codec))
+	log.Info("Set floatCodec", zap.String("codec", name))
+}
+
+func FloatCodec() int {
+	return int(atomic.LoadInt32(&floatCodec))
+}
+
+func SetStringDictionary(en bool) {
+	v := int32(0)
+	if en {
+		v = 1
+	}
+	atomic.StoreInt32(&stringDictEnabled, v)
+	log.Info("Set stringDictionary", zap.Bool("enabled", en))
+}
+
+func StringDictionary() bool {
+	return atomic.LoadInt32(&stringDictEnabled) == 1
+}
@@ -184,10 +184,20 @@ type CoderContext struct {
 	stringCoder *String
 	boolCoder   *Boolean
 	buf         []byte
+	floatCodec  int
+	stringDict  bool
 }
 
 func NewCoderContext() *CoderContext {
-	return &CoderContext{}
+	return &CoderContext{floatCodec: FloatCodec(), stringDict: StringDictionary()}
+}
+
+func (ctx *CoderContext) SetFloatCodec(codec int) {
+	ctx.floatCodec = codec
+}
+
+func (ctx *CoderContext) SetStringDictionary(en bool) {
+	ctx.stringDict = en
 }
 
 func (ctx *CoderContext) Release() {
@@ -250,6 +260,7 @@ func EncodeFloatBlock(in []byte, out []byte, ctx *CoderContext) ([]byte, error)
 	if ctx.floatCoder == nil {
 		ctx.floatCoder = GetFloatCoder()
 	}
+	ctx.floatCoder.SetEncodingType(ctx.floatCodec)
 	return ctx.floatCoder.Encoding(in, out)
 }
 
@@ -340,6 +351,12 @@ func EncodeStringBlock(in []byte, offset []uint32, out []byte, ctx *CoderContext
 		ctx.buf = ctx.buf[:0]
 	}
 
+	if ctx.stringDict {
+		if out, ok := ctx.stringCoder.encodingDictionary(in, offset, out); ok {
+			return out, nil
+		}
+	}
+
 	src := packString(in, offset, ctx)
 	return ctx.stringCoder.Encoding(src, out)
 }
@@ -354,6 +371,14 @@ func DecodeStringBlock(in []byte, out *[]byte, dstOffset *[]uint32, ctx *CoderCo
 		ctx.stringCoder = GetStringCoder()
 	}
 
+	if isStringDictType(int(in[0] >> 4)) {
+		*out, *dstOffset, err = ctx.stringCoder.decodingDictionary(in, *out, (*dstOffset)[:0])
+		if err != nil {
+			return nil, nil, err
+		}
+		return *out, *dstOffset, nil
+	}
+
 	ctx.buf, err = ctx.stringCoder.Decoding(in, ctx.buf[:0])
 	if err != nil {
 		return nil, nil, err
@@ -19,6 +19,8 @@ package immutable
 import (
 	safeRand "crypto/rand"
 	"fmt"
+	"math"
+	"math/rand"
 	"reflect"
 	"sort"
 	"testing"
@@ -180,6 +182,98 @@ func TestEncoding_FloatBlock_SameValues(t *testing.T) {
 	testFloat(false)
 }
 
+func TestEncoding_FloatBlock_Codecs(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	decimals := make([]float64, 1000)
+	randoms := make([]float64, 1000)
+	mixed := make([]float64, 1000)
+	periodic := make([]float64, 1000)
+	for i := range decimals {
+		decimals[i] = float64(rnd.Intn(100000)) / 100
+		randoms[i] = rnd.NormFloat64() * 1e6
+		mixed[i] = decimals[i]
+		if i%20 == 0 {
+			mixed[i] = randoms[i]
+		}
+		periodic[i] = randoms[i%37]
+	}
+	special := []float64{0, math.Copysign(0, -1), math.Inf(1), math.Inf(-1), math.NaN(), math.MaxFloat64, math.SmallestNonzeroFloat64, 1.5}
+
+	for _, codec := range []int{FloatCodecAuto, FloatCodecGorilla, FloatCodecChimp, FloatCodecALP} {
+		for name, values := range map[string][]float64{
+			"decimals": decimals,
+			"randoms":  randoms,
+			"mixed":    mixed,
+			"periodic": periodic,
+			"special":  special,
+			"one":      decimals[:1],
+			"two":      decimals[:2],
+		} {
+			if name == "special" && codec != FloatCodecChimp {
+				// NaN terminates the gorilla stream, which is the fallback of alp
+				values = values[:4]
+			}
+
+			ctx := NewCoderContext()
+			ctx.SetFloatCodec(codec)
+			out, err := EncodeFloatBlock(record.Float64Slice2byte(values), nil, ctx)
+			if err != nil {
+				t.Fatalf("encode %s with codec %d failed: %v", name, codec, err)
+			}
+			if codec == FloatCodecChimp && int(out[0]>>4) != floatCompressedChimp {
+				t.Fatalf("unexpected encoding type %d of %s", out[0]>>4, name)
+			}
+
+			var decOut []byte
+			got, err := DecodeFloatBlock(out, &decOut, NewCoderContext())
+			if err != nil {
+				t.Fatalf("decode %s with codec %d failed: %v", name, codec, err)
+			}
+			if len(got) != len(values) {
+				t.Fatalf("unexpected length of %s with codec %d, exp: %d, got: %d", name, codec, len(values), len(got))
+			}
+			for i := range values {
+				if math.Float64bits(got[i]) != math.Float64bits(values[i]) {
+					t.Fatalf("unexpected value %d of %s with codec %d, exp: %v, got: %v", i, name, codec, values[i], got[i])
+				}
+			}
+		}
+	}
+
+	// decimals are stored as integers, values that are not decimals fall back to gorilla
+	ctx := NewCoderContext()
+	ctx.SetFloatCodec(FloatCodecAuto)
+	out, err := EncodeFloatBlock(record.Float64Slice2byte(decimals), nil, ctx)
+	if err != nil || int(out[0]>>4) != floatCompressedALP {
+		t.Fatalf("decimals are not encoded by alp, err: %v", err)
+	}
+	ctx.SetFloatCodec(FloatCodecALP)
+	out, err = EncodeFloatBlock(record.Float64Slice2byte(randoms), nil, ctx)
+	if err != nil || int(out[0]>>4) != floatCompressedGorilla {
+		t.Fatalf("randoms are not encoded by gorilla, err: %v", err)
+	}
+}
+
+func TestParseFloatCodec(t *testing.T) {
+	for name, exp := range map[string]int{"": FloatCodecGorilla, "auto": FloatCodecAuto, "Gorilla": FloatCodecGorilla, "chimp": FloatCodecChimp, "ALP": FloatCodecALP} {
+		codec, ok := ParseFloatCodec(name)
+		if !ok || codec != exp {
+			t.Fatalf("unexpected codec of %s, exp: %d, got: %d", name, exp, codec)
+		}
+	}
+	if _, ok := ParseFloatCodec("zstd"); ok {
+		t.Fatalf("zstd is not a float codec")
+	}
+
+	conf := NewConfig()
+	conf.SetFloatCodecResolver(func(mst string) (int, bool) {
+		return FloatCodecChimp, mst == "cpu"
+	})
+	if conf.FloatCodec("cpu") != FloatCodecChimp || conf.FloatCodec("mem") != FloatCodec() {
+		t.Fatalf("unexpected float codec of measurements")
+	}
+}
+
 func TestEncoding_IntBlock(t *testing.T) {
 	testInt := func(prefix []byte, values []int64) {
 		var err error
@@ -536,6 +630,81 @@ func TestEncoding_StringBlock_Uncompressed(t *testing.T) {
 	uncompTest(2)
 }
 
+func TestEncoding_StringBlock_Dictionary(t *testing.T) {
+	gen := func(rows int, value func(i int) string) ([]byte, []uint32) {
+		values := make([]byte, 0, rows*8)
+		offset := make([]uint32, 0, rows)
+		for i := 0; i < rows; i++ {
+			offset = append(offset, uint32(len(values)))
+			values = append(values, value(i)...)
+		}
+		return values, offset
+	}
+	status := []string{"running", "stopped", "", "failed"}
+
+	cases := []struct {
+		name  string
+		rows  int
+		value func(i int) string
+		exp   int
+	}{
+		{"enum", 1000, func(i int) string { return status[i*7%len(status)] }, stringDictionary},
+		{"runs", 1000, func(i int) string { return status[i/100%len(status)] }, stringRunLength},
+		{"wide codes", 4000, func(i int) string { return fmt.Sprintf("host-%d", i%600) }, stringDictionary},
+		{"high cardinality", 1000, func(i int) string { return fmt.Sprintf("value-%d", i%500) }, stringCompressedSnappy},
+		{"few rows", 8, func(i int) string { return "running" }, stringCompressedSnappy},
+	}
+
+	// the dictionary is opt-in
+	values, offset := gen(1000, func(i int) string { return status[i*7%len(status)] })
+	out, err := EncodeStringBlock(values, offset, nil, NewCoderContext())
+	if err != nil || int(out[0]>>4) != stringCompressedSnappy {
+		t.Fatalf("string dictionary is used by default, err: %v", err)
+	}
+
+	ctx := NewCoderContext()
+	ctx.SetStringDictionary(true)
+	for _, c := range cases {
+		values, offset := gen(c.rows, c.value)
+		prefix := []byte("prefix")
+		out, err := EncodeStringBlock(values, offset, append([]byte{}, prefix...), ctx)
+		if err != nil {
+			t.Fatalf("encode %s failed: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(out[:len(prefix)], prefix) {
+			t.Fatalf("encode output prefix of %s not eq, exp:%v, get:%v", c.name, prefix, out[:len(prefix)])
+		}
+		if ty := int(out[len(prefix)] >> 4); ty != c.exp {
+			t.Fatalf("unexpected encoding type of %s, exp:%v, get:%v", c.name, c.exp, ty)
+		}
+
+		var decOff []uint32
+		decOut := append([]byte{}, prefix...)
+		decOut, decOff, err = DecodeStringBlock(out[len(prefix):], &decOut, &decOff, NewCoderContext())
+		if err != nil {
+			t.Fatalf("decode %s failed: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(decOff, offset) {
+			t.Fatalf("unexpected offsets of %s:\n\tgot: %v\n\texp: %v\n", c.name, decOff, offset)
+		}
+		if !reflect.DeepEqual(decOut[len(prefix):], values) {
+			t.Fatalf("unexpected values of %s:\n\tgot: %s\n\texp: %s\n", c.name, decOut[len(prefix):], values)
+		}
+	}
+
+	// corrupted data must not panic
+	values, offset = gen(1000, func(i int) string { return status[i/100%len(status)] })
+	out, err = EncodeStringBlock(values, offset, nil, ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var decOut []byte
+	var decOff []uint32
+	if _, _, err = DecodeStringBlock(out[:len(out)-3], &decOut, &decOff, ctx); err == nil {
+		t.Fatalf("decode corrupted data should be fail")
+	}
+}
+
 func TestEncoding_Timestamp_Second(t *testing.T) {
 	valueCount := 1000
 	tmTest := func(precision time.Duration, readTm bool, prefix []byte) {
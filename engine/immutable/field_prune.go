@@ -0,0 +1,335 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"github.com/openGemini/openGemini/lib/numberenc"
+	"github.com/openGemini/openGemini/lib/record"
+	"github.com/openGemini/openGemini/open_src/influx/influxql"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+)
+
+// chunkMayMatch reports whether any row of the chunk may satisfy cond, judged by the
+// pre-aggregated min/max and count of its numeric fields.
+// Conditions which can not be judged by the pre-aggregated values are taken as satisfiable.
+func chunkMayMatch(cm *ChunkMeta, cond influxql.Expr, filterOpts *FilterOptions, decs *ReadContext) bool {
+	switch expr := cond.(type) {
+	case *influxql.ParenExpr:
+		return chunkMayMatch(cm, expr.Expr, filterOpts, decs)
+	case *influxql.BinaryExpr:
+		switch expr.Op {
+		case influxql.AND:
+			return chunkMayMatch(cm, expr.LHS, filterOpts, decs) && chunkMayMatch(cm, expr.RHS, filterOpts, decs)
+		case influxql.OR:
+			return chunkMayMatch(cm, expr.LHS, filterOpts, decs) || chunkMayMatch(cm, expr.RHS, filterOpts, decs)
+		default:
+			return fieldMayMatch(cm, expr, filterOpts, decs)
+		}
+	default:
+		return true
+	}
+}
+
+// numericCondition splits a comparison of a numeric field and a numeric literal,
+// 90 < cpu is returned as cpu > 90
+func numericCondition(expr *influxql.BinaryExpr, filterOpts *FilterOptions) (*influxql.VarRef, influxql.Token, influxql.Expr, bool) {
+	op := expr.Op
+	ref, ok := expr.LHS.(*influxql.VarRef)
+	lit := expr.RHS
+	if !ok {
+		if ref, ok = expr.RHS.(*influxql.VarRef); !ok {
+			return nil, op, nil, false
+		}
+		lit = expr.LHS
+		op = reverseCompareOp(op)
+	}
+	switch lit.(type) {
+	case *influxql.IntegerLiteral, *influxql.NumberLiteral:
+	default:
+		return nil, op, nil, false
+	}
+	for _, tag := range filterOpts.filterTags {
+		if tag == ref.Val {
+			return nil, op, nil, false
+		}
+	}
+	return ref, op, lit, true
+}
+
+func numericColumnMeta(cm *ChunkMeta, name string) *ColumnMeta {
+	for i := range cm.colMeta[:len(cm.colMeta)-1] {
+		if cm.colMeta[i].name == name {
+			colMeta := &cm.colMeta[i]
+			if colMeta.ty != influx.Field_Type_Int && colMeta.ty != influx.Field_Type_Float {
+				return nil
+			}
+			return colMeta
+		}
+	}
+	return nil
+}
+
+func fieldMayMatch(cm *ChunkMeta, expr *influxql.BinaryExpr, filterOpts *FilterOptions, decs *ReadContext) bool {
+	ref, op, lit, ok := numericCondition(expr, filterOpts)
+	if !ok {
+		return true
+	}
+	colMeta := numericColumnMeta(cm, ref.Val)
+	if colMeta == nil {
+		return true
+	}
+
+	if decs.preAggBuilders == nil {
+		decs.preAggBuilders = newPreAggBuilders()
+	}
+	cb := decs.preAggBuilders.aggBuilder(&record.Field{Name: colMeta.name, Type: int(colMeta.ty)})
+	if _, err := cb.unmarshal(colMeta.preAgg); err != nil {
+		return true
+	}
+	if cb.count() == 0 {
+		// a condition on a field of null values is never satisfied
+		return false
+	}
+	minV, _ := cb.min()
+	maxV, _ := cb.max()
+	return rangeMayMatch(op, lit, minV, maxV)
+}
+
+// rangeMayMatch reports whether a value in [minV, maxV] may satisfy "value op lit",
+// minV and maxV are both int64 or both float64
+func rangeMayMatch(op influxql.Token, lit influxql.Expr, minV, maxV interface{}) bool {
+	var cmpMin, cmpMax int
+	switch v := lit.(type) {
+	case *influxql.IntegerLiteral:
+		if minI, ok := minV.(int64); ok {
+			cmpMin, cmpMax = compareInteger(minI, v.Val), compareInteger(maxV.(int64), v.Val)
+		} else {
+			cmpMin, cmpMax = compareFloat(minV.(float64), float64(v.Val)), compareFloat(maxV.(float64), float64(v.Val))
+		}
+	case *influxql.NumberLiteral:
+		if minI, ok := minV.(int64); ok {
+			cmpMin, cmpMax = compareFloat(float64(minI), v.Val), compareFloat(float64(maxV.(int64)), v.Val)
+		} else {
+			cmpMin, cmpMax = compareFloat(minV.(float64), v.Val), compareFloat(maxV.(float64), v.Val)
+		}
+	default:
+		return true
+	}
+
+	switch op {
+	case influxql.GT:
+		return cmpMax > 0
+	case influxql.GTE:
+		return cmpMax >= 0
+	case influxql.LT:
+		return cmpMin < 0
+	case influxql.LTE:
+		return cmpMin <= 0
+	case influxql.EQ:
+		return cmpMin <= 0 && cmpMax >= 0
+	case influxql.NEQ:
+		return cmpMin != 0 || cmpMax != 0
+	default:
+		return true
+	}
+}
+
+// segmentMayMatch reports whether any row of the current segment may satisfy cond,
+// judged by the min/max of the numeric fields and the dictionary of the dictionary encoded
+// string fields in the conditions. Only the columns of these fields are read.
+func (l *Location) segmentMayMatch(cond influxql.Expr, filterOpts *FilterOptions) bool {
+	switch expr := cond.(type) {
+	case *influxql.ParenExpr:
+		return l.segmentMayMatch(expr.Expr, filterOpts)
+	case *influxql.BinaryExpr:
+		switch expr.Op {
+		case influxql.AND:
+			return l.segmentMayMatch(expr.LHS, filterOpts) && l.segmentMayMatch(expr.RHS, filterOpts)
+		case influxql.OR:
+			return l.segmentMayMatch(expr.LHS, filterOpts) || l.segmentMayMatch(expr.RHS, filterOpts)
+		case influxql.EQ, influxql.NEQ:
+			return l.stringFieldMayMatch(expr, filterOpts) && l.numericFieldMayMatch(expr, filterOpts)
+		case influxql.GT, influxql.GTE, influxql.LT, influxql.LTE:
+			return l.numericFieldMayMatch(expr, filterOpts)
+		default:
+			return true
+		}
+	default:
+		return true
+	}
+}
+
+func (l *Location) numericFieldMayMatch(expr *influxql.BinaryExpr, filterOpts *FilterOptions) bool {
+	ref, op, lit, ok := numericCondition(expr, filterOpts)
+	if !ok {
+		return true
+	}
+	colMeta := numericColumnMeta(l.meta, ref.Val)
+	if colMeta == nil || l.segPos >= len(colMeta.entries) {
+		return true
+	}
+
+	offset, size := colMeta.entries[l.segPos].offsetSize()
+	col := l.decs.prunedColumn(offset)
+	if col == nil {
+		data, err := l.r.ReadData(offset, size, &l.decs.dictBuf)
+		if err != nil || len(data) == 0 || data[0] != colMeta.ty {
+			return true
+		}
+		if col = l.keepColumn(colMeta, offset, data); col == nil {
+			return true
+		}
+	}
+
+	if colMeta.ty == influx.Field_Type_Int {
+		values := col.IntegerValues()
+		if len(values) == 0 {
+			return false
+		}
+		minV, maxV := values[0], values[0]
+		for _, v := range values[1:] {
+			if v < minV {
+				minV = v
+			} else if v > maxV {
+				maxV = v
+			}
+		}
+		return rangeMayMatch(op, lit, minV, maxV)
+	}
+
+	values := col.FloatValues()
+	if len(values) == 0 {
+		return false
+	}
+	minV, maxV := values[0], values[0]
+	for _, v := range values[1:] {
+		if v < minV {
+			minV = v
+		} else if v > maxV {
+			maxV = v
+		}
+	}
+	return rangeMayMatch(op, lit, minV, maxV)
+}
+
+func (l *Location) stringFieldMayMatch(expr *influxql.BinaryExpr, filterOpts *FilterOptions) bool {
+	ref, ok := expr.LHS.(*influxql.VarRef)
+	lit, isStr := expr.RHS.(*influxql.StringLiteral)
+	if !ok || !isStr {
+		ref, ok = expr.RHS.(*influxql.VarRef)
+		lit, isStr = expr.LHS.(*influxql.StringLiteral)
+		if !ok || !isStr {
+			return true
+		}
+	}
+	for _, tag := range filterOpts.filterTags {
+		if tag == ref.Val {
+			return true
+		}
+	}
+
+	cm := l.meta
+	var colMeta *ColumnMeta
+	for i := range cm.colMeta[:len(cm.colMeta)-1] {
+		if cm.colMeta[i].name == ref.Val {
+			colMeta = &cm.colMeta[i]
+			break
+		}
+	}
+	if colMeta == nil || colMeta.ty != influx.Field_Type_String || l.segPos >= len(colMeta.entries) {
+		return true
+	}
+
+	offset, size := colMeta.entries[l.segPos].offsetSize()
+	equal := expr.Op == influxql.EQ
+	if col := l.decs.prunedColumn(offset); col != nil {
+		// decoded by another condition of the segment
+		return stringColumnMayMatch(col, lit.Val, equal)
+	}
+	data, err := l.r.ReadData(offset, size, &l.decs.dictBuf)
+	if err != nil || len(data) < 5 || data[0] != colMeta.ty {
+		return true
+	}
+	// skip the type, nil bitmap, bitmap offset and nil count of the column
+	pos := 1 + 4 + int(numberenc.UnmarshalUint32(data[1:])) + 4 + 4
+	if len(data) < pos {
+		return true
+	}
+
+	if l.decs.stringDict == nil {
+		l.decs.stringDict = &stringDict{}
+	}
+	if !stringDictMayMatch(data[pos:], lit.Val, equal, l.decs.stringDict) {
+		return false
+	}
+	l.keepColumn(colMeta, offset, data)
+	return true
+}
+
+func stringColumnMayMatch(col *record.ColVal, val string, equal bool) bool {
+	for i := 0; i < col.Len; i++ {
+		v, isNil := col.StringValueUnsafe(i)
+		if !isNil && (v == val) == equal {
+			return true
+		}
+	}
+	return false
+}
+
+// keepColumn decodes the column data of the current segment read by a field condition,
+// the segment reader takes the decoded column instead of reading and decoding it again
+func (l *Location) keepColumn(colMeta *ColumnMeta, offset int64, data []byte) *record.ColVal {
+	col := l.decs.allocPrunedColumn(offset)
+	if err := decodeColumnData(&record.Field{Name: colMeta.name, Type: int(colMeta.ty)}, data, col, l.decs, false); err != nil {
+		l.decs.resetPrunedColumns()
+		return nil
+	}
+	return col
+}
+
+func reverseCompareOp(op influxql.Token) influxql.Token {
+	switch op {
+	case influxql.GT:
+		return influxql.LT
+	case influxql.GTE:
+		return influxql.LTE
+	case influxql.LT:
+		return influxql.GT
+	case influxql.LTE:
+		return influxql.GTE
+	default:
+		return op
+	}
+}
+
+func compareInteger(a, b int64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+func compareFloat(a, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
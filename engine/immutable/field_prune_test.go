@@ -0,0 +1,258 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/numberenc"
+	"github.com/openGemini/openGemini/lib/record"
+	"github.com/openGemini/openGemini/open_src/influx/influxql"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/stretchr/testify/require"
+)
+
+func buildPruneChunkMeta() *ChunkMeta {
+	times := []int64{1, 2, 3, 4}
+	var intCol, floatCol, nilCol record.ColVal
+	intCol.AppendIntegers(10, 20, 30, 40)
+	floatCol.AppendFloats(1.5, 2.5, 3.5, 4.5)
+	nilCol.AppendFloatNulls(4)
+
+	colMeta := func(name string, ty int, col *record.ColVal) ColumnMeta {
+		b := acquireColumnBuilder(ty)
+		defer ReleaseColumnBuilder(b)
+		b.reset()
+		b.addValues(col, times)
+		return ColumnMeta{name: name, ty: byte(ty), preAgg: b.marshal(nil)}
+	}
+
+	return &ChunkMeta{
+		segCount: 1,
+		colMeta: []ColumnMeta{
+			colMeta("f_float", influx.Field_Type_Float, &floatCol),
+			colMeta("f_int", influx.Field_Type_Int, &intCol),
+			colMeta("f_nil", influx.Field_Type_Float, &nilCol),
+			{name: record.TimeField, ty: influx.Field_Type_Int},
+		},
+	}
+}
+
+func TestChunkMayMatch(t *testing.T) {
+	cm := buildPruneChunkMeta()
+	decs := NewReadContext(true)
+	defer decs.Release()
+	filterOpts := &FilterOptions{filterTags: []string{"host"}}
+
+	cases := []struct {
+		cond  string
+		match bool
+	}{
+		{"f_int > 40", false},
+		{"f_int >= 40", true},
+		{"f_int < 10", false},
+		{"f_int <= 10", true},
+		{"f_int = 25", true},
+		{"f_int = 45", false},
+		{"f_int != 45", true},
+		{"50 < f_int", false},
+		{"f_int > 39.5", true},
+		{"f_float > 4.5", false},
+		{"f_float < 2", true},
+		{"f_float = 5", false},
+		{"f_nil > 0", false},
+		{"f_int > 100 AND f_float > 1", false},
+		{"f_int > 100 OR f_float > 1", true},
+		{"(f_int > 100 OR f_float > 10) AND host = 'a'", false},
+		{"host = 'a'", true},
+		{"f_unknown > 100", true},
+		{"f_int + 1 > 100", true},
+	}
+	for _, c := range cases {
+		cond, err := influxql.ParseExpr(c.cond)
+		require.NoError(t, err)
+		require.Equal(t, c.match, chunkMayMatch(cm, cond, filterOpts, decs), c.cond)
+	}
+}
+
+func TestLocation_PruneByField(t *testing.T) {
+	decs := NewReadContext(true)
+	defer decs.Release()
+	cond, err := influxql.ParseExpr("f_int > 100")
+	require.NoError(t, err)
+	filterOpts := NewFilterOpts(cond, map[string]interface{}{}, []int{1}, nil, nil)
+
+	loc := NewLocation(nil, decs)
+	loc.meta = buildPruneChunkMeta()
+	loc.pruneByField(filterOpts)
+	require.False(t, loc.next())
+	require.Equal(t, int64(1), decs.PrunedChunks())
+	require.Equal(t, int64(1), decs.PrunedSegments())
+
+	loc.ResetMeta()
+	loc.meta = buildPruneChunkMeta()
+	filterOpts.cond, err = influxql.ParseExpr("f_int > 10")
+	require.NoError(t, err)
+	loc.pruneByField(filterOpts)
+	require.True(t, loc.next())
+	require.Equal(t, int64(1), decs.PrunedChunks())
+}
+
+type segmentDataFile struct {
+	TSSPFile
+	data []byte
+}
+
+func (f *segmentDataFile) ReadData(offset int64, size uint32, dst *[]byte) ([]byte, error) {
+	return f.data[offset : offset+int64(size)], nil
+}
+
+// encodeSegmentColumn appends the column data of a segment: type, nil bitmap, bitmap offset, nil count and the encoded values
+func encodeSegmentColumn(t *testing.T, data []byte, typ int, col *record.ColVal) []byte {
+	data = append(data, byte(typ))
+	nilBitmap, bitmapOffset := col.SubBitmapBytes()
+	data = numberenc.MarshalUint32Append(data, uint32(len(nilBitmap)))
+	data = append(data, nilBitmap...)
+	data = numberenc.MarshalUint32Append(data, uint32(bitmapOffset))
+	data = numberenc.MarshalUint32Append(data, uint32(col.NullN()))
+
+	var err error
+	switch typ {
+	case influx.Field_Type_String:
+		ctx := NewCoderContext()
+		ctx.SetStringDictionary(true)
+		data, err = EncodeStringBlock(col.Val, col.Offset, data, ctx)
+	case influx.Field_Type_Int:
+		data, err = EncodeIntegerBlock(col.Val, data, NewCoderContext())
+	case influx.Field_Type_Float:
+		data, err = EncodeFloatBlock(col.Val, data, NewCoderContext())
+	}
+	require.NoError(t, err)
+	return data
+}
+
+func TestLocation_SegmentMayMatch(t *testing.T) {
+	var col record.ColVal
+	for i := 0; i < 64; i++ {
+		col.AppendString([]string{"ok", "warn"}[i%2])
+	}
+
+	data := encodeSegmentColumn(t, nil, influx.Field_Type_String, &col)
+
+	cm := &ChunkMeta{colMeta: []ColumnMeta{
+		{name: "status", ty: influx.Field_Type_String, entries: []Segment{{offset: 0, size: uint32(len(data))}}},
+		{name: "time", ty: influx.Field_Type_Int},
+	}}
+	decs := NewReadContext(true)
+	defer decs.Release()
+	loc := NewLocation(&segmentDataFile{data: data}, decs)
+	loc.meta = cm
+	filterOpts := NewFilterOpts(nil, map[string]interface{}{}, []int{0}, nil, nil)
+
+	for cond, exp := range map[string]bool{
+		"status = 'ok'":                      true,
+		"status = 'error'":                   false,
+		"'error' = status":                   false,
+		"status != 'ok'":                     true,
+		"status = 'error' OR status = 'ok'":  true,
+		"(status = 'error') AND f_int > 1":   false,
+		"status =~ /err/":                    true,
+		"host = 'error'":                     true,
+		"status = 'ok' AND status = 'error'": false,
+		"status = 'ok' AND status != 'warn'": true,
+	} {
+		expr, err := influxql.ParseExpr(cond)
+		require.NoError(t, err)
+		decs.resetPrunedColumns()
+		require.Equal(t, exp, loc.segmentMayMatch(expr, filterOpts), cond)
+	}
+
+	// the column decoded by the condition is taken by the segment reader
+	expr, _ := influxql.ParseExpr("status = 'ok'")
+	decs.resetPrunedColumns()
+	require.True(t, loc.segmentMayMatch(expr, filterOpts))
+	var dst record.ColVal
+	require.True(t, decs.takePrunedColumn(0, &dst))
+	require.Equal(t, 64, dst.Len)
+	require.Equal(t, col.StringValues(nil), dst.StringValues(nil))
+	require.False(t, decs.takePrunedColumn(0, &dst))
+}
+
+func TestLocation_SkipNumericSegments(t *testing.T) {
+	var seg0, seg1, all record.ColVal
+	var times []int64
+	for i := int64(1); i <= 10; i++ {
+		seg0.AppendInteger(i)
+		all.AppendInteger(i)
+		times = append(times, i)
+	}
+	for i := int64(100); i <= 110; i++ {
+		seg1.AppendInteger(i)
+		all.AppendInteger(i)
+		times = append(times, i)
+	}
+	data := encodeSegmentColumn(t, nil, influx.Field_Type_Int, &seg0)
+	seg0Size := len(data)
+	data = encodeSegmentColumn(t, data, influx.Field_Type_Int, &seg1)
+
+	b := acquireColumnBuilder(influx.Field_Type_Int)
+	defer ReleaseColumnBuilder(b)
+	b.reset()
+	b.addValues(&all, times)
+
+	cm := &ChunkMeta{
+		segCount:  2,
+		timeRange: []SegmentRange{{1, 10}, {100, 110}},
+		colMeta: []ColumnMeta{
+			{name: "f_int", ty: influx.Field_Type_Int, preAgg: b.marshal(nil), entries: []Segment{
+				{offset: 0, size: uint32(seg0Size)},
+				{offset: int64(seg0Size), size: uint32(len(data) - seg0Size)},
+			}},
+			{name: record.TimeField, ty: influx.Field_Type_Int},
+		},
+	}
+	decs := NewReadContext(true)
+	defer decs.Release()
+	decs.SetTr(record.TimeRange{Min: 0, Max: 200})
+	loc := NewLocation(&segmentDataFile{data: data}, decs)
+	loc.meta = cm
+	filterOpts := NewFilterOpts(nil, map[string]interface{}{}, []int{0}, nil, nil)
+
+	for cond, exp := range map[string][2]bool{
+		"f_int > 50":                {false, true},
+		"f_int <= 5":                {true, false},
+		"f_int = 105":               {false, true},
+		"f_int > 5.5 AND f_int < 8": {true, false},
+		"f_int > 200":               {false, false},
+	} {
+		expr, err := influxql.ParseExpr(cond)
+		require.NoError(t, err)
+		for segPos := range exp {
+			loc.segPos = segPos
+			require.Equal(t, exp[segPos], loc.segmentMayMatch(expr, filterOpts), cond)
+		}
+	}
+
+	// the chunk may match, but none of its segments, so no segment is read
+	loc.segPos = 0
+	filterOpts.cond, _ = influxql.ParseExpr("f_int > 50 AND f_int < 60")
+	rec, err := loc.ReadData(filterOpts, nil)
+	require.NoError(t, err)
+	require.Nil(t, rec)
+	require.Equal(t, int64(0), decs.PrunedChunks())
+	require.Equal(t, int64(2), decs.PrunedSegments())
+}
@@ -21,6 +21,7 @@ The float compression modified from: https://github.com/dgryski/go-tsz
 */
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/bits"
@@ -31,10 +32,18 @@ import (
 )
 
 const (
+	// floatCompressedAuto chooses the encoding of each block from a sample, never written into the header
+	floatCompressedAuto = 0
 	// floatCompressedGorilla is a compressed format using the gorilla paper encoding
 	floatCompressedGorilla = 1
+	// floatCompressedChimp is a compressed format using the chimp128 paper encoding
+	floatCompressedChimp = 2
+	// floatCompressedALP is a compressed format that encodes decimal values as scaled integers
+	floatCompressedALP = 3
 )
 
+var errFloatNotDecimal = errors.New("float values are not decimals")
+
 type Float struct {
 	encodingType int
 	leading      uint8
@@ -43,6 +52,10 @@ type Float struct {
 	buf *BytesBuffer
 	bw  *bitstream.BitWriter
 	br  *bitstream.BitReader
+
+	chimp  *chimpState
+	alp    *alpState
+	sample []byte
 }
 
 func (enc *Float) Reset(dst []byte) {
@@ -111,6 +124,37 @@ func (enc *Float) Encoding(in []byte, out []byte) ([]byte, error) {
 		return out, nil
 	}
 
+	values := record.Bytes2Float64Slice(in)
+	ty := enc.encodingType
+	if ty != floatCompressedGorilla && ty != floatCompressedChimp && ty != floatCompressedALP {
+		ty = enc.chooseEncoding(values)
+	}
+
+	dst, err := enc.encodeValues(ty, values, out)
+	if err == errFloatNotDecimal {
+		dst, err = enc.encodeValues(floatCompressedGorilla, values, out)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+func (enc *Float) encodeValues(ty int, values []float64, out []byte) ([]byte, error) {
+	if ty == floatCompressedALP && !enc.alpPrepare(values) {
+		return out, errFloatNotDecimal
+	}
+
+	var count [4]byte
+	numberenc.MarshalUint32Copy(count[:], uint32(len(values)))
+	out = append(out, byte(ty<<4))
+	out = append(out, count[:]...)
+
+	if ty == floatCompressedALP {
+		return enc.alpEncoding(out)
+	}
+
 	enc.buf.Reset(out)
 	if enc.bw == nil {
 		enc.bw = bitstream.NewWriter(enc.buf)
@@ -118,13 +162,13 @@ func (enc *Float) Encoding(in []byte, out []byte) ([]byte, error) {
 		enc.bw.Reset(enc.buf)
 	}
 
-	values := record.Bytes2Float64Slice(in)
-	var count [4]byte
-	numberenc.MarshalUint32Copy(count[:], uint32(len(values)))
-	_ = enc.buf.WriteByte(byte(floatCompressedGorilla << 4))
-	_, _ = enc.buf.Write(count[:])
-
-	if err := enc.gorillaEncoding(values); err != nil {
+	var err error
+	if ty == floatCompressedChimp {
+		err = enc.chimpEncoding(values)
+	} else {
+		err = enc.gorillaEncoding(values)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -193,9 +237,9 @@ func (enc *Float) gorillaDecoding(out []float64) error {
 }
 
 func (enc *Float) Decoding(in []byte, out []byte) ([]byte, error) {
-	enc.encodingType, in = int(in[0]>>4), in[1:]
-	if enc.encodingType != floatCompressedGorilla {
-		return nil, fmt.Errorf("invalid input float encoded data, type = %v", enc.encodingType)
+	ty, in := int(in[0]>>4), in[1:]
+	if ty != floatCompressedGorilla && ty != floatCompressedChimp && ty != floatCompressedALP {
+		return nil, fmt.Errorf("invalid input float encoded data, type = %v", ty)
 	}
 
 	count := int(numberenc.UnmarshalUint32(in))
@@ -216,7 +260,16 @@ func (enc *Float) Decoding(in []byte, out []byte) ([]byte, error) {
 	out = out[:origLen+n]
 	outValues := record.Bytes2Float64Slice(out[origLen:])
 
-	if err := enc.gorillaDecoding(outValues); err != nil {
+	var err error
+	switch ty {
+	case floatCompressedChimp:
+		err = enc.chimpDecoding(outValues)
+	case floatCompressedALP:
+		err = enc.alpDecoding(in[4:], outValues)
+	default:
+		err = enc.gorillaDecoding(outValues)
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,379 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+/*
+The chimp128 compression is modified from: https://github.com/panagiotisl/chimp
+The decimal compression follows the idea of ALP(Adaptive Lossless floating-Point compression)
+*/
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/openGemini/openGemini/lib/numberenc"
+	"github.com/openGemini/openGemini/lib/record"
+	"github.com/openGemini/openGemini/open_src/github.com/dgryski/go-bitstream"
+)
+
+const (
+	chimpPreviousValues     = 128
+	chimpPreviousValuesLog2 = 7
+	chimpThreshold          = 6 + chimpPreviousValuesLog2
+	chimpIndexMask          = 1<<(chimpThreshold+1) - 1
+
+	// values with an exponent above this can not be represented exactly by the decimal codec
+	alpMaxExponent = 18
+	// the decimal codec is abandoned if more than 1/alpMaxExceptionRatio values are exceptions
+	alpMaxExceptionRatio = 8
+
+	// number of leading values of a block used to choose the codec adaptively
+	floatSampleSize = 128
+)
+
+var chimpLeadingRepresentation = [8]uint8{0, 8, 12, 16, 18, 20, 22, 24}
+
+var alpPow10 = [alpMaxExponent + 1]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// chimpLeadingRound rounds the number of leading zeros down to a representable value,
+// returns the rounded value and its 3 bits representation
+func chimpLeadingRound(leading int) (uint8, uint64) {
+	for i := len(chimpLeadingRepresentation) - 1; i > 0; i-- {
+		if leading >= int(chimpLeadingRepresentation[i]) {
+			return chimpLeadingRepresentation[i], uint64(i)
+		}
+	}
+	return 0, 0
+}
+
+type chimpState struct {
+	stored  [chimpPreviousValues]uint64
+	indices []int
+	// position of the first value of current block, entries of indices below it are stale
+	base int
+}
+
+func (c *chimpState) begin(n int) int {
+	if c.indices == nil {
+		c.indices = make([]int, chimpIndexMask+1)
+		for i := range c.indices {
+			c.indices[i] = -1
+		}
+	}
+	start := c.base
+	c.base += n + chimpPreviousValues
+	return start
+}
+
+func (enc *Float) chimpEncoding(values []float64) error {
+	if enc.chimp == nil {
+		enc.chimp = &chimpState{}
+	}
+	c := enc.chimp
+	start := c.begin(len(values))
+
+	v0 := math.Float64bits(values[0])
+	_ = enc.bw.WriteBits(v0, 64)
+	c.stored[0] = v0
+	c.indices[v0&chimpIndexMask] = start
+
+	storedLeading := uint8(math.MaxUint8)
+	for i := 1; i < len(values); i++ {
+		v := math.Float64bits(values[i])
+		key := v & chimpIndexMask
+		prevIdx := (i - 1) % chimpPreviousValues
+		xor := c.stored[prevIdx] ^ v
+		trailing := 0
+
+		if cand := c.indices[key]; cand >= start && start+i-cand < chimpPreviousValues {
+			idx := (cand - start) % chimpPreviousValues
+			tmp := c.stored[idx] ^ v
+			if tz := bits.TrailingZeros64(tmp); tz > chimpThreshold {
+				prevIdx, xor, trailing = idx, tmp, tz
+			}
+		}
+
+		if xor == 0 {
+			_ = enc.bw.WriteBits(0, 2)
+			_ = enc.bw.WriteBits(uint64(prevIdx), chimpPreviousValuesLog2)
+			storedLeading = math.MaxUint8
+		} else {
+			leading, repr := chimpLeadingRound(bits.LeadingZeros64(xor))
+			if trailing > chimpThreshold {
+				sigBits := 64 - int(leading) - trailing
+				_ = enc.bw.WriteBits(1, 2)
+				_ = enc.bw.WriteBits(uint64(prevIdx), chimpPreviousValuesLog2)
+				_ = enc.bw.WriteBits(repr, 3)
+				_ = enc.bw.WriteBits(uint64(sigBits), 6)
+				_ = enc.bw.WriteBits(xor>>trailing, sigBits)
+				storedLeading = math.MaxUint8
+			} else if leading == storedLeading {
+				_ = enc.bw.WriteBits(2, 2)
+				_ = enc.bw.WriteBits(xor, 64-int(leading))
+			} else {
+				storedLeading = leading
+				_ = enc.bw.WriteBits(3, 2)
+				_ = enc.bw.WriteBits(repr, 3)
+				_ = enc.bw.WriteBits(xor, 64-int(leading))
+			}
+		}
+
+		c.stored[i%chimpPreviousValues] = v
+		c.indices[key] = start + i
+	}
+
+	return enc.bw.Flush(bitstream.Zero)
+}
+
+func (enc *Float) chimpDecoding(out []float64) error {
+	if enc.chimp == nil {
+		enc.chimp = &chimpState{}
+	}
+	stored := &enc.chimp.stored
+
+	v, err := enc.br.ReadBits(64)
+	if err != nil {
+		return err
+	}
+	stored[0] = v
+	out[0] = math.Float64frombits(v)
+
+	var storedLeading uint8
+	for i := 1; i < len(out); i++ {
+		flag, err := enc.br.ReadBits(2)
+		if err != nil {
+			return err
+		}
+
+		switch flag {
+		case 0:
+			idx, err := enc.br.ReadBits(chimpPreviousValuesLog2)
+			if err != nil {
+				return err
+			}
+			v = stored[idx]
+		case 1:
+			idx, err := enc.br.ReadBits(chimpPreviousValuesLog2)
+			if err != nil {
+				return err
+			}
+			repr, err := enc.br.ReadBits(3)
+			if err != nil {
+				return err
+			}
+			sigBits, err := enc.br.ReadBits(6)
+			if err != nil {
+				return err
+			}
+			trailing := 64 - int(chimpLeadingRepresentation[repr]) - int(sigBits)
+			if sigBits == 0 || trailing < 0 {
+				return fmt.Errorf("invalid chimp encoded data, significant bits = %v", sigBits)
+			}
+			xor, err := enc.br.ReadBits(int(sigBits))
+			if err != nil {
+				return err
+			}
+			v = stored[idx] ^ (xor << trailing)
+		case 2:
+			xor, err := enc.br.ReadBits(64 - int(storedLeading))
+			if err != nil {
+				return err
+			}
+			v = stored[(i-1)%chimpPreviousValues] ^ xor
+		default:
+			repr, err := enc.br.ReadBits(3)
+			if err != nil {
+				return err
+			}
+			storedLeading = chimpLeadingRepresentation[repr]
+			xor, err := enc.br.ReadBits(64 - int(storedLeading))
+			if err != nil {
+				return err
+			}
+			v = stored[(i-1)%chimpPreviousValues] ^ xor
+		}
+
+		stored[i%chimpPreviousValues] = v
+		out[i] = math.Float64frombits(v)
+	}
+
+	return nil
+}
+
+type alpState struct {
+	exponent  int
+	ints      []int64
+	excPos    []uint32
+	excValues []uint64
+	intCoder  *Integer
+}
+
+func alpEncodeValue(v float64, e int) (int64, bool) {
+	scaled := math.Round(v * alpPow10[e])
+	if !(math.Abs(scaled) < 1<<53) {
+		return 0, false
+	}
+	n := int64(scaled)
+	return n, math.Float64bits(float64(n)/alpPow10[e]) == math.Float64bits(v)
+}
+
+// alpChooseExponent returns the exponent that encodes most of the sample values exactly
+func alpChooseExponent(sample []float64) (int, int) {
+	bestExp, bestHits := 0, -1
+	for e := 0; e <= alpMaxExponent; e++ {
+		hits := 0
+		for _, v := range sample {
+			if _, ok := alpEncodeValue(v, e); ok {
+				hits++
+			}
+		}
+		if hits > bestHits {
+			bestExp, bestHits = e, hits
+		}
+		if hits == len(sample) {
+			break
+		}
+	}
+	return bestExp, bestHits
+}
+
+// alpPrepare converts the values into integers, returns false if the values are not decimals
+func (enc *Float) alpPrepare(values []float64) bool {
+	if enc.alp == nil {
+		enc.alp = &alpState{}
+	}
+	a := enc.alp
+
+	sample := values
+	if len(sample) > floatSampleSize {
+		sample = sample[:floatSampleSize]
+	}
+	e, hits := alpChooseExponent(sample)
+	if (len(sample)-hits)*alpMaxExceptionRatio > len(sample) {
+		return false
+	}
+
+	a.exponent = e
+	a.ints = a.ints[:0]
+	a.excPos = a.excPos[:0]
+	a.excValues = a.excValues[:0]
+	maxExceptions := len(values) / alpMaxExceptionRatio
+	prev := int64(0)
+	for i, v := range values {
+		n, ok := alpEncodeValue(v, e)
+		if !ok {
+			if len(a.excPos) >= maxExceptions {
+				return false
+			}
+			// keep the delta small for the integer encoding
+			n = prev
+			a.excPos = append(a.excPos, uint32(i))
+			a.excValues = append(a.excValues, math.Float64bits(v))
+		}
+		a.ints = append(a.ints, n)
+		prev = n
+	}
+	return true
+}
+
+func (enc *Float) alpEncoding(out []byte) ([]byte, error) {
+	a := enc.alp
+	out = append(out, uint8(a.exponent))
+	out = numberenc.MarshalUint32Append(out, uint32(len(a.excPos)))
+	for i := range a.excPos {
+		out = numberenc.MarshalUint32Append(out, a.excPos[i])
+		out = numberenc.MarshalUint64Append(out, a.excValues[i])
+	}
+
+	if a.intCoder == nil {
+		a.intCoder = &Integer{buf: NewBytesBuffer(nil)}
+	}
+	return a.intCoder.Encoding(record.Int64Slice2byte(a.ints), out)
+}
+
+func (enc *Float) alpDecoding(in []byte, out []float64) error {
+	if enc.alp == nil {
+		enc.alp = &alpState{}
+	}
+	a := enc.alp
+
+	if len(in) < 5 {
+		return fmt.Errorf("too small data for decimal encoded float, %v", len(in))
+	}
+	e := int(in[0])
+	if e > alpMaxExponent {
+		return fmt.Errorf("invalid decimal encoded float, exponent = %v", e)
+	}
+	excN := int(numberenc.UnmarshalUint32(in[1:]))
+	in = in[5:]
+	if len(in) < excN*12 {
+		return fmt.Errorf("too small data for decimal encoded float exceptions, %v < %v", len(in), excN*12)
+	}
+	exceptions := in[:excN*12]
+	in = in[excN*12:]
+
+	if a.intCoder == nil {
+		a.intCoder = &Integer{buf: NewBytesBuffer(nil)}
+	}
+	buf, err := a.intCoder.Decoding(in, record.Int64Slice2byte(a.ints[:0]))
+	if err != nil {
+		return err
+	}
+	ints := record.Bytes2Int64Slice(buf)
+	a.ints = ints[:0]
+	if len(ints) != len(out) {
+		return fmt.Errorf("invalid decimal encoded float, count %v != %v", len(ints), len(out))
+	}
+
+	for i, n := range ints {
+		out[i] = float64(n) / alpPow10[e]
+	}
+	for i := 0; i < excN; i++ {
+		pos := int(numberenc.UnmarshalUint32(exceptions[i*12:]))
+		if pos >= len(out) {
+			return fmt.Errorf("invalid decimal encoded float, exception position %v >= %v", pos, len(out))
+		}
+		out[pos] = math.Float64frombits(numberenc.UnmarshalUint64(exceptions[i*12+4:]))
+	}
+
+	return nil
+}
+
+// chooseEncoding encodes a sample of the block with every codec and returns the smallest one
+func (enc *Float) chooseEncoding(values []float64) int {
+	sample := values
+	if len(sample) > floatSampleSize {
+		sample = sample[:floatSampleSize]
+	}
+
+	best, bestSize := floatCompressedGorilla, math.MaxInt64
+	for _, ty := range [...]int{floatCompressedGorilla, floatCompressedChimp, floatCompressedALP} {
+		buf, err := enc.encodeValues(ty, sample, enc.sample[:0])
+		if err != nil {
+			continue
+		}
+		enc.sample = buf
+		if len(buf) < bestSize {
+			best, bestSize = ty, len(buf)
+		}
+	}
+	return best
+}
@@ -19,6 +19,7 @@ package immutable
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/openGemini/openGemini/engine/immutable/readcache"
 	"github.com/openGemini/openGemini/lib/bufferpool"
@@ -67,7 +68,7 @@ func NewDiskFileReader(f fileops.File) *diskFileReader {
 
 	fileSize := fi.Size()
 	r := &diskFileReader{fd: f, fileSize: fileSize}
-	if mmapEn {
+	if mmapEn && !fileops.IsEncrypted(f) {
 		r.mmapData, err = fileops.Mmap(f.Fd(), int(fileSize))
 		if err != nil {
 			err = errMapFail(fName, err)
@@ -156,14 +157,14 @@ func (r *diskFileReader) Rename(newName string) error {
 
 	var err error
 	pri := fileops.FilePriorityOption(fileops.IO_PRIORITY_NORMAL)
-	r.fd, err = fileops.Open(newName, lock, pri)
+	r.fd, err = fileops.OpenDecryptedFile(newName, os.O_RDONLY, 0640, lock, pri)
 	if err != nil {
 		err = errOpenFail(newName, err)
 		log.Error("open file fail", zap.Error(err))
 		return err
 	}
 
-	if mmapEn {
+	if mmapEn && !fileops.IsEncrypted(r.fd) {
 		r.mmapData, err = fileops.Mmap(r.fd.Fd(), int(r.fileSize))
 		if err != nil {
 			err = errMapFail(newName, err)
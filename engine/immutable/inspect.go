@@ -0,0 +1,333 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/openGemini/openGemini/lib/fileops"
+	"github.com/openGemini/openGemini/lib/numberenc"
+	"github.com/openGemini/openGemini/lib/record"
+)
+
+// FileInspector reads a TSSP file outside of a shard, for offline inspection, verification and repair.
+type FileInspector struct {
+	r    *TSSPFileReader
+	decs *ReadContext
+	buf  []byte
+}
+
+type FileStat struct {
+	Name           string
+	Measurement    string
+	Version        uint64
+	FileSize       int64
+	DataSize       int64
+	MetaSize       int64
+	SeriesCount    int64
+	MinSid         uint64
+	MaxSid         uint64
+	MinTime        int64
+	MaxTime        int64
+	MetaIndexCount int
+}
+
+// ChunkError is a chunk or chunk meta block that can not be read
+type ChunkError struct {
+	MetaIndex int
+	Sid       uint64
+	// the whole chunk meta block is unreadable, Sid is the first series of the block
+	MetaBlock bool
+	Err       error
+}
+
+type VerifyReport struct {
+	Chunks int
+	Rows   int64
+	// chunks written by the stream compaction carry no crc
+	CrcSkipped int
+	Errors     []ChunkError
+}
+
+type RepairReport struct {
+	VerifyReport
+	File          string
+	WrittenChunks int
+}
+
+func OpenFileInspector(name string) (*FileInspector, error) {
+	fi, err := fileops.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	// NewTSSPFileReader removes a file that is too small, keep it for inspection
+	if fi.Size() < minTableSize() {
+		return nil, fmt.Errorf("invalid file(%v) size:%v", name, fi.Size())
+	}
+
+	r, err := NewTSSPFileReader(name)
+	if err != nil {
+		return nil, err
+	}
+	r.inMemBlock = emptyMemReader
+	if err = r.lazyInit(); err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+
+	return &FileInspector{r: r, decs: NewReadContext(true)}, nil
+}
+
+func (fi *FileInspector) Close() error {
+	fi.decs.Release()
+	return fi.r.Close()
+}
+
+func (fi *FileInspector) Stat() FileStat {
+	tr := fi.r.Stat()
+	return FileStat{
+		Name:           fi.r.FileName(),
+		Measurement:    string(tr.name),
+		Version:        fi.r.Version(),
+		FileSize:       fi.r.FileSize(),
+		DataSize:       tr.dataSize,
+		MetaSize:       tr.indexSize,
+		SeriesCount:    tr.idCount,
+		MinSid:         tr.minId,
+		MaxSid:         tr.maxId,
+		MinTime:        tr.minTime,
+		MaxTime:        tr.maxTime,
+		MetaIndexCount: len(fi.r.metaIndexItems),
+	}
+}
+
+// ChunkMetas reads the idx-th chunk meta block of the file
+func (fi *FileInspector) ChunkMetas(idx int, dst []ChunkMeta) ([]ChunkMeta, error) {
+	m, err := fi.r.MetaIndexAt(idx)
+	if err != nil {
+		return nil, err
+	}
+	return fi.r.ReadChunkMetaData(idx, m, dst)
+}
+
+// ReadChunk decodes all segments of the chunk into one record
+func (fi *FileInspector) ReadChunk(cm *ChunkMeta) (rec *record.Record, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			rec, err = nil, fmt.Errorf("decode chunk of series %v panic: %v", cm.sid, e)
+		}
+	}()
+
+	schema := cm.Schema()
+	rec = record.NewRecordBuilder(schema)
+	seg := record.NewRecordBuilder(schema)
+	for i := 0; i < cm.segmentCount(); i++ {
+		seg.ResetForReuse()
+		if _, err = fi.r.ReadData(cm, i, seg, fi.decs); err != nil {
+			return nil, err
+		}
+		rec.AppendRec(seg, 0, seg.RowNums())
+	}
+	return rec, nil
+}
+
+// checkCrc checks the crc of every column of the chunk, returns false if the chunk carries no crc
+func (fi *FileInspector) checkCrc(cm *ChunkMeta) (bool, error) {
+	checked := false
+	for i := range cm.colMeta {
+		col := &cm.colMeta[i]
+		if len(col.entries) == 0 {
+			continue
+		}
+		first, last := col.entries[0], col.entries[len(col.entries)-1]
+		start := first.offset - 4
+		size := last.offset + int64(last.size) - start
+		if start < cm.offset || size <= 4 || start+size > cm.offset+int64(cm.size) {
+			return checked, fmt.Errorf("invalid offset %v size %v of column %v", start, size, col.name)
+		}
+
+		data, err := fi.r.ReadDataBlock(start, uint32(size), &fi.buf)
+		if err != nil {
+			return checked, err
+		}
+		exp := numberenc.UnmarshalUint32(data)
+		if exp == 0 {
+			continue
+		}
+		checked = true
+		if crc := crc32.ChecksumIEEE(data[4:]); crc != exp {
+			return checked, fmt.Errorf("crc mismatch of column %v, %v != %v", col.name, crc, exp)
+		}
+	}
+	return checked, nil
+}
+
+// Walk verifies every chunk of the file, fn is called for every readable chunk
+func (fi *FileInspector) Walk(fn func(cm *ChunkMeta, rec *record.Record) error) (*VerifyReport, error) {
+	report := &VerifyReport{}
+	var metas []ChunkMeta
+	var err error
+	for i := range fi.r.metaIndexItems {
+		metas, err = fi.ChunkMetas(i, metas[:0])
+		if err != nil {
+			report.Errors = append(report.Errors, ChunkError{MetaIndex: i, Sid: fi.r.metaIndexItems[i].id, MetaBlock: true, Err: err})
+			continue
+		}
+
+		for j := range metas {
+			cm := &metas[j]
+			report.Chunks++
+			if err = fi.r.validate(cm.offset, int64(cm.size)); err != nil {
+				report.Errors = append(report.Errors, ChunkError{MetaIndex: i, Sid: cm.sid, Err: err})
+				continue
+			}
+			checked, err := fi.checkCrc(cm)
+			if !checked {
+				report.CrcSkipped++
+			}
+			var rec *record.Record
+			if err == nil {
+				rec, err = fi.ReadChunk(cm)
+			}
+			if err != nil {
+				report.Errors = append(report.Errors, ChunkError{MetaIndex: i, Sid: cm.sid, Err: err})
+				continue
+			}
+
+			report.Rows += int64(rec.RowNums())
+			if fn != nil {
+				if err = fn(cm, rec); err != nil {
+					return report, err
+				}
+			}
+		}
+	}
+	return report, nil
+}
+
+func (fi *FileInspector) Verify() (*VerifyReport, error) {
+	return fi.Walk(nil)
+}
+
+// RepairFile rewrites the file into dir/<measurement>/, skipping the chunks that can not be read.
+// The report lists the chunks that are lost.
+func RepairFile(name string, dir string) (*RepairReport, error) {
+	var fileName TSSPFileName
+	if err := fileName.ParseFileName(name); err != nil {
+		return nil, err
+	}
+	fileName.SetOrder(filepath.Base(filepath.Dir(name)) != unorderedDir)
+
+	fi, err := OpenFileInspector(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = fi.Close()
+	}()
+
+	stat := fi.Stat()
+	conf := NewConfig()
+	conf.maxSegmentLimit = math.MaxUint16
+	builder := AllocMsBuilder(dir, stat.Measurement, conf, int(stat.SeriesCount), fileName, 0, nil, 0)
+	report := &RepairReport{}
+	vr, err := fi.Walk(func(cm *ChunkMeta, rec *record.Record) error {
+		if rec.RowNums() == 0 {
+			return nil
+		}
+		report.WrittenChunks++
+		return builder.WriteData(cm.sid, rec)
+	})
+	if vr != nil {
+		report.VerifyReport = *vr
+	}
+	if err != nil {
+		PutMsBuilder(builder)
+		return report, err
+	}
+
+	f, err := builder.NewTSSPFile(false)
+	if err != nil {
+		PutMsBuilder(builder)
+		return report, err
+	}
+	if f != nil {
+		report.File = f.Path()
+		err = f.Close()
+	}
+	PutMsBuilder(builder)
+	return report, err
+}
+
+// Schema returns the fields of the chunk, the time field is the last one
+func (m *ChunkMeta) Schema() record.Schemas {
+	return unmarshalBlockHeader(m, nil)
+}
+
+func (m *ChunkMeta) SegmentCount() int {
+	return m.segmentCount()
+}
+
+// SegmentTimeRange returns the min and max time of the i-th segment
+func (m *ChunkMeta) SegmentTimeRange(i int) (int64, int64) {
+	return m.timeRange[i].minTime(), m.timeRange[i].maxTime()
+}
+
+// DataOffsetSize returns the offset and size of the chunk data in the file
+func (m *ChunkMeta) DataOffsetSize() (int64, uint32) {
+	return m.offset, m.size
+}
+
+type CompactLogReport struct {
+	File string
+	Info CompactedFileInfo
+	// one of CompactLogCommit, CompactLogRollback and CompactLogInvalid
+	Action int
+	Err    error
+}
+
+// InspectCompactLogs reads the compaction logs of the shard, and reports how procCompactLog would recover each of them
+func InspectCompactLogs(shardDir string) ([]CompactLogReport, error) {
+	logDir := filepath.Join(shardDir, compactLogDir)
+	dirs, err := fileops.ReadDir(logDir)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]CompactLogReport, 0, len(dirs))
+	for i := range dirs {
+		r := CompactLogReport{File: filepath.Join(logDir, dirs[i].Name()), Action: CompactLogInvalid}
+		if r.Err = readCompactLogFile(r.File, &r.Info); r.Err == nil {
+			mmDir := filepath.Join(shardDir, TsspDirName, r.Info.Name)
+			var files []os.FileInfo
+			if files, r.Err = fileops.ReadDir(mmDir); r.Err == nil {
+				r.Action = compactLogAction(files, &r.Info)
+			}
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// RecoverCompactLogs recovers the interrupted compactions of the shard, as the shard does when it is opened
+func RecoverCompactLogs(shardDir string) error {
+	return recoverFile(shardDir)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/record"
+	"github.com/stretchr/testify/require"
+)
+
+func writeInspectTestFile(t *testing.T, dir string) (string, []uint64) {
+	startValue := 1.1
+	tm := testTimeStart
+	ids, data := genTestData(1, 3, 100, &startValue, &tm)
+
+	conf := NewConfig()
+	fileName := NewTSSPFileName(1, 0, 0, 0, true)
+	msb := AllocMsBuilder(dir, "mst", conf, len(ids), fileName, 0, nil, 0)
+	for _, id := range ids {
+		require.NoError(t, msb.WriteData(id, data[id]))
+	}
+	f, err := msb.NewTSSPFile(false)
+	require.NoError(t, err)
+	PutMsBuilder(msb)
+	path := f.Path()
+	require.NoError(t, f.Close())
+	return path, ids
+}
+
+func TestFileInspector_VerifyAndRepair(t *testing.T) {
+	dir := t.TempDir()
+	name, ids := writeInspectTestFile(t, dir)
+
+	fi, err := OpenFileInspector(name)
+	require.NoError(t, err)
+	stat := fi.Stat()
+	require.Equal(t, "mst", stat.Measurement)
+	require.Equal(t, int64(3), stat.SeriesCount)
+
+	report, err := fi.Verify()
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Chunks)
+	require.Equal(t, int64(300), report.Rows)
+	require.Equal(t, 0, report.CrcSkipped)
+	require.Empty(t, report.Errors)
+
+	// corrupt the data of the second series
+	metas, err := fi.ChunkMetas(0, nil)
+	require.NoError(t, err)
+	require.Equal(t, ids[1], metas[1].GetSid())
+	offset, size := metas[1].DataOffsetSize()
+	require.NoError(t, fi.Close())
+
+	fd, err := os.OpenFile(name, os.O_RDWR, 0640)
+	require.NoError(t, err)
+	_, err = fd.WriteAt(make([]byte, 16), offset+int64(size)/2)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	fi, err = OpenFileInspector(name)
+	require.NoError(t, err)
+	report, err = fi.Verify()
+	require.NoError(t, err)
+	require.NoError(t, fi.Close())
+	require.Equal(t, 3, report.Chunks)
+	require.Equal(t, 1, len(report.Errors))
+	require.Equal(t, ids[1], report.Errors[0].Sid)
+
+	outDir := filepath.Join(dir, "repaired")
+	repaired, err := RepairFile(name, outDir)
+	require.NoError(t, err)
+	require.Equal(t, 2, repaired.WrittenChunks)
+	require.Equal(t, 1, len(repaired.Errors))
+	require.Equal(t, filepath.Join(outDir, "mst", filepath.Base(name)), repaired.File)
+
+	fi, err = OpenFileInspector(repaired.File)
+	require.NoError(t, err)
+	defer fi.Close()
+	var sids []uint64
+	report, err = fi.Walk(func(cm *ChunkMeta, rec *record.Record) error {
+		sids = append(sids, cm.GetSid())
+		require.Equal(t, 100, rec.RowNums())
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, report.Errors)
+	require.Equal(t, []uint64{ids[0], ids[2]}, sids)
+}
+
+func TestInspectCompactLogs(t *testing.T) {
+	shardDir := t.TempDir()
+	mstDir := filepath.Join(shardDir, TsspDirName, "mst")
+	require.NoError(t, os.MkdirAll(mstDir, 0750))
+	require.NoError(t, os.MkdirAll(filepath.Join(shardDir, compactLogDir), 0750))
+
+	writeLog := func(name string, info *CompactedFileInfo) {
+		buf := info.marshal(nil)
+		buf = append(buf, compLogMagic...)
+		require.NoError(t, os.WriteFile(filepath.Join(shardDir, compactLogDir, name), buf, 0640))
+	}
+	touch := func(name string) {
+		require.NoError(t, os.WriteFile(filepath.Join(mstDir, name), nil, 0640))
+	}
+
+	touch("00000001-0000-00000000.tssp")
+	touch("00000002-0000-00000000.tssp")
+	touch("00000001-0001-00000000.tssp.init")
+	writeLog("1", &CompactedFileInfo{Name: "mst", IsOrder: true,
+		OldFile: []string{"00000001-0000-00000000.tssp", "00000002-0000-00000000.tssp"},
+		NewFile: []string{"00000001-0001-00000000.tssp.init"}})
+	writeLog("2", &CompactedFileInfo{Name: "mst", IsOrder: true,
+		OldFile: []string{"00000003-0000-00000000.tssp"},
+		NewFile: []string{"00000003-0001-00000000.tssp.init"}})
+	require.NoError(t, os.WriteFile(filepath.Join(shardDir, compactLogDir, "3"), []byte("dirty"), 0640))
+
+	reports, err := InspectCompactLogs(shardDir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(reports))
+	require.Equal(t, CompactLogCommit, reports[0].Action)
+	require.NoError(t, reports[0].Err)
+	require.Equal(t, CompactLogInvalid, reports[1].Action)
+	require.NoError(t, reports[1].Err)
+	require.Equal(t, ErrDirtyLog, reports[2].Err)
+
+}
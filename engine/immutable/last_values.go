@@ -0,0 +1,119 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"github.com/openGemini/openGemini/lib/record"
+)
+
+// LastValueVisitor collects the newest value of every field of the series of a measurement.
+type LastValueVisitor interface {
+	// Need reports whether a chunk of the series sid, with the columns of schema
+	// and no row newer than maxTime, may hold a value which is not collected yet
+	Need(sid uint64, schema record.Schemas, maxTime int64) bool
+	// Update collects the values of one segment of a chunk of the series sid
+	Update(sid uint64, rec *record.Record)
+}
+
+// LoadLastValues walks the chunks of all files and feeds them to the visitor of their measurement.
+// Order files are walked from the newest to the oldest, out of order files from the oldest
+// to the newest, so that a visitor sees the data written later last for rows of the same time.
+func (m *MmsTables) LoadLastValues(visitor func(name string, isOrder bool) LastValueVisitor) error {
+	walk := func(isOrder bool) error {
+		m.mu.RLock()
+		names := make([]string, 0, len(m.Order))
+		tables := m.Order
+		if !isOrder {
+			tables = m.OutOfOrder
+		}
+		for name := range tables {
+			names = append(names, name)
+		}
+		m.mu.RUnlock()
+
+		for _, name := range names {
+			files := m.GetFilesRef(name, isOrder)
+			v := visitor(name, isOrder)
+			var err error
+			for i := range files {
+				f := files[i]
+				if isOrder {
+					f = files[len(files)-1-i]
+				}
+				if err == nil {
+					err = visitLastValues(f, v)
+				}
+			}
+			for _, f := range files {
+				f.Unref()
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(true); err != nil {
+		return err
+	}
+	return walk(false)
+}
+
+func visitLastValues(f TSSPFile, v LastValueVisitor) error {
+	trailer := f.FileStat()
+	decs := NewReadContext(true)
+	defer decs.Release()
+
+	var metas []ChunkMeta
+	var schema record.Schemas
+	rec := &record.Record{}
+	for i := 0; i < int(trailer.metaIndexItemNum); i++ {
+		mi, err := f.MetaIndexAt(i)
+		if err != nil {
+			return err
+		}
+		metas, err = f.ReadChunkMetaData(i, mi, metas[:0])
+		if err != nil {
+			return err
+		}
+
+		for j := range metas {
+			cm := &metas[j]
+			schema = schema[:0]
+			for k := range cm.colMeta {
+				schema = append(schema, record.Field{Name: cm.colMeta[k].name, Type: int(cm.colMeta[k].ty)})
+			}
+
+			// the newest values are in the last segments, stop as soon as older segments can not help
+			for seg := cm.segmentCount() - 1; seg >= 0; seg-- {
+				if !v.Need(cm.sid, schema, cm.timeRange[seg].maxTime()) {
+					break
+				}
+				rec.Reset()
+				rec.SetSchema(schema)
+				rec.ReserveColVal(len(schema))
+				rec, err = f.ReadAt(cm, seg, rec, decs)
+				if err != nil {
+					return err
+				}
+				v.Update(cm.sid, rec)
+			}
+		}
+	}
+	return nil
+}
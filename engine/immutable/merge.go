@@ -64,6 +64,7 @@ func (inf *OrderInfo) Swap(i, j int) {
 type OutOfOrderMergeContext struct {
 	mstName string
 	shId    uint64
+	task    *CompactionTask
 
 	tr    record.TimeRange
 	size  int64
@@ -76,6 +77,7 @@ type OutOfOrderMergeContext struct {
 func (ctx *OutOfOrderMergeContext) reset() {
 	ctx.mstName = ""
 	ctx.shId = 0
+	ctx.task = nil
 	ctx.tr.Min = 0
 	ctx.tr.Max = 0
 	ctx.size = 0
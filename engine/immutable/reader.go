@@ -717,6 +717,7 @@ type Location struct {
 	r      TSSPFile
 	meta   *ChunkMeta
 	segPos int
+	pruned bool // the chunk has been judged by the field conditions
 }
 
 func NewLocation(r TSSPFile, decs *ReadContext) *Location {
@@ -946,6 +947,7 @@ func (l *Location) readChunkMeta(id uint64, tr record.TimeRange) error {
 	}
 
 	l.meta = meta
+	l.pruned = false
 	if !l.decs.Ascending {
 		l.segPos = int(meta.segCount) - 1
 	}
@@ -1034,16 +1036,48 @@ func (l *Location) ReadData(filterOpts *FilterOptions, dst *record.Record) (*rec
 	return l.readData(filterOpts, dst)
 }
 
+// pruneByField skips the whole chunk if none of its rows may satisfy the field conditions
+func (l *Location) pruneByField(filterOpts *FilterOptions) {
+	if l.pruned || l.meta == nil || filterOpts == nil || filterOpts.cond == nil || len(filterOpts.fieldsIdx) == 0 {
+		return
+	}
+	l.pruned = true
+	if chunkMayMatch(l.meta, filterOpts.cond, filterOpts, l.decs) {
+		return
+	}
+
+	l.decs.prunedChunks++
+	if l.decs.Ascending {
+		l.decs.prunedSegments += int64(int(l.meta.segCount) - l.segPos)
+		l.segPos = int(l.meta.segCount)
+	} else {
+		l.decs.prunedSegments += int64(l.segPos + 1)
+		l.segPos = -1
+	}
+}
+
 func (l *Location) readData(filterOpts *FilterOptions, dst *record.Record) (*record.Record, error) {
 	var rec *record.Record
 	var err error
+	if !l.isPreAggRead() {
+		l.pruneByField(filterOpts)
+	}
 	for rec == nil && l.next() {
 		if !l.decs.tr.Overlaps(l.meta.MinMaxTime()) {
 			l.nextSegment()
 			continue
 		}
 
+		l.decs.resetPrunedColumns()
+		if !l.isPreAggRead() && filterOpts != nil && filterOpts.cond != nil && len(filterOpts.fieldsIdx) > 0 &&
+			!l.segmentMayMatch(filterOpts.cond, filterOpts) {
+			l.decs.prunedSegments++
+			l.nextSegment()
+			continue
+		}
+
 		rec, err = l.r.ReadAt(l.meta, l.segPos, dst, l.decs)
+		l.decs.resetPrunedColumns()
 		if err != nil {
 			return nil, err
 		}
@@ -1080,6 +1114,7 @@ func (l *Location) readMeta(filterOpts *FilterOptions, dst *record.Record) (*rec
 
 func (l *Location) ResetMeta() {
 	l.segPos = 0
+	l.pruned = false
 	if l.GetChunkMeta() != nil {
 		l.GetChunkMeta().Reset()
 	}
@@ -1284,6 +1319,16 @@ type ReadContext struct {
 	origData        []byte
 
 	readBuf []byte
+
+	// chunks and segments skipped by the field conditions
+	prunedChunks   int64
+	prunedSegments int64
+
+	// dictionary of the string segments judged by the field conditions
+	stringDict *stringDict
+	dictBuf    []byte
+	// columns of the current segment decoded by the field conditions, taken by the segment reader
+	prunedCols []prunedColumn
 }
 
 func NewReadContext(ascending bool) *ReadContext {
@@ -1346,6 +1391,57 @@ func (d *ReadContext) Release() {
 	}
 }
 
+type prunedColumn struct {
+	offset int64
+	col    record.ColVal
+}
+
+// prunedColumn returns the column of the current segment at offset decoded by the field conditions
+func (d *ReadContext) prunedColumn(offset int64) *record.ColVal {
+	for i := range d.prunedCols {
+		if d.prunedCols[i].offset == offset {
+			return &d.prunedCols[i].col
+		}
+	}
+	return nil
+}
+
+func (d *ReadContext) allocPrunedColumn(offset int64) *record.ColVal {
+	if len(d.prunedCols) < cap(d.prunedCols) {
+		d.prunedCols = d.prunedCols[:len(d.prunedCols)+1]
+	} else {
+		d.prunedCols = append(d.prunedCols, prunedColumn{})
+	}
+	pc := &d.prunedCols[len(d.prunedCols)-1]
+	pc.offset = offset
+	pc.col.Init()
+	return &pc.col
+}
+
+// takePrunedColumn swaps the decoded column at offset into dst, returns false if there is none
+func (d *ReadContext) takePrunedColumn(offset int64, dst *record.ColVal) bool {
+	for i := range d.prunedCols {
+		if d.prunedCols[i].offset == offset {
+			*dst, d.prunedCols[i].col = d.prunedCols[i].col, *dst
+			d.prunedCols[i].offset = -1
+			return true
+		}
+	}
+	return false
+}
+
+func (d *ReadContext) resetPrunedColumns() {
+	d.prunedCols = d.prunedCols[:0]
+}
+
+func (d *ReadContext) PrunedChunks() int64 {
+	return d.prunedChunks
+}
+
+func (d *ReadContext) PrunedSegments() int64 {
+	return d.prunedSegments
+}
+
 func (d *ReadContext) SetTr(tr record.TimeRange) {
 	d.tr = tr
 }
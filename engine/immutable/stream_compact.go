@@ -20,7 +20,6 @@ import (
 	"container/heap"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
 	"sort"
 	"sync"
@@ -73,6 +72,8 @@ type FileIterator struct {
 	curtChunkMeta *ChunkMeta
 	curtChunkPos  int
 	segPos        int
+	// the chunks whose rows are all older than it are skipped, zero keeps all chunks
+	expireTime int64
 
 	log *Log.Logger
 
@@ -121,6 +122,7 @@ func (itr *FileIterator) reset() {
 	itr.curtChunkMeta = nil
 	itr.curtChunkPos = 0
 	itr.segPos = 0
+	itr.expireTime = 0
 	itr.log = nil
 	itr.buffer = itr.buffer[:0]
 	itr.offset = 0
@@ -201,6 +203,18 @@ func (itr *FileIterator) readMetaBlocks() bool {
 }
 
 func (itr *FileIterator) NextChunkMeta() bool {
+	for itr.nextChunkMeta() {
+		if itr.expireTime == 0 || itr.curtChunkMeta.maxTime() >= itr.expireTime {
+			return true
+		}
+		// the measurement ttl is over for all rows of the chunk, leave it out of the compacted file
+		itr.curtChunkMeta = nil
+		itr.chunkUsed++
+	}
+	return false
+}
+
+func (itr *FileIterator) nextChunkMeta() bool {
 	if itr.chunkUsed >= itr.chunkN {
 		return false
 	}
@@ -244,6 +258,7 @@ type FileIterators []*FileIterator
 
 func (m *MmsTables) NewFileIterators(group *CompactGroup) (FilesInfo, error) {
 	var fi FilesInfo
+	expireTime := m.Conf.ExpireTime(group.name)
 	fi.compIts = make(FileIterators, 0, len(group.group))
 	fi.oldFiles = make([]TSSPFile, 0, len(group.group))
 	for _, fn := range group.group {
@@ -262,6 +277,7 @@ func (m *MmsTables) NewFileIterators(group *CompactGroup) (FilesInfo, error) {
 		}
 		fi.oldFiles = append(fi.oldFiles, f)
 		itr := NewFileIterator(f, CLog)
+		itr.expireTime = expireTime
 		if itr.NextChunkMeta() {
 			fi.compIts = append(fi.compIts, itr)
 		} else {
@@ -285,8 +301,11 @@ func (m *MmsTables) NewFileIterators(group *CompactGroup) (FilesInfo, error) {
 
 		fi.estimateSize += int(itr.r.FileSize())
 	}
-	fi.avgChunkRows /= len(fi.compIts)
+	if len(fi.compIts) > 0 {
+		fi.avgChunkRows /= len(fi.compIts)
+	}
 	fi.dropping = group.dropping
+	fi.task = group.task
 	fi.name = group.name
 	fi.shId = group.shardId
 	fi.toLevel = group.toLevel
@@ -419,6 +438,7 @@ func putStreamIterators(itr *StreamIterators) {
 type StreamIterators struct {
 	closed        chan struct{}
 	dropping      *int64
+	task          *CompactionTask
 	dir           string
 	name          string
 	itrs          []*StreamIterator
@@ -518,12 +538,13 @@ func (c *StreamIterators) Close() {
 	c.files = c.files[:0]
 	c.schemaMap.Reset()
 	c.fileSize = 0
+	c.task = nil
 	c.colBuilder.resetPreAgg()
 	putStreamIterators(c)
 }
 
 func (c *StreamIterators) stopCompact() bool {
-	if atomic.LoadInt64(c.dropping) > 0 {
+	if atomic.LoadInt64(c.dropping) > 0 || c.task.Cancelled() {
 		return true
 	}
 
@@ -667,10 +688,12 @@ func (m *MmsTables) NewStreamIterators(group FilesInfo) (*StreamIterators, error
 	compItrs := getStreamIterators()
 	compItrs.closed = m.closed
 	compItrs.dropping = group.dropping
+	compItrs.task = group.task
 	compItrs.name = group.name
 	compItrs.dir = m.path
 	compItrs.pair.Reset(group.name)
 	compItrs.Conf = m.Conf
+	compItrs.colBuilder.coder.SetFloatCodec(m.Conf.FloatCodec(group.name))
 	compItrs.itrs = compItrs.itrs[:0]
 	for _, fi := range group.compIts {
 		itr := NewStreamStreamIterator(fi)
@@ -720,7 +743,13 @@ func (m *MmsTables) streamCompactToLevel(group FilesInfo, full bool) error {
 	}
 
 	compItrs.Close()
-	if err = m.ReplaceFiles(group.name, group.oldFiles, newFiles, true, lcLog); err != nil {
+	if len(newFiles) == 0 {
+		// all chunks of the old files are expired
+		err = m.removeFiles(group.name, group.oldFiles, true)
+	} else {
+		err = m.ReplaceFiles(group.name, group.oldFiles, newFiles, true, lcLog)
+	}
+	if err != nil {
 		lcLog.Error("replace compacted file error", zap.Error(err))
 		return err
 	}
@@ -789,7 +818,7 @@ func (c *StreamIterators) NewFile(addFileExt bool) error {
 		c.log.Error("file exist", zap.String("file", filePath))
 		return fmt.Errorf("file(%s) exist", filePath)
 	}
-	c.fd, err = fileops.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0640, lock, pri)
+	c.fd, err = fileops.CreateEncryptedFile(filePath, 0640, lock, pri)
 	if err != nil {
 		log.Error("create file fail", zap.String("name", filePath), zap.Error(err))
 		return err
@@ -1074,7 +1103,7 @@ func (c *StreamIterators) Flush() error {
 }
 
 func (c *StreamIterators) isClosed() bool {
-	if atomic.LoadInt64(c.dropping) > 0 {
+	if atomic.LoadInt64(c.dropping) > 0 || c.task.Cancelled() {
 		return true
 	}
 	select {
@@ -28,6 +28,8 @@ const (
 	stringUncompressed     = 0
 	stringCompressedSnappy = 1
 	StringCompressedZstd   = 2
+	stringDictionary       = 3
+	stringRunLength        = 4
 
 	minCompReta = 0.85
 )
@@ -47,6 +49,7 @@ type String struct {
 
 	zstdEnc *zstd.Encoder
 	zstdDec *zstd.Decoder
+	dict    *stringDict
 
 	out    []byte
 	outLen int
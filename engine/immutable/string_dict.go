@@ -0,0 +1,295 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/openGemini/openGemini/lib/numberenc"
+)
+
+/*
+Low cardinality string blocks are stored as a dictionary of the distinct values and a code per row:
+
+	+-----------+--------+-------------+------------------------+-------+---------+
+	| type<<4   | rows   | dict count  | dict values            | width | codes   |
+	| 1 byte    | 4 byte | 4 byte      | (4 byte len + value)*  | 1 byte| ...     |
+	+-----------+--------+-------------+------------------------+-------+---------+
+
+The codes are a fixed width code per row for stringDictionary,
+or a 4 byte run count followed by (code, 4 byte run length) pairs for stringRunLength.
+*/
+
+const (
+	// blocks with fewer rows are not worth a dictionary
+	stringDictMinRows = 16
+	// the dictionary is used only if a value repeats stringDictMinRepeat times on average
+	stringDictMinRepeat = 4
+	stringDictMaxValues = math.MaxUint16 + 1
+)
+
+type stringDict struct {
+	index map[string]uint32
+	// row of the first appearance of each dictionary value
+	first []int
+	codes []uint32
+	runs  int
+
+	// start and end of each dictionary value in the encoded data
+	values []uint32
+}
+
+func (d *stringDict) reset() {
+	if d.index == nil || len(d.index) > stringDictMaxValues/16 {
+		d.index = make(map[string]uint32)
+	}
+	for k := range d.index {
+		delete(d.index, k)
+	}
+	d.first = d.first[:0]
+	d.codes = d.codes[:0]
+	d.runs = 0
+}
+
+func stringValue(in []byte, offset []uint32, i int) []byte {
+	if i == len(offset)-1 {
+		return in[offset[i]:]
+	}
+	return in[offset[i]:offset[i+1]]
+}
+
+func isStringDictType(ty int) bool {
+	return ty == stringDictionary || ty == stringRunLength
+}
+
+// build collects the distinct values of the block, returns false if the block is not low cardinality
+func (d *stringDict) build(in []byte, offset []uint32) bool {
+	d.reset()
+	rows := len(offset)
+	if rows < stringDictMinRows {
+		return false
+	}
+	limit := rows / stringDictMinRepeat
+	if limit > stringDictMaxValues {
+		limit = stringDictMaxValues
+	}
+
+	for i := 0; i < rows; i++ {
+		v := stringValue(in, offset, i)
+		code, ok := d.index[string(v)]
+		if !ok {
+			if len(d.first) >= limit {
+				return false
+			}
+			code = uint32(len(d.first))
+			d.index[string(v)] = code
+			d.first = append(d.first, i)
+		}
+		if i == 0 || d.codes[i-1] != code {
+			d.runs++
+		}
+		d.codes = append(d.codes, code)
+	}
+	return true
+}
+
+func (d *stringDict) codeWidth() int {
+	if len(d.first) <= math.MaxUint8+1 {
+		return 1
+	}
+	return 2
+}
+
+func appendStringCode(out []byte, code uint32, width int) []byte {
+	if width == 1 {
+		return append(out, uint8(code))
+	}
+	return numberenc.MarshalUint16Append(out, uint16(code))
+}
+
+func readStringCode(in []byte, width int) uint32 {
+	if width == 1 {
+		return uint32(in[0])
+	}
+	return uint32(numberenc.UnmarshalUint16(in))
+}
+
+// encodingDictionary encodes a low cardinality block with dictionary or run length codes,
+// returns false if the block is not suitable for a dictionary
+func (enc *String) encodingDictionary(in []byte, offset []uint32, out []byte) ([]byte, bool) {
+	if enc.dict == nil {
+		enc.dict = &stringDict{}
+	}
+	d := enc.dict
+	if !d.build(in, offset) {
+		return out, false
+	}
+
+	width := d.codeWidth()
+	ty := stringDictionary
+	if d.runs*(width+4)+4 < len(d.codes)*width {
+		ty = stringRunLength
+	}
+
+	out = append(out, byte(ty)<<4)
+	out = numberenc.MarshalUint32Append(out, uint32(len(offset)))
+	out = numberenc.MarshalUint32Append(out, uint32(len(d.first)))
+	for _, row := range d.first {
+		v := stringValue(in, offset, row)
+		out = numberenc.MarshalUint32Append(out, uint32(len(v)))
+		out = append(out, v...)
+	}
+	out = append(out, uint8(width))
+
+	if ty == stringDictionary {
+		for _, code := range d.codes {
+			out = appendStringCode(out, code, width)
+		}
+		return out, true
+	}
+
+	out = numberenc.MarshalUint32Append(out, uint32(d.runs))
+	start := 0
+	for i := 1; i <= len(d.codes); i++ {
+		if i == len(d.codes) || d.codes[i] != d.codes[start] {
+			out = appendStringCode(out, d.codes[start], width)
+			out = numberenc.MarshalUint32Append(out, uint32(i-start))
+			start = i
+		}
+	}
+	return out, true
+}
+
+// unmarshalDict reads the dictionary values, returns the rows of the block, the code width and the codes
+func (d *stringDict) unmarshalDict(in []byte) (int, int, []byte, error) {
+	if len(in) < 9 {
+		return 0, 0, nil, fmt.Errorf("too small data for string dictionary, %v", len(in))
+	}
+	rows := int(numberenc.UnmarshalUint32(in[1:]))
+	n := int(numberenc.UnmarshalUint32(in[5:]))
+	pos := 9
+
+	d.values = d.values[:0]
+	for i := 0; i < n; i++ {
+		if len(in) < pos+4 {
+			return 0, 0, nil, fmt.Errorf("too small data for string dictionary value length, %v < %v", len(in), pos+4)
+		}
+		l := int(numberenc.UnmarshalUint32(in[pos:]))
+		pos += 4
+		if len(in) < pos+l {
+			return 0, 0, nil, fmt.Errorf("too small data for string dictionary value, %v < %v", len(in), pos+l)
+		}
+		d.values = append(d.values, uint32(pos), uint32(pos+l))
+		pos += l
+	}
+
+	if len(in) < pos+1 {
+		return 0, 0, nil, fmt.Errorf("too small data for string dictionary code width, %v", len(in))
+	}
+	width := int(in[pos])
+	if width != 1 && width != 2 {
+		return 0, 0, nil, fmt.Errorf("invalid string dictionary code width %v", width)
+	}
+	return rows, width, in[pos+1:], nil
+}
+
+func (enc *String) decodingDictionary(in []byte, out []byte, offset []uint32) ([]byte, []uint32, error) {
+	if enc.dict == nil {
+		enc.dict = &stringDict{}
+	}
+	d := enc.dict
+	rows, width, codes, err := d.unmarshalDict(in)
+	if err != nil {
+		return nil, nil, err
+	}
+	dictN := uint32(len(d.values) / 2)
+
+	base := len(out)
+	appendValue := func(code uint32) error {
+		if code >= dictN {
+			return fmt.Errorf("invalid string dictionary code %v >= %v", code, dictN)
+		}
+		offset = append(offset, uint32(len(out)-base))
+		out = append(out, in[d.values[2*code]:d.values[2*code+1]]...)
+		return nil
+	}
+
+	if int(in[0]>>4) == stringDictionary {
+		if len(codes) < rows*width {
+			return nil, nil, fmt.Errorf("too small data for string dictionary codes, %v < %v", len(codes), rows*width)
+		}
+		for i := 0; i < rows; i++ {
+			if err = appendValue(readStringCode(codes[i*width:], width)); err != nil {
+				return nil, nil, err
+			}
+		}
+		return out, offset, nil
+	}
+
+	if len(codes) < 4 {
+		return nil, nil, fmt.Errorf("too small data for string run count, %v", len(codes))
+	}
+	runs := int(numberenc.UnmarshalUint32(codes))
+	codes = codes[4:]
+	if len(codes) < runs*(width+4) {
+		return nil, nil, fmt.Errorf("too small data for string runs, %v < %v", len(codes), runs*(width+4))
+	}
+	for i := 0; i < runs; i++ {
+		code := readStringCode(codes, width)
+		n := int(numberenc.UnmarshalUint32(codes[width:]))
+		codes = codes[width+4:]
+		for j := 0; j < n; j++ {
+			if err = appendValue(code); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(offset) != rows {
+		return nil, nil, fmt.Errorf("invalid string run length data, rows %v != %v", len(offset), rows)
+	}
+	return out, offset, nil
+}
+
+// stringDictMayMatch judges the condition (= val if equal, else != val) by the dictionary
+// of an encoded block without decoding its rows. Blocks which are not dictionary encoded are taken as satisfiable.
+func stringDictMayMatch(in []byte, val string, equal bool, d *stringDict) bool {
+	if len(in) == 0 || !isStringDictType(int(in[0]>>4)) {
+		return true
+	}
+	if _, _, _, err := d.unmarshalDict(in); err != nil {
+		return true
+	}
+
+	// every code of the dictionary is used by a row, so the condition is judged
+	// by whether val has a code and by the number of codes
+	_, ok := d.code(in, val)
+	if equal {
+		return ok
+	}
+	return !ok || len(d.values) > 2
+}
+
+// code returns the dictionary code of val, the dictionary is read by unmarshalDict
+func (d *stringDict) code(in []byte, val string) (uint32, bool) {
+	for i := 0; i < len(d.values); i += 2 {
+		if string(in[d.values[i]:d.values[i+1]]) == val {
+			return uint32(i / 2), true
+		}
+	}
+	return 0, false
+}
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
-	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -797,6 +796,7 @@ func GetMsBuilder(dir, name string, fileName TSSPFileName, conf *Config,
 		msBuilder.chunkBuilder.maxRowsLimit = conf.maxRowsPerSegment
 		msBuilder.chunkBuilder.colBuilder = NewColumnBuilder()
 	}
+	msBuilder.chunkBuilder.colBuilder.coder.SetFloatCodec(conf.FloatCodec(name))
 
 	if msBuilder.trailer == nil {
 		msBuilder.trailer = &Trailer{}
@@ -822,7 +822,7 @@ func GetMsBuilder(dir, name string, fileName TSSPFileName, conf *Config,
 	if err == nil {
 		panic(fmt.Sprintf("file(%v) exist", filePath))
 	}
-	msBuilder.fd, err = fileops.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0640, lock, pri)
+	msBuilder.fd, err = fileops.CreateEncryptedFile(filePath, 0640, lock, pri)
 	if err != nil {
 		log.Error("create file fail", zap.String("name", filePath), zap.Error(err))
 		panic(err)
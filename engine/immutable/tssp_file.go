@@ -19,6 +19,7 @@ package immutable
 import (
 	"fmt"
 	"math"
+	"os"
 	"sync"
 	"sync/atomic"
 
@@ -98,7 +99,7 @@ func CreateTSSPFileReader(size int64, fd fileops.File, trailer *Trailer, tb *Tab
 			log.Error("stat file fail", zap.String("name", tmpName), zap.Error(err))
 			return nil, err
 		}
-		fd, err = fileops.Open(name, lock, pri)
+		fd, err = fileops.OpenDecryptedFile(name, os.O_RDONLY, 0640, lock, pri)
 		if err != nil {
 			log.Error("open file fail", zap.String("name", tmpName), zap.Error(err))
 			return nil, err
@@ -157,17 +158,20 @@ func NewTSSPFileReader(name string) (*TSSPFileReader, error) {
 		return nil, err
 	}
 
-	size := fi.Size()
 	lock := fileops.FileLockOption("")
 	pri := fileops.FilePriorityOption(fileops.IO_PRIORITY_NORMAL)
-	fd, err := fileops.Open(name, lock, pri)
+	fd, err := fileops.OpenDecryptedFile(name, os.O_RDONLY, 0640, lock, pri)
 	if err != nil {
-		err = errCreateFail(name, err)
+		if _, ok := err.(*errno.Error); !ok {
+			err = errCreateFail(name, err)
+		}
 		log.Error("open file failed", zap.String("file", name), zap.Error(err))
 		return nil, err
 	}
 
 	dr := NewDiskFileReader(fd)
+	// the plaintext of an encrypted file is smaller than the file on disk
+	size := dr.fileSize
 
 	hd := header[:]
 	hb, err := dr.ReadAt(0, uint32(len(header[:])), &hd)
@@ -382,6 +386,10 @@ func (r *TSSPFileReader) readSegmentRecord(cm *ChunkMeta, segment int, dst *reco
 
 		var data []byte
 		segOff, segSize := seg.offsetSize()
+		if decs.takePrunedColumn(segOff, colBuilder) {
+			// decoded by the field conditions of the segment
+			continue
+		}
 		if len(chunkData) > 0 {
 			data = columnData(chunkData, cm.offset, segOff, segSize)
 		} else {
@@ -882,6 +882,13 @@ type TablesStore interface {
 	GetOutOfOrderFileNum() int
 	GetMstFileStat() *stats.FileStat
 	DropMeasurement(ctx context.Context, name string) error
+	LoadLastValues(visitor func(name string, isOrder bool) LastValueVisitor) error
+	MeasurementFloatCodec(mst string) (int, bool)
+	MeasurementTTL(mst string) time.Duration
+	DropExpiredFiles(shid uint64)
+	Compact(shid uint64, name string, full bool) error
+	CompactionTasks() []CompactionTask
+	CancelCompactions(name string) int
 }
 
 var compactGroupPool = sync.Pool{New: func() interface{} { return &CompactGroup{group: make([]string, 0, 8)} }}
@@ -893,6 +900,7 @@ type CompactGroup struct {
 	group   []string
 
 	dropping *int64
+	task     *CompactionTask
 }
 
 func NewCompactGroup(name string, toLevle uint16, count int) *CompactGroup {
@@ -909,6 +917,7 @@ func (g *CompactGroup) reset() {
 	g.toLevel = 0
 	g.group = g.group[:0]
 	g.dropping = nil
+	g.task = nil
 }
 
 func (g *CompactGroup) release() {
@@ -920,6 +929,7 @@ type FilesInfo struct {
 	name         string
 	shId         uint64
 	dropping     *int64
+	task         *CompactionTask
 	compIts      FileIterators
 	oldFiles     []TSSPFile
 	oldFids      []string
@@ -946,6 +956,7 @@ type MmsTables struct {
 	inCompLock      sync.RWMutex
 	inCompact       map[string]struct{}
 	inMerge         *InMerge
+	tasks           compactionTasks
 	sequencer       *Sequencer
 	compactRecovery bool
 
@@ -986,6 +997,14 @@ func (m *MmsTables) Tier() uint64 {
 	return tier
 }
 
+func (m *MmsTables) MeasurementFloatCodec(mst string) (int, bool) {
+	return m.Conf.MeasurementFloatCodec(mst)
+}
+
+func (m *MmsTables) MeasurementTTL(mst string) time.Duration {
+	return m.Conf.MeasurementTTL(mst)
+}
+
 func (m *MmsTables) CompactionEnabled() bool {
 	return atomic.LoadInt32(&m.compactionEn) == 1
 }
@@ -1138,13 +1157,22 @@ func (m *MmsTables) openFiles(mnFiles []*loadFilesInfo, fileCount int) error {
 	}
 
 	var errCnt int
+	var keyErr error
 	for i := 0; i < fileCount; i++ {
 		err := <-errs
 		if err != nil {
 			log.Error("open file fail", zap.Error(err))
 			errCnt++
+			if keyErr == nil && errno.Equal(err, errno.EncryptionKeyNotFound) {
+				keyErr = err
+			}
 		}
 	}
+	// a missing key is a configuration error rather than a damaged file,
+	// refuse to open the shard instead of serving part of its data
+	if keyErr != nil {
+		return keyErr
+	}
 	// if not all file open success, just log error and continue
 	if errCnt > 0 {
 		errInfo := errno.NewError(errno.NotAllTsspFileOpenSuccess, fileCount, errCnt)
@@ -1916,12 +1944,18 @@ func (m *MmsTables) LevelPlan(level uint16) []*CompactGroup {
 	if !m.CompactionEnabled() {
 		return nil
 	}
+	return m.levelPlan(level, "")
+}
 
+func (m *MmsTables) levelPlan(level uint16, name string) []*CompactGroup {
 	var plans []*CompactGroup
 	minGroupFileN := LeveLMinGroupFiles[level]
 
 	m.mu.RLock()
 	for k, v := range m.Order {
+		if name != "" && k != name {
+			continue
+		}
 		v.lock.RLock()
 		if atomic.LoadInt64(&v.closing) > 0 || v.Len() < minGroupFileN {
 			v.lock.RUnlock()
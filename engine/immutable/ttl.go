@@ -0,0 +1,103 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immutable
+
+import (
+	"go.uber.org/zap"
+)
+
+// DropExpiredFiles removes the order files whose rows are all older than the ttl of their measurement,
+// the files that still keep some unexpired rows are left to the compaction
+func (m *MmsTables) DropExpiredFiles(shid uint64) {
+	m.mu.RLock()
+	names := make([]string, 0, len(m.Order))
+	for name := range m.Order {
+		names = append(names, name)
+	}
+	m.mu.RUnlock()
+
+	for _, name := range names {
+		if m.isClosed() {
+			return
+		}
+
+		expireTime := m.Conf.ExpireTime(name)
+		if expireTime == 0 {
+			continue
+		}
+		m.dropExpiredFiles(name, expireTime, shid)
+	}
+}
+
+func (m *MmsTables) dropExpiredFiles(name string, expireTime int64, shid uint64) {
+	orderWg, _ := m.refMmsTable(name, false)
+	defer m.unrefMmsTable(orderWg, nil)
+
+	fs := m.tableFiles(name, true)
+	if fs == nil {
+		return
+	}
+
+	var files []TSSPFile
+	var paths []string
+	fs.lock.RLock()
+	for _, f := range fs.files {
+		_, maxTime, err := f.MinMaxTime()
+		if err != nil || maxTime >= expireTime {
+			continue
+		}
+		files = append(files, f)
+		paths = append(paths, f.Path())
+	}
+	fs.lock.RUnlock()
+
+	// the files in compaction are dropped by the compaction itself
+	if len(files) == 0 || !m.acquire(paths) {
+		return
+	}
+	defer m.CompactDone(paths)
+
+	if err := m.removeFiles(name, files, true); err != nil {
+		log.Error("drop expired files fail", zap.String("name", name), zap.Uint64("shid", shid), zap.Error(err))
+		return
+	}
+	log.Info("drop expired files", zap.String("name", name), zap.Uint64("shid", shid), zap.Strings("files", paths))
+}
+
+// removeFiles removes the files from the table and the disk, the rows of the files must be useless
+func (m *MmsTables) removeFiles(name string, files []TSSPFile, isOrder bool) error {
+	fs := m.tableFiles(name, isOrder)
+	if fs == nil {
+		return ErrCompStopped
+	}
+
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+	for _, f := range files {
+		if m.isClosed() {
+			return ErrCompStopped
+		}
+		if fs.fileIndex(f) < 0 {
+			continue
+		}
+		fs.deleteFile(f)
+		if err := m.deleteFiles(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1331,7 +1331,7 @@ func (w *diskWriter) CopyTo(to io.Writer) (int, error) {
 	}
 
 	lock := fileops.FileLockOption("")
-	fd, err := fileops.Open(name, lock)
+	fd, err := fileops.OpenDecryptedFile(name, os.O_RDONLY, 0640, lock)
 	if err != nil {
 		log.Error("open file fail", zap.String("file", name), zap.Error(err))
 		return 0, err
@@ -1500,7 +1500,7 @@ func (w *indexWriter) writeBuffer(p []byte) (int, error) {
 	var err error
 	for len(p) > w.available() && err == nil {
 		if w.lw == nil {
-			fd, err := fileops.OpenFile(w.name, os.O_CREATE|os.O_RDWR, 0640)
+			fd, err := fileops.CreateEncryptedFile(w.name, 0640)
 			if err != nil {
 				log.Error("create file fail", zap.String("file", w.name), zap.Error(err))
 				return 0, err
@@ -1610,7 +1610,7 @@ func (w *indexWriter) seekStart() error {
 	if err != nil {
 		return err
 	}
-	if w.lw, err = fileops.OpenFile(w.name, os.O_RDONLY, 0640); err != nil {
+	if w.lw, err = fileops.OpenDecryptedFile(w.name, os.O_RDONLY, 0640); err != nil {
 		return err
 	}
 
@@ -18,6 +18,7 @@ package ski
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"path"
 	"sync"
@@ -27,13 +28,14 @@ import (
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/bytesutil"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/encoding"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/memory"
-	"github.com/VictoriaMetrics/VictoriaMetrics/lib/mergeset"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/workingsetcache"
 	"github.com/VictoriaMetrics/fastcache"
 	"github.com/openGemini/openGemini/engine/index/mergeindex"
+	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/lib/fileops"
 	"github.com/openGemini/openGemini/lib/logger"
 	"github.com/openGemini/openGemini/lib/record"
+	"github.com/openGemini/openGemini/open_src/vm/mergeset"
 	"go.uber.org/zap"
 )
 
@@ -80,6 +82,11 @@ func (idx *ShardKeyIndex) Open() error {
 	}
 	tb, err := mergeset.OpenTable(shardKeyPath, nil, mergeIndexRows)
 	if err != nil {
+		// a missing key of an encrypted part is reported as it is, like the one of the tssp and wal files
+		var keyErr *errno.Error
+		if errors.As(err, &keyErr) && keyErr.Errno() == errno.EncryptionKeyNotFound {
+			return keyErr
+		}
 		return fmt.Errorf("cannot open index:%s, err: %+v", idx.path, err)
 	}
 	idx.tb = tb
@@ -125,11 +125,18 @@ func (iBuilder *IndexBuilder) Open() error {
 	}
 
 	// Open all indexes
+	opened := make([]*IndexRelation, 0, len(iBuilder.Relations))
 	for _, relation := range iBuilder.Relations {
 		if err := relation.IndexOpen(); err != nil {
 			logger.GetLogger().Error("Index open fail", zap.Error(err))
+			// release the files, so the index can be opened again, e.g. once the missing encryption key is there
+			for _, r := range opened {
+				_ = r.IndexClose()
+			}
+			_ = iBuilder.kvStorage.Close()
 			return err
 		}
+		opened = append(opened, relation)
 	}
 	return nil
 }
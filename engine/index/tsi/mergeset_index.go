@@ -18,6 +18,7 @@ package tsi
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"path"
@@ -30,9 +31,9 @@ import (
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/bytesutil"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/encoding"
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/memory"
-	"github.com/VictoriaMetrics/VictoriaMetrics/lib/mergeset"
 	"github.com/openGemini/openGemini/engine/hybridqp"
 	"github.com/openGemini/openGemini/engine/index/mergeindex"
+	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/lib/logger"
 	"github.com/openGemini/openGemini/lib/record"
 	"github.com/openGemini/openGemini/lib/stringinterner"
@@ -42,6 +43,7 @@ import (
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 	"github.com/openGemini/openGemini/open_src/influx/meta"
 	"github.com/openGemini/openGemini/open_src/influx/query"
+	"github.com/openGemini/openGemini/open_src/vm/mergeset"
 	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
 	"github.com/openGemini/openGemini/open_src/vm/uint64set"
 	"go.uber.org/zap"
@@ -114,6 +116,11 @@ func (idx *MergeSetIndex) Open() error {
 	tablePath := path.Join(idx.path, MergeSetDirName)
 	tb, err := mergeset.OpenTable(tablePath, invalidateTagCache, mergeIndexRows)
 	if err != nil {
+		// a missing key of an encrypted part is reported as it is, like the one of the tssp and wal files
+		var keyErr *errno.Error
+		if errors.As(err, &keyErr) && keyErr.Errno() == errno.EncryptionKeyNotFound {
+			return keyErr
+		}
 		return fmt.Errorf("cannot open index:%s, err: %+v", tablePath, err)
 	}
 	idx.tb = tb
@@ -31,6 +31,7 @@ import (
 	"github.com/openGemini/openGemini/lib/record"
 	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
 	"github.com/openGemini/openGemini/lib/tracing"
+	"github.com/openGemini/openGemini/lib/workload"
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 	"github.com/openGemini/openGemini/open_src/influx/query"
 	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
@@ -763,6 +764,12 @@ func (r *ChunkReader) Work(ctx context.Context) error {
 		tracing.Finish(r.span, r.outputSpan, r.cursorSpan, r.transSpan)
 		r.Close()
 	}()
+
+	// every chunk is read in a scan slot of the workload group, so that
+	// scans of a more urgent group take over at the next chunk
+	manager := workload.GetManager()
+	group := manager.Group(r.schema.Options().GetWorkloadGroup())
+	scheduler := manager.Scheduler()
 	for {
 		select {
 		case <-r.closed:
@@ -770,9 +777,13 @@ func (r *ChunkReader) Work(ctx context.Context) error {
 		case <-ctx.Done():
 			return nil
 		default:
+			if err := scheduler.Acquire(group, ctx.Done()); err != nil {
+				return nil
+			}
 			tracing.StartPP(r.span)
 			ch, err := r.readChunk()
 			tracing.EndPP(r.span)
+			scheduler.Release(group)
 
 			if err != nil {
 				return err
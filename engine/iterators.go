@@ -53,6 +53,8 @@ const (
 	unorderRowCount  = "unorder_row_count"
 	unorderDuration  = "unorder_duration"
 	aggIterCount     = "agg_iter"
+	prunedChunks     = "pruned_chunks"
+	prunedSegments   = "pruned_segments"
 )
 
 const (
@@ -135,7 +137,7 @@ func (s *shard) CreateCursor(ctx context.Context, schema *executor.QuerySchema)
 
 	var readers *immutable.MmsReaders
 	if executor.GetEnableFileCursor() && schema.HasInSeriesAgg() {
-		tr := record.TimeRange{Min: schema.Options().GetStartTime(), Max: schema.Options().GetEndTime()}
+		tr := s.queryTimeRange(schema)
 		readers = s.cloneMeasurementReadersByTime(schema.Options().(*query.ProcessorOptions).Name, schema.Options().IsAscending(), tr)
 	} else {
 		readers = s.cloneMeasurementReaders(schema.Options().(*query.ProcessorOptions).Name)
@@ -157,6 +159,20 @@ func (s *shard) CreateCursor(ctx context.Context, schema *executor.QuerySchema)
 	return s.createGroupCursors(span, schema, tagSets, readers)
 }
 
+// queryTimeRange returns the time range read by the query, the rows older than the ttl of the measurement
+// are filtered out until the compaction drops them
+func (s *shard) queryTimeRange(schema *executor.QuerySchema) record.TimeRange {
+	tr := record.TimeRange{Min: schema.Options().GetStartTime(), Max: schema.Options().GetEndTime()}
+	ttl := s.immTables.MeasurementTTL(schema.Options().(*query.ProcessorOptions).Name)
+	if ttl <= 0 {
+		return tr
+	}
+	if expireTime := time.Now().Add(-ttl).UnixNano(); tr.Min < expireTime {
+		tr.Min = expireTime
+	}
+	return tr
+}
+
 func (s *shard) cloneMeasurementReaders(mm string) *immutable.MmsReaders {
 	var readers immutable.MmsReaders
 	s.mu.RLock()
@@ -213,6 +229,7 @@ func (s *shard) initGroupCursors(querySchema *executor.QuerySchema, parallelism
 	var filterFieldsIdx []int
 	var filterTags []string
 	var auxTags []string
+	var lastCache bool
 
 	cursors := make(comm.KeyCursors, 0, parallelism)
 	for groupIdx := 0; groupIdx < parallelism; groupIdx++ {
@@ -245,12 +262,14 @@ func (s *shard) initGroupCursors(querySchema *executor.QuerySchema, parallelism
 			if c.ctx.schema.Len() <= 1 {
 				return nil, fmt.Errorf("no field selected")
 			}
+			lastCache = s.matchLastCache(querySchema, c.ctx)
 		} else {
 			c.ctx.schema = schema
 			c.ctx.filterFieldsIdx = filterFieldsIdx
 			c.ctx.filterTags = filterTags
 			c.ctx.auxTags = auxTags
 		}
+		c.ctx.lastCache = lastCache
 
 		// init map
 		c.ctx.m = make(map[string]interface{})
@@ -267,8 +286,7 @@ func (s *shard) initGroupCursors(querySchema *executor.QuerySchema, parallelism
 		for _, tagName := range c.ctx.filterTags {
 			c.ctx.m[tagName] = (*string)(nil)
 		}
-		c.ctx.tr.Min = querySchema.Options().GetStartTime()
-		c.ctx.tr.Max = querySchema.Options().GetEndTime()
+		c.ctx.tr = s.queryTimeRange(querySchema)
 		if executor.GetEnableFileCursor() && c.querySchema.HasInSeriesAgg() {
 			c.ctx.decs.SetTr(c.ctx.tr)
 			c.ctx.Ref()
@@ -459,6 +477,7 @@ type idKeyCursorContext struct {
 	seriesPool      *record.RecordPool
 	tmsMergePool    *record.RecordPool
 	querySchema     *executor.QuerySchema
+	lastCache       bool // serve the series from the last value cache of the shard
 }
 
 func (i *idKeyCursorContext) hasAuxTags() bool {
@@ -630,6 +649,8 @@ func (c *groupCursor) StartSpan(span *tracing.Span) {
 		c.span.CreateCounter(unorderDuration, "ns")
 		c.span.CreateCounter(tsmIterCount, "")
 		c.span.CreateCounter(tsmIterDuration, "ns")
+		c.span.CreateCounter(prunedChunks, "")
+		c.span.CreateCounter(prunedSegments, "")
 		for _, cursor := range c.tagSetCursors {
 			cursor.StartSpan(c.span)
 		}
@@ -638,6 +659,8 @@ func (c *groupCursor) StartSpan(span *tracing.Span) {
 
 func (c *groupCursor) EndSpan() {
 	if c.span != nil {
+		c.span.Count(prunedChunks, c.ctx.decs.PrunedChunks())
+		c.span.Count(prunedSegments, c.ctx.decs.PrunedSegments())
 		c.span.Finish()
 	}
 }
@@ -1377,10 +1400,20 @@ func (s *shard) newSeriesCursor(ctx *idKeyCursorContext, span *tracing.Span, sch
 		tm = time.Now()
 	}
 
-	// get record from mem table which match the select cond
-	memTableRecord := s.GetValuesInMutableAndSnapshot(schema.Options().OptionsName(), sid, ctx.tr, ctx.schema, schema.Options().IsAscending())
+	// the newest values of the series in the cache take the place of the mem table and the files
+	var memTableRecord *record.Record
+	var cached bool
+	if ctx.lastCache && filter == nil {
+		memTableRecord, cached = s.lastCache.Values(schema.Options().OptionsName(), sid, ctx.tr, ctx.schema, schema.Options().IsAscending())
+	}
 
-	memTableRecord = immutable.FilterByField(memTableRecord, ctx.m, filter, ctx.filterFieldsIdx, ctx.filterTags, ptTags)
+	var tsmCursor *tsmMergeCursor
+	if !cached {
+		// get record from mem table which match the select cond
+		memTableRecord = s.GetValuesInMutableAndSnapshot(schema.Options().OptionsName(), sid, ctx.tr, ctx.schema, schema.Options().IsAscending())
+
+		memTableRecord = immutable.FilterByField(memTableRecord, ctx.m, filter, ctx.filterFieldsIdx, ctx.filterTags, ptTags)
+	}
 
 	if span != nil {
 		span.Count(memTableDuration, int64(time.Since(tm)))
@@ -1389,12 +1422,13 @@ func (s *shard) newSeriesCursor(ctx *idKeyCursorContext, span *tracing.Span, sch
 		}
 	}
 
-	// create tsm cursor
-	var tsmCursor *tsmMergeCursor
-	tsmCursor, err = NewTsmMergeCursor(ctx, sid, filter, ptTags, span)
+	if !cached {
+		// create tsm cursor
+		tsmCursor, err = NewTsmMergeCursor(ctx, sid, filter, ptTags, span)
 
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// only if tsm or mem table have data, we will create series cursor
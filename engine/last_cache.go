@@ -0,0 +1,93 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/openGemini/openGemini/engine/executor"
+	"github.com/openGemini/openGemini/engine/hybridqp"
+	"github.com/openGemini/openGemini/open_src/influx/influxql"
+	"go.uber.org/zap"
+)
+
+// lastCacheQueryOff is switched by sysctrl, so that queries read the files again
+// if the last value cache of the shards is suspected to be wrong
+var lastCacheQueryOff int32
+
+func EnableLastCacheQuery(en bool) {
+	if en {
+		atomic.StoreInt32(&lastCacheQueryOff, 0)
+	} else {
+		atomic.StoreInt32(&lastCacheQueryOff, 1)
+	}
+}
+
+func LastCacheQueryEnabled() bool {
+	return atomic.LoadInt32(&lastCacheQueryOff) == 0
+}
+
+func (s *shard) loadLastValues() {
+	if s.lastCache == nil {
+		return
+	}
+	start := time.Now()
+	if err := s.lastCache.Load(s.immTables); err != nil {
+		// queries keep reading the files of the shard
+		s.log.Error("load last value cache failed", zap.Uint64("id", s.ident.ShardID), zap.Error(err))
+		return
+	}
+	s.log.Info("load last value cache done", zap.Uint64("id", s.ident.ShardID), zap.Duration("time used", time.Since(start)))
+}
+
+// matchLastCache reports whether the query can be served by the last value cache of the shard:
+// it selects only last() of all its fields, or the newest row of every series, without field conditions.
+func (s *shard) matchLastCache(schema *executor.QuerySchema, ctx *idKeyCursorContext) bool {
+	if !LastCacheQueryEnabled() || !s.lastCache.Ready() {
+		return false
+	}
+	if hasInterval(schema) || hasFieldCondition(ctx) || schema.Options().GetHintType() == hybridqp.ExactStatisticQuery {
+		return false
+	}
+
+	if !hasCall(schema) {
+		opt := schema.Options()
+		return !opt.IsAscending() && opt.GetLimit() == 1 && opt.GetOffset() == 0
+	}
+
+	if !MatchPreAgg(schema, ctx) {
+		return false
+	}
+	// the value of an auxiliary field is the one of the row selected by last(), which may be older
+	// than the newest value of the auxiliary field
+	lastFields := make(map[string]struct{}, len(schema.Calls()))
+	for _, call := range schema.Calls() {
+		if call.Name != "last" || len(call.Args) != 1 {
+			return false
+		}
+		if ref, ok := call.Args[0].(*influxql.VarRef); ok {
+			lastFields[ref.Val] = struct{}{}
+		}
+	}
+	for _, f := range ctx.schema[:ctx.schema.Len()-1] {
+		if _, ok := lastFields[f.Name]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/engine/comm"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/stretchr/testify/require"
+)
+
+func createLastCacheShard(t *testing.T, dir string) *shard {
+	opt := defaultEngineOption
+	defaultEngineOption.LastValueCache = true
+	defer func() {
+		defaultEngineOption = opt
+	}()
+
+	sh, err := createShard(defaultDb, defaultRp, defaultPtId, dir)
+	require.NoError(t, err)
+	return sh
+}
+
+func collectLastRows(t *testing.T, sh *shard, start, end int64, cached bool) []string {
+	tc := TestCase{"LastRow", start, end, createFieldAux(nil), "", nil, false}
+	opt := genQueryOpt(&tc, defaultMeasurementName, false)
+	opt.Limit = 1
+	querySchema := genQuerySchema(tc.fieldAux, opt)
+	cursors, err := sh.CreateCursor(context.Background(), querySchema)
+	require.NoError(t, err)
+
+	var rows []string
+	for _, cur := range cursors {
+		require.Equal(t, cached, cur.(*groupCursor).ctx.lastCache)
+		rows = collectCursorRows(t, cur, rows)
+	}
+	sort.Strings(rows)
+	return rows
+}
+
+func collectCursorRows(t *testing.T, cur comm.KeyCursor, rows []string) []string {
+	defer cur.Close()
+	groupCursor := cur.(*groupCursor)
+	groupCursor.preAgg = true
+	for i := range groupCursor.tagSetCursors {
+		groupCursor.tagSetCursors[i].(*tagSetCursor).SetNextMethod()
+	}
+	for {
+		rec, _, err := cur.Next()
+		require.NoError(t, err)
+		if rec == nil {
+			return rows
+		}
+		for i := 0; i < rec.RowNums(); i++ {
+			row := fmt.Sprintf("%d", rec.Time(i))
+			for j := 0; j < rec.ColNums()-1; j++ {
+				col := &rec.ColVals[j]
+				switch rec.Schema[j].Type {
+				case influx.Field_Type_Int:
+					v, isNil := col.IntegerValue(i)
+					row += fmt.Sprintf(" %v:%v", v, isNil)
+				case influx.Field_Type_Float:
+					v, isNil := col.FloatValue(i)
+					row += fmt.Sprintf(" %v:%v", v, isNil)
+				case influx.Field_Type_Boolean:
+					v, isNil := col.BooleanValue(i)
+					row += fmt.Sprintf(" %v:%v", v, isNil)
+				case influx.Field_Type_String:
+					v, isNil := col.StringValueSafe(i)
+					row += fmt.Sprintf(" %v:%v", v, isNil)
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+}
+
+func TestShard_LastValueCache(t *testing.T) {
+	testDir := t.TempDir()
+	sh := createLastCacheShard(t, testDir)
+
+	tm := time.Now().Truncate(time.Second)
+	rows, minTime, _ := GenDataRecord(nil, 20, 10, time.Second, tm, true, true, true)
+	require.NoError(t, writeData(sh, rows, true))
+	// some fields are left out of the newer rows, their values are kept in the files
+	rows, _, maxTime := GenDataRecord(nil, 20, 5, time.Second, tm.Add(time.Minute), false, true, true)
+	require.NoError(t, writeData(sh, rows, false))
+	require.True(t, sh.lastCache.Ready())
+	require.NoError(t, closeShard(sh))
+
+	// the values are loaded from the files and the wal
+	sh = createLastCacheShard(t, testDir)
+	defer func() {
+		require.NoError(t, closeShard(sh))
+	}()
+	require.True(t, sh.lastCache.Ready())
+
+	EnableLastCacheQuery(false)
+	expect := collectLastRows(t, sh, minTime, maxTime, false)
+	EnableLastCacheQuery(true)
+	got := collectLastRows(t, sh, minTime, maxTime, true)
+
+	require.NotEmpty(t, expect)
+	require.Equal(t, expect, got)
+
+	// the newest values are out of the time range, the files are read
+	collectLastRows(t, sh, minTime, maxTime-int64(time.Second), true)
+}
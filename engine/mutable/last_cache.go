@@ -0,0 +1,324 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutable
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/openGemini/openGemini/engine/immutable"
+	"github.com/openGemini/openGemini/lib/record"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+)
+
+// lastValue is the newest value written to a field of a series
+type lastValue struct {
+	name    string
+	typ     int
+	time    int64
+	integer int64 // integer and boolean values
+	float   float64
+	str     string
+}
+
+type lastEntry struct {
+	values []lastValue
+}
+
+func (e *lastEntry) find(name string) *lastValue {
+	for i := range e.values {
+		if e.values[i].name == name {
+			return &e.values[i]
+		}
+	}
+	return nil
+}
+
+// get returns the value of the field name, which is replaced if it is older than tm.
+// A value of the same time is replaced only if overwrite is set.
+func (e *lastEntry) get(name string, typ int, tm int64, overwrite bool) *lastValue {
+	v := e.find(name)
+	if v == nil {
+		e.values = append(e.values, lastValue{name: name})
+		v = &e.values[len(e.values)-1]
+	} else if tm < v.time || (tm == v.time && !overwrite) {
+		return nil
+	}
+	v.typ = typ
+	v.time = tm
+	return v
+}
+
+// lastMeasurement holds the last values of the series of one measurement,
+// writes to different measurements do not contend for the same lock
+type lastMeasurement struct {
+	mu      sync.RWMutex
+	entries map[uint64]*lastEntry
+}
+
+func (m *lastMeasurement) entry(sid uint64) *lastEntry {
+	e, ok := m.entries[sid]
+	if !ok {
+		e = &lastEntry{}
+		m.entries[sid] = e
+	}
+	return e
+}
+
+// LastValueCache keeps the newest value of every field of every series of a shard,
+// so that last() and latest-row queries do not need to read the files of the shard.
+// It is updated by every write to the mem table, and loaded from the files when the shard is opened.
+type LastValueCache struct {
+	mu    sync.RWMutex // protects mst, the values are protected by the lock of their measurement
+	mst   map[string]*lastMeasurement
+	ready int32
+}
+
+func NewLastValueCache() *LastValueCache {
+	return &LastValueCache{mst: make(map[string]*lastMeasurement)}
+}
+
+// Ready reports whether the cache holds the values of all files of the shard
+func (c *LastValueCache) Ready() bool {
+	return c != nil && atomic.LoadInt32(&c.ready) == 1
+}
+
+func (c *LastValueCache) measurement(name string) *lastMeasurement {
+	c.mu.RLock()
+	m, ok := c.mst[name]
+	c.mu.RUnlock()
+	if ok {
+		return m
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m, ok = c.mst[name]
+	if !ok {
+		m = &lastMeasurement{entries: make(map[uint64]*lastEntry)}
+		c.mst[name] = m
+	}
+	return m
+}
+
+// Update collects the rows written to measurement name, the rows of a series are sorted by time
+func (c *LastValueCache) Update(name string, rows []influx.Row) {
+	if c == nil {
+		return
+	}
+
+	m := c.measurement(name)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range rows {
+		e := m.entry(rows[i].SeriesId)
+		for j := range rows[i].Fields {
+			f := &rows[i].Fields[j]
+			v := e.get(f.Key, int(f.Type), rows[i].Timestamp, true)
+			if v == nil {
+				continue
+			}
+			switch f.Type {
+			case influx.Field_Type_Float:
+				v.float = f.NumValue
+			case influx.Field_Type_Int, influx.Field_Type_Boolean:
+				v.integer = int64(f.NumValue)
+			case influx.Field_Type_String:
+				v.str = f.StrValue
+			}
+		}
+	}
+}
+
+// Load collects the values of all files of the shard, the cache is ready once it succeeds
+func (c *LastValueCache) Load(store immutable.TablesStore) error {
+	err := store.LoadLastValues(func(name string, isOrder bool) immutable.LastValueVisitor {
+		return &lastValueLoader{mst: c.measurement(name), overwrite: !isOrder}
+	})
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt32(&c.ready, 1)
+	return nil
+}
+
+// DropMeasurement removes the values of measurement name
+func (c *LastValueCache) DropMeasurement(name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.mst, name)
+	c.mu.Unlock()
+}
+
+// Values returns the newest values of the fields of schema of the series, in a record with one row
+// per distinct time ordered as requested. The fields written before tr.Min are left out.
+// ok is false if the cache can not answer for the time range, because it is not loaded
+// or a field of the series was written after tr.Max.
+func (c *LastValueCache) Values(name string, sid uint64, tr record.TimeRange, schema record.Schemas, ascending bool) (*record.Record, bool) {
+	if !c.Ready() {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	m, ok := c.mst[name]
+	c.mu.RUnlock()
+	if !ok {
+		// the measurement has no data in the shard
+		return nil, true
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	e, ok := m.entries[sid]
+	if !ok {
+		// the series has no data in the shard
+		return nil, true
+	}
+
+	values := make([]*lastValue, len(schema)-1)
+	times := make([]int64, 0, len(values))
+	for i := range values {
+		v := e.find(schema[i].Name)
+		if v == nil || v.typ != schema[i].Type {
+			continue
+		}
+		if v.time > tr.Max {
+			return nil, false
+		}
+		if v.time < tr.Min {
+			continue
+		}
+		values[i] = v
+		times = append(times, v.time)
+	}
+	if len(times) == 0 {
+		return nil, true
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		if ascending {
+			return times[i] < times[j]
+		}
+		return times[i] > times[j]
+	})
+	rec := record.NewRecordBuilder(schema)
+	for i, tm := range times {
+		if i > 0 && tm == times[i-1] {
+			continue
+		}
+		for j, v := range values {
+			appendLastValue(&rec.ColVals[j], schema[j].Type, v, tm)
+		}
+		rec.AppendTime(tm)
+	}
+	return rec, true
+}
+
+func appendLastValue(col *record.ColVal, typ int, v *lastValue, tm int64) {
+	if v == nil || v.time != tm {
+		switch typ {
+		case influx.Field_Type_Float:
+			col.AppendFloatNull()
+		case influx.Field_Type_Int:
+			col.AppendIntegerNull()
+		case influx.Field_Type_Boolean:
+			col.AppendBooleanNull()
+		case influx.Field_Type_String:
+			col.AppendStringNull()
+		}
+		return
+	}
+
+	switch typ {
+	case influx.Field_Type_Float:
+		col.AppendFloat(v.float)
+	case influx.Field_Type_Int:
+		col.AppendInteger(v.integer)
+	case influx.Field_Type_Boolean:
+		col.AppendBoolean(v.integer != 0)
+	case influx.Field_Type_String:
+		col.AppendString(v.str)
+	}
+}
+
+// lastValueLoader collects the newest values of a measurement from its files
+type lastValueLoader struct {
+	mst       *lastMeasurement
+	overwrite bool
+}
+
+func (l *lastValueLoader) Need(sid uint64, schema record.Schemas, maxTime int64) bool {
+	l.mst.mu.RLock()
+	defer l.mst.mu.RUnlock()
+
+	e, ok := l.mst.entries[sid]
+	if !ok {
+		return true
+	}
+	for i := range schema {
+		if schema[i].Name == record.TimeField {
+			continue
+		}
+		v := e.find(schema[i].Name)
+		if v == nil || maxTime > v.time || (l.overwrite && maxTime == v.time) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *lastValueLoader) Update(sid uint64, rec *record.Record) {
+	l.mst.mu.Lock()
+	defer l.mst.mu.Unlock()
+
+	times := rec.Times()
+	e := l.mst.entry(sid)
+	for i := range rec.Schema {
+		field := &rec.Schema[i]
+		if field.Name == record.TimeField {
+			continue
+		}
+		col := &rec.ColVals[i]
+		for row := col.Len - 1; row >= 0; row-- {
+			if col.IsNil(row) {
+				continue
+			}
+			v := e.get(field.Name, field.Type, times[row], l.overwrite)
+			if v == nil {
+				break
+			}
+			switch field.Type {
+			case influx.Field_Type_Float:
+				v.float, _ = col.FloatValue(row)
+			case influx.Field_Type_Int:
+				v.integer, _ = col.IntegerValue(row)
+			case influx.Field_Type_Boolean:
+				b, _ := col.BooleanValue(row)
+				v.integer = 0
+				if b {
+					v.integer = 1
+				}
+			case influx.Field_Type_String:
+				v.str, _ = col.StringValueSafe(row)
+			}
+			break
+		}
+	}
+}
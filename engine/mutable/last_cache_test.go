@@ -0,0 +1,95 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutable
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/record"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastValueCache_Values(t *testing.T) {
+	c := NewLastValueCache()
+	_, ok := c.Values("mst", 1, record.TimeRange{Min: 0, Max: 100}, nil, true)
+	require.False(t, ok)
+	c.ready = 1
+
+	schema := record.Schemas{
+		record.Field{Name: "f1", Type: influx.Field_Type_Int},
+		record.Field{Name: "f2", Type: influx.Field_Type_Float},
+		record.Field{Name: "time", Type: influx.Field_Type_Int},
+	}
+	c.Update("mst", []influx.Row{
+		{SeriesId: 1, Timestamp: 10, Fields: influx.Fields{{Key: "f1", Type: influx.Field_Type_Int, NumValue: 1}, {Key: "f2", Type: influx.Field_Type_Float, NumValue: 1.5}}},
+		{SeriesId: 1, Timestamp: 20, Fields: influx.Fields{{Key: "f1", Type: influx.Field_Type_Int, NumValue: 2}}},
+		{SeriesId: 1, Timestamp: 5, Fields: influx.Fields{{Key: "f1", Type: influx.Field_Type_Int, NumValue: 3}}},
+	})
+
+	rec, ok := c.Values("mst", 1, record.TimeRange{Min: 0, Max: 100}, schema, false)
+	require.True(t, ok)
+	require.Equal(t, []int64{20, 10}, rec.Times())
+	require.Equal(t, []int64{2}, rec.ColVals[0].IntegerValues())
+	require.Equal(t, []float64{1.5}, rec.ColVals[1].FloatValues())
+
+	rec, ok = c.Values("mst", 1, record.TimeRange{Min: 15, Max: 100}, schema, true)
+	require.True(t, ok)
+	require.Equal(t, []int64{20}, rec.Times())
+
+	_, ok = c.Values("mst", 1, record.TimeRange{Min: 0, Max: 15}, schema, true)
+	require.False(t, ok)
+
+	rec, ok = c.Values("mst", 2, record.TimeRange{Min: 0, Max: 100}, schema, true)
+	require.True(t, ok)
+	require.Nil(t, rec)
+
+	c.DropMeasurement("mst")
+	rec, ok = c.Values("mst", 1, record.TimeRange{Min: 0, Max: 100}, schema, true)
+	require.True(t, ok)
+	require.Nil(t, rec)
+}
+
+func TestLastValueCache_ConcurrentMeasurements(t *testing.T) {
+	c := NewLastValueCache()
+	c.ready = 1
+	schema := record.Schemas{
+		record.Field{Name: "f1", Type: influx.Field_Type_Int},
+		record.Field{Name: "time", Type: influx.Field_Type_Int},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for tm := int64(1); tm <= 100; tm++ {
+				c.Update(name, []influx.Row{{SeriesId: 1, Timestamp: tm, Fields: influx.Fields{{Key: "f1", Type: influx.Field_Type_Int, NumValue: float64(tm)}}}})
+				c.Values(name, 1, record.TimeRange{Min: 0, Max: 1000}, schema, true)
+			}
+		}(fmt.Sprintf("mst%d", i))
+	}
+	wg.Wait()
+
+	for i := 0; i < 4; i++ {
+		rec, ok := c.Values(fmt.Sprintf("mst%d", i), 1, record.TimeRange{Min: 0, Max: 1000}, schema, true)
+		require.True(t, ok)
+		require.Equal(t, []int64{100}, rec.ColVals[0].IntegerValues())
+	}
+}
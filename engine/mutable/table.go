@@ -97,6 +97,8 @@ type MemTable struct {
 	path string
 	idx  *ski.ShardKeyIndex
 
+	lastCache *LastValueCache
+
 	msInfoMap     map[string]*MsInfo // measurements schemas
 	msInfos       []MsInfo
 	concurLimiter limiter.Fixed
@@ -132,6 +134,11 @@ func (t *MemTable) SetIdx(idx *ski.ShardKeyIndex) {
 	t.idx = idx
 }
 
+// SetLastValueCache sets the cache which collects the newest values of the rows written to the table
+func (t *MemTable) SetLastValueCache(c *LastValueCache) {
+	t.lastCache = c
+}
+
 func (t *MemTable) Ref() {
 	atomic.AddInt32(&t.ref, 1)
 }
@@ -527,6 +534,8 @@ func (t *MemTable) WriteRows(rowsD *dictpool.Dict, getLastFlushTime func(msName
 		}
 		msInfo.mu.Unlock()
 		atomic.AddInt64(&Statistics.PerfStat.WriteMstInfoNs, time.Since(start).Nanoseconds())
+
+		t.lastCache.Update(msName, rs)
 	}
 
 	return nil
@@ -537,6 +546,7 @@ func (t *MemTable) Reset() {
 	t.memSize = 0
 	t.msInfoMap = make(map[string]*MsInfo)
 	t.idx = nil
+	t.lastCache = nil
 }
 
 func (t *MemTable) Close() error {
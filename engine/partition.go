@@ -46,6 +46,8 @@ const (
 
 var (
 	reportLoadFrequency = time.Second
+	// the disk usage of the pt is walked less often than the other load stats are reported
+	diskSizeFrequency = time.Minute
 )
 
 type PtNNLock struct {
@@ -64,6 +66,8 @@ type DBPTInfo struct {
 
 	path    string
 	walPath string
+	// dirs places the shards across the data dirs, the indexes are always under path
+	dirs *storeDirs
 
 	// Maintains a set of shards that are in the process of deletion.
 	// This prevents new shards from being created while old ones are being deleted.
@@ -115,6 +119,8 @@ func (dbPT *DBPTInfo) enableReportShardLoad() {
 
 func (dbPT *DBPTInfo) reportLoad() {
 	t := time.NewTicker(reportLoadFrequency)
+	var diskSize uint64
+	var diskSizeTime time.Time
 	defer func() {
 		dbPT.wg.Done()
 		t.Stop()
@@ -164,9 +170,14 @@ func (dbPT *DBPTInfo) reportLoad() {
 				rpStats[len(rpStats)-1].ShardStats.MaxTime = proto.Int64(dbPT.shards[shardID].MaxTime())
 			}
 			dbPT.mu.RUnlock()
+			if time.Since(diskSizeTime) >= diskSizeFrequency {
+				diskSize = dbPT.diskSize()
+				diskSizeTime = time.Now()
+			}
 			dbPTStat := reportCtx.GetDBPTStat()
 			dbPTStat.DB = proto.String(dbPT.database)
 			dbPTStat.PtID = proto.Uint32(dbPT.id)
+			dbPTStat.DiskSize = proto.Uint64(diskSize)
 			dbPTStat.RpStats = append(dbPTStat.RpStats, rpStats...)
 			dbPT.logger.Debug("try to send dbPTStat to storage", zap.Any("dbPTStat", reportCtx.DBPTStat))
 			dbPT.loadCtx.LoadCh <- reportCtx
@@ -175,6 +186,30 @@ func (dbPT *DBPTInfo) reportLoad() {
 	}
 }
 
+// diskSize returns the size of the data and index files of the pt on all data dirs, it is the load the pts are balanced by
+func (dbPT *DBPTInfo) diskSize() uint64 {
+	var size int64
+	for _, p := range dbPT.dirs.dataPaths(dbPT.path, dbPT.relPath()) {
+		err := filepath.Walk(p, func(_ string, info os.FileInfo, err error) error {
+			if err != nil {
+				// the files removed by the compaction while walking are skipped
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !info.IsDir() {
+				size += info.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			dbPT.logger.Warn("failed to get the disk size of the pt", zap.String("path", p), zap.Error(err))
+		}
+	}
+	return uint64(size)
+}
+
 func (dbPT *DBPTInfo) markOffload(ch chan bool) bool {
 	dbPT.mu.Lock()
 	defer dbPT.mu.Unlock()
@@ -410,31 +445,50 @@ func parseShardDir(shardDirName string) (uint64, uint64, *meta.TimeRangeInfo, er
 	return shardID, indexID, tr, nil
 }
 
+// relPath returns the path relative to the data dirs
+func (dbPT *DBPTInfo) relPath(elem ...string) string {
+	return path.Join(append([]string{dbPT.database, strconv.Itoa(int(dbPT.id))}, elem...)...)
+}
+
+// shardWalPath returns the WAL path of an existing shard
+func (dbPT *DBPTInfo) shardWalPath(rp, shardPath, shardDirName string) string {
+	if dbPT.dirs == nil {
+		return path.Join(dbPT.walPath, rp, shardDirName)
+	}
+	return dbPT.dirs.walPathOf(shardPath, dbPT.relPath(rp, shardDirName))
+}
+
 func (dbPT *DBPTInfo) OpenShards(rp string, durationInfos map[uint64]*meta.ShardDurationInfo) error {
-	rpPath := path.Join(dbPT.path, rp)
-	shardDirs, err := fileops.ReadDir(rpPath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil
+	type shardDir struct {
+		rpPath string
+		name   string
+	}
+	var shardDirs []shardDir
+	for _, rpPath := range dbPT.dirs.dataPaths(path.Join(dbPT.path, rp), dbPT.relPath(rp)) {
+		dirs, err := fileops.ReadDir(rpPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		for i := range dirs {
+			if dirs[i].Name() != IndexFileDirectory {
+				shardDirs = append(shardDirs, shardDir{rpPath: rpPath, name: dirs[i].Name()})
+			}
 		}
-		return err
 	}
 
-	walPath := path.Join(dbPT.walPath, rp)
-
 	type res struct {
 		s   Shard
 		err error
 	}
 
-	resC := make(chan *res, len(shardDirs)-1)
+	resC := make(chan *res, len(shardDirs))
 	n := 0
 	for shIdx := range shardDirs {
-		if shardDirs[shIdx].Name() == IndexFileDirectory {
-			continue
-		}
 		n++
-		go func(shardDirName string) {
+		go func(rpPath, shardDirName string) {
 			shardId, indexID, tr, err := parseShardDir(shardDirName)
 			if err != nil {
 				dbPT.logger.Error("skip load shard invalid shard directory", zap.String("shardDir", shardDirName))
@@ -455,7 +509,7 @@ func (dbPT *DBPTInfo) OpenShards(rp string, durationInfos map[uint64]*meta.Shard
 
 			indexBuilder.SetDuration(durationInfos[shardId].DurationInfo.Duration)
 			shardPath := path.Join(rpPath, shardDirName)
-			shardWalPath := path.Join(walPath, shardDirName)
+			shardWalPath := dbPT.shardWalPath(rp, shardPath, shardDirName)
 
 			sh := NewShard(shardPath, shardWalPath, &durationInfos[shardId].Ident, indexBuilder, &durationInfos[shardId].DurationInfo, tr, dbPT.opt)
 			defer func() {
@@ -473,10 +527,10 @@ func (dbPT *DBPTInfo) OpenShards(rp string, durationInfos map[uint64]*meta.Shard
 				return
 			}
 			resC <- &res{s: sh}
-		}(shardDirs[shIdx].Name())
+		}(shardDirs[shIdx].rpPath, shardDirs[shIdx].name)
 	}
 
-	err = nil
+	var err error
 	for i := 0; i < n; i++ {
 		r := <-resC
 		if r.err != nil {
@@ -554,6 +608,14 @@ func (dbPT *DBPTInfo) NewShard(rp string, shardID uint64, timeRangeInfo *meta.Sh
 		pathSeparator + strconv.Itoa(int(timeRangeInfo.OwnerIndex.IndexID))
 	dataPath := path.Join(rpPath, shardPath)
 	walPath = path.Join(walPath, shardPath)
+	if dbPT.dirs != nil {
+		dataPath, walPath = dbPT.dirs.placeShard(dbPT.relPath(rp, shardPath))
+	}
+	defer func() {
+		if err != nil {
+			dbPT.dirs.release(dataPath, walPath)
+		}
+	}()
 	if err = fileops.MkdirAll(dataPath, 0750, lock); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,347 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/fileops"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	meta2 "github.com/openGemini/openGemini/open_src/influx/meta"
+	"go.uber.org/zap"
+)
+
+const (
+	// the names of the pt files start with the kind of the dir they are in
+	ptFileData = "data"
+	ptFileWal  = "wal"
+
+	// a file being fetched from the source node is renamed when it is complete
+	ptFileFetchingSuffix = ".fetching"
+	ptFileChunkSize      = 1024 * 1024
+)
+
+// ptRoots returns the roots of the data dirs and the WAL dirs, the home ones first
+func (e *Engine) ptRoots() map[string][]string {
+	if e.dirs == nil {
+		return map[string][]string{
+			ptFileData: {path.Join(e.dataPath, DataDirectory)},
+			ptFileWal:  {path.Join(e.walPath, WalDirectory)},
+		}
+	}
+	return map[string][]string{
+		ptFileData: storeDirRoots(e.dirs.data),
+		ptFileWal:  storeDirRoots(e.dirs.wal),
+	}
+}
+
+// walkPtFiles calls fn for the files of the pt on all dirs, a name found in more than one dir is visited once
+func (e *Engine) walkPtFiles(db string, ptId uint32, fn func(name, p string, info os.FileInfo) error) error {
+	rel := path.Join(db, strconv.Itoa(int(ptId)))
+	seen := make(map[string]struct{})
+	for _, kind := range []string{ptFileData, ptFileWal} {
+		for _, root := range e.ptRoots()[kind] {
+			ptDir := path.Join(root, rel)
+			err := filepath.Walk(ptDir, func(p string, info os.FileInfo, err error) error {
+				if err != nil {
+					if os.IsNotExist(err) {
+						return nil
+					}
+					return err
+				}
+				if info.IsDir() {
+					if strings.HasSuffix(p, shardMovingSuffix) || strings.HasSuffix(p, shardRemovingSuffix) {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				name := path.Join(kind, strings.TrimPrefix(p, ptDir+"/"))
+				if _, ok := seen[name]; ok {
+					return nil
+				}
+				seen[name] = struct{}{}
+				return fn(name, p, info)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// PtFiles returns the files of the pt, they are copied by the node the pt is moved to
+func (e *Engine) PtFiles(db string, ptId uint32) ([]netstorage.PtFile, error) {
+	var files []netstorage.PtFile
+	err := e.walkPtFiles(db, ptId, func(name, _ string, info os.FileInfo) error {
+		files = append(files, netstorage.PtFile{Name: name, Size: info.Size(), ModTime: info.ModTime().UnixNano()})
+		return nil
+	})
+	return files, err
+}
+
+// ptFilePath returns the path of the pt file on the node, or the path on the home dir if there is no such file
+func (e *Engine) ptFilePath(db string, ptId uint32, name string) (string, error) {
+	name = path.Clean(name)
+	i := strings.IndexByte(name, '/')
+	if i < 0 || path.IsAbs(name) || strings.HasPrefix(name, "../") {
+		return "", ErrInvalidPtFile
+	}
+	roots, ok := e.ptRoots()[name[:i]]
+	if !ok {
+		return "", ErrInvalidPtFile
+	}
+
+	rel := path.Join(db, strconv.Itoa(int(ptId)), name[i+1:])
+	for _, root := range roots {
+		p := path.Join(root, rel)
+		if _, err := fileops.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return path.Join(roots[0], rel), nil
+}
+
+func (e *Engine) ReadPtFile(db string, ptId uint32, name string, offset int64, size int) ([]byte, error) {
+	// the size comes from the requesting node, a chunk is read at most
+	if offset < 0 || size < 0 || size > ptFileChunkSize {
+		return nil, ErrInvalidPtRead
+	}
+	p, err := e.ptFilePath(db, ptId, name)
+	if err != nil {
+		return nil, err
+	}
+	lock := fileops.FileLockOption("")
+	fd, err := fileops.OpenFile(p, os.O_RDONLY, 0640, lock)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	buf := make([]byte, size)
+	n, err := fd.ReadAt(buf, offset)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// FetchPt copies the files of the pt from the source node, the files which are not changed since
+// the last fetch are skipped and the files removed on the source are removed too.
+// The pt must not be opened on this node. The files which fail to be read are skipped unless final is set,
+// the source is still written when the pt is fetched the first time.
+func (e *Engine) FetchPt(db string, ptId uint32, src netstorage.PtFileSource, final bool, fetched *int64) error {
+	e.mu.RLock()
+	opened := e.isDBPtExist(db, ptId)
+	e.mu.RUnlock()
+	if opened {
+		return ErrPTOpened
+	}
+
+	files, err := src.PtFiles(db, ptId)
+	if err != nil {
+		return err
+	}
+	local := make(map[string]netstorage.PtFile)
+	err = e.walkPtFiles(db, ptId, func(name, _ string, info os.FileInfo) error {
+		local[name] = netstorage.PtFile{Name: name, Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		l, ok := local[f.Name]
+		delete(local, f.Name)
+		if ok && l.Size == f.Size && l.ModTime == f.ModTime {
+			continue
+		}
+		if err = e.fetchPtFile(db, ptId, src, f, fetched); err != nil {
+			if final {
+				return err
+			}
+			e.log.Warn("skip the pt file failed to fetch", zap.String("db", db), zap.Uint32("pt", ptId),
+				zap.String("file", f.Name), zap.Error(err))
+		}
+	}
+
+	// the files are removed on the source, by compaction for example
+	lock := fileops.FileLockOption("")
+	for name := range local {
+		p, err := e.ptFilePath(db, ptId, name)
+		if err != nil {
+			return err
+		}
+		if err = fileops.Remove(p, lock); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Engine) fetchPtFile(db string, ptId uint32, src netstorage.PtFileSource, f netstorage.PtFile, fetched *int64) error {
+	p, err := e.ptFilePath(db, ptId, f.Name)
+	if err != nil {
+		return err
+	}
+	lock := fileops.FileLockOption("")
+	if err = fileops.MkdirAll(path.Dir(p), 0750, lock); err != nil {
+		return err
+	}
+
+	tmp := p + ptFileFetchingSuffix
+	fd, err := fileops.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640, lock)
+	if err != nil {
+		return err
+	}
+	for offset := int64(0); offset < f.Size; {
+		buf, err := src.ReadPtFile(db, ptId, f.Name, offset, ptFileChunkSize)
+		if err == nil && len(buf) == 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err == nil {
+			_, err = fd.Write(buf)
+		}
+		if err != nil {
+			_ = fd.Close()
+			_ = fileops.Remove(tmp, lock)
+			return err
+		}
+		offset += int64(len(buf))
+		atomic.AddInt64(fetched, int64(len(buf)))
+	}
+	err = fd.Sync()
+	if e := fd.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = fileops.RenameFile(tmp, p, lock)
+	}
+	if err != nil {
+		_ = fileops.Remove(tmp, lock)
+		return err
+	}
+	// the modification time tells whether the file is changed on the next fetch
+	mtime := time.Unix(0, f.ModTime)
+	return os.Chtimes(p, mtime, mtime)
+}
+
+// OffloadPt closes the pt and keeps its files, the pt is not served by the node anymore
+func (e *Engine) OffloadPt(db string, ptId uint32) error {
+	e.mu.RLock()
+	dbPTInfo, ok := e.DBPartitions[db][ptId]
+	if !ok {
+		e.mu.RUnlock()
+		return nil
+	}
+	done := make(chan bool, 1)
+	if ok := dbPTInfo.markOffload(done); !ok {
+		select {
+		case <-done:
+		case <-time.After(15 * time.Second):
+			log.Warn("offload dbPt timeout", zap.String("db", db), zap.Uint32("pt id", ptId))
+			dbPTInfo.unMarkOffload()
+			e.mu.RUnlock()
+			return meta2.ErrConflictWithIo
+		}
+	}
+	e.mu.RUnlock()
+
+	dbPTInfo.mu.RLock()
+	for _, sh := range dbPTInfo.shards {
+		e.dirs.release(sh.DataPath(), sh.WalPath())
+	}
+	dbPTInfo.mu.RUnlock()
+	if err := dbPTInfo.closeDBPt(); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.dropDBPTInfo(db, ptId)
+	e.mu.Unlock()
+	e.log.Info("pt offloaded", zap.String("db", db), zap.Uint32("pt", ptId))
+	return nil
+}
+
+// LoadPt opens the pt from its files, it is called when the pt is moved to the node
+func (e *Engine) LoadPt(db string, ptId uint32, durationInfos map[uint64]*meta2.ShardDurationInfo) error {
+	e.mu.RLock()
+	opened := e.isDBPtExist(db, ptId)
+	e.mu.RUnlock()
+	if opened {
+		return nil
+	}
+	e.createDBPTIfNotExist(db, ptId)
+
+	rps, err := e.ptRetentionPolicies(db, ptId)
+	if err != nil {
+		return err
+	}
+	for _, rp := range rps {
+		if err = e.loadDbRp(db, ptId, rp, durationInfos); err != nil {
+			return err
+		}
+	}
+	e.log.Info("pt loaded", zap.String("db", db), zap.Uint32("pt", ptId))
+	return nil
+}
+
+// ptRetentionPolicies returns the retention policies of the pt found on all data dirs,
+// the shards of a retention policy may be on any of them
+func (e *Engine) ptRetentionPolicies(db string, ptId uint32) ([]string, error) {
+	rel := path.Join(db, strconv.Itoa(int(ptId)))
+	var rps []string
+	found := make(map[string]struct{})
+	for _, dataPath := range e.dirs.dataPaths(path.Join(e.dataPath, DataDirectory, rel), rel) {
+		dirs, err := fileops.ReadDir(dataPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			if !dir.IsDir() || dir.Name() == "lock" {
+				continue
+			}
+			if _, ok := found[dir.Name()]; !ok {
+				found[dir.Name()] = struct{}{}
+				rps = append(rps, dir.Name())
+			}
+		}
+	}
+	return rps, nil
+}
+
+// DropPt closes the pt and removes its files from the node
+func (e *Engine) DropPt(db string, ptId uint32) error {
+	if err := e.OffloadPt(db, ptId); err != nil {
+		return err
+	}
+	rel := path.Join(db, strconv.Itoa(int(ptId)))
+	dataPath := path.Join(e.dataPath, DataDirectory, rel)
+	walPath := path.Join(e.walPath, WalDirectory, rel)
+	if err := deleteDataAndWalPath(dataPath, walPath); err != nil {
+		return err
+	}
+	return e.dirs.removeAll(rel)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine_MovePt(t *testing.T) {
+	dir := t.TempDir()
+	src := newMultiDirsEngine(filepath.Join(dir, "src"))
+	require.NoError(t, src.Open(nil, nil))
+	src.CreateDBPT(defaultDb, defaultPtId)
+	rows, _, _ := GenDataRecord([]string{"mst"}, 1, 100, time.Second, time.Now(), true, true, false)
+	for id := uint64(1); id <= 2; id++ {
+		require.NoError(t, src.CreateShard(defaultDb, defaultRp, defaultPtId, id, getTimeRangeInfoOfShard(id)))
+		sh := src.DBPartitions[defaultDb][defaultPtId].Shard(id).(*shard)
+		require.NoError(t, writeData(sh, rows, true))
+	}
+
+	dst := newMultiDirsEngine(filepath.Join(dir, "dst"))
+	require.NoError(t, dst.Open(nil, nil))
+	var fetched int64
+	// the files are copied while the source still serves the pt
+	require.NoError(t, dst.FetchPt(defaultDb, defaultPtId, src, false, &fetched))
+	require.True(t, fetched > 0)
+
+	require.NoError(t, src.OffloadPt(defaultDb, defaultPtId))
+	require.Nil(t, src.DBPartitions[defaultDb][defaultPtId])
+	srcFiles, err := src.PtFiles(defaultDb, defaultPtId)
+	require.NoError(t, err)
+	require.NotEmpty(t, srcFiles)
+
+	// the unchanged files are not copied again
+	fetched = 0
+	require.NoError(t, dst.FetchPt(defaultDb, defaultPtId, src, true, &fetched))
+	dstFiles, err := dst.PtFiles(defaultDb, defaultPtId)
+	require.NoError(t, err)
+	require.ElementsMatch(t, srcFiles, dstFiles)
+
+	// the shards are loaded from the other data dir, only the index is on the home dir
+	rel := filepath.Join(defaultDb, strconv.Itoa(int(defaultPtId)), defaultRp)
+	homeRp := filepath.Join(dir, "dst", "disk1", DataDirectory, rel)
+	otherRp := filepath.Join(dir, "dst", "disk2", DataDirectory, rel)
+	require.NoError(t, os.MkdirAll(otherRp, 0750))
+	entries, err := os.ReadDir(homeRp)
+	require.NoError(t, err)
+	for _, e := range entries {
+		if e.Name() != IndexFileDirectory {
+			require.NoError(t, os.Rename(filepath.Join(homeRp, e.Name()), filepath.Join(otherRp, e.Name())))
+		}
+	}
+
+	durations := map[uint64]*meta.ShardDurationInfo{1: getShardDurationInfo(1), 2: getShardDurationInfo(2)}
+	require.NoError(t, dst.LoadPt(defaultDb, defaultPtId, durations))
+	for id := uint64(1); id <= 2; id++ {
+		require.NotNil(t, dst.DBPartitions[defaultDb][defaultPtId].Shard(id))
+	}
+	require.Equal(t, ErrPTOpened, dst.FetchPt(defaultDb, defaultPtId, src, true, &fetched))
+
+	require.NoError(t, src.DropPt(defaultDb, defaultPtId))
+	srcFiles, err = src.PtFiles(defaultDb, defaultPtId)
+	require.NoError(t, err)
+	require.Empty(t, srcFiles)
+	_, err = os.Stat(filepath.Join(dir, "src", "disk1", DataDirectory, defaultDb, strconv.Itoa(int(defaultPtId))))
+	require.True(t, os.IsNotExist(err))
+
+	_, err = src.ReadPtFile(defaultDb, defaultPtId, "../data/x", 0, 10)
+	require.Equal(t, ErrInvalidPtFile, err)
+	for _, c := range []struct {
+		offset int64
+		size   int
+	}{{-1, 10}, {0, -1}, {0, ptFileChunkSize + 1}, {0, math.MaxInt32}} {
+		_, err = src.ReadPtFile(defaultDb, defaultPtId, "data/x", c.offset, c.size)
+		require.Equal(t, ErrInvalidPtRead, err)
+	}
+	require.NoError(t, src.Close())
+	require.NoError(t, dst.Close())
+}
+
+func TestEngine_PtRetentionPolicies(t *testing.T) {
+	dir := t.TempDir()
+	eng := newMultiDirsEngine(dir)
+	rel := filepath.Join(defaultDb, strconv.Itoa(int(defaultPtId)))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "disk1", DataDirectory, rel, "rp0"), 0750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "disk1", DataDirectory, rel, "lock"), 0750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "disk2", DataDirectory, rel, "rp0"), 0750))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "disk2", DataDirectory, rel, "rp1"), 0750))
+
+	rps, err := eng.ptRetentionPolicies(defaultDb, defaultPtId)
+	require.NoError(t, err)
+	require.Equal(t, []string{"rp0", "rp1"}, rps)
+
+	rps, err = eng.ptRetentionPolicies(defaultDb, defaultPtId+1)
+	require.NoError(t, err)
+	require.Empty(t, rps)
+}
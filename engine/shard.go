@@ -148,6 +148,8 @@ type shard struct {
 	forceChan   chan struct{}
 	defaultTags map[string]string
 	fileStat    *statistics.FileStatistics
+	lastCache   *mutable.LastValueCache
+	floatCodecs *floatCodecCache
 }
 
 type nodeMemBucket struct {
@@ -203,7 +205,7 @@ func NewShard(dataPath string, walPath string, ident *meta.ShardIdentifier, inde
 		walPath:           walPath,
 		tsspPath:          tsspPath,
 		ident:             ident,
-		wal:               NewWAL(walPath, options.WalSyncInterval, options.WalEnabled, options.WalReplayParallel, getWalPartitionNum()),
+		wal:               NewWAL(walPath, walSyncModeOf(options, db), options.WalSyncInterval, options.WalEnabled, options.WalReplayParallel, options.WalRecordCrc, getWalPartitionNum()),
 		activeTbl:         mutable.NewMemTable(mutable.NewConfig(), dataPath),
 		indexBuilder:      indexBuilder,
 		maxTime:           0,
@@ -221,6 +223,10 @@ func NewShard(dataPath string, walPath string, ident *meta.ShardIdentifier, inde
 		fileStat: statistics.NewFileStatistics(),
 	}
 	s.log = logger.NewLogger(errno.ModuleShard)
+	if options.LastValueCache {
+		s.lastCache = mutable.NewLastValueCache()
+		s.activeTbl.SetLastValueCache(s.lastCache)
+	}
 	s.SetMutableSizeLimit(options.ShardMutableSizeLimit)
 	s.durationInfo = durationInfo
 	tier, expired := s.TierDurationExpired()
@@ -232,7 +238,19 @@ func NewShard(dataPath string, walPath string, ident *meta.ShardIdentifier, inde
 			s.tier = meta.Cold
 		}
 	}
-	s.immTables = immutable.NewTableStore(tsspPath, &s.tier, options.CompactRecovery, immutable.NewConfig())
+	conf := immutable.NewConfig()
+	if options.MeasurementFloatCodec != nil {
+		s.floatCodecs = newFloatCodecCache(func(mst string) (string, bool) {
+			return options.MeasurementFloatCodec(ident.OwnerDb, ident.Policy, mst)
+		})
+		conf.SetFloatCodecResolver(s.floatCodecs.get)
+	}
+	if options.MeasurementTTL != nil {
+		conf.SetTTLResolver(func(mst string) time.Duration {
+			return options.MeasurementTTL(ident.OwnerDb, ident.Policy, mst)
+		})
+	}
+	s.immTables = immutable.NewTableStore(tsspPath, &s.tier, options.CompactRecovery, conf)
 	s.wg.Add(1)
 	go s.Snapshot()
 	return s
@@ -628,6 +646,7 @@ func flushChunkImp(dataPath, msName string, totalChunks int, tbStore immutable.T
 	orderRec := chunk.OrderWriteRec.GetRecord()
 	unOrderRec := chunk.UnOrderWriteRec.GetRecord()
 	conf := immutable.NewConfig()
+	conf.SetFloatCodecResolver(tbStore.MeasurementFloatCodec)
 	var err error
 	if orderRec.RowNums() != 0 {
 		if orderMs == nil {
@@ -747,6 +766,7 @@ func (s *shard) writeSnapshot() {
 
 	s.activeTbl = mutable.GetMemTable(s.tsspPath)
 	s.activeTbl.SetIdx(s.skIdx)
+	s.activeTbl.SetLastValueCache(s.lastCache)
 	s.activeTbl.GetConf().SetShardMutableSizeLimit(s.mutableSizeLimit)
 	s.snapshotLock.Unlock()
 
@@ -919,6 +939,8 @@ func (s *shard) Open() error {
 	s.setMaxTime(maxTime)
 	logger.GetLogger().Info("open immutable done", zap.Uint64("id", s.ident.ShardID), zap.Duration("time used", time.Since(start)))
 
+	s.loadLastValues()
+
 	// replay wal files
 	wStart := time.Now()
 	err = s.replayWal()
@@ -1007,6 +1029,8 @@ func (s *shard) DropMeasurement(ctx context.Context, name string) error {
 
 	// flush measurement data in mem
 	s.ForceFlush()
+	s.lastCache.DropMeasurement(name)
+	s.floatCodecs.drop(name)
 
 	// drop measurement from immutable
 	return s.immTables.DropMeasurement(ctx, name)
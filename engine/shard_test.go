@@ -236,6 +236,7 @@ func createShard(db, rp string, ptId uint32, pathName string) (*shard, error) {
 
 	if err := sh.Open(); err != nil {
 		_ = sh.Close()
+		_ = indexBuilder.Close()
 		return nil, err
 	}
 	return sh, nil
@@ -1017,6 +1018,61 @@ func TestQueryOnlyInImmutable(t *testing.T) {
 	}
 }
 
+// data: rows older than the measurement ttl in immutable, recent rows in both immutable and mem table
+func TestQueryMeasurementTTL(t *testing.T) {
+	testDir := t.TempDir()
+	sh, err := createShard(defaultDb, defaultRp, defaultPtId, testDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := closeShard(sh); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	sh.immTables.(*immutable.MmsTables).Conf.SetTTLResolver(func(mst string) time.Duration {
+		if mst == "cpu" {
+			return time.Hour
+		}
+		return 0
+	})
+
+	msNames := []string{"cpu"}
+	expiredRows, minTime, _ := GenDataRecord(msNames, 10, 100, time.Second, time.Now().Add(-3*time.Hour), true, true, false)
+	if err = writeData(sh, expiredRows, true); err != nil {
+		t.Fatal(err)
+	}
+	flushedRows, _, _ := GenDataRecord(msNames, 10, 100, time.Second, time.Now().Add(-30*time.Minute), true, true, false)
+	if err = writeData(sh, flushedRows, true); err != nil {
+		t.Fatal(err)
+	}
+	memRows, _, maxTime := GenDataRecord(msNames, 10, 100, time.Second, time.Now().Add(-10*time.Minute), true, true, false)
+	if err = writeData(sh, memRows, false); err != nil {
+		t.Fatal(err)
+	}
+	rows := append(flushedRows, memRows...)
+
+	c := TestCase{"AllField", minTime, maxTime, createFieldAux(nil), "", nil, true}
+	for _, ascending := range []bool{true, false} {
+		opt := genQueryOpt(&c, "cpu", ascending)
+		querySchema := genQuerySchema(c.fieldAux, opt)
+		cursors, err := sh.CreateCursor(context.Background(), querySchema)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := genExpectRecordsMap(rows, querySchema)
+		errs := make(chan error, len(cursors))
+		checkQueryResultParallel(errs, cursors, m, ascending, checkQueryResultForSingleCursor)
+		close(errs)
+		for i := 0; i < len(cursors); i++ {
+			if err = <-errs; err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
 // data: all data in immutable
 func TestQueryOnlyInImmutableWithLimit(t *testing.T) {
 	testDir := t.TempDir()
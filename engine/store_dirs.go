@@ -0,0 +1,441 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openGemini/openGemini/lib/fileops"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/lib/sysinfo"
+	"go.uber.org/zap"
+)
+
+const (
+	// a shard being moved to another data dir is copied to this temporary dir first
+	shardMovingSuffix = ".moving"
+	// the moved shard is renamed with this suffix before it is removed
+	shardRemovingSuffix = ".removing"
+)
+
+// storeDir is a root dir of the shard data or WAL files, typically one per disk
+type storeDir struct {
+	root string
+	dev  uint64
+	// number of the open shards on the dir
+	shards int
+}
+
+// storeDirs places the shards across the data and WAL dirs of the store.
+// The first data dir is the home of the partitions, the indexes are kept in it.
+type storeDirs struct {
+	mu   sync.Mutex
+	data []*storeDir
+	wal  []*storeDir
+	// data dirs created when the engine is opened, shards are rebalanced to them
+	added int
+}
+
+func newStoreDirs(dataPath, walPath string, opt netstorage.EngineOptions) *storeDirs {
+	return &storeDirs{
+		data: makeStoreDirs(DataDirectory, append([]string{dataPath}, opt.DataDirs...)),
+		wal:  makeStoreDirs(WalDirectory, append([]string{walPath}, opt.WalDirs...)),
+	}
+}
+
+func makeStoreDirs(sub string, paths []string) []*storeDir {
+	dirs := make([]*storeDir, 0, len(paths))
+	seen := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		root := path.Join(filepath.Clean(p), sub)
+		if _, ok := seen[root]; ok {
+			continue
+		}
+		seen[root] = struct{}{}
+		dirs = append(dirs, &storeDir{root: root})
+	}
+	return dirs
+}
+
+// open creates the dirs and reads their devices
+func (d *storeDirs) open() error {
+	lock := fileops.FileLockOption("")
+	for i, dir := range append(d.data, d.wal...) {
+		if _, err := fileops.Stat(dir.root); os.IsNotExist(err) && i > 0 && i < len(d.data) {
+			d.added++
+		}
+		if err := fileops.MkdirAll(dir.root, 0750, lock); err != nil {
+			return err
+		}
+		dev, err := sysinfo.DeviceID(dir.root)
+		if err != nil {
+			return err
+		}
+		dir.dev = dev
+	}
+	return nil
+}
+
+func storeDirRoots(dirs []*storeDir) []string {
+	roots := make([]string, len(dirs))
+	for i := range dirs {
+		roots[i] = dirs[i].root
+	}
+	return roots
+}
+
+// pickStoreDir returns the dir with the most free space per open shard, the dirs
+// on the avoided device are chosen only if there is no other one
+func pickStoreDir(dirs []*storeDir, avoidDev uint64, avoid bool) *storeDir {
+	if len(dirs) == 1 {
+		return dirs[0]
+	}
+
+	var best *storeDir
+	bestOther, bestScore := false, -1.0
+	for _, dir := range dirs {
+		_, free, err := sysinfo.DiskUsage(dir.root)
+		if err != nil {
+			continue
+		}
+		other := !avoid || dir.dev != avoidDev
+		score := float64(free) / float64(dir.shards+1)
+		if best == nil || (other && !bestOther) || (other == bestOther && score > bestScore) {
+			best, bestOther, bestScore = dir, other, score
+		}
+	}
+	if best == nil {
+		return dirs[0]
+	}
+	return best
+}
+
+// placeShard returns the data dir and the WAL dir of a new shard
+func (d *storeDirs) placeShard(rel string) (string, string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	data := pickStoreDir(d.data, 0, false)
+	wal := pickStoreDir(d.wal, data.dev, true)
+	data.shards++
+	wal.shards++
+	return path.Join(data.root, rel), path.Join(wal.root, rel)
+}
+
+// walPathOf returns the WAL dir of an existing shard, a new one is placed if the shard has no WAL
+func (d *storeDirs) walPathOf(dataPath, rel string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	data := d.dataDirOf(dataPath)
+	if data != nil {
+		data.shards++
+	}
+
+	for _, wal := range d.wal {
+		walPath := path.Join(wal.root, rel)
+		if _, err := fileops.Stat(walPath); err == nil {
+			wal.shards++
+			return walPath
+		}
+	}
+
+	var wal *storeDir
+	if data != nil {
+		wal = pickStoreDir(d.wal, data.dev, true)
+	} else {
+		wal = pickStoreDir(d.wal, 0, false)
+	}
+	wal.shards++
+	return path.Join(wal.root, rel)
+}
+
+func (d *storeDirs) dataDirOf(p string) *storeDir {
+	for _, dir := range d.data {
+		if strings.HasPrefix(p, dir.root+"/") {
+			return dir
+		}
+	}
+	return nil
+}
+
+// release is called when a shard is closed for deleting
+func (d *storeDirs) release(dataPath, walPath string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if dir := d.dataDirOf(dataPath); dir != nil && dir.shards > 0 {
+		dir.shards--
+	}
+	for _, dir := range d.wal {
+		if strings.HasPrefix(walPath, dir.root+"/") && dir.shards > 0 {
+			dir.shards--
+			return
+		}
+	}
+}
+
+// dataPaths returns the dirs of a relative path on all data dirs, starting with the home one
+func (d *storeDirs) dataPaths(home, rel string) []string {
+	paths := []string{home}
+	if d == nil {
+		return paths
+	}
+	for _, dir := range d.data[1:] {
+		paths = append(paths, path.Join(dir.root, rel))
+	}
+	return paths
+}
+
+// removeAll removes the relative path from the data and WAL dirs except the home ones
+func (d *storeDirs) removeAll(rel string) error {
+	if d == nil {
+		return nil
+	}
+	for _, dirs := range [][]*storeDir{d.data[1:], d.wal[1:]} {
+		for _, dir := range dirs {
+			if err := deleteDir(path.Join(dir.root, rel)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type shardDirInfo struct {
+	dir  *storeDir
+	rel  string
+	size int64
+}
+
+// walkShardDirs calls fn for every shard dir of the data dirs, rel is db/pt/rp/shard
+func (d *storeDirs) walkShardDirs(fn func(dir *storeDir, rel string) error) error {
+	for _, dir := range d.data {
+		err := walkSubDirs(dir.root, "", 4, func(rel string) error {
+			if path.Base(rel) == IndexFileDirectory {
+				return nil
+			}
+			return fn(dir, rel)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkSubDirs calls fn for the dirs at the depth under root/rel
+func walkSubDirs(root, rel string, depth int, fn func(rel string) error) error {
+	dirs, err := fileops.ReadDir(path.Join(root, rel))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		sub := path.Join(rel, dir.Name())
+		if depth == 1 {
+			err = fn(sub)
+		} else {
+			err = walkSubDirs(root, sub, depth-1, fn)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recoverMoves finishes or rolls back the shard moves interrupted by a crash
+func (d *storeDirs) recoverMoves() error {
+	exists := make(map[string]bool)
+	var moving, removing []string
+	err := d.walkShardDirs(func(dir *storeDir, rel string) error {
+		p := path.Join(dir.root, rel)
+		switch {
+		case strings.HasSuffix(rel, shardMovingSuffix):
+			moving = append(moving, p)
+		case strings.HasSuffix(rel, shardRemovingSuffix):
+			removing = append(removing, p)
+		default:
+			exists[rel] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	lock := fileops.FileLockOption("")
+	for _, p := range moving {
+		rel := strings.TrimSuffix(d.relOf(p), shardMovingSuffix)
+		if exists[rel] {
+			// the source is not removed yet, the copy may be incomplete
+			err = deleteDir(p)
+		} else {
+			err = fileops.RenameFile(p, strings.TrimSuffix(p, shardMovingSuffix), lock)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, p := range removing {
+		if err = deleteDir(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *storeDirs) relOf(p string) string {
+	for _, dir := range d.data {
+		if strings.HasPrefix(p, dir.root+"/") {
+			return strings.TrimPrefix(p, dir.root+"/")
+		}
+	}
+	return p
+}
+
+// rebalance moves shards from the dirs with the most shard data to the others,
+// it is called before the shards are opened when a data dir is added
+func (d *storeDirs) rebalance() error {
+	if d.added == 0 || len(d.data) < 2 {
+		return nil
+	}
+
+	used := make(map[*storeDir]int64, len(d.data))
+	shards := make(map[*storeDir][]shardDirInfo, len(d.data))
+	err := d.walkShardDirs(func(dir *storeDir, rel string) error {
+		size, err := dirSize(path.Join(dir.root, rel))
+		if err != nil {
+			return err
+		}
+		used[dir] += size
+		shards[dir] = append(shards[dir], shardDirInfo{dir: dir, rel: rel, size: size})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		var src, dst *storeDir
+		for _, dir := range d.data {
+			if src == nil || used[dir] > used[src] {
+				src = dir
+			}
+			if dst == nil || used[dir] < used[dst] {
+				dst = dir
+			}
+		}
+
+		// move the biggest shard which makes the two dirs closer
+		candidates := shards[src]
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].size > candidates[j].size
+		})
+		moved := -1
+		for i, sh := range candidates {
+			if sh.size > 0 && 2*sh.size <= used[src]-used[dst] {
+				moved = i
+				break
+			}
+		}
+		if moved < 0 {
+			return nil
+		}
+
+		sh := candidates[moved]
+		if err = moveShardDir(path.Join(src.root, sh.rel), path.Join(dst.root, sh.rel)); err != nil {
+			return err
+		}
+		log.Info("shard moved to the added data dir", zap.String("shard", sh.rel),
+			zap.String("from", src.root), zap.String("to", dst.root), zap.Int64("size", sh.size))
+		used[src] -= sh.size
+		used[dst] += sh.size
+		shards[src] = append(candidates[:moved], candidates[moved+1:]...)
+		sh.dir = dst
+		shards[dst] = append(shards[dst], sh)
+	}
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// moveShardDir copies the shard to the other disk, the steps can be recovered by recoverMoves
+func moveShardDir(src, dst string) error {
+	lock := fileops.FileLockOption("")
+	tmp := dst + shardMovingSuffix
+	if err := copyDir(src, tmp); err != nil {
+		_ = deleteDir(tmp)
+		return err
+	}
+	if err := fileops.RenameFile(src, src+shardRemovingSuffix, lock); err != nil {
+		return err
+	}
+	if err := fileops.RenameFile(tmp, dst, lock); err != nil {
+		return err
+	}
+	return deleteDir(src + shardRemovingSuffix)
+}
+
+func copyDir(src, dst string) error {
+	lock := fileops.FileLockOption("")
+	return filepath.Walk(src, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, name)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return fileops.MkdirAll(target, 0750, lock)
+		}
+
+		if _, err = fileops.CopyFile(name, target, lock); err != nil {
+			return err
+		}
+		fd, err := fileops.OpenFile(target, os.O_RDWR, 0640, lock)
+		if err != nil {
+			return err
+		}
+		err = fd.Sync()
+		if e := fd.Close(); err == nil {
+			err = e
+		}
+		return err
+	})
+}
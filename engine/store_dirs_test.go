@@ -0,0 +1,169 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/logger"
+	"github.com/openGemini/openGemini/lib/interruptsignal"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/stretchr/testify/require"
+)
+
+func newMultiDirsEngine(dir string) *Engine {
+	opt := defaultEngineOption
+	opt.DataDirs = []string{filepath.Join(dir, "disk2")}
+	opt.WalDirs = []string{filepath.Join(dir, "disk3")}
+	dataPath := filepath.Join(dir, "disk1")
+	eng := &Engine{
+		closed:       interruptsignal.NewInterruptSignal(),
+		dataPath:     dataPath,
+		walPath:      dataPath,
+		dirs:         newStoreDirs(dataPath, dataPath, opt),
+		DBPartitions: make(map[string]map[uint32]*DBPTInfo, 64),
+		droppingDB:   make(map[string]string),
+		droppingRP:   make(map[string]string),
+		droppingMst:  make(map[string]string),
+		loadCtx:      getLoadCtx(),
+		engOpt:       opt,
+	}
+	eng.log = logger.New(os.Stderr)
+	return eng
+}
+
+func getTimeRangeInfoOfShard(shId uint64) *meta.ShardTimeRangeInfo {
+	tr := meta.TimeRangeInfo{StartTime: mustParseTime(time.RFC3339Nano, "1999-01-01T01:00:00Z"),
+		EndTime: mustParseTime(time.RFC3339Nano, "2000-01-01T01:00:00Z")}
+	return &meta.ShardTimeRangeInfo{
+		TimeRange: tr,
+		OwnerIndex: meta.IndexDescriptor{
+			IndexID:      1,
+			IndexGroupID: defaultShGroupId,
+			TimeRange:    tr,
+		},
+		ShardDuration: getShardDurationInfo(shId),
+	}
+}
+
+func TestEngine_MultiDataDirs(t *testing.T) {
+	dir := t.TempDir()
+	eng := newMultiDirsEngine(dir)
+	require.NoError(t, eng.Open(nil, nil))
+	eng.CreateDBPT(defaultDb, defaultPtId)
+
+	// the shards are spread across the data dirs, the index is kept in the first one
+	rows, _, _ := GenDataRecord([]string{"mst"}, 1, 100, time.Second, time.Now(), true, true, false)
+	dataPaths := make(map[uint64]string)
+	walPaths := make(map[uint64]string)
+	roots := make(map[string]bool)
+	for id := uint64(1); id <= 2; id++ {
+		require.NoError(t, eng.CreateShard(defaultDb, defaultRp, defaultPtId, id, getTimeRangeInfoOfShard(id)))
+		sh := eng.DBPartitions[defaultDb][defaultPtId].Shard(id).(*shard)
+		require.NoError(t, writeData(sh, rows, false))
+		dataPaths[id], walPaths[id] = sh.DataPath(), sh.WalPath()
+		roots[strings.Split(strings.TrimPrefix(sh.DataPath(), dir+"/"), "/")[0]] = true
+		require.True(t, strings.HasPrefix(sh.WalPath(), filepath.Join(dir, "disk1", WalDirectory)) ||
+			strings.HasPrefix(sh.WalPath(), filepath.Join(dir, "disk3", WalDirectory)), sh.WalPath())
+	}
+	require.Equal(t, map[string]bool{"disk1": true, "disk2": true}, roots)
+	_, err := os.Stat(filepath.Join(dir, "disk1", DataDirectory, defaultDb, strconv.Itoa(int(defaultPtId)), defaultRp, IndexFileDirectory))
+	require.NoError(t, err)
+
+	// the disk size reported for the pt covers all the data dirs
+	var size int64
+	for _, disk := range []string{"disk1", "disk2"} {
+		n, err := dirSize(filepath.Join(dir, disk, DataDirectory, defaultDb, strconv.Itoa(int(defaultPtId))))
+		require.NoError(t, err)
+		size += n
+	}
+	require.True(t, size > 0)
+	require.Equal(t, uint64(size), eng.DBPartitions[defaultDb][defaultPtId].diskSize())
+	require.NoError(t, eng.Close())
+
+	// the shards of all the dirs are loaded
+	eng = newMultiDirsEngine(dir)
+	durations := map[uint64]*meta.ShardDurationInfo{1: getShardDurationInfo(1), 2: getShardDurationInfo(2)}
+	require.NoError(t, eng.Open([]uint32{defaultPtId}, durations))
+	for id := uint64(1); id <= 2; id++ {
+		sh := eng.DBPartitions[defaultDb][defaultPtId].Shard(id)
+		require.NotNil(t, sh)
+		require.Equal(t, dataPaths[id], sh.DataPath())
+		require.Equal(t, walPaths[id], sh.WalPath())
+	}
+
+	require.NoError(t, eng.DeleteDatabase(defaultDb, defaultPtId))
+	for _, disk := range []string{"disk1", "disk2"} {
+		_, err = os.Stat(filepath.Join(dir, disk, DataDirectory, defaultDb, strconv.Itoa(int(defaultPtId))))
+		require.True(t, os.IsNotExist(err), disk)
+	}
+	require.NoError(t, eng.Close())
+}
+
+func TestStoreDirs_RebalanceAndRecover(t *testing.T) {
+	dir := t.TempDir()
+	rpPath := filepath.Join(dir, "disk1", DataDirectory, "db0", "0", "rp0")
+	writeFile := func(name string, size int) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(name), 0750))
+		require.NoError(t, os.WriteFile(name, make([]byte, size), 0640))
+	}
+	for _, sh := range []string{"1_0_1_1", "2_0_1_1", "3_0_1_1", "4_0_1_1"} {
+		writeFile(filepath.Join(rpPath, sh, "tssp", "mst", "00000001-0000-00000000.tssp"), 1000)
+	}
+	writeFile(filepath.Join(rpPath, IndexFileDirectory, "1_0_1", "mergeset", "parts.json"), 1000)
+	// interrupted moves: the copy of shard 1 is incomplete, shard 5 is copied but not renamed
+	writeFile(filepath.Join(rpPath, "1_0_1_1"+shardMovingSuffix, "tssp", "mst", "00000001-0000-00000000.tssp"), 10)
+	writeFile(filepath.Join(rpPath, "5_0_1_1"+shardMovingSuffix, "tssp", "mst", "00000001-0000-00000000.tssp"), 10)
+
+	opt := defaultEngineOption
+	opt.DataDirs = []string{filepath.Join(dir, "disk2")}
+	dirs := newStoreDirs(filepath.Join(dir, "disk1"), filepath.Join(dir, "disk1"), opt)
+	require.NoError(t, dirs.open())
+	require.Equal(t, 1, dirs.added)
+	require.NoError(t, dirs.recoverMoves())
+	require.NoError(t, dirs.rebalance())
+
+	count := func(disk string) []string {
+		var shards []string
+		d := newStoreDirs(filepath.Join(dir, disk), filepath.Join(dir, disk), defaultEngineOption)
+		require.NoError(t, d.walkShardDirs(func(_ *storeDir, rel string) error {
+			shards = append(shards, rel)
+			return nil
+		}))
+		return shards
+	}
+	disk1, disk2 := count("disk1"), count("disk2")
+	require.Equal(t, 5, len(disk1)+len(disk2))
+	require.Equal(t, 2, len(disk2), disk2)
+	for _, rel := range append(disk1, disk2...) {
+		require.False(t, strings.HasSuffix(rel, shardMovingSuffix) || strings.HasSuffix(rel, shardRemovingSuffix), rel)
+	}
+
+	// the index is not moved
+	_, err := os.Stat(filepath.Join(rpPath, IndexFileDirectory, "1_0_1", "mergeset", "parts.json"))
+	require.NoError(t, err)
+	for _, rel := range disk2 {
+		size, err := dirSize(filepath.Join(dir, "disk2", DataDirectory, rel))
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), size)
+	}
+}
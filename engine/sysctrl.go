@@ -17,6 +17,7 @@ limitations under the License.
 package engine
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -33,6 +34,8 @@ import (
  curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=compen&switchon=true&allshards=true&shid=4'
  curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=merge&switchon=true&allshards=true&shid=4'
  curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=snapshot&duration=30m'
+ curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=compact&shid=4&full=true'
+ curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=cancelcompact&db=db0&rp=rp0&mst=cpu'
 */
 
 const (
@@ -42,6 +45,10 @@ const (
 	snapshot     = "snapshot"
 	Failpoint    = "failpoint"
 	Readonly     = "readonly"
+	lastCache    = "lastcache"
+	compactions  = "compactions"
+	compact      = "compact"
+	cancelComp   = "cancelcompact"
 )
 
 var (
@@ -120,11 +127,99 @@ func (e *Engine) processReq(req *netstorage.SysCtrlRequest) error {
 		return nil
 	case Readonly:
 		return e.handleReadonly(req)
+	case lastCache:
+		en, err := boolValue(req.Param(), "switchon")
+		if err != nil {
+			log.Error("get last cache switchon from param fail", zap.Error(err))
+			return err
+		}
+		EnableLastCacheQuery(en)
+		log.Info("set last cache query switch", zap.Bool("switch", en))
+		return nil
+	case compact:
+		// a compaction needs extra space, which is not available above the flood watermark
+		if err := e.watermark.checkWrite(); err != nil {
+			return err
+		}
+		match, err := shardMatcher(req.Param())
+		if err != nil {
+			log.Error("get compact shards from param fail", zap.Error(err))
+			return err
+		}
+		full, err := boolValue(req.Param(), "full")
+		if err != nil && err != ErrNoSuchParam {
+			log.Error("get full compact from param fail", zap.Error(err))
+			return err
+		}
+		n := compWorker.CompactShards(match, req.Param()["mst"], full)
+		log.Info("manual compaction scheduled", zap.Int("shards", n), zap.String("mst", req.Param()["mst"]), zap.Bool("full", full))
+		return nil
+	case cancelComp:
+		match, err := shardMatcher(req.Param())
+		if err != nil {
+			log.Error("get cancel compact shards from param fail", zap.Error(err))
+			return err
+		}
+		n := compWorker.CancelCompactions(match, req.Param()["mst"])
+		log.Info("compactions cancelled", zap.Int("tasks", n), zap.String("mst", req.Param()["mst"]))
+		return nil
 	default:
 		return fmt.Errorf("unknown sys cmd %v", req.Mod())
 	}
 }
 
+// shardMatcher selects the shards by the shid parameter, or by the db and rp parameters.
+// All shards are selected when none of them is given.
+func shardMatcher(param map[string]string) (func(sh *shard) bool, error) {
+	if _, ok := param["shid"]; ok {
+		shid, err := intValue(param, "shid")
+		if err != nil {
+			return nil, err
+		}
+		return func(sh *shard) bool {
+			return sh.ident.ShardID == uint64(shid)
+		}, nil
+	}
+
+	db, rp := param["db"], param["rp"]
+	if db == "" && rp != "" {
+		return nil, fmt.Errorf("no db in parameter")
+	}
+	return func(sh *shard) bool {
+		return (db == "" || sh.ident.OwnerDb == db) && (rp == "" || sh.ident.Policy == rp)
+	}, nil
+}
+
+func compactionsResult() (map[string]string, error) {
+	now := time.Now()
+	tasks := compWorker.CompactionTasks()
+	infos := make([]netstorage.CompactionInfo, 0, len(tasks))
+	for i := range tasks {
+		t := &tasks[i]
+		info := netstorage.CompactionInfo{
+			ID:          t.ID,
+			ShardID:     t.ShardID,
+			Measurement: t.Name,
+			Type:        t.Type,
+			Level:       t.Level,
+			Files:       t.Files,
+			Size:        t.Size,
+			Running:     t.Running(),
+			Elapsed:     int64(now.Sub(t.Queued)),
+		}
+		if info.Running {
+			info.Elapsed = int64(now.Sub(t.Started))
+		}
+		infos = append(infos, info)
+	}
+
+	buf, err := json.Marshal(infos)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{compactions: string(buf)}, nil
+}
+
 func handleFailpoint(req *netstorage.SysCtrlRequest) error {
 	switchon, err := boolValue(req.Param(), "switchon")
 	if err != nil {
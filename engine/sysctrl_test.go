@@ -17,9 +17,11 @@ limitations under the License.
 package engine
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
@@ -52,3 +54,76 @@ func TestEngine_processReq_readonly(t *testing.T) {
 	})
 	require.NoError(t, e.processReq(req))
 }
+
+func TestEngine_processReq_lastCache(t *testing.T) {
+	log = zap.NewNop()
+	e := Engine{
+		log: zap.NewNop(),
+	}
+	defer EnableLastCacheQuery(true)
+
+	req := &netstorage.SysCtrlRequest{}
+	req.SetMod(lastCache)
+	req.SetParam(map[string]string{"switchon": "false"})
+	require.NoError(t, e.processReq(req))
+	require.False(t, LastCacheQueryEnabled())
+
+	req.SetParam(map[string]string{"switchon": "true"})
+	require.NoError(t, e.processReq(req))
+	require.True(t, LastCacheQueryEnabled())
+
+	req.SetParam(map[string]string{})
+	require.Error(t, e.processReq(req))
+}
+
+func TestEngine_SysCtrl_compaction(t *testing.T) {
+	log = zap.NewNop()
+	e := Engine{
+		log: zap.NewNop(),
+	}
+
+	req := &netstorage.SysCtrlRequest{}
+	req.SetMod(compact)
+	req.SetParam(map[string]string{"shid": "1000000", "full": "true"})
+	require.NoError(t, e.processReq(req))
+
+	req.SetParam(map[string]string{"shid": "x"})
+	require.Error(t, e.processReq(req))
+
+	req.SetParam(map[string]string{"rp": "rp0"})
+	require.Error(t, e.processReq(req))
+
+	req.SetParam(map[string]string{"db": "db_sysctrl", "mst": "cpu", "full": "yes"})
+	require.Error(t, e.processReq(req))
+
+	req.SetMod(cancelComp)
+	req.SetParam(map[string]string{"db": "db_sysctrl", "rp": "rp0", "mst": "cpu"})
+	require.NoError(t, e.processReq(req))
+
+	req.SetMod(compactions)
+	req.SetParam(nil)
+	ret, err := e.SysCtrl(req)
+	require.NoError(t, err)
+	var infos []netstorage.CompactionInfo
+	require.NoError(t, json.Unmarshal([]byte(ret[compactions]), &infos))
+}
+
+func TestShardMatcher(t *testing.T) {
+	sh := &shard{ident: &meta.ShardIdentifier{ShardID: 3, OwnerDb: "db0", Policy: "rp0"}}
+	for _, c := range []struct {
+		param map[string]string
+		match bool
+	}{
+		{map[string]string{}, true},
+		{map[string]string{"shid": "3"}, true},
+		{map[string]string{"shid": "4"}, false},
+		{map[string]string{"db": "db0"}, true},
+		{map[string]string{"db": "db0", "rp": "rp0"}, true},
+		{map[string]string{"db": "db0", "rp": "rp1"}, false},
+		{map[string]string{"db": "db1"}, false},
+	} {
+		match, err := shardMatcher(c.param)
+		require.NoError(t, err)
+		require.Equal(t, c.match, match(sh), c.param)
+	}
+}
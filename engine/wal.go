@@ -18,6 +18,7 @@ package engine
 import (
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"os"
 	"path/filepath"
@@ -30,27 +31,73 @@ import (
 
 	"github.com/golang/snappy"
 	"github.com/openGemini/openGemini/lib/bufferpool"
+	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/lib/fileops"
 	"github.com/openGemini/openGemini/lib/logger"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
 	"github.com/openGemini/openGemini/lib/util"
 	"github.com/pingcap/failpoint"
+	"go.uber.org/zap"
 )
 
 const (
 	DefaultFileSize   = 10 * 1024 * 1024
 	WALFileSuffixes   = "wal"
 	WalRecordHeadSize = 1 + 4
-	WalCompBufSize    = 256 * 1024
-	WalCompMaxBufSize = 2 * 1024 * 1024
+	// header of the records with a crc: type, length and crc32 of the compressed data
+	WalRecordCrcHeadSize = WalRecordHeadSize + 4
+	WalCompBufSize       = 256 * 1024
+	WalCompMaxBufSize    = 2 * 1024 * 1024
 )
 
 type WalRecordType byte
 
 const (
 	WriteWALRecord WalRecordType = 0x01
+	// same as WriteWALRecord, with the crc of the compressed data in the header.
+	// Written only if enabled by config, versions without it can not replay such files
+	WriteWALRecordCrc WalRecordType = 0x02
 )
 
+// records found by resync are at most this size, so that a damaged header does not make it read a huge body
+const walResyncMaxRecordSize = 64 * 1024 * 1024
+
+// durability modes of the WAL
+const (
+	// the WAL files are synced every SyncInterval
+	WalSyncPeriodic = iota
+	// the WAL files are synced only when they are switched or closed
+	WalSyncAsync
+	// a write returns after it is synced, concurrent writers of a partition share one fsync
+	WalSyncFsync
+)
+
+// corrupt WAL files are moved into this dir of the shard WAL path when they are replayed
+const walQuarantineDir = "quarantine"
+
+var walStat = statistics.NewWalStatistics()
+
+func ParseWalSyncMode(mode string) int {
+	switch strings.ToLower(mode) {
+	case config.WalSyncModeAsync:
+		return WalSyncAsync
+	case config.WalSyncModeFsync:
+		return WalSyncFsync
+	default:
+		return WalSyncPeriodic
+	}
+}
+
+// walSyncModeOf returns the WAL durability of the database
+func walSyncModeOf(options netstorage.EngineOptions, db string) int {
+	if mode, ok := options.WalSyncModes[db]; ok {
+		return ParseWalSyncMode(mode)
+	}
+	return ParseWalSyncMode(options.WalSyncMode)
+}
+
 var (
 	walCompBufPool = bufferpool.NewByteBufferPool(WalCompBufSize)
 )
@@ -64,15 +111,17 @@ type WAL struct {
 	logWriter      []LogWriter
 	walEnabled     bool
 	replayParallel bool
+	recordCrc      bool
 }
 
-func NewWAL(path string, walSyncInterval time.Duration, walEnabled, replayParallel bool, partitionNum int) *WAL {
+func NewWAL(path string, syncMode int, walSyncInterval time.Duration, walEnabled, replayParallel, recordCrc bool, partitionNum int) *WAL {
 	wal := &WAL{
 		logPath:        path,
 		partitionNum:   partitionNum,
 		logWriter:      make([]LogWriter, partitionNum),
 		walEnabled:     walEnabled,
 		replayParallel: replayParallel,
+		recordCrc:      recordCrc,
 		log:            logger.NewLogger(errno.ModuleWal),
 	}
 
@@ -82,7 +131,9 @@ func NewWAL(path string, walSyncInterval time.Duration, walEnabled, replayParall
 			closed:       make(chan struct{}),
 			logPath:      filepath.Join(path, strconv.Itoa(i)),
 			SyncInterval: walSyncInterval,
+			SyncMode:     syncMode,
 		}
+		wal.logWriter[i].groupCond = sync.NewCond(&wal.logWriter[i].groupMu)
 
 		err := fileops.MkdirAll(wal.logWriter[i].logPath, 0750, lock)
 		if err != nil {
@@ -97,7 +148,11 @@ func (l *WAL) writeBinary(binaryData []byte) error {
 	// prepare for compress memory
 	compBuf := walCompBufPool.Get()
 	maxEncodeLen := snappy.MaxEncodedLen(len(binaryData))
-	compBuf = bufferpool.Resize(compBuf, WalRecordHeadSize+maxEncodeLen)
+	headSize := WalRecordHeadSize
+	if l.recordCrc {
+		headSize = WalRecordCrcHeadSize
+	}
+	compBuf = bufferpool.Resize(compBuf, headSize+maxEncodeLen)
 	defer func() {
 		if len(compBuf) <= WalCompMaxBufSize {
 			walCompBufPool.Put(compBuf)
@@ -105,12 +160,16 @@ func (l *WAL) writeBinary(binaryData []byte) error {
 	}()
 
 	// compress data
-	compData := snappy.Encode(compBuf[WalRecordHeadSize:], binaryData)
+	compData := snappy.Encode(compBuf[headSize:], binaryData)
 
 	// encode record header
 	compBuf[0] = byte(WriteWALRecord)
 	binary.BigEndian.PutUint32(compBuf[1:WalRecordHeadSize], uint32(len(compData)))
-	compBuf = compBuf[:WalRecordHeadSize+len(compData)]
+	if l.recordCrc {
+		compBuf[0] = byte(WriteWALRecordCrc)
+		binary.BigEndian.PutUint32(compBuf[WalRecordHeadSize:WalRecordCrcHeadSize], crc32.ChecksumIEEE(compData))
+	}
+	compBuf = compBuf[:headSize+len(compData)]
 
 	// write data, switch to new file if needed
 	l.mu.RLock()
@@ -119,6 +178,8 @@ func (l *WAL) writeBinary(binaryData []byte) error {
 	if err != nil {
 		return fmt.Errorf("writing WAL entry failed: %v", err)
 	}
+	walStat.AddWriteRecords(1)
+	walStat.AddWriteBytes(int64(len(compBuf)))
 	return nil
 }
 
@@ -183,95 +244,262 @@ func (l *WAL) Remove(files []string) error {
 	return nil
 }
 
-func (l *WAL) replayPhysicRecord(fd fileops.File, offset, fileSize int64, recordCompBuff []byte,
-	callBack func(binary []byte) error) (int64, []byte, error) {
-	// check file is all replayed
-	if offset >= fileSize {
-		return offset, recordCompBuff, io.EOF
+// WalRecord is a record of a WAL file
+type WalRecord struct {
+	Offset int64
+	Type   WalRecordType
+	// size of the record in the file, including the header
+	Size int64
+	// Data is the decompressed record, nil if the record is corrupt
+	Data []byte
+	// Err is the reason why the record is corrupt. A corrupt record spans the bytes up to the next valid record
+	Err error
+}
+
+// WalReader reads the records of a WAL file one by one. A corrupt record is skipped by searching for
+// the next record whose crc matches, or without crc, whose body decompresses and is followed by another record.
+type WalReader struct {
+	fd     fileops.File
+	size   int64
+	offset int64
+	buf    []byte
+	scan   []byte
+}
+
+func OpenWalReader(name string) (*WalReader, error) {
+	lock := fileops.FileLockOption("")
+	pri := fileops.FilePriorityOption(fileops.IO_PRIORITY_NORMAL)
+	fd, err := fileops.OpenDecryptedFile(name, os.O_RDONLY, 0640, lock, pri)
+	if err != nil {
+		return nil, err
 	}
 
-	// read record header
-	var recordHeader [WalRecordHeadSize]byte
-	n, err := fd.ReadAt(recordHeader[:], offset)
+	stat, err := fd.Stat()
 	if err != nil {
-		l.log.Warn(errno.NewError(errno.ReadWalFileFailed, fd.Name(), offset, "record header").Error())
-		return offset, recordCompBuff, io.EOF
-	}
-	if n != WalRecordHeadSize {
-		l.log.Warn(errno.NewError(errno.WalRecordHeaderCorrupted, fd.Name(), offset).Error())
-		return offset, recordCompBuff, io.EOF
-	}
-	offset += int64(len(recordHeader))
-
-	// prepare record memory
-	compBinaryLen := binary.BigEndian.Uint32(recordHeader[1:WalRecordHeadSize])
-	recordCompBuff = bufferpool.Resize(recordCompBuff, int(compBinaryLen))
-
-	// read record body
-	var recordBuff []byte
-	n, err = fd.ReadAt(recordCompBuff, offset)
-	if err == nil || err == io.EOF {
-		offset += int64(n)
-		var innerErr error
-		recordBuff, innerErr = snappy.Decode(recordBuff, recordCompBuff)
-		if innerErr != nil {
-			l.log.Warn(errno.NewError(errno.DecompressWalRecordFailed, fd.Name(), offset, innerErr.Error()).Error())
-			return offset, recordCompBuff, io.EOF
-		}
+		util.MustClose(fd)
+		return nil, err
+	}
+	return &WalReader{fd: fd, size: stat.Size()}, nil
+}
+
+func (r *WalReader) Name() string {
+	return r.fd.Name()
+}
 
-		innerErr = callBack(recordBuff)
-		if innerErr == nil {
-			return offset, recordCompBuff, err
+func (r *WalReader) Close() error {
+	if len(r.buf) <= WalCompMaxBufSize {
+		walCompBufPool.Put(r.buf)
+	}
+	r.buf = nil
+	return r.fd.Close()
+}
+
+func (r *WalReader) readAt(size int, offset int64) ([]byte, error) {
+	if r.buf == nil {
+		r.buf = walCompBufPool.Get()
+	}
+	r.buf = bufferpool.Resize(r.buf, size)
+	n, err := r.fd.ReadAt(r.buf, offset)
+	if n == size {
+		return r.buf, nil
+	}
+	if err == nil {
+		err = io.ErrUnexpectedEOF
+	}
+	return nil, err
+}
+
+// validRecordAt reports whether a valid record starts at offset. A record with crc is valid if the crc matches,
+// a record without crc if its body decompresses and it is followed by the end of file or another record type
+func (r *WalReader) validRecordAt(head []byte, offset int64) bool {
+	headSize := int64(WalRecordCrcHeadSize)
+	switch WalRecordType(head[0]) {
+	case WriteWALRecordCrc:
+	case WriteWALRecord:
+		headSize = WalRecordHeadSize
+	default:
+		return false
+	}
+	size := int64(binary.BigEndian.Uint32(head[1:WalRecordHeadSize]))
+	if size == 0 || size > walResyncMaxRecordSize || offset+headSize+size > r.size {
+		return false
+	}
+	data, err := r.readAt(int(size), offset+headSize)
+	if err != nil {
+		return false
+	}
+	if headSize == WalRecordCrcHeadSize {
+		return crc32.ChecksumIEEE(data) == binary.BigEndian.Uint32(head[WalRecordHeadSize:WalRecordCrcHeadSize])
+	}
+
+	n, err := snappy.DecodedLen(data)
+	if err != nil || n > walResyncMaxRecordSize {
+		return false
+	}
+	if _, err = snappy.Decode(nil, data); err != nil {
+		return false
+	}
+	next := offset + headSize + size
+	if next == r.size {
+		return true
+	}
+	var ty [1]byte
+	if _, err = r.fd.ReadAt(ty[:], next); err != nil {
+		return false
+	}
+	return WalRecordType(ty[0]) == WriteWALRecord || WalRecordType(ty[0]) == WriteWALRecordCrc
+}
+
+// resync returns the offset of the first valid record after offset, or the file size if there is none
+func (r *WalReader) resync(offset int64) int64 {
+	const window = 64 * 1024
+	if r.scan == nil {
+		r.scan = make([]byte, window+WalRecordCrcHeadSize)
+	}
+	for start := offset + 1; start+WalRecordCrcHeadSize <= r.size; start += window {
+		n := int64(len(r.scan))
+		if start+n > r.size {
+			n = r.size - start
 		}
+		data := r.scan[:n]
+		if _, err := r.fd.ReadAt(data, start); err != nil && err != io.EOF {
+			return r.size
+		}
+		for i := int64(0); i < window && i+WalRecordCrcHeadSize <= n; i++ {
+			if r.validRecordAt(data[i:i+WalRecordCrcHeadSize], start+i) {
+				return start + i
+			}
+		}
+	}
+	return r.size
+}
+
+func (r *WalReader) corrupt(rec *WalRecord, err error) (*WalRecord, error) {
+	next := r.resync(rec.Offset)
+	rec.Size = next - rec.Offset
+	rec.Data = nil
+	rec.Err = err
+	r.offset = next
+	return rec, nil
+}
+
+// Next returns the next record of the file, io.EOF if all records are read
+func (r *WalReader) Next() (*WalRecord, error) {
+	if r.offset >= r.size {
+		return nil, io.EOF
+	}
+	rec := &WalRecord{Offset: r.offset}
+
+	var head [WalRecordCrcHeadSize]byte
+	headSize := int64(WalRecordHeadSize)
+	if n, _ := r.fd.ReadAt(head[:], r.offset); n < WalRecordHeadSize {
+		return r.corrupt(rec, errno.NewError(errno.WalRecordHeaderCorrupted, r.Name(), r.offset))
+	}
 
-		return offset, recordCompBuff, innerErr
+	rec.Type = WalRecordType(head[0])
+	switch rec.Type {
+	case WriteWALRecord:
+	case WriteWALRecordCrc:
+		headSize = WalRecordCrcHeadSize
+	default:
+		return r.corrupt(rec, errno.NewError(errno.WalRecordHeaderCorrupted, r.Name(), r.offset))
+	}
+
+	size := int64(binary.BigEndian.Uint32(head[1:WalRecordHeadSize]))
+	if r.offset+headSize+size > r.size {
+		return r.corrupt(rec, errno.NewError(errno.ReadWalFileFailed, r.Name(), r.offset, "truncated record body"))
+	}
+	compData, err := r.readAt(int(size), r.offset+headSize)
+	if err != nil {
+		return r.corrupt(rec, errno.NewError(errno.ReadWalFileFailed, r.Name(), r.offset, "record body"))
 	}
-	l.log.Warn(errno.NewError(errno.ReadWalFileFailed, fd.Name(), offset, "record body").Error())
-	return offset, recordCompBuff, io.EOF
+	if rec.Type == WriteWALRecordCrc && crc32.ChecksumIEEE(compData) != binary.BigEndian.Uint32(head[WalRecordHeadSize:]) {
+		return r.corrupt(rec, errno.NewError(errno.WalRecordCrcMismatch, r.Name(), r.offset))
+	}
+
+	// the crc matches, skip only this record if it can not be decompressed
+	rec.Size = headSize + size
+	r.offset += rec.Size
+	rec.Data, err = snappy.Decode(nil, compData)
+	if err != nil {
+		rec.Data = nil
+		rec.Err = errno.NewError(errno.DecompressWalRecordFailed, r.Name(), rec.Offset, err.Error())
+	}
+	return rec, nil
 }
 
-func (l *WAL) replayWalFile(walFileName string, callBack func(binary []byte) error) error {
+// replayWalFile replays the valid records of the file, returns the corrupt records skipped
+func (l *WAL) replayWalFile(walFileName string, callBack func(binary []byte) error) ([]*WalRecord, error) {
 	failpoint.Inject("mock-replay-wal-error", func(val failpoint.Value) {
 		msg := val.(string)
 		if strings.Contains(walFileName, msg) {
-			failpoint.Return(fmt.Errorf(msg))
+			failpoint.Return(nil, fmt.Errorf(msg))
 		}
 	})
-	lock := fileops.FileLockOption("")
-	pri := fileops.FilePriorityOption(fileops.IO_PRIORITY_NORMAL)
-	fd, err := fileops.OpenFile(walFileName, os.O_RDONLY, 0640, lock, pri)
+	r, err := OpenWalReader(walFileName)
 	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	var corrupt []*WalRecord
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return corrupt, nil
+		}
+		if err != nil {
+			return corrupt, err
+		}
+		if rec.Err != nil {
+			corrupt = append(corrupt, rec)
+			l.log.Warn("skip corrupt wal record", zap.String("file", walFileName), zap.Int64("offset", rec.Offset),
+				zap.Int64("size", rec.Size), zap.Error(rec.Err))
+			continue
+		}
+		if err = callBack(rec.Data); err != nil {
+			return corrupt, err
+		}
+	}
+}
+
+// quarantine copies the corrupt records of a WAL file out of the partition, so that they are kept for inspection.
+// The valid records of the file are replayed, and the file is removed as usual once they are flushed.
+func (l *WAL) quarantine(fileName string, corrupt []*WalRecord) error {
+	dir := filepath.Join(l.logPath, walQuarantineDir)
+	lock := fileops.FileLockOption("")
+	if err := fileops.MkdirAll(dir, 0750, lock); err != nil {
 		return err
 	}
 
-	stat, err := fd.Stat()
+	src, err := fileops.OpenDecryptedFile(fileName, os.O_RDONLY, 0640, lock)
 	if err != nil {
 		return err
 	}
+	defer util.MustClose(src)
 
-	fileSize := stat.Size()
-	if fileSize == 0 {
-		return nil
+	pt := filepath.Base(filepath.Dir(fileName))
+	dstName := filepath.Join(dir, fmt.Sprintf("%s_%d_%s", pt, time.Now().UnixNano(), filepath.Base(fileName)))
+	dst, err := fileops.CreateEncryptedFile(dstName, 0640, lock)
+	if err != nil {
+		return err
 	}
-
-	recordCompBuff := walCompBufPool.Get()
-	defer func() {
-		if len(recordCompBuff) <= WalCompMaxBufSize {
-			walCompBufPool.Put(recordCompBuff)
-		}
-	}()
-
-	offset := int64(0)
-	for {
-		offset, recordCompBuff, err = l.replayPhysicRecord(fd, offset, fileSize, recordCompBuff, callBack)
-		if err != nil {
-			util.MustClose(fd)
-			if err == io.EOF {
-				return nil
-			}
+	for _, rec := range corrupt {
+		if _, err = io.Copy(dst, io.NewSectionReader(src, rec.Offset, rec.Size)); err != nil {
+			util.MustClose(dst)
 			return err
 		}
 	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+
+	walStat.AddQuarantinedFiles(1)
+	l.log.Warn("quarantine corrupt wal records", zap.String("file", fileName), zap.Int("records", len(corrupt)),
+		zap.String("to", dstName))
+	return nil
 }
 
 func (l *WAL) replayOnePartition(logPath string, callBack func(binary []byte) error) ([]string, error) {
@@ -294,10 +522,16 @@ func (l *WAL) replayOnePartition(logPath string, callBack func(binary []byte) er
 	walFileNames := make([]string, 0, len(dirs))
 	for i := range dirs {
 		fileName := filepath.Join(logPath, dirs[i].Name())
-		err = l.replayWalFile(fileName, callBack)
+		corrupt, err := l.replayWalFile(fileName, callBack)
 		if err != nil {
 			return nil, err
 		}
+		if len(corrupt) > 0 {
+			walStat.AddCorruptRecords(int64(len(corrupt)))
+			if err = l.quarantine(fileName, corrupt); err != nil {
+				return nil, err
+			}
+		}
 		walFileNames = append(walFileNames, fileName)
 	}
 	return walFileNames, nil
@@ -391,6 +625,12 @@ func (l *WAL) Replay(callBack func(binary []byte) error) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	for i := range dirs {
+		if dirs[i].Name() == walQuarantineDir {
+			dirs = append(dirs[:i], dirs[i+1:]...)
+			break
+		}
+	}
 
 	// replay wal files
 	var mu = sync.Mutex{}
@@ -467,7 +707,7 @@ func (w *LogWriter) trySwitchFile(logPath string) error {
 		fileName := filepath.Join(logPath, fmt.Sprintf("%d.%s", w.fileSeq, WALFileSuffixes))
 		lock := fileops.FileLockOption("")
 		pri := fileops.FilePriorityOption(fileops.IO_PRIORITY_ULTRA_HIGH)
-		fd, err := fileops.OpenFile(fileName, os.O_CREATE|os.O_RDWR, 0640, lock, pri)
+		fd, err := fileops.CreateEncryptedFile(fileName, 0640, lock, pri)
 		if err != nil {
 			return err
 		}
@@ -486,11 +726,19 @@ type LogWriter struct {
 	logPath         string
 	syncTaskCount   int32
 	SyncInterval    time.Duration
+	SyncMode        int
 	closed          chan struct{}
 	fileSeq         int
 	fileNames       []string
 	currentFd       fileops.File
 	currentFileSize int
+
+	// group commit of WalSyncFsync, records are numbered by writtenSeq when they are written
+	groupMu    sync.Mutex
+	groupCond  *sync.Cond
+	syncing    bool
+	writtenSeq uint64
+	syncedSeq  uint64
 }
 
 func (w *LogWriter) closeCurrentFile() error {
@@ -526,14 +774,8 @@ func (w *LogWriter) Switch() ([]string, error) {
 }
 
 func (w *LogWriter) sync() error {
-	var err error
 	if w.SyncInterval == 0 {
-		w.syncMu.Lock()
-		if w.currentFd != nil {
-			err = w.currentFd.Sync()
-		}
-		w.syncMu.Unlock()
-		return err
+		return w.syncFile()
 	}
 
 	t := time.NewTicker(w.SyncInterval)
@@ -545,19 +787,58 @@ func (w *LogWriter) sync() error {
 			atomic.StoreInt32(&w.syncTaskCount, 0)
 			return nil
 		case <-t.C:
-			w.syncMu.Lock()
-			if w.currentFd != nil {
-				err = w.currentFd.Sync()
-			}
+			err := w.syncFile()
 			atomic.StoreInt32(&w.syncTaskCount, 0)
-			w.syncMu.Unlock()
+			return err
+		}
+	}
+}
+
+func (w *LogWriter) syncFile() error {
+	w.syncMu.Lock()
+	defer w.syncMu.Unlock()
+	if w.currentFd == nil {
+		return nil
+	}
+	walStat.AddSyncs(1)
+	return w.currentFd.Sync()
+}
 
+// groupSync returns after the seq-th record is synced. One of the waiting writers syncs the file
+// for all the records written so far, the others wait for it.
+func (w *LogWriter) groupSync(seq uint64) error {
+	w.groupMu.Lock()
+	defer w.groupMu.Unlock()
+
+	for w.syncedSeq < seq {
+		if w.syncing {
+			w.groupCond.Wait()
+			continue
+		}
+
+		w.syncing = true
+		w.groupMu.Unlock()
+		// the records before the target are written, and the records in the closed files are synced when they are closed
+		target := atomic.LoadUint64(&w.writtenSeq)
+		err := w.syncFile()
+		w.groupMu.Lock()
+		w.syncing = false
+		w.groupCond.Broadcast()
+		if err != nil {
 			return err
 		}
+
+		walStat.AddGroupCommits(1)
+		walStat.AddGroupCommitRecords(int64(target - w.syncedSeq))
+		w.syncedSeq = target
 	}
+	return nil
 }
 
 func (w *LogWriter) trySync() error {
+	if w.SyncMode == WalSyncAsync {
+		return nil
+	}
 	if w.SyncInterval == 0 {
 		return w.sync()
 	}
@@ -577,28 +858,46 @@ func (w *LogWriter) Write(compBuf []byte) error {
 	case <-w.closed:
 		return fmt.Errorf("WAL log writer closed")
 	default:
+		if w.SyncMode == WalSyncFsync {
+			seq, err := w.write(compBuf)
+			if err != nil {
+				return err
+			}
+			return w.groupSync(seq)
+		}
+
 		w.writeMu.Lock()
 		defer w.writeMu.Unlock()
 
-		w.syncMu.Lock()
-		err := w.trySwitchFile(w.logPath)
-		if err != nil {
-			w.syncMu.Unlock()
+		if _, err := w.writeLocked(compBuf); err != nil {
 			return err
 		}
-		w.syncMu.Unlock()
+		return w.trySync()
+	}
+}
 
-		if _, err = w.currentFd.Write(compBuf); err != nil {
-			return err
-		}
+func (w *LogWriter) write(compBuf []byte) (uint64, error) {
+	w.writeMu.Lock()
+	defer w.writeMu.Unlock()
+	return w.writeLocked(compBuf)
+}
 
-		if err = w.trySync(); err != nil {
-			return err
-		}
+// writeLocked appends the record to the current file, returns the sequence of the record
+func (w *LogWriter) writeLocked(compBuf []byte) (uint64, error) {
+	w.syncMu.Lock()
+	err := w.trySwitchFile(w.logPath)
+	if err != nil {
+		w.syncMu.Unlock()
+		return 0, err
+	}
+	w.syncMu.Unlock()
 
-		w.currentFileSize += len(compBuf)
-		return nil
+	if _, err = w.currentFd.Write(compBuf); err != nil {
+		return 0, err
 	}
+
+	w.currentFileSize += len(compBuf)
+	return atomic.AddUint64(&w.writtenSeq, 1), nil
 }
 
 func (w *LogWriter) close() error {
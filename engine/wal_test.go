@@ -16,10 +16,20 @@ limitations under the License.
 package engine
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
 	"os"
+	"path/filepath"
+	"sort"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/golang/snappy"
+	"github.com/openGemini/openGemini/lib/netstorage"
 )
 
 func TestWalReplayParallel(t *testing.T) {
@@ -183,3 +193,206 @@ func TestWalReplaySerial(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func replayWalRecords(t *testing.T, wal *WAL) ([]string, []string) {
+	var mu sync.Mutex
+	var records []string
+	files, err := wal.Replay(func(binary []byte) error {
+		mu.Lock()
+		records = append(records, string(binary))
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(records)
+	return records, files
+}
+
+func TestWalSyncMode(t *testing.T) {
+	opt := netstorage.EngineOptions{WalSyncMode: "async", WalSyncModes: map[string]string{"db0": "fsync"}}
+	if mode := walSyncModeOf(opt, "db0"); mode != WalSyncFsync {
+		t.Fatalf("exp mode %v, got %v", WalSyncFsync, mode)
+	}
+	if mode := walSyncModeOf(opt, "db1"); mode != WalSyncAsync {
+		t.Fatalf("exp mode %v, got %v", WalSyncAsync, mode)
+	}
+	if mode := ParseWalSyncMode(""); mode != WalSyncPeriodic {
+		t.Fatalf("exp mode %v, got %v", WalSyncPeriodic, mode)
+	}
+}
+
+func TestWalGroupCommit(t *testing.T) {
+	testDir := t.TempDir()
+	wal := NewWAL(testDir, WalSyncFsync, 0, true, false, true, 1)
+
+	const writers = 64
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- wal.Write([]byte(fmt.Sprintf("record-%02d", i)))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lw := &wal.logWriter[0]
+	if lw.syncedSeq != writers {
+		t.Fatalf("exp %v records synced, got %v", writers, lw.syncedSeq)
+	}
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	wal = NewWAL(testDir, WalSyncFsync, 0, true, false, true, 1)
+	records, files := replayWalRecords(t, wal)
+	if len(records) != writers || len(files) != 1 {
+		t.Fatalf("exp %v records in 1 file, got %v records in %v files", writers, len(records), len(files))
+	}
+	for i := range records {
+		if exp := fmt.Sprintf("record-%02d", i); records[i] != exp {
+			t.Fatalf("exp %v, got %v", exp, records[i])
+		}
+	}
+}
+
+func TestWalReplayCorruptRecord(t *testing.T) {
+	for _, recordCrc := range []bool{true, false} {
+		testDir := t.TempDir()
+		wal := NewWAL(testDir, WalSyncAsync, 0, true, false, recordCrc, 1)
+		for i := 0; i < 5; i++ {
+			if err := wal.Write(bytes.Repeat([]byte{byte('a' + i)}, 100)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := wal.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		// damage the second record, and leave a truncated record at the tail
+		fileName := filepath.Join(testDir, "0", "1.wal")
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		size := len(data) / 5
+		if recordCrc {
+			if WalRecordType(data[0]) != WriteWALRecordCrc {
+				t.Fatalf("exp record type %v, got %v", WriteWALRecordCrc, data[0])
+			}
+			data[size+WalRecordCrcHeadSize+2] ^= 0xff
+		} else {
+			if WalRecordType(data[0]) != WriteWALRecord {
+				t.Fatalf("exp record type %v, got %v", WriteWALRecord, data[0])
+			}
+			data[size] = 0xff
+		}
+		data = append(data, data[:size/2]...)
+		if err = os.WriteFile(fileName, data, 0640); err != nil {
+			t.Fatal(err)
+		}
+
+		wal = NewWAL(testDir, WalSyncAsync, 0, true, false, recordCrc, 1)
+		records, files := replayWalRecords(t, wal)
+		if len(files) != 1 || files[0] != fileName {
+			t.Fatalf("the file of the valid records should be kept, but got %v", files)
+		}
+		exp := []string{"a", "c", "d", "e"}
+		if len(records) != len(exp) {
+			t.Fatalf("exp %v records, got %v", len(exp), len(records))
+		}
+		for i := range exp {
+			if records[i] != string(bytes.Repeat([]byte(exp[i]), 100)) {
+				t.Fatalf("unexpected record %v: %v", i, records[i])
+			}
+		}
+
+		// only the corrupt records are copied into the quarantine dir
+		quarantined, err := os.ReadDir(filepath.Join(testDir, walQuarantineDir))
+		if err != nil || len(quarantined) != 1 {
+			t.Fatalf("exp 1 quarantined file, got %v, %v", len(quarantined), err)
+		}
+		info, err := quarantined[0].Info()
+		if err != nil || info.Size() != int64(size+size/2) {
+			t.Fatalf("exp %v quarantined bytes, got %v, %v", size+size/2, info.Size(), err)
+		}
+
+		// the quarantine dir is not replayed
+		records, _ = replayWalRecords(t, wal)
+		if len(records) != len(exp) {
+			t.Fatalf("exp %v records, got %v", len(exp), len(records))
+		}
+	}
+}
+
+func TestWalReader_ResyncBound(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "1.wal")
+	comp := snappy.Encode(nil, []byte("valid"))
+	// a damaged header which claims a huge body, followed by a valid record
+	data := []byte{0xff, byte(WriteWALRecordCrc), 0xff, 0xff, 0xff, 0xf0}
+	data = append(data, make([]byte, WalRecordCrcHeadSize)...)
+	offset := len(data)
+	var head [WalRecordCrcHeadSize]byte
+	head[0] = byte(WriteWALRecordCrc)
+	binary.BigEndian.PutUint32(head[1:WalRecordHeadSize], uint32(len(comp)))
+	binary.BigEndian.PutUint32(head[WalRecordHeadSize:], crc32.ChecksumIEEE(comp))
+	data = append(data, head[:]...)
+	data = append(data, comp...)
+
+	r := &WalReader{size: int64(len(data))}
+	if r.validRecordAt(data[1:], 1) {
+		t.Fatal("a record larger than the resync bound should not be valid")
+	}
+	if err := os.WriteFile(fileName, data, 0640); err != nil {
+		t.Fatal(err)
+	}
+	r, err := OpenWalReader(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	if next := r.resync(0); next != int64(offset) {
+		t.Fatalf("exp next record at %v, got %v", offset, next)
+	}
+}
+
+func TestWalReader_LegacyRecord(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "1.wal")
+	comp := snappy.Encode(nil, []byte("legacy"))
+	data := make([]byte, WalRecordHeadSize, WalRecordHeadSize+len(comp))
+	data[0] = byte(WriteWALRecord)
+	binary.BigEndian.PutUint32(data[1:], uint32(len(comp)))
+	data = append(data, comp...)
+	if err := os.WriteFile(fileName, data, 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := OpenWalReader(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	rec, err := r.Next()
+	if err != nil || rec.Err != nil {
+		t.Fatalf("read legacy record failed, %v, %v", err, rec.Err)
+	}
+	if string(rec.Data) != "legacy" || rec.Size != int64(len(data)) {
+		t.Fatalf("unexpected record %q, size %v", rec.Data, rec.Size)
+	}
+	if _, err = r.Next(); err == nil {
+		t.Fatal("exp EOF")
+	}
+}
@@ -25,6 +25,7 @@ require (
 	github.com/klauspost/compress v1.13.6
 	github.com/mitchellh/cli v1.1.0
 	github.com/mitchellh/copystructure v1.2.0
+	github.com/pierrec/lz4/v4 v4.1.17
 	github.com/pingcap/failpoint v0.0.0-20200702092429-9f69995143ce
 	github.com/pkg/errors v0.9.1
 	github.com/prometheus/client_golang v1.12.1
@@ -28,6 +28,12 @@ type BinaryDecoder struct {
 	offset int
 }
 
+// RemainSize returns the size of the bytes which are not decoded yet, the optional fields sent by a peer
+// of an older version may be missing
+func (c *BinaryDecoder) RemainSize() int {
+	return len(c.buf) - c.offset
+}
+
 func (c *BinaryDecoder) Int() int {
 	i := encoding.UnmarshalInt64(c.buf[c.offset : c.offset+8])
 	c.offset += 8
@@ -26,6 +26,7 @@ type BaseArguments struct {
 	FuncName  string
 	Typ       string
 	Ref       string
+	Feature   string
 }
 
 type SizeArguments struct {
@@ -35,6 +36,7 @@ type SizeArguments struct {
 func NewSizeArguments(fn string, f *Field) SizeArguments {
 	a := SizeArguments{}
 	a.FieldName = f.name
+	a.Feature = f.feature
 	a.FuncName = fn
 	a.Typ = strings.Join(f.typList, typeSep)
 	a.Ref = fmt.Sprintf("%t", f.ref)
@@ -48,6 +50,7 @@ type EncodeArguments struct {
 func NewEncodeArguments(fn []string, f *Field) EncodeArguments {
 	a := EncodeArguments{}
 	a.FieldName = f.name
+	a.Feature = f.feature
 	if len(fn) > 0 {
 		a.FuncName = fn[0]
 	}
@@ -65,6 +68,7 @@ type DecodeArguments struct {
 func NewDecodeArguments(fn []string, f *Field) DecodeArguments {
 	a := DecodeArguments{}
 	a.FieldName = f.name
+	a.Feature = f.feature
 	if len(fn) > 1 {
 		a.FuncName = fn[1]
 	}
@@ -90,6 +94,8 @@ type Arguments struct {
 	Size       []SizeArguments
 	Encode     []EncodeArguments
 	Decode     []DecodeArguments
+
+	optional bool
 }
 
 func NewArguments() *Arguments {
@@ -110,6 +116,16 @@ func (a *Arguments) Add(field *Field) {
 		panic(fmt.Sprintf("unsupported type: %s", typ))
 	}
 
+	// the optional fields are decoded only if a peer of a newer version sent them, they must be the last ones
+	if field.feature != "" {
+		if _, ok := optionalTypes[typ]; !ok {
+			panic(fmt.Sprintf("unsupported type of optional field %s.%s: %s", a.StructName, field.name, typ))
+		}
+		a.optional = true
+	} else if a.optional {
+		panic(fmt.Sprintf("field %s.%s must be optional as it follows an optional field", a.StructName, field.name))
+	}
+
 	switch field.typList[0] {
 	case typeString, typeNormal, typeObject:
 		a.AddNormal(field)
@@ -54,6 +54,13 @@ var allowTypes = map[string]struct{}{
 	"map_string_object": struct{}{},
 }
 
+var optionalTypes = map[string]struct{}{
+	typeString:     struct{}{},
+	"slice_string": struct{}{},
+	typeNormal:     struct{}{},
+	"slice_normal": struct{}{},
+}
+
 func (ft FieldType) String() string {
 	return string(ft)
 }
@@ -90,6 +97,9 @@ type Field struct {
 	mapKeyKind reflect.Kind
 
 	typList []string
+
+	// feature of an optional field, set by the tag codec:"feature=<codec.Feature>"
+	feature string
 }
 
 func (f *Field) SetType(typ FieldType) {
@@ -109,7 +119,7 @@ func NewCodecGen(pkg string) *CodecGen {
 		},
 		am:         make(map[string]*Arguments),
 		subStructs: make(map[string]SubStruct),
-		excludes:   []string{"codec.EmptyCodec"},
+		excludes:   []string{"codec.EmptyCodec", "codec.Versioned"},
 	}
 }
 
@@ -142,6 +152,7 @@ func (g *CodecGen) gen(typ reflect.Type, val *reflect.Value) {
 			ref:           false,
 			name:          f.Name,
 			interfaceName: "",
+			feature:       strings.TrimPrefix(f.Tag.Get("codec"), "feature="),
 		}
 
 		g.genItem(f.Type, v, field)
@@ -48,6 +48,37 @@ func TestGen(t *testing.T) {
 	assert.NoError(t, os.Remove(path))
 }
 
+func TestGen_Optional(t *testing.T) {
+	g := gen.NewCodecGen("gen_test")
+	g.Gen(&VersionedObject{})
+
+	path := filepath.Join(t.TempDir(), "codec.gen_test.go")
+	g.SaveTo(path)
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	code := string(b)
+	assert.Contains(t, code, "if o.Supports(codec.FeatureNodeTopology) {\n\t\tbuf = codec.AppendString(buf, o.zone)")
+	assert.Contains(t, code, "if dec.RemainSize() > 0 {\n\t\to.weight = dec.Int64()")
+	assert.Contains(t, code, "if o.Supports(codec.FeatureNodeTopology) {\n\t\tsize += codec.SizeOfInt64()")
+	assert.NotContains(t, code, "Versioned.")
+
+	assert.Panics(t, func() {
+		gen.NewCodecGen("gen_test").Gen(&MisplacedObject{})
+	})
+}
+
+type VersionedObject struct {
+	codec.Versioned
+	host   string
+	zone   string `codec:"feature=FeatureNodeTopology"`
+	weight int64  `codec:"feature=FeatureNodeTopology"`
+}
+
+type MisplacedObject struct {
+	zone string `codec:"feature=FeatureNodeTopology"`
+	host string
+}
+
 type IObject interface {
 	transport.Codec
 	String() string
@@ -21,7 +21,11 @@ func (o *{{.StructName}}) Marshal(buf []byte) ([]byte, error) {
 	var err error
 
 	{{- range .Encode }}
-	{{- if eq .Typ "object"}}
+	{{- if .Feature}}
+	if o.Supports(codec.{{.Feature}}) {
+		buf = codec.{{.FuncName}}(buf, o.{{.FieldName}})
+	}
+	{{- else if eq .Typ "object"}}
 
 	func() {
 		{{- if eq .Ref "true"}}
@@ -85,7 +89,11 @@ func (o *{{.StructName}}) Unmarshal(buf []byte) error {
 	dec := codec.NewBinaryDecoder(buf)
 
 	{{- range .Decode }}
-	{{- if eq .Typ "object"}}
+	{{- if .Feature}}
+	if dec.RemainSize() > 0 {
+		o.{{.FieldName}} = dec.{{.FuncName}}()
+	}
+	{{- else if eq .Typ "object"}}
 
 	func() {
 		subBuf := dec.BytesNoCopy()
@@ -147,7 +155,15 @@ func (o *{{.StructName}}) Size() int {
 	size := 0
 
 	{{- range .Size }}
-	{{- if eq .Typ "normal"}}
+	{{- if and .Feature (eq .Typ "normal")}}
+	if o.Supports(codec.{{.Feature}}) {
+		size += codec.{{.FuncName}}()
+	}
+	{{- else if .Feature}}
+	if o.Supports(codec.{{.Feature}}) {
+		size += codec.{{.FuncName}}(o.{{.FieldName}})
+	}
+	{{- else if eq .Typ "normal"}}
 	size += codec.{{.FuncName}}()
 	{{- else if eq .Typ "object"}}
 
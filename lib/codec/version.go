@@ -0,0 +1,71 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codec
+
+import "github.com/openGemini/openGemini/engine/executor/spdy"
+
+// Version is the wire format version of the messages. The spdy sessions negotiate the lower one of the versions
+// of both peers, and the optional fields of a feature are only encoded if the negotiated version supports it.
+type Version uint16
+
+const (
+	// VersionLegacy is the format of the nodes without version negotiation
+	VersionLegacy Version = 0
+	// Version1 adds the zone and the rack of the store nodes to CreateNodeRequest
+	Version1 Version = 1
+
+	// CurrentVersion is the newest version, announced by the spdy sessions
+	CurrentVersion = Version(spdy.WireVersion)
+)
+
+// Feature is a group of optional fields added to the messages by a version.
+// The optional fields follow the other fields of a message, in the order of their features.
+type Feature uint16
+
+const (
+	FeatureNodeTopology Feature = iota
+	featureEnd
+)
+
+var featureVersions = [featureEnd]Version{
+	FeatureNodeTopology: Version1,
+}
+
+// Supports returns whether the peers of version v understand the fields of the feature
+func (v Version) Supports(f Feature) bool {
+	return f < featureEnd && v >= featureVersions[f]
+}
+
+// Versioned is embedded by the messages with optional fields, it holds the wire version of the session the message
+// is sent on. A message which is not sent by a spdy session encodes all fields.
+type Versioned struct {
+	version Version
+	set     bool
+}
+
+func (v *Versioned) SetWireVersion(version uint16) {
+	v.version = Version(version)
+	v.set = true
+}
+
+// Supports returns whether the fields of the feature are encoded
+func (v *Versioned) Supports(f Feature) bool {
+	if !v.set {
+		return f < featureEnd
+	}
+	return v.version.Supports(f)
+}
@@ -86,6 +86,10 @@ type Common struct {
 	MemorySize      itoml.Size     `toml:"memory-size"`
 	MemoryLimitSize itoml.Size     `toml:"executor-memory-size-limit"`
 	MemoryWaitTime  itoml.Duration `toml:"executor-memory-wait-time"`
+
+	// spill of transforms which hold their whole input, disabled if the threshold is 0
+	SpillDir       string     `toml:"executor-spill-dir"`
+	SpillThreshold itoml.Size `toml:"executor-spill-threshold"`
 }
 
 // NewCommon builds a new CommonConfiguration with default values.
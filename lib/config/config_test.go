@@ -23,6 +23,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/influxdb/toml"
 	"github.com/openGemini/openGemini/lib/config"
 	"github.com/stretchr/testify/assert"
 )
@@ -201,3 +202,84 @@ func TestCombineDomain(t *testing.T) {
 
 	assert.Equal(t, "127.0.0.1", config.CombineDomain("", "127.0.0.1"))
 }
+
+func TestSlowQuery(t *testing.T) {
+	conf := config.NewSlowQuery()
+	assert.NoError(t, conf.Validate())
+
+	conf.Enabled = true
+	assert.NoError(t, conf.Validate())
+
+	conf.Threshold = 0
+	assert.EqualError(t, conf.Validate(), "slow-query threshold must be positive")
+
+	conf = config.NewSlowQuery()
+	conf.Enabled = true
+	conf.RetentionPolicy = ""
+	assert.EqualError(t, conf.Validate(), "slow-query database and retention-policy must not be empty")
+
+	conf = config.NewSlowQuery()
+	conf.Enabled = true
+	conf.FlushInterval = 0
+	assert.EqualError(t, conf.Validate(), "slow-query flush-interval must be positive")
+}
+
+func TestWorkload(t *testing.T) {
+	conf := config.NewWorkload()
+	assert.NoError(t, conf.Validate())
+
+	conf.Enabled = true
+	assert.NoError(t, conf.Validate())
+
+	conf.MemoryWait = -1
+	assert.EqualError(t, conf.Validate(), "workload memory-wait can not be negative")
+	conf.MemoryWait = toml.Duration(config.DefaultWorkloadMemoryWait)
+
+	conf.DefaultGroup = "unknown"
+	assert.EqualError(t, conf.Validate(), `workload default-group "unknown" is not defined`)
+	conf.DefaultGroup = config.WorkloadGroupBatch
+
+	conf.Groups[0].Users = []string{"root"}
+	conf.Groups[1].Users = []string{"root"}
+	assert.EqualError(t, conf.Validate(), `user "root" is mapped to workload groups "admin" and "realtime"`)
+	conf.Groups[1].Users = nil
+
+	conf.Groups[1].CPUShare = 0
+	assert.EqualError(t, conf.Validate(), `workload group "realtime" cpu-share must be positive`)
+	conf.Groups[1].CPUShare = 1
+
+	conf.Groups[2].MemoryPercent = 101
+	assert.EqualError(t, conf.Validate(), `workload group "batch" memory-percent must be in [0, 100]`)
+	conf.Groups[2].MemoryPercent = 50
+
+	conf.Groups = append(conf.Groups, config.WorkloadGroup{Name: config.WorkloadGroupBatch, CPUShare: 1})
+	assert.EqualError(t, conf.Validate(), `duplicate workload group "batch"`)
+}
+
+func TestReplication(t *testing.T) {
+	conf := config.NewReplication()
+	assert.NoError(t, conf.Validate())
+
+	conf.Enabled = true
+	assert.EqualError(t, conf.Validate(), "replication remote-url must not be empty")
+
+	conf.RemoteURL = "127.0.0.1:8086"
+	assert.EqualError(t, conf.Validate(), "replication remote-url must be a http or https url")
+
+	conf.RemoteURL = "http://127.0.0.1:8086"
+	assert.NoError(t, conf.Validate())
+
+	conf.MaxSegmentSize = conf.MaxQueueSize + 1
+	assert.EqualError(t, conf.Validate(), "replication max-segment-size must be positive and not greater than max-queue-size")
+	conf.MaxSegmentSize = config.DefaultReplicationMaxSegmentSize
+
+	conf.MaxRetryInterval = 0
+	assert.EqualError(t, conf.Validate(), "replication retry-interval must be positive and not greater than max-retry-interval")
+
+	conf = config.NewReplication()
+	assert.True(t, conf.Replicated("db0"))
+	assert.False(t, conf.Replicated(config.DefaultSlowQueryDatabase))
+	conf.Databases = []string{"db1"}
+	assert.False(t, conf.Replicated("db0"))
+	assert.True(t, conf.Replicated("db1"))
+}
@@ -42,6 +42,9 @@ const (
 	DefaultVersion              = 0
 	DefaultSplitRowThreshold    = 1000
 	DefaultImbalanceFactor      = 0.3
+	DefaultRebalanceInterval    = toml.Duration(time.Minute)
+	DefaultRebalanceMaxMoves    = 1
+	DefaultRebalanceDiskFactor  = 0.2
 	DefaultRaftStore            = "boltdb"
 	DefaultHostname             = "localhost"
 	DefaultSuspicionMult        = 4
@@ -119,6 +122,8 @@ type Meta struct {
 	ClusterTracing      bool `toml:"cluster-tracing"`
 	LoggingEnabled      bool `toml:"logging-enabled"`
 	BatchApplyCh        bool `toml:"batch-enabled"`
+	// the admin api requires the credentials of the admin user
+	AuthEnabled bool `toml:"auth-enabled"`
 
 	Domain                  string  `toml:"domain"`
 	Dir                     string  `toml:"dir"`
@@ -135,6 +140,13 @@ type Meta struct {
 	RaftStore               string  `toml:"raft-store"`
 	RemoteHostname          string
 
+	// the balancer moves pts from the store nodes owning more pts, or more data, to the others
+	RebalanceEnabled    bool          `toml:"rebalance-enabled"`
+	RebalanceDryRun     bool          `toml:"rebalance-dry-run"`
+	RebalanceInterval   toml.Duration `toml:"rebalance-interval"`
+	RebalanceMaxMoves   int           `toml:"rebalance-max-moves"`
+	RebalanceDiskFactor float64       `toml:"rebalance-disk-factor"`
+
 	JoinPeers          []string
 	ElectionTimeout    toml.Duration `toml:"election-timeout"`
 	HeartbeatTimeout   toml.Duration `toml:"heartbeat-timeout"`
@@ -166,6 +178,9 @@ func NewMeta() *Meta {
 		RaftStore:               DefaultRaftStore,
 		RemoteHostname:          DefaultHostname,
 		ClusterTracing:          true,
+		RebalanceInterval:       DefaultRebalanceInterval,
+		RebalanceMaxMoves:       DefaultRebalanceMaxMoves,
+		RebalanceDiskFactor:     DefaultRebalanceDiskFactor,
 	}
 }
 
@@ -189,6 +204,15 @@ func (c *Meta) Validate() error {
 		return fmt.Errorf("meta split-row-threshold must be greater than 0. got: %d", c.SplitRowThreshold)
 	}
 
+	if c.RebalanceEnabled {
+		if c.RebalanceInterval <= 0 {
+			return fmt.Errorf("meta rebalance-interval must be greater than 0. got: %v", c.RebalanceInterval)
+		}
+		if c.RebalanceMaxMoves <= 0 {
+			return fmt.Errorf("meta rebalance-max-moves must be greater than 0. got: %d", c.RebalanceMaxMoves)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,120 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	DefaultReplicationDir              = "/tmp/openGemini/replication"
+	DefaultReplicationMaxQueueSize     = 1024 * 1024 * 1024
+	DefaultReplicationMaxSegmentSize   = 16 * 1024 * 1024
+	DefaultReplicationTimeout          = 10 * time.Second
+	DefaultReplicationRetryInterval    = time.Second
+	DefaultReplicationMaxRetryInterval = time.Minute
+)
+
+// Replication represents the configuration for replicating the writes accepted by the SQL node
+// to the /write endpoint of a remote cluster.
+type Replication struct {
+	Enabled            bool   `toml:"enabled"`
+	RemoteURL          string `toml:"remote-url"`
+	Username           string `toml:"username"`
+	Password           string `toml:"password"`
+	InsecureSkipVerify bool   `toml:"insecure-skip-verify"`
+
+	// SourceID identifies the batches of this node on the remote cluster, defaults to a random id kept in dir
+	SourceID string `toml:"source-id"`
+
+	// Databases are the databases to replicate, all databases when empty
+	Databases        []string `toml:"databases"`
+	ExcludeDatabases []string `toml:"exclude-databases"`
+
+	Dir            string    `toml:"dir"`
+	MaxQueueSize   toml.Size `toml:"max-queue-size"`
+	MaxSegmentSize toml.Size `toml:"max-segment-size"`
+	// SyncWrites fsyncs the queue before a write is acknowledged to the client
+	SyncWrites bool `toml:"sync-writes"`
+
+	// Timeout bounds a request to the remote cluster and the wait of a write for room in a full queue
+	Timeout          toml.Duration `toml:"timeout"`
+	RetryInterval    toml.Duration `toml:"retry-interval"`
+	MaxRetryInterval toml.Duration `toml:"max-retry-interval"`
+}
+
+func NewReplication() Replication {
+	return Replication{
+		Enabled:          false,
+		ExcludeDatabases: []string{DefaultSlowQueryDatabase},
+		Dir:              DefaultReplicationDir,
+		MaxQueueSize:     DefaultReplicationMaxQueueSize,
+		MaxSegmentSize:   DefaultReplicationMaxSegmentSize,
+		Timeout:          toml.Duration(DefaultReplicationTimeout),
+		RetryInterval:    toml.Duration(DefaultReplicationRetryInterval),
+		MaxRetryInterval: toml.Duration(DefaultReplicationMaxRetryInterval),
+	}
+}
+
+// Validate validates that the configuration is acceptable.
+func (c Replication) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.RemoteURL == "" {
+		return errors.New("replication remote-url must not be empty")
+	}
+	if u, err := url.Parse(c.RemoteURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("replication remote-url must be a http or https url")
+	}
+	if c.Dir == "" {
+		return errors.New("replication dir must not be empty")
+	}
+	if c.MaxSegmentSize <= 0 || c.MaxQueueSize < c.MaxSegmentSize {
+		return errors.New("replication max-segment-size must be positive and not greater than max-queue-size")
+	}
+	if c.Timeout <= 0 {
+		return errors.New("replication timeout must be positive")
+	}
+	if c.RetryInterval <= 0 || c.MaxRetryInterval < c.RetryInterval {
+		return errors.New("replication retry-interval must be positive and not greater than max-retry-interval")
+	}
+	return nil
+}
+
+// Replicated returns true if the writes to db are replicated.
+func (c Replication) Replicated(db string) bool {
+	for _, d := range c.ExcludeDatabases {
+		if d == db {
+			return false
+		}
+	}
+	if len(c.Databases) == 0 {
+		return true
+	}
+	for _, d := range c.Databases {
+		if d == db {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	DefaultSlowQueryDatabase        = "_internal"
+	DefaultSlowQueryRetentionPolicy = "slow_queries"
+	DefaultSlowQueryMeasurement     = "slow_queries"
+	DefaultSlowQueryThreshold       = 10 * time.Second
+	DefaultSlowQueryRetention       = 7 * 24 * time.Hour
+	DefaultSlowQueryFlushInterval   = 10 * time.Second
+	DefaultSlowQueryMaxQueryLength  = 4096
+)
+
+// SlowQuery represents the configuration for persisting slow queries into an internal measurement.
+type SlowQuery struct {
+	Enabled         bool          `toml:"enabled"`
+	Threshold       toml.Duration `toml:"threshold"`
+	Database        string        `toml:"database"`
+	RetentionPolicy string        `toml:"retention-policy"`
+	Retention       toml.Duration `toml:"retention"`
+	FlushInterval   toml.Duration `toml:"flush-interval"`
+	MaxQueryLength  int           `toml:"max-query-length"`
+	RedactLiterals  bool          `toml:"redact-literals"`
+}
+
+func NewSlowQuery() SlowQuery {
+	return SlowQuery{
+		Enabled:         false,
+		Threshold:       toml.Duration(DefaultSlowQueryThreshold),
+		Database:        DefaultSlowQueryDatabase,
+		RetentionPolicy: DefaultSlowQueryRetentionPolicy,
+		Retention:       toml.Duration(DefaultSlowQueryRetention),
+		FlushInterval:   toml.Duration(DefaultSlowQueryFlushInterval),
+		MaxQueryLength:  DefaultSlowQueryMaxQueryLength,
+		RedactLiterals:  true,
+	}
+}
+
+// Validate validates that the configuration is acceptable.
+func (c SlowQuery) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Threshold <= 0 {
+		return errors.New("slow-query threshold must be positive")
+	}
+	if c.Database == "" || c.RetentionPolicy == "" {
+		return errors.New("slow-query database and retention-policy must not be empty")
+	}
+	if c.Retention < 0 {
+		return errors.New("slow-query retention can not be negative")
+	}
+	if c.FlushInterval <= 0 {
+		return errors.New("slow-query flush-interval must be positive")
+	}
+	return nil
+}
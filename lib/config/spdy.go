@@ -47,6 +47,14 @@ type Spdy struct {
 	TLSClientPrivateKey   string `toml:"tls-client-private-key"`
 	TLSCARoot             string `toml:"tls-ca-root"`
 	TLSServerName         string `toml:"tls-server-name"`
+
+	CompressionAlgorithm string    `toml:"compression-algorithm"`
+	CompressionThreshold toml.Size `toml:"compression-threshold"`
+
+	// WireVersion is the highest wire format version announced to the peers when a session is opened.
+	// 0 announces the newest version of the binary and -1 keeps the messages in the format of the nodes
+	// without version negotiation, which allows to roll back an upgrade until all nodes are upgraded
+	WireVersion int `toml:"wire-version"`
 }
 
 const (
@@ -65,6 +73,16 @@ const (
 	DefaultSessionSelectTimeout    = 300 * Second
 	DefaultTCPDialTimeout          = Second
 	DefaultConnPoolSize            = 4
+	DefaultCompressionThreshold    = 4096
+	DefaultWireVersion             = 0
+	LegacyWireVersion              = -1
+)
+
+const (
+	SpdyCompressNone   = "none"
+	SpdyCompressSnappy = "snappy"
+	SpdyCompressZstd   = "zstd"
+	SpdyCompressLz4    = "lz4"
 )
 
 func NewSpdy() Spdy {
@@ -77,6 +95,9 @@ func NewSpdy() Spdy {
 		TCPDialTimeout:            DefaultTCPDialTimeout,
 		TLSEnable:                 false,
 		ConnPoolSize:              DefaultConnPoolSize,
+		CompressionAlgorithm:      SpdyCompressNone,
+		CompressionThreshold:      DefaultCompressionThreshold,
+		WireVersion:               DefaultWireVersion,
 	}
 }
 
@@ -121,6 +142,12 @@ func (c *Spdy) NewClientTLSConfig() (*tls.Config, error) {
 }
 
 func (c Spdy) Validate() error {
+	switch c.CompressionAlgorithm {
+	case "", SpdyCompressNone, SpdyCompressSnappy, SpdyCompressZstd, SpdyCompressLz4:
+	default:
+		return errno.NewError(errno.InvalidSpdyCompression, c.CompressionAlgorithm)
+	}
+
 	if !c.TLSEnable {
 		return nil
 	}
@@ -155,6 +182,12 @@ func FormatSpdy(cfg *Spdy) {
 	cfg.SessionSelectTimeout = limitDuration(cfg.SessionSelectTimeout, MinSessionSelectTimeout, DefaultSessionSelectTimeout)
 	cfg.TCPDialTimeout = limitDuration(cfg.TCPDialTimeout, MinTCPDialTimeout, DefaultTCPDialTimeout)
 	cfg.ConnPoolSize = formatInt(cfg.ConnPoolSize, MinConnPoolSize, DefaultConnPoolSize)
+	if cfg.CompressionAlgorithm == "" {
+		cfg.CompressionAlgorithm = SpdyCompressNone
+	}
+	if cfg.WireVersion < 0 {
+		cfg.WireVersion = LegacyWireVersion
+	}
 	if cfg.TLSCertificate == "" {
 		cfg.TLSEnable = false
 	}
@@ -22,6 +22,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/influxdata/influxdb/toml"
 	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/stretchr/testify/assert"
@@ -107,3 +108,20 @@ func execCommand(cmdList []string) error {
 
 	return nil
 }
+
+func TestSpdyCompression(t *testing.T) {
+	conf := config.NewSpdy()
+	assert.Equal(t, config.SpdyCompressNone, conf.CompressionAlgorithm)
+	assert.NoError(t, conf.Validate())
+
+	conf.CompressionAlgorithm = config.SpdyCompressZstd
+	assert.NoError(t, conf.Validate())
+
+	conf.CompressionAlgorithm = "gzip"
+	assert.EqualError(t, conf.Validate(), errno.NewError(errno.InvalidSpdyCompression, "gzip").Error())
+
+	conf.CompressionAlgorithm = ""
+	config.FormatSpdy(&conf)
+	assert.Equal(t, config.SpdyCompressNone, conf.CompressionAlgorithm)
+	assert.Equal(t, toml.Size(config.DefaultCompressionThreshold), conf.CompressionThreshold)
+}
@@ -65,6 +65,10 @@ type TSSql struct {
 	// TLS provides configuration options for all https endpoints.
 	TLS      tlsconfig.Config `toml:"tls"`
 	Analysis Castor           `toml:"castor"`
+
+	SlowQuery   SlowQuery   `toml:"slow-query"`
+	Workload    Workload    `toml:"workload"`
+	Replication Replication `toml:"replication"`
 }
 
 // NewTSSql returns an instance of Config with reasonable defaults.
@@ -76,6 +80,9 @@ func NewTSSql() *TSSql {
 	c.Logging = NewLogger(AppSql)
 	c.HTTP = httpdConfig.NewConfig()
 	c.Analysis = NewCastor()
+	c.SlowQuery = NewSlowQuery()
+	c.Workload = NewWorkload()
+	c.Replication = NewReplication()
 	return c
 }
 
@@ -90,6 +97,9 @@ func (c *TSSql) Validate() error {
 		c.HTTP,
 		c.Spdy,
 		c.Analysis,
+		c.SlowQuery,
+		c.Workload,
+		c.Replication,
 	}
 
 	for _, item := range items {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/influxdata/influxdb/pkg/tlsconfig"
@@ -52,8 +53,50 @@ const (
 	DefaultSnapshotThroughputBurst = 64 * MB
 	DefaultMaxWriteHangTime        = 15 * time.Second
 	DefaultWALSyncInterval         = 100 * time.Millisecond
+
+	// disk watermarks, percent of the used space of the data and WAL disks
+	DefaultDiskLowWatermark   = 85.0
+	DefaultDiskHighWatermark  = 90.0
+	DefaultDiskFloodWatermark = 95.0
+	DefaultDiskCheckInterval  = 10 * time.Second
+)
+
+// float codecs of TSSP files, auto chooses the smallest one per block
+const (
+	FloatCodecAuto    = "auto"
+	FloatCodecGorilla = "gorilla"
+	FloatCodecChimp   = "chimp"
+	FloatCodecALP     = "alp"
 )
 
+// durability modes of WAL writes
+const (
+	// the WAL files are synced only when they are switched or closed
+	WalSyncModeAsync = "async"
+	// the WAL files are synced every wal-sync-interval
+	WalSyncModePeriodic = "periodic"
+	// a write is acknowledged after it is synced, concurrent writes share one fsync
+	WalSyncModeFsync = "fsync"
+)
+
+func IsValidWalSyncMode(mode string) bool {
+	switch strings.ToLower(mode) {
+	case WalSyncModeAsync, WalSyncModePeriodic, WalSyncModeFsync:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidFloatCodec(name string) bool {
+	switch strings.ToLower(name) {
+	case FloatCodecAuto, FloatCodecGorilla, FloatCodecChimp, FloatCodecALP:
+		return true
+	default:
+		return false
+	}
+}
+
 // TSStore represents the configuration format for the influxd binary.
 type TSStore struct {
 	Common      *Common     `toml:"common"`
@@ -71,6 +114,7 @@ type TSStore struct {
 	// TLS provides configuration options for all https endpoints.
 	TLS      tlsconfig.Config `toml:"tls"`
 	Analysis Castor           `toml:"castor"`
+	Workload Workload         `toml:"workload"`
 }
 
 // NewTSStore returns an instance of Config with reasonable defaults.
@@ -90,6 +134,7 @@ func NewTSStore() *TSStore {
 	c.Gossip = NewGossip()
 
 	c.Analysis = NewCastor()
+	c.Workload = NewWorkload()
 	return c
 }
 
@@ -109,6 +154,7 @@ func (c *TSStore) Validate() error {
 		c.Logging,
 		c.Spdy,
 		c.Analysis,
+		c.Workload,
 	}
 
 	for _, item := range items {
@@ -148,6 +194,16 @@ type Store struct {
 	Engine          string `toml:"engine-type"`
 	Index           string `toml:"index-version"`
 
+	// Zone and Rack are the physical location of the node, the partitions of every database
+	// are spread across the zones and the racks. The nodes without labels share one location
+	Zone string `toml:"zone"`
+	Rack string `toml:"rack"`
+
+	// DataDirs and WALDirs are more directories for the shards, typically one per disk.
+	// store-data-dir is always used, and keeps the indexes of the partitions
+	DataDirs []string `toml:"store-data-dirs"`
+	WALDirs  []string `toml:"store-wal-dirs"`
+
 	// The max inmem percent of immutable
 	ImmTableMaxMemoryPercentage int `toml:"imm-table-max-memory-percentage"`
 
@@ -168,6 +224,14 @@ type Store struct {
 	MaxWriteHangTime      toml.Duration `toml:"max-write-hang-time"`
 	WalSyncInterval       toml.Duration `toml:"wal-sync-interval"`
 
+	// WalSyncMode is the durability of WAL writes: async, periodic or fsync
+	WalSyncMode string `toml:"wal-sync-mode"`
+	// WalSyncModes overrides WalSyncMode of the databases, database name -> mode
+	WalSyncModes map[string]string `toml:"wal-sync-modes"`
+
+	// WalRecordCrc writes the crc of every WAL record, which can not be replayed by versions without it
+	WalRecordCrc bool `toml:"wal-record-crc"`
+
 	WalEnabled        bool `toml:"wal-enabled"`
 	WalReplayParallel bool `toml:"wal-replay-parallel"`
 	CacheDataBlock    bool `toml:"cache-table-data-block"`
@@ -175,6 +239,29 @@ type Store struct {
 	EnableMmapRead    bool `toml:"enable-mmap-read"`
 	Readonly          bool `toml:"readonly"`
 	CompactRecovery   bool `toml:"compact-recovery"`
+	LastValueCache    bool `toml:"last-value-cache"`
+
+	// FloatCodec is the default codec of float columns, can be overridden per measurement.
+	// gorilla is readable by all versions, the other codecs are opt-in
+	FloatCodec string `toml:"float-codec"`
+	// StringDictionary encodes low cardinality string blocks with a dictionary,
+	// which can not be read by versions without the dictionary encoding
+	StringDictionary bool `toml:"string-dictionary"`
+
+	// Disk watermarks in percent of the used space. Above the high watermark the node accepts
+	// no new shards, above the flood watermark it is readonly until the usage is below high.
+	// The check is disabled if disk-check-interval is 0
+	DiskLowWatermark   float64       `toml:"disk-low-watermark"`
+	DiskHighWatermark  float64       `toml:"disk-high-watermark"`
+	DiskFloodWatermark float64       `toml:"disk-flood-watermark"`
+	DiskCheckInterval  toml.Duration `toml:"disk-check-interval"`
+
+	// EncryptionKeyDir enables the encryption at rest of TSSP, WAL and mergeset index files.
+	// The key of id "xxx" is loaded from the file "xxx.key" in the directory, the old keys must be kept
+	// as long as the files which are not compacted any more use them
+	EncryptionKeyDir string `toml:"encryption-key-dir"`
+	// EncryptionKeyID is the key of new files, the greatest key id in EncryptionKeyDir if empty
+	EncryptionKeyID string `toml:"encryption-key-id"`
 
 	ReadCacheLimit       int `toml:"read-cache-limit"`
 	WriteConcurrentLimit int `toml:"write-concurrent-limit"`
@@ -199,9 +286,15 @@ func NewStore() Store {
 		WriteConcurrentLimit:         0,
 		WalSyncInterval:              toml.Duration(DefaultWALSyncInterval),
 		WalEnabled:                   true,
+		WalSyncMode:                  WalSyncModePeriodic,
 		WalReplayParallel:            false,
 		CompactRecovery:              true,
 		CompactionMethod:             0,
+		FloatCodec:                   FloatCodecGorilla,
+		DiskLowWatermark:             DefaultDiskLowWatermark,
+		DiskHighWatermark:            DefaultDiskHighWatermark,
+		DiskFloodWatermark:           DefaultDiskFloodWatermark,
+		DiskCheckInterval:            toml.Duration(DefaultDiskCheckInterval),
 	}
 }
 
@@ -244,6 +337,12 @@ func (c Store) Validate() error {
 	if c.WalEnabled {
 		svItems = append(svItems, stringValidatorItem{"data store-wal-dir", c.WALDir})
 	}
+	for _, dir := range c.DataDirs {
+		svItems = append(svItems, stringValidatorItem{"data store-data-dirs", dir})
+	}
+	for _, dir := range c.WALDirs {
+		svItems = append(svItems, stringValidatorItem{"data store-wal-dirs", dir})
+	}
 	if err := (stringValidator{}).Validate(svItems); err != nil {
 		return err
 	}
@@ -260,6 +359,32 @@ func (c Store) Validate() error {
 		return err
 	}
 
+	if c.WalSyncMode != "" && !IsValidWalSyncMode(c.WalSyncMode) {
+		return fmt.Errorf("invalid data wal-sync-mode: %v", c.WalSyncMode)
+	}
+	for db, mode := range c.WalSyncModes {
+		if !IsValidWalSyncMode(mode) {
+			return fmt.Errorf("invalid data wal-sync-modes: %v = %v", db, mode)
+		}
+	}
+
+	if c.FloatCodec != "" && !IsValidFloatCodec(c.FloatCodec) {
+		return fmt.Errorf("invalid data float-codec: %v", c.FloatCodec)
+	}
+
+	if c.DiskCheckInterval < 0 {
+		return fmt.Errorf("invalid data disk-check-interval: %v", c.DiskCheckInterval)
+	}
+	if c.DiskCheckInterval > 0 && !(0 < c.DiskLowWatermark && c.DiskLowWatermark <= c.DiskHighWatermark &&
+		c.DiskHighWatermark <= c.DiskFloodWatermark && c.DiskFloodWatermark <= 100) {
+		return fmt.Errorf("invalid data disk watermarks, need 0 < low <= high <= flood <= 100, got %v, %v, %v",
+			c.DiskLowWatermark, c.DiskHighWatermark, c.DiskFloodWatermark)
+	}
+
+	if c.EncryptionKeyID != "" && c.EncryptionKeyDir == "" {
+		return fmt.Errorf("data encryption-key-id %v is set without encryption-key-dir", c.EncryptionKeyID)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+)
+
+const (
+	WorkloadGroupRealtime = "realtime"
+	WorkloadGroupBatch    = "batch"
+	WorkloadGroupAdmin    = "admin"
+
+	DefaultWorkloadMemoryWait = 300 * time.Second
+)
+
+// Workload represents the configuration of workload groups.
+// A query is mapped to a group by the "workload_group=<name>" hint, by its user, or falls back to DefaultGroup.
+type Workload struct {
+	Enabled      bool            `toml:"enabled"`
+	DefaultGroup string          `toml:"default-group"`
+	ScanSlots    int             `toml:"scan-slots"`
+	MemoryWait   toml.Duration   `toml:"memory-wait"`
+	Groups       []WorkloadGroup `toml:"group"`
+}
+
+// WorkloadGroup represents the resource shares of one workload group.
+// Groups with a higher priority are scheduled first, groups with the same priority
+// share the scan slots of a store in proportion to CPUShare.
+type WorkloadGroup struct {
+	Name           string   `toml:"name"`
+	Priority       int      `toml:"priority"`
+	CPUShare       int      `toml:"cpu-share"`
+	MaxConcurrency int      `toml:"max-concurrency"`
+	MemoryPercent  int      `toml:"memory-percent"`
+	Users          []string `toml:"users"`
+}
+
+func NewWorkload() Workload {
+	return Workload{
+		Enabled:      false,
+		DefaultGroup: WorkloadGroupBatch,
+		MemoryWait:   toml.Duration(DefaultWorkloadMemoryWait),
+		Groups: []WorkloadGroup{
+			{Name: WorkloadGroupAdmin, Priority: 20, CPUShare: 10, MaxConcurrency: 4, MemoryPercent: 10},
+			{Name: WorkloadGroupRealtime, Priority: 10, CPUShare: 60, MaxConcurrency: 0, MemoryPercent: 40},
+			{Name: WorkloadGroupBatch, Priority: 0, CPUShare: 30, MaxConcurrency: 8, MemoryPercent: 50},
+		},
+	}
+}
+
+// Validate validates that the configuration is acceptable.
+func (c Workload) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MemoryWait < 0 {
+		return fmt.Errorf("workload memory-wait can not be negative")
+	}
+
+	names := make(map[string]struct{}, len(c.Groups))
+	users := make(map[string]string)
+	for _, g := range c.Groups {
+		if g.Name == "" {
+			return fmt.Errorf("workload group name must not be empty")
+		}
+		if _, ok := names[g.Name]; ok {
+			return fmt.Errorf("duplicate workload group %q", g.Name)
+		}
+		names[g.Name] = struct{}{}
+
+		if g.CPUShare <= 0 {
+			return fmt.Errorf("workload group %q cpu-share must be positive", g.Name)
+		}
+		if g.MaxConcurrency < 0 {
+			return fmt.Errorf("workload group %q max-concurrency can not be negative", g.Name)
+		}
+		if g.MemoryPercent < 0 || g.MemoryPercent > 100 {
+			return fmt.Errorf("workload group %q memory-percent must be in [0, 100]", g.Name)
+		}
+		for _, u := range g.Users {
+			if other, ok := users[u]; ok {
+				return fmt.Errorf("user %q is mapped to workload groups %q and %q", u, other, g.Name)
+			}
+			users[u] = g.Name
+		}
+	}
+
+	if _, ok := names[c.DefaultGroup]; !ok {
+		return fmt.Errorf("workload default-group %q is not defined", c.DefaultGroup)
+	}
+	return nil
+}
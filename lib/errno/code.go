@@ -46,34 +46,36 @@ const (
 
 // network module error codes
 const (
-	NoConnectionAvailable = 1001
-	NoNodeAvailable       = 1002
-	NodeConflict          = 1003
-	SelectClosedConn      = 1004
-	UnsupportedFlags      = 1005
-	InvalidHeaderSize     = 1006
-	InvalidHeader         = 1007
-	DuplicateSession      = 1008
-	InvalidDataSize       = 1009
-	TooManySessions       = 1010
-	ConnectionClosed      = 1011
-	SessionSelectTimeout  = 1012
-	DuplicateEvent        = 1013
-	InvalidPublicKey      = 1014
-	ShortPublicKey        = 1015
-	UnsupportedSignAlgo   = 1016
-	CertificateExpired    = 1017
-	PoolClosed            = 1018
-	DuplicateConnection   = 1019
-	NoReactorHandler      = 1020
-	ResponserClosed       = 1021
-	InvalidAddress        = 1022
-	BadListen             = 1023
-	FailedConvertToCodec  = 1024
-	OpenSessionTimeout    = 1025
-	RemoteError           = 1206
-	DataACKTimeout        = 1027
-	InvalidTLSConfig      = 1208
+	NoConnectionAvailable  = 1001
+	NoNodeAvailable        = 1002
+	NodeConflict           = 1003
+	SelectClosedConn       = 1004
+	UnsupportedFlags       = 1005
+	InvalidHeaderSize      = 1006
+	InvalidHeader          = 1007
+	DuplicateSession       = 1008
+	InvalidDataSize        = 1009
+	TooManySessions        = 1010
+	ConnectionClosed       = 1011
+	SessionSelectTimeout   = 1012
+	DuplicateEvent         = 1013
+	InvalidPublicKey       = 1014
+	ShortPublicKey         = 1015
+	UnsupportedSignAlgo    = 1016
+	CertificateExpired     = 1017
+	PoolClosed             = 1018
+	DuplicateConnection    = 1019
+	NoReactorHandler       = 1020
+	ResponserClosed        = 1021
+	InvalidAddress         = 1022
+	BadListen              = 1023
+	FailedConvertToCodec   = 1024
+	OpenSessionTimeout     = 1025
+	RemoteError            = 1206
+	DataACKTimeout         = 1027
+	InvalidTLSConfig       = 1208
+	InvalidSpdyCompression = 1209
+	DecompressFailed       = 1210
 )
 
 // query engine error codes
@@ -93,6 +95,7 @@ const (
 	BucketLacks                  = 1113
 	CreatePipelineExecutorFail   = 1114
 	LogicalPlainBuildFailInShard = 1115
+	WorkloadGroupAborted         = 1116
 )
 
 // store engine error codes
@@ -124,6 +127,10 @@ const (
 	WalRecordHeaderCorrupted           = 2125
 	WalRecordUnmarshalFailed           = 2126
 	CompactPanicFail                   = 2127
+	WalRecordCrcMismatch               = 2128
+	EncryptionKeyNotFound              = 2129
+	EncryptedBlockCorrupted            = 2130
+	InvalidEncryptionKey               = 2131
 )
 
 // merge out of order
@@ -192,6 +199,8 @@ const (
 	WritePointOutOfRP          = 5013
 	WritePointShardKeyTooLarge = 5014
 	EngineClosed               = 5015
+	ErrDiskFlood               = 5016
+	ErrDiskHighWatermark       = 5017
 )
 
 // index
@@ -75,35 +75,40 @@ var messageMap = map[Errno]*Message{
 	DuplicateField:     newWarnMessage("duplicate field: %s", ModuleWrite),
 	EngineClosed:       newWarnMessage("engine is closed", ModuleWrite),
 
+	ErrDiskFlood:         newWarnMessage("disk usage %.2f%% of %s exceeds the flood watermark, this node is readonly", ModuleWrite),
+	ErrDiskHighWatermark: newWarnMessage("disk usage %.2f%% of %s exceeds the high watermark, no new shard is created", ModuleWrite),
+
 	// network module error codes
-	NoConnectionAvailable: newFatalMessage("no connections available, node: %v, %v", ModuleNetwork),
-	NoNodeAvailable:       newFatalMessage("no node available, node: %v", ModuleNetwork),
-	NodeConflict:          newWarnMessage("node conflict", ModuleNetwork),
-	SelectClosedConn:      newWarnMessage("select data from closed connection. remote addr: %s; local addr: %s", ModuleNetwork),
-	UnsupportedFlags:      newWarnMessage("handle data with unsupported flags(%d)", ModuleNetwork),
-	InvalidHeaderSize:     newFatalMessage("expect read header with length %d, but %d", ModuleNetwork),
-	InvalidHeader:         newFatalMessage("invalid version(%d), type(%d) of header", ModuleNetwork),
-	DuplicateSession:      newNoticeMessage("add duplicate session with id %d", ModuleNetwork),
-	InvalidDataSize:       newFatalMessage("expect write with data length %d, but %d", ModuleNetwork),
-	TooManySessions:       newWarnMessage("accepted concurrent session exceeds the threshold(%d)", ModuleNetwork),
-	ConnectionClosed:      newWarnMessage("multiplexed connection closed", ModuleNetwork),
-	DuplicateEvent:        newFatalMessage("duplicate event for transition (%d, %d, %d)", ModuleNetwork),
-	InvalidPublicKey:      newFatalMessage("invalid public key type, exp: *rsa.PublicKey; got: %s", ModuleUnknown),
-	ShortPublicKey:        newFatalMessage("public key is too short, at least %d bit are required. got: %d bit", ModuleUnknown),
-	UnsupportedSignAlgo:   newFatalMessage("unsupported signature algorithm: %s", ModuleUnknown),
-	CertificateExpired:    newFatalMessage("certificate: %s expires on %s", ModuleUnknown),
-	PoolClosed:            newWarnMessage("try get connection from a closed pool", ModuleNetwork),
-	DuplicateConnection:   newWarnMessage("duplicate connection accept by server session", ModuleNetwork),
-	NoReactorHandler:      newWarnMessage("handler of reactor for type %d is nil", ModuleNetwork),
-	ResponserClosed:       newWarnMessage("apply on the closed responser", ModuleNetwork),
-	InvalidAddress:        newNoticeMessage("invalid address: %s", ModuleNetwork),
-	BadListen:             newNoticeMessage("bad practice to listen on %s", ModuleNetwork),
-	FailedConvertToCodec:  newWarnMessage("failed to convert to Codec, give type: %s", ModuleNetwork),
-	OpenSessionTimeout:    newWarnMessage("failed to open session: timeout", ModuleNetwork),
-	SessionSelectTimeout:  newWarnMessage("select timeout in %d seconds", ModuleNetwork),
-	RemoteError:           newWarnMessage("remote error: %v", ModuleNetwork),
-	DataACKTimeout:        newWarnMessage("wait data ack signal timeout", ModuleNetwork),
-	InvalidTLSConfig:      newWarnMessage("tsl configuration is not enabled or invalid", ModuleNetwork),
+	NoConnectionAvailable:  newFatalMessage("no connections available, node: %v, %v", ModuleNetwork),
+	NoNodeAvailable:        newFatalMessage("no node available, node: %v", ModuleNetwork),
+	NodeConflict:           newWarnMessage("node conflict", ModuleNetwork),
+	SelectClosedConn:       newWarnMessage("select data from closed connection. remote addr: %s; local addr: %s", ModuleNetwork),
+	UnsupportedFlags:       newWarnMessage("handle data with unsupported flags(%d)", ModuleNetwork),
+	InvalidHeaderSize:      newFatalMessage("expect read header with length %d, but %d", ModuleNetwork),
+	InvalidHeader:          newFatalMessage("invalid version(%d), type(%d) of header", ModuleNetwork),
+	DuplicateSession:       newNoticeMessage("add duplicate session with id %d", ModuleNetwork),
+	InvalidDataSize:        newFatalMessage("expect write with data length %d, but %d", ModuleNetwork),
+	TooManySessions:        newWarnMessage("accepted concurrent session exceeds the threshold(%d)", ModuleNetwork),
+	ConnectionClosed:       newWarnMessage("multiplexed connection closed", ModuleNetwork),
+	DuplicateEvent:         newFatalMessage("duplicate event for transition (%d, %d, %d)", ModuleNetwork),
+	InvalidPublicKey:       newFatalMessage("invalid public key type, exp: *rsa.PublicKey; got: %s", ModuleUnknown),
+	ShortPublicKey:         newFatalMessage("public key is too short, at least %d bit are required. got: %d bit", ModuleUnknown),
+	UnsupportedSignAlgo:    newFatalMessage("unsupported signature algorithm: %s", ModuleUnknown),
+	CertificateExpired:     newFatalMessage("certificate: %s expires on %s", ModuleUnknown),
+	PoolClosed:             newWarnMessage("try get connection from a closed pool", ModuleNetwork),
+	DuplicateConnection:    newWarnMessage("duplicate connection accept by server session", ModuleNetwork),
+	NoReactorHandler:       newWarnMessage("handler of reactor for type %d is nil", ModuleNetwork),
+	ResponserClosed:        newWarnMessage("apply on the closed responser", ModuleNetwork),
+	InvalidAddress:         newNoticeMessage("invalid address: %s", ModuleNetwork),
+	BadListen:              newNoticeMessage("bad practice to listen on %s", ModuleNetwork),
+	FailedConvertToCodec:   newWarnMessage("failed to convert to Codec, give type: %s", ModuleNetwork),
+	OpenSessionTimeout:     newWarnMessage("failed to open session: timeout", ModuleNetwork),
+	SessionSelectTimeout:   newWarnMessage("select timeout in %d seconds", ModuleNetwork),
+	RemoteError:            newWarnMessage("remote error: %v", ModuleNetwork),
+	DataACKTimeout:         newWarnMessage("wait data ack signal timeout", ModuleNetwork),
+	InvalidTLSConfig:       newWarnMessage("tsl configuration is not enabled or invalid", ModuleNetwork),
+	InvalidSpdyCompression: newWarnMessage("unsupported spdy compression algorithm: %s", ModuleNetwork),
+	DecompressFailed:       newWarnMessage("failed to decompress spdy frame: %v", ModuleNetwork),
 
 	// query engine error codes
 	PipelineExecuting:          newNoticeMessage("pipeline executor is executing with %v and %v", ModuleQueryEngine),
@@ -146,10 +151,16 @@ var messageMap = map[Errno]*Message{
 	CompactPanicFail:                   newFatalMessage("compact fail", ModuleTssp),
 
 	// wal error codes
-	ReadWalFileFailed:         newWarnMessage("read wal file failed", ModuleWal),
-	DecompressWalRecordFailed: newWarnMessage("decompress wal record failed", ModuleWal),
-	WalRecordHeaderCorrupted:  newWarnMessage("wal record header is corrupt", ModuleWal),
+	ReadWalFileFailed:         newWarnMessage("read wal file failed, file: %s, offset: %d, %s", ModuleWal),
+	DecompressWalRecordFailed: newWarnMessage("decompress wal record failed, file: %s, offset: %d, %s", ModuleWal),
+	WalRecordHeaderCorrupted:  newWarnMessage("wal record header is corrupt, file: %s, offset: %d", ModuleWal),
 	WalRecordUnmarshalFailed:  newWarnMessage("wal record unmarshal failed", ModuleWal),
+	WalRecordCrcMismatch:      newWarnMessage("wal record crc mismatch, file: %s, offset: %d", ModuleWal),
+
+	// encryption at rest error codes
+	EncryptionKeyNotFound:   newFatalMessage("encryption key %q of file %s is not found", ModuleStorageEngine),
+	EncryptedBlockCorrupted: newFatalMessage("decrypt block failed, file: %s, offset: %d", ModuleStorageEngine),
+	InvalidEncryptionKey:    newFatalMessage("invalid encryption key %q: %s", ModuleStorageEngine),
 
 	// merge out of order
 	SeriesIdIsZero:     newFatalMessage("invalid record, series id is 0. file: %s", ModuleMerge),
@@ -161,6 +172,7 @@ var messageMap = map[Errno]*Message{
 	UnsupportedExprType:       newWarnMessage("unsupported expr type of fill processor", ModuleQueryEngine),
 	UnsupportedToFillPrevious: newFatalMessage("the data type is not supported to fill previous: %s", ModuleQueryEngine),
 	BucketLacks:               newWarnMessage("get resources out of time: bucket lacks of resources", ModuleQueryEngine),
+	WorkloadGroupAborted:      newNoticeMessage("query aborted while waiting for resources of workload group %s", ModuleQueryEngine),
 
 	// meta error codes
 	FieldTypeConflict:        newWarnMessage(`field type conflict: input field "%s" on measurement "%s" is type %s, already exists as type %s`, ModuleMeta),
@@ -0,0 +1,619 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileops
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openGemini/openGemini/lib/bufferpool"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/open_src/golang.org/x/crypto/hkdf"
+)
+
+// An encrypted file starts with a header followed by runs of sealed records, every Write
+// appends a run, so nothing written to the file is rewritten:
+//
+//	header: magic(8) | version(1) | key id length(1) | key id | file id(16)
+//	run:    plaintext size(4) | counter of the first record(8) | crc32 of the run header(4) | records
+//	record: ciphertext(up to encryptRecordSize) | tag(16)
+//
+// Every file is sealed by AES-GCM with its own key, which is derived by HKDF from the key
+// of the key id and the random file id. The nonce of a record is a counter which is never
+// reused within a file, and the plaintext offset of the record is authenticated with it,
+// so records can not be moved within a file or between files.
+// A random read only decrypts the records it covers. Offsets seen by callers are plaintext offsets.
+// The TSSP files, the WAL files and the part files of the mergeset index are encrypted.
+const (
+	encryptMagic         = "OGCRYPT1"
+	encryptVersion       = 1
+	encryptFileIDSize    = 16
+	encryptNonceSize     = 12
+	encryptTagSize       = 16
+	encryptRunHeaderSize = 16
+	encryptRecordSize    = 4096
+	encryptMaxRunSize    = 1 << 30
+
+	EncryptionKeySuffix = ".key"
+)
+
+var encryptKeyInfo = []byte("openGemini encryption at rest")
+
+// KeyProvider supplies the keys of encryption at rest.
+type KeyProvider interface {
+	// CurrentKeyID returns the id of the key used to encrypt new files
+	CurrentKeyID() (string, error)
+	// Key returns the key of the id, the key must be 16, 24 or 32 bytes
+	Key(id string) ([]byte, error)
+}
+
+var encryption = struct {
+	mu       sync.RWMutex
+	provider KeyProvider
+}{}
+
+// SetKeyProvider enables encryption at rest for the files created by EncryptFile,
+// a nil provider disables it. Encrypted files can only be read while a provider
+// which knows their keys is set.
+func SetKeyProvider(p KeyProvider) {
+	encryption.mu.Lock()
+	encryption.provider = p
+	encryption.mu.Unlock()
+}
+
+func EncryptionEnabled() bool {
+	return keyProvider() != nil
+}
+
+func keyProvider() KeyProvider {
+	encryption.mu.RLock()
+	defer encryption.mu.RUnlock()
+	return encryption.provider
+}
+
+// aeadOf returns the AEAD of the file of fileID, its key is derived from the key of id
+func aeadOf(id string, fileID []byte, file string) (cipher.AEAD, error) {
+	p := keyProvider()
+	if p == nil {
+		return nil, errno.NewError(errno.EncryptionKeyNotFound, id, file)
+	}
+
+	key, err := p.Key(id)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errno.NewError(errno.EncryptionKeyNotFound, id, file)
+		}
+		return nil, err
+	}
+	fileKey := make([]byte, len(key))
+	if _, err = io.ReadFull(hkdf.New(sha256.New, key, fileID, encryptKeyInfo), fileKey); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(fileKey)
+	if err != nil {
+		return nil, errno.NewError(errno.InvalidEncryptionKey, id, err.Error())
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errno.NewError(errno.InvalidEncryptionKey, id, err.Error())
+	}
+	return aead, nil
+}
+
+// EncryptFile writes the encryption header to the newly created and empty file f,
+// and returns a File which encrypts everything written to it with the current key.
+// f is returned as it is if encryption is not enabled.
+// The returned File only supports appending writes.
+func EncryptFile(f File) (File, error) {
+	p := keyProvider()
+	if p == nil {
+		return f, nil
+	}
+
+	id, err := p.CurrentKeyID()
+	if err != nil {
+		return nil, err
+	}
+	if len(id) == 0 || len(id) > math.MaxUint8 {
+		return nil, errno.NewError(errno.InvalidEncryptionKey, id, "the length of key id must be in [1, 255]")
+	}
+
+	ef := &encryptedFile{File: f, keyID: id}
+	if _, err = rand.Read(ef.fileID[:]); err != nil {
+		return nil, err
+	}
+	if ef.aead, err = aeadOf(id, ef.fileID[:], f.Name()); err != nil {
+		return nil, err
+	}
+
+	hdr := make([]byte, 0, len(encryptMagic)+2+len(id)+encryptFileIDSize)
+	hdr = append(hdr, encryptMagic...)
+	hdr = append(hdr, encryptVersion, uint8(len(id)))
+	hdr = append(hdr, id...)
+	hdr = append(hdr, ef.fileID[:]...)
+	if _, err = f.Write(hdr); err != nil {
+		return nil, err
+	}
+	ef.phySize, ef.fileSize = int64(len(hdr)), int64(len(hdr))
+	return ef, nil
+}
+
+// DecryptFile returns a File which reads and appends the plaintext of f if f is
+// encrypted, else f is returned as it is.
+func DecryptFile(f File) (File, error) {
+	var head [len(encryptMagic) + 2]byte
+	n, err := f.ReadAt(head[:], 0)
+	if n < len(head) || string(head[:len(encryptMagic)]) != encryptMagic {
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	name := f.Name()
+	if head[len(encryptMagic)] != encryptVersion {
+		return nil, fmt.Errorf("unsupported encryption version %d of file %s", head[len(encryptMagic)], name)
+	}
+	rest := make([]byte, int(head[len(head)-1])+encryptFileIDSize)
+	if n, _ = f.ReadAt(rest, int64(len(head))); n < len(rest) {
+		return nil, errno.NewError(errno.EncryptedBlockCorrupted, name, 0)
+	}
+	id := string(rest[:len(rest)-encryptFileIDSize])
+	ef := &encryptedFile{File: f, keyID: id}
+	copy(ef.fileID[:], rest[len(id):])
+	if ef.aead, err = aeadOf(id, ef.fileID[:], name); err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	ef.phySize = int64(len(head) + len(rest))
+	ef.fileSize = fi.Size()
+	if err = ef.loadRuns(); err != nil {
+		return nil, err
+	}
+	return ef, nil
+}
+
+// CreateEncryptedFile creates the named file for writing, the file is encrypted
+// if encryption at rest is enabled.
+func CreateEncryptedFile(name string, perm os.FileMode, opt ...FSOption) (File, error) {
+	fd, err := OpenFile(name, os.O_CREATE|os.O_RDWR, perm, opt...)
+	if err != nil {
+		return nil, err
+	}
+	ef, err := EncryptFile(fd)
+	if err != nil {
+		_ = fd.Close()
+		return nil, err
+	}
+	return ef, nil
+}
+
+// OpenDecryptedFile opens the named file like OpenFile, and decrypts it if it is encrypted.
+func OpenDecryptedFile(name string, flag int, perm os.FileMode, opt ...FSOption) (File, error) {
+	fd, err := OpenFile(name, flag, perm, opt...)
+	if err != nil {
+		return nil, err
+	}
+	df, err := DecryptFile(fd)
+	if err != nil {
+		_ = fd.Close()
+		return nil, err
+	}
+	return df, nil
+}
+
+// IsEncrypted reports whether f is a File returned by EncryptFile or DecryptFile.
+// The content of an encrypted file on disk is ciphertext, it can not be mapped into memory.
+func IsEncrypted(f File) bool {
+	_, ok := f.(*encryptedFile)
+	return ok
+}
+
+// EncryptionKeyID returns the id of the key encrypting f, or an empty string if f is not encrypted.
+func EncryptionKeyID(f File) string {
+	if ef, ok := f.(*encryptedFile); ok {
+		return ef.keyID
+	}
+	return ""
+}
+
+// encryptRun is the sealed records appended by a Write
+type encryptRun struct {
+	off     int64 // plaintext offset
+	phyOff  int64 // offset of the first record in the file
+	size    int64 // plaintext size
+	counter uint64
+}
+
+func (r *encryptRun) records() int64 {
+	return (r.size + encryptRecordSize - 1) / encryptRecordSize
+}
+
+func (r *encryptRun) phySize() int64 {
+	return r.size + r.records()*encryptTagSize
+}
+
+type encryptedFile struct {
+	File
+	aead   cipher.AEAD
+	keyID  string
+	fileID [encryptFileIDSize]byte
+
+	mu       sync.RWMutex
+	size     int64 // plaintext size
+	phySize  int64 // end of the last complete run
+	fileSize int64 // size of the underlying file, greater than phySize if the last write is torn
+	counter  uint64
+	runs     []encryptRun
+	wbuf     []byte
+
+	posMu sync.Mutex
+	pos   int64 // plaintext offset of Read and Seek
+}
+
+func encodeRunHeader(dst []byte, size int64, counter uint64) {
+	binary.BigEndian.PutUint32(dst, uint32(size))
+	binary.BigEndian.PutUint64(dst[4:], counter)
+	binary.BigEndian.PutUint32(dst[12:], crc32.ChecksumIEEE(dst[:12]))
+}
+
+// loadRuns reads the run headers of the file. A torn run left by a crash is dropped like
+// a torn write of a plain file, and the counters its records may have used are skipped.
+func (f *encryptedFile) loadRuns() error {
+	var hdr [encryptRunHeaderSize]byte
+	for f.phySize < f.fileSize {
+		n, err := f.File.ReadAt(hdr[:], f.phySize)
+		if n < len(hdr) {
+			if err != nil && err != io.EOF {
+				return err
+			}
+			break
+		}
+		if crc32.ChecksumIEEE(hdr[:12]) != binary.BigEndian.Uint32(hdr[12:]) {
+			break
+		}
+		run := encryptRun{
+			off:     f.size,
+			phyOff:  f.phySize + encryptRunHeaderSize,
+			size:    int64(binary.BigEndian.Uint32(hdr[:])),
+			counter: binary.BigEndian.Uint64(hdr[4:]),
+		}
+		if run.size == 0 || run.phyOff+run.phySize() > f.fileSize {
+			break
+		}
+		f.runs = append(f.runs, run)
+		f.size += run.size
+		f.phySize = run.phyOff + run.phySize()
+		f.counter = run.counter + uint64(run.records())
+	}
+
+	if torn := f.fileSize - f.phySize; torn > 0 {
+		// every record takes more than a tag
+		f.counter += uint64(torn/encryptTagSize) + 1
+	}
+	return nil
+}
+
+func nonceOf(dst *[encryptNonceSize]byte, counter uint64) []byte {
+	binary.BigEndian.PutUint64(dst[encryptNonceSize-8:], counter)
+	return dst[:]
+}
+
+func additionalData(dst *[8]byte, off int64) []byte {
+	binary.BigEndian.PutUint64(dst[:], uint64(off))
+	return dst[:]
+}
+
+// Write appends b to the file as sealed records, the data is handed to the underlying
+// file when Write returns.
+func (f *encryptedFile) Write(b []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.fileSize > f.phySize {
+		// drop the torn run, its counters are not used again
+		if err := f.File.Truncate(f.phySize); err != nil {
+			return 0, err
+		}
+		f.fileSize = f.phySize
+	}
+
+	wn := 0
+	for len(b) > 0 {
+		n := len(b)
+		if n > encryptMaxRunSize {
+			n = encryptMaxRunSize
+		}
+		if err := f.writeRun(b[:n]); err != nil {
+			return wn, err
+		}
+		wn += n
+		b = b[n:]
+	}
+	return wn, nil
+}
+
+func (f *encryptedFile) writeRun(b []byte) error {
+	run := encryptRun{off: f.size, phyOff: f.phySize + encryptRunHeaderSize, size: int64(len(b)), counter: f.counter}
+	// the counters are consumed even if the write fails
+	f.counter += uint64(run.records())
+
+	sealed := bufferpool.Resize(f.wbuf, encryptRunHeaderSize)
+	encodeRunHeader(sealed, run.size, run.counter)
+	var nonce [encryptNonceSize]byte
+	var ad [8]byte
+	for i := int64(0); i < run.records(); i++ {
+		plain := b[i*encryptRecordSize:]
+		if len(plain) > encryptRecordSize {
+			plain = plain[:encryptRecordSize]
+		}
+		sealed = f.aead.Seal(sealed, nonceOf(&nonce, run.counter+uint64(i)), plain,
+			additionalData(&ad, run.off+i*encryptRecordSize))
+	}
+	f.wbuf = sealed
+
+	if _, err := f.File.Seek(f.phySize, io.SeekStart); err != nil {
+		return err
+	}
+	n, err := f.File.Write(sealed)
+	f.fileSize += int64(n)
+	if err != nil {
+		return err
+	}
+
+	f.runs = append(f.runs, run)
+	f.size += run.size
+	f.phySize = f.fileSize
+	return nil
+}
+
+func (f *encryptedFile) ReadAt(b []byte, off int64) (int, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d of file %s", off, f.Name())
+	}
+	if off >= f.size {
+		return 0, io.EOF
+	}
+
+	i := sort.Search(len(f.runs), func(i int) bool {
+		return f.runs[i].off+f.runs[i].size > off
+	})
+	n := 0
+	for ; i < len(f.runs) && n < len(b); i++ {
+		m, err := f.readRun(&f.runs[i], b[n:], off+int64(n))
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readRun reads the plaintext at off of the run into b
+func (f *encryptedFile) readRun(run *encryptRun, b []byte, off int64) (int, error) {
+	end := off + int64(len(b))
+	if end > run.off+run.size {
+		end = run.off + run.size
+	}
+	first, last := (off-run.off)/encryptRecordSize, (end-run.off-1)/encryptRecordSize
+	recordOff := func(idx int64) int64 {
+		return run.phyOff + idx*(encryptRecordSize+encryptTagSize)
+	}
+	phyEnd := run.phyOff + run.phySize()
+	if last < run.records()-1 {
+		phyEnd = recordOff(last + 1)
+	}
+
+	buf := bufferpool.Resize(bufferpool.Get(), int(phyEnd-recordOff(first)))
+	defer bufferpool.Put(buf)
+	if n, err := f.File.ReadAt(buf, recordOff(first)); n < len(buf) {
+		if err == nil || err == io.EOF {
+			err = errno.NewError(errno.EncryptedBlockCorrupted, f.Name(), recordOff(first)+int64(n))
+		}
+		return 0, err
+	}
+
+	var nonce [encryptNonceSize]byte
+	var ad [8]byte
+	n := 0
+	for idx := first; idx <= last; idx++ {
+		pos := (idx - first) * (encryptRecordSize + encryptTagSize)
+		rec := buf[pos:]
+		if int64(len(rec)) > encryptRecordSize+encryptTagSize {
+			rec = rec[:encryptRecordSize+encryptTagSize]
+		}
+		plain, err := f.aead.Open(rec[:0], nonceOf(&nonce, run.counter+uint64(idx)), rec,
+			additionalData(&ad, run.off+idx*encryptRecordSize))
+		if err != nil {
+			return n, errno.NewError(errno.EncryptedBlockCorrupted, f.Name(), recordOff(idx))
+		}
+		if idx == first {
+			plain = plain[off-run.off-first*encryptRecordSize:]
+		}
+		n += copy(b[n:], plain)
+	}
+	return n, nil
+}
+
+func (f *encryptedFile) Read(b []byte) (int, error) {
+	f.posMu.Lock()
+	defer f.posMu.Unlock()
+
+	n, err := f.ReadAt(b, f.pos)
+	f.pos += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *encryptedFile) Seek(offset int64, whence int) (int64, error) {
+	f.posMu.Lock()
+	defer f.posMu.Unlock()
+
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		f.mu.RLock()
+		offset += f.size
+		f.mu.RUnlock()
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset %d of file %s", offset, f.Name())
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+func (f *encryptedFile) Truncate(size int64) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if size == f.size {
+		return nil
+	}
+	return fmt.Errorf("truncate encrypted file %s is not supported", f.Name())
+}
+
+func (f *encryptedFile) Stat() (os.FileInfo, error) {
+	fi, err := f.File.Stat()
+	if err != nil {
+		return nil, err
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return &encryptedFileInfo{FileInfo: fi, size: f.size}, nil
+}
+
+type encryptedFileInfo struct {
+	os.FileInfo
+	size int64
+}
+
+func (fi *encryptedFileInfo) Size() int64 {
+	return fi.size
+}
+
+// FileKeyProvider loads keys from the files in a directory, the key of id "xxx" is
+// stored in the file "xxx.key" as raw bytes or hex encoded.
+// The current key is the configured one, or else the key with the greatest id,
+// so keys are rotated by adding a key file with a greater id: new files including
+// the outputs of compaction are encrypted with the new key, and the old key can be
+// removed once no file uses it any more. The content of a key file must not change.
+type FileKeyProvider struct {
+	dir     string
+	current string
+
+	mu   sync.RWMutex
+	keys map[string][]byte
+}
+
+func NewFileKeyProvider(dir string, current string) (*FileKeyProvider, error) {
+	p := &FileKeyProvider{
+		dir:     dir,
+		current: current,
+		keys:    make(map[string][]byte),
+	}
+	if _, err := p.CurrentKeyID(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *FileKeyProvider) CurrentKeyID() (string, error) {
+	id := p.current
+	if id == "" {
+		files, err := Glob(filepath.Join(p.dir, "*"+EncryptionKeySuffix))
+		if err != nil {
+			return "", err
+		}
+		if len(files) == 0 {
+			return "", fmt.Errorf("no encryption key is found in %s", p.dir)
+		}
+		sort.Strings(files)
+		id = strings.TrimSuffix(filepath.Base(files[len(files)-1]), EncryptionKeySuffix)
+	}
+
+	if _, err := p.Key(id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (p *FileKeyProvider) Key(id string) ([]byte, error) {
+	p.mu.RLock()
+	key, ok := p.keys[id]
+	p.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, errno.NewError(errno.InvalidEncryptionKey, id, "invalid key id")
+	}
+	b, err := ReadFile(filepath.Join(p.dir, id+EncryptionKeySuffix))
+	if err != nil {
+		return nil, err
+	}
+	if key, err = parseEncryptionKey(id, b); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.keys[id] = key
+	p.mu.Unlock()
+	return key, nil
+}
+
+func parseEncryptionKey(id string, b []byte) ([]byte, error) {
+	validSize := func(n int) bool {
+		return n == 16 || n == 24 || n == 32
+	}
+
+	if key, err := hex.DecodeString(string(bytes.TrimSpace(b))); err == nil && validSize(len(key)) {
+		return key, nil
+	}
+	if validSize(len(b)) {
+		return b, nil
+	}
+	return nil, errno.NewError(errno.InvalidEncryptionKey, id, "the key must be 16, 24 or 32 bytes, raw or hex encoded")
+}
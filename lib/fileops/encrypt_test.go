@@ -0,0 +1,310 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileops_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/fileops"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEncryptionKey(t *testing.T, dir, id string) {
+	key := make([]byte, 32)
+	rand.Read(key)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, id+fileops.EncryptionKeySuffix), []byte(hex.EncodeToString(key)), 0600))
+}
+
+func setKeyProvider(t *testing.T, dir, current string) {
+	p, err := fileops.NewFileKeyProvider(dir, current)
+	require.NoError(t, err)
+	fileops.SetKeyProvider(p)
+	t.Cleanup(func() {
+		fileops.SetKeyProvider(nil)
+	})
+}
+
+func TestEncryptFile(t *testing.T) {
+	keyDir := t.TempDir()
+	writeEncryptionKey(t, keyDir, "k1")
+	setKeyProvider(t, keyDir, "")
+
+	name := filepath.Join(t.TempDir(), "a.tssp")
+	fd, err := fileops.OpenFile(name, os.O_CREATE|os.O_RDWR, 0640)
+	require.NoError(t, err)
+	fd, err = fileops.EncryptFile(fd)
+	require.NoError(t, err)
+	require.True(t, fileops.IsEncrypted(fd))
+	require.Equal(t, "k1", fileops.EncryptionKeyID(fd))
+
+	// writes of all sizes, the records written before are never rewritten
+	var data, raw []byte
+	for _, n := range []int{1, 7, 4000, 4096, 100, 20000, 3, 65536} {
+		b := make([]byte, n)
+		rand.Read(b)
+		wn, err := fd.Write(b)
+		require.NoError(t, err)
+		require.Equal(t, n, wn)
+		data = append(data, b...)
+
+		cur, err := os.ReadFile(name)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(raw, cur[:len(raw)]))
+		raw = cur
+
+		fi, err := fd.Stat()
+		require.NoError(t, err)
+		require.Equal(t, int64(len(data)), fi.Size())
+	}
+	require.NoError(t, fd.Sync())
+	require.NoError(t, fd.Close())
+
+	raw, err = os.ReadFile(name)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(raw, data[:64]))
+
+	fd, err = fileops.OpenFile(name, os.O_RDWR, 0640)
+	require.NoError(t, err)
+	fd, err = fileops.DecryptFile(fd)
+	require.NoError(t, err)
+	defer fd.Close()
+
+	fi, err := fd.Stat()
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), fi.Size())
+
+	for i := 0; i < 200; i++ {
+		off := rand.Intn(len(data))
+		b := make([]byte, rand.Intn(10000)+1)
+		n, err := fd.ReadAt(b, int64(off))
+		if off+len(b) > len(data) {
+			require.Equal(t, io.EOF, err)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, data[off:off+n], b[:n])
+	}
+
+	all, err := io.ReadAll(fd)
+	require.NoError(t, err)
+	require.Equal(t, data, all)
+
+	// appending to a reopened file
+	_, err = fd.Write([]byte("tail"))
+	require.NoError(t, err)
+	data = append(data, "tail"...)
+	b := make([]byte, 100)
+	n, err := fd.ReadAt(b, int64(len(data)-len(b)))
+	require.NoError(t, err)
+	require.Equal(t, data[len(data)-n:], b)
+}
+
+func TestEncryptFile_Disabled(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "a.wal")
+	fd, err := fileops.OpenFile(name, os.O_CREATE|os.O_RDWR, 0640)
+	require.NoError(t, err)
+	ef, err := fileops.EncryptFile(fd)
+	require.NoError(t, err)
+	require.Equal(t, fd, ef)
+	_, err = fd.Write([]byte("plain data"))
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	// plain files are still readable after encryption is enabled
+	keyDir := t.TempDir()
+	writeEncryptionKey(t, keyDir, "k1")
+	setKeyProvider(t, keyDir, "")
+
+	fd, err = fileops.Open(name)
+	require.NoError(t, err)
+	defer fd.Close()
+	df, err := fileops.DecryptFile(fd)
+	require.NoError(t, err)
+	require.False(t, fileops.IsEncrypted(df))
+}
+
+func TestEncryptFile_KeyRotation(t *testing.T) {
+	keyDir := t.TempDir()
+	writeEncryptionKey(t, keyDir, "20221001")
+	setKeyProvider(t, keyDir, "")
+
+	create := func(name string) {
+		fd, err := fileops.OpenFile(name, os.O_CREATE|os.O_RDWR, 0640)
+		require.NoError(t, err)
+		fd, err = fileops.EncryptFile(fd)
+		require.NoError(t, err)
+		_, err = fd.Write([]byte(name))
+		require.NoError(t, err)
+		require.NoError(t, fd.Close())
+	}
+	open := func(name string) (fileops.File, error) {
+		fd, err := fileops.Open(name)
+		require.NoError(t, err)
+		df, err := fileops.DecryptFile(fd)
+		if err != nil {
+			_ = fd.Close()
+			return nil, err
+		}
+		return df, nil
+	}
+
+	dir := t.TempDir()
+	oldFile, newFile := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+	create(oldFile)
+	writeEncryptionKey(t, keyDir, "20221101")
+	create(newFile)
+
+	fd, err := open(newFile)
+	require.NoError(t, err)
+	require.Equal(t, "20221101", fileops.EncryptionKeyID(fd))
+	require.NoError(t, fd.Close())
+	fd, err = open(oldFile)
+	require.NoError(t, err)
+	require.Equal(t, "20221001", fileops.EncryptionKeyID(fd))
+	require.NoError(t, fd.Close())
+
+	// the old key is removed
+	require.NoError(t, os.Remove(filepath.Join(keyDir, "20221001"+fileops.EncryptionKeySuffix)))
+	setKeyProvider(t, keyDir, "")
+	_, err = open(oldFile)
+	require.True(t, errno.Equal(err, errno.EncryptionKeyNotFound))
+	require.Contains(t, err.Error(), "20221001")
+
+	// encryption is disabled
+	fileops.SetKeyProvider(nil)
+	_, err = open(newFile)
+	require.True(t, errno.Equal(err, errno.EncryptionKeyNotFound))
+}
+
+func TestEncryptFile_Corrupted(t *testing.T) {
+	keyDir := t.TempDir()
+	writeEncryptionKey(t, keyDir, "k1")
+	setKeyProvider(t, keyDir, "k1")
+
+	name := filepath.Join(t.TempDir(), "a.tssp")
+	fd, err := fileops.OpenFile(name, os.O_CREATE|os.O_RDWR, 0640)
+	require.NoError(t, err)
+	fd, err = fileops.EncryptFile(fd)
+	require.NoError(t, err)
+	_, err = fd.Write(make([]byte, 10000))
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+
+	// the first record of the file
+	raw, err := os.ReadFile(name)
+	require.NoError(t, err)
+	raw[100] ^= 0xff
+	require.NoError(t, os.WriteFile(name, raw, 0640))
+
+	fd, err = fileops.Open(name)
+	require.NoError(t, err)
+	defer fd.Close()
+	fd, err = fileops.DecryptFile(fd)
+	require.NoError(t, err)
+	_, err = fd.ReadAt(make([]byte, 10), 5000)
+	require.NoError(t, err)
+	_, err = fd.ReadAt(make([]byte, 10), 0)
+	require.True(t, errno.Equal(err, errno.EncryptedBlockCorrupted))
+}
+
+func TestEncryptFile_TornWrite(t *testing.T) {
+	keyDir := t.TempDir()
+	writeEncryptionKey(t, keyDir, "k1")
+	setKeyProvider(t, keyDir, "k1")
+
+	name := filepath.Join(t.TempDir(), "a.wal")
+	fd, err := fileops.CreateEncryptedFile(name, 0640)
+	require.NoError(t, err)
+	data := make([]byte, 10000)
+	rand.Read(data)
+	_, err = fd.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+	raw, err := os.ReadFile(name)
+	require.NoError(t, err)
+
+	// the same plaintext is sealed with another key in another file
+	other := filepath.Join(t.TempDir(), "b.wal")
+	fd, err = fileops.CreateEncryptedFile(other, 0640)
+	require.NoError(t, err)
+	_, err = fd.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, fd.Close())
+	otherRaw, err := os.ReadFile(other)
+	require.NoError(t, err)
+	require.Equal(t, len(raw), len(otherRaw))
+	require.NotEqual(t, raw[len(raw)-4096:], otherRaw[len(raw)-4096:])
+
+	// a run is torn by a crash
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0640)
+	require.NoError(t, err)
+	_, err = f.Write(raw[len(raw)-100:])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	fd, err = fileops.OpenDecryptedFile(name, os.O_RDWR, 0640)
+	require.NoError(t, err)
+	defer fd.Close()
+	fi, err := fd.Stat()
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), fi.Size())
+
+	_, err = fd.Write([]byte("tail"))
+	require.NoError(t, err)
+	data = append(data, "tail"...)
+	b := make([]byte, len(data))
+	_, err = fd.ReadAt(b, 0)
+	require.NoError(t, err)
+	require.Equal(t, data, b)
+
+	// the torn run is replaced, the records before it are kept
+	cur, err := os.ReadFile(name)
+	require.NoError(t, err)
+	require.Equal(t, raw, cur[:len(raw)])
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	keyDir := t.TempDir()
+	_, err := fileops.NewFileKeyProvider(keyDir, "")
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(keyDir, "raw.key"), []byte("0123456789abcdef"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(keyDir, "bad.key"), []byte("short"), 0600))
+
+	p, err := fileops.NewFileKeyProvider(keyDir, "")
+	require.NoError(t, err)
+	id, err := p.CurrentKeyID()
+	require.NoError(t, err)
+	require.Equal(t, "raw", id)
+	key, err := p.Key("raw")
+	require.NoError(t, err)
+	require.Equal(t, []byte("0123456789abcdef"), key)
+
+	_, err = fileops.NewFileKeyProvider(keyDir, "bad")
+	require.True(t, errno.Equal(err, errno.InvalidEncryptionKey))
+	_, err = fileops.NewFileKeyProvider(keyDir, "none")
+	require.True(t, os.IsNotExist(err))
+	_, err = p.Key("../raw")
+	require.True(t, errno.Equal(err, errno.InvalidEncryptionKey))
+}
@@ -34,7 +34,7 @@ type Handler struct {
 		AdminUserExists() bool
 		DataNodes() ([]meta2.DataNode, error)
 		InitMetaClient(joinPeers []string, tlsEn bool, storageNodeInfo *meta.StorageNodeInfo) (uint64, uint64, error)
-		CreateDataNode(httpAddr, tcpAddr string) (uint64, uint64, error)
+		CreateDataNode(httpAddr, tcpAddr, zone, rack string) (uint64, uint64, error)
 	}
 
 	QueryAuthorizer interface {
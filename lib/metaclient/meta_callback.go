@@ -209,3 +209,25 @@ func (c *GetShardInfoCallback) Handle(data interface{}) error {
 	c.Data = msg.Data
 	return nil
 }
+
+type StatsCallback struct {
+	BaseCallback
+
+	Data []byte
+}
+
+func (c *StatsCallback) Handle(data interface{}) error {
+	metaMsg, err := c.Trans2MetaMsg(data)
+	if err != nil {
+		return err
+	}
+	msg, ok := metaMsg.Data().(*message.StatsResponse)
+	if !ok {
+		return errors.New("data is not a StatsResponse")
+	}
+	if msg.Err != "" {
+		return errors.New(msg.Err)
+	}
+	c.Data = msg.Data
+	return nil
+}
@@ -101,6 +101,8 @@ var cliOnce sync.Once
 type StorageNodeInfo struct {
 	InsertAddr string
 	SelectAddr string
+	Zone       string
+	Rack       string
 }
 
 type FieldKey struct {
@@ -116,8 +118,9 @@ func (a FieldKeys) Less(i, j int) bool { return a[i].Field < a[j].Field }
 
 // MetaClient is an interface for accessing meta data.
 type MetaClient interface {
-	CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation) (*meta2.MeasurementInfo, error)
+	CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation, floatCodec string) (*meta2.MeasurementInfo, error)
 	AlterShardKey(database, retentionPolicy, mst string, shardKey *meta2.ShardKeyInfo) error
+	AlterMeasurementTTL(database, retentionPolicy, mst string, ttl time.Duration) error
 	CreateDatabase(name string) (*meta2.DatabaseInfo, error)
 	CreateDatabaseWithRetentionPolicy(name string, spec *meta2.RetentionPolicySpec, shardKey *meta2.ShardKeyInfo) (*meta2.DatabaseInfo, error)
 	CreateRetentionPolicy(database string, spec *meta2.RetentionPolicySpec, makeDefault bool) (*meta2.RetentionPolicyInfo, error)
@@ -128,6 +131,7 @@ type MetaClient interface {
 	DataNode(id uint64) (*meta2.DataNode, error)
 	DataNodes() ([]meta2.DataNode, error)
 	DeleteDataNode(id uint64) error
+	DecommissionDataNode(id uint64) error
 	DeleteMetaNode(id uint64) error
 	DropShard(id uint64) error
 	DropDatabase(name string) error
@@ -135,6 +139,11 @@ type MetaClient interface {
 	DropSubscription(database, rp, name string) error
 	DropUser(name string) error
 	MetaNodes() ([]meta2.NodeInfo, error)
+	MetaNodeStats(mod string, nodeID uint64) (map[string][]byte, error)
+	MigratePt(database string, pt uint32, nodeID uint64) error
+	MigrateEvents() []*meta2.MigrateEventInfo
+	SetBalancer(paused, dryRun bool) error
+	SetReplicationPaused(paused bool) error
 	RetentionPolicy(database, name string) (rpi *meta2.RetentionPolicyInfo, err error)
 	SetAdminPrivilege(username string, admin bool) error
 	SetPrivilege(username, database string, p originql.Privilege) error
@@ -182,6 +191,7 @@ func (ctx *LoadCtx) GetReportCtx() *DBPTCtx {
 func (ctx *LoadCtx) PutReportCtx(dbPTCtx *DBPTCtx) {
 	dbPTCtx.DBPTStat.DB = proto.String("")
 	dbPTCtx.DBPTStat.PtID = proto.Uint32(0)
+	dbPTCtx.DBPTStat.DiskSize = nil
 	dbPTCtx.putRpStat(&dbPTCtx.DBPTStat.RpStats)
 	ctx.ReportCtx.Put(dbPTCtx)
 }
@@ -336,7 +346,47 @@ func (c *Client) SetMetaServers(a []string) {
 	c.metaServers = a
 
 	for i, server := range a {
-		transport.NewMetaNodeManager().Add(uint64(i), server)
+		transport.NewMetaNodeManager().Reset(uint64(i), server)
+	}
+}
+
+// refreshMetaServers follows the meta nodes added and removed online, so the sql and store nodes need no restart.
+// The servers still in the cluster keep their indexes as far as possible. The caller must hold c.mu.
+func (c *Client) refreshMetaServers() {
+	nodes := c.cacheData.MetaNodes
+	if len(nodes) == 0 {
+		return
+	}
+	latest := make(map[string]bool, len(nodes))
+	for i := range nodes {
+		if nodes[i].RPCAddr == "" {
+			// the meta nodes of old versions have no rpc address
+			return
+		}
+		latest[nodes[i].RPCAddr] = true
+	}
+
+	servers := make([]string, 0, len(nodes))
+	for _, server := range c.metaServers {
+		if latest[server] {
+			servers = append(servers, server)
+			delete(latest, server)
+		}
+	}
+	for i := range nodes {
+		if latest[nodes[i].RPCAddr] {
+			servers = append(servers, nodes[i].RPCAddr)
+			delete(latest, nodes[i].RPCAddr)
+		}
+	}
+	if Peers(servers).equal(c.metaServers) {
+		return
+	}
+
+	c.logger.Info("meta servers changed", zap.Strings("old", c.metaServers), zap.Strings("new", servers))
+	c.metaServers = servers
+	for i, server := range servers {
+		transport.NewMetaNodeManager().Reset(uint64(i), server)
 	}
 }
 
@@ -389,8 +439,8 @@ func (c *Client) DataNodes() ([]meta2.DataNode, error) {
 	return c.cacheData.DataNodes, nil
 }
 
-// CreateDataNode will create a new data node in the metastore
-func (c *Client) CreateDataNode(writeHost, queryHost string) (uint64, uint64, error) {
+// CreateDataNode will create a new data node in the zone and the rack in the metastore
+func (c *Client) CreateDataNode(writeHost, queryHost, zone, rack string) (uint64, uint64, error) {
 	currentServer := connectedServer
 	for {
 		// exit if we're closed
@@ -406,7 +456,7 @@ func (c *Client) CreateDataNode(writeHost, queryHost string) (uint64, uint64, er
 		}
 		c.mu.RUnlock()
 
-		node, err := c.getNode(currentServer, writeHost, queryHost)
+		node, err := c.getNode(currentServer, writeHost, queryHost, zone, rack)
 
 		if err == nil && node.NodeId > 0 {
 			c.nodeID = node.NodeId
@@ -431,6 +481,13 @@ func (c *Client) AddPt(ptId uint32) {
 	c.ptIds = append(c.ptIds, ptId)
 }
 
+// PtDurationInfos returns the duration infos of the shards of the pt
+func (c *Client) PtDurationInfos(ptId uint32) map[uint64]*meta2.ShardDurationInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cacheData.GetDurationInfos([]uint32{ptId})
+}
+
 // DataNodeByHTTPHost returns the data node with the give http bind address
 func (c *Client) DataNodeByHTTPHost(httpAddr string) (*meta2.DataNode, error) {
 	nodes, err := c.DataNodes()
@@ -470,6 +527,67 @@ func (c *Client) DeleteDataNode(id uint64) error {
 	return c.retryUntilExec(proto2.Command_DeleteDataNodeCommand, proto2.E_DeleteDataNodeCommand_Command, cmd)
 }
 
+// DecommissionDataNode moves the pts of the data node to the other nodes, the node is deleted when it owns no pt.
+func (c *Client) DecommissionDataNode(id uint64) error {
+	cmd := &proto2.DecommissionDataNodeCommand{
+		ID: proto.Uint64(id),
+	}
+
+	return c.retryUntilExec(proto2.Command_DecommissionDataNodeCommand, proto2.E_DecommissionDataNodeCommand_Command, cmd)
+}
+
+// SetBalancer pauses or resumes the balancer moving pts between the data nodes.
+func (c *Client) SetBalancer(paused, dryRun bool) error {
+	cmd := &proto2.SetBalancerCommand{
+		Paused: proto.Bool(paused),
+		DryRun: proto.Bool(dryRun),
+	}
+
+	return c.retryUntilExec(proto2.Command_SetBalancerCommand, proto2.E_SetBalancerCommand_Command, cmd)
+}
+
+// SetReplicationPaused pauses or resumes shipping the replicated writes on all the SQL nodes.
+func (c *Client) SetReplicationPaused(paused bool) error {
+	cmd := &proto2.SetReplicationPausedCommand{
+		Paused: proto.Bool(paused),
+	}
+
+	return c.retryUntilExec(proto2.Command_SetReplicationPausedCommand, proto2.E_SetReplicationPausedCommand_Command, cmd)
+}
+
+// ReplicationPaused returns true if shipping the replicated writes is paused.
+func (c *Client) ReplicationPaused() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cacheData.ReplicationPaused
+}
+
+// MigrateEvents returns the events assigning and moving pts, ordered by their operation ids.
+func (c *Client) MigrateEvents() []*meta2.MigrateEventInfo {
+	c.mu.RLock()
+	events := make([]*meta2.MigrateEventInfo, 0, len(c.cacheData.MigrateEvents))
+	for _, e := range c.cacheData.MigrateEvents {
+		events = append(events, e.Clone())
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].GetOpId() < events[j].GetOpId()
+	})
+	return events
+}
+
+// MigratePt moves the pt of the database to the data node.
+func (c *Client) MigratePt(database string, pt uint32, nodeID uint64) error {
+	cmd := &proto2.MigratePtCommand{
+		Database: proto.String(database),
+		PtId:     proto.Uint32(pt),
+		NodeID:   proto.Uint64(nodeID),
+	}
+
+	return c.retryUntilExec(proto2.Command_MigratePtCommand, proto2.E_MigratePtCommand_Command, cmd)
+}
+
 // MetaNodes returns the meta nodes' info.
 func (c *Client) MetaNodes() ([]meta2.NodeInfo, error) {
 	c.mu.RLock()
@@ -646,7 +764,7 @@ func (c *Client) UpdateSchema(database string, retentionPolicy string, mst strin
 	return nil
 }
 
-func (c *Client) CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation) (*meta2.MeasurementInfo, error) {
+func (c *Client) CreateMeasurement(database string, retentionPolicy string, mst string, shardKey *meta2.ShardKeyInfo, indexR *meta2.IndexRelation, floatCodec string) (*meta2.MeasurementInfo, error) {
 	msti, err := c.Measurement(database, retentionPolicy, mst)
 	if msti != nil {
 		return msti, nil
@@ -665,6 +783,10 @@ func (c *Client) CreateMeasurement(database string, retentionPolicy string, mst
 		cmd.Ski = shardKey.Marshal()
 	}
 
+	if floatCodec != "" {
+		cmd.FloatCodec = proto.String(floatCodec)
+	}
+
 	if indexR != nil {
 		if msti == nil {
 			indexR.Rid = 0
@@ -696,6 +818,27 @@ func (c *Client) AlterShardKey(database, retentionPolicy, mst string, shardKey *
 	return c.retryUntilExec(proto2.Command_AlterShardKeyCmd, proto2.E_AlterShardKeyCmd_Command, cmd)
 }
 
+// AlterMeasurementTTL sets the ttl of the measurement, zero clears it
+func (c *Client) AlterMeasurementTTL(database, retentionPolicy, mst string, ttl time.Duration) error {
+	if ttl < 0 {
+		return meta2.ErrInvalidTTL
+	}
+
+	_, err := c.Measurement(database, retentionPolicy, mst)
+	if err != nil {
+		return err
+	}
+
+	cmd := &proto2.AlterMeasurementTTLCommand{
+		DBName: proto.String(database),
+		RpName: proto.String(retentionPolicy),
+		Name:   proto.String(mst),
+		TTL:    proto.Int64(int64(ttl)),
+	}
+
+	return c.retryUntilExec(proto2.Command_AlterMeasurementTTLCommand, proto2.E_AlterMeasurementTTLCommand_Command, cmd)
+}
+
 // CreateDatabase creates a database or returns it if it already exists.
 func (c *Client) CreateDatabase(name string) (*meta2.DatabaseInfo, error) {
 	if strings.Count(name, "") > maxDbOrRpName {
@@ -1147,6 +1290,13 @@ func (c *Client) CompareHashAndPlainPwd(hashed, plaintext string) error {
 	}
 }
 
+// CompareHashAndPlainPwd compares a hashed password with its plaintext without a connected client,
+// it is used by the meta nodes to authenticate the requests of the admin api.
+func CompareHashAndPlainPwd(hashed, plaintext string) error {
+	c := &Client{logger: logger.NewLogger(errno.ModuleMetaClient).With(zap.String("service", "metaclient"))}
+	return c.CompareHashAndPlainPwd(hashed, plaintext)
+}
+
 // CreateUser adds a user with the given name and password and admin status.
 func (c *Client) CreateUser(name, password string, admin, rwuser bool) (meta2.User, error) {
 	// verify name length
@@ -1809,6 +1959,17 @@ func (c *Client) UpdateShardInfoTier(shardID uint64, tier uint64, dbName, rpName
 	return nil
 }
 
+// UpdateNodeDiskStatus reports the disk watermark status of this data node,
+// the meta avoids to assign new pts to the nodes above the high watermark.
+func (c *Client) UpdateNodeDiskStatus(status meta2.DiskStatus) error {
+	cmd := &proto2.UpdateNodeDiskStatusCommand{
+		ID:         proto.Uint64(c.nodeID),
+		DiskStatus: proto.Int32(int32(status)),
+	}
+	_, err := c.retryExec(proto2.Command_UpdateNodeDiskStatusCommand, proto2.E_UpdateNodeDiskStatusCommand_Command, cmd)
+	return err
+}
+
 // ShardOwner returns the owning shard group info for a specific shard.
 func (c *Client) ShardOwner(shardID uint64) (database, policy string, sgi *meta2.ShardGroupInfo) {
 	c.mu.RLock()
@@ -2093,6 +2254,7 @@ func (c *Client) pollForUpdates() {
 		if idx < data.Index {
 			c.cacheData = data
 			c.updateAuthCache()
+			c.refreshMetaServers()
 			for len(c.changed) > 0 {
 				notifyC := <-c.changed
 				close(notifyC)
@@ -2102,11 +2264,12 @@ func (c *Client) pollForUpdates() {
 	}
 }
 
-func (c *Client) getNode(currentServer int, writeHost, queryHost string) (*meta2.NodeStartInfo, error) {
+func (c *Client) getNode(currentServer int, writeHost, queryHost, zone, rack string) (*meta2.NodeStartInfo, error) {
 	callback := &CreateNodeCallback{
 		NodeStartInfo: &meta2.NodeStartInfo{},
 	}
-	msg := message.NewMetaMessage(message.CreateNodeRequestMessage, &message.CreateNodeRequest{WriteHost: writeHost, QueryHost: queryHost})
+	msg := message.NewMetaMessage(message.CreateNodeRequestMessage, &message.CreateNodeRequest{WriteHost: writeHost, QueryHost: queryHost,
+		Zone: zone, Rack: rack})
 	err := c.SendRPCMsg(currentServer, msg, callback)
 	if err != nil {
 		return nil, err
@@ -2171,6 +2334,53 @@ func (c *Client) SendRPCMsg(currentServer int, msg *message.MetaMessage, callbac
 	return nil
 }
 
+// MetaIndex returns the largest index of the meta data served by the meta servers, a meta node joining the cluster
+// as a learner waits for its own data to reach the index before it is promoted to a voter
+func (c *Client) MetaIndex() uint64 {
+	var index uint64
+	for currentServer := range c.metaServers {
+		data, err := c.getSnapshot(currentServer, 0)
+		if err != nil || data == nil {
+			continue
+		}
+		if data.Index > index {
+			index = data.Index
+		}
+	}
+	return index
+}
+
+// MetaNodeStats returns the statistics or the diagnostics of the meta servers in line protocol, keyed by the
+// address of the meta server. Only the meta node of the id is asked when nodeID is not 0
+func (c *Client) MetaNodeStats(mod string, nodeID uint64) (map[string][]byte, error) {
+	c.mu.RLock()
+	servers := append([]string(nil), c.metaServers...)
+	var addr string
+	for i := range c.cacheData.MetaNodes {
+		if c.cacheData.MetaNodes[i].ID == nodeID {
+			addr = c.cacheData.MetaNodes[i].RPCAddr
+		}
+	}
+	c.mu.RUnlock()
+	if nodeID != 0 && addr == "" {
+		return nil, meta2.ErrNodeNotFound
+	}
+
+	ret := make(map[string][]byte, len(servers))
+	for currentServer, server := range servers {
+		if addr != "" && server != addr {
+			continue
+		}
+		callback := &StatsCallback{}
+		msg := message.NewMetaMessage(message.StatsRequestMessage, &message.StatsRequest{Mod: mod})
+		if err := c.SendRPCMsg(currentServer, msg, callback); err != nil {
+			return nil, fmt.Errorf("%s: %v", server, err)
+		}
+		ret[server] = callback.Data
+	}
+	return ret, nil
+}
+
 // Peers returns the TCPHost addresses of all the metaservers
 func (c *Client) Peers() []string {
 
@@ -2353,7 +2563,8 @@ func (c *Client) InitMetaClient(joinPeers []string, tlsEn bool, storageNodeInfo
 	var err error
 	var clock uint64
 	if storageNodeInfo != nil {
-		nid, clock, err = c.CreateDataNode(storageNodeInfo.InsertAddr, storageNodeInfo.SelectAddr)
+		nid, clock, err = c.CreateDataNode(storageNodeInfo.InsertAddr, storageNodeInfo.SelectAddr,
+			storageNodeInfo.Zone, storageNodeInfo.Rack)
 	}
 
 	return nid, clock, err
@@ -2500,6 +2711,18 @@ func (peers Peers) Append(p ...string) Peers {
 	return peers.Unique()
 }
 
+func (peers Peers) equal(other []string) bool {
+	if len(peers) != len(other) {
+		return false
+	}
+	for i := range peers {
+		if peers[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func (peers Peers) Unique() Peers {
 	distinct := map[string]struct{}{}
 	for _, p := range peers {
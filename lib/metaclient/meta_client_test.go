@@ -202,7 +202,7 @@ func TestClient_BasicFunctions(t *testing.T) {
 			ClusterID:    1,
 			DataNodes: []meta2.DataNode{
 				meta2.DataNode{
-					meta2.NodeInfo{
+					NodeInfo: meta2.NodeInfo{
 						ID:      1,
 						Host:    "127.0.0.1:8090",
 						TCPHost: "127.0.0.1:8091",
@@ -333,3 +333,29 @@ func TestClient_CreateDatabaseWithRetentionPolicy2(t *testing.T) {
 	_, err := c.CreateDatabaseWithRetentionPolicy("test", spec, ski)
 	require.EqualError(t, err, "shard key conflict")
 }
+
+func TestClient_refreshMetaServers(t *testing.T) {
+	c := &Client{
+		logger:      logger.NewLogger(errno.ModuleMetaClient),
+		metaServers: []string{"127.0.0.1:8092", "127.0.0.2:8092", "127.0.0.3:8092"},
+		cacheData:   &meta2.Data{},
+	}
+
+	// no meta nodes registered yet
+	c.refreshMetaServers()
+	require.Equal(t, []string{"127.0.0.1:8092", "127.0.0.2:8092", "127.0.0.3:8092"}, c.metaServers)
+
+	// the meta node 2 is replaced by the meta node 4
+	c.cacheData.MetaNodes = []meta2.NodeInfo{
+		{ID: 1, RPCAddr: "127.0.0.1:8092"},
+		{ID: 3, RPCAddr: "127.0.0.3:8092"},
+		{ID: 4, RPCAddr: "127.0.0.4:8092"},
+	}
+	c.refreshMetaServers()
+	require.Equal(t, []string{"127.0.0.1:8092", "127.0.0.3:8092", "127.0.0.4:8092"}, c.metaServers)
+
+	// the meta nodes of old versions have no rpc address
+	c.cacheData.MetaNodes = append(c.cacheData.MetaNodes, meta2.NodeInfo{ID: 5})
+	c.refreshMetaServers()
+	require.Equal(t, []string{"127.0.0.1:8092", "127.0.0.3:8092", "127.0.0.4:8092"}, c.metaServers)
+}
@@ -806,6 +806,384 @@ func (m *ExactCardinalityResponse) GetErr() string {
 	return ""
 }
 
+type MigratePtRequest struct {
+	Db                   *string  `protobuf:"bytes,1,req,name=Db" json:"Db,omitempty"`
+	Pt                   *uint32  `protobuf:"varint,2,req,name=Pt" json:"Pt,omitempty"`
+	Phase                *int32   `protobuf:"varint,3,req,name=Phase" json:"Phase,omitempty"`
+	Src                  *uint64  `protobuf:"varint,4,opt,name=Src" json:"Src,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigratePtRequest) Reset()         { *m = MigratePtRequest{} }
+func (m *MigratePtRequest) String() string { return proto.CompactTextString(m) }
+func (*MigratePtRequest) ProtoMessage()    {}
+func (*MigratePtRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{15}
+}
+func (m *MigratePtRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigratePtRequest.Unmarshal(m, b)
+}
+func (m *MigratePtRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigratePtRequest.Marshal(b, m, deterministic)
+}
+func (m *MigratePtRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigratePtRequest.Merge(m, src)
+}
+func (m *MigratePtRequest) XXX_Size() int {
+	return xxx_messageInfo_MigratePtRequest.Size(m)
+}
+func (m *MigratePtRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigratePtRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigratePtRequest proto.InternalMessageInfo
+
+func (m *MigratePtRequest) GetDb() string {
+	if m != nil && m.Db != nil {
+		return *m.Db
+	}
+	return ""
+}
+
+func (m *MigratePtRequest) GetPt() uint32 {
+	if m != nil && m.Pt != nil {
+		return *m.Pt
+	}
+	return 0
+}
+
+func (m *MigratePtRequest) GetPhase() int32 {
+	if m != nil && m.Phase != nil {
+		return *m.Phase
+	}
+	return 0
+}
+
+func (m *MigratePtRequest) GetSrc() uint64 {
+	if m != nil && m.Src != nil {
+		return *m.Src
+	}
+	return 0
+}
+
+type MigratePtResponse struct {
+	State                *int32   `protobuf:"varint,1,opt,name=State" json:"State,omitempty"`
+	Bytes                *int64   `protobuf:"varint,2,opt,name=Bytes" json:"Bytes,omitempty"`
+	Err                  *string  `protobuf:"bytes,3,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigratePtResponse) Reset()         { *m = MigratePtResponse{} }
+func (m *MigratePtResponse) String() string { return proto.CompactTextString(m) }
+func (*MigratePtResponse) ProtoMessage()    {}
+func (*MigratePtResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{16}
+}
+func (m *MigratePtResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigratePtResponse.Unmarshal(m, b)
+}
+func (m *MigratePtResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigratePtResponse.Marshal(b, m, deterministic)
+}
+func (m *MigratePtResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigratePtResponse.Merge(m, src)
+}
+func (m *MigratePtResponse) XXX_Size() int {
+	return xxx_messageInfo_MigratePtResponse.Size(m)
+}
+func (m *MigratePtResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigratePtResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigratePtResponse proto.InternalMessageInfo
+
+func (m *MigratePtResponse) GetState() int32 {
+	if m != nil && m.State != nil {
+		return *m.State
+	}
+	return 0
+}
+
+func (m *MigratePtResponse) GetBytes() int64 {
+	if m != nil && m.Bytes != nil {
+		return *m.Bytes
+	}
+	return 0
+}
+
+func (m *MigratePtResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type PtFilesRequest struct {
+	Db                   *string  `protobuf:"bytes,1,req,name=Db" json:"Db,omitempty"`
+	Pt                   *uint32  `protobuf:"varint,2,req,name=Pt" json:"Pt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PtFilesRequest) Reset()         { *m = PtFilesRequest{} }
+func (m *PtFilesRequest) String() string { return proto.CompactTextString(m) }
+func (*PtFilesRequest) ProtoMessage()    {}
+func (*PtFilesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{17}
+}
+func (m *PtFilesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PtFilesRequest.Unmarshal(m, b)
+}
+func (m *PtFilesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PtFilesRequest.Marshal(b, m, deterministic)
+}
+func (m *PtFilesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PtFilesRequest.Merge(m, src)
+}
+func (m *PtFilesRequest) XXX_Size() int {
+	return xxx_messageInfo_PtFilesRequest.Size(m)
+}
+func (m *PtFilesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PtFilesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PtFilesRequest proto.InternalMessageInfo
+
+func (m *PtFilesRequest) GetDb() string {
+	if m != nil && m.Db != nil {
+		return *m.Db
+	}
+	return ""
+}
+
+func (m *PtFilesRequest) GetPt() uint32 {
+	if m != nil && m.Pt != nil {
+		return *m.Pt
+	}
+	return 0
+}
+
+type PtFile struct {
+	Name                 *string  `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
+	Length               *int64   `protobuf:"varint,2,req,name=Length" json:"Length,omitempty"`
+	ModTime              *int64   `protobuf:"varint,3,opt,name=ModTime" json:"ModTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PtFile) Reset()         { *m = PtFile{} }
+func (m *PtFile) String() string { return proto.CompactTextString(m) }
+func (*PtFile) ProtoMessage()    {}
+func (*PtFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{18}
+}
+func (m *PtFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PtFile.Unmarshal(m, b)
+}
+func (m *PtFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PtFile.Marshal(b, m, deterministic)
+}
+func (m *PtFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PtFile.Merge(m, src)
+}
+func (m *PtFile) XXX_Size() int {
+	return xxx_messageInfo_PtFile.Size(m)
+}
+func (m *PtFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_PtFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PtFile proto.InternalMessageInfo
+
+func (m *PtFile) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *PtFile) GetLength() int64 {
+	if m != nil && m.Length != nil {
+		return *m.Length
+	}
+	return 0
+}
+
+func (m *PtFile) GetModTime() int64 {
+	if m != nil && m.ModTime != nil {
+		return *m.ModTime
+	}
+	return 0
+}
+
+type PtFilesResponse struct {
+	Files                []*PtFile `protobuf:"bytes,1,rep,name=Files" json:"Files,omitempty"`
+	Err                  *string   `protobuf:"bytes,2,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PtFilesResponse) Reset()         { *m = PtFilesResponse{} }
+func (m *PtFilesResponse) String() string { return proto.CompactTextString(m) }
+func (*PtFilesResponse) ProtoMessage()    {}
+func (*PtFilesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{19}
+}
+func (m *PtFilesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PtFilesResponse.Unmarshal(m, b)
+}
+func (m *PtFilesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PtFilesResponse.Marshal(b, m, deterministic)
+}
+func (m *PtFilesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PtFilesResponse.Merge(m, src)
+}
+func (m *PtFilesResponse) XXX_Size() int {
+	return xxx_messageInfo_PtFilesResponse.Size(m)
+}
+func (m *PtFilesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PtFilesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PtFilesResponse proto.InternalMessageInfo
+
+func (m *PtFilesResponse) GetFiles() []*PtFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+func (m *PtFilesResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
+type ReadPtFileRequest struct {
+	Db                   *string  `protobuf:"bytes,1,req,name=Db" json:"Db,omitempty"`
+	Pt                   *uint32  `protobuf:"varint,2,req,name=Pt" json:"Pt,omitempty"`
+	Name                 *string  `protobuf:"bytes,3,req,name=Name" json:"Name,omitempty"`
+	Offset               *int64   `protobuf:"varint,4,req,name=Offset" json:"Offset,omitempty"`
+	Length               *int64   `protobuf:"varint,5,req,name=Length" json:"Length,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadPtFileRequest) Reset()         { *m = ReadPtFileRequest{} }
+func (m *ReadPtFileRequest) String() string { return proto.CompactTextString(m) }
+func (*ReadPtFileRequest) ProtoMessage()    {}
+func (*ReadPtFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{20}
+}
+func (m *ReadPtFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadPtFileRequest.Unmarshal(m, b)
+}
+func (m *ReadPtFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadPtFileRequest.Marshal(b, m, deterministic)
+}
+func (m *ReadPtFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadPtFileRequest.Merge(m, src)
+}
+func (m *ReadPtFileRequest) XXX_Size() int {
+	return xxx_messageInfo_ReadPtFileRequest.Size(m)
+}
+func (m *ReadPtFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadPtFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadPtFileRequest proto.InternalMessageInfo
+
+func (m *ReadPtFileRequest) GetDb() string {
+	if m != nil && m.Db != nil {
+		return *m.Db
+	}
+	return ""
+}
+
+func (m *ReadPtFileRequest) GetPt() uint32 {
+	if m != nil && m.Pt != nil {
+		return *m.Pt
+	}
+	return 0
+}
+
+func (m *ReadPtFileRequest) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *ReadPtFileRequest) GetOffset() int64 {
+	if m != nil && m.Offset != nil {
+		return *m.Offset
+	}
+	return 0
+}
+
+func (m *ReadPtFileRequest) GetLength() int64 {
+	if m != nil && m.Length != nil {
+		return *m.Length
+	}
+	return 0
+}
+
+type ReadPtFileResponse struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=Data" json:"Data,omitempty"`
+	Err                  *string  `protobuf:"bytes,2,opt,name=Err" json:"Err,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReadPtFileResponse) Reset()         { *m = ReadPtFileResponse{} }
+func (m *ReadPtFileResponse) String() string { return proto.CompactTextString(m) }
+func (*ReadPtFileResponse) ProtoMessage()    {}
+func (*ReadPtFileResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{21}
+}
+func (m *ReadPtFileResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReadPtFileResponse.Unmarshal(m, b)
+}
+func (m *ReadPtFileResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReadPtFileResponse.Marshal(b, m, deterministic)
+}
+func (m *ReadPtFileResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadPtFileResponse.Merge(m, src)
+}
+func (m *ReadPtFileResponse) XXX_Size() int {
+	return xxx_messageInfo_ReadPtFileResponse.Size(m)
+}
+func (m *ReadPtFileResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadPtFileResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReadPtFileResponse proto.InternalMessageInfo
+
+func (m *ReadPtFileResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ReadPtFileResponse) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*SeriesKeysRequest)(nil), "internal.SeriesKeysRequest")
 	proto.RegisterType((*SeriesKeysResponse)(nil), "internal.SeriesKeysResponse")
@@ -825,53 +1203,71 @@ func init() {
 	proto.RegisterType((*TagValuesSlice)(nil), "internal.TagValuesSlice")
 	proto.RegisterType((*ExactCardinalityResponse)(nil), "internal.ExactCardinalityResponse")
 	proto.RegisterMapType((map[string]uint64)(nil), "internal.ExactCardinalityResponse.CardinalityEntry")
+	proto.RegisterType((*MigratePtRequest)(nil), "internal.MigratePtRequest")
+	proto.RegisterType((*MigratePtResponse)(nil), "internal.MigratePtResponse")
+	proto.RegisterType((*PtFilesRequest)(nil), "internal.PtFilesRequest")
+	proto.RegisterType((*PtFile)(nil), "internal.PtFile")
+	proto.RegisterType((*PtFilesResponse)(nil), "internal.PtFilesResponse")
+	proto.RegisterType((*ReadPtFileRequest)(nil), "internal.ReadPtFileRequest")
+	proto.RegisterType((*ReadPtFileResponse)(nil), "internal.ReadPtFileResponse")
 }
 
 func init() { proto.RegisterFile("data.proto", fileDescriptor_871986018790d2fd) }
 
 var fileDescriptor_871986018790d2fd = []byte{
-	// 674 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xdf, 0x6e, 0xd3, 0x3e,
-	0x14, 0x56, 0x9c, 0xb6, 0x5b, 0x4f, 0xb7, 0xfe, 0xf6, 0xb3, 0xb6, 0xc9, 0x2a, 0x08, 0x45, 0x41,
-	0x48, 0x11, 0x17, 0x11, 0x1a, 0x37, 0x1b, 0x88, 0x5d, 0xb4, 0x9d, 0xa6, 0x09, 0x55, 0x1a, 0xee,
-	0xe0, 0x02, 0x24, 0x24, 0x6f, 0xb1, 0xb6, 0x68, 0x59, 0x12, 0x6c, 0x17, 0x16, 0xf1, 0x06, 0xdc,
-	0x71, 0xc5, 0x15, 0x4f, 0xc3, 0x8b, 0xa1, 0xd8, 0x4e, 0x93, 0x96, 0x55, 0x68, 0xdc, 0xf9, 0x7c,
-	0x3e, 0x7f, 0x3e, 0x9f, 0xf3, 0x1d, 0x03, 0x44, 0x4c, 0xb1, 0x30, 0x17, 0x99, 0xca, 0xf0, 0x7a,
-	0x9c, 0x2a, 0x2e, 0x52, 0x96, 0xf8, 0x5f, 0xe1, 0xff, 0x29, 0x17, 0x31, 0x97, 0xaf, 0x79, 0x21,
-	0x29, 0xff, 0x34, 0xe3, 0x52, 0xe1, 0x3e, 0xa0, 0xf1, 0x39, 0x71, 0x3c, 0x14, 0x74, 0x29, 0x1a,
-	0x9f, 0xe3, 0x6d, 0x68, 0x9f, 0xaa, 0x93, 0xb1, 0x24, 0xc8, 0x73, 0x83, 0x4d, 0x6a, 0x0c, 0xec,
-	0xc3, 0xc6, 0x84, 0x33, 0x39, 0x13, 0xfc, 0x86, 0xa7, 0x4a, 0x12, 0xd7, 0x73, 0x83, 0x2e, 0x5d,
-	0xc0, 0xf0, 0x43, 0xe8, 0x5e, 0x64, 0x69, 0x14, 0xab, 0x38, 0x4b, 0x49, 0xcb, 0x73, 0x82, 0x2e,
-	0xad, 0x01, 0xff, 0x10, 0x70, 0xb3, 0xb8, 0xcc, 0xb3, 0x54, 0x72, 0xbc, 0x0b, 0x1d, 0x83, 0x12,
-	0x47, 0x67, 0xb4, 0x16, 0xde, 0x02, 0xf7, 0x48, 0x08, 0x82, 0x74, 0x96, 0xf2, 0xe8, 0x1f, 0xc3,
-	0xce, 0x48, 0x70, 0xa6, 0xf8, 0x98, 0x29, 0x36, 0x64, 0x92, 0xaf, 0x7a, 0x40, 0x1f, 0x50, 0xae,
-	0x08, 0xf2, 0x50, 0xb0, 0x49, 0x51, 0xae, 0xef, 0x45, 0x4e, 0x5c, 0x73, 0x2f, 0x72, 0xff, 0x29,
-	0xec, 0x2e, 0x27, 0xb2, 0x64, 0x6c, 0x51, 0xa7, 0x2e, 0xfa, 0xc3, 0x81, 0xfe, 0xb4, 0x90, 0x23,
-	0x25, 0x92, 0xaa, 0xdc, 0x16, 0xb8, 0x93, 0x2c, 0xb2, 0xf5, 0xca, 0x23, 0x3e, 0x80, 0xf6, 0x29,
-	0x13, 0xec, 0x46, 0x77, 0xac, 0xb7, 0xf7, 0x38, 0xac, 0x1a, 0x1e, 0x2e, 0x86, 0x86, 0xda, 0xeb,
-	0x28, 0x55, 0xa2, 0xa0, 0x26, 0x62, 0xb0, 0x0f, 0x50, 0x83, 0x65, 0xea, 0x6b, 0x5e, 0x54, 0xf5,
-	0xaf, 0x79, 0x51, 0x0e, 0xe3, 0x33, 0x4b, 0x66, 0xdc, 0x36, 0xc2, 0x18, 0x2f, 0xd0, 0xbe, 0xe3,
-	0xff, 0x74, 0xe0, 0xbf, 0x79, 0xfa, 0x65, 0xfe, 0xc8, 0xf2, 0xc7, 0xaf, 0xa0, 0x43, 0xb9, 0x9c,
-	0x25, 0xca, 0x72, 0x7b, 0x72, 0x07, 0x37, 0x13, 0x1c, 0x1a, 0x3f, 0xc3, 0xce, 0x06, 0x0d, 0x0e,
-	0xa0, 0xd7, 0x80, 0xef, 0xc5, 0x2f, 0x87, 0xc1, 0x31, 0x57, 0xd3, 0x2b, 0x26, 0xa2, 0x69, 0x9e,
-	0xc4, 0xea, 0x34, 0x8b, 0x53, 0xb5, 0x20, 0xba, 0xe1, 0x7c, 0x66, 0x43, 0x8c, 0xa1, 0x55, 0xea,
-	0xcc, 0x4e, 0x4d, 0x9f, 0x31, 0x81, 0x35, 0x1d, 0x7e, 0x32, 0xd6, 0xc3, 0x6b, 0xd1, 0xca, 0x2c,
-	0xab, 0x9e, 0x44, 0xb7, 0x5c, 0x92, 0x96, 0xe7, 0x06, 0x2e, 0x35, 0x86, 0xff, 0x06, 0x1e, 0xdc,
-	0x59, 0xd1, 0x36, 0xc7, 0x83, 0x5e, 0x03, 0xb6, 0x72, 0x6b, 0x42, 0x77, 0x68, 0xee, 0xbb, 0x03,
-	0x9b, 0x63, 0x9e, 0x70, 0xc5, 0x57, 0x11, 0xef, 0x03, 0xa2, 0xb9, 0x0d, 0x41, 0x34, 0xd7, 0xea,
-	0x90, 0x8a, 0xb8, 0x26, 0xc7, 0x44, 0x2a, 0x3c, 0x80, 0x75, 0xcb, 0xdb, 0xf0, 0x6d, 0xd1, 0xb9,
-	0x8d, 0x1f, 0x01, 0x98, 0xf4, 0x67, 0x45, 0xce, 0x49, 0xdb, 0x43, 0x41, 0x9b, 0x36, 0x10, 0xdb,
-	0x96, 0x88, 0x74, 0x3c, 0xc7, 0xb6, 0x25, 0xf2, 0x7d, 0xe8, 0x57, 0x94, 0x56, 0xca, 0xf6, 0x9b,
-	0x03, 0xdb, 0xd3, 0xab, 0xec, 0xcb, 0x19, 0xbb, 0x7c, 0x57, 0x4e, 0xe4, 0x9e, 0xcb, 0x1e, 0xc2,
-	0xda, 0x19, 0xbb, 0x2c, 0xf7, 0x54, 0xef, 0x79, 0x6f, 0x6f, 0xbb, 0x96, 0xcd, 0x84, 0xe5, 0xf6,
-	0x8e, 0x56, 0x4e, 0xe5, 0xe2, 0x8f, 0x96, 0x17, 0x7f, 0x0e, 0xf8, 0x1f, 0x60, 0x67, 0x89, 0xcb,
-	0x2a, 0xde, 0xf8, 0x19, 0x74, 0x8c, 0x8f, 0x95, 0x2b, 0xa9, 0xeb, 0xce, 0xc3, 0xa7, 0x49, 0x7c,
-	0xc1, 0xa9, 0xf5, 0xf3, 0x87, 0x00, 0x35, 0xa3, 0x72, 0xc6, 0x8d, 0x1f, 0xc9, 0xbe, 0xb3, 0x09,
-	0x95, 0x1d, 0xd5, 0xef, 0x42, 0x7a, 0xfc, 0xfa, 0xec, 0x7f, 0x84, 0xfe, 0x62, 0xf6, 0x7f, 0xcb,
-	0x53, 0xfe, 0x65, 0x96, 0xbd, 0xf9, 0x1d, 0x2b, 0x8e, 0xbf, 0x1c, 0x20, 0x47, 0xb7, 0xec, 0x42,
-	0x8d, 0x98, 0x88, 0xe2, 0x94, 0x25, 0xb1, 0x2a, 0xe6, 0x4d, 0x78, 0x0b, 0xbd, 0x06, 0xac, 0x65,
-	0xd9, 0xdb, 0x7b, 0x5e, 0xbf, 0x7b, 0x55, 0x60, 0xd8, 0xc0, 0xcc, 0xd2, 0x36, 0xf3, 0xfc, 0xa9,
-	0xe5, 0xc1, 0x21, 0x6c, 0x2d, 0x87, 0xfc, 0x6d, 0xa1, 0x5b, 0x8d, 0x85, 0x1e, 0x6e, 0xbc, 0x87,
-	0xf0, 0x65, 0x45, 0xeb, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0x22, 0x3a, 0xc2, 0x01, 0x61, 0x06,
-	0x00, 0x00,
+	// 862 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0x5b, 0x6f, 0x1b, 0x45,
+	0x14, 0xd6, 0xde, 0xdc, 0xe6, 0x38, 0x71, 0x9d, 0x51, 0x5a, 0xad, 0x0c, 0x42, 0xab, 0x41, 0x20,
+	0x8b, 0x07, 0xab, 0x0a, 0x2f, 0x6d, 0x11, 0x7d, 0x70, 0x1c, 0xaa, 0xa8, 0xb8, 0xb8, 0xe3, 0xc0,
+	0x43, 0x91, 0x90, 0xa6, 0xd9, 0xd3, 0x64, 0xd5, 0xcd, 0xee, 0x32, 0x33, 0xa1, 0xb5, 0xf8, 0x07,
+	0xbc, 0xf1, 0xc4, 0x13, 0xbf, 0x86, 0x3f, 0x86, 0xe6, 0xb2, 0x17, 0xbb, 0x36, 0xb4, 0x7d, 0x9b,
+	0xf3, 0xed, 0xb9, 0x7c, 0xe7, 0xba, 0x00, 0x29, 0x57, 0x7c, 0x52, 0x89, 0x52, 0x95, 0xe4, 0x76,
+	0x56, 0x28, 0x14, 0x05, 0xcf, 0xe9, 0xef, 0x70, 0xb8, 0x44, 0x91, 0xa1, 0x7c, 0x8a, 0x2b, 0xc9,
+	0xf0, 0xd7, 0x1b, 0x94, 0x8a, 0x0c, 0xc0, 0x9f, 0xbd, 0x8c, 0xbd, 0xc4, 0x1f, 0xef, 0x31, 0x7f,
+	0xf6, 0x92, 0x1c, 0x41, 0xb4, 0x50, 0x67, 0x33, 0x19, 0xfb, 0x49, 0x30, 0x3e, 0x60, 0x56, 0x20,
+	0x14, 0xf6, 0xe7, 0xc8, 0xe5, 0x8d, 0xc0, 0x6b, 0x2c, 0x94, 0x8c, 0x83, 0x24, 0x18, 0xef, 0xb1,
+	0x35, 0x8c, 0x7c, 0x0a, 0x7b, 0x17, 0x65, 0x91, 0x66, 0x2a, 0x2b, 0x8b, 0x38, 0x4c, 0xbc, 0xf1,
+	0x1e, 0x6b, 0x01, 0xfa, 0x18, 0x48, 0x37, 0xb8, 0xac, 0xca, 0x42, 0x22, 0xb9, 0x07, 0x3d, 0x8b,
+	0xc6, 0x9e, 0xf1, 0xe8, 0x24, 0x32, 0x84, 0xe0, 0x54, 0x88, 0xd8, 0x37, 0x5e, 0xf4, 0x93, 0x3e,
+	0x81, 0xbb, 0x27, 0x02, 0xb9, 0xc2, 0x19, 0x57, 0x7c, 0xca, 0x25, 0xee, 0x4a, 0x60, 0x00, 0x7e,
+	0xa5, 0x62, 0x3f, 0xf1, 0xc7, 0x07, 0xcc, 0xaf, 0xcc, 0x77, 0x51, 0xc5, 0x81, 0xfd, 0x2e, 0x2a,
+	0xfa, 0x15, 0xdc, 0xdb, 0x74, 0xe4, 0xc8, 0xb8, 0xa0, 0x5e, 0x1b, 0xf4, 0x2f, 0x0f, 0x06, 0xcb,
+	0x95, 0x3c, 0x51, 0x22, 0xaf, 0xc3, 0x0d, 0x21, 0x98, 0x97, 0xa9, 0x8b, 0xa7, 0x9f, 0xe4, 0x21,
+	0x44, 0x0b, 0x2e, 0xf8, 0xb5, 0xa9, 0x58, 0xff, 0xf8, 0xf3, 0x49, 0x5d, 0xf0, 0xc9, 0xba, 0xe9,
+	0xc4, 0x68, 0x9d, 0x16, 0x4a, 0xac, 0x98, 0xb5, 0x18, 0x3d, 0x00, 0x68, 0x41, 0xed, 0xfa, 0x35,
+	0xae, 0xea, 0xf8, 0xaf, 0x71, 0xa5, 0x9b, 0xf1, 0x1b, 0xcf, 0x6f, 0xd0, 0x15, 0xc2, 0x0a, 0x8f,
+	0xfc, 0x07, 0x1e, 0xfd, 0xdb, 0x83, 0x3b, 0x8d, 0xfb, 0x4d, 0xfe, 0xbe, 0xe3, 0x4f, 0xbe, 0x85,
+	0x1e, 0x43, 0x79, 0x93, 0x2b, 0xc7, 0xed, 0x8b, 0x2d, 0xdc, 0xac, 0xf1, 0xc4, 0xea, 0x59, 0x76,
+	0xce, 0x68, 0xf4, 0x10, 0xfa, 0x1d, 0xf8, 0x83, 0xf8, 0x55, 0x30, 0x7a, 0x82, 0x6a, 0x79, 0xc5,
+	0x45, 0xba, 0xac, 0xf2, 0x4c, 0x2d, 0xca, 0xac, 0x50, 0x6b, 0x43, 0x37, 0x6d, 0x7a, 0x36, 0x25,
+	0x04, 0x42, 0x3d, 0x67, 0xae, 0x6b, 0xe6, 0x4d, 0x62, 0xb8, 0x65, 0xcc, 0xcf, 0x66, 0xa6, 0x79,
+	0x21, 0xab, 0x45, 0x1d, 0xf5, 0x2c, 0x7d, 0x8b, 0x32, 0x0e, 0x93, 0x60, 0x1c, 0x30, 0x2b, 0xd0,
+	0xe7, 0xf0, 0xc9, 0xd6, 0x88, 0xae, 0x38, 0x09, 0xf4, 0x3b, 0xb0, 0x1b, 0xb7, 0x2e, 0xb4, 0x65,
+	0xe6, 0xfe, 0xf4, 0xe0, 0x60, 0x86, 0x39, 0x2a, 0xdc, 0x45, 0x7c, 0x00, 0x3e, 0xab, 0x9c, 0x89,
+	0xcf, 0x2a, 0x33, 0x1d, 0x52, 0xc5, 0x81, 0xf5, 0x31, 0x97, 0x8a, 0x8c, 0xe0, 0xb6, 0xe3, 0x6d,
+	0xf9, 0x86, 0xac, 0x91, 0xc9, 0x67, 0x00, 0xd6, 0xfd, 0xf9, 0xaa, 0xc2, 0x38, 0x4a, 0xfc, 0x71,
+	0xc4, 0x3a, 0x88, 0x2b, 0x4b, 0x1a, 0xf7, 0x12, 0xcf, 0x95, 0x25, 0xa5, 0x14, 0x06, 0x35, 0xa5,
+	0x9d, 0x63, 0xfb, 0x87, 0x07, 0x47, 0xcb, 0xab, 0xf2, 0xcd, 0x39, 0xbf, 0xfc, 0x49, 0x77, 0xe4,
+	0x03, 0x97, 0x7d, 0x02, 0xb7, 0xce, 0xf9, 0xa5, 0xde, 0x53, 0xb3, 0xe7, 0xfd, 0xe3, 0xa3, 0x76,
+	0x6c, 0xe6, 0xbc, 0x72, 0xdf, 0x58, 0xad, 0xa4, 0x17, 0xff, 0x64, 0x73, 0xf1, 0x1b, 0x80, 0xfe,
+	0x0c, 0x77, 0x37, 0xb8, 0xec, 0xe2, 0x4d, 0xee, 0x43, 0xcf, 0xea, 0xb8, 0x71, 0x8d, 0xdb, 0xb8,
+	0x8d, 0xf9, 0x32, 0xcf, 0x2e, 0x90, 0x39, 0x3d, 0x3a, 0x05, 0x68, 0x19, 0xe9, 0x1e, 0x77, 0x2e,
+	0x92, 0xcb, 0xb3, 0x0b, 0xe9, 0x8a, 0x9a, 0xbc, 0x7c, 0xd3, 0x7e, 0xf3, 0xa6, 0xbf, 0xc0, 0x60,
+	0xdd, 0xfb, 0xc7, 0xf9, 0xd1, 0xb7, 0xcc, 0xb1, 0xb7, 0xd7, 0xb1, 0xe6, 0xf8, 0x8f, 0x07, 0xf1,
+	0xe9, 0x5b, 0x7e, 0xa1, 0x4e, 0xb8, 0x48, 0xb3, 0x82, 0xe7, 0x99, 0x5a, 0x35, 0x45, 0xf8, 0x11,
+	0xfa, 0x1d, 0xd8, 0x8c, 0x65, 0xff, 0xf8, 0xeb, 0x36, 0xef, 0x5d, 0x86, 0x93, 0x0e, 0x66, 0x97,
+	0xb6, 0xeb, 0xe7, 0xdd, 0x59, 0x1e, 0x3d, 0x86, 0xe1, 0xa6, 0xc9, 0xff, 0x2d, 0x74, 0xd8, 0x5d,
+	0xe8, 0x17, 0x30, 0x9c, 0x67, 0x97, 0x82, 0x2b, 0x5c, 0xa8, 0xff, 0x38, 0xbd, 0x8b, 0xe6, 0xf4,
+	0x2e, 0x94, 0x19, 0xaf, 0x2b, 0x2e, 0xd1, 0x2c, 0x70, 0xc4, 0xac, 0xa0, 0xa3, 0x2e, 0xc5, 0x85,
+	0x19, 0x94, 0x90, 0xe9, 0x27, 0x7d, 0x0e, 0x87, 0x1d, 0xdf, 0xae, 0x32, 0x47, 0x10, 0x2d, 0x15,
+	0x57, 0x68, 0xe8, 0x45, 0xcc, 0x0a, 0x1a, 0x9d, 0xae, 0x94, 0x99, 0x10, 0x4f, 0xef, 0xbe, 0x11,
+	0xea, 0x74, 0x83, 0x76, 0x05, 0xee, 0xc3, 0x60, 0xa1, 0xbe, 0xcb, 0x72, 0x94, 0xef, 0x49, 0x96,
+	0x3e, 0x83, 0x9e, 0xb5, 0xd0, 0xcd, 0x7d, 0xc6, 0xaf, 0xd1, 0xe9, 0x9a, 0xb7, 0x6e, 0xee, 0xf7,
+	0x58, 0x5c, 0xaa, 0x2b, 0x63, 0x11, 0x30, 0x27, 0xe9, 0x2b, 0x35, 0x2f, 0xd3, 0xf3, 0xec, 0x1a,
+	0x4d, 0xf4, 0x80, 0xd5, 0x22, 0x7d, 0x0a, 0x77, 0x1a, 0x06, 0x2e, 0xa5, 0x2f, 0x21, 0x32, 0x80,
+	0x6b, 0xf3, 0xb0, 0x6d, 0xb3, 0xd5, 0x64, 0xf6, 0xf3, 0x96, 0x4b, 0xf4, 0x06, 0x0e, 0x19, 0xf2,
+	0xd4, 0xa9, 0xbd, 0x67, 0xf9, 0xeb, 0x3c, 0x82, 0xf5, 0x3c, 0x7e, 0x78, 0xf5, 0x4a, 0xa2, 0x8a,
+	0x43, 0x9b, 0x87, 0x95, 0x3a, 0xf9, 0x45, 0xdd, 0xfc, 0xe8, 0x23, 0x20, 0xdd, 0xc0, 0x2e, 0x11,
+	0x02, 0xa1, 0xfe, 0x7b, 0x9a, 0xd6, 0xec, 0x33, 0xf3, 0x7e, 0x97, 0xf4, 0x74, 0xff, 0x05, 0x4c,
+	0xbe, 0xa9, 0x53, 0xfc, 0x77, 0x00, 0xdf, 0xb0, 0x93, 0xf9, 0x94, 0x08, 0x00, 0x00,
 }
@@ -98,6 +98,26 @@ type Engine interface {
 
 	UpdateShardDurationInfo(info *meta.ShardDurationInfo) error
 
-	SysCtrl(req *SysCtrlRequest) error
+	SysCtrl(req *SysCtrlRequest) (map[string]string, error)
 	Statistics(buffer []byte) ([]byte, error)
+
+	PtFiles(db string, ptId uint32) ([]PtFile, error)
+	ReadPtFile(db string, ptId uint32, name string, offset int64, size int) ([]byte, error)
+	FetchPt(db string, ptId uint32, src PtFileSource, final bool, fetched *int64) error
+	OffloadPt(db string, ptId uint32) error
+	LoadPt(db string, ptId uint32, durationInfos map[uint64]*meta.ShardDurationInfo) error
+	DropPt(db string, ptId uint32) error
+}
+
+// PtFile is a file of a db pt, the name is relative to the data or WAL dirs of the node
+type PtFile struct {
+	Name    string
+	Size    int64
+	ModTime int64
+}
+
+// PtFileSource reads the files of a db pt on the node the pt is moved from
+type PtFileSource interface {
+	PtFiles(db string, ptId uint32) ([]PtFile, error)
+	ReadPtFile(db string, ptId uint32, name string, offset int64, size int) ([]byte, error)
 }
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb/pkg/limiter"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
 )
 
 const (
@@ -54,10 +55,19 @@ type EngineOptions struct {
 	SnapshotThroughput       int64
 	SnapshotThroughputBurst  int64
 
+	// DataDirs and WalDirs are more directories for the shards besides the data path and the WAL path of the engine
+	DataDirs []string
+	WalDirs  []string
+
 	// WalSyncInterval is the interval of wal file sync
 	WalEnabled        bool
 	WalSyncInterval   time.Duration
 	WalReplayParallel bool
+	// WalRecordCrc writes the crc of every WAL record
+	WalRecordCrc bool
+	// WalSyncMode is the durability of WAL writes, WalSyncModes overrides it per database
+	WalSyncMode  string
+	WalSyncModes map[string]string
 
 	// Immutable config
 	ReadCacheLimit   int
@@ -65,6 +75,31 @@ type EngineOptions struct {
 	CacheMetaBlock   bool
 	EnableMmapRead   bool
 	CompactionMethod int // 0:auto, 1:stream, 2: non-stream
+
+	// LastValueCache keeps the newest value of every field of every series in memory
+	LastValueCache bool
+
+	// FloatCodec is the default codec of float columns
+	FloatCodec string
+	// StringDictionary encodes low cardinality string blocks with a dictionary
+	StringDictionary bool
+	// MeasurementFloatCodec returns the float codec set by CREATE MEASUREMENT, empty if not set.
+	// ok is false if the measurement is not known by the node yet
+	MeasurementFloatCodec func(db, rp, mst string) (codec string, ok bool)
+	// MeasurementTTL returns how long the rows of the measurement are kept, zero if the measurement sets no ttl
+	MeasurementTTL func(db, rp, mst string) time.Duration
+
+	// disk watermarks in percent, the check is disabled if DiskCheckInterval is 0
+	DiskLowWatermark   float64
+	DiskHighWatermark  float64
+	DiskFloodWatermark float64
+	DiskCheckInterval  time.Duration
+	// DiskStatusChanged is called when the disk status of the node changes
+	DiskStatusChanged func(status meta.DiskStatus)
+
+	// EncryptionKeyDir enables encryption at rest, EncryptionKeyID is the key of new files
+	EncryptionKeyDir string
+	EncryptionKeyID  string
 }
 
 func NewEngineOptions() EngineOptions {
@@ -150,6 +150,9 @@ func (s *SysCtrlRequest) Get(key string) (v string, ok bool) {
 	return
 }
 
+// SysCtrlErrorKey is the key of the error of a failed store node in the result of NetStorage.SendSysCtrlOnNode
+const SysCtrlErrorKey = "error"
+
 type SysCtrlResponse struct {
 	err    string
 	result map[string]string
@@ -199,6 +202,20 @@ func (s *SysCtrlResponse) SetResult(ret map[string]string) {
 	s.result = ret
 }
 
+// CompactionInfo is a queued or running compaction of a store node, the nodes return them
+// JSON encoded in the SysCtrlResponse result of the compactions request
+type CompactionInfo struct {
+	ID          uint64 `json:"id"`
+	ShardID     uint64 `json:"shard"`
+	Measurement string `json:"measurement"`
+	Type        string `json:"type"`
+	Level       uint16 `json:"level"`
+	Files       int    `json:"files"`
+	Size        int64  `json:"bytes"`
+	Running     bool   `json:"running"`
+	Elapsed     int64  `json:"elapsed"`
+}
+
 func (s *SysCtrlResponse) Instance() transport.Codec {
 	return &SysCtrlResponse{}
 }
@@ -50,6 +50,15 @@ const (
 
 	CreateDataBaseRequestMessage
 	CreateDatabaseResponseMessage
+
+	MigratePtRequestMessage
+	MigratePtResponseMessage
+
+	PtFilesRequestMessage
+	PtFilesResponseMessage
+
+	ReadPtFileRequestMessage
+	ReadPtFileResponseMessage
 )
 
 func NewMessage(typ uint8) codec.BinaryCodec {
@@ -86,6 +95,18 @@ func NewMessage(typ uint8) codec.BinaryCodec {
 		return &CreateDataBaseRequest{}
 	case CreateDatabaseResponseMessage:
 		return &CreateDataBaseResponse{}
+	case MigratePtRequestMessage:
+		return &MigratePtRequest{}
+	case MigratePtResponseMessage:
+		return &MigratePtResponse{}
+	case PtFilesRequestMessage:
+		return &PtFilesRequest{}
+	case PtFilesResponseMessage:
+		return &PtFilesResponse{}
+	case ReadPtFileRequestMessage:
+		return &ReadPtFileRequest{}
+	case ReadPtFileResponseMessage:
+		return &ReadPtFileResponse{}
 	default:
 		return nil
 	}
@@ -107,6 +128,12 @@ func GetResponseMessageType(typ uint8) uint8 {
 		return GetShardSplitPointsResponseMessage
 	case DeleteRequestMessage:
 		return DeleteResponseMessage
+	case MigratePtRequestMessage:
+		return MigratePtResponseMessage
+	case PtFilesRequestMessage:
+		return PtFilesResponseMessage
+	case ReadPtFileRequestMessage:
+		return ReadPtFileResponseMessage
 	default:
 		return UnknownMessage
 	}
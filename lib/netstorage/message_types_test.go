@@ -39,6 +39,9 @@ func TestMessageTypes(t *testing.T) {
 		store.ShowTagValuesCardinalityRequestMessage: {&store.ShowTagValuesCardinalityRequest{}, &store.ShowTagValuesCardinalityResponse{}},
 		store.GetShardSplitPointsRequestMessage:      {&store.GetShardSplitPointsRequest{}, &store.GetShardSplitPointsResponse{}},
 		store.DeleteRequestMessage:                   {&store.DeleteRequest{}, &store.DeleteResponse{}},
+		store.MigratePtRequestMessage:                {&store.MigratePtRequest{}, &store.MigratePtResponse{}},
+		store.PtFilesRequestMessage:                  {&store.PtFilesRequest{}, &store.PtFilesResponse{}},
+		store.ReadPtFileRequestMessage:               {&store.ReadPtFileRequest{}, &store.ReadPtFileResponse{}},
 	}
 
 	for typ, items := range data {
@@ -366,3 +366,149 @@ func (r *CreateDataBaseResponse) Error() error {
 	}
 	return fmt.Errorf("%s", *r.Err)
 }
+
+type MigratePtPhase int32
+
+const (
+	// MigrateFetch copies the files of the pt from the source node, it is sent to the target node
+	MigrateFetch MigratePtPhase = iota
+	// MigrateFetchFinal copies the files changed since the last fetch and loads the pt on the target node
+	MigrateFetchFinal
+	// MigrateStatus returns the state of the fetching on the target node
+	MigrateStatus
+	// MigrateOffload closes the pt on the source node, its files are kept for the target node
+	MigrateOffload
+	// MigrateLoad opens the offloaded pt again on the source node
+	MigrateLoad
+	// MigrateDrop closes the pt and removes its files
+	MigrateDrop
+)
+
+type MigratePtState int32
+
+const (
+	MigrateIdle MigratePtState = iota
+	MigrateRunning
+	MigrateDone
+	MigrateFailed
+)
+
+type MigratePtRequest struct {
+	internal2.MigratePtRequest
+}
+
+func (r *MigratePtRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&r.MigratePtRequest)
+}
+
+func (r *MigratePtRequest) UnmarshalBinary(buf []byte) error {
+	return proto.Unmarshal(buf, &r.MigratePtRequest)
+}
+
+func (r *MigratePtRequest) GetMigratePhase() MigratePtPhase {
+	return MigratePtPhase(r.GetPhase())
+}
+
+type MigratePtResponse struct {
+	internal2.MigratePtResponse
+}
+
+func (r *MigratePtResponse) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&r.MigratePtResponse)
+}
+
+func (r *MigratePtResponse) UnmarshalBinary(buf []byte) error {
+	return proto.Unmarshal(buf, &r.MigratePtResponse)
+}
+
+func (r *MigratePtResponse) GetMigrateState() MigratePtState {
+	return MigratePtState(r.GetState())
+}
+
+func (r *MigratePtResponse) Error() error {
+	if r.Err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s", *r.Err)
+}
+
+type PtFilesRequest struct {
+	internal2.PtFilesRequest
+}
+
+func (r *PtFilesRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&r.PtFilesRequest)
+}
+
+func (r *PtFilesRequest) UnmarshalBinary(buf []byte) error {
+	return proto.Unmarshal(buf, &r.PtFilesRequest)
+}
+
+type PtFilesResponse struct {
+	internal2.PtFilesResponse
+}
+
+func (r *PtFilesResponse) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&r.PtFilesResponse)
+}
+
+func (r *PtFilesResponse) UnmarshalBinary(buf []byte) error {
+	return proto.Unmarshal(buf, &r.PtFilesResponse)
+}
+
+func (r *PtFilesResponse) SetPtFiles(files []PtFile) {
+	r.Files = make([]*internal2.PtFile, len(files))
+	for i := range files {
+		r.Files[i] = &internal2.PtFile{
+			Name:    proto.String(files[i].Name),
+			Length:  proto.Int64(files[i].Size),
+			ModTime: proto.Int64(files[i].ModTime),
+		}
+	}
+}
+
+func (r *PtFilesResponse) GetPtFiles() []PtFile {
+	files := make([]PtFile, len(r.Files))
+	for i, f := range r.Files {
+		files[i] = PtFile{Name: f.GetName(), Size: f.GetLength(), ModTime: f.GetModTime()}
+	}
+	return files
+}
+
+func (r *PtFilesResponse) Error() error {
+	if r.Err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s", *r.Err)
+}
+
+type ReadPtFileRequest struct {
+	internal2.ReadPtFileRequest
+}
+
+func (r *ReadPtFileRequest) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&r.ReadPtFileRequest)
+}
+
+func (r *ReadPtFileRequest) UnmarshalBinary(buf []byte) error {
+	return proto.Unmarshal(buf, &r.ReadPtFileRequest)
+}
+
+type ReadPtFileResponse struct {
+	internal2.ReadPtFileResponse
+}
+
+func (r *ReadPtFileResponse) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(&r.ReadPtFileResponse)
+}
+
+func (r *ReadPtFileResponse) UnmarshalBinary(buf []byte) error {
+	return proto.Unmarshal(buf, &r.ReadPtFileResponse)
+}
+
+func (r *ReadPtFileResponse) Error() error {
+	if r.Err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s", *r.Err)
+}
@@ -64,6 +64,10 @@ type Storage interface {
 	DeleteDatabase(node *meta2.DataNode, database string, pt uint32) error
 	DeleteRetentionPolicy(node *meta2.DataNode, db string, rp string, pt uint32) error
 	DeleteMeasurement(node *meta2.DataNode, db string, rp string, name string, shardIds []uint64) error
+
+	MigratePt(node *meta2.DataNode, req *MigratePtRequest) (*MigratePtResponse, error)
+	PtFiles(nodeID uint64, db string, pt uint32) ([]PtFile, error)
+	ReadPtFile(nodeID uint64, db string, pt uint32, name string, offset int64, size int) ([]byte, error)
 }
 
 type NetStorage struct {
@@ -360,10 +364,86 @@ func (s *NetStorage) SendSysCtrlOnNode(nodeID uint64, req SysCtrlRequest) (map[s
 		return nil, executor.NewInvalidTypeError("*netstorage.SysCtrlResponse", v)
 	}
 
-	ret := map[string]string{r.node.TCPHost: "success"}
-	if resp.Error() != nil {
+	ret := make(map[string]string, len(resp.Result())+2)
+	for k, v := range resp.Result() {
+		ret[k] = v
+	}
+	ret[r.node.TCPHost] = "success"
+	if err = resp.Error(); err != nil {
 		ret[r.node.TCPHost] = "failure"
+		ret[SysCtrlErrorKey] = err.Error()
 	}
 
 	return ret, nil
 }
+
+func (s *NetStorage) MigratePt(node *meta2.DataNode, req *MigratePtRequest) (*MigratePtResponse, error) {
+	v, err := s.ddlRequestWithNode(node, MigratePtRequestMessage, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := v.(*MigratePtResponse)
+	if !ok {
+		return nil, executor.NewInvalidTypeError("*netstorage.MigratePtResponse", v)
+	}
+
+	return resp, resp.Error()
+}
+
+func (s *NetStorage) PtFiles(nodeID uint64, db string, pt uint32) ([]PtFile, error) {
+	req := &PtFilesRequest{}
+	req.Db = proto.String(db)
+	req.Pt = proto.Uint32(pt)
+
+	v, err := s.ddlRequestWithNodeId(nodeID, PtFilesRequestMessage, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := v.(*PtFilesResponse)
+	if !ok {
+		return nil, executor.NewInvalidTypeError("*netstorage.PtFilesResponse", v)
+	}
+
+	return resp.GetPtFiles(), resp.Error()
+}
+
+func (s *NetStorage) ReadPtFile(nodeID uint64, db string, pt uint32, name string, offset int64, size int) ([]byte, error) {
+	req := &ReadPtFileRequest{}
+	req.Db = proto.String(db)
+	req.Pt = proto.Uint32(pt)
+	req.Name = proto.String(name)
+	req.Offset = proto.Int64(offset)
+	req.Length = proto.Int64(int64(size))
+
+	v, err := s.ddlRequestWithNodeId(nodeID, ReadPtFileRequestMessage, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := v.(*ReadPtFileResponse)
+	if !ok {
+		return nil, executor.NewInvalidTypeError("*netstorage.ReadPtFileResponse", v)
+	}
+
+	return resp.Data, resp.Error()
+}
+
+type nodePtFileSource struct {
+	store  Storage
+	nodeID uint64
+}
+
+// NewPtFileSource returns the source reading the pt files from the node over the network
+func NewPtFileSource(store Storage, nodeID uint64) PtFileSource {
+	return &nodePtFileSource{store: store, nodeID: nodeID}
+}
+
+func (s *nodePtFileSource) PtFiles(db string, ptId uint32) ([]PtFile, error) {
+	return s.store.PtFiles(s.nodeID, db, ptId)
+}
+
+func (s *nodePtFileSource) ReadPtFile(db string, ptId uint32, name string, offset int64, size int) ([]byte, error) {
+	return s.store.ReadPtFile(s.nodeID, db, ptId, name, offset, size)
+}
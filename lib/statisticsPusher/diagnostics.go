@@ -0,0 +1,107 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statisticsPusher
+
+import (
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+)
+
+// modules reported by SHOW DIAGNOSTICS
+const (
+	DiagnosticsBuild   = "build"
+	DiagnosticsRuntime = "runtime"
+	DiagnosticsSystem  = "system"
+	DiagnosticsConfig  = "config"
+)
+
+// BuildInfo is the version information the binary is built with
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Branch  string
+	Time    string
+}
+
+type diagnostics struct {
+	mu      sync.RWMutex
+	tags    map[string]string
+	build   BuildInfo
+	config  map[string]interface{}
+	started time.Time
+}
+
+var diag = &diagnostics{started: time.Now()}
+
+// InitDiagnostics sets the tags, the build information and the main configuration items the process
+// reports in SHOW DIAGNOSTICS. The servers of a single process merge their configuration items
+func InitDiagnostics(tags map[string]string, build BuildInfo, conf map[string]interface{}) {
+	diag.mu.Lock()
+	defer diag.mu.Unlock()
+
+	diag.tags = tags
+	diag.build = build
+	if diag.config == nil {
+		diag.config = make(map[string]interface{}, len(conf))
+	}
+	for k, v := range conf {
+		diag.config[k] = v
+	}
+}
+
+// CollectDiagnostics appends the diagnostics of the process to the buffer in line protocol, one point per module
+func CollectDiagnostics(buf []byte) []byte {
+	diag.mu.RLock()
+	defer diag.mu.RUnlock()
+
+	if len(diag.tags) == 0 {
+		return buf
+	}
+
+	buf = statistics.AddPointToBuffer(DiagnosticsBuild, diag.tags, map[string]interface{}{
+		"Version":   diag.build.Version,
+		"Commit":    diag.build.Commit,
+		"Branch":    diag.build.Branch,
+		"BuildTime": diag.build.Time,
+	}, buf)
+
+	buf = statistics.AddPointToBuffer(DiagnosticsRuntime, diag.tags, map[string]interface{}{
+		"GoVersion":    runtime.Version(),
+		"GOOS":         runtime.GOOS,
+		"GOARCH":       runtime.GOARCH,
+		"GOMAXPROCS":   int64(runtime.GOMAXPROCS(0)),
+		"NumCPU":       int64(runtime.NumCPU()),
+		"NumGoroutine": int64(runtime.NumGoroutine()),
+	}, buf)
+
+	now := time.Now()
+	buf = statistics.AddPointToBuffer(DiagnosticsSystem, diag.tags, map[string]interface{}{
+		"PID":         int64(os.Getpid()),
+		"CurrentTime": now.UTC().Format(time.RFC3339),
+		"Started":     diag.started.UTC().Format(time.RFC3339),
+		"Uptime":      now.Sub(diag.started).Truncate(time.Second).String(),
+	}, buf)
+
+	if len(diag.config) > 0 {
+		buf = statistics.AddPointToBuffer(DiagnosticsConfig, diag.tags, diag.config, buf)
+	}
+	return buf
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statisticsPusher
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/toml"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/logger"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectWithoutPushers(t *testing.T) {
+	var nilPusher *StatisticsPusher
+	buf, err := nilPusher.Collect(nil)
+	require.NoError(t, err)
+	require.Empty(t, buf)
+
+	p := newStatisticsPusher(&config.Monitor{StoreInterval: toml.Duration(config.DefaultStoreInterval)}, logger.NewLogger(errno.ModuleUnknown))
+	require.NotNil(t, p)
+	p.Register(func(buf []byte) ([]byte, error) {
+		return append(buf[:0], "m0,hostname=h0 a=1 1\n"...), nil
+	}, func(buf []byte) ([]byte, error) {
+		return append(buf[:0], "m1,hostname=h0 b=2 1\n"...), nil
+	})
+	// nothing is pushed without pushers, so there is nothing to stop
+	p.Start()
+
+	buf, err = p.Collect([]byte("m2,hostname=h0 c=3 1\n"))
+	require.NoError(t, err)
+	rows := &influx.PointRows{}
+	require.NoError(t, rows.Unmarshal(string(buf)))
+	names := map[string]bool{}
+	for _, r := range rows.Rows {
+		names[r.Name] = true
+	}
+	require.Equal(t, map[string]bool{"m0": true, "m1": true, "m2": true}, names)
+}
+
+func TestCollectDiagnostics(t *testing.T) {
+	InitDiagnostics(map[string]string{"hostname": "127.0.0.1:8086", "app": "ts-sql"},
+		BuildInfo{Version: "v1.0.0", Commit: "abc", Branch: "main", Time: "2022-10-10T10:00:00Z"},
+		map[string]interface{}{"http.bind-address": "127.0.0.1:8086", "coordinator.max-concurrent-queries": int64(8)})
+
+	rows := &influx.PointRows{}
+	require.NoError(t, rows.Unmarshal(string(CollectDiagnostics(nil))))
+	fields := map[string]map[string]influx.Field{}
+	for _, r := range rows.Rows {
+		require.Equal(t, "127.0.0.1:8086", r.Tags.FindPointTag("hostname").Value)
+		fields[r.Name] = map[string]influx.Field{}
+		for _, f := range r.Fields {
+			fields[r.Name][f.Key] = f
+		}
+	}
+
+	require.Len(t, fields, 4)
+	require.Equal(t, "v1.0.0", fields[DiagnosticsBuild]["Version"].StrValue)
+	require.Equal(t, "abc", fields[DiagnosticsBuild]["Commit"].StrValue)
+	require.NotEmpty(t, fields[DiagnosticsRuntime]["GoVersion"].StrValue)
+	require.NotZero(t, fields[DiagnosticsRuntime]["NumCPU"].NumValue)
+	require.NotEmpty(t, fields[DiagnosticsSystem]["Uptime"].StrValue)
+	require.Equal(t, "127.0.0.1:8086", fields[DiagnosticsConfig]["http.bind-address"].StrValue)
+	require.Equal(t, float64(8), fields[DiagnosticsConfig]["coordinator.max-concurrent-queries"].NumValue)
+}
+
+type mockPusher struct {
+	data []byte
+}
+
+func (p *mockPusher) Push(buf []byte) error {
+	p.data = append(p.data, buf...)
+	return nil
+}
+
+func (p *mockPusher) Stop() {}
+
+func TestCollectKeepsDrainedPoints(t *testing.T) {
+	mp := &mockPusher{}
+	p := newStatisticsPusher(&config.Monitor{StoreInterval: toml.Duration(config.DefaultStoreInterval)}, logger.NewLogger(errno.ModuleUnknown))
+	p.pushers = append(p.pushers, mp)
+
+	events := []byte("merge_event,hostname=h0 files=3 1\n")
+	p.Register(func(buf []byte) ([]byte, error) {
+		buf = append(buf, events...)
+		events = events[:0]
+		return buf, nil
+	})
+
+	buf, err := p.Collect(nil)
+	require.NoError(t, err)
+	require.Equal(t, "merge_event,hostname=h0 files=3 1\n", string(buf))
+
+	p.push()
+	require.Equal(t, "merge_event,hostname=h0 files=3 1\n", string(mp.data))
+}
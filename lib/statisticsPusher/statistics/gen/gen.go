@@ -24,3 +24,9 @@ package gen
 
 //go:generate tmpl -data=@meta.data -o=../meta_statistics.gen.go statistics.tmpl
 //go:generate tmpl -data=@meta.data -o=../meta_statistics.gen_test.go statistics_test.tmpl
+
+//go:generate tmpl -data=@wal.data -o=../wal_statistics.gen.go statistics.tmpl
+//go:generate tmpl -data=@wal.data -o=../wal_statistics.gen_test.go statistics_test.tmpl
+
+//go:generate tmpl -data=@replication.data -o=../replication_statistics.gen.go statistics.tmpl
+//go:generate tmpl -data=@replication.data -o=../replication_statistics.gen_test.go statistics_test.tmpl
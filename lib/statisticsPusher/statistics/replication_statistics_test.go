@@ -0,0 +1,64 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statistics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+)
+
+func TestReplication(t *testing.T) {
+	stat := statistics.NewReplicationStatistics()
+	tags := map[string]string{"hostname": "127.0.0.1:8086", "mst": "replication"}
+	stat.Init(tags)
+	stat.AddEnqueuedBatches(2)
+	stat.AddEnqueuedBytes(2)
+	stat.AddShippedBatches(2)
+	stat.AddShippedBytes(2)
+	stat.AddRetriedRequests(2)
+	stat.AddRejectedBatches(2)
+	stat.AddDroppedBatches(2)
+	stat.SetPendingBatches(3)
+	stat.SetPendingBytes(3)
+	stat.SetLagMs(3)
+	stat.SetPaused(3)
+
+	fields := map[string]interface{}{
+		"EnqueuedBatches": int64(2),
+		"EnqueuedBytes":   int64(2),
+		"ShippedBatches":  int64(2),
+		"ShippedBytes":    int64(2),
+		"RetriedRequests": int64(2),
+		"RejectedBatches": int64(2),
+		"DroppedBatches":  int64(2),
+		"PendingBatches":  int64(3),
+		"PendingBytes":    int64(3),
+		"LagMs":           int64(3),
+		"Paused":          int64(3),
+	}
+	statistics.NewTimestamp().Init(time.Second)
+	buf, err := stat.Collect(nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if err := compareBuffer("replication", tags, fields, buf); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
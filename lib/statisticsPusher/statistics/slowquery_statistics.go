@@ -17,6 +17,7 @@ limitations under the License.
 package statistics
 
 import (
+	"sync"
 	"sync/atomic"
 )
 
@@ -35,13 +36,21 @@ const (
 
 // SQL Statistics
 type SQLSlowQueryStatistics struct {
-	TotalDuration    int64
-	PrepareDuration  int64
-	IteratorDuration int64
-	EmitDuration     int64
-	Query            string
-	DB               string
-	QueryBatch       int64
+	TotalDuration         int64
+	PrepareDuration       int64
+	IteratorDuration      int64
+	LocalIteratorDuration int64
+	EmitDuration          int64
+	Query                 string
+	DB                    string
+	User                  string
+	QueryBatch            int64
+	Rows                  int64
+	Shards                int64
+
+	// StageDurations is the time each stage of the pipeline executors took, keyed by the transform name
+	mu             sync.Mutex
+	StageDurations map[string]int64
 }
 
 var SlowQueryStat = NewSqlSlowQueryStatistics()
@@ -77,6 +86,11 @@ func (s *SQLSlowQueryStatistics) AddDuration(durationName string, d int64) {
 		if d > td {
 			atomic.StoreInt64(&s.IteratorDuration, d)
 		}
+	case "LocalIteratorDuration":
+		td := atomic.LoadInt64(&s.LocalIteratorDuration)
+		if d > td {
+			atomic.StoreInt64(&s.LocalIteratorDuration, d)
+		}
 	case "EmitDuration":
 		td := atomic.LoadInt64(&s.EmitDuration)
 		if d > td {
@@ -86,6 +100,53 @@ func (s *SQLSlowQueryStatistics) AddDuration(durationName string, d int64) {
 	}
 }
 
+func (s *SQLSlowQueryStatistics) AddRows(n int) {
+	if s != nil {
+		atomic.AddInt64(&s.Rows, int64(n))
+	}
+}
+
+func (s *SQLSlowQueryStatistics) AddShards(n int) {
+	if s != nil {
+		atomic.AddInt64(&s.Shards, int64(n))
+	}
+}
+
+// AddStageDurations merges the stage durations of a pipeline executor, the longest duration is kept
+// if several statements of the query have the same stage.
+func (s *SQLSlowQueryStatistics) AddStageDurations(durations map[string]int64) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.StageDurations == nil {
+		s.StageDurations = make(map[string]int64, len(durations))
+	}
+	for name, d := range durations {
+		if d > s.StageDurations[name] {
+			s.StageDurations[name] = d
+		}
+	}
+}
+
+// GetStageDurations returns a copy of the stage durations
+func (s *SQLSlowQueryStatistics) GetStageDurations() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	durations := make(map[string]int64, len(s.StageDurations))
+	for name, d := range s.StageDurations {
+		durations[name] = d
+	}
+	return durations
+}
+
+func (s *SQLSlowQueryStatistics) SetUser(user string) {
+	if s != nil {
+		s.User = user
+	}
+}
+
 func (s *SQLSlowQueryStatistics) SetQuery(q string) {
 	if s != nil {
 		s.Query = q
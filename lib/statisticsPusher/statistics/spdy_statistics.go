@@ -18,6 +18,8 @@ package statistics
 
 import (
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type SpdyStatistics struct {
@@ -143,6 +145,7 @@ func InitSpdyStatistics(tags map[string]string) {
 
 func CollectSpdyStatistics(buffer []byte) ([]byte, error) {
 	buffer = append(buffer, NewSpdyStatistics().CollectBuf()...)
+	buffer = SpdyCompressionStat.Collect(buffer)
 	return buffer, nil
 }
 
@@ -211,3 +214,65 @@ func (s *SpdyStatistics) processJob(job *SpdyJob) {
 
 	s.data[job.addr][k] += job.value
 }
+
+var spdyCompressionStatisticsName = "spdy_compression"
+
+var SpdyCompressionStat = &SpdyCompressionStatistics{}
+
+// SpdyCompressionStatistics records the effect of compressing spdy data frames
+type SpdyCompressionStatistics struct {
+	CompressedFrames   int64
+	SkippedFrames      int64
+	RawBytes           int64
+	CompressedBytes    int64
+	CompressNs         int64
+	DecompressedFrames int64
+	DecompressNs       int64
+}
+
+func (s *SpdyCompressionStatistics) AddCompressed(raw, compressed int, d time.Duration) {
+	atomic.AddInt64(&s.CompressedFrames, 1)
+	atomic.AddInt64(&s.RawBytes, int64(raw))
+	atomic.AddInt64(&s.CompressedBytes, int64(compressed))
+	atomic.AddInt64(&s.CompressNs, d.Nanoseconds())
+}
+
+// AddSkipped is called when a frame is not sent compressed because compression did not shrink it
+func (s *SpdyCompressionStatistics) AddSkipped(d time.Duration) {
+	atomic.AddInt64(&s.SkippedFrames, 1)
+	atomic.AddInt64(&s.CompressNs, d.Nanoseconds())
+}
+
+func (s *SpdyCompressionStatistics) AddDecompressed(d time.Duration) {
+	atomic.AddInt64(&s.DecompressedFrames, 1)
+	atomic.AddInt64(&s.DecompressNs, d.Nanoseconds())
+}
+
+func (s *SpdyCompressionStatistics) Collect(buffer []byte) []byte {
+	raw := atomic.LoadInt64(&s.RawBytes)
+	compressed := atomic.LoadInt64(&s.CompressedBytes)
+	ratio := float64(0)
+	if compressed > 0 {
+		ratio = float64(raw) / float64(compressed)
+	}
+
+	valueMap := map[string]interface{}{
+		"compressedFrames":   atomic.LoadInt64(&s.CompressedFrames),
+		"skippedFrames":      atomic.LoadInt64(&s.SkippedFrames),
+		"rawBytes":           raw,
+		"compressedBytes":    compressed,
+		"compressRatio":      ratio,
+		"compressNs":         atomic.LoadInt64(&s.CompressNs),
+		"decompressedFrames": atomic.LoadInt64(&s.DecompressedFrames),
+		"decompressNs":       atomic.LoadInt64(&s.DecompressNs),
+	}
+
+	tagMap := make(map[string]string, len(spdyTagMap))
+	for k, v := range spdyTagMap {
+		if k == "remote_addr" || k == "link" {
+			continue
+		}
+		tagMap[k] = v
+	}
+	return AddPointToBuffer(spdyCompressionStatisticsName, tagMap, valueMap, buffer)
+}
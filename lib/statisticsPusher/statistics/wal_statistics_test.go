@@ -0,0 +1,56 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statistics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+)
+
+func TestWal(t *testing.T) {
+	stat := statistics.NewWalStatistics()
+	tags := map[string]string{"hostname": "127.0.0.1:8866", "mst": "wal"}
+	stat.Init(tags)
+	stat.AddWriteRecords(2)
+	stat.AddWriteBytes(2)
+	stat.AddSyncs(2)
+	stat.AddGroupCommits(2)
+	stat.AddGroupCommitRecords(2)
+	stat.AddCorruptRecords(2)
+	stat.AddQuarantinedFiles(2)
+
+	fields := map[string]interface{}{
+		"WriteRecords":       int64(2),
+		"WriteBytes":         int64(2),
+		"Syncs":              int64(2),
+		"GroupCommits":       int64(2),
+		"GroupCommitRecords": int64(2),
+		"CorruptRecords":     int64(2),
+		"QuarantinedFiles":   int64(2),
+	}
+	statistics.NewTimestamp().Init(time.Second)
+	buf, err := stat.Collect(nil)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if err := compareBuffer("wal", tags, fields, buf); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
@@ -40,7 +40,13 @@ type StatisticsPusher struct {
 	collects     map[uintptr]collectFunc
 	logger       *logger.Logger
 
+	mu sync.RWMutex
 	wg sync.WaitGroup
+
+	// pending keeps the points collected on demand, some collectors drain their
+	// event points when collected, so they are pushed with the next interval
+	pendingMu sync.Mutex
+	pending   []byte
 }
 
 var bufferPool = bufferpool.NewByteBufferPool(0)
@@ -54,9 +60,13 @@ func NewStatisticsPusher(conf *config.Monitor, logger *logger.Logger) *Statistic
 	return sp
 }
 
+// newStatisticsPusher returns a pusher whose statistics are only collected on demand if store-enabled is not set
 func newStatisticsPusher(conf *config.Monitor, logger *logger.Logger) *StatisticsPusher {
 	var pushers []pusher.Pusher
 	for _, pt := range strings.Split(conf.Pushers, config.PusherSep) {
+		if !conf.StoreEnabled {
+			break
+		}
 		var p pusher.Pusher
 		switch pt {
 		case config.HttpPusher:
@@ -68,10 +78,6 @@ func newStatisticsPusher(conf *config.Monitor, logger *logger.Logger) *Statistic
 			pushers = append(pushers, p)
 		}
 	}
-	if len(pushers) == 0 {
-		return nil
-	}
-
 	statistics.NewTimestamp().Init(time.Duration(conf.StoreInterval))
 	return &StatisticsPusher{
 		pushers:      pushers,
@@ -118,8 +124,23 @@ func (sp *StatisticsPusher) push() {
 		return
 	}
 
+	sp.pendingMu.Lock()
+	pending := sp.pending
+	sp.pending = nil
+	sp.pendingMu.Unlock()
+	if len(pending) > 0 {
+		for _, p := range sp.pushers {
+			if err := p.Push(pending); err != nil {
+				sp.logger.Error("push statistics data error", zap.Error(err))
+				return
+			}
+		}
+	}
+
 	buf := bufferPool.Get()
 	var err error
+	sp.mu.RLock()
+	defer sp.mu.RUnlock()
 	for _, collect := range sp.collects {
 		// collect statistics data
 		buf, err = collect(buf[0:])
@@ -144,14 +165,52 @@ func (sp *StatisticsPusher) push() {
 }
 
 func (sp *StatisticsPusher) Register(collects ...collectFunc) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
 	for _, fn := range collects {
 		ptr := reflect.ValueOf(fn).Pointer()
 		sp.collects[ptr] = fn
 	}
 }
 
+// Collect appends the statistics of all the registered modules to the buffer in line protocol,
+// it serves SHOW STATS whether any pusher is configured or not
+func (sp *StatisticsPusher) Collect(buf []byte) ([]byte, error) {
+	if sp == nil {
+		return buf, nil
+	}
+
+	sp.mu.RLock()
+	defer sp.mu.RUnlock()
+	for _, collect := range sp.collects {
+		// some collectors reset or drop the buffer they are given
+		data, err := collect(nil)
+		if err != nil {
+			return buf, err
+		}
+		buf = append(buf, data...)
+
+		if len(sp.pushers) > 0 && len(data) > 0 {
+			sp.pendingMu.Lock()
+			sp.pending = append(sp.pending, data...)
+			sp.pendingMu.Unlock()
+		}
+	}
+	return buf, nil
+}
+
+// CollectStatistics appends the statistics of the process to the buffer in line protocol,
+// nothing is collected when the monitor is not enabled
+func CollectStatistics(buf []byte) ([]byte, error) {
+	return sp.Collect(buf)
+}
+
 // Start starts push statistics data in interval time
 func (sp *StatisticsPusher) Start() {
+	if len(sp.pushers) == 0 {
+		// the statistics are only collected on demand
+		return
+	}
 	sp.wg.Add(1)
 
 	go func() {
@@ -36,6 +36,7 @@ func TestPusher(t *testing.T) {
 		StoreInterval: toml.Duration(config.DefaultStoreInterval),
 		Pushers:       config.FilePusher + config.PusherSep + config.HttpPusher,
 		StorePath:     t.TempDir() + "/stat_metric.data",
+		StoreEnabled:  true,
 	}
 
 	sp := NewStatisticsPusher(conf, logger.NewLogger(errno.ModuleUnknown))
@@ -48,3 +49,25 @@ func TestPusher(t *testing.T) {
 		t.Fatalf("exp %d pushers, got: %d", 2, len(sp.pushers))
 	}
 }
+
+func TestPusherStoreDisabled(t *testing.T) {
+	conf := &config.Monitor{
+		StoreInterval: toml.Duration(config.DefaultStoreInterval),
+		Pushers:       config.FilePusher,
+		StorePath:     t.TempDir() + "/stat_metric.data",
+	}
+
+	// the statistics are still collected for SHOW STATS, but never pushed
+	sp := newStatisticsPusher(conf, logger.NewLogger(errno.ModuleUnknown))
+	sp.Register(func(buf []byte) ([]byte, error) {
+		return append(buf, "m0,hostname=h0 a=1 1\n"...), nil
+	})
+	sp.Start()
+	if len(sp.pushers) != 0 {
+		t.Fatalf("exp no pushers, got: %d", len(sp.pushers))
+	}
+	buf, err := sp.Collect(nil)
+	if err != nil || string(buf) != "m0,hostname=h0 a=1 1\n" {
+		t.Fatalf("unexpected statistics: %q, %v", buf, err)
+	}
+}
@@ -50,6 +50,8 @@ curl -i -XPOST 'https://127.0.0.1:8086/debug/ctrl?mod=snapshot&flushduration=5m'
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=compen&switchon=true&allshards=true&shid=4'
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=merge&switchon=true&allshards=true&shid=4'
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=snapshot&duration=30m'
+curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=compact&shid=4&full=true'
+curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=cancelcompact&shid=4'
 
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=readonly&switchon=true&allnodes=y'
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=readonly&switchon=true&host=127.0.0.1'
@@ -61,6 +63,10 @@ curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=print_logical_plan&enabled=
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=sliding_window_push_up&enabled=1'
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=log_rows&switchon=true&rules=mst,tk1=tv1'
 curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=force_broadcast_query&enabled=1'
+
+Meta cmd:
+curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=balancer&switchon=false'
+curl -i -XPOST 'http://127.0.0.1:8086/debug/ctrl?mod=balancer&switchon=true&dryrun=true'
 */
 
 const (
@@ -76,6 +82,12 @@ const (
 	Failpoint           = "failpoint"
 	Readonly            = "readonly"
 	LogRows             = "log_rows"
+	Compactions         = "compactions"
+	Compact             = "compact"
+	CancelCompact       = "cancelcompact"
+	Balancer            = "balancer"
+	Stats               = "stats"
+	Diagnostics         = "diagnostics"
 )
 
 var (
@@ -128,7 +140,7 @@ func SetLogRowsRuleSwitch(switchon bool, rules string) error {
 
 func ProcessRequest(req netstorage.SysCtrlRequest, resp *strings.Builder) (err error) {
 	switch req.Mod() {
-	case DataFlush, compactionEn, compmerge, snapshot, Failpoint:
+	case DataFlush, compactionEn, compmerge, snapshot, Failpoint, Compact, CancelCompact:
 		// store SysCtrl cmd
 		dataNodes, err := SysCtrl.MetaClient.DataNodes()
 		if err != nil {
@@ -212,6 +224,23 @@ func ProcessRequest(req netstorage.SysCtrlRequest, resp *strings.Builder) (err e
 		executor.SetEnableForceBroadcastQuery(enabled)
 		res := "\n\tsuccess"
 		resp.WriteString(res)
+	case Balancer:
+		// meta SysCtrl cmd
+		switchon, err := getBoolValue(req.Param(), "switchon")
+		if err != nil {
+			return err
+		}
+		var dryRun bool
+		if _, ok := req.Param()["dryrun"]; ok {
+			if dryRun, err = getBoolValue(req.Param(), "dryrun"); err != nil {
+				return err
+			}
+		}
+		if err = SysCtrl.MetaClient.SetBalancer(!switchon, dryRun); err != nil {
+			return err
+		}
+		res := "\n\tsuccess"
+		resp.WriteString(res)
 	default:
 		return fmt.Errorf("unknown sysctrl mod: %v", req.Mod())
 	}
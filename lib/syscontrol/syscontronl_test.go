@@ -145,3 +145,37 @@ func TestProcessRequest_ForceBroadcastQuery(t *testing.T) {
 	assert.Equal(t, executor.EnableForceBroadcastQuery, int64(0))
 	sb.Reset()
 }
+
+var balancerPaused, balancerDryRun bool
+
+func (mockMetaClient) SetBalancer(paused, dryRun bool) error {
+	balancerPaused, balancerDryRun = paused, dryRun
+	return nil
+}
+
+func TestProcessRequest_Balancer(t *testing.T) {
+	SysCtrl.MetaClient = &mockMetaClient{}
+	var req netstorage.SysCtrlRequest
+	req.SetMod("balancer")
+	req.SetParam(map[string]string{
+		"switchon": "false",
+	})
+	var sb strings.Builder
+	require.NoError(t, ProcessRequest(req, &sb))
+	require.Contains(t, sb.String(), "\n\tsuccess")
+	require.True(t, balancerPaused)
+	require.False(t, balancerDryRun)
+
+	req.SetParam(map[string]string{
+		"switchon": "true",
+		"dryrun":   "true",
+	})
+	require.NoError(t, ProcessRequest(req, &sb))
+	require.False(t, balancerPaused)
+	require.True(t, balancerDryRun)
+
+	req.SetParam(map[string]string{
+		"dryrun": "true",
+	})
+	require.Error(t, ProcessRequest(req, &sb))
+}
@@ -32,3 +32,22 @@ func CreateTime(name string) (*time.Time, error) {
 	tm := time.Unix(int64(st.Ctim.Sec), int64(st.Ctim.Nsec))
 	return &tm, nil
 }
+
+// DiskUsage returns the total and the available bytes of the file system of the path
+func DiskUsage(path string) (uint64, uint64, error) {
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(path, &fs); err != nil {
+		return 0, 0, err
+	}
+	return fs.Blocks * uint64(fs.Bsize), fs.Bavail * uint64(fs.Bsize), nil
+}
+
+// DeviceID returns the id of the device the path is on
+func DeviceID(path string) (uint64, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	st := fi.Sys().(*syscall.Stat_t)
+	return uint64(st.Dev), nil
+}
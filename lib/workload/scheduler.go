@@ -0,0 +1,139 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"sync"
+
+	"github.com/openGemini/openGemini/lib/errno"
+)
+
+// Scheduler shares a fixed number of scan slots between workload groups.
+// Scans take a slot for every chunk they read, so a waiting scan of a higher priority
+// group preempts scans of lower priority groups at the next chunk boundary.
+// Groups with the same priority get slots in proportion to their cpu-share (stride scheduling).
+type Scheduler struct {
+	mu      sync.Mutex
+	free    int
+	vtime   uint64
+	seq     uint64
+	waiters []*waiter
+}
+
+type waiter struct {
+	group *Group
+	seq   uint64
+	ready chan struct{}
+}
+
+func NewScheduler(slots int) *Scheduler {
+	return &Scheduler{free: slots}
+}
+
+// Acquire waits for a scan slot for g, or returns an error if abort is closed first
+func (s *Scheduler) Acquire(g *Group, abort <-chan struct{}) error {
+	if s == nil || g == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	// an idle group does not save up credit while it is not scanning
+	if g.pass < s.vtime {
+		g.pass = s.vtime
+	}
+	if s.free > 0 && len(s.waiters) == 0 {
+		s.free--
+		s.charge(g)
+		s.mu.Unlock()
+		return nil
+	}
+	s.seq++
+	w := &waiter{group: g, seq: s.seq, ready: make(chan struct{})}
+	s.waiters = append(s.waiters, w)
+	s.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-abort:
+	}
+
+	s.mu.Lock()
+	for i := range s.waiters {
+		if s.waiters[i] == w {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			s.mu.Unlock()
+			return errno.NewError(errno.WorkloadGroupAborted, g.name)
+		}
+	}
+	s.mu.Unlock()
+
+	// the slot was granted concurrently, hand it over to the next waiter
+	s.Release(g)
+	return errno.NewError(errno.WorkloadGroupAborted, g.name)
+}
+
+// Release returns the slot acquired for g, which is granted to the most urgent waiter
+func (s *Scheduler) Release(g *Group) {
+	if s == nil || g == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.waiters) == 0 {
+		s.free++
+		return
+	}
+
+	next := 0
+	for i := 1; i < len(s.waiters); i++ {
+		if s.before(s.waiters[i], s.waiters[next]) {
+			next = i
+		}
+	}
+	w := s.waiters[next]
+	s.waiters = append(s.waiters[:next], s.waiters[next+1:]...)
+	s.charge(w.group)
+	close(w.ready)
+}
+
+func (s *Scheduler) before(x, y *waiter) bool {
+	if x.group.priority != y.group.priority {
+		return x.group.priority > y.group.priority
+	}
+	if x.group.pass != y.group.pass {
+		return x.group.pass < y.group.pass
+	}
+	return x.seq < y.seq
+}
+
+func (s *Scheduler) charge(g *Group) {
+	s.vtime = g.pass
+	g.pass += g.stride
+}
+
+// NumOfWaiters returns the number of scans waiting for a slot
+func (s *Scheduler) NumOfWaiters() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.waiters)
+}
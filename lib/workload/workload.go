@@ -0,0 +1,192 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/bucket"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/cpu"
+	"github.com/openGemini/openGemini/lib/errno"
+)
+
+const strideBase = 1 << 20
+
+// Group is a workload group. A nil group has no limits, so callers do not need
+// to check whether workload management is enabled.
+type Group struct {
+	name     string
+	priority int
+	stride   uint64
+	pass     uint64 // protected by Scheduler.mu
+
+	sem chan struct{}
+	mem bucket.ResourceBucket
+}
+
+func newGroup(conf config.WorkloadGroup, memLimit int64, memWait time.Duration) *Group {
+	g := &Group{
+		name:     conf.Name,
+		priority: conf.Priority,
+		stride:   strideBase / uint64(conf.CPUShare),
+	}
+	if conf.MaxConcurrency > 0 {
+		g.sem = make(chan struct{}, conf.MaxConcurrency)
+	}
+	if conf.MemoryPercent > 0 && memLimit > 0 {
+		g.mem = bucket.NewInt64Bucket(memWait, memLimit*int64(conf.MemoryPercent)/100)
+	}
+	return g
+}
+
+func (g *Group) Name() string {
+	if g == nil {
+		return ""
+	}
+	return g.name
+}
+
+func (g *Group) Priority() int {
+	if g == nil {
+		return 0
+	}
+	return g.priority
+}
+
+// Acquire waits until the group has a free query slot or abort is closed
+func (g *Group) Acquire(abort <-chan struct{}) error {
+	if g == nil || g.sem == nil {
+		return nil
+	}
+	select {
+	case g.sem <- struct{}{}:
+		return nil
+	default:
+	}
+	select {
+	case g.sem <- struct{}{}:
+		return nil
+	case <-abort:
+		return errno.NewError(errno.WorkloadGroupAborted, g.name)
+	}
+}
+
+func (g *Group) Release() {
+	if g == nil || g.sem == nil {
+		return
+	}
+	<-g.sem
+}
+
+func (g *Group) AcquireMemory(n int64) error {
+	if g == nil || g.mem == nil {
+		return nil
+	}
+	return g.mem.GetResource(n)
+}
+
+func (g *Group) ReleaseMemory(n int64) {
+	if g == nil || g.mem == nil {
+		return
+	}
+	g.mem.ReleaseResource(n)
+}
+
+type Manager struct {
+	groups       map[string]*Group
+	users        map[string]*Group
+	defaultGroup *Group
+	scheduler    *Scheduler
+}
+
+// NewManager creates the workload groups. memLimit is the memory which all queries
+// of the process may use, it is shared between the groups by their memory-percent.
+func NewManager(conf config.Workload, memLimit int64) *Manager {
+	m := &Manager{
+		groups: make(map[string]*Group, len(conf.Groups)),
+		users:  make(map[string]*Group),
+	}
+	memWait := time.Duration(conf.MemoryWait)
+	if memWait <= 0 {
+		memWait = config.DefaultWorkloadMemoryWait
+	}
+	for _, gc := range conf.Groups {
+		g := newGroup(gc, memLimit, memWait)
+		m.groups[gc.Name] = g
+		for _, u := range gc.Users {
+			m.users[u] = g
+		}
+	}
+	m.defaultGroup = m.groups[conf.DefaultGroup]
+
+	slots := conf.ScanSlots
+	if slots <= 0 {
+		slots = cpu.GetCpuNum()
+	}
+	m.scheduler = NewScheduler(slots)
+	return m
+}
+
+// Resolve maps a query to its workload group. A valid hint wins over the user mapping.
+func (m *Manager) Resolve(user, hint string) *Group {
+	if m == nil {
+		return nil
+	}
+	if g, ok := m.groups[hint]; ok {
+		return g
+	}
+	if g, ok := m.users[user]; ok {
+		return g
+	}
+	return m.defaultGroup
+}
+
+// Group returns the group with the given name, or the default group if it does not exist
+func (m *Manager) Group(name string) *Group {
+	if m == nil {
+		return nil
+	}
+	if g, ok := m.groups[name]; ok {
+		return g
+	}
+	return m.defaultGroup
+}
+
+func (m *Manager) Scheduler() *Scheduler {
+	if m == nil {
+		return nil
+	}
+	return m.scheduler
+}
+
+var manager atomic.Value
+
+// Init sets up workload management of the process. It is disabled if conf is not enabled.
+func Init(conf config.Workload, memLimit int64) {
+	if !conf.Enabled {
+		manager.Store((*Manager)(nil))
+		return
+	}
+	manager.Store(NewManager(conf, memLimit))
+}
+
+func GetManager() *Manager {
+	m, _ := manager.Load().(*Manager)
+	return m
+}
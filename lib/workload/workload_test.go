@@ -0,0 +1,197 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workload_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/workload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig() config.Workload {
+	conf := config.NewWorkload()
+	conf.Enabled = true
+	conf.ScanSlots = 1
+	conf.Groups[0].Users = []string{"root"}
+	return conf
+}
+
+func TestResolve(t *testing.T) {
+	m := workload.NewManager(newTestConfig(), 1000)
+
+	assert.Equal(t, config.WorkloadGroupRealtime, m.Resolve("root", config.WorkloadGroupRealtime).Name())
+	assert.Equal(t, config.WorkloadGroupAdmin, m.Resolve("root", "unknown").Name())
+	assert.Equal(t, config.WorkloadGroupBatch, m.Resolve("grafana", "").Name())
+	assert.Equal(t, config.WorkloadGroupBatch, m.Group("").Name())
+
+	var nilManager *workload.Manager
+	g := nilManager.Resolve("root", config.WorkloadGroupAdmin)
+	assert.Nil(t, g)
+	assert.NoError(t, g.Acquire(nil))
+	g.Release()
+	assert.NoError(t, g.AcquireMemory(1<<40))
+	assert.Nil(t, nilManager.Scheduler())
+}
+
+func TestInit(t *testing.T) {
+	workload.Init(config.NewWorkload(), 0)
+	assert.Nil(t, workload.GetManager())
+
+	workload.Init(newTestConfig(), 0)
+	assert.NotNil(t, workload.GetManager())
+
+	workload.Init(config.NewWorkload(), 0)
+	assert.Nil(t, workload.GetManager())
+}
+
+func TestGroupConcurrencyAndMemory(t *testing.T) {
+	m := workload.NewManager(newTestConfig(), 1000)
+	admin := m.Group(config.WorkloadGroupAdmin)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, admin.Acquire(nil))
+	}
+	abort := make(chan struct{})
+	close(abort)
+	assert.Error(t, admin.Acquire(abort))
+	admin.Release()
+	assert.NoError(t, admin.Acquire(abort))
+
+	// admin owns 10% of the memory
+	assert.NoError(t, admin.AcquireMemory(100))
+	admin.ReleaseMemory(100)
+}
+
+func TestGroupMemoryWait(t *testing.T) {
+	conf := newTestConfig()
+	conf.MemoryWait = toml.Duration(10 * time.Millisecond)
+	admin := workload.NewManager(conf, 1000).Group(config.WorkloadGroupAdmin)
+
+	require.NoError(t, admin.AcquireMemory(100))
+	start := time.Now()
+	assert.Error(t, admin.AcquireMemory(1))
+	assert.Less(t, time.Since(start), time.Second)
+	admin.ReleaseMemory(100)
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	m := workload.NewManager(newTestConfig(), 0)
+	s := m.Scheduler()
+	batch := m.Group(config.WorkloadGroupBatch)
+	realtime := m.Group(config.WorkloadGroupRealtime)
+
+	// the only slot is held by a batch scan
+	require.NoError(t, s.Acquire(batch, nil))
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	run := func(g *workload.Group) {
+		defer wg.Done()
+		require.NoError(t, s.Acquire(g, nil))
+		mu.Lock()
+		order = append(order, g.Name())
+		mu.Unlock()
+		s.Release(g)
+	}
+
+	wg.Add(1)
+	go run(batch)
+	waitWaiters(t, s, 1)
+	wg.Add(1)
+	go run(realtime)
+	waitWaiters(t, s, 2)
+
+	s.Release(batch)
+	wg.Wait()
+	assert.Equal(t, []string{config.WorkloadGroupRealtime, config.WorkloadGroupBatch}, order)
+}
+
+func TestSchedulerShare(t *testing.T) {
+	conf := newTestConfig()
+	for i := range conf.Groups {
+		conf.Groups[i].Priority = 0
+	}
+	m := workload.NewManager(conf, 0)
+	s := m.Scheduler()
+	batch := m.Group(config.WorkloadGroupBatch)
+	realtime := m.Group(config.WorkloadGroupRealtime)
+
+	require.NoError(t, s.Acquire(batch, nil))
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	run := func(g *workload.Group) {
+		defer wg.Done()
+		require.NoError(t, s.Acquire(g, nil))
+		mu.Lock()
+		order = append(order, g.Name())
+		mu.Unlock()
+		s.Release(g)
+	}
+	// the same number of scans of both groups compete for the only slot
+	waiters := 0
+	for i := 0; i < 4; i++ {
+		for _, g := range []*workload.Group{batch, realtime} {
+			wg.Add(1)
+			go run(g)
+			waiters++
+			waitWaiters(t, s, waiters)
+		}
+	}
+	s.Release(batch)
+	wg.Wait()
+
+	// realtime has a cpu-share of 60, batch of 30
+	counts := map[string]int{}
+	for _, name := range order[:4] {
+		counts[name]++
+	}
+	assert.Equal(t, 3, counts[config.WorkloadGroupRealtime])
+}
+
+func TestSchedulerAbort(t *testing.T) {
+	m := workload.NewManager(newTestConfig(), 0)
+	s := m.Scheduler()
+	batch := m.Group(config.WorkloadGroupBatch)
+
+	require.NoError(t, s.Acquire(batch, nil))
+	abort := make(chan struct{})
+	close(abort)
+	assert.Error(t, s.Acquire(batch, abort))
+	assert.Equal(t, 0, s.NumOfWaiters())
+
+	s.Release(batch)
+	assert.NoError(t, s.Acquire(batch, nil))
+}
+
+func waitWaiters(t *testing.T, s *workload.Scheduler, n int) {
+	for i := 0; i < 1000; i++ {
+		if s.NumOfWaiters() == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("expect %d waiters, got %d", n, s.NumOfWaiters())
+}
@@ -6,6 +6,18 @@ This code is originally from: https://github.com/influxdata/influxdb/blob/1.7/co
 
 2022.01.23 The ExecuteStatement function is taken from original function, add statements cases:
 AlterShardKeyStatement
+AlterMeasurementTTLStatement
+ShowCompactionsStatement
+CompactStatement
+CancelCompactionsStatement
+MigratePartitionStatement
+DecommissionNodeStatement
+ShowEventsStatement
+PauseReplicationStatement
+ResumeReplicationStatement
+ShowDataNodesStatement
+ShowStatsStatement
+ShowDiagnosticsStatement
 ShowFieldKeysStatement
 ShowFieldKeyCardinalityStatement
 ShowTagKeyCardinalityStatement
@@ -23,8 +35,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -35,18 +49,22 @@ import (
 	originql "github.com/influxdata/influxql"
 	"github.com/openGemini/openGemini/coordinator"
 	"github.com/openGemini/openGemini/engine/executor"
+	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/lib/logger"
 	meta "github.com/openGemini/openGemini/lib/metaclient"
 	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/lib/statisticsPusher"
 	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
 	"github.com/openGemini/openGemini/lib/syscontrol"
 	"github.com/openGemini/openGemini/lib/tracing"
+	"github.com/openGemini/openGemini/lib/workload"
 	set "github.com/openGemini/openGemini/open_src/github.com/deckarep/golang-set"
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 	meta2 "github.com/openGemini/openGemini/open_src/influx/meta"
 	query2 "github.com/openGemini/openGemini/open_src/influx/query"
 	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/openGemini/openGemini/yacc"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -80,6 +98,13 @@ type StatementExecutor struct {
 	RetentionPolicyLimit    int
 	MaxQueryParallel        int
 
+	// Where the slow queries are persisted, empty if the persistence is disabled.
+	SlowQueryDatabase        string
+	SlowQueryRetentionPolicy string
+
+	// ReplicationEnabled is set if the accepted writes are shipped to a remote cluster.
+	ReplicationEnabled bool
+
 	StmtExecLogger *logger.Logger
 }
 
@@ -115,6 +140,23 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx *query
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
 		}
 		err = e.executeAlterShardKeyStatement(stmt)
+	case *influxql.AlterMeasurementTTLStatement:
+		if ctx.ReadOnly {
+			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
+		}
+		err = e.executeAlterMeasurementTTLStatement(stmt)
+	case *influxql.CompactStatement:
+		rows, err = e.executeCompactStatement(stmt)
+	case *influxql.CancelCompactionsStatement:
+		rows, err = e.executeCancelCompactionsStatement(stmt)
+	case *influxql.MigratePartitionStatement:
+		err = e.executeMigratePartitionStatement(stmt)
+	case *influxql.DecommissionNodeStatement:
+		err = e.executeDecommissionNodeStatement(stmt)
+	case *influxql.PauseReplicationStatement:
+		err = e.executePauseReplicationStatement()
+	case *influxql.ResumeReplicationStatement:
+		err = e.executeResumeReplicationStatement()
 	case *influxql.CreateDatabaseStatement:
 		if ctx.ReadOnly {
 			messages = append(messages, query.ReadOnlyWarning(stmt.String()))
@@ -207,7 +249,7 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx *query
 	case *influxql.ShowDatabasesStatement:
 		rows, err = e.executeShowDatabasesStatement(stmt, ctx)
 	case *influxql.ShowDiagnosticsStatement:
-		return meta2.ErrUnsupportCommand
+		rows, err = e.executeShowDiagnosticsStatement(stmt)
 	case *influxql.ShowGrantsForUserStatement:
 		rows, err = e.executeShowGrantsForUserStatement(stmt)
 	case *influxql.ShowMeasurementsStatement:
@@ -227,8 +269,18 @@ func (e *StatementExecutor) ExecuteStatement(stmt influxql.Statement, ctx *query
 		rows, err = e.retryExecuteStatement(stmt, ctx)
 	case *influxql.ShowShardsStatement:
 		rows, err = e.executeShowShardsStatement(stmt)
+	case *influxql.ShowCompactionsStatement:
+		rows, err = e.executeShowCompactionsStatement(stmt)
+	case *influxql.ShowEventsStatement:
+		rows, err = e.executeShowEventsStatement()
+	case *influxql.ShowDataNodesStatement:
+		rows, err = e.executeShowDataNodesStatement()
+	case *influxql.ShowStatsStatement:
+		rows, err = e.executeShowStatsStatement(stmt)
 	case *influxql.ShowShardGroupsStatement:
 		rows, err = e.executeShowShardGroupsStatement(stmt)
+	case *influxql.ShowSlowQueriesStatement:
+		return e.executeShowSlowQueriesStatement(stmt, ctx)
 	case *influxql.ShowSubscriptionsStatement:
 		return meta2.ErrUnsupportCommand
 		rows, err = e.executeShowSubscriptionsStatement(stmt)
@@ -383,6 +435,10 @@ func (e *StatementExecutor) executeCreateMeasurementStatement(stmt *influxql.Cre
 	if err := meta2.ValidShardKey(stmt.ShardKey); err != nil {
 		return err
 	}
+
+	if err := meta2.ValidFloatCodec(stmt.FloatCodec); err != nil {
+		return err
+	}
 	e.StmtExecLogger.Info("create measurement ", zap.String("name", stmt.Name))
 	ski := &meta2.ShardKeyInfo{ShardKey: stmt.ShardKey, Type: stmt.Type}
 	indexR := &meta2.IndexRelation{Oid: 1, IndexName: stmt.IndexType}
@@ -393,8 +449,11 @@ func (e *StatementExecutor) executeCreateMeasurementStatement(stmt *influxql.Cre
 		}
 	}
 	indexR.IndexList = indexLists
-	_, err := e.MetaClient.CreateMeasurement(stmt.Database, stmt.RetentionPolicy, stmt.Name, ski, indexR)
-	return err
+	_, err := e.MetaClient.CreateMeasurement(stmt.Database, stmt.RetentionPolicy, stmt.Name, ski, indexR, stmt.FloatCodec)
+	if err != nil || stmt.TTL == 0 {
+		return err
+	}
+	return e.MetaClient.AlterMeasurementTTL(stmt.Database, stmt.RetentionPolicy, stmt.Name, stmt.TTL)
 }
 
 func (e *StatementExecutor) executeAlterShardKeyStatement(stmt *influxql.AlterShardKeyStatement) error {
@@ -405,6 +464,11 @@ func (e *StatementExecutor) executeAlterShardKeyStatement(stmt *influxql.AlterSh
 	return e.MetaClient.AlterShardKey(stmt.Database, stmt.RetentionPolicy, stmt.Name, ski)
 }
 
+func (e *StatementExecutor) executeAlterMeasurementTTLStatement(stmt *influxql.AlterMeasurementTTLStatement) error {
+	e.StmtExecLogger.Info("alter measurement ttl", zap.String("name", stmt.Name), zap.Duration("ttl", stmt.TTL))
+	return e.MetaClient.AlterMeasurementTTL(stmt.Database, stmt.RetentionPolicy, stmt.Name, stmt.TTL)
+}
+
 func (e *StatementExecutor) executeCreateDatabaseStatement(stmt *influxql.CreateDatabaseStatement) error {
 	if !meta2.ValidName(stmt.Name) {
 		// TODO This should probably be in `(*meta.Data).CreateDatabase`
@@ -732,6 +796,14 @@ func (e *StatementExecutor) executeSelectStatement(stmt *influxql.SelectStatemen
 	ctx.ExecutionOptions.RowsChan = make(chan query2.RowsChan)
 	// omit Time field for stmt
 	stmt.OmitTime = true
+
+	group := workload.GetManager().Resolve(ctx.ExecutionOptions.UserID, stmt.Hints.WorkloadGroup())
+	if err := group.Acquire(ctx.Done()); err != nil {
+		return err
+	}
+	defer group.Release()
+	ctx.ExecutionOptions.WorkloadGroup = group.Name()
+
 	pipelineExecutor, err := e.retryCreatePipelineExecutor(ctx, stmt, ctx.ExecutionOptions)
 	if err == influxql.ErrDeclareEmptyCollection {
 		// skip empty collection err and return empty result set
@@ -766,8 +838,8 @@ func (e *StatementExecutor) executeSelectStatement(stmt *influxql.SelectStatemen
 		close(ec)
 	}()
 
+	qStat, _ := ctx.Value(query2.QueryDurationKey).(*statistics.SQLSlowQueryStatistics)
 	defer func() {
-		qStat, _ := ctx.Value(query2.QueryDurationKey).(*statistics.SQLSlowQueryStatistics)
 		if qStat != nil {
 			qStat.AddDuration("SqlIteratorDuration", end.Sub(start).Nanoseconds())
 			qStat.AddDuration("EmitDuration", time.Now().Sub(end).Nanoseconds())
@@ -787,6 +859,11 @@ func (e *StatementExecutor) executeSelectStatement(stmt *influxql.SelectStatemen
 				Series:  rowsChan.Rows,
 				Partial: rowsChan.Partial,
 			}
+			if qStat != nil {
+				for _, row := range rowsChan.Rows {
+					qStat.AddRows(len(row.Values))
+				}
+			}
 			// Send results or exit if closing.
 			if err := ctx.Send(result); err != nil {
 				pipelineExecutor.Abort()
@@ -805,6 +882,7 @@ func (e *StatementExecutor) executeSelectStatement(stmt *influxql.SelectStatemen
 	}
 
 	wg.Wait()
+	qStat.AddStageDurations(pipelineExecutor.StageDurations())
 	if err := <-ec; err != nil {
 		e.StmtExecLogger.Error("PipelineExecutor execute failed", zap.Error(err))
 		return err
@@ -837,6 +915,7 @@ func (e *StatementExecutor) createPipelineExecutor(ctx context.Context, stmt *in
 		ChunkSize:               opt.InnerChunkSize,
 		Traceid:                 opt.Traceid,
 		AbortChan:               opt.AbortCh,
+		WorkloadGroup:           opt.WorkloadGroup,
 	}
 
 	defer func() {
@@ -955,10 +1034,402 @@ func (e *StatementExecutor) executeShowShardsStatement(stmt *influxql.ShowShards
 	return e.MetaClient.ShowShards(), nil
 }
 
+func (e *StatementExecutor) executeShowCompactionsStatement(stmt *influxql.ShowCompactionsStatement) (models.Rows, error) {
+	req := netstorage.SysCtrlRequest{}
+	req.SetMod(syscontrol.Compactions)
+	req.SetParam(nil)
+	results, err := e.sysCtrlOnDataNodes(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// a node which fails is shown as a row with its error
+	row := &models.Row{Columns: []string{"id", "node", "shard", "measurement", "type", "level", "files", "bytes", "status", "elapsed", "error"}}
+	for _, host := range sortedHosts(results) {
+		res := results[host]
+		var infos []netstorage.CompactionInfo
+		if res.err == nil {
+			if err = json.Unmarshal([]byte(res.ret[syscontrol.Compactions]), &infos); err != nil {
+				res.err = fmt.Errorf("invalid compactions: %v", err)
+			}
+		}
+		if res.err != nil {
+			row.Values = append(row.Values, []interface{}{nil, host, nil, nil, nil, nil, nil, nil, "failure", nil, res.err.Error()})
+			continue
+		}
+		for _, info := range infos {
+			status := "queued"
+			if info.Running {
+				status = "running"
+			}
+			row.Values = append(row.Values, []interface{}{info.ID, host, info.ShardID, info.Measurement, info.Type,
+				info.Level, info.Files, info.Size, status, time.Duration(info.Elapsed).Truncate(time.Millisecond).String(), ""})
+		}
+	}
+	return models.Rows{row}, nil
+}
+
+func (e *StatementExecutor) executeCompactStatement(stmt *influxql.CompactStatement) (models.Rows, error) {
+	param, err := e.compactionParam(stmt.ShardID, stmt.Database, stmt.RetentionPolicy, stmt.Name)
+	if err != nil {
+		return nil, err
+	}
+	if stmt.Full {
+		param["full"] = "true"
+	}
+
+	e.StmtExecLogger.Info("compact", zap.Uint64("shard", stmt.ShardID), zap.String("name", stmt.Name), zap.Bool("full", stmt.Full))
+	req := netstorage.SysCtrlRequest{}
+	req.SetMod(syscontrol.Compact)
+	req.SetParam(param)
+	results, err := e.sysCtrlOnDataNodes(req)
+	if err != nil {
+		return nil, err
+	}
+	return sysCtrlRows(results), nil
+}
+
+func (e *StatementExecutor) executeCancelCompactionsStatement(stmt *influxql.CancelCompactionsStatement) (models.Rows, error) {
+	param := map[string]string{}
+	if stmt.ShardID != 0 || stmt.Name != "" {
+		var err error
+		if param, err = e.compactionParam(stmt.ShardID, stmt.Database, stmt.RetentionPolicy, stmt.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	e.StmtExecLogger.Info("cancel compactions", zap.Uint64("shard", stmt.ShardID), zap.String("name", stmt.Name))
+	req := netstorage.SysCtrlRequest{}
+	req.SetMod(syscontrol.CancelCompact)
+	req.SetParam(param)
+	results, err := e.sysCtrlOnDataNodes(req)
+	if err != nil {
+		return nil, err
+	}
+	return sysCtrlRows(results), nil
+}
+
+func (e *StatementExecutor) executeMigratePartitionStatement(stmt *influxql.MigratePartitionStatement) error {
+	e.StmtExecLogger.Info("migrate partition", zap.String("db", stmt.Database), zap.Uint32("pt", stmt.PtId),
+		zap.Uint64("node", stmt.NodeID))
+	return e.MetaClient.MigratePt(stmt.Database, stmt.PtId, stmt.NodeID)
+}
+
+func (e *StatementExecutor) executeDecommissionNodeStatement(stmt *influxql.DecommissionNodeStatement) error {
+	e.StmtExecLogger.Info("decommission node", zap.Uint64("node", stmt.NodeID))
+	return e.MetaClient.DecommissionDataNode(stmt.NodeID)
+}
+
+// executePauseReplicationStatement pauses the replication on all the SQL nodes, the switch is kept in the meta data
+func (e *StatementExecutor) executePauseReplicationStatement() error {
+	if !e.ReplicationEnabled {
+		return errors.New("replication is disabled")
+	}
+	e.StmtExecLogger.Info("pause replication")
+	return e.MetaClient.SetReplicationPaused(true)
+}
+
+func (e *StatementExecutor) executeResumeReplicationStatement() error {
+	if !e.ReplicationEnabled {
+		return errors.New("replication is disabled")
+	}
+	e.StmtExecLogger.Info("resume replication")
+	return e.MetaClient.SetReplicationPaused(false)
+}
+
+func (e *StatementExecutor) executeShowEventsStatement() (models.Rows, error) {
+	row := &models.Row{Columns: []string{"id", "type", "database", "pt", "src", "dst", "state", "bytes", "error"}}
+	for _, ev := range e.MetaClient.MigrateEvents() {
+		pt := ev.GetPtInfo()
+		row.Values = append(row.Values, []interface{}{ev.GetOpId(), meta2.EventTypeName(ev.GetEventType()), pt.Db,
+			pt.Pti.PtId, ev.GetSrc(), ev.GetDst(), ev.StateName(), ev.GetBytes(), ev.GetErr()})
+	}
+	return models.Rows{row}, nil
+}
+
+func (e *StatementExecutor) executeShowDataNodesStatement() (models.Rows, error) {
+	nodes, err := e.MetaClient.DataNodes()
+	if err != nil {
+		return nil, err
+	}
+	row := &models.Row{Columns: []string{"id", "http_addr", "tcp_addr", "status", "zone", "rack"}}
+	for _, n := range nodes {
+		row.Values = append(row.Values, []interface{}{n.ID, n.Host, n.TCPHost, n.Status.String(), n.Zone, n.Rack})
+	}
+	return models.Rows{row}, nil
+}
+
+func (e *StatementExecutor) executeShowStatsStatement(stmt *influxql.ShowStatsStatement) (models.Rows, error) {
+	data, err := e.collectNodeStats(syscontrol.Stats, stmt.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return statsRows(data, stmt.Module)
+}
+
+func (e *StatementExecutor) executeShowDiagnosticsStatement(stmt *influxql.ShowDiagnosticsStatement) (models.Rows, error) {
+	data, err := e.collectNodeStats(syscontrol.Diagnostics, stmt.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return statsRows(data, stmt.Module)
+}
+
+// collectNodeStats gathers the statistics or the diagnostics in line protocol from this sql node, the store nodes
+// and the meta nodes. Only the store or meta node of the id is asked when nodeID is not 0
+func (e *StatementExecutor) collectNodeStats(mod string, nodeID uint64) ([][]byte, error) {
+	req := netstorage.SysCtrlRequest{}
+	req.SetMod(mod)
+	req.SetParam(nil)
+
+	var data [][]byte
+	var stores map[string]sysCtrlResult
+	var metas map[string][]byte
+	var err error
+	if nodeID == 0 {
+		var local []byte
+		if mod == syscontrol.Diagnostics {
+			local = statisticsPusher.CollectDiagnostics(nil)
+		} else if local, err = statisticsPusher.CollectStatistics(nil); err != nil {
+			return nil, err
+		}
+		data = append(data, local)
+
+		if stores, err = e.sysCtrlOnDataNodes(req); err != nil {
+			return nil, err
+		}
+		if metas, err = e.MetaClient.MetaNodeStats(mod, 0); err != nil {
+			return nil, err
+		}
+	} else if node, nerr := e.MetaClient.DataNode(nodeID); nerr == nil {
+		res := e.sysCtrlOnNode(node, req)
+		if res.err != nil {
+			return nil, fmt.Errorf("%s: %v", node.Host, res.err)
+		}
+		stores = map[string]sysCtrlResult{node.Host: res}
+	} else if metas, err = e.MetaClient.MetaNodeStats(mod, nodeID); err != nil {
+		return nil, err
+	}
+
+	for host, res := range stores {
+		if res.err != nil {
+			// the statistics of the other nodes are still shown
+			e.StmtExecLogger.Warn("collect statistics failed", zap.String("node", host), zap.Error(res.err))
+			continue
+		}
+		data = append(data, []byte(res.ret[mod]))
+	}
+	for _, ret := range metas {
+		data = append(data, ret)
+	}
+	return data, nil
+}
+
+// statsRows converts the statistics or the diagnostics in line protocol to one row per module and tag set,
+// sorted by module and tags. The points of the same module and tags reported by several servers sharing
+// a process are shown once
+func statsRows(data [][]byte, module string) (models.Rows, error) {
+	// the key of a row is the module followed by the sorted tags
+	var keys []string
+	rows := make(map[string]*models.Row)
+	points := &influx.PointRows{}
+	for _, b := range data {
+		if len(b) == 0 {
+			continue
+		}
+		if err := points.Unmarshal(string(b)); err != nil {
+			return nil, err
+		}
+		for i := range points.Rows {
+			p := &points.Rows[i]
+			if module != "" && p.Name != module {
+				continue
+			}
+
+			tags := make(map[string]string, len(p.Tags))
+			key := p.Name
+			sort.Sort(&p.Tags)
+			for _, tag := range p.Tags {
+				tags[tag.Key] = tag.Value
+				key += "," + tag.Key + "=" + tag.Value
+			}
+			if _, ok := rows[key]; ok {
+				continue
+			}
+
+			sort.Sort(p.Fields)
+			row := &models.Row{Name: p.Name, Tags: tags, Columns: make([]string, 0, len(p.Fields))}
+			values := make([]interface{}, 0, len(p.Fields))
+			for _, f := range p.Fields {
+				row.Columns = append(row.Columns, f.Key)
+				values = append(values, statsValue(&f))
+			}
+			row.Values = [][]interface{}{values}
+			rows[key] = row
+			keys = append(keys, key)
+		}
+		points.Reset()
+	}
+
+	sort.Strings(keys)
+	ret := make(models.Rows, 0, len(keys))
+	for _, key := range keys {
+		ret = append(ret, rows[key])
+	}
+	return ret, nil
+}
+
+// statsValue returns the value of the field, the collectors write integers without the i suffix so whole numbers
+// are shown as integers
+func statsValue(f *influx.Field) interface{} {
+	switch f.Type {
+	case influx.Field_Type_String:
+		return f.StrValue
+	case influx.Field_Type_Boolean:
+		return f.NumValue != 0
+	case influx.Field_Type_Int:
+		return int64(f.NumValue)
+	case influx.Field_Type_UInt:
+		return uint64(f.NumValue)
+	}
+	if f.NumValue == math.Trunc(f.NumValue) && math.Abs(f.NumValue) < 1<<53 {
+		return int64(f.NumValue)
+	}
+	return f.NumValue
+}
+
+// compactionParam selects the shard, or the measurement in all shards of its retention policy, for the store nodes
+func (e *StatementExecutor) compactionParam(shardID uint64, database, rp, name string) (map[string]string, error) {
+	if name == "" {
+		if db, _, sg := e.MetaClient.ShardOwner(shardID); db == "" || sg == nil {
+			return nil, fmt.Errorf("shard %d not found", shardID)
+		}
+		return map[string]string{"shid": strconv.FormatUint(shardID, 10)}, nil
+	}
+
+	if rp == "" {
+		dbi, err := e.MetaClient.Database(database)
+		if err != nil {
+			return nil, err
+		}
+		rp = dbi.DefaultRetentionPolicy
+	}
+	if _, err := e.MetaClient.Measurement(database, rp, name); err != nil {
+		return nil, err
+	}
+	return map[string]string{"db": database, "rp": rp, "mst": name}, nil
+}
+
+// sysCtrlResult is the result of a system control request on a store node
+type sysCtrlResult struct {
+	ret map[string]string
+	err error
+}
+
+// sysCtrlOnDataNodes sends the request to all data nodes and returns their results by host,
+// a node which fails does not fail the request on the other nodes
+func (e *StatementExecutor) sysCtrlOnDataNodes(req netstorage.SysCtrlRequest) (map[string]sysCtrlResult, error) {
+	nodes, err := e.MetaClient.DataNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]sysCtrlResult, len(nodes))
+	for i := range nodes {
+		wg.Add(1)
+		go func(node *meta2.DataNode) {
+			defer wg.Done()
+			res := e.sysCtrlOnNode(node, req)
+			mu.Lock()
+			results[node.Host] = res
+			mu.Unlock()
+		}(&nodes[i])
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (e *StatementExecutor) sysCtrlOnNode(node *meta2.DataNode, req netstorage.SysCtrlRequest) sysCtrlResult {
+	ret, err := e.NetStorage.SendSysCtrlOnNode(node.ID, req)
+	if err == nil && ret[node.TCPHost] == "failure" {
+		err = errors.New(ret[netstorage.SysCtrlErrorKey])
+	}
+	return sysCtrlResult{ret: ret, err: err}
+}
+
+// sysCtrlRows returns the status of the request on every store node
+func sysCtrlRows(results map[string]sysCtrlResult) models.Rows {
+	row := &models.Row{Columns: []string{"node", "status", "error"}}
+	for _, host := range sortedHosts(results) {
+		if err := results[host].err; err != nil {
+			row.Values = append(row.Values, []interface{}{host, "failure", err.Error()})
+			continue
+		}
+		row.Values = append(row.Values, []interface{}{host, "success", ""})
+	}
+	return models.Rows{row}
+}
+
+func sortedHosts(results map[string]sysCtrlResult) []string {
+	hosts := make([]string, 0, len(results))
+	for host := range results {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
 func (e *StatementExecutor) executeShowShardGroupsStatement(stmt *influxql.ShowShardGroupsStatement) (models.Rows, error) {
 	return e.MetaClient.ShowShardGroups(), nil
 }
 
+// executeShowSlowQueriesStatement lists the persisted slow queries, newest first.
+// The statement is rewritten into a SELECT which is prepared like the queries of the users.
+func (e *StatementExecutor) executeShowSlowQueriesStatement(stmt *influxql.ShowSlowQueriesStatement, ctx *query2.ExecutionContext) error {
+	selectStmt, err := e.rewriteShowSlowQueriesStatement(stmt)
+	if err != nil {
+		return err
+	}
+	return e.executeSelectStatement(selectStmt, ctx)
+}
+
+func (e *StatementExecutor) rewriteShowSlowQueriesStatement(stmt *influxql.ShowSlowQueriesStatement) (*influxql.SelectStatement, error) {
+	if e.SlowQueryDatabase == "" {
+		return nil, errors.New("slow query persistence is disabled")
+	}
+
+	mst := &influxql.Measurement{
+		Database:        e.SlowQueryDatabase,
+		RetentionPolicy: e.SlowQueryRetentionPolicy,
+		Name:            config.DefaultSlowQueryMeasurement,
+	}
+	q := fmt.Sprintf("SELECT * FROM %s ORDER BY time DESC", mst.String())
+	if stmt.Limit > 0 {
+		q += fmt.Sprintf(" LIMIT %d", stmt.Limit)
+	}
+
+	parser := &yacc.YyParser{Query: influxql.Query{}, Scanner: influxql.NewScanner(strings.NewReader(q))}
+	parser.ParseTokens()
+	parsed, err := parser.GetQuery()
+	if err != nil {
+		return nil, err
+	}
+	rewritten, err := query2.RewriteStatement(parsed.Statements[0])
+	if err != nil {
+		return nil, err
+	}
+	if err = e.NormalizeStatement(rewritten, e.SlowQueryDatabase, e.SlowQueryRetentionPolicy); err != nil {
+		return nil, err
+	}
+	selectStmt, ok := rewritten.(*influxql.SelectStatement)
+	if !ok {
+		return nil, fmt.Errorf("unexpected statement %T for %s", rewritten, stmt)
+	}
+	return selectStmt, nil
+}
+
 func (e *StatementExecutor) executeShowSubscriptionsStatement(stmt *influxql.ShowSubscriptionsStatement) (models.Rows, error) {
 	return e.MetaClient.ShowSubscriptions(), nil
 }
@@ -1498,6 +1969,18 @@ func (e *StatementExecutor) NormalizeStatement(stmt influxql.Statement, defaultD
 			if node.Database == "" {
 				node.Database = defaultDatabase
 			}
+		case *influxql.AlterMeasurementTTLStatement:
+			if node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.CompactStatement:
+			if node.Name != "" && node.Database == "" {
+				node.Database = defaultDatabase
+			}
+		case *influxql.CancelCompactionsStatement:
+			if node.Name != "" && node.Database == "" {
+				node.Database = defaultDatabase
+			}
 		case *influxql.Measurement:
 			switch stmt.(type) {
 			case *influxql.DropSeriesStatement, *influxql.DeleteSeriesStatement:
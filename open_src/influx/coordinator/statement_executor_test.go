@@ -0,0 +1,113 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package coordinator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/logger"
+	"github.com/openGemini/openGemini/lib/metaclient"
+	"github.com/openGemini/openGemini/lib/netstorage"
+	"github.com/openGemini/openGemini/lib/syscontrol"
+	"github.com/openGemini/openGemini/open_src/influx/influxql"
+	meta2 "github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMetaClient struct {
+	metaclient.MetaClient
+	nodes []meta2.DataNode
+}
+
+func (c *mockMetaClient) DataNodes() ([]meta2.DataNode, error) {
+	return c.nodes, nil
+}
+
+func (c *mockMetaClient) Database(name string) (*meta2.DatabaseInfo, error) {
+	return &meta2.DatabaseInfo{Name: name, DefaultRetentionPolicy: "autogen"}, nil
+}
+
+func TestStatementExecutor_RewriteShowSlowQueries(t *testing.T) {
+	e := &StatementExecutor{MetaClient: &mockMetaClient{}}
+
+	_, err := e.rewriteShowSlowQueriesStatement(&influxql.ShowSlowQueriesStatement{})
+	assert.EqualError(t, err, "slow query persistence is disabled")
+
+	e.SlowQueryDatabase = config.DefaultSlowQueryDatabase
+	stmt, err := e.rewriteShowSlowQueriesStatement(&influxql.ShowSlowQueriesStatement{Limit: 5})
+	require.NoError(t, err)
+	// the retention policy is normalized to the default one of the database
+	assert.Equal(t, "SELECT * FROM _internal.autogen.slow_queries ORDER BY time DESC LIMIT 5", stmt.String())
+	assert.True(t, stmt.IsRawQuery)
+
+	e.SlowQueryRetentionPolicy = "rp0"
+	stmt, err = e.rewriteShowSlowQueriesStatement(&influxql.ShowSlowQueriesStatement{})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM _internal.rp0.slow_queries ORDER BY time DESC", stmt.String())
+	assert.Equal(t, 0, stmt.Limit)
+}
+
+type mockNetStorage struct {
+	netstorage.Storage
+	results map[uint64]map[string]string
+}
+
+func (s *mockNetStorage) SendSysCtrlOnNode(nodeID uint64, req netstorage.SysCtrlRequest) (map[string]string, error) {
+	ret, ok := s.results[nodeID]
+	if !ok {
+		return nil, errors.New("connection refused")
+	}
+	return ret, nil
+}
+
+func TestStatementExecutor_CompactionsNodeErrors(t *testing.T) {
+	e := &StatementExecutor{
+		MetaClient: &mockMetaClient{nodes: []meta2.DataNode{
+			{NodeInfo: meta2.NodeInfo{ID: 1, Host: "h1", TCPHost: "t1"}},
+			{NodeInfo: meta2.NodeInfo{ID: 2, Host: "h2", TCPHost: "t2"}},
+			{NodeInfo: meta2.NodeInfo{ID: 3, Host: "h3", TCPHost: "t3"}},
+		}},
+		NetStorage: &mockNetStorage{results: map[uint64]map[string]string{
+			1: {"t1": "success", syscontrol.Compactions: `[{"id":7,"shard":4,"measurement":"cpu","type":"level","level":1,"files":2,"bytes":10,"running":true}]`},
+			2: {"t2": "failure", netstorage.SysCtrlErrorKey: "disk full"},
+		}},
+		StmtExecLogger: logger.NewLogger(errno.ModuleUnknown),
+	}
+
+	// the failed nodes are reported as rows, the others are still shown
+	rows, err := e.executeShowCompactionsStatement(&influxql.ShowCompactionsStatement{})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, [][]interface{}{
+		{uint64(7), "h1", uint64(4), "cpu", "level", uint16(1), 2, int64(10), "running", "0s", ""},
+		{nil, "h2", nil, nil, nil, nil, nil, nil, "failure", nil, "disk full"},
+		{nil, "h3", nil, nil, nil, nil, nil, nil, "failure", nil, "connection refused"},
+	}, rows[0].Values)
+
+	rows, err = e.executeCancelCompactionsStatement(&influxql.CancelCompactionsStatement{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node", "status", "error"}, rows[0].Columns)
+	assert.Equal(t, [][]interface{}{
+		{"h1", "success", ""},
+		{"h2", "failure", "disk full"},
+		{"h3", "failure", "connection refused"},
+	}, rows[0].Values)
+}
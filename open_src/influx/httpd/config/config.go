@@ -75,6 +75,8 @@ type Config struct {
 	QueryMemoryLimitEnabled bool           `toml:"query-memory-limit-enabled"`
 	ChunkReaderParallel     int            `toml:"chunk-reader-parallel"`
 	ReadBlockSize           toml.Size      `toml:"read-block-size"`
+	// the writes of these users carrying a replication batch id are not replicated again, auth-enabled must be set
+	ReplicationUsers []string `toml:"replication-users"`
 }
 
 // NewHttpConfig returns a new Config with default settings.
@@ -138,6 +138,10 @@ type Handler struct {
 
 	QueryExecutor *query2.Executor
 
+	SlowQueryRecorder interface {
+		Record(stat *statistics.SQLSlowQueryStatistics, d time.Duration)
+	}
+
 	Monitor interface {
 	}
 
@@ -464,12 +468,18 @@ func (h *Handler) serveQuery(w http.ResponseWriter, r *http.Request, user meta2.
 	if !isInternalDatabase(db) {
 		qDuration = statistics.NewSqlSlowQueryStatistics()
 		qDuration.SetDatabase(db)
+		if user != nil {
+			qDuration.SetUser(user.ID())
+		}
 		defer func() {
 			d := time.Now().Sub(start)
 			if d.Nanoseconds() > time.Second.Nanoseconds()*10 {
 				qDuration.AddDuration("TotalDuration", d.Nanoseconds())
 				statistics.AppendSqlQueryDuration(qDuration)
 			}
+			if h.SlowQueryRecorder != nil {
+				h.SlowQueryRecorder.Record(qDuration, d)
+			}
 			h.Logger.Info("sql query duration", zap.Duration("duration", d))
 		}()
 	}
@@ -586,6 +596,9 @@ func (h *Handler) serveQuery(w http.ResponseWriter, r *http.Request, user meta2.
 		Quiet:   true,
 		Traceid: traceId,
 	}
+	if user != nil {
+		opts.UserID = user.ID()
+	}
 
 	if h.Config.AuthEnabled {
 		if user != nil && user.AuthorizeUnrestricted() {
@@ -889,6 +902,13 @@ func (h *Handler) serveWrite(w http.ResponseWriter, r *http.Request, user meta2.
 		}
 	}
 
+	writePointRows := h.PointsWriter.WritePointRows
+	if r.Header.Get(ReplicationBatchIDHeader) != "" && h.isReplicationUser(user) {
+		if pw, ok := h.PointsWriter.(replicatedPointsWriter); ok {
+			writePointRows = pw.WriteReplicatedPointRows
+		}
+	}
+
 	precision := r.URL.Query().Get("precision")
 
 	tsMultiplier := int64(1)
@@ -925,7 +945,7 @@ func (h *Handler) serveWrite(w http.ResponseWriter, r *http.Request, user meta2.
 			if atomic.LoadInt32(&syscontrol.LogRowsRuleSwitch) == 1 {
 				h.logRowsIfNecessary(rows, uw.ReqBuf)
 			}
-			if err = h.PointsWriter.WritePointRows(db, r.URL.Query().Get("rp"), rows); err != nil {
+			if err = writePointRows(db, r.URL.Query().Get("rp"), rows); err != nil {
 				ctx.CallbackErrLock.Lock()
 				if ctx.CallbackErr == nil {
 					ctx.CallbackErr = err
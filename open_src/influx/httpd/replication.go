@@ -0,0 +1,46 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpd
+
+import (
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+)
+
+// ReplicationBatchIDHeader carries the id of a batch replicated from another cluster,
+// formatted as <source id>-<sequence>. The rows of the batch are not replicated again.
+// A batch shipped again after a failure is written again, it overwrites the same points.
+const ReplicationBatchIDHeader = "X-Replication-Batch-Id"
+
+// replicatedPointsWriter is implemented by the points writers which do not replicate the written rows again.
+type replicatedPointsWriter interface {
+	WriteReplicatedPointRows(database, retentionPolicy string, rows []influx.Row) error
+}
+
+// isReplicationUser returns true if the user is one of the replication-users, only their writes can be marked
+// as replicated. The header is ignored if auth-enabled is not set.
+func (h *Handler) isReplicationUser(user meta.User) bool {
+	if !h.Config.AuthEnabled || user == nil {
+		return false
+	}
+	for _, name := range h.Config.ReplicationUsers {
+		if name == user.ID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/services/httpd"
+	"github.com/openGemini/openGemini/open_src/influx/httpd/config"
+	meta2 "github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWriteMetaClient struct{}
+
+func (c *mockWriteMetaClient) Database(name string) (*meta2.DatabaseInfo, error) {
+	return &meta2.DatabaseInfo{Name: name}, nil
+}
+
+func (c *mockWriteMetaClient) Authenticate(username, password string) (meta2.User, error) {
+	return nil, nil
+}
+
+func (c *mockWriteMetaClient) User(username string) (meta2.User, error) {
+	return nil, nil
+}
+
+func (c *mockWriteMetaClient) AdminUserExists() bool {
+	return false
+}
+
+func (c *mockWriteMetaClient) DataNodes() ([]meta2.DataNode, error) {
+	return nil, nil
+}
+
+func (c *mockWriteMetaClient) ShowShards() models.Rows {
+	return nil
+}
+
+type mockPointsWriter struct {
+	written    int
+	replicated int
+}
+
+func (w *mockPointsWriter) WritePointRows(database, retentionPolicy string, rows []influx.Row) error {
+	w.written += len(rows)
+	return nil
+}
+
+func (w *mockPointsWriter) WriteReplicatedPointRows(database, retentionPolicy string, rows []influx.Row) error {
+	w.replicated += len(rows)
+	return nil
+}
+
+type mockWriteAuthorizer struct{}
+
+func (a *mockWriteAuthorizer) AuthorizeWrite(username, database string) error {
+	return nil
+}
+
+func TestServeWrite_ReplicatedBatch(t *testing.T) {
+	influx.StartUnmarshalWorkers()
+	defer influx.StopUnmarshalWorkers()
+
+	c := config.NewConfig()
+	c.AuthEnabled = true
+	c.ReplicationUsers = []string{"replicator"}
+	pw := &mockPointsWriter{}
+	h := &Handler{
+		Config:          &c,
+		MetaClient:      &mockWriteMetaClient{},
+		PointsWriter:    pw,
+		WriteAuthorizer: &mockWriteAuthorizer{},
+		requestTracker:  httpd.NewRequestTracker(),
+	}
+
+	write := func(user, batchID string) int {
+		r := httptest.NewRequest(http.MethodPost, "/write?db=db0&precision=ns", strings.NewReader("cpu value=1 1\n"))
+		if batchID != "" {
+			r.Header.Set(ReplicationBatchIDHeader, batchID)
+		}
+		w := httptest.NewRecorder()
+		h.serveWrite(w, r, &meta2.UserInfo{Name: user})
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, write("replicator", ""))
+	assert.Equal(t, 1, pw.written)
+
+	// the rows replicated by the replication user are not replicated again, the batches shipped again are written again
+	assert.Equal(t, http.StatusNoContent, write("replicator", "source-1"))
+	assert.Equal(t, http.StatusNoContent, write("replicator", "source-1"))
+	assert.Equal(t, 2, pw.replicated)
+	assert.Equal(t, 1, pw.written)
+
+	// the header is ignored for the other users
+	assert.Equal(t, http.StatusNoContent, write("writer", "source-2"))
+	assert.Equal(t, 2, pw.replicated)
+	assert.Equal(t, 2, pw.written)
+
+	// and if auth is not enabled
+	c.AuthEnabled = false
+	assert.Equal(t, http.StatusNoContent, write("replicator", "source-3"))
+	assert.Equal(t, 2, pw.replicated)
+	assert.Equal(t, 3, pw.written)
+}
@@ -5,6 +5,16 @@ This code is originally from: https://github.com/influxdata/influxql/blob/v1.1.0
 2022.01.23 changed.
 Add statement cases:
 AlterShardKeyStatement
+AlterMeasurementTTLStatement
+ShowCompactionsStatement
+CompactStatement
+CancelCompactionsStatement
+MigratePartitionStatement
+DecommissionNodeStatement
+ShowEventsStatement
+PauseReplicationStatement
+ResumeReplicationStatement
+ShowDataNodesStatement
 ShowFieldKeysStatement
 ShowFieldKeyCardinalityStatement
 ShowTagKeyCardinalityStatement
@@ -253,6 +263,15 @@ func (*CreateContinuousQueryStatement) node()      {}
 func (*CreateDatabaseStatement) node()             {}
 func (*CreateMeasurementStatement) node()          {}
 func (*AlterShardKeyStatement) node()              {}
+func (*AlterMeasurementTTLStatement) node()        {}
+func (*CancelCompactionsStatement) node()          {}
+func (*MigratePartitionStatement) node()           {}
+func (*DecommissionNodeStatement) node()           {}
+func (*ShowEventsStatement) node()                 {}
+func (*ShowDataNodesStatement) node()              {}
+func (*PauseReplicationStatement) node()           {}
+func (*ResumeReplicationStatement) node()          {}
+func (*CompactStatement) node()                    {}
 func (*CreateRetentionPolicyStatement) node()      {}
 func (*CreateSubscriptionStatement) node()         {}
 func (*CreateUserStatement) node()                 {}
@@ -288,6 +307,8 @@ func (*ShowSeriesStatement) node()                 {}
 func (*ShowSeriesCardinalityStatement) node()      {}
 func (*ShowShardGroupsStatement) node()            {}
 func (*ShowShardsStatement) node()                 {}
+func (*ShowCompactionsStatement) node()            {}
+func (*ShowSlowQueriesStatement) node()            {}
 func (*ShowStatsStatement) node()                  {}
 func (*ShowSubscriptionsStatement) node()          {}
 func (*ShowDiagnosticsStatement) node()            {}
@@ -392,6 +413,15 @@ func (*CreateContinuousQueryStatement) stmt()      {}
 func (*CreateDatabaseStatement) stmt()             {}
 func (*CreateMeasurementStatement) stmt()          {}
 func (*AlterShardKeyStatement) stmt()              {}
+func (*AlterMeasurementTTLStatement) stmt()        {}
+func (*CancelCompactionsStatement) stmt()          {}
+func (*MigratePartitionStatement) stmt()           {}
+func (*DecommissionNodeStatement) stmt()           {}
+func (*ShowEventsStatement) stmt()                 {}
+func (*ShowDataNodesStatement) stmt()              {}
+func (*PauseReplicationStatement) stmt()           {}
+func (*ResumeReplicationStatement) stmt()          {}
+func (*CompactStatement) stmt()                    {}
 func (*CreateRetentionPolicyStatement) stmt()      {}
 func (*CreateSubscriptionStatement) stmt()         {}
 func (*CreateUserStatement) stmt()                 {}
@@ -421,6 +451,8 @@ func (*ShowSeriesStatement) stmt()                 {}
 func (*ShowSeriesCardinalityStatement) stmt()      {}
 func (*ShowShardGroupsStatement) stmt()            {}
 func (*ShowShardsStatement) stmt()                 {}
+func (*ShowCompactionsStatement) stmt()            {}
+func (*ShowSlowQueriesStatement) stmt()            {}
 func (*ShowStatsStatement) stmt()                  {}
 func (*DropShardStatement) stmt()                  {}
 func (*ShowSubscriptionsStatement) stmt()          {}
@@ -702,6 +734,8 @@ type CreateMeasurementStatement struct {
 	Type            string
 	IndexType       []string
 	IndexList       [][]string
+	FloatCodec      string
+	TTL             time.Duration
 }
 
 func (s *CreateMeasurementStatement) String() string {
@@ -740,6 +774,16 @@ func (s *CreateMeasurementStatement) String() string {
 
 	}
 
+	if s.FloatCodec != "" {
+		_, _ = buf.WriteString(" CODEC ")
+		_, _ = buf.WriteString(s.FloatCodec)
+	}
+
+	if s.TTL > 0 {
+		_, _ = buf.WriteString(" TTL ")
+		_, _ = buf.WriteString(FormatDuration(s.TTL))
+	}
+
 	return buf.String()
 }
 
@@ -784,6 +828,39 @@ func (s *AlterShardKeyStatement) RequiredPrivileges() (ExecutionPrivileges, erro
 	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
 }
 
+// AlterMeasurementTTLStatement represents a command to change how long the rows of a measurement are kept.
+type AlterMeasurementTTLStatement struct {
+	Database        string
+	RetentionPolicy string
+	Name            string
+	// zero keeps the rows as long as the retention policy
+	TTL time.Duration
+}
+
+func (s *AlterMeasurementTTLStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("ALTER MEASUREMENT ")
+	if s.Database != "" {
+		_, _ = buf.WriteString(QuoteIdent(s.Database))
+		_, _ = buf.WriteString(".")
+	}
+
+	if s.RetentionPolicy != "" {
+		_, _ = buf.WriteString(QuoteIdent(s.RetentionPolicy))
+		_, _ = buf.WriteString(".")
+	}
+
+	_, _ = buf.WriteString(QuoteIdent(s.Name))
+	_, _ = buf.WriteString(" WITH TTL ")
+	_, _ = buf.WriteString(FormatDuration(s.TTL))
+
+	return buf.String()
+}
+
+func (s *AlterMeasurementTTLStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
 // DropDatabaseStatement represents a command to drop a database.
 type DropDatabaseStatement struct {
 	// Name of the database to be dropped.
@@ -3124,6 +3201,28 @@ func (s *ShowQueriesStatement) RequiredPrivileges() (ExecutionPrivileges, error)
 	return ExecutionPrivileges{{Admin: false, Name: "", Rwuser: true, Privilege: ReadPrivilege}}, nil
 }
 
+// ShowSlowQueriesStatement represents a command for listing the persisted slow queries.
+type ShowSlowQueriesStatement struct {
+	// Maximum number of slow queries to return, 0 means no limit.
+	Limit int
+}
+
+// String returns a string representation of the show slow queries statement.
+func (s *ShowSlowQueriesStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("SHOW SLOW QUERIES")
+	if s.Limit > 0 {
+		_, _ = buf.WriteString(" LIMIT ")
+		_, _ = buf.WriteString(strconv.Itoa(s.Limit))
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privilege required to execute a ShowSlowQueriesStatement.
+func (s *ShowSlowQueriesStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
 // ShowRetentionPoliciesStatement represents a command for listing retention policies.
 type ShowRetentionPoliciesStatement struct {
 	// Name of the database to list policies for.
@@ -3154,6 +3253,9 @@ func (s *ShowRetentionPoliciesStatement) DefaultDatabase() string {
 // ShowStatsStatement displays statistics for a given module.
 type ShowStatsStatement struct {
 	Module string
+
+	// NodeID limits the statistics to one store or meta node, all nodes are shown when it is 0
+	NodeID uint64
 }
 
 // String returns a string representation of a ShowStatsStatement.
@@ -3164,6 +3266,10 @@ func (s *ShowStatsStatement) String() string {
 		_, _ = buf.WriteString(" FOR ")
 		_, _ = buf.WriteString(QuoteString(s.Module))
 	}
+	if s.NodeID != 0 {
+		_, _ = buf.WriteString(" ON NODE ")
+		_, _ = buf.WriteString(strconv.FormatUint(s.NodeID, 10))
+	}
 	return buf.String()
 }
 
@@ -3194,10 +3300,183 @@ func (s *ShowShardsStatement) RequiredPrivileges() (ExecutionPrivileges, error)
 	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
 }
 
+// ShowCompactionsStatement represents a command for displaying the queued and running compactions in the cluster.
+type ShowCompactionsStatement struct{}
+
+// String returns a string representation.
+func (s *ShowCompactionsStatement) String() string { return "SHOW COMPACTIONS" }
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *ShowCompactionsStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// CompactStatement represents a command to compact a shard, or a measurement in all of its shards.
+type CompactStatement struct {
+	// ShardID is set when compacting a shard, the measurement fields are empty then
+	ShardID uint64
+
+	Database        string
+	RetentionPolicy string
+	Name            string
+
+	// Full merges all files of a measurement into one level instead of running the level compactions
+	Full bool
+}
+
+// String returns a string representation.
+func (s *CompactStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("COMPACT ")
+	if s.Name == "" {
+		_, _ = buf.WriteString("SHARD ")
+		_, _ = buf.WriteString(strconv.FormatUint(s.ShardID, 10))
+	} else {
+		_, _ = buf.WriteString("MEASUREMENT ")
+		writeQualifiedMeasurement(&buf, s.Database, s.RetentionPolicy, s.Name)
+	}
+	if s.Full {
+		_, _ = buf.WriteString(" FULL")
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *CompactStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// CancelCompactionsStatement represents a command to cancel the queued and running compactions
+// of a shard, of a measurement, or of the whole cluster when neither is given.
+type CancelCompactionsStatement struct {
+	ShardID uint64
+
+	Database        string
+	RetentionPolicy string
+	Name            string
+}
+
+// String returns a string representation.
+func (s *CancelCompactionsStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("CANCEL COMPACTIONS")
+	if s.ShardID != 0 {
+		_, _ = buf.WriteString(" SHARD ")
+		_, _ = buf.WriteString(strconv.FormatUint(s.ShardID, 10))
+	} else if s.Name != "" {
+		_, _ = buf.WriteString(" MEASUREMENT ")
+		writeQualifiedMeasurement(&buf, s.Database, s.RetentionPolicy, s.Name)
+	}
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *CancelCompactionsStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// MigratePartitionStatement represents a command to move a partition of a database to another data node.
+type MigratePartitionStatement struct {
+	Database string
+	PtId     uint32
+	NodeID   uint64
+}
+
+// String returns a string representation.
+func (s *MigratePartitionStatement) String() string {
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("MIGRATE PARTITION ")
+	_, _ = buf.WriteString(QuoteIdent(s.Database))
+	_, _ = buf.WriteString(".")
+	_, _ = buf.WriteString(strconv.FormatUint(uint64(s.PtId), 10))
+	_, _ = buf.WriteString(" TO NODE ")
+	_, _ = buf.WriteString(strconv.FormatUint(s.NodeID, 10))
+	return buf.String()
+}
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *MigratePartitionStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// DecommissionNodeStatement represents a command to move all partitions off a data node and remove the node.
+type DecommissionNodeStatement struct {
+	NodeID uint64
+}
+
+// String returns a string representation.
+func (s *DecommissionNodeStatement) String() string {
+	return "DECOMMISSION NODE " + strconv.FormatUint(s.NodeID, 10)
+}
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *DecommissionNodeStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// ShowEventsStatement represents a command for displaying the events assigning and moving partitions.
+type ShowEventsStatement struct{}
+
+// String returns a string representation.
+func (s *ShowEventsStatement) String() string { return "SHOW EVENTS" }
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *ShowEventsStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// ShowDataNodesStatement represents a command for listing the data nodes with their zones and racks.
+type ShowDataNodesStatement struct{}
+
+// String returns a string representation.
+func (s *ShowDataNodesStatement) String() string { return "SHOW DATA NODES" }
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *ShowDataNodesStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// PauseReplicationStatement represents a command to stop shipping the writes queued for replication to the remote cluster.
+type PauseReplicationStatement struct{}
+
+// String returns a string representation.
+func (s *PauseReplicationStatement) String() string { return "PAUSE REPLICATION" }
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *PauseReplicationStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+// ResumeReplicationStatement represents a command to restart shipping the writes queued for replication to the remote cluster.
+type ResumeReplicationStatement struct{}
+
+// String returns a string representation.
+func (s *ResumeReplicationStatement) String() string { return "RESUME REPLICATION" }
+
+// RequiredPrivileges returns the privileges required to execute the statement.
+func (s *ResumeReplicationStatement) RequiredPrivileges() (ExecutionPrivileges, error) {
+	return ExecutionPrivileges{{Admin: true, Name: "", Rwuser: true, Privilege: AllPrivileges}}, nil
+}
+
+func writeQualifiedMeasurement(buf *bytes.Buffer, db, rp, name string) {
+	if db != "" {
+		_, _ = buf.WriteString(QuoteIdent(db))
+		_, _ = buf.WriteString(".")
+	}
+	if rp != "" {
+		_, _ = buf.WriteString(QuoteIdent(rp))
+		_, _ = buf.WriteString(".")
+	}
+	_, _ = buf.WriteString(QuoteIdent(name))
+}
+
 // ShowDiagnosticsStatement represents a command for show node diagnostics.
 type ShowDiagnosticsStatement struct {
 	// Module
 	Module string
+
+	// NodeID limits the diagnostics to one store or meta node, all nodes are shown when it is 0
+	NodeID uint64
 }
 
 // String returns a string representation of the ShowDiagnosticsStatement.
@@ -3208,6 +3487,10 @@ func (s *ShowDiagnosticsStatement) String() string {
 		_, _ = buf.WriteString(" FOR ")
 		_, _ = buf.WriteString(QuoteString(s.Module))
 	}
+	if s.NodeID != 0 {
+		_, _ = buf.WriteString(" ON NODE ")
+		_, _ = buf.WriteString(strconv.FormatUint(s.NodeID, 10))
+	}
 	return buf.String()
 }
 
@@ -3848,6 +4131,16 @@ func (a Hints) String() string {
 	return strings.Join(str, ", ")
 }
 
+// WorkloadGroup returns the workload group given by the workload_group hint, or "" if there is none
+func (a Hints) WorkloadGroup() string {
+	for _, h := range a {
+		if s := h.String(); strings.HasPrefix(s, WorkloadGroupHint) {
+			return strings.TrimPrefix(s, WorkloadGroupHint)
+		}
+	}
+	return ""
+}
+
 // Measurements represents a list of measurements.
 type Measurements []*Measurement
 
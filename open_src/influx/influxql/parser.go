@@ -39,6 +39,9 @@ const (
 	FilterNullColumn = "filter_null_column"
 
 	ExactStatisticQuery = "exact_statistic_query"
+
+	// WorkloadGroupHint maps the query to a workload group, e.g. /*+ workload_group=realtime */
+	WorkloadGroupHint = "workload_group="
 )
 
 var SupportHit = map[string]bool{
@@ -2197,11 +2200,14 @@ func (p *Parser) parseShowStatsStatement() (*ShowStatsStatement, error) {
 	var err error
 
 	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == FOR {
-		stmt.Module, err = p.parseString()
+		if stmt.Module, err = p.parseString(); err != nil {
+			return nil, err
+		}
 	} else {
 		p.Unscan()
 	}
 
+	stmt.NodeID, err = p.parseOnNode()
 	return stmt, err
 }
 
@@ -2211,14 +2217,29 @@ func (p *Parser) parseShowDiagnosticsStatement() (*ShowDiagnosticsStatement, err
 	var err error
 
 	if tok, _, _ := p.ScanIgnoreWhitespace(); tok == FOR {
-		stmt.Module, err = p.parseString()
+		if stmt.Module, err = p.parseString(); err != nil {
+			return nil, err
+		}
 	} else {
 		p.Unscan()
 	}
 
+	stmt.NodeID, err = p.parseOnNode()
 	return stmt, err
 }
 
+// parseOnNode parses the optional "ON NODE <id>" clause, it returns 0 when the clause is absent.
+func (p *Parser) parseOnNode() (uint64, error) {
+	if tok, _, _ := p.ScanIgnoreWhitespace(); tok != ON {
+		p.Unscan()
+		return 0, nil
+	}
+	if tok, pos, lit := p.ScanIgnoreWhitespace(); tok != NODE {
+		return 0, newParseError(tokstr(tok, lit), []string{"NODE"}, pos)
+	}
+	return p.ParseUInt64()
+}
+
 // parseDropContinuousQueriesStatement parses a string and returns a DropContinuousQueryStatement.
 // This function assumes the "DROP CONTINUOUS" tokens have already been consumed.
 func (p *Parser) parseDropContinuousQueryStatement() (*DropContinuousQueryStatement, error) {
@@ -3124,7 +3145,14 @@ func (p *Parser) parseResample() (time.Duration, time.Duration, error) {
 }
 
 // scan returns the next token from the underlying scanner.
-func (p *Parser) Scan() (tok Token, pos Pos, lit string) { return p.s.Scan() }
+// The unreserved keywords are only known by the yacc parser, they are identifiers here.
+func (p *Parser) Scan() (tok Token, pos Pos, lit string) {
+	tok, pos, lit = p.s.Scan()
+	if isUnreservedKeyword(tok) {
+		tok = IDENT
+	}
+	return
+}
 
 // ScanIgnoreWhitespace scans the next non-whitespace and non-comment token.
 func (p *Parser) ScanIgnoreWhitespace() (tok Token, pos Pos, lit string) {
@@ -3329,7 +3357,7 @@ func QuoteIdent(segments ...string) string {
 func IdentNeedsQuotes(ident string) bool {
 	// check if this identifier is a keyword
 	tok := Lookup(ident)
-	if tok != IDENT {
+	if tok != IDENT && !isUnreservedKeyword(tok) {
 		return true
 	}
 	for i, r := range ident {
@@ -3406,8 +3434,7 @@ func (p *Parser) parseHints() (Hints, error) {
 
 	var hints Hints
 	for _, l := range hitLits {
-		support, ok := SupportHit[l]
-		if support && ok {
+		if IsSupportHint(l) {
 			val := &StringLiteral{Val: l}
 			hints = append(hints, &Hint{Expr: val})
 		}
@@ -3415,4 +3442,13 @@ func (p *Parser) parseHints() (Hints, error) {
 	return hints, nil
 }
 
+// IsSupportHint returns true if the literal of a hint is supported
+func IsSupportHint(l string) bool {
+	if strings.HasPrefix(l, WorkloadGroupHint) {
+		return len(l) > len(WorkloadGroupHint)
+	}
+	support, ok := SupportHit[l]
+	return support && ok
+}
+
 var parserPool = sync.Pool{}
@@ -281,8 +281,12 @@ func (s *Scanner) scanIdent(lookup bool) (tok Token, pos Pos, lit string) {
 	lit = buf.String()
 
 	// If the literal matches a keyword then return that keyword.
+	// The unreserved keywords keep the literal, it is the name if they are used as an identifier.
 	if lookup {
 		if tok = Lookup(lit); tok != IDENT {
+			if isUnreservedKeyword(tok) {
+				return tok, pos, lit
+			}
 			return tok, pos, ""
 		}
 	}
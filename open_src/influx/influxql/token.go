@@ -130,6 +130,19 @@ const DIV = 57460
 const MOD = 57461
 const BITWISE_AND = 57462
 const UMINUS = 57463
+const SLOW = 57464
+const CODEC = 57465
+const TTL = 57466
+const COMPACT = 57467
+const COMPACTIONS = 57468
+const CANCEL = 57469
+const MIGRATE = 57470
+const DECOMMISSION = 57471
+const NODE = 57472
+const EVENTS = 57473
+const PAUSE = 57474
+const RESUME = 57475
+const NODES = 57476
 
 // Token is a lexical token of the InfluxQL language.
 type Token int
@@ -427,6 +440,19 @@ var tokens = [...]string{
 	REPLICANUM:    "REPLICANUM",
 	INDEXTYPE:     "INDEXTYPE",
 	INDEXLIST:     "INDEXLIST",
+	SLOW:          "SLOW",
+	CODEC:         "CODEC",
+	TTL:           "TTL",
+	COMPACT:       "COMPACT",
+	COMPACTIONS:   "COMPACTIONS",
+	CANCEL:        "CANCEL",
+	MIGRATE:       "MIGRATE",
+	DECOMMISSION:  "DECOMMISSION",
+	NODE:          "NODE",
+	EVENTS:        "EVENTS",
+	PAUSE:         "PAUSE",
+	RESUME:        "RESUME",
+	NODES:         "NODES",
 }
 
 var keywords map[string]int
@@ -439,6 +465,9 @@ func init() {
 	for _, tok := range []int{AND, OR} {
 		keywords[strings.ToLower(tokens[tok])] = tok
 	}
+	for tok := SLOW; tok <= NODES; tok++ {
+		keywords[strings.ToLower(tokens[tok])] = tok
+	}
 	/*	keywords["true"] = TRUE
 		keywords["false"] = FALSE*/
 }
@@ -500,6 +529,30 @@ func tokstr(tok Token, lit string) string {
 	return tok.String()
 }
 
+// unreservedKeywords are the keywords which are still valid identifiers, so that the names used before the
+// keywords were added keep working. sql.y accepts them by KEYWORD_AS_IDENT.
+var unreservedKeywords = map[Token]struct{}{
+	SLOW:         {},
+	CODEC:        {},
+	TTL:          {},
+	COMPACT:      {},
+	COMPACTIONS:  {},
+	CANCEL:       {},
+	MIGRATE:      {},
+	DECOMMISSION: {},
+	NODE:         {},
+	EVENTS:       {},
+	PAUSE:        {},
+	RESUME:       {},
+	NODES:        {},
+}
+
+// isUnreservedKeyword returns whether the keyword may also be used as an identifier.
+func isUnreservedKeyword(tok Token) bool {
+	_, ok := unreservedKeywords[tok]
+	return ok
+}
+
 // Lookup returns the token associated with a given string.
 func Lookup(ident string) Token {
 	if tok, ok := keywords[strings.ToLower(ident)]; ok {
@@ -41,6 +41,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/influxdata/influxdb/models"
 	originql "github.com/influxdata/influxql"
+	"github.com/openGemini/openGemini/lib/config"
 	"github.com/openGemini/openGemini/lib/errno"
 	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
 	proto2 "github.com/openGemini/openGemini/open_src/influx/meta/proto"
@@ -95,6 +96,13 @@ type Data struct {
 	AdminUserExists bool
 	TakeOverEnabled bool // set by syscontrol command
 
+	// the balancer moving pts between the data nodes, set by syscontrol command
+	BalancerPaused bool
+	BalancerDryRun bool
+
+	// the SQL nodes stop shipping the replicated writes to the remote cluster, set by PAUSE REPLICATION
+	ReplicationPaused bool
+
 	MaxNodeID       uint64
 	MaxShardGroupID uint64
 	MaxShardID      uint64
@@ -111,6 +119,7 @@ type ReShardingInfo struct {
 	ShardGroupID uint64
 	SplitTime    int64
 	Bounds       []string
+	HashSplits   []uint32
 }
 
 func (data *Data) WalkDatabases(fn func(db *DatabaseInfo)) {
@@ -229,6 +238,33 @@ func (data *Data) Measurement(database, retentionPolicy, mst string) (*Measureme
 	return msti, nil
 }
 
+func (data *Data) SetMeasurementFloatCodec(database, retentionPolicy, mst, codec string) error {
+	if err := ValidFloatCodec(codec); err != nil {
+		return err
+	}
+
+	msti, err := data.Measurement(database, retentionPolicy, mst)
+	if err != nil {
+		return err
+	}
+	msti.FloatCodec = strings.ToLower(codec)
+	return nil
+}
+
+// SetMeasurementTTL sets how long the rows of the measurement are kept, zero keeps them as long as the retention policy
+func (data *Data) SetMeasurementTTL(database, retentionPolicy, mst string, ttl time.Duration) error {
+	if ttl < 0 {
+		return ErrInvalidTTL
+	}
+
+	msti, err := data.Measurement(database, retentionPolicy, mst)
+	if err != nil {
+		return err
+	}
+	msti.TTL = ttl
+	return nil
+}
+
 func (data *Data) UpdateSchema(database string, retentionPolicy string, mst string, fieldToCreate []*proto2.FieldSchema) error {
 	msti, err := data.Measurement(database, retentionPolicy, mst)
 	if err != nil {
@@ -270,8 +306,16 @@ func (data *Data) ReSharding(info *ReShardingInfo) error {
 		return ErrShardGroupAlreadyReSharding(info.ShardGroupID)
 	}
 
+	if shardN := len(rp.ShardGroups[length-1].Shards); len(info.HashSplits) > 0 && len(info.HashSplits) != shardN {
+		return ErrInvalidHashSplits(info.ShardGroupID, shardN, len(info.HashSplits))
+	}
+
 	startTime := time.Unix(0, info.SplitTime+1)
 	data.createIndexGroup(rp, startTime)
+	if len(info.HashSplits) > 0 {
+		DataLogger.Info("reSharding info", zap.Time("splitTime", startTime), zap.Uint32s("hashSplits", info.HashSplits))
+		return data.CreateShardGroupWithHashSplits(rp, startTime, info.HashSplits)
+	}
 	DataLogger.Info("reSharding info", zap.Time("splitTime", time.Unix(0, info.SplitTime+1)), zap.Any("bounds", info.Bounds))
 	err = data.CreateShardGroupWithBounds(rp, startTime, info.Bounds)
 	return err
@@ -344,6 +388,52 @@ func (data *Data) CreateShardGroupWithBounds(rp *RetentionPolicyInfo, startTime
 	return nil
 }
 
+// CreateShardGroupWithHashSplits creates a shard group starting at startTime whose hash space is the one of
+// the newest shard group with the i-th shard split into splits[i] shards. The split shards are spread over
+// the pts following the owner of the original shard.
+func (data *Data) CreateShardGroupWithHashSplits(rp *RetentionPolicyInfo, startTime time.Time, splits []uint32) error {
+	lastSg := &rp.ShardGroups[len(rp.ShardGroups)-1]
+	data.MaxShardGroupID++
+	sgi := ShardGroupInfo{}
+	sgi.ID = data.MaxShardGroupID
+	sgi.StartTime = startTime.UTC()
+	sgi.EndTime = lastSg.EndTime.UTC()
+	sgi.HashSlotN = lastSg.HashSlotN
+	if sgi.HashSlotN == 0 {
+		sgi.HashSlotN = uint32(len(lastSg.Shards))
+	}
+
+	igi := rp.IndexGroups[len(rp.IndexGroups)-1]
+	sgi.Shards = make([]ShardInfo, 0, len(lastSg.Shards))
+	for i := range lastSg.Shards {
+		sh := lastSg.Shards[i]
+		if lastSg.HashSlotN == 0 {
+			sh.HashSlot = uint32(i)
+		}
+
+		n := uint64(1)
+		if i < len(splits) && splits[i] > 1 {
+			n = uint64(splits[i])
+		}
+		shards := sh.splitHash(sgi.HashSlotN, n)
+		for j := range shards {
+			data.MaxShardID++
+			shards[j].ID = data.MaxShardID
+			for k := range shards[j].Owners {
+				shards[j].Owners[k] = (sh.Owners[k] + uint32(j)) % data.ClusterPtNum
+			}
+			if len(shards[j].Owners) > 0 {
+				shards[j].IndexID = igi.Indexes[shards[j].Owners[0]].ID
+			}
+		}
+		sgi.Shards = append(sgi.Shards, shards...)
+	}
+
+	rp.ShardGroups = append(rp.ShardGroups, sgi)
+	sort.Sort(ShardGroupInfos(rp.ShardGroups))
+	return nil
+}
+
 func (data *Data) CreateMeasurement(database string, rpName string, mst string, shardKey *proto2.ShardKeyInfo, indexR *proto2.IndexRelation) error {
 	rp, err := data.RetentionPolicy(database, rpName)
 	if err != nil {
@@ -539,8 +629,8 @@ func (data *Data) ClusterChangeState(nodeID uint64, newState serf.MemberStatus)
 	return false
 }
 
-// CreateDataNode adds a node to the metadata.
-func (data *Data) CreateDataNode(host, tcpHost string) (error, uint64) {
+// CreateDataNode adds a node in the zone and the rack to the metadata.
+func (data *Data) CreateDataNode(host, tcpHost, zone, rack string) (error, uint64) {
 	// If an existing meta node exists with the same TCPHost address,
 	// then these nodes are actually the same so re-use the existing ID
 	var existingID uint64
@@ -561,7 +651,9 @@ func (data *Data) CreateDataNode(host, tcpHost string) (error, uint64) {
 		NodeInfo: NodeInfo{
 			ID:      existingID,
 			Host:    host,
-			TCPHost: tcpHost}}
+			TCPHost: tcpHost},
+		Zone: zone,
+		Rack: rack}
 	if len(data.MetaNodes) == 1 {
 		dn.Status = serf.StatusAlive
 		dn.LTime = 1
@@ -577,6 +669,17 @@ func (data *Data) CreateDataNode(host, tcpHost string) (error, uint64) {
 	return nil, existingID
 }
 
+// SetDataNodeTopology changes the zone and the rack of the data node, the pts already on the node are not moved.
+func (data *Data) SetDataNodeTopology(id uint64, zone, rack string) error {
+	dn := data.DataNode(id)
+	if dn == nil {
+		return ErrNodeNotFound
+	}
+	dn.Zone = zone
+	dn.Rack = rack
+	return nil
+}
+
 func (data *Data) updatePtStatus(db string, ptId uint32, nodeId uint64, status PtStatus) {
 	dbPtView, ok := data.PtView[db]
 	if !ok {
@@ -625,7 +728,23 @@ func (data *Data) SetDataNode(nodeID uint64, host, tcpHost string) error {
 	return nil
 }
 
+// DeleteDataNode removes the data node, its pts must have been moved to the other nodes
 func (data *Data) DeleteDataNode(id uint64) error {
+	idx := -1
+	for i := range data.DataNodes {
+		if data.DataNodes[i].ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ErrNodeNotFound
+	}
+	if len(data.GetPtsByNodeId(id)) > 0 {
+		return ErrNodeOwnsPts
+	}
+
+	data.DataNodes = append(data.DataNodes[:idx], data.DataNodes[idx+1:]...)
 	return nil
 }
 
@@ -799,8 +918,9 @@ func (data *Data) createDBPtView(name string) error {
 		data.PtView = make(map[string]DBPtInfos)
 	}
 
+	order := data.topologyOrder()
 	for ptId := 0; ptId < int(data.ClusterPtNum); ptId++ {
-		pos := ptId % len(data.DataNodes)
+		pos := data.ptOwnerPos(order, uint32(ptId))
 		if err := data.CheckDataNodeAlive(data.DataNodes[pos].ID); err != nil {
 			data.updatePtStatus(name, uint32(ptId), data.DataNodes[pos].ID, Offline)
 			continue
@@ -828,6 +948,79 @@ func (data *Data) CloneDBPtView() map[string]DBPtInfos {
 	return dbPts
 }
 
+// ptOwnerPos returns the position of the data node a new pt is assigned to.
+// The pts are spread over the nodes in the topology order in turn, a node whose disk is above the
+// high watermark or which is being decommissioned is skipped for the next alive one that is not.
+func (data *Data) ptOwnerPos(order []int, ptId uint32) int {
+	n := len(order)
+	pos := int(ptId) % n
+	for i := 0; i < n; i++ {
+		dn := &data.DataNodes[order[(pos+i)%n]]
+		if dn.DiskStatus < DiskHigh && !dn.Decommissioning && (i == 0 || dn.Status == serf.StatusAlive) {
+			return order[(pos+i)%n]
+		}
+	}
+	return order[pos]
+}
+
+// topologyOrder returns the positions of the data nodes taking the zones in turn, and the racks
+// of a zone in turn, so that the consecutive pts are in different zones. The order is by node id
+// when no node has a zone or a rack.
+func (data *Data) topologyOrder() []int {
+	order := make([]int, len(data.DataNodes))
+	labeled := false
+	for i := range data.DataNodes {
+		order[i] = i
+		labeled = labeled || data.DataNodes[i].Zone != "" || data.DataNodes[i].Rack != ""
+	}
+	if !labeled {
+		return order
+	}
+
+	racks := make(map[string]map[string][]int)
+	var zoneNames []string
+	for i := range data.DataNodes {
+		dn := &data.DataNodes[i]
+		if racks[dn.Zone] == nil {
+			racks[dn.Zone] = make(map[string][]int)
+			zoneNames = append(zoneNames, dn.Zone)
+		}
+		racks[dn.Zone][dn.Rack] = append(racks[dn.Zone][dn.Rack], i)
+	}
+	sort.Strings(zoneNames)
+	zones := make([][]int, 0, len(zoneNames))
+	for _, zone := range zoneNames {
+		rackNames := make([]string, 0, len(racks[zone]))
+		for rack := range racks[zone] {
+			rackNames = append(rackNames, rack)
+		}
+		sort.Strings(rackNames)
+		zoneRacks := make([][]int, 0, len(rackNames))
+		for _, rack := range rackNames {
+			zoneRacks = append(zoneRacks, racks[zone][rack])
+		}
+		zones = append(zones, interleave(zoneRacks))
+	}
+	return interleave(zones)
+}
+
+// interleave takes the first elements of the lists in turn, then the second ones and so on
+func interleave(lists [][]int) []int {
+	var ret []int
+	for i := 0; ; i++ {
+		taken := false
+		for _, l := range lists {
+			if i < len(l) {
+				ret = append(ret, l[i])
+				taken = true
+			}
+		}
+		if !taken {
+			return ret
+		}
+	}
+}
+
 func (data *Data) initDataNodePtView() {
 	if data.ClusterPtNum < data.PtNumPerNode*uint32(len(data.DataNodes)) {
 		data.ClusterPtNum = data.PtNumPerNode * uint32(len(data.DataNodes))
@@ -1406,6 +1599,11 @@ func (data *Data) CreateShardGroup(database, policy string, timestamp time.Time,
 			lastSgi = &rpi.ShardGroups[len(rpi.ShardGroups)-1]
 			sgi.Shards = make([]ShardInfo, len(lastSgi.Shards))
 		}
+	} else if len(rpi.ShardGroups) > 0 && rpi.ShardGroups[len(rpi.ShardGroups)-1].HashSlotN > 0 {
+		// keep the hash space of the split shard group for future time ranges
+		lastSgi = &rpi.ShardGroups[len(rpi.ShardGroups)-1]
+		sgi.HashSlotN = lastSgi.HashSlotN
+		sgi.Shards = make([]ShardInfo, len(lastSgi.Shards))
 	} else {
 		sgi.Shards = make([]ShardInfo, shardN)
 	}
@@ -1413,14 +1611,20 @@ func (data *Data) CreateShardGroup(database, policy string, timestamp time.Time,
 	//check index group contain this shard group
 	igi := data.createIndexGroupIfNeeded(rpi, timestamp)
 
+	usedPts := make([]bool, data.ClusterPtNum)
 	for i := range sgi.Shards {
 		data.MaxShardID++
 		sgi.Shards[i] = ShardInfo{ID: data.MaxShardID, Tier: tier}
 		sgi.Shards[i].Owners = make([]uint32, 0, replicaN)
-		for ptId := 0; ptId < int(data.ClusterPtNum); ptId++ {
-			if ptId%shardN == i {
-				for j := 0; j < replicaN; j++ {
-					sgi.Shards[i].Owners = append(sgi.Shards[i].Owners, uint32(ptId+j))
+		if replicaN > 1 {
+			if i < shardN {
+				sgi.Shards[i].Owners = data.replicaOwners(database, uint32(i), shardN, replicaN, usedPts)
+				sgi.Shards[i].IndexID = igi.Indexes[i].ID
+			}
+		} else {
+			for ptId := 0; ptId < int(data.ClusterPtNum); ptId++ {
+				if ptId%shardN == i {
+					sgi.Shards[i].Owners = append(sgi.Shards[i].Owners, uint32(ptId))
 					sgi.Shards[i].IndexID = igi.Indexes[ptId].ID
 				}
 			}
@@ -1429,6 +1633,13 @@ func (data *Data) CreateShardGroup(database, policy string, timestamp time.Time,
 			sgi.Shards[i].Min = lastSgi.Shards[i].Min
 			sgi.Shards[i].Max = lastSgi.Shards[i].Max
 		}
+		if sgi.HashSlotN > 0 && len(lastSgi.Shards[i].Owners) > 0 {
+			sgi.Shards[i].Owners = append(sgi.Shards[i].Owners[:0], lastSgi.Shards[i].Owners...)
+			sgi.Shards[i].IndexID = igi.Indexes[sgi.Shards[i].Owners[0]].ID
+			sgi.Shards[i].HashSlot = lastSgi.Shards[i].HashSlot
+			sgi.Shards[i].HashMin = lastSgi.Shards[i].HashMin
+			sgi.Shards[i].HashMax = lastSgi.Shards[i].HashMax
+		}
 	}
 
 	// Retention policy has a new shard group, so update the policy. Shard
@@ -1476,6 +1687,53 @@ func (data *Data) createIndexGroupIfNeeded(rpi *RetentionPolicyInfo, timestamp t
 	return data.CreateIndexGroup(rpi, timestamp)
 }
 
+// replicaOwners returns the pts of the replicas of a shard, the first replica is on the pt of the shard
+// and every other one is on the first unused pt from shardN in a zone without a replica yet, or else
+// on a node without a replica yet, so that every zone holds one replica at most if there are enough zones.
+func (data *Data) replicaOwners(database string, ptId uint32, shardN, replicaN int, used []bool) []uint32 {
+	ptNode := func(pt uint32) *DataNode {
+		if int(pt) >= len(data.PtView[database]) {
+			return nil
+		}
+		return data.DataNode(data.PtView[database][pt].Owner.NodeID)
+	}
+	zones := make(map[string]bool, replicaN)
+	nodes := make(map[uint64]bool, replicaN)
+	take := func(pt uint32) {
+		used[pt] = true
+		if dn := ptNode(pt); dn != nil {
+			zones[dn.Zone] = true
+			nodes[dn.ID] = true
+		}
+	}
+
+	owners := []uint32{ptId}
+	take(ptId)
+	for len(owners) < replicaN {
+		best, bestScore := -1, -1
+		for pt := shardN; pt < len(used); pt++ {
+			if used[pt] {
+				continue
+			}
+			score := 0
+			if dn := ptNode(uint32(pt)); dn != nil && !zones[dn.Zone] {
+				score = 2
+			} else if dn != nil && !nodes[dn.ID] {
+				score = 1
+			}
+			if score > bestScore {
+				best, bestScore = pt, score
+			}
+		}
+		if best < 0 {
+			break
+		}
+		owners = append(owners, uint32(best))
+		take(uint32(best))
+	}
+	return owners
+}
+
 func (data *Data) expendDBPtView(database string, ptNum uint32) {
 	dbPtInfos := data.DBPtView(database)
 	oldDBPtNums := uint32(len(dbPtInfos))
@@ -1487,8 +1745,9 @@ func (data *Data) expendDBPtView(database string, ptNum uint32) {
 		DataLogger.Info("expend db ptview", zap.String("db", database), zap.Uint32("from", oldDBPtNums), zap.Uint32("to", ptNum))
 	}
 
+	order := data.topologyOrder()
 	for ptId := oldDBPtNums; ptId < ptNum; ptId++ {
-		pos := ptId % uint32(len(data.DataNodes))
+		pos := data.ptOwnerPos(order, ptId)
 		if data.DataNodes[pos].Status == serf.StatusAlive {
 			data.updatePtStatus(database, ptId, data.DataNodes[pos].ID, Online)
 			continue
@@ -1827,6 +2086,10 @@ func (data *Data) Marshal() *proto2.Data {
 		PtNumPerNode:    proto.Uint32(data.PtNumPerNode),
 		MaxEventOpId:    proto.Uint64(data.MaxEventOpId),
 		TakeOverEnabled: proto.Bool(data.TakeOverEnabled),
+		BalancerPaused:  proto.Bool(data.BalancerPaused),
+		BalancerDryRun:  proto.Bool(data.BalancerDryRun),
+
+		ReplicationPaused: proto.Bool(data.ReplicationPaused),
 	}
 
 	pb.DataNodes = make([]*proto2.DataNode, len(data.DataNodes))
@@ -1887,6 +2150,9 @@ func (data *Data) Unmarshal(pb *proto2.Data) {
 	data.DataNodes = make([]DataNode, len(pb.GetDataNodes()))
 	data.MaxEventOpId = pb.GetMaxEventOpId()
 	data.TakeOverEnabled = pb.GetTakeOverEnabled()
+	data.BalancerPaused = pb.GetBalancerPaused()
+	data.BalancerDryRun = pb.GetBalancerDryRun()
+	data.ReplicationPaused = pb.GetReplicationPaused()
 	for i, x := range pb.GetDataNodes() {
 		data.DataNodes[i].unmarshal(x)
 	}
@@ -2073,16 +2339,22 @@ func (data *Data) importOneDB(other Data, backupDBName, restoreDBName, backupRPN
 	return restoreDBName, nil
 }
 
+// GetPtsByNodeId returns the ids of the pts owned by the node in any database,
+// a pt may be moved to another node for a single database
 func (data *Data) GetPtsByNodeId(nid uint64) []uint32 {
 	var ptIds []uint32
+	seen := make(map[uint32]struct{})
 	for db := range data.PtView {
 		for i := range data.PtView[db] {
-			if data.PtView[db][i].Owner.NodeID == nid {
-				ptIds = append(ptIds, data.PtView[db][i].PtId)
+			ptId := data.PtView[db][i].PtId
+			if _, ok := seen[ptId]; ok || data.PtView[db][i].Owner.NodeID != nid {
+				continue
 			}
+			seen[ptId] = struct{}{}
+			ptIds = append(ptIds, ptId)
 		}
-		break
 	}
+	sort.Slice(ptIds, func(i, j int) bool { return ptIds[i] < ptIds[j] })
 	return ptIds
 }
 
@@ -2130,6 +2402,15 @@ func (data *Data) UpdateNodeStatus(id uint64, status int32, lTime uint64, gossip
 	return nil
 }
 
+func (data *Data) UpdateNodeDiskStatus(id uint64, status int32) error {
+	dn := data.DataNode(id)
+	if dn == nil {
+		return errno.NewError(errno.DataNodeNotFound, id)
+	}
+	dn.DiskStatus = DiskStatus(status)
+	return nil
+}
+
 // return pts for the nid
 func (data *Data) updatePtViewStatus(nid uint64, status PtStatus) {
 	for db := range data.PtView {
@@ -2253,6 +2534,8 @@ func (data *Data) UpdateMigrateEvent(e *proto2.MigrateEventInfo) error {
 	eventInfo := data.MigrateEvents[e.GetEventId()]
 	eventInfo.currState = int(e.GetCurrState())
 	eventInfo.preState = int(e.GetPreState())
+	eventInfo.bytes = e.GetBytes()
+	eventInfo.err = e.GetErr()
 	return nil
 }
 
@@ -2261,6 +2544,157 @@ func (data *Data) RemoveEventInfo(eventId string) error {
 	return nil
 }
 
+// RemoveEventOp removes the event if it is still the operation of opId, so that an event
+// which replaced it is kept
+func (data *Data) RemoveEventOp(eventId string, opId uint64) error {
+	if e := data.MigrateEvents[eventId]; e != nil && e.opId == opId {
+		delete(data.MigrateEvents, eventId)
+	}
+	return nil
+}
+
+// MigratePt creates the event moving the db pt to the data node
+func (data *Data) MigratePt(database string, ptId uint32, nodeID uint64) error {
+	if data.Database(database) == nil {
+		return errno.NewError(errno.DatabaseNotFound, database)
+	}
+	if int(ptId) >= len(data.PtView[database]) {
+		return errno.NewError(errno.PtNotFound)
+	}
+	dn := data.DataNode(nodeID)
+	if dn == nil {
+		return ErrNodeNotFound
+	}
+	if dn.Decommissioning {
+		return ErrNodeDecommissioning
+	}
+
+	pt := data.PtView[database][ptId]
+	if pt.Owner.NodeID == nodeID {
+		return fmt.Errorf("pt %d of database %s is already on node %d", ptId, database, nodeID)
+	}
+	return data.createMoveEvent(database, pt, nodeID)
+}
+
+// createMoveEvent replaces the failed move of the pt, the other events of the pt must finish first
+func (data *Data) createMoveEvent(database string, pt PtInfo, nodeID uint64) error {
+	dbPt := &DbPtInfo{Db: database, Pti: &pt}
+	if e := data.MigrateEvents[dbPt.String()]; e != nil &&
+		(e.eventType != MoveEventType || MoveState(e.currState) != MoveFailed) {
+		return ErrPtMoving
+	}
+	if data.MigrateEvents == nil {
+		data.MigrateEvents = make(map[string]*MigrateEventInfo)
+	}
+
+	mei := NewMigrateEventInfo(dbPt.String(), MoveEventType, dbPt, nodeID)
+	mei.src = pt.Owner.NodeID
+	data.MaxEventOpId++
+	mei.opId = data.MaxEventOpId
+	data.MigrateEvents[mei.eventId] = mei
+	return nil
+}
+
+// SetReplicationPaused pauses or resumes shipping the replicated writes on all the SQL nodes
+func (data *Data) SetReplicationPaused(paused bool) {
+	data.ReplicationPaused = paused
+}
+
+// HasMoveEventsOf returns whether any pt of the node is being moved, or failed to move
+func (data *Data) HasMoveEventsOf(nodeID uint64) bool {
+	for _, e := range data.MigrateEvents {
+		if e.eventType == MoveEventType && e.src == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetBalancer pauses or resumes the balancer, the balancer only logs the moves it would make in dry-run mode
+func (data *Data) SetBalancer(paused, dryRun bool) {
+	data.BalancerPaused = paused
+	data.BalancerDryRun = dryRun
+}
+
+// DecommissionDataNode marks the data node to be removed and moves its pts to the other nodes,
+// the nodes in the zone with the fewest pts of the database are chosen first, and then the nodes
+// with the fewest pts. The node is deleted when all its pts are moved. The offline pts can not be
+// moved, so the node is not decommissioned while it owns any.
+func (data *Data) DecommissionDataNode(id uint64) error {
+	dn := data.DataNode(id)
+	if dn == nil {
+		return ErrNodeNotFound
+	}
+
+	owned := make(map[uint64]int)
+	zones := make(map[uint64]string)
+	for i := range data.DataNodes {
+		n := &data.DataNodes[i]
+		zones[n.ID] = n.Zone
+		if n.ID != id && !n.Decommissioning && n.Status == serf.StatusAlive && n.DiskStatus < DiskHigh {
+			owned[n.ID] = 0
+		}
+	}
+	if len(owned) == 0 {
+		return ErrNodeUnableToDropFinalNode
+	}
+	zoneCounts := make(map[string]map[string]int) // db -> zone -> pt count, without the pts to move
+	for db := range data.PtView {
+		zoneCounts[db] = make(map[string]int)
+		for _, pt := range data.PtView[db] {
+			if pt.Owner.NodeID != id {
+				zoneCounts[db][zones[pt.Owner.NodeID]]++
+			}
+			if _, ok := owned[pt.Owner.NodeID]; ok {
+				owned[pt.Owner.NodeID]++
+			}
+		}
+	}
+
+	dbs := make([]string, 0, len(data.PtView))
+	for db := range data.PtView {
+		dbs = append(dbs, db)
+	}
+	sort.Strings(dbs)
+	for _, db := range dbs {
+		for _, pt := range data.PtView[db] {
+			if pt.Owner.NodeID == id && pt.Status == Offline {
+				return fmt.Errorf("%w: db %s pt %d", ErrDecommissionBlocked, db, pt.PtId)
+			}
+		}
+	}
+
+	dn.Decommissioning = true
+	for _, db := range dbs {
+		for _, pt := range data.PtView[db] {
+			if pt.Owner.NodeID != id {
+				continue
+			}
+			var target uint64
+			for nid, n := range owned {
+				if target == 0 {
+					target = nid
+					continue
+				}
+				zc, tzc := zoneCounts[db][zones[nid]], zoneCounts[db][zones[target]]
+				if zc < tzc || (zc == tzc && (n < owned[target] || (n == owned[target] && nid < target))) {
+					target = nid
+				}
+			}
+			err := data.createMoveEvent(db, pt, target)
+			if err == ErrPtMoving {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			owned[target]++
+			zoneCounts[db][zones[target]]++
+		}
+	}
+	return nil
+}
+
 func (data *Data) UpdatePtInfo(db string, info *proto2.PtInfo, ownerId uint64, status uint32) error {
 	oldPtNum := len(data.PtView[db])
 	if int(info.GetPtId()) >= oldPtNum {
@@ -2341,6 +2775,14 @@ func ValidShardKey(shardKeys []string) error {
 	return nil
 }
 
+// ValidFloatCodec checks the float codec set by CREATE MEASUREMENT, empty means the node default
+func ValidFloatCodec(codec string) error {
+	if codec == "" || config.IsValidFloatCodec(codec) {
+		return nil
+	}
+	return ErrInvalidFloatCodec
+}
+
 func GetInt64Duration(duration *time.Duration) *int64 {
 	if duration != nil {
 		value := int64(*duration)
@@ -17,7 +17,9 @@ limitations under the License.
 package meta
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"reflect"
 	"runtime"
@@ -28,6 +30,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/influxdata/influxdb/logger"
 	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/open_src/github.com/hashicorp/serf/serf"
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 	proto2 "github.com/openGemini/openGemini/open_src/influx/meta/proto"
 	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
@@ -209,19 +212,19 @@ func Test_Data_ReSharding(t *testing.T) {
 	splitTime := sg0.StartTime.Add(2 * time.Hour)
 	bounds := []string{"cpu,hostname=host_5"}
 
-	err = data.ReSharding(&ReShardingInfo{"foo", "bar", 1, splitTime.UnixNano(), bounds})
+	err = data.ReSharding(&ReShardingInfo{"foo", "bar", 1, splitTime.UnixNano(), bounds, nil})
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	shardgroups, err := data.ShardGroups("foo", "bar")
-	shards1 := []ShardInfo{{1, []uint32{0}, "", "", Hot, 1}}
+	shards1 := []ShardInfo{{1, []uint32{0}, "", "", Hot, 1, 0, 0, 0}}
 	sg1 := ShardGroupInfo{1, sg0.StartTime, sg0.EndTime,
-		sg0.DeletedAt, shards1, sg0.TruncatedAt}
-	shards2 := []ShardInfo{{2, []uint32{0}, "", "cpu,hostname=host_5", Hot, 3},
-		{3, []uint32{1}, "cpu,hostname=host_5", "", Hot, 4}}
+		sg0.DeletedAt, shards1, sg0.TruncatedAt, 0}
+	shards2 := []ShardInfo{{2, []uint32{0}, "", "cpu,hostname=host_5", Hot, 3, 0, 0, 0},
+		{3, []uint32{1}, "cpu,hostname=host_5", "", Hot, 4, 0, 0, 0}}
 	sg2 := ShardGroupInfo{2, time.Unix(0, splitTime.UnixNano()+1).UTC(), sg0.EndTime,
-		sg0.DeletedAt, shards2, sg0.TruncatedAt}
+		sg0.DeletedAt, shards2, sg0.TruncatedAt, 0}
 	expSgs := []ShardGroupInfo{sg1, sg2}
 	if got, exp := shardgroups, expSgs; !reflect.DeepEqual(got, exp) {
 		t.Fatalf("got %v, expected %v", got, exp)
@@ -237,6 +240,103 @@ func Test_Data_ReSharding(t *testing.T) {
 	}
 }
 
+func Test_Data_HashReSharding(t *testing.T) {
+	data := initData()
+	must := func(err error) {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	must(data.CreateDatabase("foo", nil, nil))
+	rp := NewRetentionPolicyInfo("bar")
+	rp.ShardGroupDuration = 24 * time.Hour
+	must(data.CreateRetentionPolicy("foo", rp, false))
+	must(data.CreateMeasurement("foo", "bar", "cpu",
+		&proto2.ShardKeyInfo{ShardKey: []string{"hostname"}, Type: proto.String(influxql.HASH)}, nil))
+	must(data.CreateShardGroup("foo", "bar", time.Unix(0, 0), Hot))
+
+	sg0, err := data.ShardGroupByTimestamp("foo", "bar", time.Unix(0, 0))
+	must(err)
+	if len(sg0.Shards) != 2 {
+		t.Fatalf("got %d shards, exp 2", len(sg0.Shards))
+	}
+
+	splitTime := sg0.StartTime.Add(2 * time.Hour)
+	info := &ReShardingInfo{Database: "foo", Rp: "bar", ShardGroupID: sg0.ID, SplitTime: splitTime.UnixNano(), HashSplits: []uint32{1}}
+	if err = data.ReSharding(info); err == nil {
+		t.Fatal("expect error for invalid hash splits")
+	}
+	info.HashSplits = []uint32{2, 1}
+	must(data.ReSharding(info))
+
+	sgs, err := data.ShardGroups("foo", "bar")
+	must(err)
+	sg1 := sgs[1]
+	if sg1.HashSlotN != 2 || len(sg1.Shards) != 3 {
+		t.Fatalf("got hash slot %d shards %d, exp 2 and 3", sg1.HashSlotN, len(sg1.Shards))
+	}
+	mid := uint64(math.MaxUint64/2)/2 + 1
+	expShards := []ShardInfo{
+		{ID: 3, Owners: []uint32{0}, Tier: Hot, IndexID: 3, HashSlot: 0, HashMin: 0, HashMax: mid},
+		{ID: 4, Owners: []uint32{1}, Tier: Hot, IndexID: 4, HashSlot: 0, HashMin: mid, HashMax: 0},
+		{ID: 5, Owners: []uint32{1}, Tier: Hot, IndexID: 4, HashSlot: 1, HashMin: 0, HashMax: 0},
+	}
+	if !reflect.DeepEqual(sg1.Shards, expShards) {
+		t.Fatalf("got %+v, exp %+v", sg1.Shards, expShards)
+	}
+
+	alive := []int{0, 1, 2}
+	for _, hash := range []uint64{0, 1, 6, 7, mid * 2, mid*2 + 1, math.MaxUint64 - 1, math.MaxUint64} {
+		sh := sg1.ShardFor(hash, alive)
+		if sh.HashSlot != uint32(hash%2) || !sh.ContainHash(hash/2) {
+			t.Fatalf("hash %d routed to shard %+v", hash, sh)
+		}
+		// rows of the shard which is not split keep the owner of the old shard group
+		if hash%2 == 1 && sh.Owners[0] != sg0.ShardFor(hash, []int{0, 1}).Owners[0] {
+			t.Fatalf("hash %d routed to shard %+v", hash, sh)
+		}
+	}
+	if sh := sg1.ShardFor(1, []int{0, 1}); sh != nil {
+		t.Fatalf("got shard %d, exp nil when the destination shard is not alive", sh.ID)
+	}
+
+	mst := &MeasurementInfo{Name: "cpu", Schema: map[string]int32{"hostname": influx.Field_Type_Tag}}
+	ski := &ShardKeyInfo{ShardKey: []string{"hostname"}, Type: influxql.HASH}
+	for _, host := range []string{"host_1", "host_2", "host_3", "host_4"} {
+		cond := &influxql.BinaryExpr{Op: influxql.EQ, LHS: &influxql.VarRef{Val: "hostname"}, RHS: &influxql.StringLiteral{Val: host}}
+		shards := sg1.TargetShards("cpu", mst, ski, cond, alive)
+		exp := sg1.ShardFor(HashID([]byte("cpu,hostname="+host)), alive)
+		if len(shards) != 1 || shards[0].ID != exp.ID {
+			t.Fatalf("got %+v, exp shard %d", shards, exp.ID)
+		}
+	}
+
+	// the split hash space is kept by the shard groups of the future time ranges
+	must(data.CreateShardGroup("foo", "bar", sg0.EndTime, Hot))
+	sg2, err := data.ShardGroupByTimestamp("foo", "bar", sg0.EndTime)
+	must(err)
+	if sg2.HashSlotN != sg1.HashSlotN || len(sg2.Shards) != len(sg1.Shards) {
+		t.Fatalf("got %+v, exp hash space of %+v", sg2, sg1)
+	}
+	for i := range sg2.Shards {
+		if sg2.Shards[i].HashSlot != sg1.Shards[i].HashSlot || sg2.Shards[i].HashMin != sg1.Shards[i].HashMin ||
+			sg2.Shards[i].HashMax != sg1.Shards[i].HashMax || !reflect.DeepEqual(sg2.Shards[i].Owners, sg1.Shards[i].Owners) {
+			t.Fatalf("got %+v, exp hash space of %+v", sg2.Shards[i], sg1.Shards[i])
+		}
+	}
+
+	buf, err := data.MarshalBinary()
+	must(err)
+	other := &Data{}
+	must(other.UnmarshalBinary(buf))
+	sgs, err = other.ShardGroups("foo", "bar")
+	must(err)
+	if !reflect.DeepEqual(sgs[1].Shards, expShards) || sgs[1].HashSlotN != 2 {
+		t.Fatalf("got %+v, exp %+v", sgs[1], expShards)
+	}
+}
+
 func NewMockData(database, policy string) Data {
 	data := Data{}
 
@@ -308,8 +408,8 @@ func TestData_PruneGroups(t *testing.T) {
 func initData() *Data {
 	data := &Data{PtNumPerNode: 1}
 	DataLogger = logger.New(os.Stderr)
-	data.CreateDataNode("127.0.0.1:8086", "127.0.0.1:8188")
-	data.CreateDataNode("127.0.0.2:8086", "127.0.0.2:8188")
+	data.CreateDataNode("127.0.0.1:8086", "127.0.0.1:8188", "", "")
+	data.CreateDataNode("127.0.0.2:8086", "127.0.0.2:8188", "", "")
 	return data
 }
 
@@ -461,6 +561,25 @@ func TestData_UpdateRetentionPolicy(t *testing.T) {
 	}
 }
 
+func TestRetentionPolicyInfo_HashSharding(t *testing.T) {
+	rpi := &RetentionPolicyInfo{Measurements: map[string]*MeasurementInfo{
+		"cpu": {Name: "cpu"},
+		"mem": {Name: "mem", ShardKeys: []ShardKeyInfo{{ShardKey: []string{"host"}, Type: HASH}}},
+	}}
+	for i := 0; i < 10; i++ {
+		if !rpi.HashSharding() {
+			t.Fatalf("retention policy without range sharded measurements should be hash sharded")
+		}
+	}
+
+	rpi.Measurements["disk"] = &MeasurementInfo{Name: "disk", ShardKeys: []ShardKeyInfo{{ShardKey: []string{"host"}, Type: RANGE}}}
+	for i := 0; i < 10; i++ {
+		if rpi.HashSharding() {
+			t.Fatalf("retention policy with a range sharded measurement should not be hash sharded")
+		}
+	}
+}
+
 func TestShardInfo_ContainPrefix(t *testing.T) {
 	shard1 := ShardInfo{Min: "", Max: "cpu,hostname=host1,ip=127.0.0.1"}
 	shard2 := ShardInfo{Min: "cpu,hostname=host1,ip=127.0.0.1", Max: ""}
@@ -563,11 +682,104 @@ func TestData_CreateRetentionPolicy(t *testing.T) {
 	assert(igs[0].EndTime.Equal(mustParseTime(time.RFC3339Nano, "2039-08-25T00:00:00Z")), "index group endTime error")
 }
 
+func TestData_SetMeasurementFloatCodec(t *testing.T) {
+	data := initData()
+	dbName := "test"
+	rpName := "default"
+	mstName := "foo"
+	rpi := &RetentionPolicyInfo{
+		Name:               rpName,
+		ReplicaN:           1,
+		ShardGroupDuration: 12 * time.Hour,
+		IndexGroupDuration: 30 * 365 * 24 * time.Hour}
+	if err := data.CreateDatabase(dbName, rpi, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := data.CreateMeasurement(dbName, rpName, mstName, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := data.SetMeasurementFloatCodec(dbName, rpName, mstName, "zstd"); err != ErrInvalidFloatCodec {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.SetMeasurementFloatCodec(dbName, rpName, "bar", "chimp"); err != ErrMeasurementNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.SetMeasurementFloatCodec(dbName, rpName, mstName, "Chimp"); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := &Data{}
+	if err = other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	msti, err := other.Measurement(dbName, rpName, mstName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(msti.FloatCodec == "chimp", "float codec of measurement error")
+}
+
+func TestData_SetMeasurementTTL(t *testing.T) {
+	data := initData()
+	dbName := "test"
+	rpName := "default"
+	mstName := "foo"
+	rpi := &RetentionPolicyInfo{
+		Name:               rpName,
+		ReplicaN:           1,
+		ShardGroupDuration: 12 * time.Hour,
+		IndexGroupDuration: 30 * 365 * 24 * time.Hour}
+	if err := data.CreateDatabase(dbName, rpi, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := data.CreateMeasurement(dbName, rpName, mstName, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := data.SetMeasurementTTL(dbName, rpName, mstName, -time.Hour); err != ErrInvalidTTL {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.SetMeasurementTTL(dbName, rpName, "bar", time.Hour); err != ErrMeasurementNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := data.SetMeasurementTTL(dbName, rpName, mstName, 24*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := data.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := &Data{}
+	if err = other.UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	msti, err := other.Measurement(dbName, rpName, mstName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(msti.TTL == 24*time.Hour, "ttl of measurement error")
+
+	if err := data.SetMeasurementTTL(dbName, rpName, mstName, 0); err != nil {
+		t.Fatal(err)
+	}
+	msti, err = data.Measurement(dbName, rpName, mstName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert(msti.TTL == 0, "ttl of measurement is not cleared")
+}
+
 func TestShardGroupOutOfOrder(t *testing.T) {
 	data := Data{}
 	data.PtNumPerNode = 1
 	DataLogger = logger.New(os.Stderr)
-	err, _ := data.CreateDataNode("127.0.0.1:8400", "127.0.0.1:8401")
+	err, _ := data.CreateDataNode("127.0.0.1:8400", "127.0.0.1:8401", "", "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -660,7 +872,7 @@ func TestData_CreateShardGroup(t *testing.T) {
 	assert(len(igs) == 1, "err num of index groups")
 	assert(igs[0].StartTime.Equal(mustParseTime(time.RFC3339Nano, "2022-06-08T08:00:00Z")), "index group startTime error")
 	assert(igs[0].EndTime.Equal(mustParseTime(time.RFC3339Nano, "2022-06-08T10:00:00Z")), "index group endTime error")
-	err, _ = data.CreateDataNode("127.0.0.3:8400", "127.0.0.3:8401")
+	err, _ = data.CreateDataNode("127.0.0.3:8400", "127.0.0.3:8401", "", "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -781,7 +993,7 @@ func initDataWithDataNode() *Data {
 
 	for i := 1; i <= 40; i++ {
 		nodeIp := prefix + fmt.Sprint(i)
-		err, _ := data.CreateDataNode(nodeIp+fmt.Sprint(selectPort), nodeIp+fmt.Sprint(writePort))
+		err, _ := data.CreateDataNode(nodeIp+fmt.Sprint(selectPort), nodeIp+fmt.Sprint(writePort), "", "")
 		if err != nil {
 			panic(err)
 		}
@@ -1166,6 +1378,223 @@ func TestData_CreateMigrateEvent(t *testing.T) {
 	assert2.Equal(t, uint64(1), data.MigrateEvents[dbPt1.String()].opId)
 }
 
+func TestData_CreateDatabase_AvoidFullNode(t *testing.T) {
+	data := initData()
+	data.CreateDataNode("127.0.0.3:8086", "127.0.0.3:8188", "", "")
+	for i := range data.DataNodes {
+		data.DataNodes[i].Status = serf.StatusAlive
+	}
+	require.NoError(t, data.UpdateNodeDiskStatus(2, int32(DiskHigh)))
+	require.Error(t, data.UpdateNodeDiskStatus(10, int32(DiskHigh)))
+
+	require.NoError(t, data.CreateDatabase("db0", nil, nil))
+	owners := make([]uint64, 0, len(data.PtView["db0"]))
+	for _, pt := range data.PtView["db0"] {
+		owners = append(owners, pt.Owner.NodeID)
+	}
+	assert2.Equal(t, []uint64{1, 3, 3}, owners)
+
+	// all nodes are full, the pts are spread as usual
+	for i := range data.DataNodes {
+		data.DataNodes[i].DiskStatus = DiskFlood
+	}
+	require.NoError(t, data.CreateDatabase("db1", nil, nil))
+	for i, pt := range data.PtView["db1"] {
+		assert2.Equal(t, data.DataNodes[i].ID, pt.Owner.NodeID)
+	}
+
+	other := &Data{}
+	other.Unmarshal(data.Marshal())
+	assert2.Equal(t, DiskFlood, other.DataNode(2).DiskStatus)
+}
+
+func TestData_MigratePt(t *testing.T) {
+	data := initData()
+	for i := range data.DataNodes {
+		data.DataNodes[i].Status = serf.StatusAlive
+	}
+	require.NoError(t, data.CreateDatabase("db0", nil, nil))
+	src := data.PtView["db0"][0].Owner.NodeID
+	dst := data.PtView["db0"][1].Owner.NodeID
+
+	require.Error(t, data.MigratePt("db1", 0, dst))
+	require.Error(t, data.MigratePt("db0", 10, dst))
+	require.Equal(t, ErrNodeNotFound, data.MigratePt("db0", 0, 10))
+	require.Error(t, data.MigratePt("db0", 0, src))
+
+	require.NoError(t, data.MigratePt("db0", 0, dst))
+	require.Equal(t, ErrPtMoving, data.MigratePt("db0", 0, dst))
+	e := data.MigrateEvents[(&DbPtInfo{Db: "db0", Pti: &data.PtView["db0"][0]}).String()]
+	assert2.Equal(t, MoveEventType, e.GetEventType())
+	assert2.Equal(t, src, e.GetSrc())
+	assert2.Equal(t, dst, e.GetDst())
+	assert2.Equal(t, "move", EventTypeName(e.GetEventType()))
+
+	// a failed move is replaced
+	e.SetCurrentState(int(MoveFailed))
+	e.SetErr("failed")
+	require.NoError(t, data.MigratePt("db0", 0, dst))
+	assert2.Equal(t, "", data.MigrateEvents[e.GetEventId()].GetErr())
+
+	// the removal of the failed move keeps the move replacing it
+	require.NoError(t, data.RemoveEventOp(e.GetEventId(), e.GetOpId()))
+	require.NotNil(t, data.MigrateEvents[e.GetEventId()])
+	require.NoError(t, data.RemoveEventOp(e.GetEventId(), data.MigrateEvents[e.GetEventId()].GetOpId()))
+	require.Nil(t, data.MigrateEvents[e.GetEventId()])
+}
+
+func TestData_DecommissionDataNode(t *testing.T) {
+	data := initData()
+	for i := range data.DataNodes {
+		data.DataNodes[i].Status = serf.StatusAlive
+	}
+	require.NoError(t, data.CreateDatabase("db0", nil, nil))
+	id := data.DataNodes[0].ID
+	owned := data.GetPtsByNodeId(id)
+	require.NotEmpty(t, owned)
+
+	require.Equal(t, ErrNodeOwnsPts, data.DeleteDataNode(id))
+	require.Equal(t, ErrNodeNotFound, data.DecommissionDataNode(10))
+
+	// an offline pt can not be moved, the decommission is refused until it is online
+	data.updatePtStatus("db0", owned[0], id, Offline)
+	require.True(t, errors.Is(data.DecommissionDataNode(id), ErrDecommissionBlocked))
+	assert2.False(t, data.DataNode(id).Decommissioning)
+	assert2.Equal(t, 0, len(data.MigrateEvents))
+	data.updatePtStatus("db0", owned[0], id, Online)
+
+	require.False(t, data.HasMoveEventsOf(id))
+	require.NoError(t, data.DecommissionDataNode(id))
+	require.True(t, data.HasMoveEventsOf(id))
+	assert2.True(t, data.DataNode(id).Decommissioning)
+	assert2.Equal(t, len(owned), len(data.MigrateEvents))
+	for _, e := range data.MigrateEvents {
+		assert2.Equal(t, id, e.GetSrc())
+		assert2.NotEqual(t, id, e.GetDst())
+	}
+	require.Equal(t, ErrNodeDecommissioning, data.MigratePt("db0", 1, id))
+
+	other := &Data{}
+	other.Unmarshal(data.Marshal())
+	assert2.True(t, other.DataNode(id).Decommissioning)
+
+	// the last node can not be decommissioned
+	for i := range data.DataNodes {
+		if data.DataNodes[i].ID != id {
+			data.DataNodes[i].Decommissioning = true
+		}
+	}
+	require.Equal(t, ErrNodeUnableToDropFinalNode, data.DecommissionDataNode(id))
+
+	for _, pt := range owned {
+		data.updatePtStatus("db0", pt, data.DataNodes[1].ID, Online)
+	}
+	require.NoError(t, data.DeleteDataNode(id))
+	assert2.Nil(t, data.DataNode(id))
+}
+
+func initZoneData() *Data {
+	data := &Data{PtNumPerNode: 2}
+	DataLogger = logger.New(os.Stderr)
+	for i, loc := range [][2]string{{"a", "r1"}, {"a", "r2"}, {"b", "r1"}, {"b", "r1"}} {
+		host := fmt.Sprintf("127.0.0.%d", i+1)
+		data.CreateDataNode(host+":8400", host+":8401", loc[0], loc[1])
+	}
+	for i := range data.DataNodes {
+		data.DataNodes[i].Status = serf.StatusAlive
+	}
+	return data
+}
+
+func TestData_CreateDatabase_SpreadZones(t *testing.T) {
+	data := initZoneData()
+	require.NoError(t, data.CreateDatabase("db0", nil, nil))
+	owners := make([]uint64, 0, len(data.PtView["db0"]))
+	for _, pt := range data.PtView["db0"] {
+		owners = append(owners, pt.Owner.NodeID)
+	}
+	// the zones are taken in turn, and the racks of zone a in turn
+	assert2.Equal(t, []uint64{1, 3, 2, 4, 1, 3, 2, 4}, owners)
+
+	// the nodes without labels keep the order by id
+	data = initData()
+	require.NoError(t, data.CreateDatabase("db0", nil, nil))
+	for i, pt := range data.PtView["db0"] {
+		assert2.Equal(t, data.DataNodes[i].ID, pt.Owner.NodeID)
+	}
+
+	other := &Data{}
+	other.Unmarshal(initZoneData().Marshal())
+	assert2.Equal(t, "b", other.DataNode(3).Zone)
+	assert2.Equal(t, "r1", other.DataNode(3).Rack)
+	require.NoError(t, other.SetDataNodeTopology(3, "c", "r2"))
+	assert2.Equal(t, "c", other.DataNode(3).Zone)
+	assert2.Equal(t, "r2", other.DataNode(3).Rack)
+	require.Equal(t, ErrNodeNotFound, other.SetDataNodeTopology(10, "c", "r2"))
+}
+
+func TestData_DecommissionDataNode_SpreadZones(t *testing.T) {
+	data := initZoneData()
+	require.NoError(t, data.CreateDatabase("db0", nil, nil))
+
+	// the pts of node 1 stay in zone a, so that zone b has as many pts of db0 as zone a
+	require.NoError(t, data.DecommissionDataNode(1))
+	require.Equal(t, 2, len(data.MigrateEvents))
+	for _, e := range data.MigrateEvents {
+		assert2.Equal(t, uint64(2), e.GetDst())
+	}
+}
+
+func TestData_CreateShardGroup_ReplicaZones(t *testing.T) {
+	data := initZoneData()
+	require.NoError(t, data.CreateDatabase("db0", nil, nil))
+	rpi := &RetentionPolicyInfo{
+		Name:               "rp0",
+		ReplicaN:           2,
+		ShardGroupDuration: time.Hour,
+		IndexGroupDuration: time.Hour}
+	require.NoError(t, data.CreateRetentionPolicy("db0", rpi, true))
+	require.NoError(t, data.CreateMeasurement("db0", "rp0", "cpu", nil, nil))
+	now := mustParseTime(time.RFC3339Nano, "2022-06-08T09:00:00Z")
+	require.NoError(t, data.CreateShardGroup("db0", "rp0", now, Hot))
+	sg, err := data.ShardGroupByTimestamp("db0", "rp0", now)
+	require.NoError(t, err)
+
+	require.Equal(t, 4, len(sg.Shards))
+	used := map[uint32]bool{}
+	for _, sh := range sg.Shards {
+		require.Equal(t, 2, len(sh.Owners))
+		zones := map[string]bool{}
+		for _, pt := range sh.Owners {
+			assert2.False(t, used[pt])
+			used[pt] = true
+			zones[data.DataNode(data.PtView["db0"][pt].Owner.NodeID).Zone] = true
+		}
+		assert2.Equal(t, 2, len(zones))
+	}
+}
+
+func TestData_SetBalancer(t *testing.T) {
+	data := &Data{}
+	data.SetBalancer(true, true)
+	other := &Data{}
+	other.Unmarshal(data.Marshal())
+	assert2.True(t, other.BalancerPaused)
+	assert2.True(t, other.BalancerDryRun)
+}
+
+func TestData_SetReplicationPaused(t *testing.T) {
+	data := &Data{}
+	data.SetReplicationPaused(true)
+	other := &Data{}
+	other.Unmarshal(data.Marshal())
+	assert2.True(t, other.ReplicationPaused)
+
+	data.SetReplicationPaused(false)
+	other.Unmarshal(data.Marshal())
+	assert2.False(t, other.ReplicationPaused)
+}
+
 func PrintMemUsage() {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
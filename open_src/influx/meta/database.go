@@ -178,6 +178,27 @@ const (
 	Disabled
 )
 
+func (s PtStatus) String() string {
+	switch s {
+	case Online:
+		return "online"
+	case PrepareOffload:
+		return "prepareOffload"
+	case PrepareAssign:
+		return "prepareAssign"
+	case Offline:
+		return "offline"
+	case RollbackPrepareOffload:
+		return "rollbackPrepareOffload"
+	case RollbackPrepareAssign:
+		return "rollbackPrepareAssign"
+	case Disabled:
+		return "disabled"
+	default:
+		return "unknown"
+	}
+}
+
 type PtInfo struct {
 	Owner  PtOwner
 	Status PtStatus
@@ -65,6 +65,21 @@ var (
 	ErrDataViewBootStrap = errors.New("cluster is bootstrapping for initial data view")
 	ErrDuplicateShardKey = errors.New("duplicate shard key")
 	ErrInvalidShardKey   = errors.New("invalid shard key")
+	ErrInvalidFloatCodec = errors.New("invalid float codec")
+	ErrInvalidTTL        = errors.New("measurement ttl must not be negative")
+
+	// ErrNodeDecommissioning is returned when moving a pt to a node being decommissioned
+	ErrNodeDecommissioning = errors.New("node is being decommissioned")
+
+	// ErrNodeOwnsPts is returned when deleting a data node which still owns pts
+	ErrNodeOwnsPts = errors.New("node still owns pts")
+
+	// ErrPtMoving is returned when moving a pt which is being moved
+	ErrPtMoving = errors.New("pt is being moved")
+
+	// ErrDecommissionBlocked is returned when decommissioning a node which owns offline pts,
+	// the pts can not be moved until they are online again
+	ErrDecommissionBlocked = errors.New("decommission is blocked by offline pts")
 )
 
 var (
@@ -218,6 +233,10 @@ func ErrShardGroupAlreadyReSharding(id uint64) error {
 	return fmt.Errorf("shard group already reSharding: %d", id)
 }
 
+func ErrInvalidHashSplits(id uint64, shardN, splitN int) error {
+	return fmt.Errorf("invalid hash splits of shard group %d: %d shards but %d splits", id, shardN, splitN)
+}
+
 func ErrShardingTypeNotEqual(rp, existType, inputType string) error {
 	return fmt.Errorf("sharding type are not equal in %s exist type %s inputType %s", rp, existType, inputType)
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package meta
 
 import (
+	"time"
+
 	"github.com/gogo/protobuf/proto"
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 	proto2 "github.com/openGemini/openGemini/open_src/influx/meta/proto"
@@ -29,6 +31,8 @@ type MeasurementInfo struct {
 	Schema         map[string]int32
 	IndexRelations []IndexRelation
 	MarkDeleted    bool
+	FloatCodec     string
+	TTL            time.Duration
 }
 
 func (msti *MeasurementInfo) walkSchema(fn func(fieldName string, fieldType int32)) {
@@ -52,6 +56,14 @@ func (msti *MeasurementInfo) marshal() *proto2.MeasurementInfo {
 		MarkDeleted: proto.Bool(msti.MarkDeleted),
 	}
 
+	if msti.FloatCodec != "" {
+		pb.FloatCodec = proto.String(msti.FloatCodec)
+	}
+
+	if msti.TTL > 0 {
+		pb.TTL = proto.Int64(int64(msti.TTL))
+	}
+
 	if msti.ShardKeys != nil {
 		pb.ShardKeys = make([]*proto2.ShardKeyInfo, len(msti.ShardKeys))
 		for i := range msti.ShardKeys {
@@ -79,6 +91,8 @@ func (msti *MeasurementInfo) marshal() *proto2.MeasurementInfo {
 func (msti *MeasurementInfo) unmarshal(pb *proto2.MeasurementInfo) {
 	msti.Name = pb.GetName()
 	msti.MarkDeleted = pb.GetMarkDeleted()
+	msti.FloatCodec = pb.GetFloatCodec()
+	msti.TTL = time.Duration(pb.GetTTL())
 	if pb.GetShardKeys() != nil {
 		msti.ShardKeys = make([]ShardKeyInfo, len(pb.GetShardKeys()))
 		for i := range pb.GetShardKeys() {
@@ -1,6 +1,8 @@
 package meta
 
 import (
+	"strconv"
+
 	"github.com/gogo/protobuf/proto"
 	metaProto "github.com/openGemini/openGemini/open_src/influx/meta/proto"
 )
@@ -14,6 +16,9 @@ type MigrateEventInfo struct {
 	preState  int
 	src       uint64
 	dest      uint64
+	// bytes copied to the dest node and the error of the event, they are shown by SHOW EVENTS
+	bytes int64
+	err   string
 }
 
 func NewMigrateEventInfo(eventId string, eventType int, pt *DbPtInfo, dest uint64) *MigrateEventInfo {
@@ -69,6 +74,38 @@ func (m *MigrateEventInfo) SetPreState(state int) {
 	m.preState = state
 }
 
+func (m *MigrateEventInfo) GetEventId() string {
+	return m.eventId
+}
+
+func (m *MigrateEventInfo) GetBytes() int64 {
+	return m.bytes
+}
+
+func (m *MigrateEventInfo) SetBytes(bytes int64) {
+	m.bytes = bytes
+}
+
+func (m *MigrateEventInfo) GetErr() string {
+	return m.err
+}
+
+func (m *MigrateEventInfo) SetErr(err string) {
+	m.err = err
+}
+
+// StateName returns the name of the current state for the move events, and the state number for the others
+func (m *MigrateEventInfo) StateName() string {
+	if m.eventType == MoveEventType {
+		return MoveState(m.currState).String()
+	}
+	return strconv.Itoa(m.currState)
+}
+
+func (m *MigrateEventInfo) Marshal() *metaProto.MigrateEventInfo {
+	return m.marshal()
+}
+
 func (m *MigrateEventInfo) marshal() *metaProto.MigrateEventInfo {
 	pb := &metaProto.MigrateEventInfo{
 		EventId:   proto.String(m.eventId),
@@ -79,6 +116,8 @@ func (m *MigrateEventInfo) marshal() *metaProto.MigrateEventInfo {
 		PreState:  proto.Int(m.preState),
 		Dest:      proto.Uint64(m.dest),
 		Src:       proto.Uint64(m.src),
+		Bytes:     proto.Int64(m.bytes),
+		Err:       proto.String(m.err),
 	}
 	return pb
 }
@@ -93,6 +132,8 @@ func (m *MigrateEventInfo) unmarshal(pb *metaProto.MigrateEventInfo) {
 	m.currState = int(pb.GetCurrState())
 	m.src = pb.GetSrc()
 	m.dest = pb.GetDest()
+	m.bytes = pb.GetBytes()
+	m.err = pb.GetErr()
 }
 
 func (m *MigrateEventInfo) Clone() *MigrateEventInfo {
@@ -100,3 +141,53 @@ func (m *MigrateEventInfo) Clone() *MigrateEventInfo {
 	other.pt = &(*(m.pt))
 	return &other
 }
+
+// MoveEventType is the type of the events moving a pt from one node to another
+const MoveEventType = 2
+
+var eventTypeNames = []string{"assign", "offload", "move"}
+
+func EventTypeName(typ int) string {
+	if typ < 0 || typ >= len(eventTypeNames) {
+		return "unknown"
+	}
+	return eventTypeNames[typ]
+}
+
+// MoveState is the state of moving a pt, the files of the pt are copied while the source node still serves it,
+// then the pt is offloaded from the source node, the files changed meanwhile are copied and the owner is switched
+type MoveState int
+
+const (
+	MoveInit MoveState = iota
+	MovePreCopy
+	MoveOffload
+	MoveFinalSync
+	MoveCutover
+	MoveClean
+	MoveRollback
+	MoveFailed
+)
+
+func (s MoveState) String() string {
+	switch s {
+	case MoveInit:
+		return "init"
+	case MovePreCopy:
+		return "preCopy"
+	case MoveOffload:
+		return "offload"
+	case MoveFinalSync:
+		return "finalSync"
+	case MoveCutover:
+		return "cutover"
+	case MoveClean:
+		return "clean"
+	case MoveRollback:
+		return "rollback"
+	case MoveFailed:
+		return "failed"
+	default:
+		return "unknown move state"
+	}
+}
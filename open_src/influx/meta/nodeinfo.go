@@ -26,6 +26,34 @@ const (
 	StatusLeft
 )
 
+// DiskStatus is the disk usage level of a data node against its watermarks
+type DiskStatus int32
+
+const (
+	DiskNormal DiskStatus = iota
+	// the usage exceeds the low watermark
+	DiskLow
+	// the usage exceeds the high watermark, the node accepts no new shard or partition
+	DiskHigh
+	// the usage exceeds the flood watermark, the node rejects the writes
+	DiskFlood
+)
+
+func (s DiskStatus) String() string {
+	switch s {
+	case DiskNormal:
+		return "normal"
+	case DiskLow:
+		return "low"
+	case DiskHigh:
+		return "high"
+	case DiskFlood:
+		return "flood"
+	default:
+		return "unknown"
+	}
+}
+
 // NodeInfo represents information about a single node in the cluster.
 type NodeInfo struct {
 	ID         uint64
@@ -66,6 +94,12 @@ func (ni *NodeInfo) unmarshal(pb *proto2.NodeInfo) {
 
 type DataNode struct {
 	NodeInfo
+	DiskStatus DiskStatus
+	// the node is being removed, its pts are moved to the other nodes
+	Decommissioning bool
+	// physical location of the node, the pts are spread across the zones and racks
+	Zone string
+	Rack string
 }
 
 func (n *DataNode) MarshalBinary() ([]byte, error) {
@@ -84,10 +118,22 @@ func (n *DataNode) UnmarshalBinary(buf []byte) error {
 func (n *DataNode) marshal() *proto2.DataNode {
 	pb := &proto2.DataNode{}
 	pb.Ni = n.NodeInfo.marshal()
+	pb.DiskStatus = proto.Int32(int32(n.DiskStatus))
+	pb.Decommissioning = proto.Bool(n.Decommissioning)
+	if n.Zone != "" {
+		pb.Zone = proto.String(n.Zone)
+	}
+	if n.Rack != "" {
+		pb.Rack = proto.String(n.Rack)
+	}
 	return pb
 }
 func (n *DataNode) unmarshal(pb *proto2.DataNode) {
 	n.NodeInfo.unmarshal(pb.GetNi())
+	n.DiskStatus = DiskStatus(pb.GetDiskStatus())
+	n.Decommissioning = pb.GetDecommissioning()
+	n.Zone = pb.GetZone()
+	n.Rack = pb.GetRack()
 }
 
 // NodeInfos is a slice of NodeInfo used for sorting
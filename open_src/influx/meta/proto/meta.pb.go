@@ -67,6 +67,13 @@ const (
 	Command_UpdateEventCommand               Command_Type = 66
 	Command_UpdatePtInfoCommand              Command_Type = 67
 	Command_RemoveEventCommand               Command_Type = 68
+	Command_UpdateNodeDiskStatusCommand      Command_Type = 69
+	Command_AlterMeasurementTTLCommand       Command_Type = 70
+	Command_MigratePtCommand                 Command_Type = 71
+	Command_DecommissionDataNodeCommand      Command_Type = 72
+	Command_SetBalancerCommand               Command_Type = 73
+	Command_ImportSchemaCommand              Command_Type = 74
+	Command_SetReplicationPausedCommand      Command_Type = 75
 )
 
 var Command_Type_name = map[int32]string{
@@ -113,6 +120,13 @@ var Command_Type_name = map[int32]string{
 	66: "UpdateEventCommand",
 	67: "UpdatePtInfoCommand",
 	68: "RemoveEventCommand",
+	69: "UpdateNodeDiskStatusCommand",
+	70: "AlterMeasurementTTLCommand",
+	71: "MigratePtCommand",
+	72: "DecommissionDataNodeCommand",
+	73: "SetBalancerCommand",
+	74: "ImportSchemaCommand",
+	75: "SetReplicationPausedCommand",
 }
 
 var Command_Type_value = map[string]int32{
@@ -159,6 +173,13 @@ var Command_Type_value = map[string]int32{
 	"UpdateEventCommand":               66,
 	"UpdatePtInfoCommand":              67,
 	"RemoveEventCommand":               68,
+	"UpdateNodeDiskStatusCommand":      69,
+	"AlterMeasurementTTLCommand":       70,
+	"MigratePtCommand":                 71,
+	"DecommissionDataNodeCommand":      72,
+	"SetBalancerCommand":               73,
+	"ImportSchemaCommand":              74,
+	"SetReplicationPausedCommand":      75,
 }
 
 func (x Command_Type) Enum() *Command_Type {
@@ -204,6 +225,9 @@ type Data struct {
 	MaxEventOpId         *uint64              `protobuf:"varint,19,opt,name=MaxEventOpId" json:"MaxEventOpId,omitempty"`
 	TakeOverEnabled      *bool                `protobuf:"varint,20,opt,name=TakeOverEnabled" json:"TakeOverEnabled,omitempty"`
 	MigrateEvents        []*MigrateEventInfo  `protobuf:"bytes,21,rep,name=MigrateEvents" json:"MigrateEvents,omitempty"`
+	BalancerPaused       *bool                `protobuf:"varint,22,opt,name=BalancerPaused" json:"BalancerPaused,omitempty"`
+	BalancerDryRun       *bool                `protobuf:"varint,23,opt,name=BalancerDryRun" json:"BalancerDryRun,omitempty"`
+	ReplicationPaused    *bool                `protobuf:"varint,24,opt,name=ReplicationPaused" json:"ReplicationPaused,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
 	XXX_sizecache        int32                `json:"-"`
@@ -366,6 +390,27 @@ func (m *Data) GetMigrateEvents() []*MigrateEventInfo {
 	return nil
 }
 
+func (m *Data) GetBalancerPaused() bool {
+	if m != nil && m.BalancerPaused != nil {
+		return *m.BalancerPaused
+	}
+	return false
+}
+
+func (m *Data) GetBalancerDryRun() bool {
+	if m != nil && m.BalancerDryRun != nil {
+		return *m.BalancerDryRun
+	}
+	return false
+}
+
+func (m *Data) GetReplicationPaused() bool {
+	if m != nil && m.ReplicationPaused != nil {
+		return *m.ReplicationPaused
+	}
+	return false
+}
+
 type PtOwner struct {
 	NodeID               *uint64  `protobuf:"varint,1,req,name=NodeID" json:"NodeID,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -584,6 +629,10 @@ func (m *NodeInfo) GetGossipAddr() string {
 
 type DataNode struct {
 	Ni                   *NodeInfo `protobuf:"bytes,1,req,name=Ni" json:"Ni,omitempty"`
+	DiskStatus           *int32    `protobuf:"varint,2,opt,name=DiskStatus" json:"DiskStatus,omitempty"`
+	Decommissioning      *bool     `protobuf:"varint,3,opt,name=Decommissioning" json:"Decommissioning,omitempty"`
+	Zone                 *string   `protobuf:"bytes,4,opt,name=Zone" json:"Zone,omitempty"`
+	Rack                 *string   `protobuf:"bytes,5,opt,name=Rack" json:"Rack,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -620,6 +669,34 @@ func (m *DataNode) GetNi() *NodeInfo {
 	return nil
 }
 
+func (m *DataNode) GetDiskStatus() int32 {
+	if m != nil && m.DiskStatus != nil {
+		return *m.DiskStatus
+	}
+	return 0
+}
+
+func (m *DataNode) GetDecommissioning() bool {
+	if m != nil && m.Decommissioning != nil {
+		return *m.Decommissioning
+	}
+	return false
+}
+
+func (m *DataNode) GetZone() string {
+	if m != nil && m.Zone != nil {
+		return *m.Zone
+	}
+	return ""
+}
+
+func (m *DataNode) GetRack() string {
+	if m != nil && m.Rack != nil {
+		return *m.Rack
+	}
+	return ""
+}
+
 type DatabaseInfo struct {
 	Name                   *string                `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
 	DefaultRetentionPolicy *string                `protobuf:"bytes,2,req,name=DefaultRetentionPolicy" json:"DefaultRetentionPolicy,omitempty"`
@@ -758,6 +835,8 @@ type MeasurementInfo struct {
 	Schema               map[string]int32 `protobuf:"bytes,3,rep,name=Schema" json:"Schema,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
 	MarkDeleted          *bool            `protobuf:"varint,4,opt,name=MarkDeleted" json:"MarkDeleted,omitempty"`
 	IndexRelations       []*IndexRelation `protobuf:"bytes,5,rep,name=indexRelations" json:"indexRelations,omitempty"`
+	FloatCodec           *string          `protobuf:"bytes,6,opt,name=FloatCodec" json:"FloatCodec,omitempty"`
+	TTL                  *int64           `protobuf:"varint,7,opt,name=TTL" json:"TTL,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -822,6 +901,20 @@ func (m *MeasurementInfo) GetIndexRelations() []*IndexRelation {
 	return nil
 }
 
+func (m *MeasurementInfo) GetFloatCodec() string {
+	if m != nil && m.FloatCodec != nil {
+		return *m.FloatCodec
+	}
+	return ""
+}
+
+func (m *MeasurementInfo) GetTTL() int64 {
+	if m != nil && m.TTL != nil {
+		return *m.TTL
+	}
+	return 0
+}
+
 type RetentionPolicyInfo struct {
 	Name                 *string             `protobuf:"bytes,1,req,name=Name" json:"Name,omitempty"`
 	Duration             *int64              `protobuf:"varint,2,req,name=Duration" json:"Duration,omitempty"`
@@ -955,6 +1048,7 @@ type ShardGroupInfo struct {
 	DeletedAt            *int64       `protobuf:"varint,4,req,name=DeletedAt" json:"DeletedAt,omitempty"`
 	Shards               []*ShardInfo `protobuf:"bytes,5,rep,name=Shards" json:"Shards,omitempty"`
 	TruncatedAt          *int64       `protobuf:"varint,6,opt,name=TruncatedAt" json:"TruncatedAt,omitempty"`
+	HashSlotN            *uint32      `protobuf:"varint,7,opt,name=HashSlotN" json:"HashSlotN,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
 	XXX_unrecognized     []byte       `json:"-"`
 	XXX_sizecache        int32        `json:"-"`
@@ -1026,6 +1120,13 @@ func (m *ShardGroupInfo) GetTruncatedAt() int64 {
 	return 0
 }
 
+func (m *ShardGroupInfo) GetHashSlotN() uint32 {
+	if m != nil && m.HashSlotN != nil {
+		return *m.HashSlotN
+	}
+	return 0
+}
+
 type ShardInfo struct {
 	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
 	OwnerIDs             []uint32 `protobuf:"varint,2,rep,name=OwnerIDs" json:"OwnerIDs,omitempty"` // Deprecated: Do not use.
@@ -1033,6 +1134,9 @@ type ShardInfo struct {
 	Max                  *string  `protobuf:"bytes,4,req,name=Max" json:"Max,omitempty"`
 	Tier                 *uint64  `protobuf:"varint,5,req,name=Tier" json:"Tier,omitempty"`
 	IndexID              *uint64  `protobuf:"varint,6,req,name=IndexID" json:"IndexID,omitempty"`
+	HashSlot             *uint32  `protobuf:"varint,7,opt,name=HashSlot" json:"HashSlot,omitempty"`
+	HashMin              *uint64  `protobuf:"varint,8,opt,name=HashMin" json:"HashMin,omitempty"`
+	HashMax              *uint64  `protobuf:"varint,9,opt,name=HashMax" json:"HashMax,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1105,6 +1209,27 @@ func (m *ShardInfo) GetIndexID() uint64 {
 	return 0
 }
 
+func (m *ShardInfo) GetHashSlot() uint32 {
+	if m != nil && m.HashSlot != nil {
+		return *m.HashSlot
+	}
+	return 0
+}
+
+func (m *ShardInfo) GetHashMin() uint64 {
+	if m != nil && m.HashMin != nil {
+		return *m.HashMin
+	}
+	return 0
+}
+
+func (m *ShardInfo) GetHashMax() uint64 {
+	if m != nil && m.HashMax != nil {
+		return *m.HashMax
+	}
+	return 0
+}
+
 type ShardKeyInfo struct {
 	ShardKey             []string `protobuf:"bytes,1,rep,name=ShardKey" json:"ShardKey,omitempty"`
 	Type                 *string  `protobuf:"bytes,2,opt,name=Type" json:"Type,omitempty"`
@@ -2613,6 +2738,8 @@ var E_CreateMetaNodeCommand_Command = &proto.ExtensionDesc{
 type CreateDataNodeCommand struct {
 	HTTPAddr             *string  `protobuf:"bytes,1,req,name=HTTPAddr" json:"HTTPAddr,omitempty"`
 	TCPAddr              *string  `protobuf:"bytes,2,req,name=TCPAddr" json:"TCPAddr,omitempty"`
+	Zone                 *string  `protobuf:"bytes,3,opt,name=Zone" json:"Zone,omitempty"`
+	Rack                 *string  `protobuf:"bytes,4,opt,name=Rack" json:"Rack,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2656,6 +2783,20 @@ func (m *CreateDataNodeCommand) GetTCPAddr() string {
 	return ""
 }
 
+func (m *CreateDataNodeCommand) GetZone() string {
+	if m != nil && m.Zone != nil {
+		return *m.Zone
+	}
+	return ""
+}
+
+func (m *CreateDataNodeCommand) GetRack() string {
+	if m != nil && m.Rack != nil {
+		return *m.Rack
+	}
+	return ""
+}
+
 var E_CreateDataNodeCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
 	ExtensionType: (*CreateDataNodeCommand)(nil),
@@ -3166,6 +3307,7 @@ type CreateMeasurementCommand struct {
 	Name                 *string        `protobuf:"bytes,3,req,name=Name" json:"Name,omitempty"`
 	Ski                  *ShardKeyInfo  `protobuf:"bytes,4,opt,name=Ski" json:"Ski,omitempty"`
 	IR                   *IndexRelation `protobuf:"bytes,5,opt,name=IR" json:"IR,omitempty"`
+	FloatCodec           *string        `protobuf:"bytes,6,opt,name=FloatCodec" json:"FloatCodec,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -3230,6 +3372,13 @@ func (m *CreateMeasurementCommand) GetIR() *IndexRelation {
 	return nil
 }
 
+func (m *CreateMeasurementCommand) GetFloatCodec() string {
+	if m != nil && m.FloatCodec != nil {
+		return *m.FloatCodec
+	}
+	return ""
+}
+
 var E_CreateMeasurementCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
 	ExtensionType: (*CreateMeasurementCommand)(nil),
@@ -3395,6 +3544,7 @@ type ReShardingCommand struct {
 	ShardGroupID         *uint64  `protobuf:"varint,3,req,name=ShardGroupID" json:"ShardGroupID,omitempty"`
 	SplitTime            *int64   `protobuf:"varint,4,req,name=SplitTime" json:"SplitTime,omitempty"`
 	ShardBounds          []string `protobuf:"bytes,5,rep,name=ShardBounds" json:"ShardBounds,omitempty"`
+	HashSplits           []uint32 `protobuf:"varint,6,rep,name=HashSplits" json:"HashSplits,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3459,6 +3609,13 @@ func (m *ReShardingCommand) GetShardBounds() []string {
 	return nil
 }
 
+func (m *ReShardingCommand) GetHashSplits() []uint32 {
+	if m != nil {
+		return m.HashSplits
+	}
+	return nil
+}
+
 var E_ReShardingCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
 	ExtensionType: (*ReShardingCommand)(nil),
@@ -3813,6 +3970,7 @@ type DBPtStatus struct {
 	DB                   *string          `protobuf:"bytes,1,req,name=DB" json:"DB,omitempty"`
 	PtID                 *uint32          `protobuf:"varint,2,req,name=PtID" json:"PtID,omitempty"`
 	RpStats              []*RpShardStatus `protobuf:"bytes,3,rep,name=RpStats" json:"RpStats,omitempty"`
+	DiskSize             *uint64          `protobuf:"varint,4,opt,name=DiskSize" json:"DiskSize,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
 	XXX_unrecognized     []byte           `json:"-"`
 	XXX_sizecache        int32            `json:"-"`
@@ -3863,6 +4021,13 @@ func (m *DBPtStatus) GetRpStats() []*RpShardStatus {
 	return nil
 }
 
+func (m *DBPtStatus) GetDiskSize() uint64 {
+	if m != nil && m.DiskSize != nil {
+		return *m.DiskSize
+	}
+	return 0
+}
+
 type ReportShardsLoadCommand struct {
 	DBPTStat             []*DBPtStatus `protobuf:"bytes,1,rep,name=DBPTStat" json:"DBPTStat,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -5063,6 +5228,8 @@ type MigrateEventInfo struct {
 	PreState             *int32   `protobuf:"varint,6,opt,name=preState" json:"preState,omitempty"`
 	Src                  *uint64  `protobuf:"varint,7,opt,name=src" json:"src,omitempty"`
 	Dest                 *uint64  `protobuf:"varint,8,opt,name=dest" json:"dest,omitempty"`
+	Bytes                *int64   `protobuf:"varint,9,opt,name=bytes" json:"bytes,omitempty"`
+	Err                  *string  `protobuf:"bytes,10,opt,name=err" json:"err,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -5148,6 +5315,20 @@ func (m *MigrateEventInfo) GetDest() uint64 {
 	return 0
 }
 
+func (m *MigrateEventInfo) GetBytes() int64 {
+	if m != nil && m.Bytes != nil {
+		return *m.Bytes
+	}
+	return 0
+}
+
+func (m *MigrateEventInfo) GetErr() string {
+	if m != nil && m.Err != nil {
+		return *m.Err
+	}
+	return ""
+}
+
 type CreateEventCommand struct {
 	EventInfo            *MigrateEventInfo `protobuf:"bytes,1,req,name=eventInfo" json:"eventInfo,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
@@ -5315,6 +5496,7 @@ var E_UpdatePtInfoCommand_Command = &proto.ExtensionDesc{
 
 type RemoveEventCommand struct {
 	EventId              *string  `protobuf:"bytes,1,req,name=eventId" json:"eventId,omitempty"`
+	OpId                 *uint64  `protobuf:"varint,2,opt,name=opId" json:"opId,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -5351,6 +5533,13 @@ func (m *RemoveEventCommand) GetEventId() string {
 	return ""
 }
 
+func (m *RemoveEventCommand) GetOpId() uint64 {
+	if m != nil && m.OpId != nil {
+		return *m.OpId
+	}
+	return 0
+}
+
 var E_RemoveEventCommand_Command = &proto.ExtensionDesc{
 	ExtendedType:  (*Command)(nil),
 	ExtensionType: (*RemoveEventCommand)(nil),
@@ -5360,6 +5549,391 @@ var E_RemoveEventCommand_Command = &proto.ExtensionDesc{
 	Filename:      "open_src/influx/meta/proto/meta.proto",
 }
 
+type UpdateNodeDiskStatusCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	DiskStatus           *int32   `protobuf:"varint,2,req,name=DiskStatus" json:"DiskStatus,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateNodeDiskStatusCommand) Reset()         { *m = UpdateNodeDiskStatusCommand{} }
+func (m *UpdateNodeDiskStatusCommand) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeDiskStatusCommand) ProtoMessage()    {}
+func (*UpdateNodeDiskStatusCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4aed0c02de55ead8, []int{84}
+}
+func (m *UpdateNodeDiskStatusCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateNodeDiskStatusCommand.Unmarshal(m, b)
+}
+func (m *UpdateNodeDiskStatusCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateNodeDiskStatusCommand.Marshal(b, m, deterministic)
+}
+func (m *UpdateNodeDiskStatusCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeDiskStatusCommand.Merge(m, src)
+}
+func (m *UpdateNodeDiskStatusCommand) XXX_Size() int {
+	return xxx_messageInfo_UpdateNodeDiskStatusCommand.Size(m)
+}
+func (m *UpdateNodeDiskStatusCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateNodeDiskStatusCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateNodeDiskStatusCommand proto.InternalMessageInfo
+
+func (m *UpdateNodeDiskStatusCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
+	}
+	return 0
+}
+
+func (m *UpdateNodeDiskStatusCommand) GetDiskStatus() int32 {
+	if m != nil && m.DiskStatus != nil {
+		return *m.DiskStatus
+	}
+	return 0
+}
+
+var E_UpdateNodeDiskStatusCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*UpdateNodeDiskStatusCommand)(nil),
+	Field:         169,
+	Name:          "proto.UpdateNodeDiskStatusCommand.command",
+	Tag:           "bytes,169,opt,name=command",
+	Filename:      "open_src/influx/meta/proto/meta.proto",
+}
+
+type AlterMeasurementTTLCommand struct {
+	DBName               *string  `protobuf:"bytes,1,req,name=DBName" json:"DBName,omitempty"`
+	RpName               *string  `protobuf:"bytes,2,req,name=RpName" json:"RpName,omitempty"`
+	Name                 *string  `protobuf:"bytes,3,req,name=Name" json:"Name,omitempty"`
+	TTL                  *int64   `protobuf:"varint,4,req,name=TTL" json:"TTL,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlterMeasurementTTLCommand) Reset()         { *m = AlterMeasurementTTLCommand{} }
+func (m *AlterMeasurementTTLCommand) String() string { return proto.CompactTextString(m) }
+func (*AlterMeasurementTTLCommand) ProtoMessage()    {}
+func (*AlterMeasurementTTLCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4aed0c02de55ead8, []int{85}
+}
+func (m *AlterMeasurementTTLCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlterMeasurementTTLCommand.Unmarshal(m, b)
+}
+func (m *AlterMeasurementTTLCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlterMeasurementTTLCommand.Marshal(b, m, deterministic)
+}
+func (m *AlterMeasurementTTLCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlterMeasurementTTLCommand.Merge(m, src)
+}
+func (m *AlterMeasurementTTLCommand) XXX_Size() int {
+	return xxx_messageInfo_AlterMeasurementTTLCommand.Size(m)
+}
+func (m *AlterMeasurementTTLCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlterMeasurementTTLCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlterMeasurementTTLCommand proto.InternalMessageInfo
+
+func (m *AlterMeasurementTTLCommand) GetDBName() string {
+	if m != nil && m.DBName != nil {
+		return *m.DBName
+	}
+	return ""
+}
+
+func (m *AlterMeasurementTTLCommand) GetRpName() string {
+	if m != nil && m.RpName != nil {
+		return *m.RpName
+	}
+	return ""
+}
+
+func (m *AlterMeasurementTTLCommand) GetName() string {
+	if m != nil && m.Name != nil {
+		return *m.Name
+	}
+	return ""
+}
+
+func (m *AlterMeasurementTTLCommand) GetTTL() int64 {
+	if m != nil && m.TTL != nil {
+		return *m.TTL
+	}
+	return 0
+}
+
+var E_AlterMeasurementTTLCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*AlterMeasurementTTLCommand)(nil),
+	Field:         170,
+	Name:          "proto.AlterMeasurementTTLCommand.command",
+	Tag:           "bytes,170,opt,name=command",
+	Filename:      "open_src/influx/meta/proto/meta.proto",
+}
+
+type MigratePtCommand struct {
+	Database             *string  `protobuf:"bytes,1,req,name=Database" json:"Database,omitempty"`
+	PtId                 *uint32  `protobuf:"varint,2,req,name=PtId" json:"PtId,omitempty"`
+	NodeID               *uint64  `protobuf:"varint,3,req,name=NodeID" json:"NodeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MigratePtCommand) Reset()         { *m = MigratePtCommand{} }
+func (m *MigratePtCommand) String() string { return proto.CompactTextString(m) }
+func (*MigratePtCommand) ProtoMessage()    {}
+func (*MigratePtCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4aed0c02de55ead8, []int{86}
+}
+func (m *MigratePtCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MigratePtCommand.Unmarshal(m, b)
+}
+func (m *MigratePtCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MigratePtCommand.Marshal(b, m, deterministic)
+}
+func (m *MigratePtCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MigratePtCommand.Merge(m, src)
+}
+func (m *MigratePtCommand) XXX_Size() int {
+	return xxx_messageInfo_MigratePtCommand.Size(m)
+}
+func (m *MigratePtCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_MigratePtCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MigratePtCommand proto.InternalMessageInfo
+
+func (m *MigratePtCommand) GetDatabase() string {
+	if m != nil && m.Database != nil {
+		return *m.Database
+	}
+	return ""
+}
+
+func (m *MigratePtCommand) GetPtId() uint32 {
+	if m != nil && m.PtId != nil {
+		return *m.PtId
+	}
+	return 0
+}
+
+func (m *MigratePtCommand) GetNodeID() uint64 {
+	if m != nil && m.NodeID != nil {
+		return *m.NodeID
+	}
+	return 0
+}
+
+var E_MigratePtCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*MigratePtCommand)(nil),
+	Field:         171,
+	Name:          "proto.MigratePtCommand.command",
+	Tag:           "bytes,171,opt,name=command",
+	Filename:      "open_src/influx/meta/proto/meta.proto",
+}
+
+type DecommissionDataNodeCommand struct {
+	ID                   *uint64  `protobuf:"varint,1,req,name=ID" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DecommissionDataNodeCommand) Reset()         { *m = DecommissionDataNodeCommand{} }
+func (m *DecommissionDataNodeCommand) String() string { return proto.CompactTextString(m) }
+func (*DecommissionDataNodeCommand) ProtoMessage()    {}
+func (*DecommissionDataNodeCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4aed0c02de55ead8, []int{87}
+}
+func (m *DecommissionDataNodeCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DecommissionDataNodeCommand.Unmarshal(m, b)
+}
+func (m *DecommissionDataNodeCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DecommissionDataNodeCommand.Marshal(b, m, deterministic)
+}
+func (m *DecommissionDataNodeCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DecommissionDataNodeCommand.Merge(m, src)
+}
+func (m *DecommissionDataNodeCommand) XXX_Size() int {
+	return xxx_messageInfo_DecommissionDataNodeCommand.Size(m)
+}
+func (m *DecommissionDataNodeCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_DecommissionDataNodeCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DecommissionDataNodeCommand proto.InternalMessageInfo
+
+func (m *DecommissionDataNodeCommand) GetID() uint64 {
+	if m != nil && m.ID != nil {
+		return *m.ID
+	}
+	return 0
+}
+
+var E_DecommissionDataNodeCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*DecommissionDataNodeCommand)(nil),
+	Field:         172,
+	Name:          "proto.DecommissionDataNodeCommand.command",
+	Tag:           "bytes,172,opt,name=command",
+	Filename:      "open_src/influx/meta/proto/meta.proto",
+}
+
+type SetBalancerCommand struct {
+	Paused               *bool    `protobuf:"varint,1,req,name=Paused" json:"Paused,omitempty"`
+	DryRun               *bool    `protobuf:"varint,2,req,name=DryRun" json:"DryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetBalancerCommand) Reset()         { *m = SetBalancerCommand{} }
+func (m *SetBalancerCommand) String() string { return proto.CompactTextString(m) }
+func (*SetBalancerCommand) ProtoMessage()    {}
+func (*SetBalancerCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4aed0c02de55ead8, []int{88}
+}
+func (m *SetBalancerCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetBalancerCommand.Unmarshal(m, b)
+}
+func (m *SetBalancerCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetBalancerCommand.Marshal(b, m, deterministic)
+}
+func (m *SetBalancerCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetBalancerCommand.Merge(m, src)
+}
+func (m *SetBalancerCommand) XXX_Size() int {
+	return xxx_messageInfo_SetBalancerCommand.Size(m)
+}
+func (m *SetBalancerCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetBalancerCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetBalancerCommand proto.InternalMessageInfo
+
+func (m *SetBalancerCommand) GetPaused() bool {
+	if m != nil && m.Paused != nil {
+		return *m.Paused
+	}
+	return false
+}
+
+func (m *SetBalancerCommand) GetDryRun() bool {
+	if m != nil && m.DryRun != nil {
+		return *m.DryRun
+	}
+	return false
+}
+
+var E_SetBalancerCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*SetBalancerCommand)(nil),
+	Field:         173,
+	Name:          "proto.SetBalancerCommand.command",
+	Tag:           "bytes,173,opt,name=command",
+	Filename:      "open_src/influx/meta/proto/meta.proto",
+}
+
+type ImportSchemaCommand struct {
+	Schema               []byte   `protobuf:"bytes,1,req,name=Schema" json:"Schema,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportSchemaCommand) Reset()         { *m = ImportSchemaCommand{} }
+func (m *ImportSchemaCommand) String() string { return proto.CompactTextString(m) }
+func (*ImportSchemaCommand) ProtoMessage()    {}
+func (*ImportSchemaCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4aed0c02de55ead8, []int{89}
+}
+func (m *ImportSchemaCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportSchemaCommand.Unmarshal(m, b)
+}
+func (m *ImportSchemaCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportSchemaCommand.Marshal(b, m, deterministic)
+}
+func (m *ImportSchemaCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportSchemaCommand.Merge(m, src)
+}
+func (m *ImportSchemaCommand) XXX_Size() int {
+	return xxx_messageInfo_ImportSchemaCommand.Size(m)
+}
+func (m *ImportSchemaCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportSchemaCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportSchemaCommand proto.InternalMessageInfo
+
+func (m *ImportSchemaCommand) GetSchema() []byte {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+var E_ImportSchemaCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*ImportSchemaCommand)(nil),
+	Field:         174,
+	Name:          "proto.ImportSchemaCommand.command",
+	Tag:           "bytes,174,opt,name=command",
+	Filename:      "open_src/influx/meta/proto/meta.proto",
+}
+
+type SetReplicationPausedCommand struct {
+	Paused               *bool    `protobuf:"varint,1,req,name=Paused" json:"Paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetReplicationPausedCommand) Reset()         { *m = SetReplicationPausedCommand{} }
+func (m *SetReplicationPausedCommand) String() string { return proto.CompactTextString(m) }
+func (*SetReplicationPausedCommand) ProtoMessage()    {}
+func (*SetReplicationPausedCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4aed0c02de55ead8, []int{90}
+}
+func (m *SetReplicationPausedCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetReplicationPausedCommand.Unmarshal(m, b)
+}
+func (m *SetReplicationPausedCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetReplicationPausedCommand.Marshal(b, m, deterministic)
+}
+func (m *SetReplicationPausedCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetReplicationPausedCommand.Merge(m, src)
+}
+func (m *SetReplicationPausedCommand) XXX_Size() int {
+	return xxx_messageInfo_SetReplicationPausedCommand.Size(m)
+}
+func (m *SetReplicationPausedCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetReplicationPausedCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetReplicationPausedCommand proto.InternalMessageInfo
+
+func (m *SetReplicationPausedCommand) GetPaused() bool {
+	if m != nil && m.Paused != nil {
+		return *m.Paused
+	}
+	return false
+}
+
+var E_SetReplicationPausedCommand_Command = &proto.ExtensionDesc{
+	ExtendedType:  (*Command)(nil),
+	ExtensionType: (*SetReplicationPausedCommand)(nil),
+	Field:         175,
+	Name:          "proto.SetReplicationPausedCommand.command",
+	Tag:           "bytes,175,opt,name=command",
+	Filename:      "open_src/influx/meta/proto/meta.proto",
+}
+
 func init() {
 	proto.RegisterEnum("proto.Command_Type", Command_Type_name, Command_Type_value)
 	proto.RegisterType((*Data)(nil), "proto.Data")
@@ -5492,6 +6066,20 @@ func init() {
 	proto.RegisterType((*UpdatePtInfoCommand)(nil), "proto.UpdatePtInfoCommand")
 	proto.RegisterExtension(E_RemoveEventCommand_Command)
 	proto.RegisterType((*RemoveEventCommand)(nil), "proto.RemoveEventCommand")
+	proto.RegisterExtension(E_UpdateNodeDiskStatusCommand_Command)
+	proto.RegisterType((*UpdateNodeDiskStatusCommand)(nil), "proto.UpdateNodeDiskStatusCommand")
+	proto.RegisterExtension(E_AlterMeasurementTTLCommand_Command)
+	proto.RegisterType((*AlterMeasurementTTLCommand)(nil), "proto.AlterMeasurementTTLCommand")
+	proto.RegisterExtension(E_MigratePtCommand_Command)
+	proto.RegisterType((*MigratePtCommand)(nil), "proto.MigratePtCommand")
+	proto.RegisterExtension(E_DecommissionDataNodeCommand_Command)
+	proto.RegisterType((*DecommissionDataNodeCommand)(nil), "proto.DecommissionDataNodeCommand")
+	proto.RegisterExtension(E_SetBalancerCommand_Command)
+	proto.RegisterType((*SetBalancerCommand)(nil), "proto.SetBalancerCommand")
+	proto.RegisterExtension(E_ImportSchemaCommand_Command)
+	proto.RegisterType((*ImportSchemaCommand)(nil), "proto.ImportSchemaCommand")
+	proto.RegisterExtension(E_SetReplicationPausedCommand_Command)
+	proto.RegisterType((*SetReplicationPausedCommand)(nil), "proto.SetReplicationPausedCommand")
 }
 
 func init() {
@@ -5499,252 +6087,280 @@ func init() {
 }
 
 var fileDescriptor_4aed0c02de55ead8 = []byte{
-	// 3947 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3b, 0x5d, 0x8f, 0x5c, 0xc7,
-	0x52, 0xea, 0x33, 0x33, 0xbb, 0x33, 0xbd, 0x9e, 0xdd, 0x75, 0xdb, 0x5e, 0x1f, 0x6f, 0xd6, 0xf6,
-	0xf8, 0x5c, 0x47, 0x59, 0x5d, 0xb8, 0x6b, 0x32, 0xba, 0xd7, 0xc9, 0x35, 0x37, 0xf7, 0x5e, 0xef,
-	0x8e, 0x63, 0x4f, 0xec, 0x5d, 0x0f, 0x67, 0x37, 0x44, 0x02, 0x09, 0x72, 0x76, 0xa7, 0x6d, 0x4f,
-	0xbc, 0xf3, 0xc1, 0x39, 0x67, 0xec, 0x75, 0x14, 0x14, 0x87, 0x48, 0xf0, 0x80, 0x78, 0x88, 0x50,
-	0x12, 0x82, 0x14, 0x3e, 0x42, 0x12, 0xe0, 0x01, 0x41, 0x9e, 0x00, 0xf1, 0x21, 0x11, 0x78, 0x40,
-	0xbc, 0xf2, 0x0a, 0xfc, 0x02, 0x90, 0x78, 0x8b, 0x78, 0x43, 0x55, 0xdd, 0x7d, 0xba, 0xfb, 0x7c,
-	0xed, 0xda, 0x92, 0xf3, 0x34, 0xd3, 0x55, 0xd5, 0xdd, 0x55, 0xd5, 0xd5, 0x55, 0x5d, 0xd5, 0x7d,
-	0xe8, 0xf3, 0xe3, 0x09, 0x1f, 0xfd, 0x6a, 0x14, 0xee, 0x5d, 0x1a, 0x8c, 0xee, 0xec, 0x4f, 0x0f,
-	0x2e, 0x0d, 0x79, 0x1c, 0x5c, 0x9a, 0x84, 0xe3, 0x78, 0x8c, 0x7f, 0xd7, 0xf0, 0x2f, 0xab, 0xe1,
-	0x8f, 0xf7, 0xd5, 0x0c, 0xad, 0x76, 0x82, 0x38, 0x60, 0x8c, 0x56, 0x77, 0x78, 0x38, 0x74, 0x49,
-	0xcb, 0x59, 0xad, 0xfa, 0xf8, 0x9f, 0x9d, 0xa4, 0xb5, 0xee, 0xa8, 0xcf, 0x0f, 0x5c, 0x07, 0x81,
-	0xa2, 0xc1, 0x56, 0x68, 0x63, 0x63, 0x7f, 0x1a, 0xc5, 0x3c, 0xec, 0x76, 0xdc, 0x0a, 0x62, 0x34,
-	0x80, 0x3d, 0x4f, 0x6b, 0x5b, 0xe3, 0x3e, 0x8f, 0xdc, 0x6a, 0xab, 0xb2, 0x3a, 0xd7, 0x5e, 0x10,
-	0xd3, 0xad, 0x01, 0xac, 0x3b, 0xba, 0x33, 0xf6, 0x05, 0x96, 0xbd, 0x48, 0x1b, 0x30, 0xed, 0x6e,
-	0x10, 0xf1, 0xc8, 0xad, 0x21, 0xe9, 0x09, 0x49, 0xaa, 0xe0, 0x48, 0xae, 0xa9, 0x60, 0xe4, 0xd7,
-	0x23, 0x1e, 0x46, 0xee, 0x8c, 0x35, 0x32, 0xc0, 0xc4, 0xc8, 0x88, 0x05, 0xf6, 0x36, 0x83, 0x03,
-	0x9c, 0xaf, 0xe3, 0xce, 0x0a, 0xf6, 0x12, 0x00, 0x5b, 0xa5, 0x0b, 0x9b, 0xc1, 0xc1, 0xf6, 0xbd,
-	0x20, 0xec, 0x5f, 0x0f, 0xc7, 0xd3, 0x49, 0xb7, 0xe3, 0xd6, 0x91, 0x26, 0x0d, 0x66, 0xe7, 0x28,
-	0x55, 0xa0, 0x6e, 0xc7, 0x6d, 0x20, 0x91, 0x01, 0x61, 0xdf, 0x13, 0x12, 0x08, 0x61, 0xa9, 0xc5,
-	0x92, 0x82, 0xfb, 0x9a, 0x02, 0xc8, 0x37, 0xb9, 0x22, 0x9f, 0xcb, 0xd7, 0x8d, 0xa6, 0x60, 0x1e,
-	0x3d, 0x26, 0x75, 0xda, 0x8b, 0xb7, 0xa6, 0x43, 0x77, 0xbe, 0xe5, 0xac, 0x36, 0x7d, 0x0b, 0xc6,
-	0x2e, 0xd1, 0x99, 0x5e, 0xfc, 0x8b, 0x03, 0xfe, 0xd0, 0x5d, 0xc0, 0xf1, 0x4e, 0x1b, 0xd3, 0xaf,
-	0x09, 0xcc, 0xb5, 0x51, 0x1c, 0x3e, 0xf2, 0x25, 0x19, 0x0c, 0x8a, 0x3d, 0x7b, 0x3c, 0x84, 0x59,
-	0xdc, 0xc5, 0x16, 0x81, 0x41, 0x4d, 0x98, 0x54, 0x10, 0xae, 0xb4, 0x52, 0xd0, 0xf1, 0x44, 0x41,
-	0x26, 0x58, 0x2a, 0x08, 0x41, 0xdd, 0x8e, 0xcb, 0x12, 0x05, 0x49, 0x08, 0xcc, 0xb6, 0x19, 0x1c,
-	0x5c, 0x7b, 0xc0, 0x47, 0xf1, 0xed, 0x49, 0xb7, 0xef, 0x9e, 0x68, 0x91, 0xd5, 0xaa, 0x6f, 0xc1,
-	0x60, 0xb6, 0x9d, 0xe0, 0x3e, 0xbf, 0xfd, 0x80, 0x87, 0xd7, 0x46, 0xc1, 0xee, 0x3e, 0xef, 0xbb,
-	0x27, 0x5b, 0x64, 0xb5, 0xee, 0xa7, 0xc1, 0xec, 0x15, 0xda, 0xdc, 0x1c, 0xdc, 0x0d, 0x83, 0x98,
-	0x63, 0xef, 0xc8, 0x3d, 0x65, 0xc9, 0x6c, 0xe2, 0x50, 0x97, 0x36, 0xf5, 0xf2, 0x6b, 0x74, 0xce,
-	0xd0, 0x08, 0x5b, 0xa4, 0x95, 0xfb, 0xfc, 0x91, 0x4b, 0x5a, 0x64, 0xb5, 0xe1, 0xc3, 0x5f, 0xb0,
-	0xae, 0x07, 0xc1, 0xfe, 0x94, 0xbb, 0x4e, 0x8b, 0x98, 0x4b, 0xb9, 0xde, 0x13, 0xe3, 0x09, 0xec,
-	0x15, 0xe7, 0x65, 0xe2, 0x5d, 0xa0, 0xb3, 0xbd, 0xf8, 0xf6, 0xc3, 0x11, 0x0f, 0xd9, 0x12, 0x9d,
-	0x91, 0x96, 0x26, 0xf6, 0x8d, 0x6c, 0x79, 0xbf, 0x04, 0x4b, 0x03, 0xfd, 0xd8, 0x45, 0x5a, 0x43,
-	0x52, 0x24, 0x98, 0x6b, 0xcf, 0xcb, 0x71, 0xe5, 0x00, 0x7e, 0x2d, 0x19, 0x67, 0x3b, 0x0e, 0xe2,
-	0x69, 0x84, 0x5b, 0xad, 0xe9, 0xcb, 0x16, 0xec, 0xca, 0x5e, 0xdc, 0xed, 0xe3, 0x36, 0x6b, 0xfa,
-	0xf8, 0xdf, 0xfb, 0x1e, 0xad, 0x2b, 0xae, 0xd8, 0x05, 0x5a, 0xed, 0xec, 0xf6, 0x62, 0x97, 0xa0,
-	0x32, 0x9a, 0xc9, 0xe0, 0xc8, 0x32, 0xa2, 0xbc, 0xbf, 0x22, 0xb4, 0xae, 0x2c, 0x8c, 0xcd, 0x53,
-	0x27, 0xe1, 0xd5, 0xe9, 0x76, 0x60, 0xfc, 0x1b, 0xe3, 0x28, 0xc6, 0x59, 0x1b, 0x3e, 0xfe, 0x67,
-	0x2e, 0x9d, 0xf5, 0x7b, 0x1b, 0x57, 0xfb, 0xfd, 0xd0, 0xad, 0xa1, 0x7e, 0x54, 0x13, 0x30, 0x3b,
-	0x1b, 0x3d, 0xec, 0x50, 0x11, 0x18, 0xd9, 0x34, 0xf8, 0xaf, 0xb6, 0x9c, 0xd5, 0x4a, 0xc2, 0xff,
-	0x49, 0x5a, 0xbb, 0xb5, 0x33, 0x18, 0x72, 0x77, 0x46, 0x78, 0x10, 0x6c, 0x80, 0xe5, 0x5c, 0x1f,
-	0x47, 0xd1, 0x60, 0x82, 0x93, 0xcc, 0xe2, 0xdc, 0x06, 0xc4, 0xfb, 0x19, 0x5a, 0x57, 0x1b, 0x87,
-	0x9d, 0xa7, 0xce, 0xd6, 0x40, 0x2a, 0x2f, 0xb3, 0x61, 0x9c, 0xad, 0x81, 0xf7, 0x0d, 0xa1, 0xc7,
-	0x4c, 0x97, 0x01, 0x32, 0x6d, 0x05, 0x43, 0x8e, 0x7d, 0x1a, 0x3e, 0xfe, 0x67, 0x97, 0xe9, 0x52,
-	0x87, 0xdf, 0x09, 0xa6, 0xfb, 0xb1, 0xcf, 0x63, 0x3e, 0x8a, 0x07, 0xe3, 0x51, 0x6f, 0xbc, 0x3f,
-	0xd8, 0x7b, 0x24, 0x25, 0x2f, 0xc0, 0xb2, 0x1b, 0xf4, 0xb8, 0x0d, 0x1a, 0xf0, 0xc8, 0xad, 0xa0,
-	0xb2, 0x97, 0x25, 0x33, 0xa9, 0x2e, 0xc8, 0x57, 0xb6, 0x13, 0x6b, 0xd1, 0xb9, 0xcd, 0x20, 0xbc,
-	0xdf, 0xe1, 0xfb, 0x3c, 0xe6, 0x7d, 0xd4, 0x6c, 0xdd, 0x37, 0x41, 0xec, 0x12, 0xad, 0xa3, 0x6f,
-	0xb9, 0xc9, 0x1f, 0xb9, 0x33, 0x68, 0x84, 0xca, 0x23, 0x2a, 0x30, 0x8e, 0x9d, 0x10, 0x79, 0x1f,
-	0x10, 0x7a, 0x22, 0x35, 0xfb, 0xf6, 0x84, 0xef, 0x19, 0x0a, 0x20, 0x89, 0x02, 0x96, 0x69, 0xbd,
-	0x33, 0x0d, 0x03, 0xa0, 0x44, 0x0b, 0xaf, 0xf8, 0x49, 0x9b, 0xad, 0x51, 0xa6, 0x3d, 0x5f, 0x42,
-	0x55, 0x41, 0xaa, 0x1c, 0x0c, 0x8c, 0xe5, 0xf3, 0xc9, 0xfe, 0x60, 0x2f, 0xd8, 0x72, 0xab, 0xe8,
-	0x42, 0x92, 0xb6, 0xf7, 0x97, 0x0e, 0x5d, 0xd8, 0xe4, 0x41, 0x34, 0x0d, 0xf9, 0x50, 0x6e, 0xc5,
-	0xdc, 0x05, 0x79, 0x91, 0x36, 0x94, 0x1c, 0x60, 0xf3, 0x95, 0x22, 0x69, 0x35, 0x15, 0xbb, 0x42,
-	0x67, 0xb6, 0xf7, 0xee, 0xf1, 0x61, 0x20, 0x17, 0xc0, 0x53, 0x5b, 0xdf, 0x9e, 0x6e, 0x4d, 0x10,
-	0x49, 0xcf, 0x27, 0x1a, 0x69, 0xed, 0x57, 0xb3, 0xda, 0xff, 0x11, 0x9d, 0x1f, 0x80, 0xe3, 0xf2,
-	0xf9, 0x3e, 0x4a, 0xa9, 0xa2, 0xd2, 0x49, 0x39, 0x4b, 0xd7, 0x44, 0xfa, 0x29, 0xda, 0xe5, 0x1f,
-	0xd2, 0x39, 0x63, 0xda, 0x1c, 0xf7, 0x72, 0xd2, 0x74, 0x2f, 0x35, 0xd3, 0x9b, 0x7c, 0x54, 0xcd,
-	0xac, 0x62, 0xa1, 0xd6, 0xec, 0x55, 0x74, 0x8e, 0xb4, 0x8a, 0xce, 0x91, 0x56, 0xd1, 0x31, 0x57,
-	0x91, 0x5d, 0xa1, 0xc7, 0x0c, 0xad, 0x2a, 0x55, 0x2c, 0xe5, 0x2b, 0xdc, 0xb7, 0x68, 0xd9, 0x4b,
-	0x74, 0x4e, 0xcf, 0xa6, 0x82, 0xf5, 0x29, 0x73, 0x6d, 0x45, 0x00, 0x81, 0x9e, 0x26, 0x25, 0x78,
-	0xf8, 0xed, 0xe9, 0x6e, 0xb4, 0x17, 0x0e, 0x26, 0x62, 0x01, 0x66, 0x2d, 0x0f, 0x6f, 0xe2, 0x84,
-	0x87, 0xb7, 0xa8, 0xd3, 0x4b, 0x5c, 0xcf, 0x2e, 0x71, 0x8b, 0xce, 0xdd, 0x18, 0xc7, 0x89, 0x6a,
-	0x1a, 0xa8, 0x1a, 0x13, 0x04, 0x21, 0xeb, 0x8d, 0x20, 0x1c, 0x26, 0x24, 0x14, 0x49, 0x2c, 0x18,
-	0xe8, 0x59, 0x87, 0xc1, 0x84, 0x72, 0x4e, 0xe8, 0x39, 0x8b, 0x01, 0x7d, 0x68, 0x68, 0xe4, 0x1e,
-	0xb3, 0xf4, 0x61, 0x04, 0x54, 0xd4, 0x87, 0x41, 0xe9, 0x7d, 0x4d, 0xe8, 0xbc, 0xad, 0xaf, 0x8c,
-	0xfb, 0x5e, 0xa1, 0x8d, 0xed, 0x38, 0x08, 0x63, 0x74, 0xb1, 0xc2, 0x20, 0x34, 0x00, 0xdc, 0xf5,
-	0xb5, 0x51, 0x1f, 0x71, 0xc2, 0x0c, 0x54, 0x13, 0xfa, 0x49, 0xa5, 0x5c, 0x8d, 0xa5, 0xc7, 0xd6,
-	0x00, 0xb6, 0x4a, 0x67, 0x70, 0x5e, 0xb5, 0xee, 0x8b, 0xe6, 0xe2, 0x21, 0x9f, 0x12, 0x0f, 0x1a,
-	0xdd, 0x09, 0xa7, 0xa3, 0xbd, 0x40, 0x8c, 0x34, 0x83, 0x2e, 0xc3, 0x04, 0x79, 0xbf, 0x43, 0xe4,
-	0x46, 0xcf, 0xe5, 0xff, 0x1c, 0xad, 0x63, 0xfc, 0xeb, 0x76, 0x84, 0x13, 0x68, 0xae, 0x3b, 0x2e,
-	0xf1, 0x13, 0x18, 0xec, 0xa3, 0xcd, 0x81, 0x30, 0xe2, 0x86, 0x0f, 0x7f, 0x11, 0x12, 0x1c, 0x20,
-	0xcf, 0x00, 0x09, 0x0e, 0xf0, 0xe0, 0x3a, 0xe0, 0x10, 0xab, 0xc4, 0xc1, 0x75, 0xc0, 0x31, 0x50,
-	0xa9, 0x73, 0x89, 0x08, 0x3c, 0xaa, 0xe9, 0xf9, 0xf4, 0x98, 0xe9, 0x5f, 0x60, 0x17, 0x24, 0x4e,
-	0x17, 0x82, 0x68, 0x43, 0xfb, 0x57, 0x1c, 0xf9, 0xd1, 0x44, 0x6c, 0xd9, 0x86, 0x8f, 0xff, 0x01,
-	0xb6, 0x7d, 0x17, 0xcf, 0xbd, 0x70, 0x98, 0xc1, 0xff, 0xde, 0xaf, 0xd0, 0xc5, 0xb4, 0x71, 0xe6,
-	0xee, 0x5e, 0x46, 0xab, 0x9b, 0x70, 0xec, 0x92, 0xc1, 0x16, 0xfe, 0x83, 0xc5, 0x75, 0x78, 0x14,
-	0x0f, 0x46, 0xd2, 0xe9, 0x54, 0x90, 0x07, 0x0b, 0xe6, 0x5d, 0xa4, 0x14, 0x79, 0x2a, 0x3f, 0x72,
-	0x7c, 0x44, 0x68, 0x5d, 0x9d, 0x85, 0x8b, 0xa6, 0xbf, 0x11, 0x44, 0xf7, 0x92, 0x58, 0x1f, 0x44,
-	0xf7, 0xc0, 0x2d, 0x5d, 0xed, 0x0f, 0xa5, 0x8a, 0xeb, 0xbe, 0x68, 0xc0, 0x14, 0xfe, 0x43, 0x18,
-	0x4b, 0x3a, 0x4a, 0xd9, 0x62, 0xdf, 0xa7, 0xb4, 0x17, 0x0e, 0x1e, 0x0c, 0xf6, 0xf9, 0x5d, 0x9e,
-	0xf6, 0x8f, 0x40, 0x90, 0x20, 0x7d, 0x83, 0xce, 0xeb, 0xd2, 0xa6, 0x85, 0x44, 0x2f, 0x26, 0x03,
-	0xb6, 0x64, 0x30, 0x69, 0x83, 0x65, 0x26, 0x84, 0xc8, 0x69, 0xcd, 0xd7, 0x00, 0xef, 0x7d, 0x42,
-	0x9b, 0x96, 0x23, 0x06, 0x7b, 0xf0, 0x07, 0x7d, 0x1c, 0xa6, 0xe9, 0xc3, 0x5f, 0x80, 0xdc, 0x1e,
-	0xf4, 0xe5, 0x39, 0x0a, 0xfe, 0xc2, 0x98, 0xd8, 0x09, 0x35, 0x22, 0x14, 0xac, 0x01, 0xec, 0xe7,
-	0x28, 0xc5, 0xc6, 0xad, 0x41, 0x14, 0xab, 0xac, 0x65, 0xd1, 0xdc, 0x9e, 0x80, 0xf0, 0x0d, 0x1a,
-	0xef, 0x82, 0x1c, 0x0f, 0x5a, 0x98, 0x23, 0xc1, 0x1f, 0x69, 0x3d, 0xa2, 0xe1, 0xfd, 0x47, 0x83,
-	0xce, 0x6e, 0x8c, 0x87, 0xc3, 0x60, 0xd4, 0x67, 0x2f, 0xd0, 0x6a, 0x0c, 0x66, 0x04, 0x3c, 0xce,
-	0x27, 0x51, 0x4e, 0x62, 0xd7, 0xc0, 0xaa, 0x7c, 0x24, 0xf0, 0x3e, 0x6d, 0x08, 0x83, 0x63, 0x67,
-	0xe8, 0xa9, 0x8d, 0x90, 0x07, 0x31, 0x57, 0x6a, 0x91, 0xc4, 0x8b, 0x15, 0x76, 0x9a, 0x9e, 0xe8,
-	0x84, 0xe3, 0x49, 0x1a, 0x51, 0x65, 0x2d, 0xba, 0x22, 0xfa, 0xa4, 0x62, 0x89, 0xa2, 0xa8, 0xb1,
-	0x73, 0x74, 0x19, 0xba, 0x16, 0xe0, 0x67, 0xd8, 0x45, 0xda, 0xda, 0xe6, 0x71, 0xfe, 0x41, 0x48,
-	0x51, 0xcd, 0xc2, 0x3c, 0xaf, 0x4f, 0xfa, 0xc5, 0xf3, 0xd4, 0xd9, 0x73, 0xf4, 0xb4, 0xe0, 0x44,
-	0x3b, 0x2f, 0x85, 0x6c, 0x00, 0x52, 0x38, 0x9a, 0x2c, 0x92, 0xb2, 0x53, 0xf4, 0xb8, 0xe8, 0x09,
-	0xf6, 0xa2, 0xc0, 0x4d, 0x76, 0x82, 0x2e, 0x00, 0xe3, 0x26, 0x70, 0x1e, 0x68, 0x05, 0x1f, 0x26,
-	0x78, 0x01, 0xf4, 0xb3, 0xcd, 0xe3, 0xc4, 0x62, 0x14, 0x62, 0x91, 0x31, 0x3a, 0x0f, 0xd2, 0x05,
-	0x71, 0xa0, 0x60, 0xc7, 0xd9, 0x0a, 0x75, 0xb7, 0x79, 0x8c, 0x36, 0x9f, 0xe9, 0xc1, 0xd8, 0x59,
-	0x7a, 0x46, 0xca, 0x61, 0x6c, 0x6e, 0x85, 0x3e, 0x85, 0x92, 0x84, 0xe3, 0x49, 0x1e, 0x72, 0x49,
-	0xaf, 0xa0, 0xca, 0xe8, 0x14, 0xca, 0xb5, 0x17, 0xd7, 0x44, 0x9d, 0x01, 0x94, 0x90, 0x29, 0x8d,
-	0x5a, 0x06, 0x94, 0xd0, 0x5b, 0x7a, 0xc0, 0xe7, 0x34, 0x2a, 0xdd, 0x6b, 0x85, 0x2d, 0x51, 0xb6,
-	0xcd, 0xe3, 0x74, 0x97, 0xb3, 0xec, 0x24, 0x5d, 0x44, 0xde, 0x61, 0x0d, 0x14, 0xf4, 0x1c, 0x08,
-	0x8c, 0xe1, 0x52, 0xda, 0x96, 0x18, 0x54, 0xa1, 0xcf, 0x83, 0xc0, 0x82, 0x3b, 0xed, 0x8c, 0x14,
-	0xf2, 0x3b, 0x60, 0x3c, 0xd0, 0x37, 0x65, 0x14, 0xf6, 0x10, 0x2f, 0x80, 0xc2, 0x95, 0x5a, 0x92,
-	0x13, 0x83, 0xc2, 0xbe, 0x08, 0x5c, 0x5d, 0xdd, 0x8f, 0x79, 0xa8, 0x1c, 0xf0, 0xc6, 0xb0, 0xbf,
-	0xd8, 0x86, 0x85, 0xf6, 0xc5, 0x94, 0x83, 0xd1, 0x5d, 0x45, 0xfc, 0x7d, 0x58, 0x68, 0xc9, 0x0d,
-	0x9e, 0xbb, 0x14, 0xe2, 0x07, 0x80, 0xf0, 0xf9, 0x64, 0x1c, 0xc6, 0x22, 0x46, 0x29, 0xc4, 0x65,
-	0x50, 0x46, 0x2f, 0x9c, 0x8e, 0xb8, 0x08, 0xaf, 0x0a, 0xfe, 0x43, 0xb0, 0x68, 0x60, 0xdd, 0x60,
-	0xc9, 0x66, 0xfb, 0x0a, 0x5b, 0xa6, 0x4b, 0xa0, 0xae, 0x1c, 0xa6, 0x7f, 0x1e, 0x98, 0x86, 0x90,
-	0xea, 0x07, 0x23, 0x6d, 0x3b, 0x3f, 0x62, 0x2e, 0x3d, 0x89, 0xd3, 0xab, 0x53, 0x80, 0xc2, 0xbc,
-	0xa2, 0x37, 0x80, 0x0e, 0xf5, 0x0a, 0xf9, 0x63, 0xd8, 0xa2, 0x86, 0x8a, 0xc1, 0x93, 0x43, 0x38,
-	0x53, 0xf8, 0x9f, 0xe8, 0x25, 0x80, 0xe5, 0x14, 0x29, 0x96, 0x42, 0xfe, 0x14, 0xe4, 0x13, 0xca,
-	0xc5, 0x94, 0x57, 0xc1, 0xaf, 0x02, 0x5c, 0x74, 0xb2, 0xe0, 0xeb, 0x5a, 0x83, 0x22, 0x5d, 0x54,
-	0x88, 0x0d, 0xe8, 0xe0, 0xf3, 0xe1, 0xf8, 0x81, 0xdd, 0xa1, 0xf3, 0xdd, 0x7a, 0xbd, 0xbf, 0xf8,
-	0xf8, 0xf1, 0xe3, 0xc7, 0x8e, 0xf7, 0xd8, 0x29, 0xf0, 0x50, 0xb9, 0x81, 0xa7, 0x43, 0x17, 0xb2,
-	0x59, 0x17, 0x39, 0x24, 0x85, 0x4a, 0x77, 0x81, 0xa4, 0x51, 0x9d, 0x4f, 0xa7, 0x43, 0x8c, 0xbf,
-	0x4d, 0xdf, 0x80, 0xb0, 0xe7, 0x69, 0x65, 0xfb, 0xfe, 0x00, 0x23, 0x56, 0x41, 0x2e, 0x01, 0xf8,
-	0xf6, 0xab, 0x74, 0x76, 0x4f, 0xf2, 0x3a, 0x6f, 0xbb, 0x62, 0xf7, 0x2e, 0x76, 0x5d, 0x51, 0xd0,
-	0x3c, 0xf9, 0x7c, 0xd5, 0xd9, 0x1b, 0xe7, 0x3a, 0xe2, 0x3c, 0xf9, 0xdb, 0x9d, 0xe2, 0x29, 0xef,
-	0x59, 0x7a, 0xc8, 0x19, 0x50, 0x4f, 0xf8, 0x3f, 0xa4, 0xdc, 0xc3, 0x97, 0x86, 0xd5, 0xdc, 0x25,
-	0x70, 0x9e, 0x74, 0x09, 0xf0, 0xd8, 0x28, 0xc2, 0x43, 0x4f, 0x9e, 0x18, 0x34, 0xa0, 0xbd, 0x59,
-	0x2c, 0xe6, 0x00, 0xc5, 0xfc, 0x8e, 0xa5, 0xd9, 0x7c, 0x29, 0xb4, 0xbc, 0x9f, 0x90, 0xb2, 0x78,
-	0x55, 0x2a, 0xad, 0x5a, 0x04, 0xc7, 0x58, 0x84, 0x9b, 0xc5, 0xdc, 0xbd, 0x85, 0xdc, 0x5d, 0x30,
-	0x16, 0xe1, 0x30, 0xde, 0xbe, 0x20, 0x87, 0xc7, 0xca, 0x27, 0xe6, 0xf0, 0x17, 0x8a, 0x39, 0xbc,
-	0x8f, 0x1c, 0xbe, 0xa0, 0x8c, 0xfa, 0x90, 0x99, 0x35, 0x9f, 0x7f, 0x5d, 0x29, 0x8f, 0xd6, 0x4f,
-	0xca, 0x23, 0x1c, 0xac, 0xb7, 0xf8, 0x43, 0x79, 0x90, 0xc2, 0x0a, 0x90, 0x6c, 0x5a, 0xa9, 0x69,
-	0x35, 0x55, 0x60, 0x30, 0x53, 0xcd, 0x9a, 0x5d, 0x30, 0x28, 0x48, 0x5b, 0x67, 0x0a, 0x8b, 0x0f,
-	0x98, 0xe6, 0xdd, 0xe7, 0x52, 0x01, 0x58, 0x3c, 0xc2, 0x34, 0x2f, 0x01, 0x65, 0xd3, 0x3c, 0x72,
-	0x78, 0x9a, 0x47, 0x8e, 0x9c, 0xe6, 0x91, 0xfc, 0x34, 0xaf, 0xcc, 0xfa, 0xf7, 0x2d, 0xeb, 0x2f,
-	0x5b, 0x0f, 0xbd, 0x72, 0xff, 0x4e, 0x0a, 0x4f, 0x51, 0xa5, 0x8b, 0xb6, 0x44, 0x67, 0xac, 0xc2,
-	0xd6, 0x8c, 0xde, 0xba, 0x10, 0xa6, 0xa2, 0x38, 0x18, 0x4e, 0x64, 0x36, 0xa8, 0x01, 0x98, 0x47,
-	0xc2, 0x34, 0x98, 0x48, 0x55, 0x45, 0xcd, 0x3c, 0x01, 0xb4, 0x6f, 0x14, 0x8b, 0x36, 0x44, 0xd1,
-	0xce, 0x59, 0x1b, 0x3b, 0xc3, 0xb0, 0x96, 0xea, 0xef, 0x48, 0xe1, 0xf1, 0xef, 0xa9, 0xa4, 0xf2,
-	0x64, 0x36, 0xa7, 0x2a, 0xd5, 0xe2, 0x36, 0xc2, 0x82, 0x95, 0x71, 0x3f, 0xb2, 0xb8, 0x2f, 0x60,
-	0x4c, 0x73, 0xff, 0x17, 0x24, 0xe7, 0x7c, 0xfa, 0x6c, 0x52, 0xad, 0xf6, 0x7a, 0x31, 0xd7, 0xbf,
-	0x86, 0x5c, 0xbb, 0x96, 0xce, 0x0d, 0x86, 0x34, 0xbf, 0x77, 0x33, 0xe7, 0xe6, 0xdc, 0xf0, 0xf4,
-	0xd3, 0xe2, 0xa9, 0x42, 0x9c, 0x6a, 0xc9, 0xf0, 0x8c, 0xb9, 0x13, 0xbd, 0x9b, 0x73, 0x16, 0x3f,
-	0xaa, 0x5e, 0xca, 0x24, 0x8d, 0x2c, 0x49, 0x33, 0x53, 0x68, 0x06, 0xbe, 0x22, 0xb9, 0xc7, 0x7e,
-	0xb0, 0x29, 0xa0, 0x1f, 0x69, 0x3e, 0x92, 0xb6, 0x65, 0x6f, 0x4e, 0x59, 0x16, 0x5a, 0x49, 0x65,
-	0xa1, 0x65, 0xf1, 0x3c, 0xb6, 0xe2, 0x79, 0x0e, 0x4b, 0x9a, 0xe7, 0x30, 0x9d, 0x90, 0xb0, 0xf3,
-	0xe2, 0x2a, 0x4e, 0x16, 0xbb, 0xe7, 0x8c, 0xdb, 0x1c, 0x1f, 0x11, 0xed, 0x9f, 0x14, 0x4f, 0x3c,
-	0xc5, 0x89, 0x4f, 0x19, 0x11, 0x42, 0x0f, 0xac, 0xe7, 0xfc, 0x98, 0x14, 0x67, 0x3c, 0xa5, 0xca,
-	0x4a, 0x8c, 0xd7, 0x31, 0x8c, 0xb7, 0xdd, 0x2d, 0xe6, 0xe7, 0x01, 0xf2, 0x73, 0x5e, 0xf3, 0x93,
-	0x3b, 0xa7, 0xe6, 0xec, 0xff, 0x48, 0x49, 0xb6, 0x55, 0x58, 0x0b, 0x2d, 0x5a, 0xbf, 0xd5, 0xec,
-	0x71, 0x47, 0xd4, 0x90, 0x32, 0x47, 0x1a, 0x55, 0x93, 0xa9, 0x96, 0xd4, 0x64, 0x6a, 0xd9, 0x9a,
-	0x4c, 0xfb, 0xb5, 0x62, 0xd1, 0x1f, 0xa1, 0xe8, 0x2d, 0xdb, 0x27, 0x66, 0x85, 0xd2, 0xb2, 0xff,
-	0x03, 0x29, 0x4c, 0x25, 0x9f, 0x9d, 0xe4, 0x65, 0x7e, 0xf1, 0x6d, 0xdb, 0x2f, 0xe6, 0xb3, 0xa6,
-	0xf9, 0xff, 0x67, 0x52, 0x90, 0xed, 0x02, 0xa7, 0x37, 0x76, 0x76, 0x7a, 0x78, 0xcd, 0x23, 0x4d,
-	0x4a, 0xb5, 0xcd, 0x6b, 0x26, 0xa1, 0xfc, 0xd4, 0x35, 0x13, 0x62, 0x84, 0x78, 0xaa, 0x09, 0xda,
-	0xf0, 0x81, 0x41, 0xe1, 0xe7, 0xf1, 0x7f, 0xd9, 0x81, 0xfe, 0x9d, 0x9c, 0x03, 0x7d, 0x8a, 0x45,
-	0x2d, 0xc5, 0x87, 0xa4, 0x20, 0x31, 0x3f, 0x4c, 0x8a, 0x7c, 0x5e, 0xcb, 0xf8, 0xfa, 0xf5, 0x82,
-	0x44, 0x23, 0x97, 0xaf, 0x37, 0x68, 0x53, 0xe1, 0x30, 0x1f, 0x4b, 0xee, 0xec, 0x80, 0x95, 0x63,
-	0xf2, 0xce, 0x6e, 0x85, 0x36, 0x10, 0x29, 0xeb, 0x95, 0x18, 0xde, 0x13, 0x80, 0xbe, 0x85, 0xab,
-	0x18, 0xb7, 0x70, 0xde, 0xb8, 0xa0, 0xa4, 0x90, 0xae, 0xd2, 0x96, 0x49, 0xf2, 0xae, 0x25, 0x49,
-	0xee, 0x70, 0x5a, 0x92, 0x49, 0x41, 0xa1, 0x22, 0x33, 0xe1, 0xf5, 0xe2, 0x09, 0x1f, 0x93, 0x9c,
-	0x19, 0x0b, 0x75, 0xf7, 0x2a, 0x1c, 0x3c, 0xa3, 0xc9, 0x78, 0x14, 0x71, 0x98, 0xe4, 0xf6, 0x4d,
-	0x9c, 0xa4, 0xee, 0x3b, 0xb7, 0x6f, 0x82, 0x52, 0xae, 0x85, 0xe1, 0x38, 0x94, 0xe5, 0x5d, 0xd1,
-	0xd0, 0x4f, 0x1e, 0x44, 0x81, 0x57, 0x34, 0xbc, 0x7f, 0x24, 0x79, 0x85, 0x94, 0x6f, 0xc5, 0xbc,
-	0x4b, 0x82, 0xcd, 0x7b, 0x42, 0x17, 0x67, 0xb4, 0x93, 0x2d, 0x54, 0xfd, 0x9d, 0x6c, 0xc1, 0x27,
-	0xa3, 0xf5, 0x92, 0x40, 0xfc, 0x1b, 0x62, 0xa6, 0xd3, 0xa6, 0x47, 0x30, 0x86, 0xd2, 0xf3, 0xbc,
-	0x53, 0x52, 0x42, 0xca, 0x3d, 0x7c, 0x94, 0xa4, 0x65, 0xef, 0x13, 0xcb, 0x91, 0x16, 0x8e, 0xab,
-	0x67, 0xff, 0x57, 0x52, 0x58, 0xa2, 0x02, 0xad, 0x8b, 0xa2, 0x8a, 0x28, 0x16, 0x57, 0x7c, 0xd5,
-	0x04, 0x8c, 0xb8, 0x70, 0xe8, 0xcb, 0x9d, 0xa3, 0x9a, 0x70, 0x38, 0xeb, 0xec, 0xca, 0x64, 0x07,
-	0x8f, 0x9d, 0xa2, 0x85, 0x87, 0xb6, 0x09, 0xc2, 0xc5, 0xd2, 0xca, 0x56, 0x59, 0x3c, 0xfc, 0x2d,
-	0x62, 0xf9, 0xd4, 0x02, 0x2e, 0xb5, 0x28, 0x5f, 0x92, 0xc3, 0x0b, 0x6a, 0x4f, 0x9c, 0x61, 0xfa,
-	0xc5, 0xfc, 0xfd, 0x36, 0xb1, 0x52, 0xcc, 0xc3, 0xa6, 0xd6, 0x8c, 0x7e, 0x43, 0x8a, 0x6b, 0x7a,
-	0xa8, 0xc0, 0x75, 0x63, 0xcd, 0x65, 0xcb, 0x50, 0xa0, 0x63, 0x2a, 0x30, 0x61, 0xba, 0x62, 0x44,
-	0xbb, 0xa3, 0xd5, 0x75, 0xd8, 0x45, 0xea, 0x74, 0x7d, 0xcc, 0x2e, 0x8b, 0xee, 0x6c, 0x9d, 0xae,
-	0x5f, 0x16, 0xb6, 0x3f, 0x24, 0xd6, 0x91, 0xa5, 0x48, 0x26, 0x2d, 0xf9, 0x3f, 0x91, 0x6c, 0xbd,
-	0xf2, 0x5b, 0x94, 0xb8, 0x6c, 0xbf, 0x7e, 0x64, 0xef, 0xd7, 0x34, 0x97, 0x5a, 0x86, 0x7f, 0x4b,
-	0x76, 0x4c, 0x67, 0xb7, 0x17, 0x5b, 0x15, 0x45, 0x60, 0x79, 0x27, 0x88, 0xee, 0xeb, 0x8b, 0x26,
-	0xd1, 0x4a, 0x2e, 0xa0, 0xfa, 0xf2, 0x59, 0x98, 0x6c, 0x81, 0x3f, 0xe9, 0xac, 0x4b, 0x41, 0x9c,
-	0xce, 0x3a, 0xb4, 0x7b, 0x3b, 0xf2, 0x6a, 0xd9, 0xe9, 0xed, 0x68, 0x87, 0x5b, 0x33, 0x1c, 0x6e,
-	0xd9, 0x9e, 0xf9, 0x38, 0x6f, 0xcf, 0x64, 0xf8, 0xd4, 0xc2, 0xfc, 0x2f, 0xc9, 0x29, 0x15, 0x1f,
-	0x96, 0x57, 0xe6, 0xae, 0xca, 0x11, 0xf2, 0x4a, 0xcc, 0x99, 0x27, 0xfb, 0x03, 0x71, 0xf7, 0x2a,
-	0xef, 0x50, 0x13, 0x00, 0x6b, 0xc9, 0x5b, 0xf0, 0xf5, 0xf1, 0x74, 0xd4, 0x57, 0x47, 0x48, 0x13,
-	0xd4, 0xde, 0x28, 0x16, 0xfc, 0xf7, 0x88, 0x95, 0xf8, 0x64, 0x64, 0xd2, 0x22, 0xff, 0x37, 0xc9,
-	0x2d, 0x83, 0x3f, 0x95, 0xd0, 0x2d, 0x3a, 0x67, 0x98, 0xbb, 0x5c, 0x48, 0x13, 0xc4, 0x5e, 0xa6,
-	0xcd, 0x57, 0x07, 0x7c, 0xbf, 0xbf, 0x33, 0x16, 0xbb, 0x43, 0xde, 0x96, 0x31, 0xc9, 0x27, 0xe2,
-	0x04, 0x1f, 0xbe, 0x4d, 0xd8, 0xbe, 0x56, 0x2c, 0xec, 0x27, 0xc4, 0xca, 0x99, 0x72, 0xa4, 0xd1,
-	0xe2, 0x76, 0xe9, 0x9c, 0x31, 0x09, 0x2c, 0x01, 0x36, 0x8d, 0xfd, 0xa6, 0x01, 0x09, 0x36, 0x39,
-	0x13, 0xd5, 0x7c, 0x0d, 0xf0, 0x5e, 0x92, 0x97, 0x78, 0xb9, 0xf7, 0xd2, 0xcb, 0xe9, 0x7b, 0x69,
-	0x7d, 0x27, 0xed, 0x7d, 0x46, 0xe8, 0xbc, 0x7d, 0x6d, 0xff, 0x2d, 0x5d, 0xcb, 0x7f, 0x57, 0x5e,
-	0x6a, 0xf3, 0xf4, 0xbd, 0x7c, 0x22, 0x87, 0xaf, 0x08, 0xbc, 0xf7, 0x88, 0xb4, 0x3f, 0xf9, 0x0e,
-	0x2b, 0x89, 0x7e, 0x8a, 0x4d, 0xd5, 0x4c, 0x4a, 0x3f, 0xdb, 0x83, 0xb7, 0xb9, 0xdc, 0xd0, 0x1a,
-	0x80, 0x66, 0xcc, 0xc3, 0x01, 0x8f, 0x36, 0xc6, 0x53, 0x69, 0x13, 0x35, 0xdf, 0x04, 0xc1, 0xc8,
-	0x9b, 0xc1, 0x81, 0xb1, 0x09, 0x54, 0xd3, 0xfb, 0x65, 0xda, 0xf4, 0x27, 0x26, 0x13, 0xda, 0xf0,
-	0x88, 0x65, 0x78, 0x6d, 0x79, 0xbf, 0x0d, 0x64, 0x91, 0xac, 0x4b, 0x33, 0xd3, 0xed, 0x89, 0xfe,
-	0xbe, 0x41, 0xe5, 0xbd, 0x49, 0x69, 0x67, 0x5d, 0x79, 0x02, 0xe9, 0x7a, 0x48, 0xe2, 0x7a, 0xc4,
-	0xb3, 0xb9, 0x8e, 0xbc, 0x04, 0xc6, 0xff, 0x6c, 0x8d, 0xce, 0xfa, 0x13, 0x31, 0x45, 0xc5, 0xba,
-	0xb9, 0xb6, 0x98, 0xf4, 0x15, 0x91, 0xf7, 0xbb, 0x84, 0x9e, 0x36, 0x2f, 0x92, 0x6e, 0x8d, 0x83,
-	0xe4, 0xe8, 0x24, 0x9e, 0xe0, 0xed, 0x00, 0xa1, 0x7c, 0x7a, 0x77, 0xdc, 0x78, 0x2f, 0x28, 0x47,
-	0x4a, 0x48, 0xca, 0x7c, 0xdc, 0xef, 0xdb, 0x3e, 0xae, 0x60, 0x42, 0xbd, 0x03, 0xde, 0xce, 0xbb,
-	0xc4, 0x62, 0xe7, 0xa4, 0x06, 0x11, 0x2a, 0xcf, 0xb8, 0x06, 0xa4, 0xec, 0x10, 0xf9, 0xa9, 0x7d,
-	0x88, 0xcc, 0x0e, 0xae, 0xe7, 0xfe, 0x17, 0x52, 0x7e, 0x53, 0xf6, 0x54, 0x25, 0xbc, 0x43, 0xbd,
-	0x4e, 0x7b, 0xab, 0x98, 0xf9, 0x3f, 0x20, 0x56, 0x69, 0xb5, 0x8c, 0x39, 0x2d, 0xc6, 0xdf, 0x90,
-	0xa2, 0xeb, 0xbc, 0x67, 0x24, 0x40, 0x49, 0xa6, 0xfd, 0x87, 0x42, 0x80, 0xb3, 0xc6, 0xc1, 0xba,
-	0xec, 0xc8, 0xf1, 0xe7, 0x84, 0x36, 0xe5, 0xd5, 0x5f, 0x28, 0xde, 0xd6, 0xad, 0x88, 0x57, 0xc8,
-	0x22, 0x67, 0x11, 0x5b, 0x5b, 0x03, 0x8c, 0xb7, 0x22, 0x66, 0xa8, 0xee, 0x40, 0x28, 0xee, 0xc5,
-	0xdd, 0xbe, 0xd8, 0x09, 0x4d, 0x5f, 0x34, 0xd8, 0x65, 0xda, 0x50, 0xe5, 0x6c, 0xf5, 0x10, 0xc2,
-	0x35, 0xb7, 0xa1, 0x42, 0xca, 0x87, 0xd9, 0x8a, 0x54, 0xa7, 0x97, 0x35, 0x33, 0xbd, 0xfc, 0x9c,
-	0x64, 0x6f, 0x46, 0x9f, 0x4a, 0xc1, 0x86, 0xef, 0xaa, 0x58, 0xbe, 0xab, 0xec, 0x04, 0xf4, 0x47,
-	0xf6, 0x09, 0x28, 0xcd, 0x88, 0x56, 0xe9, 0x6f, 0x92, 0xfc, 0xab, 0x5a, 0x9d, 0x09, 0x12, 0xf3,
-	0xf1, 0xfb, 0x22, 0xad, 0xf4, 0x62, 0x15, 0x14, 0xe0, 0x6f, 0x59, 0x76, 0xfc, 0xc7, 0x82, 0x89,
-	0xe7, 0xf2, 0x94, 0x98, 0x93, 0x1d, 0x33, 0x85, 0xeb, 0x70, 0x51, 0x6c, 0x19, 0x87, 0xa0, 0xb0,
-	0x9d, 0x01, 0x0f, 0x77, 0xd4, 0x03, 0x92, 0xaa, 0x9f, 0xb4, 0xe1, 0x94, 0x02, 0xff, 0x53, 0x2f,
-	0x02, 0x2d, 0x98, 0x75, 0x2d, 0x53, 0xb1, 0x5f, 0x0c, 0x7a, 0x7f, 0x4b, 0xe8, 0x82, 0x4c, 0x82,
-	0xe0, 0xa0, 0x7f, 0x47, 0xbe, 0x9c, 0x2a, 0x08, 0x14, 0xe9, 0x33, 0x91, 0x93, 0x73, 0x26, 0x52,
-	0xa9, 0x54, 0x67, 0x57, 0xee, 0x03, 0xd5, 0x4c, 0x30, 0xbd, 0x58, 0x9e, 0x08, 0x55, 0xd3, 0x58,
-	0xf6, 0x5a, 0xfa, 0xc6, 0x42, 0x5c, 0x41, 0x80, 0xe8, 0x33, 0x22, 0xb8, 0x27, 0x00, 0xef, 0x3a,
-	0x6d, 0x26, 0x6b, 0xaa, 0x36, 0x82, 0x8e, 0xb9, 0xa4, 0x24, 0xe6, 0x3a, 0x56, 0xcc, 0xf5, 0xde,
-	0x27, 0x74, 0x01, 0x97, 0xd6, 0x50, 0xba, 0xf1, 0x7c, 0x8c, 0x58, 0xcf, 0xc7, 0x40, 0x09, 0xd6,
-	0xd3, 0x78, 0xa9, 0x04, 0xeb, 0x5d, 0x7c, 0x5b, 0x5c, 0xb5, 0x20, 0x6b, 0xa8, 0x06, 0x1d, 0x6a,
-	0x2c, 0x96, 0x7d, 0x4d, 0xe6, 0x3d, 0x26, 0xf4, 0x78, 0x66, 0x8f, 0xb1, 0x9f, 0xa5, 0x35, 0x5c,
-	0x1a, 0x97, 0x58, 0x75, 0xf8, 0xd4, 0x9a, 0xf9, 0x82, 0x88, 0xbd, 0x42, 0x8f, 0x99, 0xbd, 0x65,
-	0x20, 0x55, 0x8e, 0x3d, 0x6b, 0x5b, 0xbe, 0x45, 0xee, 0xfd, 0x17, 0x91, 0x37, 0x71, 0xb6, 0x5e,
-	0x2d, 0x69, 0xc8, 0x91, 0xa4, 0x61, 0x97, 0x29, 0x15, 0xc7, 0xa5, 0xe4, 0xe3, 0x11, 0xcd, 0x7c,
-	0x4a, 0xd7, 0xbe, 0x41, 0xc9, 0x7e, 0x4c, 0x9b, 0x96, 0x12, 0xa4, 0xf6, 0x8a, 0x9d, 0x90, 0x4d,
-	0x6e, 0x9b, 0x4c, 0x15, 0xb3, 0x0c, 0xc3, 0x64, 0x86, 0xf4, 0x94, 0x45, 0x9e, 0x54, 0x86, 0xca,
-	0x7d, 0xa8, 0xe5, 0x15, 0x9d, 0x23, 0x7b, 0x45, 0xef, 0xef, 0x49, 0xe1, 0x4b, 0x8f, 0xa7, 0xbd,
-	0xeb, 0xb2, 0x4c, 0xaf, 0x92, 0x35, 0xbd, 0xb2, 0x83, 0xc6, 0x67, 0x24, 0xe7, 0xb2, 0x2b, 0xc3,
-	0x99, 0x55, 0x4b, 0x29, 0x79, 0x8b, 0x52, 0xe2, 0x27, 0xd4, 0x7b, 0x4c, 0xc7, 0x78, 0x8f, 0xf9,
-	0xa4, 0x85, 0x94, 0x5b, 0xc5, 0x72, 0xfc, 0x09, 0xb1, 0x6e, 0xeb, 0x8b, 0x59, 0xb4, 0xee, 0xc1,
-	0x36, 0x30, 0x7f, 0x0a, 0xf6, 0x07, 0xf1, 0xa3, 0xa7, 0xb6, 0xea, 0x16, 0x9d, 0x33, 0x86, 0x91,
-	0xf2, 0x99, 0x20, 0xef, 0x2d, 0xba, 0x6c, 0x46, 0xef, 0xd4, 0x9c, 0x79, 0xa5, 0xfc, 0x97, 0xd3,
-	0x63, 0x9a, 0xef, 0xac, 0x53, 0x03, 0xd8, 0x73, 0xbd, 0x49, 0x4f, 0x18, 0xcd, 0xc4, 0x96, 0x5f,
-	0x82, 0xa8, 0x75, 0x67, 0x1c, 0xc9, 0x63, 0xe9, 0x85, 0xec, 0x93, 0xed, 0xf4, 0xa8, 0x82, 0x1e,
-	0x02, 0xdb, 0xb5, 0x50, 0x15, 0x43, 0xe1, 0xaf, 0xf7, 0x35, 0x29, 0x7c, 0x6d, 0x94, 0xc9, 0x78,
-	0xec, 0xef, 0x57, 0x6a, 0xd6, 0xf7, 0x1f, 0xb1, 0x59, 0x79, 0x8e, 0xb3, 0xdf, 0x7f, 0x54, 0xd3,
-	0xdf, 0x7f, 0x94, 0x99, 0xf1, 0xe7, 0x79, 0x35, 0x81, 0x0c, 0x7f, 0xd6, 0x8d, 0x33, 0x7e, 0x06,
-	0x83, 0x29, 0xc2, 0x6e, 0x92, 0x22, 0xec, 0xb2, 0xb3, 0xd4, 0xe9, 0xc5, 0xd2, 0x37, 0xa5, 0xbe,
-	0x9b, 0x71, 0x7a, 0x31, 0xbb, 0x94, 0xbc, 0x81, 0xae, 0xd8, 0xdf, 0x56, 0xed, 0xf6, 0x62, 0xb1,
-	0xef, 0x23, 0xf5, 0x85, 0x01, 0x36, 0x96, 0xb7, 0x65, 0xc2, 0x15, 0x65, 0xbe, 0x00, 0xa8, 0x8a,
-	0x2f, 0x00, 0xd6, 0xec, 0x0f, 0x8c, 0x8a, 0x7d, 0x88, 0xf1, 0x6d, 0xc0, 0x7f, 0x12, 0xba, 0x98,
-	0xfe, 0xb2, 0x09, 0xb6, 0x1e, 0xc7, 0x46, 0x5f, 0x7e, 0x60, 0xa0, 0x9a, 0xe0, 0xc8, 0xb8, 0x71,
-	0x0b, 0x40, 0x20, 0xe3, 0x4d, 0x00, 0x60, 0x7f, 0xe3, 0x09, 0x7e, 0x4b, 0x84, 0x4f, 0x97, 0xe1,
-	0x3f, 0x3b, 0x4b, 0x2b, 0x93, 0x58, 0x95, 0x9a, 0xe6, 0x0c, 0x19, 0x7d, 0x80, 0xc3, 0x80, 0x7b,
-	0xd3, 0x30, 0x04, 0xdd, 0x72, 0x2c, 0xdb, 0xd4, 0x7c, 0x0d, 0x00, 0x2f, 0x36, 0x09, 0xb9, 0x40,
-	0xce, 0x20, 0x32, 0x69, 0x83, 0xfc, 0x51, 0xb8, 0xe7, 0xce, 0x0a, 0xf9, 0xa3, 0x10, 0xbf, 0x4a,
-	0xe9, 0xf3, 0x28, 0xc6, 0x87, 0xf9, 0x55, 0x1f, 0xff, 0x7b, 0x1f, 0x90, 0xbc, 0x37, 0x6b, 0xec,
-	0x07, 0x52, 0x0e, 0x0c, 0x63, 0x62, 0x77, 0x16, 0x7e, 0xe7, 0xa5, 0x29, 0xcb, 0xb2, 0x9c, 0x2f,
-	0xec, 0x2c, 0x27, 0x3b, 0xa7, 0xb6, 0x18, 0xe0, 0x29, 0xfb, 0x5e, 0xee, 0x19, 0xf0, 0xf4, 0xa5,
-	0xcd, 0x53, 0x76, 0x4e, 0xab, 0xd4, 0x98, 0xf7, 0x56, 0xef, 0x49, 0x8d, 0x7a, 0x85, 0x36, 0x30,
-	0xda, 0xe2, 0xc7, 0x7f, 0xc2, 0x0c, 0x34, 0xc0, 0xfa, 0x86, 0x8b, 0xe8, 0x6f, 0xd0, 0xca, 0x6a,
-	0x37, 0x7f, 0x9a, 0x57, 0xbb, 0xb1, 0x58, 0xd4, 0x32, 0xc4, 0x79, 0xaf, 0x0a, 0x6d, 0x63, 0x76,
-	0x0c, 0x63, 0x2e, 0xd3, 0xdc, 0x9f, 0xd9, 0x9a, 0xcb, 0x0e, 0x9b, 0xcc, 0xfa, 0xff, 0x01, 0x00,
-	0x00, 0xff, 0xff, 0x5a, 0x80, 0x21, 0xa5, 0x0e, 0x3b, 0x00, 0x00,
+	// 4396 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3b, 0x5b, 0x70, 0x5c, 0xc9,
+	0x55, 0xd5, 0x77, 0x66, 0xa4, 0x99, 0x96, 0x47, 0x96, 0xdb, 0xaf, 0xbb, 0x5a, 0xad, 0x3d, 0xbe,
+	0xd9, 0x65, 0x55, 0xa9, 0xc4, 0x66, 0x55, 0xc9, 0xee, 0x66, 0xc9, 0x26, 0xb1, 0x34, 0x7e, 0xcc,
+	0xda, 0x92, 0x87, 0x96, 0x42, 0xaa, 0x42, 0x15, 0x70, 0xa5, 0x69, 0xdb, 0x13, 0xcd, 0x8b, 0x7b,
+	0xef, 0x78, 0xa5, 0xad, 0xa5, 0xe2, 0x10, 0x5e, 0x05, 0x3f, 0x50, 0x14, 0x1b, 0x42, 0x91, 0x00,
+	0x21, 0x59, 0x08, 0x10, 0x02, 0x5f, 0x40, 0xf1, 0x28, 0x5e, 0x1f, 0x14, 0xbf, 0x7c, 0x43, 0xc1,
+	0x17, 0x3f, 0xf0, 0x4d, 0xf1, 0x47, 0x9d, 0xd3, 0xdd, 0xb7, 0xbb, 0xef, 0x4b, 0xb6, 0x8b, 0xdd,
+	0xaf, 0xb9, 0x7d, 0xce, 0x99, 0x3e, 0x8f, 0x3e, 0x7d, 0xba, 0xcf, 0xe9, 0x6e, 0xfa, 0xd2, 0x74,
+	0x26, 0x26, 0x3f, 0x1e, 0x47, 0x07, 0xd7, 0x86, 0x93, 0xfb, 0xa3, 0xf9, 0xd1, 0xb5, 0xb1, 0x48,
+	0xc2, 0x6b, 0xb3, 0x68, 0x9a, 0x4c, 0xf1, 0xf3, 0x2a, 0x7e, 0xb2, 0x06, 0xfe, 0x04, 0xdf, 0x5f,
+	0xa4, 0xf5, 0x6e, 0x98, 0x84, 0x8c, 0xd1, 0xfa, 0x9e, 0x88, 0xc6, 0x3e, 0xe9, 0x78, 0xeb, 0x75,
+	0x8e, 0xdf, 0xec, 0x1c, 0x6d, 0xf4, 0x26, 0x03, 0x71, 0xe4, 0x7b, 0x08, 0x94, 0x0d, 0xb6, 0x46,
+	0x5b, 0x5b, 0xa3, 0x79, 0x9c, 0x88, 0xa8, 0xd7, 0xf5, 0x6b, 0x88, 0x31, 0x00, 0xf6, 0x12, 0x6d,
+	0xec, 0x4c, 0x07, 0x22, 0xf6, 0xeb, 0x9d, 0xda, 0xfa, 0xd2, 0xc6, 0x69, 0xc9, 0xee, 0x2a, 0xc0,
+	0x7a, 0x93, 0xfb, 0x53, 0x2e, 0xb1, 0xec, 0x15, 0xda, 0x02, 0xb6, 0xfb, 0x61, 0x2c, 0x62, 0xbf,
+	0x81, 0xa4, 0x67, 0x15, 0xa9, 0x86, 0x23, 0xb9, 0xa1, 0x82, 0x9e, 0x3f, 0x1f, 0x8b, 0x28, 0xf6,
+	0x17, 0x9c, 0x9e, 0x01, 0x26, 0x7b, 0x46, 0x2c, 0x88, 0xb7, 0x1d, 0x1e, 0x21, 0xbf, 0xae, 0xbf,
+	0x28, 0xc5, 0x4b, 0x01, 0x6c, 0x9d, 0x9e, 0xde, 0x0e, 0x8f, 0x76, 0x1f, 0x86, 0xd1, 0xe0, 0x56,
+	0x34, 0x9d, 0xcf, 0x7a, 0x5d, 0xbf, 0x89, 0x34, 0x59, 0x30, 0xbb, 0x44, 0xa9, 0x06, 0xf5, 0xba,
+	0x7e, 0x0b, 0x89, 0x2c, 0x08, 0xfb, 0xb8, 0xd4, 0x40, 0x2a, 0x4b, 0x1d, 0x91, 0x34, 0x9c, 0x1b,
+	0x0a, 0x20, 0xdf, 0x16, 0x9a, 0x7c, 0xa9, 0xd8, 0x36, 0x86, 0x82, 0x05, 0xf4, 0x94, 0xb2, 0x69,
+	0x3f, 0xd9, 0x99, 0x8f, 0xfd, 0xe5, 0x8e, 0xb7, 0xde, 0xe6, 0x0e, 0x8c, 0x5d, 0xa3, 0x0b, 0xfd,
+	0xe4, 0x47, 0x86, 0xe2, 0x6d, 0xff, 0x34, 0xf6, 0x77, 0xd1, 0x62, 0x7f, 0x55, 0x62, 0x6e, 0x4c,
+	0x92, 0xe8, 0x98, 0x2b, 0x32, 0xe8, 0x14, 0xff, 0xd9, 0x17, 0x11, 0x70, 0xf1, 0x57, 0x3a, 0x04,
+	0x3a, 0xb5, 0x61, 0xca, 0x40, 0x38, 0xd2, 0xda, 0x40, 0x67, 0x52, 0x03, 0xd9, 0x60, 0x65, 0x20,
+	0x04, 0xf5, 0xba, 0x3e, 0x4b, 0x0d, 0xa4, 0x20, 0xc0, 0x6d, 0x3b, 0x3c, 0xba, 0xf1, 0x48, 0x4c,
+	0x92, 0x7b, 0xb3, 0xde, 0xc0, 0x3f, 0xdb, 0x21, 0xeb, 0x75, 0xee, 0xc0, 0x80, 0xdb, 0x5e, 0x78,
+	0x28, 0xee, 0x3d, 0x12, 0xd1, 0x8d, 0x49, 0xb8, 0x3f, 0x12, 0x03, 0xff, 0x5c, 0x87, 0xac, 0x37,
+	0x79, 0x16, 0xcc, 0xde, 0xa4, 0xed, 0xed, 0xe1, 0x83, 0x28, 0x4c, 0x04, 0xfe, 0x3b, 0xf6, 0xcf,
+	0x3b, 0x3a, 0xdb, 0x38, 0xb4, 0xa5, 0x4b, 0xcd, 0x7e, 0x80, 0x2e, 0x6f, 0x86, 0xa3, 0x70, 0x72,
+	0x20, 0xa2, 0x7e, 0x38, 0x8f, 0xc5, 0xc0, 0xbf, 0x80, 0x7c, 0x32, 0x50, 0x9b, 0xae, 0x1b, 0x1d,
+	0xf3, 0xf9, 0xc4, 0xbf, 0xe8, 0xd2, 0x49, 0x28, 0xfb, 0x18, 0x3d, 0xc3, 0xc5, 0x6c, 0x34, 0x3c,
+	0x08, 0x93, 0xe1, 0x74, 0xa2, 0xba, 0xf4, 0x91, 0x34, 0x8f, 0x58, 0x7d, 0x8b, 0x2e, 0x59, 0xe3,
+	0xc1, 0x56, 0x68, 0xed, 0x50, 0x1c, 0xfb, 0xa4, 0x43, 0xd6, 0x5b, 0x1c, 0x3e, 0xc1, 0xb7, 0x1f,
+	0x85, 0xa3, 0xb9, 0xf0, 0xbd, 0x0e, 0xb1, 0x1d, 0x69, 0xb3, 0x2f, 0xb5, 0x91, 0xd8, 0x37, 0xbc,
+	0xd7, 0x49, 0x70, 0x85, 0x2e, 0xf6, 0x93, 0x7b, 0x6f, 0x4f, 0x44, 0xc4, 0x2e, 0xd0, 0x05, 0xe5,
+	0xe7, 0x72, 0xd6, 0xaa, 0x56, 0xf0, 0x45, 0xba, 0x20, 0xff, 0xc7, 0x5e, 0xa4, 0x0d, 0x24, 0x45,
+	0x82, 0xa5, 0x8d, 0x65, 0xd5, 0xaf, 0xea, 0x80, 0x37, 0xd2, 0x7e, 0x76, 0x93, 0x30, 0x99, 0xc7,
+	0x38, 0xd1, 0xdb, 0x5c, 0xb5, 0x20, 0x26, 0xf4, 0x93, 0xde, 0x00, 0x27, 0x79, 0x9b, 0xe3, 0x77,
+	0xf0, 0x71, 0xda, 0xd4, 0x52, 0xb1, 0x2b, 0xb4, 0xde, 0xdd, 0xef, 0x27, 0x3e, 0xc1, 0xa1, 0x68,
+	0xa7, 0x9d, 0xa3, 0xc8, 0x88, 0x0a, 0xfe, 0x84, 0xd0, 0xa6, 0xf6, 0x6f, 0xb6, 0x4c, 0xbd, 0x54,
+	0x56, 0xaf, 0xd7, 0x85, 0xfe, 0x6f, 0x4f, 0xe3, 0x04, 0xb9, 0xb6, 0x38, 0x7e, 0x33, 0x9f, 0x2e,
+	0xf2, 0xfe, 0xd6, 0xf5, 0xc1, 0x20, 0xf2, 0x1b, 0x68, 0x1f, 0xdd, 0x04, 0xcc, 0xde, 0x56, 0x1f,
+	0xff, 0x50, 0x93, 0x18, 0xd5, 0xb4, 0xe4, 0xaf, 0x77, 0xbc, 0xf5, 0x5a, 0x2a, 0xff, 0x39, 0xda,
+	0xb8, 0xbb, 0x37, 0x1c, 0x0b, 0x7f, 0x41, 0xc6, 0x2f, 0x6c, 0x80, 0xdf, 0xde, 0x9a, 0xc6, 0xf1,
+	0x70, 0x86, 0x4c, 0x16, 0x91, 0xb7, 0x05, 0x09, 0xbe, 0x41, 0x68, 0x53, 0xcf, 0x5b, 0x76, 0x99,
+	0x7a, 0x3b, 0x43, 0x65, 0xbd, 0xdc, 0x7c, 0xf5, 0x76, 0x86, 0xd0, 0x5b, 0x77, 0x18, 0x1f, 0xa6,
+	0xf6, 0x23, 0xeb, 0x0d, 0x6e, 0x41, 0xc0, 0xc3, 0xbb, 0xe2, 0x60, 0x3a, 0x1e, 0x0f, 0xe3, 0x78,
+	0x38, 0x9d, 0x0c, 0x27, 0x0f, 0x50, 0xfa, 0x26, 0xcf, 0x82, 0xc1, 0x1a, 0x5f, 0x9c, 0x4e, 0x84,
+	0x5f, 0x47, 0xe5, 0xf0, 0x1b, 0x60, 0x3c, 0x3c, 0x38, 0x54, 0xa6, 0xc0, 0xef, 0xe0, 0x7f, 0x08,
+	0x3d, 0x65, 0xc7, 0x48, 0x20, 0xda, 0x09, 0xc7, 0x02, 0xa5, 0x6c, 0x71, 0xfc, 0x66, 0xaf, 0xd2,
+	0x0b, 0x5d, 0x71, 0x3f, 0x9c, 0x8f, 0x12, 0x2e, 0x12, 0x31, 0x41, 0x5f, 0x9c, 0x8e, 0x86, 0x07,
+	0xc7, 0xca, 0xd8, 0x25, 0x58, 0x76, 0x9b, 0x9e, 0x71, 0x41, 0x43, 0x11, 0xfb, 0x35, 0x1c, 0xdf,
+	0x55, 0xa5, 0x7e, 0xe6, 0x2f, 0x68, 0x89, 0xfc, 0x9f, 0x58, 0x87, 0x2e, 0x6d, 0x87, 0xd1, 0x61,
+	0x57, 0x8c, 0x44, 0x22, 0x06, 0xa8, 0x41, 0x93, 0xdb, 0x20, 0x76, 0x8d, 0x36, 0x31, 0x98, 0xde,
+	0x11, 0xc7, 0xfe, 0x42, 0x87, 0x58, 0x4b, 0x80, 0x06, 0x63, 0xdf, 0x29, 0x51, 0xf0, 0x2b, 0x84,
+	0x9e, 0xcd, 0x70, 0xdf, 0x9d, 0x89, 0x03, 0xcb, 0x00, 0x24, 0x35, 0xc0, 0x2a, 0x6d, 0x76, 0xe7,
+	0x11, 0x4e, 0x42, 0x1c, 0x95, 0x1a, 0x4f, 0xdb, 0xec, 0x2a, 0x65, 0x26, 0xd4, 0xa7, 0x54, 0x35,
+	0xa4, 0x2a, 0xc0, 0x40, 0x5f, 0x6a, 0x4e, 0xef, 0xe0, 0xe8, 0xb4, 0x79, 0xda, 0x0e, 0xfe, 0xc3,
+	0xa3, 0xa7, 0xb7, 0x45, 0x18, 0xcf, 0x23, 0x31, 0x56, 0xb1, 0xa7, 0x70, 0x40, 0x5e, 0xa1, 0x2d,
+	0xad, 0x07, 0xb8, 0x49, 0xad, 0x4c, 0x5b, 0x43, 0xc5, 0xde, 0xa0, 0x0b, 0xbb, 0x07, 0x0f, 0xc5,
+	0x38, 0x54, 0x03, 0x10, 0xe8, 0x58, 0xe7, 0xb2, 0xbb, 0x2a, 0x89, 0x54, 0xa8, 0x97, 0x8d, 0xac,
+	0xf5, 0xeb, 0x79, 0xeb, 0x7f, 0x9a, 0x2e, 0x0f, 0x21, 0x52, 0x73, 0x31, 0x42, 0x2d, 0xf5, 0x32,
+	0x7c, 0x4e, 0x71, 0xe9, 0xd9, 0x48, 0x9e, 0xa1, 0x05, 0xb7, 0xbf, 0x39, 0x9a, 0x86, 0xc9, 0xd6,
+	0x74, 0x20, 0x0e, 0x70, 0xf4, 0x5a, 0xdc, 0x82, 0x40, 0x88, 0xdb, 0xdb, 0xbb, 0xeb, 0x2f, 0xa2,
+	0x4d, 0xe1, 0x73, 0xf5, 0x53, 0x74, 0xc9, 0x12, 0xb4, 0x20, 0x06, 0x9e, 0xb3, 0x63, 0x60, 0xc3,
+	0x0e, 0x79, 0xef, 0xd5, 0x73, 0xe3, 0x5e, 0x6a, 0x67, 0x77, 0xdc, 0xbd, 0x27, 0x1a, 0x77, 0xef,
+	0x89, 0xc6, 0xdd, 0xb3, 0xc7, 0x9d, 0xbd, 0x41, 0x4f, 0x59, 0xe3, 0xa0, 0x8d, 0x77, 0xa1, 0x78,
+	0x88, 0xb8, 0x43, 0xcb, 0x5e, 0xa3, 0x4b, 0x86, 0x9b, 0xde, 0xcf, 0x9c, 0xb7, 0xbd, 0x01, 0x31,
+	0xf8, 0x4f, 0x9b, 0x12, 0x16, 0xc1, 0xdd, 0xf9, 0x7e, 0x7c, 0x10, 0x0d, 0x67, 0x72, 0xc8, 0x16,
+	0x9d, 0x45, 0xd0, 0xc6, 0xc9, 0x45, 0xd0, 0xa1, 0xce, 0x3a, 0x45, 0x33, 0xef, 0x14, 0x1d, 0xba,
+	0x74, 0x7b, 0x9a, 0xa4, 0xa6, 0x69, 0xa1, 0x69, 0x6c, 0x10, 0xac, 0xea, 0x5f, 0x08, 0xa3, 0x71,
+	0x4a, 0x42, 0x91, 0xc4, 0x81, 0x81, 0x9d, 0xcd, 0x4e, 0x21, 0xa5, 0x5c, 0x92, 0x76, 0xce, 0x63,
+	0xc0, 0x1e, 0x06, 0x1a, 0xfb, 0xa7, 0x1c, 0x7b, 0x18, 0x8c, 0xb4, 0x87, 0x45, 0x19, 0xfc, 0x3b,
+	0xa1, 0xcb, 0xae, 0xbd, 0x72, 0x6b, 0xcc, 0x1a, 0x6d, 0xed, 0x26, 0x61, 0x94, 0xe0, 0x3a, 0x20,
+	0x1d, 0xc2, 0x00, 0x60, 0x4d, 0xb9, 0x31, 0x19, 0x20, 0x4e, 0xba, 0x81, 0x6e, 0xc2, 0xff, 0x94,
+	0x51, 0xae, 0x27, 0x6a, 0x59, 0x31, 0x00, 0xb6, 0x4e, 0x17, 0x90, 0xaf, 0x1e, 0xf7, 0x15, 0x7b,
+	0xf0, 0x50, 0x4e, 0x85, 0x07, 0x8b, 0xee, 0x45, 0xf3, 0xc9, 0x41, 0x28, 0x7b, 0x5a, 0xc0, 0x09,
+	0x61, 0x83, 0x80, 0xd3, 0xed, 0x30, 0x7e, 0xb8, 0x3b, 0x9a, 0x26, 0x3b, 0x38, 0x61, 0xda, 0xdc,
+	0x00, 0x82, 0x7f, 0x25, 0xb4, 0x95, 0xf6, 0x9a, 0xd3, 0xee, 0x12, 0x6d, 0xe2, 0x12, 0xde, 0xeb,
+	0xca, 0xa0, 0xd2, 0xde, 0xf4, 0x7c, 0xc2, 0x53, 0x18, 0xcc, 0xb2, 0xed, 0xa1, 0x74, 0xf1, 0x16,
+	0x87, 0x4f, 0x84, 0x84, 0x47, 0x7e, 0x5d, 0x41, 0xc2, 0x23, 0xdc, 0xf9, 0x0f, 0x05, 0x2c, 0xb7,
+	0x72, 0xe7, 0x3f, 0x14, 0xb8, 0xd6, 0xea, 0x8d, 0x9d, 0x5c, 0x3b, 0x75, 0x13, 0xe6, 0x84, 0x16,
+	0x4e, 0x09, 0x9b, 0xb6, 0xe1, 0x5f, 0xf0, 0x0d, 0x1c, 0x9b, 0xb8, 0xd9, 0xd3, 0xcd, 0x14, 0x13,
+	0x1e, 0xf9, 0x2d, 0x0b, 0x13, 0x1e, 0x05, 0x9c, 0x9e, 0xb2, 0xe3, 0x1f, 0xf4, 0xaf, 0xdb, 0xb8,
+	0xaf, 0x68, 0x99, 0xf8, 0x8f, 0x92, 0x1e, 0xcf, 0x64, 0x80, 0x68, 0x71, 0xfc, 0x06, 0xd8, 0xee,
+	0x03, 0x4c, 0x44, 0xa0, 0x5b, 0xfc, 0x0e, 0x7e, 0x8c, 0xae, 0x64, 0xa7, 0x42, 0x61, 0xac, 0x60,
+	0xb4, 0xbe, 0x3d, 0x1d, 0x48, 0xb7, 0x68, 0x71, 0xfc, 0x06, 0xff, 0xee, 0x8a, 0x38, 0x19, 0x4e,
+	0x54, 0x50, 0xac, 0xa1, 0x0c, 0x0e, 0x2c, 0x78, 0x91, 0x52, 0x94, 0xa9, 0x7a, 0x17, 0xf6, 0x1e,
+	0xa1, 0x4d, 0x9d, 0x9c, 0x94, 0xb1, 0x07, 0x2b, 0xa4, 0xdb, 0x9f, 0x30, 0x7e, 0x08, 0x41, 0xf0,
+	0xfa, 0x60, 0xac, 0x86, 0xac, 0xc9, 0x65, 0x03, 0x58, 0xf0, 0xb7, 0xa1, 0x2f, 0x15, 0xc8, 0x55,
+	0x8b, 0x7d, 0x82, 0xd2, 0x7e, 0x34, 0x7c, 0x34, 0x1c, 0x89, 0x07, 0x22, 0x1b, 0xbf, 0x81, 0x20,
+	0x45, 0x72, 0x8b, 0x2e, 0xe8, 0xd1, 0xb6, 0x83, 0xc4, 0x98, 0xa9, 0x36, 0x14, 0x4a, 0xc0, 0xb4,
+	0x0d, 0xde, 0x99, 0x12, 0xa2, 0xa4, 0x0d, 0x6e, 0x00, 0xc1, 0x57, 0x09, 0x6d, 0x3b, 0x0b, 0x05,
+	0xf8, 0x17, 0x1f, 0x0e, 0xb0, 0x9b, 0x36, 0x87, 0x4f, 0x80, 0xdc, 0x1b, 0x0e, 0xd4, 0xd6, 0x12,
+	0x3e, 0xa1, 0x4f, 0xfc, 0x13, 0x5a, 0x44, 0x1a, 0xd8, 0x00, 0xd8, 0x0f, 0x52, 0x8a, 0x8d, 0xbb,
+	0xc3, 0x38, 0xd1, 0x69, 0xe4, 0x8a, 0x1d, 0x0c, 0x00, 0xc1, 0x2d, 0x9a, 0xe0, 0x0a, 0x6d, 0xa5,
+	0x2d, 0x4c, 0x5a, 0xe1, 0x43, 0x79, 0x8f, 0x6c, 0x04, 0xef, 0x2f, 0xd1, 0xc5, 0xad, 0xe9, 0x78,
+	0x1c, 0x4e, 0x06, 0xec, 0x65, 0x5a, 0x4f, 0xc0, 0x8d, 0x40, 0xc6, 0xe5, 0x74, 0x15, 0x56, 0xd8,
+	0xab, 0xe0, 0x55, 0x1c, 0x09, 0x82, 0xff, 0xa4, 0xd2, 0xe1, 0xd8, 0x73, 0xf4, 0xfc, 0x56, 0x24,
+	0xc2, 0x44, 0x68, 0xb3, 0x28, 0xe2, 0x95, 0x1a, 0xbb, 0x48, 0xcf, 0x76, 0xa3, 0xe9, 0x2c, 0x8b,
+	0xa8, 0xb3, 0x0e, 0x5d, 0x93, 0xff, 0xc9, 0xac, 0x5c, 0x9a, 0xa2, 0xc1, 0x2e, 0xd1, 0x55, 0xf8,
+	0x6b, 0x09, 0x7e, 0x81, 0xbd, 0x48, 0x3b, 0xbb, 0x22, 0x29, 0xde, 0xa8, 0x69, 0xaa, 0x45, 0xe0,
+	0xf3, 0xf9, 0xd9, 0xa0, 0x9c, 0x4f, 0x93, 0x3d, 0x4f, 0x2f, 0x4a, 0x49, 0x4c, 0xa8, 0xd4, 0xc8,
+	0x16, 0x20, 0x65, 0x58, 0xcb, 0x23, 0x29, 0x3b, 0x4f, 0xcf, 0xc8, 0x7f, 0x82, 0xbf, 0x68, 0x70,
+	0x9b, 0x9d, 0xa5, 0xa7, 0x41, 0x70, 0x1b, 0xb8, 0x0c, 0xb4, 0x52, 0x0e, 0x1b, 0x7c, 0x1a, 0xec,
+	0xb3, 0x2b, 0x92, 0xd4, 0x63, 0x34, 0x62, 0x85, 0x31, 0xba, 0x0c, 0xda, 0x85, 0x49, 0xa8, 0x61,
+	0x67, 0xd8, 0x1a, 0xf5, 0x77, 0x45, 0x82, 0x3e, 0x9f, 0xfb, 0x07, 0x63, 0x2f, 0xd0, 0xe7, 0x94,
+	0x1e, 0xd6, 0xe4, 0xd6, 0xe8, 0xf3, 0xa8, 0x49, 0x34, 0x9d, 0x15, 0x21, 0x2f, 0x98, 0x11, 0xd4,
+	0x29, 0xb6, 0x46, 0xf9, 0xee, 0xe0, 0xda, 0xa8, 0xe7, 0x00, 0x25, 0x75, 0xca, 0xa2, 0x56, 0x01,
+	0x25, 0xed, 0x96, 0xed, 0xf0, 0x79, 0x83, 0xca, 0xfe, 0x6b, 0x8d, 0x5d, 0xa0, 0x6c, 0x57, 0x24,
+	0xd9, 0xbf, 0xbc, 0xc0, 0xce, 0xd1, 0x15, 0x94, 0x1d, 0xc6, 0x40, 0x43, 0x2f, 0x81, 0xc2, 0xb8,
+	0x38, 0x2b, 0xdf, 0x92, 0x9d, 0x6a, 0xf4, 0x65, 0x50, 0x58, 0x4a, 0x67, 0x82, 0x91, 0x46, 0x7e,
+	0x04, 0x9c, 0x07, 0xfe, 0x9b, 0x71, 0x0a, 0xb7, 0x8b, 0x97, 0xc1, 0xe0, 0xda, 0x2c, 0xe9, 0xfe,
+	0x44, 0x63, 0x5f, 0x01, 0xa9, 0xae, 0x8f, 0x12, 0x11, 0xe9, 0x00, 0xbc, 0x35, 0x1e, 0xac, 0x6c,
+	0xc0, 0x40, 0x73, 0xc9, 0x72, 0x38, 0x79, 0xa0, 0x89, 0x3f, 0x01, 0x03, 0xad, 0xa4, 0xc1, 0x5d,
+	0x9e, 0x46, 0x7c, 0x12, 0x10, 0x5c, 0xcc, 0xa6, 0x51, 0x22, 0x57, 0x44, 0x8d, 0x78, 0x15, 0x8c,
+	0xd1, 0x8f, 0xe6, 0x13, 0x21, 0x17, 0x73, 0x0d, 0xff, 0x14, 0x78, 0x34, 0x88, 0x6e, 0x89, 0xe4,
+	0x8a, 0xfd, 0x06, 0x5b, 0xa5, 0x17, 0xc0, 0x5c, 0x05, 0x42, 0xff, 0x10, 0x08, 0x0d, 0x0b, 0x38,
+	0x0f, 0x27, 0xc6, 0x77, 0x3e, 0xcd, 0x7c, 0x7a, 0x0e, 0xd9, 0xeb, 0x3d, 0x87, 0xc6, 0xbc, 0x69,
+	0x26, 0x80, 0xd9, 0x58, 0x68, 0xe4, 0x67, 0x60, 0x8a, 0x5a, 0x26, 0x86, 0x48, 0x0e, 0xcb, 0xa3,
+	0xc6, 0x7f, 0xd6, 0x0c, 0x01, 0x0c, 0xa7, 0xcc, 0xf8, 0x34, 0xf2, 0x73, 0xa0, 0x9f, 0x34, 0x2e,
+	0xd6, 0x20, 0x34, 0xfc, 0x3a, 0xc0, 0xe5, 0x9f, 0x1c, 0xf8, 0xa6, 0xb1, 0xa0, 0xcc, 0xa0, 0x35,
+	0x62, 0x0b, 0xfe, 0xc0, 0xc5, 0x78, 0xfa, 0xc8, 0xfd, 0x43, 0x97, 0x5d, 0xa6, 0xcf, 0x1b, 0xee,
+	0x26, 0xe7, 0xd4, 0x04, 0x37, 0x40, 0x7c, 0x1c, 0x40, 0xcb, 0x50, 0x7b, 0x7b, 0x77, 0x35, 0xfe,
+	0x26, 0xd8, 0x4a, 0x95, 0x49, 0xfa, 0x69, 0xb7, 0xb7, 0xa0, 0x5b, 0x3b, 0x37, 0xcd, 0x7a, 0xf1,
+	0x6d, 0xe5, 0xc5, 0xba, 0x22, 0xa2, 0xe1, 0x3d, 0x50, 0xa0, 0x37, 0xc6, 0x91, 0x76, 0x5c, 0xe0,
+	0x2d, 0xe8, 0x71, 0x57, 0x24, 0xb9, 0xba, 0x88, 0x26, 0xb8, 0xf3, 0xd1, 0x66, 0x73, 0xb0, 0xf2,
+	0xf8, 0xf1, 0xe3, 0xc7, 0x5e, 0xf0, 0xd8, 0x2b, 0x89, 0xb5, 0x85, 0x4b, 0x68, 0x97, 0x9e, 0xce,
+	0xe7, 0xb7, 0xe4, 0x84, 0x64, 0x35, 0xfb, 0x17, 0x48, 0x66, 0xf4, 0xbe, 0x7e, 0x3e, 0xc6, 0x9d,
+	0x44, 0x9b, 0x5b, 0x10, 0xf6, 0x12, 0xad, 0xed, 0x1e, 0x0e, 0x71, 0xed, 0x2d, 0xc9, 0xda, 0x00,
+	0xbf, 0x71, 0x93, 0x2e, 0x1e, 0x28, 0x59, 0x97, 0xdd, 0x45, 0xc5, 0x7f, 0x80, 0x7f, 0x5d, 0xd3,
+	0xd0, 0x22, 0xfd, 0xb8, 0xfe, 0x73, 0x30, 0x2d, 0x5c, 0x52, 0x8a, 0xf4, 0xdf, 0xe8, 0x96, 0xb3,
+	0x7c, 0xe8, 0xd8, 0xa1, 0xa0, 0x43, 0xc3, 0xf0, 0xbf, 0x49, 0xf5, 0x5a, 0x55, 0xb9, 0x41, 0x28,
+	0x1c, 0x02, 0xef, 0x69, 0x87, 0x00, 0xb7, 0xdb, 0x72, 0xa1, 0xeb, 0xab, 0xbd, 0x8f, 0x01, 0x6c,
+	0x6c, 0x97, 0xab, 0x39, 0x44, 0x35, 0x3f, 0xe2, 0x58, 0xb6, 0x58, 0x0b, 0xa3, 0xef, 0xd7, 0x49,
+	0xd5, 0xca, 0x5b, 0xa9, 0xad, 0x1e, 0x04, 0xcf, 0x1a, 0x84, 0x3b, 0xe5, 0xd2, 0x7d, 0x09, 0xa5,
+	0xbb, 0x62, 0x0d, 0xc2, 0x49, 0xb2, 0x7d, 0x87, 0x9c, 0xbc, 0xea, 0x3f, 0xb5, 0x84, 0x3f, 0x5c,
+	0x2e, 0xe1, 0x21, 0x4a, 0xf8, 0xb2, 0x76, 0xea, 0x13, 0x38, 0x1b, 0x39, 0xff, 0xac, 0x56, 0xbd,
+	0xef, 0x78, 0x5a, 0x19, 0x21, 0x45, 0xd8, 0x11, 0x6f, 0xab, 0x2d, 0x21, 0x96, 0xf7, 0x54, 0xd3,
+	0x49, 0xe9, 0xeb, 0x99, 0x52, 0x8e, 0x9d, 0xa2, 0x37, 0xdc, 0xd2, 0x4c, 0x49, 0xba, 0xbf, 0x50,
+	0x5a, 0xe6, 0xc1, 0xf4, 0xf8, 0x50, 0x28, 0x03, 0x60, 0x65, 0xb0, 0xc9, 0x6d, 0x50, 0x3e, 0x3d,
+	0x26, 0x27, 0xa7, 0xc7, 0xe4, 0x89, 0xd3, 0x63, 0x52, 0x9c, 0x1e, 0x57, 0x79, 0xff, 0xc8, 0xf1,
+	0xfe, 0xaa, 0xf1, 0x30, 0x23, 0xf7, 0x2f, 0xa4, 0x74, 0x3f, 0x58, 0x39, 0x68, 0x17, 0xe8, 0x82,
+	0x53, 0x42, 0x5c, 0x30, 0x53, 0x17, 0x16, 0xdc, 0x38, 0x09, 0xc7, 0x33, 0x95, 0x45, 0x1b, 0x00,
+	0x60, 0x91, 0x0d, 0xa6, 0x98, 0x75, 0x79, 0x1c, 0x93, 0x02, 0x36, 0x6e, 0x97, 0xab, 0x36, 0x46,
+	0xd5, 0x2e, 0x39, 0x13, 0x3b, 0x27, 0xb0, 0xd1, 0xea, 0x2f, 0x49, 0xe9, 0x46, 0xf6, 0x99, 0xb4,
+	0x0a, 0xe8, 0x29, 0xd3, 0x51, 0x7a, 0xd0, 0xe5, 0xc0, 0xaa, 0xa4, 0x9f, 0x38, 0xd2, 0x97, 0x08,
+	0x66, 0xa4, 0xff, 0x1e, 0x29, 0xd8, 0x69, 0x7f, 0x30, 0x49, 0xe3, 0xc6, 0x66, 0xb9, 0xd4, 0x3f,
+	0x89, 0x52, 0xfb, 0x8e, 0xcd, 0x2d, 0x81, 0x8c, 0xbc, 0x0f, 0x72, 0x19, 0x40, 0xe1, 0xf2, 0xf4,
+	0xb9, 0x72, 0x56, 0x51, 0x87, 0x58, 0xf5, 0xb2, 0x4c, 0x67, 0x86, 0xd1, 0x97, 0x0b, 0xb2, 0x8a,
+	0x27, 0xb5, 0x4b, 0x95, 0xa6, 0xb1, 0xa3, 0x69, 0x8e, 0x85, 0x11, 0xe0, 0x4f, 0x49, 0x61, 0x02,
+	0x03, 0x3e, 0x05, 0xf4, 0x13, 0x23, 0x47, 0xda, 0x76, 0xfc, 0xcd, 0xab, 0xca, 0xa7, 0x6b, 0x99,
+	0x7c, 0xba, 0x6a, 0x3d, 0x4f, 0x9c, 0xf5, 0xbc, 0x40, 0x24, 0x23, 0x73, 0x94, 0x4d, 0xad, 0xd8,
+	0x65, 0x79, 0xca, 0xab, 0x0e, 0x32, 0x96, 0xac, 0x83, 0x42, 0x8e, 0x88, 0x8d, 0xcf, 0x96, 0x33,
+	0x9e, 0x77, 0x88, 0x55, 0x8e, 0x73, 0x3b, 0x36, 0x3c, 0xbf, 0x46, 0xca, 0x73, 0xb7, 0x4a, 0x63,
+	0xa5, 0xce, 0xeb, 0x59, 0xce, 0xbb, 0xd1, 0x2b, 0x97, 0xe7, 0x11, 0xca, 0x73, 0xd9, 0xc8, 0x53,
+	0xc8, 0xd3, 0x48, 0xf6, 0xbf, 0xa4, 0x22, 0x6f, 0x2c, 0xad, 0x21, 0x97, 0x8d, 0xdf, 0x7a, 0x7e,
+	0xbb, 0x23, 0xab, 0x6b, 0x59, 0x70, 0x5a, 0x5d, 0xaa, 0x57, 0x54, 0x97, 0x1a, 0xf9, 0xea, 0xd2,
+	0xc6, 0x5b, 0xe5, 0xaa, 0x1f, 0xa3, 0xea, 0x1d, 0x37, 0x26, 0xe6, 0x95, 0x32, 0xba, 0xff, 0x35,
+	0x29, 0x4d, 0x8a, 0x3f, 0x38, 0xcd, 0xab, 0xe2, 0xe2, 0x3b, 0x6e, 0x5c, 0x2c, 0x16, 0xcd, 0xc8,
+	0xff, 0x0f, 0xa4, 0x24, 0x6f, 0xc7, 0x3a, 0xe4, 0xde, 0x5e, 0x1f, 0xcf, 0xf0, 0x94, 0x4b, 0xe9,
+	0xb6, 0x7d, 0x86, 0x28, 0x8d, 0x9f, 0x39, 0x43, 0x44, 0x8c, 0x54, 0x4f, 0x37, 0xe5, 0x49, 0xdb,
+	0x64, 0xa0, 0xe2, 0x3c, 0x7e, 0x57, 0x6d, 0xe8, 0xdf, 0x2d, 0xd8, 0xd0, 0x67, 0x44, 0x74, 0x46,
+	0xa1, 0xb8, 0xc4, 0x70, 0x92, 0x16, 0xe5, 0xb2, 0xe2, 0x49, 0x61, 0xad, 0xe0, 0xa4, 0xb0, 0x6e,
+	0x4e, 0x0a, 0xab, 0xe4, 0xff, 0xa9, 0x92, 0x84, 0xa4, 0x50, 0xfe, 0x2f, 0xd0, 0xb6, 0xc6, 0x61,
+	0x06, 0x9a, 0x1e, 0xdc, 0x82, 0xc8, 0xa7, 0xd4, 0xc1, 0xed, 0x1a, 0x6d, 0x21, 0x52, 0x55, 0x68,
+	0x71, 0x1b, 0x90, 0x02, 0xcc, 0x51, 0x6c, 0xcd, 0x3a, 0x8a, 0x0d, 0xa6, 0x25, 0x45, 0x94, 0x6c,
+	0x9d, 0xbb, 0x4a, 0x93, 0x2f, 0x3b, 0x9a, 0x14, 0x76, 0x67, 0x34, 0x99, 0x95, 0x94, 0x66, 0x72,
+	0x0c, 0x6f, 0x95, 0x33, 0x7c, 0x4c, 0x0a, 0x38, 0x96, 0xda, 0xee, 0x26, 0x6c, 0x50, 0xe3, 0xd9,
+	0x74, 0x12, 0x0b, 0x60, 0x72, 0xef, 0x0e, 0x32, 0x69, 0x72, 0xef, 0xde, 0x1d, 0x30, 0xca, 0x8d,
+	0x28, 0x9a, 0x46, 0xaa, 0xa0, 0x2d, 0x1b, 0xe6, 0xd6, 0x8d, 0x2c, 0x69, 0xcb, 0x46, 0xf0, 0x37,
+	0xa4, 0xa8, 0x74, 0xf4, 0xa1, 0x4c, 0x83, 0x8a, 0x45, 0xe9, 0x2b, 0xd2, 0x16, 0xcf, 0x99, 0x60,
+	0x5c, 0x6a, 0xfa, 0xfb, 0xf9, 0x12, 0x57, 0xce, 0xea, 0x15, 0x0b, 0xf6, 0x4f, 0x4b, 0x4e, 0x17,
+	0xed, 0xc8, 0x61, 0x75, 0x65, 0xf8, 0xbc, 0x5b, 0x51, 0x34, 0x2b, 0xdc, 0xa4, 0x54, 0xa4, 0x6f,
+	0x5f, 0x25, 0x4e, 0xc0, 0x2d, 0xed, 0xd7, 0x70, 0xff, 0x27, 0x52, 0x5a, 0x94, 0x03, 0xab, 0x23,
+	0xb0, 0x27, 0xcb, 0xe3, 0x35, 0xae, 0x9b, 0x80, 0x41, 0xca, 0xde, 0x40, 0xcd, 0x1c, 0xdd, 0x84,
+	0x4d, 0x5c, 0x77, 0x5f, 0x25, 0x45, 0xb8, 0x3d, 0x95, 0x2d, 0x80, 0xf3, 0x19, 0xc2, 0xe5, 0xd0,
+	0xaa, 0x56, 0xd5, 0xba, 0xf9, 0xf3, 0xc4, 0x89, 0xbd, 0x25, 0x52, 0x1a, 0x55, 0xde, 0x27, 0x27,
+	0x97, 0x10, 0x9f, 0x3a, 0x13, 0xe5, 0xe5, 0xf2, 0xfd, 0x12, 0x71, 0x52, 0xd1, 0x93, 0x58, 0x5b,
+	0x5b, 0x0f, 0xaf, 0xbc, 0x8a, 0x89, 0x06, 0xdc, 0xb4, 0xc6, 0x5c, 0xb5, 0x2c, 0x03, 0x7a, 0xb6,
+	0x01, 0x53, 0xa1, 0x6b, 0xd6, 0xaa, 0xf8, 0x64, 0xf5, 0x1f, 0xf6, 0x22, 0xf5, 0x7a, 0x1c, 0xb3,
+	0xd0, 0xb2, 0x53, 0x74, 0xaf, 0xc7, 0x4f, 0x3a, 0x39, 0xaf, 0x5a, 0xfe, 0x7f, 0x8d, 0x38, 0x5b,
+	0x9f, 0x32, 0x9d, 0x8d, 0x65, 0xfe, 0x8e, 0xe4, 0x2b, 0xb8, 0x1f, 0xa2, 0x45, 0xaa, 0xe6, 0xf3,
+	0x7b, 0xee, 0x7c, 0xce, 0x4a, 0x69, 0x74, 0xf8, 0xe7, 0x74, 0x46, 0xc1, 0x85, 0x22, 0xa7, 0xc2,
+	0x09, 0x22, 0xef, 0x85, 0xf1, 0xa1, 0x39, 0x7a, 0x93, 0xad, 0xf4, 0x48, 0x6e, 0xa0, 0x6e, 0x2e,
+	0xaa, 0x16, 0xc4, 0x9b, 0xee, 0xa6, 0x52, 0xc4, 0xeb, 0x6e, 0x42, 0xbb, 0xbf, 0xa7, 0x8e, 0xf6,
+	0xbd, 0xfe, 0x9e, 0x09, 0xc8, 0x0d, 0x2b, 0x20, 0x57, 0xcd, 0xa9, 0xaf, 0x15, 0xcd, 0xa9, 0x9c,
+	0x9c, 0x46, 0x99, 0x5f, 0xf6, 0x0a, 0x8a, 0xe7, 0x27, 0xe5, 0xa7, 0x85, 0xa3, 0xf2, 0x04, 0xf9,
+	0x29, 0xe6, 0xde, 0xb3, 0xd1, 0x50, 0x9e, 0x7d, 0xab, 0x33, 0xec, 0x14, 0x00, 0xc5, 0x0c, 0xa4,
+	0xde, 0x9c, 0xce, 0x27, 0x03, 0xbd, 0x15, 0xb5, 0x41, 0xe0, 0xaa, 0x78, 0xb6, 0x0b, 0x7f, 0x91,
+	0xd7, 0x14, 0xda, 0xdc, 0x82, 0x6c, 0x6c, 0x95, 0x1b, 0xe6, 0xd7, 0x89, 0x93, 0x60, 0xe5, 0x74,
+	0x36, 0x26, 0xf9, 0x2f, 0x52, 0x78, 0x70, 0xf0, 0x4c, 0x46, 0x81, 0x0a, 0x8e, 0x99, 0x0e, 0x6a,
+	0xa0, 0x6d, 0x10, 0x7b, 0x9d, 0xb6, 0x6f, 0x0e, 0xc5, 0x68, 0xb0, 0x37, 0x95, 0xb3, 0x47, 0x9d,
+	0x2f, 0x32, 0x25, 0x27, 0xe2, 0xa4, 0x1c, 0xdc, 0x25, 0xdc, 0xb8, 0x51, 0xae, 0xec, 0xd7, 0x89,
+	0x93, 0x9b, 0x15, 0x68, 0x63, 0xd4, 0xed, 0xd1, 0x25, 0x8b, 0x09, 0x0c, 0x11, 0x36, 0xad, 0xf9,
+	0x68, 0x00, 0x29, 0x36, 0xdd, 0x53, 0x35, 0xb8, 0x01, 0x04, 0xaf, 0xa9, 0x63, 0xcf, 0xc2, 0x9b,
+	0x01, 0xab, 0xd9, 0x9b, 0x01, 0xe6, 0x56, 0x40, 0xf0, 0x2d, 0x42, 0x97, 0xdd, 0x6b, 0x15, 0x1f,
+	0xd2, 0xb5, 0x89, 0x8f, 0xaa, 0x6b, 0x05, 0x22, 0x7b, 0x6f, 0x22, 0xd5, 0x83, 0x6b, 0x82, 0xe0,
+	0x2b, 0x44, 0xf9, 0xa7, 0xba, 0x48, 0x97, 0xae, 0x9e, 0x5a, 0x4c, 0xdd, 0x4c, 0x4b, 0x4c, 0xbb,
+	0xc3, 0x77, 0x84, 0x9a, 0xf0, 0x06, 0x80, 0x6e, 0x2e, 0xa2, 0xa1, 0x88, 0xb7, 0xa6, 0x73, 0xe5,
+	0x13, 0x0d, 0x6e, 0x83, 0xa0, 0xe7, 0xed, 0xf0, 0xc8, 0x9a, 0x24, 0xba, 0x19, 0xfc, 0x28, 0x6d,
+	0xf3, 0x99, 0x2d, 0x84, 0x71, 0x3c, 0xe2, 0x38, 0xde, 0x06, 0xa5, 0x29, 0x59, 0xac, 0xea, 0xdf,
+	0xcc, 0x0e, 0x8b, 0xf2, 0xff, 0xdc, 0xa2, 0x0a, 0xde, 0xa5, 0x14, 0x6e, 0x52, 0xaa, 0x9e, 0x65,
+	0x68, 0x22, 0x69, 0x68, 0x92, 0x77, 0x2f, 0xbb, 0xea, 0xd8, 0x1c, 0xbf, 0xd9, 0x55, 0xba, 0xc8,
+	0x67, 0x92, 0x45, 0xcd, 0x39, 0xeb, 0x77, 0x84, 0xe4, 0x9a, 0x08, 0xa7, 0x10, 0x9c, 0x0a, 0x81,
+	0x5d, 0xea, 0xb8, 0x99, 0x4c, 0xdb, 0xc1, 0xaf, 0x12, 0x7a, 0xd1, 0x3e, 0x96, 0xbb, 0x3b, 0x0d,
+	0xd3, 0x6d, 0x99, 0xbc, 0xe3, 0xb9, 0x07, 0x9d, 0xa8, 0xbb, 0x9d, 0x67, 0xac, 0x0b, 0xa9, 0x8a,
+	0x4b, 0x4a, 0x52, 0x15, 0x1f, 0x7f, 0xc3, 0x8d, 0x8f, 0x25, 0x0c, 0xcd, 0xec, 0x78, 0xa7, 0xe8,
+	0x48, 0x10, 0xe2, 0x90, 0x89, 0x6b, 0x6a, 0xff, 0x6c, 0x41, 0xaa, 0x36, 0xa8, 0xdf, 0x70, 0x37,
+	0xa8, 0xf9, 0xce, 0x0d, 0xef, 0x7f, 0x24, 0xd5, 0xe7, 0x8e, 0xcf, 0x54, 0x46, 0x3c, 0x31, 0x22,
+	0x6d, 0xec, 0x94, 0x0b, 0xff, 0x4d, 0xe2, 0x94, 0x77, 0xab, 0x84, 0x33, 0x6a, 0xfc, 0x39, 0x29,
+	0x3b, 0x1c, 0xfd, 0x80, 0x14, 0xa8, 0xc8, 0xf6, 0x7f, 0x4b, 0x2a, 0xf0, 0x82, 0xb5, 0x69, 0xaf,
+	0xda, 0xae, 0x7c, 0x97, 0xd0, 0xb6, 0x3a, 0x48, 0x8d, 0xe4, 0x4d, 0xca, 0x35, 0x79, 0xc9, 0x5e,
+	0xe6, 0x43, 0x72, 0xda, 0x1b, 0x80, 0x75, 0xf3, 0xc6, 0x5e, 0xe6, 0xbb, 0xb0, 0x8c, 0xc3, 0x5d,
+	0x65, 0x39, 0x4b, 0xda, 0x5c, 0x36, 0xd8, 0xab, 0xb4, 0xa5, 0x4b, 0xea, 0xfa, 0x5a, 0x89, 0x6f,
+	0x4f, 0x51, 0x8d, 0x54, 0xef, 0x0e, 0x34, 0xa9, 0x49, 0x5d, 0x1b, 0x76, 0xea, 0xfa, 0x6d, 0x92,
+	0x3f, 0x67, 0x7e, 0x26, 0x03, 0x5b, 0x71, 0xad, 0xe6, 0xc4, 0xb5, 0xaa, 0xdd, 0xd3, 0x6f, 0xbb,
+	0xbb, 0xa7, 0xac, 0x20, 0xc6, 0xa4, 0x3f, 0x47, 0x8a, 0x0f, 0xbe, 0x4d, 0x96, 0x49, 0xec, 0xb7,
+	0x1d, 0x2b, 0xb4, 0xd6, 0x4f, 0xf4, 0x82, 0x01, 0x9f, 0x55, 0x99, 0xf7, 0xef, 0x48, 0x21, 0x9e,
+	0x2f, 0x32, 0x62, 0x41, 0xe6, 0xcd, 0x34, 0xae, 0x2b, 0x64, 0xc1, 0x67, 0x1a, 0x81, 0xc1, 0xe0,
+	0x1c, 0x60, 0x4f, 0x5f, 0xc7, 0xa9, 0xf3, 0xb4, 0x0d, 0x3b, 0x1c, 0xf8, 0xce, 0xdc, 0xe6, 0x74,
+	0x60, 0xce, 0xd1, 0x50, 0xcd, 0xbd, 0xed, 0x19, 0xfc, 0x05, 0xa1, 0xa7, 0x55, 0x82, 0x05, 0x49,
+	0xc4, 0x7d, 0x75, 0xaf, 0xad, 0x64, 0x11, 0xc9, 0xee, 0xa7, 0xbc, 0x82, 0xfd, 0x94, 0x4e, 0xd3,
+	0xba, 0xfb, 0x6a, 0x1e, 0xe8, 0x66, 0x8a, 0xe9, 0x27, 0x6a, 0x37, 0xa9, 0x9b, 0xd6, 0xb0, 0x37,
+	0xb2, 0xa7, 0x26, 0xf2, 0x18, 0x04, 0x54, 0x5f, 0x40, 0x94, 0x01, 0x04, 0xb7, 0x68, 0x3b, 0x1d,
+	0x53, 0x3d, 0x11, 0xcc, 0x7a, 0x4c, 0x2a, 0xd6, 0x63, 0xcf, 0x59, 0x8f, 0xe1, 0x82, 0xd6, 0x69,
+	0x1c, 0x5a, 0xcb, 0xe8, 0xd6, 0xe5, 0x3e, 0xe2, 0x5e, 0xee, 0x0b, 0xe8, 0x29, 0xe7, 0xe5, 0x87,
+	0x32, 0x82, 0x0d, 0x63, 0x1b, 0xb4, 0x95, 0x8a, 0x86, 0x66, 0x30, 0xcb, 0x90, 0x23, 0x32, 0x37,
+	0x64, 0xc1, 0x63, 0x42, 0xcf, 0xe4, 0xe6, 0x18, 0xfb, 0x18, 0x6d, 0xe0, 0xd0, 0xf8, 0xc4, 0x39,
+	0x0b, 0xc8, 0x8c, 0x19, 0x97, 0x44, 0xec, 0x4d, 0x7a, 0xca, 0xfe, 0xb7, 0x5a, 0x64, 0x75, 0x60,
+	0xcf, 0xfb, 0x16, 0x77, 0xc8, 0x83, 0x7f, 0x23, 0xea, 0x34, 0xd0, 0xb5, 0xab, 0xa3, 0x0d, 0x79,
+	0x22, 0x6d, 0xd8, 0xab, 0x94, 0xca, 0xad, 0x54, 0xfa, 0x36, 0xca, 0x08, 0x9f, 0xb1, 0x35, 0xb7,
+	0x28, 0xd9, 0x67, 0x68, 0xdb, 0x31, 0x82, 0xb2, 0x5e, 0x79, 0x10, 0x72, 0xc9, 0x5d, 0x97, 0x91,
+	0x75, 0x3e, 0xcb, 0x65, 0xc6, 0xf4, 0xbc, 0x43, 0x9e, 0x56, 0x9d, 0xaa, 0x63, 0xa8, 0x13, 0x15,
+	0xbd, 0x27, 0x8e, 0x8a, 0xc1, 0x5f, 0x91, 0xd2, 0x7b, 0x33, 0xcf, 0x7a, 0xde, 0xe6, 0xb8, 0x5e,
+	0x2d, 0xef, 0x7a, 0x55, 0x1b, 0x8d, 0x6f, 0x91, 0x82, 0x03, 0xb7, 0x9c, 0x64, 0x4e, 0x9d, 0xa6,
+	0xe2, 0x66, 0x4f, 0x45, 0x9c, 0xd0, 0xb7, 0x65, 0x3d, 0xeb, 0xb6, 0xec, 0xd3, 0x16, 0x69, 0xee,
+	0x96, 0xeb, 0xf1, 0xbb, 0xc4, 0xb9, 0x31, 0x50, 0x2e, 0xa2, 0x73, 0x16, 0xb7, 0x85, 0xb9, 0x55,
+	0x38, 0x1a, 0x26, 0xc7, 0xcf, 0xec, 0xd5, 0x1d, 0xba, 0x64, 0x75, 0xa3, 0xf4, 0xb3, 0x41, 0xc1,
+	0x97, 0xe8, 0xaa, 0xbd, 0x7a, 0x67, 0x78, 0x16, 0x1d, 0x27, 0xbc, 0x9e, 0xed, 0xd3, 0xbe, 0x23,
+	0x9f, 0xe9, 0xc0, 0xe5, 0xf5, 0x13, 0xf4, 0xac, 0xd5, 0x4c, 0x7d, 0xf9, 0x35, 0x58, 0xb5, 0xee,
+	0x4f, 0x63, 0xb5, 0x2d, 0xbd, 0x92, 0xbf, 0x6e, 0x9f, 0xed, 0x55, 0xd2, 0xc3, 0xc2, 0x76, 0x23,
+	0xd2, 0x85, 0x56, 0xf8, 0x0c, 0xfe, 0x9e, 0x94, 0xde, 0xdd, 0xca, 0x65, 0x43, 0xee, 0x03, 0xa9,
+	0x86, 0xf3, 0xc0, 0x28, 0xb1, 0xab, 0xda, 0x49, 0xfe, 0x81, 0x51, 0x3d, 0xfb, 0xc0, 0xa8, 0xca,
+	0x8d, 0xbf, 0x5d, 0x54, 0x4f, 0xc8, 0xc9, 0xe7, 0x9c, 0x7a, 0xe3, 0x3b, 0x2b, 0x4c, 0x1f, 0xf6,
+	0xd3, 0xf4, 0x61, 0x9f, 0xbd, 0x40, 0xbd, 0x7e, 0xa2, 0x62, 0x53, 0xe6, 0x61, 0x96, 0xd7, 0x4f,
+	0xe0, 0xe9, 0xa0, 0xba, 0xbf, 0x5e, 0x73, 0x9f, 0x0e, 0xee, 0xf7, 0x13, 0x39, 0xef, 0x63, 0xfd,
+	0x9e, 0x04, 0x1b, 0xab, 0xbb, 0x74, 0xc9, 0x02, 0xdb, 0xaf, 0x37, 0xea, 0xf2, 0xf5, 0xc6, 0x55,
+	0xf7, 0x05, 0x5b, 0x79, 0x0c, 0xb1, 0xde, 0x75, 0xfc, 0xac, 0x97, 0xde, 0x3f, 0x4b, 0x1f, 0xee,
+	0xc1, 0xd4, 0x13, 0xd8, 0x18, 0xa8, 0xc7, 0x21, 0xba, 0x09, 0x81, 0x4c, 0x58, 0x27, 0x0c, 0xf0,
+	0x48, 0xc4, 0x00, 0xc0, 0xff, 0xa6, 0x33, 0x7c, 0xac, 0x06, 0x32, 0xe1, 0x37, 0x7b, 0x81, 0xd6,
+	0x66, 0x89, 0x2e, 0x53, 0x2d, 0x59, 0x3a, 0x72, 0x80, 0x43, 0x87, 0x07, 0xf3, 0x28, 0x02, 0xdb,
+	0x0a, 0x2c, 0xf9, 0x34, 0xb8, 0x01, 0x40, 0x14, 0x9b, 0x45, 0x42, 0x22, 0x17, 0x10, 0x99, 0xb6,
+	0x41, 0xff, 0x38, 0x3a, 0xc0, 0x0b, 0xf0, 0x75, 0x0e, 0x9f, 0xc0, 0x7e, 0x20, 0xe2, 0x44, 0x5d,
+	0x7c, 0xc7, 0x6f, 0x70, 0x8f, 0xfd, 0xe3, 0x44, 0xc4, 0xea, 0xa2, 0x88, 0x6c, 0xc0, 0x7f, 0x45,
+	0x14, 0xe1, 0xcd, 0x90, 0x16, 0x87, 0x4f, 0x78, 0xd7, 0x54, 0x70, 0x53, 0x90, 0x7d, 0x52, 0xe9,
+	0x8b, 0xcb, 0x9d, 0x9c, 0xc5, 0xa5, 0xcf, 0x1d, 0x0d, 0x65, 0x55, 0x36, 0xf4, 0x1d, 0x37, 0x1b,
+	0xca, 0xf3, 0x34, 0x9e, 0x05, 0x32, 0xe5, 0x6f, 0x29, 0x7e, 0x00, 0x32, 0xbd, 0xef, 0xca, 0x94,
+	0xe7, 0xe9, 0x94, 0x33, 0x8b, 0x6e, 0x48, 0x3e, 0xad, 0xf3, 0xaf, 0xd1, 0x16, 0xae, 0xca, 0xf8,
+	0x06, 0x56, 0xba, 0x8b, 0x01, 0x38, 0x8f, 0x09, 0x89, 0x79, 0x0c, 0x59, 0x55, 0xff, 0xf9, 0xbd,
+	0xa2, 0xfa, 0x8f, 0x23, 0xa2, 0xd1, 0xe1, 0x17, 0x48, 0xd1, 0x65, 0x4e, 0xd7, 0xeb, 0x3d, 0xdb,
+	0xeb, 0xb5, 0x5f, 0x7b, 0xc6, 0xaf, 0xab, 0xcc, 0xf9, 0xfb, 0xae, 0x39, 0xf3, 0xac, 0x8c, 0x28,
+	0xbf, 0x49, 0x2a, 0xef, 0x8f, 0x16, 0x3c, 0x36, 0x71, 0x9f, 0x3a, 0x7a, 0xee, 0x53, 0xc7, 0xaa,
+	0x7b, 0x4a, 0xdf, 0x95, 0x52, 0x05, 0xb9, 0xb8, 0x96, 0xe3, 0x6a, 0xc4, 0xfb, 0x5b, 0x52, 0x75,
+	0x7b, 0xf5, 0xff, 0xa5, 0x8c, 0xad, 0x5e, 0xa9, 0xc9, 0xaa, 0x0f, 0x7c, 0x56, 0x2d, 0xcd, 0x7f,
+	0xe0, 0x2e, 0xcd, 0xe5, 0xa2, 0x19, 0x15, 0xbe, 0x49, 0xf2, 0x17, 0x6c, 0x4f, 0x3a, 0x32, 0xc1,
+	0x17, 0xb7, 0xa6, 0xea, 0x33, 0xb0, 0xb2, 0xdc, 0x9a, 0x9d, 0xe5, 0x56, 0xa5, 0x87, 0x7f, 0xe8,
+	0xa6, 0x87, 0x59, 0x11, 0xec, 0xc3, 0xb2, 0xaa, 0xab, 0xbe, 0xb9, 0xf3, 0xb9, 0x8a, 0x11, 0xfe,
+	0x23, 0x77, 0x84, 0x2b, 0x7a, 0x35, 0xdc, 0x7f, 0x91, 0x14, 0x5d, 0x24, 0xc6, 0x2d, 0xa2, 0x7c,
+	0x50, 0x2d, 0x4b, 0x3d, 0xaa, 0x85, 0x23, 0x2e, 0xdf, 0x64, 0xcb, 0xab, 0x22, 0xaa, 0x55, 0x35,
+	0x1b, 0xbe, 0x97, 0x3b, 0x9f, 0xcc, 0x30, 0x33, 0xc2, 0x24, 0x85, 0x97, 0x97, 0x31, 0x1c, 0x20,
+	0x40, 0x1d, 0x76, 0xab, 0x56, 0x55, 0x38, 0xf8, 0x63, 0x37, 0x1c, 0x14, 0xf4, 0x6a, 0xb8, 0xfe,
+	0x0c, 0xa9, 0xbc, 0x1a, 0x5d, 0x66, 0x8b, 0xaa, 0x91, 0xf8, 0xbe, 0x3b, 0x12, 0x15, 0xbd, 0xa7,
+	0x62, 0xfc, 0xdf, 0x00, 0x27, 0xfc, 0x7d, 0x62, 0x35, 0x42, 0x00, 0x00,
 }
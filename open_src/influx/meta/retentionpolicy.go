@@ -486,6 +486,16 @@ func (rpi *RetentionPolicyInfo) validMeasurementShardType(shardType, mstName str
 	return nil
 }
 
+// HashSharding returns true if none of the measurements of the retention policy is range sharded.
+func (rpi *RetentionPolicyInfo) HashSharding() bool {
+	for _, mst := range rpi.Measurements {
+		if len(mst.ShardKeys) > 0 && mst.ShardKeys[0].Type == RANGE {
+			return false
+		}
+	}
+	return true
+}
+
 func (rpi *RetentionPolicyInfo) maxShardGroupID() uint64 {
 	if len(rpi.ShardGroups) == 0 {
 		return 0
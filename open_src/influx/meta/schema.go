@@ -0,0 +1,460 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	originql "github.com/influxdata/influxql"
+	"github.com/openGemini/openGemini/lib/errno"
+	proto2 "github.com/openGemini/openGemini/open_src/influx/meta/proto"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+)
+
+// SchemaVersion is the version of the json layout of ClusterSchema.
+const SchemaVersion = 1
+
+// ClusterSchema is the human-readable schema of a cluster: the databases down to the fields of the measurements,
+// and the users with their privileges. It holds no node ids, pts, shard groups or raft indexes, so the schema of
+// one cluster can be imported into another one which places the pts on its own data nodes.
+// Continuous queries are not kept in the meta data of this version, so they are not part of the schema.
+type ClusterSchema struct {
+	Version   int              `json:"version"`
+	Databases []DatabaseSchema `json:"databases"`
+	Users     []UserSchema     `json:"users,omitempty"`
+}
+
+type DatabaseSchema struct {
+	Name                   string                  `json:"name"`
+	DefaultRetentionPolicy string                  `json:"defaultRetentionPolicy,omitempty"`
+	ShardKey               *ShardKeySchema         `json:"shardKey,omitempty"`
+	RetentionPolicies      []RetentionPolicySchema `json:"retentionPolicies"`
+}
+
+type RetentionPolicySchema struct {
+	Name               string               `json:"name"`
+	ReplicaN           int                  `json:"replicaN"`
+	Duration           string               `json:"duration"`
+	ShardGroupDuration string               `json:"shardGroupDuration"`
+	HotDuration        string               `json:"hotDuration"`
+	WarmDuration       string               `json:"warmDuration"`
+	IndexGroupDuration string               `json:"indexGroupDuration"`
+	Measurements       []MeasurementSchema  `json:"measurements,omitempty"`
+	Subscriptions      []SubscriptionSchema `json:"subscriptions,omitempty"`
+}
+
+type ShardKeySchema struct {
+	ShardKey []string `json:"shardKey"`
+	Type     string   `json:"type"`
+}
+
+type MeasurementSchema struct {
+	Name           string                `json:"name"`
+	ShardKey       *ShardKeySchema       `json:"shardKey,omitempty"`
+	Fields         map[string]string     `json:"fields,omitempty"`
+	IndexRelations []IndexRelationSchema `json:"indexRelations,omitempty"`
+	FloatCodec     string                `json:"floatCodec,omitempty"`
+	TTL            string                `json:"ttl,omitempty"`
+}
+
+type IndexRelationSchema struct {
+	Rid        uint32     `json:"rid"`
+	Oid        uint32     `json:"oid"`
+	IndexNames []string   `json:"indexNames"`
+	IndexLists [][]string `json:"indexLists"`
+}
+
+type SubscriptionSchema struct {
+	Name         string   `json:"name"`
+	Mode         string   `json:"mode"`
+	Destinations []string `json:"destinations"`
+}
+
+type UserSchema struct {
+	Name       string            `json:"name"`
+	Hash       string            `json:"hash,omitempty"`
+	Admin      bool              `json:"admin,omitempty"`
+	Rwuser     bool              `json:"rwuser,omitempty"`
+	Privileges map[string]string `json:"privileges,omitempty"`
+}
+
+// SkippedUser is a user of an imported schema which is not created, with the reason.
+type SkippedUser struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ExportSchema returns the schema of the databases and users, the objects being deleted are left out.
+// The password hashes of the users are left out unless withHash is set.
+func (data *Data) ExportSchema(withHash bool) *ClusterSchema {
+	s := &ClusterSchema{Version: SchemaVersion, Databases: []DatabaseSchema{}}
+	for _, dbi := range data.Databases {
+		if dbi.MarkDeleted {
+			continue
+		}
+		s.Databases = append(s.Databases, exportDatabase(dbi))
+	}
+	sort.Slice(s.Databases, func(i, j int) bool {
+		return s.Databases[i].Name < s.Databases[j].Name
+	})
+
+	for i := range data.Users {
+		u := &data.Users[i]
+		us := UserSchema{Name: u.Name, Admin: u.Admin, Rwuser: u.Rwuser}
+		if withHash {
+			us.Hash = u.Hash
+		}
+		for db, p := range u.Privileges {
+			if us.Privileges == nil {
+				us.Privileges = make(map[string]string, len(u.Privileges))
+			}
+			us.Privileges[db] = p.String()
+		}
+		s.Users = append(s.Users, us)
+	}
+	return s
+}
+
+func exportDatabase(dbi *DatabaseInfo) DatabaseSchema {
+	ds := DatabaseSchema{
+		Name:                   dbi.Name,
+		DefaultRetentionPolicy: dbi.DefaultRetentionPolicy,
+		ShardKey:               exportShardKey(&dbi.ShardKey),
+		RetentionPolicies:      []RetentionPolicySchema{},
+	}
+	for _, rpi := range dbi.RetentionPolicies {
+		if rpi.MarkDeleted {
+			continue
+		}
+		ds.RetentionPolicies = append(ds.RetentionPolicies, exportRetentionPolicy(rpi))
+	}
+	sort.Slice(ds.RetentionPolicies, func(i, j int) bool {
+		return ds.RetentionPolicies[i].Name < ds.RetentionPolicies[j].Name
+	})
+	return ds
+}
+
+func exportRetentionPolicy(rpi *RetentionPolicyInfo) RetentionPolicySchema {
+	rs := RetentionPolicySchema{
+		Name:               rpi.Name,
+		ReplicaN:           rpi.ReplicaN,
+		Duration:           rpi.Duration.String(),
+		ShardGroupDuration: rpi.ShardGroupDuration.String(),
+		HotDuration:        rpi.HotDuration.String(),
+		WarmDuration:       rpi.WarmDuration.String(),
+		IndexGroupDuration: rpi.IndexGroupDuration.String(),
+	}
+	for _, msti := range rpi.Measurements {
+		if msti.MarkDeleted {
+			continue
+		}
+		rs.Measurements = append(rs.Measurements, exportMeasurement(msti))
+	}
+	sort.Slice(rs.Measurements, func(i, j int) bool {
+		return rs.Measurements[i].Name < rs.Measurements[j].Name
+	})
+	for _, sub := range rpi.Subscriptions {
+		rs.Subscriptions = append(rs.Subscriptions, SubscriptionSchema{Name: sub.Name, Mode: sub.Mode, Destinations: sub.Destinations})
+	}
+	return rs
+}
+
+// exportMeasurement keeps the current shard key only, the earlier ones belong to the existing shard groups
+func exportMeasurement(msti *MeasurementInfo) MeasurementSchema {
+	ms := MeasurementSchema{Name: msti.Name, FloatCodec: msti.FloatCodec}
+	if n := len(msti.ShardKeys); n > 0 {
+		ms.ShardKey = exportShardKey(&msti.ShardKeys[n-1])
+	}
+	if msti.TTL > 0 {
+		ms.TTL = msti.TTL.String()
+	}
+	for name, typ := range msti.Schema {
+		if ms.Fields == nil {
+			ms.Fields = make(map[string]string, len(msti.Schema))
+		}
+		ms.Fields[name] = influx.FieldTypeString(typ)
+	}
+	for _, ir := range msti.IndexRelations {
+		irs := IndexRelationSchema{Rid: ir.Rid, Oid: ir.Oid, IndexNames: ir.IndexName, IndexLists: make([][]string, len(ir.IndexList))}
+		for i, l := range ir.IndexList {
+			irs.IndexLists[i] = l.IList
+		}
+		ms.IndexRelations = append(ms.IndexRelations, irs)
+	}
+	return ms
+}
+
+func exportShardKey(ski *ShardKeyInfo) *ShardKeySchema {
+	if len(ski.ShardKey) == 0 && ski.Type == "" {
+		return nil
+	}
+	return &ShardKeySchema{ShardKey: ski.ShardKey, Type: ski.Type}
+}
+
+// ImportSchema creates the databases, retention policies, measurements, fields, subscriptions, users and
+// privileges of s which are missing. The existing objects are kept, so importing the same schema again changes
+// nothing. A field type, shard key or retention policy which conflicts with an existing one fails the import
+// and nothing of s is applied. The pts of the new databases are placed on the data nodes of this cluster.
+// A new user without a password hash, or a new admin user while this cluster has one already, is skipped
+// with its privileges and returned, as a cluster has a single admin user.
+func (data *Data) ImportSchema(s *ClusterSchema) ([]SkippedUser, error) {
+	if s.Version != SchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version %d", s.Version)
+	}
+
+	other := data.Clone()
+	for i := range s.Databases {
+		if err := other.importDatabase(&s.Databases[i]); err != nil {
+			return nil, fmt.Errorf("database %s: %s", s.Databases[i].Name, err)
+		}
+	}
+	var skipped []SkippedUser
+	for i := range s.Users {
+		reason, err := other.importUser(&s.Users[i])
+		if err != nil {
+			return nil, fmt.Errorf("user %s: %s", s.Users[i].Name, err)
+		}
+		if reason != "" {
+			skipped = append(skipped, SkippedUser{Name: s.Users[i].Name, Reason: reason})
+		}
+	}
+	*data = *other
+	return skipped, nil
+}
+
+func (data *Data) importDatabase(ds *DatabaseSchema) error {
+	shardKey := importShardKey(ds.ShardKey)
+	dbi := data.Database(ds.Name)
+	created := dbi == nil
+	if created {
+		var pb *proto2.ShardKeyInfo
+		if shardKey != nil {
+			pb = shardKey.Marshal()
+		}
+		if err := data.CreateDatabase(ds.Name, nil, pb); err != nil {
+			return err
+		}
+	} else if dbi.MarkDeleted {
+		return errno.NewError(errno.DatabaseIsBeingDelete, ds.Name)
+	} else if !equalShardKey(&dbi.ShardKey, shardKey) {
+		return fmt.Errorf("shard key conflicts with the existing one")
+	}
+
+	for i := range ds.RetentionPolicies {
+		if err := data.importRetentionPolicy(ds.Name, &ds.RetentionPolicies[i]); err != nil {
+			return fmt.Errorf("retention policy %s: %s", ds.RetentionPolicies[i].Name, err)
+		}
+	}
+
+	if ds.DefaultRetentionPolicy != "" && (created || data.Database(ds.Name).DefaultRetentionPolicy == "") {
+		return data.SetDefaultRetentionPolicy(ds.Name, ds.DefaultRetentionPolicy)
+	}
+	return nil
+}
+
+func (data *Data) importRetentionPolicy(database string, rs *RetentionPolicySchema) error {
+	rpi := &RetentionPolicyInfo{Name: rs.Name, ReplicaN: rs.ReplicaN}
+	durations := []struct {
+		s string
+		d *time.Duration
+	}{
+		{rs.Duration, &rpi.Duration},
+		{rs.ShardGroupDuration, &rpi.ShardGroupDuration},
+		{rs.HotDuration, &rpi.HotDuration},
+		{rs.WarmDuration, &rpi.WarmDuration},
+		{rs.IndexGroupDuration, &rpi.IndexGroupDuration},
+	}
+	for _, d := range durations {
+		if err := parseSchemaDuration(d.s, d.d); err != nil {
+			return err
+		}
+	}
+	if err := data.CreateRetentionPolicy(database, rpi, false); err != nil {
+		return err
+	}
+
+	for i := range rs.Measurements {
+		if err := data.importMeasurement(database, rs.Name, &rs.Measurements[i]); err != nil {
+			return fmt.Errorf("measurement %s: %s", rs.Measurements[i].Name, err)
+		}
+	}
+
+	for _, sub := range rs.Subscriptions {
+		err := data.CreateSubscription(database, rs.Name, sub.Name, sub.Mode, sub.Destinations)
+		if err != nil && err != ErrSubscriptionExists {
+			return fmt.Errorf("subscription %s: %s", sub.Name, err)
+		}
+	}
+	return nil
+}
+
+func (data *Data) importMeasurement(database, rp string, ms *MeasurementSchema) error {
+	fields := make([]*proto2.FieldSchema, 0, len(ms.Fields))
+	for name, typ := range ms.Fields {
+		t := fieldTypeFromString(typ)
+		if t == influx.Field_Type_Unknown {
+			return fmt.Errorf("unknown type %s of field %s", typ, name)
+		}
+		fields = append(fields, &proto2.FieldSchema{FieldName: proto.String(name), FieldType: proto.Int32(t)})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].GetFieldName() < fields[j].GetFieldName()
+	})
+
+	rpi, err := data.RetentionPolicy(database, rp)
+	if err != nil {
+		return err
+	}
+	shardKey := importShardKey(ms.ShardKey)
+	if msti := rpi.Measurement(ms.Name); msti != nil {
+		if msti.MarkDeleted {
+			return fmt.Errorf("measurement %s is being deleted", ms.Name)
+		}
+		var last *ShardKeyInfo
+		if n := len(msti.ShardKeys); n > 0 {
+			last = &msti.ShardKeys[n-1]
+		}
+		if !equalShardKey(last, shardKey) {
+			return fmt.Errorf("shard key conflicts with the existing one")
+		}
+		return data.UpdateSchema(database, rp, ms.Name, fields)
+	}
+
+	var ttl time.Duration
+	if err = parseSchemaDuration(ms.TTL, &ttl); err != nil {
+		return err
+	}
+	if err = ValidFloatCodec(ms.FloatCodec); err != nil {
+		return err
+	}
+
+	var pb *proto2.ShardKeyInfo
+	if shardKey != nil {
+		pb = shardKey.Marshal()
+	}
+	var indexR *proto2.IndexRelation
+	if len(ms.IndexRelations) > 0 {
+		indexR = importIndexRelation(&ms.IndexRelations[0]).Marshal()
+	}
+	if err = data.CreateMeasurement(database, rp, ms.Name, pb, indexR); err != nil {
+		return err
+	}
+
+	msti := rpi.Measurement(ms.Name)
+	for i := 1; i < len(ms.IndexRelations); i++ {
+		msti.IndexRelations = append(msti.IndexRelations, *importIndexRelation(&ms.IndexRelations[i]))
+	}
+	msti.FloatCodec = ms.FloatCodec
+	msti.TTL = ttl
+	return data.UpdateSchema(database, rp, ms.Name, fields)
+}
+
+// importUser returns the reason why the user is skipped, or an empty string if it is imported
+func (data *Data) importUser(us *UserSchema) (string, error) {
+	if data.GetUser(us.Name) == nil {
+		if us.Hash == "" {
+			return "no password hash in the schema", nil
+		}
+		if us.Admin && data.HasAdminUser() {
+			return "the cluster has an admin user already", nil
+		}
+		if err := data.CreateUser(us.Name, us.Hash, us.Admin, us.Rwuser); err != nil {
+			return "", err
+		}
+	}
+
+	ui := data.GetUser(us.Name)
+	for db, name := range us.Privileges {
+		p, ok := privilegeFromString(name)
+		if !ok {
+			return "", fmt.Errorf("unknown privilege %s on database %s", name, db)
+		}
+		if _, ok = ui.Privileges[db]; ok {
+			continue
+		}
+		if err := data.SetPrivilege(us.Name, db, p); err != nil {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+func importShardKey(sks *ShardKeySchema) *ShardKeyInfo {
+	if sks == nil {
+		return nil
+	}
+	ski := &ShardKeyInfo{ShardKey: append([]string(nil), sks.ShardKey...), Type: sks.Type}
+	sort.Strings(ski.ShardKey)
+	return ski
+}
+
+func importIndexRelation(irs *IndexRelationSchema) *IndexRelation {
+	ir := &IndexRelation{Rid: irs.Rid, Oid: irs.Oid, IndexName: irs.IndexNames, IndexList: make([]*IndexList, len(irs.IndexLists))}
+	for i, l := range irs.IndexLists {
+		ir.IndexList[i] = &IndexList{IList: l}
+	}
+	return ir
+}
+
+// equalShardKey compares the keys and the type, the shard group the key takes effect from is not part of the schema
+func equalShardKey(ski, other *ShardKeyInfo) bool {
+	if ski == nil || other == nil {
+		empty := &ShardKeyInfo{}
+		if ski == nil {
+			ski = empty
+		}
+		if other == nil {
+			other = empty
+		}
+	}
+	return ski.EqualsToAnother(other)
+}
+
+func parseSchemaDuration(s string, d *time.Duration) error {
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+func fieldTypeFromString(s string) int32 {
+	for typ := int32(influx.Field_Type_Int); typ < influx.Field_Type_Last; typ++ {
+		if influx.FieldTypeString(typ) == s {
+			return typ
+		}
+	}
+	return influx.Field_Type_Unknown
+}
+
+func privilegeFromString(s string) (originql.Privilege, bool) {
+	for _, p := range []originql.Privilege{originql.NoPrivileges, originql.ReadPrivilege,
+		originql.WritePrivilege, originql.AllPrivileges} {
+		if p.String() == s {
+			return p, true
+		}
+	}
+	return originql.NoPrivileges, false
+}
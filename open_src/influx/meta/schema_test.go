@@ -0,0 +1,169 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package meta
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	originql "github.com/influxdata/influxql"
+	proto2 "github.com/openGemini/openGemini/open_src/influx/meta/proto"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	assert2 "github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initSchemaData(t *testing.T) *Data {
+	data := initZoneData()
+	rp := NewRetentionPolicyInfo("rp0")
+	ski := &proto2.ShardKeyInfo{ShardKey: []string{"region"}, Type: proto.String(HASH)}
+	require.NoError(t, data.CreateDatabase("db0", rp, ski))
+	require.NoError(t, data.CreateRetentionPolicy("db0", NewRetentionPolicyInfo("rp1"), false))
+
+	mstKey := &proto2.ShardKeyInfo{ShardKey: []string{"host"}, Type: proto.String(HASH)}
+	indexR := &proto2.IndexRelation{Rid: proto.Uint32(0), Oid: proto.Uint32(0), IndexName: []string{"tsi"},
+		IndexLists: []*proto2.IndexList{{IList: []string{"host"}}}}
+	require.NoError(t, data.CreateMeasurement("db0", "rp0", "cpu", mstKey, indexR))
+	require.NoError(t, data.UpdateSchema("db0", "rp0", "cpu", []*proto2.FieldSchema{
+		{FieldName: proto.String("host"), FieldType: proto.Int32(influx.Field_Type_Tag)},
+		{FieldName: proto.String("value"), FieldType: proto.Int32(influx.Field_Type_Float)},
+	}))
+	require.NoError(t, data.SetMeasurementFloatCodec("db0", "rp0", "cpu", "gorilla"))
+	require.NoError(t, data.CreateMeasurement("db0", "rp1", "mem", nil, nil))
+	require.NoError(t, data.CreateSubscription("db0", "rp0", "sub0", "ALL", []string{"http://127.0.0.1:9000"}))
+
+	require.NoError(t, data.CreateUser("admin", "hash0", true, false))
+	require.NoError(t, data.CreateUser("reader", "hash1", false, false))
+	require.NoError(t, data.SetPrivilege("reader", "db0", originql.ReadPrivilege))
+	return data
+}
+
+func TestData_ExportImportSchema(t *testing.T) {
+	src := initSchemaData(t)
+	s := src.ExportSchema(true)
+	require.Equal(t, 1, len(s.Databases))
+	require.Equal(t, 2, len(s.Databases[0].RetentionPolicies))
+	cpu := s.Databases[0].RetentionPolicies[0].Measurements[0]
+	assert2.Equal(t, map[string]string{"host": "tag", "value": "float"}, cpu.Fields)
+	assert2.Equal(t, &ShardKeySchema{ShardKey: []string{"host"}, Type: HASH}, cpu.ShardKey)
+	assert2.Equal(t, map[string]string{"db0": "READ"}, s.Users[1].Privileges)
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	imported := &ClusterSchema{}
+	require.NoError(t, json.Unmarshal(b, imported))
+
+	// the target cluster has other data nodes, the pts are placed on them
+	dst := initData()
+	skipped, err := dst.ImportSchema(imported)
+	require.NoError(t, err)
+	assert2.Empty(t, skipped)
+	assert2.Equal(t, s, dst.ExportSchema(true))
+	for _, pt := range dst.PtView["db0"] {
+		require.NotNil(t, dst.DataNode(pt.Owner.NodeID))
+	}
+	rp, err := dst.RetentionPolicy("db0", "rp0")
+	require.NoError(t, err)
+	assert2.Equal(t, dst.MaxShardGroupID+1, rp.Measurement("cpu").ShardKeys[0].ShardGroup)
+
+	// importing again changes nothing
+	before := dst.Clone()
+	_, err = dst.ImportSchema(imported)
+	require.NoError(t, err)
+	assert2.Equal(t, before, dst)
+}
+
+func TestData_ExportSchema_WithoutHash(t *testing.T) {
+	s := initSchemaData(t).ExportSchema(false)
+	require.Equal(t, 2, len(s.Users))
+	for _, u := range s.Users {
+		assert2.Empty(t, u.Hash)
+	}
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert2.NotContains(t, string(b), `"hash":`)
+
+	// the users without a hash are skipped, the databases are imported
+	dst := initData()
+	skipped, err := dst.ImportSchema(s)
+	require.NoError(t, err)
+	assert2.Equal(t, []SkippedUser{{Name: "admin", Reason: "no password hash in the schema"},
+		{Name: "reader", Reason: "no password hash in the schema"}}, skipped)
+	assert2.NotNil(t, dst.Database("db0"))
+	assert2.Empty(t, dst.Users)
+}
+
+func TestData_ImportSchema_ExistingAdmin(t *testing.T) {
+	s := initSchemaData(t).ExportSchema(true)
+
+	dst := initData()
+	require.NoError(t, dst.CreateUser("root", "hash3", true, false))
+	skipped, err := dst.ImportSchema(s)
+	require.NoError(t, err)
+	assert2.Equal(t, []SkippedUser{{Name: "admin", Reason: "the cluster has an admin user already"}}, skipped)
+	assert2.Nil(t, dst.GetUser("admin"))
+	assert2.True(t, dst.GetUser("root").Admin)
+	reader := dst.GetUser("reader")
+	require.NotNil(t, reader)
+	assert2.Equal(t, originql.ReadPrivilege, reader.Privileges["db0"])
+
+	// the admin user of the same name is kept
+	dst = initData()
+	require.NoError(t, dst.CreateUser("admin", "hash3", true, false))
+	skipped, err = dst.ImportSchema(s)
+	require.NoError(t, err)
+	assert2.Empty(t, skipped)
+	assert2.Equal(t, "hash3", dst.GetUser("admin").Hash)
+}
+
+func TestData_ImportSchema_Merge(t *testing.T) {
+	s := initSchemaData(t).ExportSchema(true)
+
+	dst := initData()
+	require.NoError(t, dst.CreateDatabase("db0", nil, &proto2.ShardKeyInfo{ShardKey: []string{"region"}, Type: proto.String(HASH)}))
+	require.NoError(t, dst.CreateRetentionPolicy("db0", NewRetentionPolicyInfo("rp0"), true))
+	require.NoError(t, dst.CreateMeasurement("db0", "rp0", "cpu",
+		&proto2.ShardKeyInfo{ShardKey: []string{"host"}, Type: proto.String(HASH)}, nil))
+	require.NoError(t, dst.UpdateSchema("db0", "rp0", "cpu", []*proto2.FieldSchema{
+		{FieldName: proto.String("usage"), FieldType: proto.Int32(influx.Field_Type_Int)},
+	}))
+	_, err := dst.ImportSchema(s)
+	require.NoError(t, err)
+	mst, err := dst.Measurement("db0", "rp0", "cpu")
+	require.NoError(t, err)
+	assert2.Equal(t, 3, len(mst.Schema))
+
+	// a conflicting field type fails the whole import
+	dst = initData()
+	s.Users = append(s.Users, UserSchema{Name: "writer", Hash: "hash2"})
+	s.Databases[0].RetentionPolicies[0].Measurements[0].Fields["value"] = "integer"
+	_, err = dst.ImportSchema(&ClusterSchema{Version: SchemaVersion, Databases: []DatabaseSchema{{
+		Name: "db0", RetentionPolicies: []RetentionPolicySchema{{Name: "rp0", ReplicaN: 1, Duration: "0s",
+			Measurements: []MeasurementSchema{{Name: "cpu", ShardKey: &ShardKeySchema{ShardKey: []string{"host"}, Type: HASH},
+				Fields: map[string]string{"value": "float"}}}}}}}})
+	require.NoError(t, err)
+	before := dst.Clone()
+	_, err = dst.ImportSchema(s)
+	require.Error(t, err)
+	assert2.Equal(t, before, dst)
+	assert2.Nil(t, dst.GetUser("writer"))
+
+	s.Version = SchemaVersion + 1
+	_, err = dst.ImportSchema(s)
+	require.Error(t, err)
+}
@@ -9,6 +9,7 @@ Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
 */
 
 import (
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -42,6 +43,9 @@ type ShardGroupInfo struct {
 	DeletedAt   time.Time
 	Shards      []ShardInfo
 	TruncatedAt time.Time
+	// HashSlotN is the number of hash slots of a hash shard group whose shards have been split,
+	// zero means every shard owns one slot and rows are routed by hash modulo the alive shards.
+	HashSlotN uint32
 }
 
 func (sgi *ShardGroupInfo) walkShards(fn func(sh *ShardInfo)) {
@@ -57,7 +61,9 @@ func (sgi ShardGroupInfo) getShardsAndSeriesKeyForHintQuery(tagsGroup *influx.Po
 	r.UnmarshalIndexKeys(nil)
 	r.UnmarshalShardKeyByTag(nil)
 	shard := sgi.ShardFor(HashID(r.ShardKey), aliveShardIdxes)
-	shards = append(shards, *shard)
+	if shard != nil {
+		shards = append(shards, *shard)
+	}
 	// Force the query to be broadcast
 	if executor.GetEnableForceBroadcastQuery() == executor.OnForceBroadcastQuery {
 		return sgi.Shards, r.IndexKey
@@ -339,13 +345,41 @@ func (sgi *ShardGroupInfo) DestShard(shardKey string) *ShardInfo {
 }
 
 // ShardFor returns the ShardInfo for a Point hash.
+// The hash of a split shard group is owned by one shard, nil is returned when that shard is not alive.
 func (sgi *ShardGroupInfo) ShardFor(hash uint64, aliveShardIdxes []int) *ShardInfo {
 	if len(aliveShardIdxes) == 0 {
 		return nil
 	}
+	if sgi.HashSlotN > 0 {
+		if idx := sgi.hashShardIndex(hash); idx >= 0 && containsIndex(aliveShardIdxes, idx) {
+			return &sgi.Shards[idx]
+		}
+		return nil
+	}
 	return &sgi.Shards[aliveShardIdxes[hash%uint64(len(aliveShardIdxes))]]
 }
 
+// hashShardIndex returns the index of the shard whose hash slot range contains hash, or -1 if none.
+func (sgi *ShardGroupInfo) hashShardIndex(hash uint64) int {
+	slot := uint32(hash % uint64(sgi.HashSlotN))
+	pos := hash / uint64(sgi.HashSlotN)
+	for i := range sgi.Shards {
+		if sgi.Shards[i].HashSlot == slot && sgi.Shards[i].ContainHash(pos) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsIndex(idxes []int, idx int) bool {
+	for i := range idxes {
+		if idxes[i] == idx {
+			return true
+		}
+	}
+	return false
+}
+
 // marshal serializes to a protobuf representation.
 func (sgi *ShardGroupInfo) marshal() *proto2.ShardGroupInfo {
 	pb := &proto2.ShardGroupInfo{
@@ -355,6 +389,10 @@ func (sgi *ShardGroupInfo) marshal() *proto2.ShardGroupInfo {
 		DeletedAt: proto.Int64(MarshalTime(sgi.DeletedAt)),
 	}
 
+	if sgi.HashSlotN > 0 {
+		pb.HashSlotN = proto.Uint32(sgi.HashSlotN)
+	}
+
 	if !sgi.TruncatedAt.IsZero() {
 		pb.TruncatedAt = proto.Int64(MarshalTime(sgi.TruncatedAt))
 	}
@@ -386,6 +424,7 @@ func (sgi *ShardGroupInfo) unmarshal(pb *proto2.ShardGroupInfo) {
 	if pb != nil && pb.TruncatedAt != nil {
 		sgi.TruncatedAt = UnmarshalTime(pb.GetTruncatedAt())
 	}
+	sgi.HashSlotN = pb.GetHashSlotN()
 
 	if len(pb.GetShards()) > 0 {
 		sgi.Shards = make([]ShardInfo, len(pb.GetShards()))
@@ -403,6 +442,38 @@ type ShardInfo struct {
 	Max     string
 	Tier    uint64
 	IndexID uint64
+	// HashSlot, HashMin and HashMax locate the shard in the hash space of a split hash shard group,
+	// the shard owns hashes h with h%HashSlotN == HashSlot and HashMin <= h/HashSlotN < HashMax.
+	HashSlot uint32
+	HashMin  uint64
+	HashMax  uint64
+}
+
+// splitHash splits the hash range of the shard into at most n ranges of equal size.
+func (si ShardInfo) splitHash(slotN uint32, n uint64) []ShardInfo {
+	// inclusive end of the hash range
+	end := math.MaxUint64 / uint64(slotN)
+	if si.HashMax > 0 {
+		end = si.HashMax - 1
+	}
+	if span := end - si.HashMin; span < n {
+		n = span + 1
+	}
+
+	step := (end-si.HashMin)/n + 1
+	shards := make([]ShardInfo, n)
+	for i := range shards {
+		shards[i] = si.clone()
+		shards[i].HashMin = si.HashMin + uint64(i)*step
+		shards[i].HashMax = shards[i].HashMin + step
+	}
+	shards[n-1].HashMax = si.HashMax
+	return shards
+}
+
+// ContainHash returns true if pos is in the hash range of the shard, HashMax 0 means the range is unbounded.
+func (si ShardInfo) ContainHash(pos uint64) bool {
+	return si.HashMin <= pos && (si.HashMax == 0 || pos < si.HashMax)
 }
 
 func (si ShardInfo) Contain(shardKey string) bool {
@@ -446,6 +517,11 @@ func (si ShardInfo) marshal() *proto2.ShardInfo {
 		Tier:    proto.Uint64(uint64(si.Tier)),
 		IndexID: proto.Uint64(si.IndexID),
 	}
+	if si.HashSlot > 0 || si.HashMin > 0 || si.HashMax > 0 {
+		pb.HashSlot = proto.Uint32(si.HashSlot)
+		pb.HashMin = proto.Uint64(si.HashMin)
+		pb.HashMax = proto.Uint64(si.HashMax)
+	}
 	pb.OwnerIDs = make([]uint32, len(si.Owners))
 	for i := range si.Owners {
 		pb.OwnerIDs[i] = si.Owners[i]
@@ -471,6 +547,9 @@ func (si *ShardInfo) unmarshal(pb *proto2.ShardInfo) {
 	si.Max = pb.GetMax()
 	si.Tier = pb.GetTier()
 	si.IndexID = pb.GetIndexID()
+	si.HashSlot = pb.GetHashSlot()
+	si.HashMin = pb.GetHashMin()
+	si.HashMax = pb.GetHashMax()
 
 	si.Owners = make([]uint32, len(pb.GetOwnerIDs()))
 	for i, x := range pb.GetOwnerIDs() {
@@ -184,6 +184,12 @@ type ExecutionOptions struct {
 
 	Traceid uint64
 
+	// UserID is the user who runs the query, it maps the query to a workload group
+	UserID string
+
+	// WorkloadGroup is the workload group which the query is scheduled by
+	WorkloadGroup string
+
 	// The results of the query executor
 	RowsChan chan RowsChan
 }
@@ -66,6 +66,8 @@ type SelectOptions struct {
 	RowsChan  chan RowsChan
 
 	HintType hybridqp.HintType
+
+	WorkloadGroup string
 }
 
 type LogicalPlanCreator interface {
@@ -216,6 +218,9 @@ type ProcessorOptions struct {
 	SeriesKey []byte
 
 	GroupByAllDims bool
+
+	// WorkloadGroup is the workload group which the query is scheduled by
+	WorkloadGroup string
 }
 
 // NewProcessorOptionsStmt creates the iterator options from stmt.
@@ -297,6 +302,7 @@ func NewProcessorOptionsStmt(stmt *influxql.SelectStatement, sopt SelectOptions)
 	opt.AbortChan = sopt.AbortChan
 	opt.RowsChan = sopt.RowsChan
 	opt.GroupByAllDims = stmt.GroupByAllDims
+	opt.WorkloadGroup = sopt.WorkloadGroup
 
 	return opt, nil
 }
@@ -620,6 +626,10 @@ func (opt *ProcessorOptions) SetHintType(h hybridqp.HintType) {
 	opt.HintType = h
 }
 
+func (opt *ProcessorOptions) GetWorkloadGroup() string {
+	return opt.WorkloadGroup
+}
+
 func ContainDim(des []string, src string) bool {
 	for i := range des {
 		if src == des[i] {
@@ -0,0 +1,182 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mergeset
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/filestream"
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/fs"
+	"github.com/VictoriaMetrics/VictoriaMetrics/lib/logger"
+	"github.com/openGemini/openGemini/lib/fileops"
+)
+
+// The files of a part hold the items, which are the series keys and the tag values, in plaintext, so they are
+// encrypted by fileops if encryption at rest is enabled, like the TSSP and WAL files. An encrypted file is
+// read through fileops, the other files are read by filestream and fs as before.
+
+const encryptedBufferSize = 64 * 1024
+
+var encryptedTmpFileNum uint64
+
+// createPartFile creates the file of a part in stream mode
+func createPartFile(path string, nocache bool) (filestream.WriteCloser, error) {
+	if !fileops.EncryptionEnabled() {
+		return filestream.Create(path, nocache)
+	}
+	f, err := fileops.CreateEncryptedFile(path, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedWriter{f: f, bw: bufio.NewWriterSize(f, encryptedBufferSize)}, nil
+}
+
+// openPartFile opens the file of a part in stream mode
+func openPartFile(path string, nocache bool) (filestream.ReadCloser, error) {
+	f, err := fileops.OpenDecryptedFile(path, os.O_RDONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+	if !fileops.IsEncrypted(f) {
+		_ = f.Close()
+		return filestream.Open(path, nocache)
+	}
+	return &encryptedReader{f: f, br: bufio.NewReaderSize(f, encryptedBufferSize)}, nil
+}
+
+// mustOpenPartReaderAt opens the file of a part for random reads
+func mustOpenPartReaderAt(path string) fs.MustReadAtCloser {
+	f, err := fileops.OpenDecryptedFile(path, os.O_RDONLY, 0640)
+	if err != nil {
+		logger.Panicf("FATAL: cannot open file %q for reading: %s", path, err)
+	}
+	if !fileops.IsEncrypted(f) {
+		_ = f.Close()
+		return fs.MustOpenReaderAt(path)
+	}
+	return &encryptedReaderAt{f: f}
+}
+
+// readPartFile reads the whole file of a part
+func readPartFile(path string) ([]byte, error) {
+	f, err := fileops.OpenDecryptedFile(path, os.O_RDONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return ioutil.ReadAll(f)
+}
+
+// writePartFileAtomically writes data to the new file of path like fs.WriteFileAtomically
+func writePartFileAtomically(path string, data []byte) error {
+	if !fileops.EncryptionEnabled() {
+		return fs.WriteFileAtomically(path, data)
+	}
+	if fs.IsPathExist(path) {
+		return fmt.Errorf("cannot create file %q, since it already exists", path)
+	}
+
+	tmpPath := fmt.Sprintf("%s.tmp.%d", path, atomic.AddUint64(&encryptedTmpFileNum, 1))
+	f, err := fileops.CreateEncryptedFile(tmpPath, 0640)
+	if err != nil {
+		return fmt.Errorf("cannot create file %q: %w", tmpPath, err)
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if errClose := f.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		fs.MustRemoveAll(tmpPath)
+		return fmt.Errorf("cannot write %d bytes to file %q: %w", len(data), tmpPath, err)
+	}
+
+	if err = os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("cannot move %q to %q: %w", tmpPath, path, err)
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("cannot obtain absolute path to %q: %w", path, err)
+	}
+	fs.MustSyncPath(filepath.Dir(absPath))
+	return nil
+}
+
+type encryptedWriter struct {
+	f  fileops.File
+	bw *bufio.Writer
+}
+
+func (w *encryptedWriter) Write(p []byte) (int, error) {
+	return w.bw.Write(p)
+}
+
+// MustClose flushes, syncs and closes the file, as filestream.Writer does
+func (w *encryptedWriter) MustClose() {
+	if err := w.bw.Flush(); err != nil {
+		logger.Panicf("FATAL: cannot flush buffered data to file %q: %s", w.f.Name(), err)
+	}
+	if err := w.f.Sync(); err != nil {
+		logger.Panicf("FATAL: cannot sync file %q: %s", w.f.Name(), err)
+	}
+	if err := w.f.Close(); err != nil {
+		logger.Panicf("FATAL: cannot close file %q: %s", w.f.Name(), err)
+	}
+}
+
+type encryptedReader struct {
+	f  fileops.File
+	br *bufio.Reader
+}
+
+func (r *encryptedReader) Read(p []byte) (int, error) {
+	return r.br.Read(p)
+}
+
+func (r *encryptedReader) MustClose() {
+	if err := r.f.Close(); err != nil {
+		logger.Panicf("FATAL: cannot close file %q: %s", r.f.Name(), err)
+	}
+}
+
+type encryptedReaderAt struct {
+	f fileops.File
+}
+
+func (r *encryptedReaderAt) MustReadAt(p []byte, off int64) {
+	if len(p) == 0 {
+		return
+	}
+	n, err := r.f.ReadAt(p, off)
+	if n != len(p) {
+		logger.Panicf("FATAL: cannot read %d bytes at offset %d of file %q: %v", len(p), off, r.f.Name(), err)
+	}
+}
+
+func (r *encryptedReaderAt) MustClose() {
+	if err := r.f.Close(); err != nil {
+		logger.Panicf("FATAL: cannot close file %q: %s", r.f.Name(), err)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package mergeset
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openGemini/openGemini/lib/fileops"
+	"github.com/stretchr/testify/require"
+)
+
+func setEncryptionKey(t *testing.T, dir, id string) {
+	key := hex.EncodeToString([]byte(id + "-0123456789abcdef")[:16])
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, id+fileops.EncryptionKeySuffix), []byte(key), 0600))
+	provider, err := fileops.NewFileKeyProvider(dir, "")
+	require.NoError(t, err)
+	fileops.SetKeyProvider(provider)
+}
+
+func addTestItems(t *testing.T, path string, prefix string, n int) {
+	tb, err := OpenTable(path, nil, nil)
+	require.NoError(t, err)
+	items := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		items = append(items, []byte(fmt.Sprintf("%s,host=server%04d", prefix, i)))
+	}
+	require.NoError(t, tb.AddItems(items))
+	tb.DebugFlush()
+	tb.MustClose()
+}
+
+func countTestItems(t *testing.T, path string, prefix string) int {
+	tb, err := OpenTable(path, nil, nil)
+	require.NoError(t, err)
+	defer tb.MustClose()
+
+	var ts TableSearch
+	ts.Init(tb)
+	defer ts.MustClose()
+	n := 0
+	ts.Seek([]byte(prefix))
+	for ts.NextItem() {
+		if len(ts.Item) < len(prefix) || string(ts.Item[:len(prefix)]) != prefix {
+			break
+		}
+		n++
+	}
+	require.NoError(t, ts.Error())
+	return n
+}
+
+var partFileNames = []string{"metaindex.bin", "index.bin", "items.bin", "lens.bin", "metadata.json"}
+
+func partDirs(t *testing.T, path string) map[string]bool {
+	matches, err := filepath.Glob(filepath.Join(path, "*", "metadata.json"))
+	require.NoError(t, err)
+	dirs := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		dirs[filepath.Dir(m)] = true
+	}
+	return dirs
+}
+
+func encryptionKeyOf(t *testing.T, file string) string {
+	fd, err := fileops.Open(file)
+	require.NoError(t, err)
+	fd, err = fileops.DecryptFile(fd)
+	require.NoError(t, err)
+	defer fd.Close()
+	return fileops.EncryptionKeyID(fd)
+}
+
+func TestTable_EncryptionAtRest(t *testing.T) {
+	defer fileops.SetKeyProvider(nil)
+	path := filepath.Join(t.TempDir(), "table")
+
+	// a part written before encryption is enabled stays readable
+	addTestItems(t, path, "mst0", 1000)
+	plain := partDirs(t, path)
+	require.NotEmpty(t, plain)
+	for dir := range plain {
+		for _, name := range partFileNames {
+			require.Equal(t, "", encryptionKeyOf(t, filepath.Join(dir, name)))
+		}
+	}
+
+	keyDir := t.TempDir()
+	setEncryptionKey(t, keyDir, "k1")
+	addTestItems(t, path, "mst1", 1000)
+	encrypted := 0
+	for dir := range partDirs(t, path) {
+		if plain[dir] {
+			continue
+		}
+		for _, name := range partFileNames {
+			require.Equal(t, "k1", encryptionKeyOf(t, filepath.Join(dir, name)), dir+"/"+name)
+		}
+		encrypted++
+	}
+	require.NotZero(t, encrypted)
+	require.Equal(t, 1000, countTestItems(t, path, "mst0"))
+	require.Equal(t, 1000, countTestItems(t, path, "mst1"))
+
+	// the encrypted part can not be opened without its key
+	fileops.SetKeyProvider(nil)
+	_, err := OpenTable(path, nil, nil)
+	require.Error(t, err)
+	_, err = os.Stat(path)
+	require.NoError(t, err)
+
+	setEncryptionKey(t, keyDir, "k1")
+	require.Equal(t, 1000, countTestItems(t, path, "mst1"))
+}
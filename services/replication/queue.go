@@ -0,0 +1,448 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/numberenc"
+)
+
+const (
+	segmentFileSuffix = ".seg"
+	ackFileName       = "ack"
+
+	// | crc32 | payload length |
+	entryHeaderSize = 8
+	// | batch id | enqueue time | db length | rp length |
+	batchHeaderSize = 20
+	// | segment id | offset | last batch id | crc32 |
+	ackSize = 28
+)
+
+var (
+	errQueueFull    = errors.New("replication queue is full")
+	errQueueClosed  = errors.New("replication queue is closed")
+	errCorruptEntry = errors.New("corrupt replication queue entry")
+)
+
+// batch is a write batch accepted by the SQL node and waiting to be shipped to the remote cluster.
+type batch struct {
+	id   uint64
+	time int64
+	db   string
+	rp   string
+	body []byte
+}
+
+func (b *batch) size() int64 {
+	return int64(entryHeaderSize + batchHeaderSize + len(b.db) + len(b.rp) + len(b.body))
+}
+
+func (b *batch) marshal(dst []byte) []byte {
+	start := len(dst)
+	dst = numberenc.MarshalUint32Append(dst, 0)
+	dst = numberenc.MarshalUint32Append(dst, uint32(b.size()-entryHeaderSize))
+	dst = numberenc.MarshalUint64Append(dst, b.id)
+	dst = numberenc.MarshalInt64Append(dst, b.time)
+	dst = numberenc.MarshalUint16Append(dst, uint16(len(b.db)))
+	dst = numberenc.MarshalUint16Append(dst, uint16(len(b.rp)))
+	dst = append(dst, b.db...)
+	dst = append(dst, b.rp...)
+	dst = append(dst, b.body...)
+	numberenc.MarshalUint32Copy(dst[start:], crc32.ChecksumIEEE(dst[start+entryHeaderSize:]))
+	return dst
+}
+
+func (b *batch) unmarshal(src []byte) error {
+	if len(src) < batchHeaderSize {
+		return errCorruptEntry
+	}
+	b.id = numberenc.UnmarshalUint64(src)
+	b.time = numberenc.UnmarshalInt64(src[8:])
+	dbLen := int(numberenc.UnmarshalUint16(src[16:]))
+	rpLen := int(numberenc.UnmarshalUint16(src[18:]))
+	src = src[batchHeaderSize:]
+	if len(src) < dbLen+rpLen {
+		return errCorruptEntry
+	}
+	b.db = string(src[:dbLen])
+	b.rp = string(src[dbLen : dbLen+rpLen])
+	b.body = src[dbLen+rpLen:]
+	return nil
+}
+
+type segment struct {
+	id   uint64
+	path string
+	size int64
+	f    *os.File
+}
+
+// queue is a durable FIFO of batches made of append-only segment files. The position of the first
+// batch which has not been shipped yet is kept in the ack file, so batches are delivered at least once.
+type queue struct {
+	dir            string
+	maxSize        int64
+	maxSegmentSize int64
+	sync           bool
+
+	mu       sync.Mutex
+	segments []*segment
+	ackFile  *os.File
+	readOff  int64
+	lastID   uint64
+	count    int64
+	size     int64
+
+	// head is the batch returned by peek, it is cached until it is acked
+	head     *batch
+	headSize int64
+
+	// room is closed when a batch is acked or the queue is closed, to wake up the appends waiting for room
+	room chan struct{}
+}
+
+func openQueue(dir string, maxSize, maxSegmentSize int64, sync bool) (*queue, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+
+	q := &queue{dir: dir, maxSize: maxSize, maxSegmentSize: maxSegmentSize, sync: sync, room: make(chan struct{})}
+	if err := q.load(); err != nil {
+		q.close()
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *queue) load() error {
+	ackSeg, ackOff, ackID, err := q.readAck()
+	if err != nil {
+		return err
+	}
+	q.lastID = ackID
+
+	ids, err := q.segmentIDs()
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		path := q.segmentPath(id)
+		if id < ackSeg {
+			if err = os.Remove(path); err != nil {
+				return err
+			}
+			continue
+		}
+		start := int64(0)
+		if id == ackSeg {
+			start = ackOff
+		}
+		seg, err := q.loadSegment(id, start)
+		if err != nil {
+			return err
+		}
+		q.segments = append(q.segments, seg)
+	}
+
+	if len(q.segments) > 0 && q.segments[0].id == ackSeg && ackOff <= q.segments[0].size {
+		q.readOff = ackOff
+	}
+	if len(q.segments) == 0 {
+		if q.lastID == 0 {
+			// batch ids keep increasing even if the queue directory is lost, so that the
+			// remote cluster doesn't take the batches of a new queue as duplicates
+			q.lastID = uint64(time.Now().UnixNano())
+		}
+		return q.rotate()
+	}
+	return nil
+}
+
+// loadSegment counts the batches of the segment from the offset start and truncates the segment
+// at the first corrupt batch, which is left by a crash in the middle of a write.
+func (q *queue) loadSegment(id uint64, start int64) (*segment, error) {
+	path := q.segmentPath(id)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	off := int64(0)
+	for off < int64(len(data)) {
+		b, n, err := decodeEntry(data[off:])
+		if err != nil {
+			break
+		}
+		if b.id > q.lastID {
+			q.lastID = b.id
+		}
+		if off >= start {
+			q.count++
+			q.size += n
+		}
+		off += n
+	}
+	if off < int64(len(data)) {
+		if err = os.Truncate(path, off); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+	return &segment{id: id, path: path, size: off, f: f}, nil
+}
+
+func decodeEntry(src []byte) (*batch, int64, error) {
+	if len(src) < entryHeaderSize {
+		return nil, 0, errCorruptEntry
+	}
+	n := int64(numberenc.UnmarshalUint32(src[4:]))
+	if int64(len(src)-entryHeaderSize) < n {
+		return nil, 0, errCorruptEntry
+	}
+	payload := src[entryHeaderSize : entryHeaderSize+n]
+	if crc32.ChecksumIEEE(payload) != numberenc.UnmarshalUint32(src) {
+		return nil, 0, errCorruptEntry
+	}
+	b := &batch{}
+	if err := b.unmarshal(payload); err != nil {
+		return nil, 0, err
+	}
+	return b, entryHeaderSize + n, nil
+}
+
+func (q *queue) segmentIDs() ([]uint64, error) {
+	dirs, err := ioutil.ReadDir(q.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []uint64
+	for _, d := range dirs {
+		name := d.Name()
+		if d.IsDir() || !strings.HasSuffix(name, segmentFileSuffix) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentFileSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func (q *queue) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", id, segmentFileSuffix))
+}
+
+func (q *queue) readAck() (uint64, int64, uint64, error) {
+	f, err := os.OpenFile(filepath.Join(q.dir, ackFileName), os.O_RDWR|os.O_CREATE, 0640)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	q.ackFile = f
+
+	buf := make([]byte, ackSize)
+	if n, _ := f.ReadAt(buf, 0); n < ackSize || crc32.ChecksumIEEE(buf[:ackSize-4]) != numberenc.UnmarshalUint32(buf[ackSize-4:]) {
+		// shipping restarts from the first segment
+		return 0, 0, 0, nil
+	}
+	return numberenc.UnmarshalUint64(buf), numberenc.UnmarshalInt64(buf[8:]), numberenc.UnmarshalUint64(buf[16:]), nil
+}
+
+func (q *queue) writeAck(ackedID uint64) error {
+	buf := make([]byte, 0, ackSize)
+	buf = numberenc.MarshalUint64Append(buf, q.segments[0].id)
+	buf = numberenc.MarshalInt64Append(buf, q.readOff)
+	buf = numberenc.MarshalUint64Append(buf, ackedID)
+	buf = numberenc.MarshalUint32Append(buf, crc32.ChecksumIEEE(buf))
+	_, err := q.ackFile.WriteAt(buf, 0)
+	return err
+}
+
+// rotate creates a new segment to append batches to.
+func (q *queue) rotate() error {
+	id := uint64(0)
+	if len(q.segments) > 0 {
+		id = q.segments[len(q.segments)-1].id + 1
+	}
+	path := q.segmentPath(id)
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return err
+	}
+	q.segments = append(q.segments, &segment{id: id, path: path, f: f})
+	return nil
+}
+
+// append assigns the next batch id to b and appends it to the queue. If the queue is full, it waits up to
+// timeout for the shipped batches to make room.
+func (q *queue) append(b *batch, timeout time.Duration) error {
+	n := b.size()
+	if n > q.maxSize {
+		return errQueueFull
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var timer *time.Timer
+	for q.size+n > q.maxSize && q.segments != nil {
+		if timer == nil {
+			timer = time.NewTimer(timeout)
+			defer timer.Stop()
+		}
+		room := q.room
+		q.mu.Unlock()
+		select {
+		case <-room:
+		case <-timer.C:
+			q.mu.Lock()
+			return errQueueFull
+		}
+		q.mu.Lock()
+	}
+	if q.segments == nil {
+		return errQueueClosed
+	}
+
+	tail := q.segments[len(q.segments)-1]
+	if tail.size > 0 && tail.size+n > q.maxSegmentSize {
+		if err := q.rotate(); err != nil {
+			return err
+		}
+		tail = q.segments[len(q.segments)-1]
+	}
+
+	b.id = q.lastID + 1
+	if _, err := tail.f.Write(b.marshal(make([]byte, 0, n))); err != nil {
+		return err
+	}
+	if q.sync {
+		if err := tail.f.Sync(); err != nil {
+			return err
+		}
+	}
+	q.lastID = b.id
+	tail.size += n
+	q.count++
+	q.size += n
+	return nil
+}
+
+// peek returns the first batch which has not been acked, or nil if the queue is empty.
+func (q *queue) peek() (*batch, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.head != nil {
+		return q.head, nil
+	}
+
+	seg := q.segments[0]
+	if q.readOff >= seg.size {
+		return nil, nil
+	}
+	header := make([]byte, entryHeaderSize)
+	if _, err := seg.f.ReadAt(header, q.readOff); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, entryHeaderSize+int64(numberenc.UnmarshalUint32(header[4:])))
+	if q.readOff+int64(len(buf)) > seg.size {
+		return nil, errCorruptEntry
+	}
+	if _, err := seg.f.ReadAt(buf, q.readOff); err != nil {
+		return nil, err
+	}
+	b, n, err := decodeEntry(buf)
+	if err != nil {
+		return nil, err
+	}
+	q.head, q.headSize = b, n
+	return b, nil
+}
+
+// ack removes the batch returned by peek from the queue.
+func (q *queue) ack() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.head == nil {
+		return nil
+	}
+
+	ackedID := q.head.id
+	q.readOff += q.headSize
+	q.count--
+	q.size -= q.headSize
+	q.head, q.headSize = nil, 0
+	close(q.room)
+	q.room = make(chan struct{})
+
+	// the segment has been shipped and no more batch will be appended to it
+	for len(q.segments) > 1 && q.readOff >= q.segments[0].size {
+		seg := q.segments[0]
+		q.segments = q.segments[1:]
+		q.readOff = 0
+		_ = seg.f.Close()
+		if err := os.Remove(seg.path); err != nil {
+			return err
+		}
+	}
+	return q.writeAck(ackedID)
+}
+
+// stat returns the number and the size of the batches in the queue, and the enqueue time of the first one.
+func (q *queue) stat() (int64, int64, int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var headTime int64
+	if q.head != nil {
+		headTime = q.head.time
+	}
+	return q.count, q.size, headTime
+}
+
+func (q *queue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, seg := range q.segments {
+		_ = seg.f.Close()
+	}
+	q.segments = nil
+	if q.room != nil {
+		close(q.room)
+		q.room = nil
+	}
+	if q.ackFile != nil {
+		_ = q.ackFile.Close()
+		q.ackFile = nil
+	}
+}
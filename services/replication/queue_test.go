@@ -0,0 +1,179 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendBatches(t *testing.T, q *queue, n int) []uint64 {
+	var ids []uint64
+	for i := 0; i < n; i++ {
+		b := &batch{time: int64(i + 1), db: "db0", rp: "rp0", body: []byte("cpu value=1 1\n")}
+		require.NoError(t, q.append(b, 0))
+		ids = append(ids, b.id)
+	}
+	return ids
+}
+
+func TestQueue_AppendPeekAck(t *testing.T) {
+	q, err := openQueue(t.TempDir(), 1024*1024, 128, false)
+	require.NoError(t, err)
+	defer q.close()
+
+	b, err := q.peek()
+	require.NoError(t, err)
+	assert.Nil(t, b)
+
+	ids := appendBatches(t, q, 5)
+	for i := 1; i < len(ids); i++ {
+		assert.Equal(t, ids[i-1]+1, ids[i])
+	}
+	// every segment holds 2 batches at most
+	assert.Equal(t, 3, len(q.segments))
+
+	for i := range ids {
+		b, err = q.peek()
+		require.NoError(t, err)
+		require.NotNil(t, b)
+		assert.Equal(t, ids[i], b.id)
+		assert.Equal(t, int64(i+1), b.time)
+		assert.Equal(t, "db0", b.db)
+		assert.Equal(t, "rp0", b.rp)
+		assert.Equal(t, "cpu value=1 1\n", string(b.body))
+
+		count, _, headTime := q.stat()
+		assert.Equal(t, int64(len(ids)-i), count)
+		assert.Equal(t, b.time, headTime)
+		require.NoError(t, q.ack())
+	}
+
+	b, err = q.peek()
+	require.NoError(t, err)
+	assert.Nil(t, b)
+	count, size, _ := q.stat()
+	assert.Equal(t, int64(0), count)
+	assert.Equal(t, int64(0), size)
+	// the shipped segments are removed
+	assert.Equal(t, 1, len(q.segments))
+}
+
+func TestQueue_Full(t *testing.T) {
+	b := &batch{db: "db0", rp: "rp0", body: []byte("cpu value=1 1\n")}
+	q, err := openQueue(t.TempDir(), 2*b.size(), 1024, false)
+	require.NoError(t, err)
+	defer q.close()
+
+	appendBatches(t, q, 2)
+	assert.Equal(t, errQueueFull, q.append(b, 10*time.Millisecond))
+	assert.Equal(t, errQueueFull, q.append(&batch{body: make([]byte, 4*b.size())}, time.Second))
+
+	// the append waits for a batch to be shipped
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = q.peek()
+		_ = q.ack()
+	}()
+	require.NoError(t, q.append(b, time.Second))
+	count, _, _ := q.stat()
+	assert.Equal(t, int64(2), count)
+}
+
+func TestQueue_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openQueue(dir, 1024*1024, 128, true)
+	require.NoError(t, err)
+	ids := appendBatches(t, q, 5)
+
+	for i := 0; i < 3; i++ {
+		_, err = q.peek()
+		require.NoError(t, err)
+		require.NoError(t, q.ack())
+	}
+	q.close()
+
+	q, err = openQueue(dir, 1024*1024, 128, true)
+	require.NoError(t, err)
+	count, _, _ := q.stat()
+	assert.Equal(t, int64(2), count)
+
+	b, err := q.peek()
+	require.NoError(t, err)
+	assert.Equal(t, ids[3], b.id)
+
+	// the batch ids keep increasing after restarting
+	more := appendBatches(t, q, 1)
+	assert.Equal(t, ids[4]+1, more[0])
+
+	// all batches are shipped before restarting
+	for i := 0; i < 3; i++ {
+		_, err = q.peek()
+		require.NoError(t, err)
+		require.NoError(t, q.ack())
+	}
+	q.close()
+
+	q, err = openQueue(dir, 1024*1024, 128, true)
+	require.NoError(t, err)
+	defer q.close()
+	count, _, _ = q.stat()
+	assert.Equal(t, int64(0), count)
+	more = appendBatches(t, q, 1)
+	assert.Equal(t, ids[4]+2, more[0])
+}
+
+func TestQueue_TruncateTornBatch(t *testing.T) {
+	dir := t.TempDir()
+	q, err := openQueue(dir, 1024*1024, 1024, false)
+	require.NoError(t, err)
+	ids := appendBatches(t, q, 2)
+	path := q.segments[0].path
+	size := q.segments[0].size
+	q.close()
+
+	// a crash in the middle of appending the third batch
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0640)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{1, 2, 3, 4, 0, 0, 0, 100, 5})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	q, err = openQueue(dir, 1024*1024, 1024, false)
+	require.NoError(t, err)
+	defer q.close()
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, size, info.Size())
+
+	count, _, _ := q.stat()
+	assert.Equal(t, int64(2), count)
+	more := appendBatches(t, q, 1)
+	assert.Equal(t, ids[1]+1, more[0])
+
+	for _, id := range append(ids, more...) {
+		b, err := q.peek()
+		require.NoError(t, err)
+		assert.Equal(t, id, b.id)
+		require.NoError(t, q.ack())
+	}
+}
@@ -0,0 +1,361 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/openGemini/openGemini/services"
+	"go.uber.org/zap"
+)
+
+const (
+	// BatchIDHeader carries the id of a replicated batch, formatted as <source id>-<sequence>.
+	// The remote cluster does not replicate the batch again if it is written by one of its replication users.
+	// A batch shipped again after a failure is written again and overwrites the same points.
+	BatchIDHeader = "X-Replication-Batch-Id"
+
+	statInterval = time.Second
+
+	// sourceIDFileName keeps the source id generated for the queue in its dir
+	sourceIDFileName = "source-id"
+)
+
+// Service queues the write batches accepted by the SQL node on disk and ships them
+// to the /write endpoint of a remote cluster.
+type Service struct {
+	services.Base
+
+	// MetaClient tells whether the replication is paused, which is kept in the meta data
+	// so that PAUSE REPLICATION applies to all the SQL nodes and survives restarts
+	MetaClient interface {
+		ReplicationPaused() bool
+	}
+
+	conf     config.Replication
+	sourceID string
+	writeURL string
+	client   *http.Client
+	queue    *queue
+	stat     *statistics.ReplicationStatistics
+
+	wake chan struct{}
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewService(c config.Replication) *Service {
+	s := &Service{
+		conf:     c,
+		sourceID: c.SourceID,
+		writeURL: strings.TrimSuffix(c.RemoteURL, "/") + "/write",
+		client: &http.Client{
+			Timeout: time.Duration(c.Timeout),
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify},
+			},
+		},
+		stat: statistics.NewReplicationStatistics(),
+		wake: make(chan struct{}, 1),
+	}
+	s.Init("replication", statInterval, s.handle)
+	return s
+}
+
+func (s *Service) Open() error {
+	q, err := openQueue(s.conf.Dir, int64(s.conf.MaxQueueSize), int64(s.conf.MaxSegmentSize), s.conf.SyncWrites)
+	if err != nil {
+		return err
+	}
+	s.queue = q
+	if s.sourceID == "" {
+		if s.sourceID, err = loadSourceID(s.conf.Dir); err != nil {
+			q.close()
+			return err
+		}
+	}
+	s.Logger.Info("replication source", zap.String("id", s.sourceID))
+	s.done = make(chan struct{})
+
+	if err = s.Base.Open(); err != nil {
+		return err
+	}
+	s.wg.Add(1)
+	go s.ship()
+	return nil
+}
+
+func (s *Service) Close() error {
+	if s.done == nil {
+		return nil
+	}
+	close(s.done)
+	s.wg.Wait()
+	s.done = nil
+
+	err := s.Base.Close()
+	s.queue.close()
+	return err
+}
+
+// loadSourceID returns the source id kept in dir, a random one is generated and kept the first time,
+// so that the batches of the SQL nodes sharing the same http bind address are told apart.
+func loadSourceID(dir string) (string, error) {
+	path := filepath.Join(dir, sourceIDFileName)
+	b, err := ioutil.ReadFile(path)
+	if err == nil && len(bytes.TrimSpace(b)) > 0 {
+		return string(bytes.TrimSpace(b)), nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	id := make([]byte, 16)
+	if _, err = rand.Read(id); err != nil {
+		return "", err
+	}
+	source := hex.EncodeToString(id)
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, []byte(source), 0640); err != nil {
+		return "", err
+	}
+	if err = os.Rename(tmp, path); err != nil {
+		return "", err
+	}
+	return source, nil
+}
+
+// Replicate queues the rows written to the database db for replication. If the queue is full, it waits up to
+// the timeout for the shipped batches to make room, and fails so that the client retries the write.
+func (s *Service) Replicate(db, rp string, rows []influx.Row) error {
+	if s == nil || len(rows) == 0 || !s.conf.Replicated(db) {
+		return nil
+	}
+
+	var body []byte
+	for i := range rows {
+		body = appendRow(body, &rows[i])
+	}
+	b := &batch{time: time.Now().UnixNano(), db: db, rp: rp, body: body}
+	if err := s.queue.append(b, time.Duration(s.conf.Timeout)); err != nil {
+		s.stat.AddDroppedBatches(1)
+		s.Logger.Error("failed to queue replicated batch", zap.String("db", db), zap.Int("rows", len(rows)), zap.Error(err))
+		return err
+	}
+	s.stat.AddEnqueuedBatches(1)
+	s.stat.AddEnqueuedBytes(int64(len(body)))
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Paused returns true if shipping the queued batches is paused, the accepted writes are still queued.
+func (s *Service) Paused() bool {
+	return s.MetaClient != nil && s.MetaClient.ReplicationPaused()
+}
+
+// Lag returns how long the oldest queued batch has been waiting to be shipped.
+func (s *Service) Lag() time.Duration {
+	count, _, headTime := s.queue.stat()
+	if count == 0 || headTime == 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, headTime))
+}
+
+func (s *Service) handle() {
+	count, size, _ := s.queue.stat()
+	s.stat.SetPendingBatches(count)
+	s.stat.SetPendingBytes(size)
+	s.stat.SetLagMs(s.Lag().Milliseconds())
+	if s.Paused() {
+		s.stat.SetPaused(1)
+	} else {
+		s.stat.SetPaused(0)
+	}
+}
+
+// ship sends the queued batches in order. A batch is removed from the queue once the remote cluster
+// has accepted or rejected it, so every batch is delivered at least once.
+func (s *Service) ship() {
+	defer s.wg.Done()
+
+	backoff := time.Duration(s.conf.RetryInterval)
+	for {
+		var b *batch
+		var err error
+		if !s.Paused() {
+			b, err = s.queue.peek()
+		}
+		if err != nil {
+			s.Logger.Error("failed to read replication queue", zap.Error(err))
+		}
+		if b == nil {
+			wait := time.Duration(s.conf.RetryInterval)
+			if err != nil {
+				wait = backoff
+			}
+			if !s.wait(wait) {
+				return
+			}
+			continue
+		}
+
+		rejected, err := s.send(b)
+		if err != nil {
+			s.stat.AddRetriedRequests(1)
+			s.Logger.Warn("failed to ship replicated batch, retrying", zap.Uint64("id", b.id), zap.Duration("backoff", backoff), zap.Error(err))
+			if !s.wait(backoff) {
+				return
+			}
+			if backoff *= 2; backoff > time.Duration(s.conf.MaxRetryInterval) {
+				backoff = time.Duration(s.conf.MaxRetryInterval)
+			}
+			continue
+		}
+		backoff = time.Duration(s.conf.RetryInterval)
+
+		if rejected {
+			s.stat.AddRejectedBatches(1)
+		} else {
+			s.stat.AddShippedBatches(1)
+			s.stat.AddShippedBytes(int64(len(b.body)))
+		}
+		if err = s.queue.ack(); err != nil {
+			s.Logger.Error("failed to ack replicated batch", zap.Uint64("id", b.id), zap.Error(err))
+		}
+	}
+}
+
+// wait returns false if the service is closed, it returns early when a batch is queued or shipping is resumed.
+func (s *Service) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-s.done:
+		return false
+	case <-s.wake:
+	case <-timer.C:
+	}
+	return true
+}
+
+// send writes the batch to the remote cluster, it returns true if the remote cluster rejects the points
+// of the batch, which will never be accepted by retrying.
+func (s *Service) send(b *batch) (bool, error) {
+	params := url.Values{}
+	params.Set("db", b.db)
+	if b.rp != "" {
+		params.Set("rp", b.rp)
+	}
+	params.Set("precision", "ns")
+
+	req, err := http.NewRequest(http.MethodPost, s.writeURL+"?"+params.Encode(), bytes.NewReader(b.body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set(BatchIDHeader, s.sourceID+"-"+strconv.FormatUint(b.id, 10))
+	if s.conf.Username != "" {
+		req.SetBasicAuth(s.conf.Username, s.conf.Password)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode/100 == 2:
+		return false, nil
+	case resp.StatusCode == http.StatusBadRequest:
+		s.Logger.Error("replicated batch rejected by the remote cluster", zap.Uint64("id", b.id), zap.String("db", b.db), zap.ByteString("error", msg))
+		return true, nil
+	}
+	return false, fmt.Errorf("remote cluster responded %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+}
+
+// appendRow appends the row to dst in line protocol.
+func appendRow(dst []byte, r *influx.Row) []byte {
+	dst = appendEscaped(dst, r.Name, " ,")
+	for i := range r.Tags {
+		dst = append(dst, ',')
+		dst = appendEscaped(dst, r.Tags[i].Key, " ,=")
+		dst = append(dst, '=')
+		dst = appendEscaped(dst, r.Tags[i].Value, " ,=")
+	}
+	for i := range r.Fields {
+		f := &r.Fields[i]
+		if i == 0 {
+			dst = append(dst, ' ')
+		} else {
+			dst = append(dst, ',')
+		}
+		dst = appendEscaped(dst, f.Key, " ,=")
+		dst = append(dst, '=')
+		switch f.Type {
+		case influx.Field_Type_String:
+			// the string values are kept as they are received by the line protocol parser
+			dst = append(dst, '"')
+			dst = append(dst, f.StrValue...)
+			dst = append(dst, '"')
+		case influx.Field_Type_Boolean:
+			dst = strconv.AppendBool(dst, f.NumValue != 0)
+		case influx.Field_Type_Int, influx.Field_Type_UInt:
+			dst = strconv.AppendInt(dst, int64(f.NumValue), 10)
+			dst = append(dst, 'i')
+		default:
+			dst = strconv.AppendFloat(dst, f.NumValue, 'g', -1, 64)
+		}
+	}
+	dst = append(dst, ' ')
+	dst = strconv.AppendInt(dst, r.Timestamp, 10)
+	return append(dst, '\n')
+}
+
+func appendEscaped(dst []byte, s, chars string) []byte {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(chars, s[i]) >= 0 {
+			dst = append(dst, '\\')
+		}
+		dst = append(dst, s[i])
+	}
+	return dst
+}
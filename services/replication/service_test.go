@@ -0,0 +1,264 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replication
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type request struct {
+	batchID string
+	query   string
+	body    string
+}
+
+// remoteCluster records the writes to its /write endpoint, it responds the queued status codes first.
+type remoteCluster struct {
+	mu       sync.Mutex
+	codes    []int
+	requests []request
+}
+
+func (c *remoteCluster) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests = append(c.requests, request{batchID: r.Header.Get(BatchIDHeader), query: r.URL.RawQuery, body: string(body)})
+	code := http.StatusNoContent
+	if len(c.codes) > 0 {
+		code, c.codes = c.codes[0], c.codes[1:]
+	}
+	w.WriteHeader(code)
+}
+
+func (c *remoteCluster) received() []request {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]request(nil), c.requests...)
+}
+
+func newTestService(t *testing.T, url string) *Service {
+	c := config.NewReplication()
+	c.Enabled = true
+	c.RemoteURL = url
+	c.Dir = t.TempDir()
+	c.RetryInterval = toml.Duration(10 * time.Millisecond)
+	c.MaxRetryInterval = toml.Duration(20 * time.Millisecond)
+	require.NoError(t, c.Validate())
+
+	s := NewService(c)
+	require.NoError(t, s.Open())
+	return s
+}
+
+// mockMetaClient keeps the replication switch of the meta data
+type mockMetaClient struct {
+	paused int32
+}
+
+func (c *mockMetaClient) ReplicationPaused() bool {
+	return atomic.LoadInt32(&c.paused) == 1
+}
+
+func (c *mockMetaClient) setPaused(paused bool) {
+	v := int32(0)
+	if paused {
+		v = 1
+	}
+	atomic.StoreInt32(&c.paused, v)
+}
+
+func testRows(v float64) []influx.Row {
+	return []influx.Row{{
+		Name:      "cpu",
+		Tags:      influx.PointTags{{Key: "host", Value: "server 1"}},
+		Fields:    influx.Fields{{Key: "value", NumValue: v, Type: influx.Field_Type_Float}},
+		Timestamp: 1,
+	}}
+}
+
+func waitRequests(t *testing.T, remote *remoteCluster, n int) []request {
+	for i := 0; i < 200; i++ {
+		if reqs := remote.received(); len(reqs) >= n {
+			return reqs
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("remote cluster received %d requests, expected %d", len(remote.received()), n)
+	return nil
+}
+
+func TestService_Ship(t *testing.T) {
+	remote := &remoteCluster{codes: []int{http.StatusInternalServerError, http.StatusServiceUnavailable}}
+	server := httptest.NewServer(remote)
+	defer server.Close()
+
+	s := newTestService(t, server.URL)
+	defer s.Close()
+
+	require.NoError(t, s.Replicate("db0", "rp0", testRows(1)))
+	require.NoError(t, s.Replicate(config.DefaultSlowQueryDatabase, "rp0", testRows(2)))
+	require.NoError(t, s.Replicate("db0", "rp0", testRows(3)))
+
+	reqs := waitRequests(t, remote, 4)
+	// the first batch is retried until it is accepted
+	assert.Equal(t, reqs[0], reqs[1])
+	assert.Equal(t, reqs[0], reqs[2])
+	assert.Equal(t, "db=db0&precision=ns&rp=rp0", reqs[0].query)
+	assert.Equal(t, "cpu,host=server\\ 1 value=1 1\n", reqs[0].body)
+	assert.Equal(t, "cpu,host=server\\ 1 value=3 1\n", reqs[3].body)
+
+	id0 := reqs[0].batchID[strings.LastIndexByte(reqs[0].batchID, '-')+1:]
+	id1 := reqs[3].batchID[strings.LastIndexByte(reqs[3].batchID, '-')+1:]
+	assert.True(t, strings.HasPrefix(reqs[0].batchID, s.sourceID+"-"))
+	assert.Less(t, id0, id1)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 4, len(remote.received()))
+	assert.Equal(t, time.Duration(0), s.Lag())
+}
+
+func TestService_Rejected(t *testing.T) {
+	remote := &remoteCluster{codes: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(remote)
+	defer server.Close()
+
+	s := newTestService(t, server.URL)
+	defer s.Close()
+
+	require.NoError(t, s.Replicate("db0", "", testRows(1)))
+	require.NoError(t, s.Replicate("db0", "", testRows(2)))
+
+	// the rejected batch is not retried
+	reqs := waitRequests(t, remote, 2)
+	assert.Equal(t, "db=db0&precision=ns", reqs[0].query)
+	assert.NotEqual(t, reqs[0].batchID, reqs[1].batchID)
+}
+
+func TestService_PauseResume(t *testing.T) {
+	remote := &remoteCluster{}
+	server := httptest.NewServer(remote)
+	defer server.Close()
+
+	s := newTestService(t, server.URL)
+	defer s.Close()
+	mc := &mockMetaClient{}
+	s.MetaClient = mc
+
+	mc.setPaused(true)
+	assert.True(t, s.Paused())
+	require.NoError(t, s.Replicate("db0", "rp0", testRows(1)))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, len(remote.received()))
+	count, _, _ := s.queue.stat()
+	assert.Equal(t, int64(1), count)
+
+	mc.setPaused(false)
+	assert.False(t, s.Paused())
+	waitRequests(t, remote, 1)
+}
+
+func TestService_SourceID(t *testing.T) {
+	c := config.NewReplication()
+	c.Enabled = true
+	c.RemoteURL = "http://127.0.0.1:8086"
+	c.Dir = t.TempDir()
+
+	// a random source id is kept in the queue dir
+	s := NewService(c)
+	require.NoError(t, s.Open())
+	source := s.sourceID
+	require.NoError(t, s.Close())
+	assert.Len(t, source, 32)
+
+	s = NewService(c)
+	require.NoError(t, s.Open())
+	assert.Equal(t, source, s.sourceID)
+	require.NoError(t, s.Close())
+
+	other := c
+	other.Dir = t.TempDir()
+	s = NewService(other)
+	require.NoError(t, s.Open())
+	assert.NotEqual(t, source, s.sourceID)
+	require.NoError(t, s.Close())
+
+	c.SourceID = "cluster-a"
+	s = NewService(c)
+	require.NoError(t, s.Open())
+	assert.Equal(t, "cluster-a", s.sourceID)
+	require.NoError(t, s.Close())
+}
+
+func TestService_QueueFull(t *testing.T) {
+	remote := &remoteCluster{}
+	server := httptest.NewServer(remote)
+	defer server.Close()
+
+	c := config.NewReplication()
+	c.Enabled = true
+	c.RemoteURL = server.URL
+	c.Dir = t.TempDir()
+	c.MaxQueueSize = 64
+	c.MaxSegmentSize = 64
+	c.Timeout = toml.Duration(10 * time.Millisecond)
+	s := NewService(c)
+	s.MetaClient = &mockMetaClient{paused: 1}
+	require.NoError(t, s.Open())
+	defer s.Close()
+
+	// the write fails instead of dropping the rows when the shipping can not keep up
+	require.NoError(t, s.Replicate("db0", "rp0", testRows(1)))
+	assert.Equal(t, errQueueFull, s.Replicate("db0", "rp0", testRows(2)))
+}
+
+func TestAppendRow(t *testing.T) {
+	r := influx.Row{
+		Name: "cpu load,1",
+		Tags: influx.PointTags{{Key: "host name", Value: "a=b,c"}},
+		Fields: influx.Fields{
+			{Key: "b", NumValue: 1, Type: influx.Field_Type_Boolean},
+			{Key: "f", NumValue: 1.5, Type: influx.Field_Type_Float},
+			{Key: "i", NumValue: -2, Type: influx.Field_Type_Int},
+			{Key: "s", StrValue: "hello world", Type: influx.Field_Type_String},
+		},
+		Timestamp: 1000,
+	}
+	line := string(appendRow(nil, &r))
+	assert.Equal(t, "cpu\\ load\\,1,host\\ name=a\\=b\\,c b=true,f=1.5,i=-2i,s=\"hello world\" 1000\n", line)
+
+	var rows influx.PointRows
+	require.NoError(t, rows.Unmarshal(line))
+	require.Equal(t, 1, len(rows.Rows))
+	assert.Equal(t, r.Name, rows.Rows[0].Name)
+	assert.Equal(t, r.Tags, rows.Rows[0].Tags)
+	assert.Equal(t, r.Fields, rows.Rows[0].Fields)
+	assert.Equal(t, r.Timestamp, rows.Rows[0].Timestamp)
+}
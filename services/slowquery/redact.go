@@ -0,0 +1,116 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowquery
+
+import (
+	"strings"
+)
+
+const redactedLiteral = "?"
+
+// RedactLiterals replaces string, numeric and regex literals of an InfluxQL query with placeholders,
+// so that persisted queries do not leak the values users filter on.
+// Identifiers, durations and hints are kept as is.
+func RedactLiterals(q string) string {
+	var b strings.Builder
+	b.Grow(len(q))
+
+	for i := 0; i < len(q); {
+		c := q[i]
+		switch {
+		case c == '\'':
+			i = skipQuoted(q, i, '\'')
+			b.WriteString("'" + redactedLiteral + "'")
+		case c == '"':
+			end := skipQuoted(q, i, '"')
+			b.WriteString(q[i:end])
+			i = end
+		case c == '/' && i+1 < len(q) && q[i+1] == '*':
+			end := strings.Index(q[i+2:], "*/")
+			if end < 0 {
+				end = len(q)
+			} else {
+				end += i + 4
+			}
+			b.WriteString(q[i:end])
+			i = end
+		case c == '/' && isRegexStart(b.String()):
+			i = skipQuoted(q, i, '/')
+			b.WriteString("/" + redactedLiteral + "/")
+		case isDigit(c) && (i == 0 || !isIdentChar(q[i-1])):
+			end := skipNumber(q, i)
+			if end < len(q) && isLetter(q[end]) {
+				// duration literal such as 10m or 1h
+				for end < len(q) && isLetter(q[end]) {
+					end++
+				}
+				b.WriteString(q[i:end])
+			} else {
+				b.WriteString(redactedLiteral)
+			}
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// skipQuoted returns the position after the closing quote of the literal starting at i.
+func skipQuoted(q string, i int, quote byte) int {
+	for i++; i < len(q); i++ {
+		if q[i] == '\\' {
+			i++
+			continue
+		}
+		if q[i] == quote {
+			return i + 1
+		}
+	}
+	return len(q)
+}
+
+func skipNumber(q string, i int) int {
+	for i < len(q) && (isDigit(q[i]) || q[i] == '.') {
+		i++
+	}
+	if i+1 < len(q) && (q[i] == 'e' || q[i] == 'E') && (isDigit(q[i+1]) || q[i+1] == '+' || q[i+1] == '-') {
+		i += 2
+		for i < len(q) && isDigit(q[i]) {
+			i++
+		}
+	}
+	return i
+}
+
+func isRegexStart(prev string) bool {
+	prev = strings.TrimRight(prev, " \t\r\n")
+	return strings.HasSuffix(prev, "=~") || strings.HasSuffix(prev, "!~")
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isLetter(c) || isDigit(c) || c == '_'
+}
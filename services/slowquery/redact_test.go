@@ -0,0 +1,56 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowquery_test
+
+import (
+	"testing"
+
+	"github.com/openGemini/openGemini/services/slowquery"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactLiterals(t *testing.T) {
+	cases := []struct {
+		in  string
+		out string
+	}{
+		{
+			in:  "SELECT mean(usage) FROM cpu WHERE host = 'server01' AND usage > 90.5",
+			out: "SELECT mean(usage) FROM cpu WHERE host = '?' AND usage > ?",
+		},
+		{
+			in:  `SELECT "value_1" FROM "db0"."rp0"."m1" WHERE time > now() - 1h GROUP BY time(10m) LIMIT 10`,
+			out: `SELECT "value_1" FROM "db0"."rp0"."m1" WHERE time > now() - 1h GROUP BY time(10m) LIMIT ?`,
+		},
+		{
+			in:  `SELECT * FROM cpu WHERE host =~ /^prod-[0-9]+$/ AND region = 'it\'s'`,
+			out: `SELECT * FROM cpu WHERE host =~ /?/ AND region = '?'`,
+		},
+		{
+			in:  "SELECT /*+ Filter_Null_Column */ f1 FROM mst WHERE f2 = 1e-3 OR f3 < -2",
+			out: "SELECT /*+ Filter_Null_Column */ f1 FROM mst WHERE f2 = ? OR f3 < -?",
+		},
+		{
+			in:  "SELECT f1 / 2 FROM mst2",
+			out: "SELECT f1 / ? FROM mst2",
+		},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.out, slowquery.RedactLiterals(c.in))
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowquery
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/openGemini/openGemini/services"
+	"go.uber.org/zap"
+)
+
+const (
+	TagDatabase = "database"
+	TagUser     = "user"
+	TagHostname = "hostname"
+
+	FieldQuery                 = "query"
+	FieldTotalDuration         = "total_duration"
+	FieldPrepareDuration       = "prepare_duration"
+	FieldIteratorDuration      = "iterator_duration"
+	FieldLocalIteratorDuration = "local_iterator_duration"
+	FieldEmitDuration          = "emit_duration"
+	FieldRows                  = "rows"
+	FieldShards                = "shards"
+	FieldQueryBatch            = "query_batch"
+	FieldStageDurations        = "stage_durations"
+
+	// maxPendingRecords bounds the memory held by records waiting to be flushed.
+	maxPendingRecords = 4096
+)
+
+type record struct {
+	stat *statistics.SQLSlowQueryStatistics
+	time int64
+}
+
+// Service persists the slow queries of the SQL node into an internal measurement,
+// so that they can be queried afterwards with SHOW SLOW QUERIES.
+type Service struct {
+	services.Base
+
+	MetaClient interface {
+		Database(name string) (*meta.DatabaseInfo, error)
+		CreateDatabaseWithRetentionPolicy(name string, spec *meta.RetentionPolicySpec, shardKey *meta.ShardKeyInfo) (*meta.DatabaseInfo, error)
+		CreateRetentionPolicy(database string, spec *meta.RetentionPolicySpec, makeDefault bool) (*meta.RetentionPolicyInfo, error)
+	}
+
+	PointsWriter interface {
+		WritePointRows(database, retentionPolicy string, rows []influx.Row) error
+	}
+
+	Hostname string
+
+	conf    config.SlowQuery
+	mu      sync.Mutex
+	pending []record
+	dropped int64
+	ready   bool
+}
+
+func NewService(c config.SlowQuery) *Service {
+	s := &Service{conf: c}
+	s.Init("slow query", time.Duration(c.FlushInterval), s.handle)
+	return s
+}
+
+// Record queues a finished query to be persisted if its duration exceeds the threshold.
+func (s *Service) Record(stat *statistics.SQLSlowQueryStatistics, d time.Duration) {
+	if s == nil || stat == nil || d < time.Duration(s.conf.Threshold) {
+		return
+	}
+	stat.AddDuration("TotalDuration", d.Nanoseconds())
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.pending) >= maxPendingRecords {
+		atomic.AddInt64(&s.dropped, 1)
+		return
+	}
+	s.pending = append(s.pending, record{stat: stat, time: time.Now().UnixNano()})
+}
+
+func (s *Service) handle() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = nil
+	s.mu.Unlock()
+
+	if n := atomic.SwapInt64(&s.dropped, 0); n > 0 {
+		s.Logger.Warn("slow query records dropped", zap.Int64("count", n))
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	if err := s.createDatabaseIfNotExists(); err != nil {
+		s.Logger.Error("failed to create slow query database", zap.String("db", s.conf.Database), zap.Error(err))
+		return
+	}
+
+	rows := make([]influx.Row, 0, len(pending))
+	for i := range pending {
+		rows = append(rows, s.buildRow(&pending[i]))
+	}
+	if err := s.PointsWriter.WritePointRows(s.conf.Database, s.conf.RetentionPolicy, rows); err != nil {
+		s.Logger.Error("failed to write slow queries", zap.Int("rows", len(rows)), zap.Error(err))
+	}
+}
+
+func (s *Service) createDatabaseIfNotExists() error {
+	if s.ready {
+		return nil
+	}
+
+	duration := time.Duration(s.conf.Retention)
+	spec := &meta.RetentionPolicySpec{
+		Name:     s.conf.RetentionPolicy,
+		Duration: &duration,
+	}
+
+	db, err := s.MetaClient.Database(s.conf.Database)
+	if errno.Equal(err, errno.DatabaseNotFound) {
+		_, err = s.MetaClient.CreateDatabaseWithRetentionPolicy(s.conf.Database, spec, nil)
+	} else if err == nil && db.RetentionPolicy(s.conf.RetentionPolicy) == nil {
+		_, err = s.MetaClient.CreateRetentionPolicy(s.conf.Database, spec, false)
+	}
+	if err != nil {
+		return err
+	}
+	s.ready = true
+	return nil
+}
+
+func (s *Service) buildRow(r *record) influx.Row {
+	stat := r.stat
+	q := stat.Query
+	if s.conf.RedactLiterals {
+		q = RedactLiterals(q)
+	}
+	if s.conf.MaxQueryLength > 0 && len(q) > s.conf.MaxQueryLength {
+		q = q[:s.conf.MaxQueryLength]
+	}
+
+	row := influx.Row{
+		Name:      config.DefaultSlowQueryMeasurement,
+		Timestamp: r.time,
+	}
+	row.Tags = append(row.Tags,
+		influx.Tag{Key: TagDatabase, Value: stat.DB},
+		influx.Tag{Key: TagHostname, Value: s.Hostname},
+		influx.Tag{Key: TagUser, Value: stat.User},
+	)
+	sort.Sort(&row.Tags)
+
+	row.Fields = influx.Fields{
+		{Key: FieldQuery, StrValue: q, Type: influx.Field_Type_String},
+		intField(FieldTotalDuration, atomic.LoadInt64(&stat.TotalDuration)),
+		intField(FieldPrepareDuration, atomic.LoadInt64(&stat.PrepareDuration)),
+		intField(FieldIteratorDuration, atomic.LoadInt64(&stat.IteratorDuration)),
+		intField(FieldLocalIteratorDuration, atomic.LoadInt64(&stat.LocalIteratorDuration)),
+		intField(FieldEmitDuration, atomic.LoadInt64(&stat.EmitDuration)),
+		intField(FieldRows, atomic.LoadInt64(&stat.Rows)),
+		intField(FieldShards, atomic.LoadInt64(&stat.Shards)),
+		intField(FieldQueryBatch, atomic.LoadInt64(&stat.QueryBatch)),
+	}
+	if stages := formatStageDurations(stat.GetStageDurations()); stages != "" {
+		row.Fields = append(row.Fields, influx.Field{Key: FieldStageDurations, StrValue: stages, Type: influx.Field_Type_String})
+	}
+	sort.Sort(row.Fields)
+
+	row.UnmarshalIndexKeys(nil)
+	row.ShardKey = row.IndexKey
+	return row
+}
+
+// formatStageDurations formats the durations of the pipeline stages as "name=duration" pairs sorted by name
+func formatStageDurations(durations map[string]int64) string {
+	names := make([]string, 0, len(durations))
+	for name := range durations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(name)
+		sb.WriteByte('=')
+		sb.WriteString(time.Duration(durations[name]).String())
+	}
+	return sb.String()
+}
+
+func intField(key string, v int64) influx.Field {
+	return influx.Field{Key: key, NumValue: float64(v), Type: influx.Field_Type_Int}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 Huawei Cloud Computing Technologies Co., Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slowquery
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/toml"
+	"github.com/openGemini/openGemini/lib/config"
+	"github.com/openGemini/openGemini/lib/errno"
+	"github.com/openGemini/openGemini/lib/statisticsPusher/statistics"
+	"github.com/openGemini/openGemini/open_src/influx/meta"
+	"github.com/openGemini/openGemini/open_src/vm/protoparser/influx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockMetaClient struct {
+	databases map[string]*meta.DatabaseInfo
+}
+
+func (c *mockMetaClient) Database(name string) (*meta.DatabaseInfo, error) {
+	db, ok := c.databases[name]
+	if !ok {
+		return nil, errno.NewError(errno.DatabaseNotFound, name)
+	}
+	return db, nil
+}
+
+func (c *mockMetaClient) CreateDatabaseWithRetentionPolicy(name string, spec *meta.RetentionPolicySpec, _ *meta.ShardKeyInfo) (*meta.DatabaseInfo, error) {
+	db := &meta.DatabaseInfo{
+		Name:                   name,
+		DefaultRetentionPolicy: spec.Name,
+		RetentionPolicies:      map[string]*meta.RetentionPolicyInfo{spec.Name: spec.NewRetentionPolicyInfo()},
+	}
+	c.databases[name] = db
+	return db, nil
+}
+
+func (c *mockMetaClient) CreateRetentionPolicy(database string, spec *meta.RetentionPolicySpec, _ bool) (*meta.RetentionPolicyInfo, error) {
+	rp := spec.NewRetentionPolicyInfo()
+	c.databases[database].RetentionPolicies[spec.Name] = rp
+	return rp, nil
+}
+
+type mockPointsWriter struct {
+	db, rp string
+	rows   []influx.Row
+}
+
+func (w *mockPointsWriter) WritePointRows(database, retentionPolicy string, rows []influx.Row) error {
+	w.db, w.rp = database, retentionPolicy
+	w.rows = append(w.rows, rows...)
+	return nil
+}
+
+func newTestService() (*Service, *mockMetaClient, *mockPointsWriter) {
+	conf := config.NewSlowQuery()
+	conf.Enabled = true
+	conf.Threshold = toml.Duration(time.Second)
+
+	mc := &mockMetaClient{databases: map[string]*meta.DatabaseInfo{}}
+	pw := &mockPointsWriter{}
+	s := NewService(conf)
+	s.MetaClient = mc
+	s.PointsWriter = pw
+	s.Hostname = "127.0.0.1:8086"
+	return s, mc, pw
+}
+
+func TestService_RecordAndFlush(t *testing.T) {
+	s, mc, pw := newTestService()
+
+	fast := statistics.NewSqlSlowQueryStatistics()
+	fast.SetQuery("SELECT * FROM cpu")
+	s.Record(fast, 10*time.Millisecond)
+
+	slow := statistics.NewSqlSlowQueryStatistics()
+	slow.SetDatabase("db0")
+	slow.SetUser("admin")
+	slow.SetQuery("SELECT * FROM cpu WHERE host = 'server01'")
+	slow.AddDuration("PrepareDuration", 100)
+	slow.AddRows(3)
+	slow.AddShards(2)
+	slow.AddStageDurations(map[string]int64{"RPCReaderTransform": int64(time.Second), "HttpSenderTransform": int64(time.Millisecond)})
+	slow.AddStageDurations(map[string]int64{"RPCReaderTransform": int64(2 * time.Second)})
+	s.Record(slow, 2*time.Second)
+
+	s.handle()
+
+	require.Contains(t, mc.databases, config.DefaultSlowQueryDatabase)
+	require.NotNil(t, mc.databases[config.DefaultSlowQueryDatabase].RetentionPolicy(config.DefaultSlowQueryRetentionPolicy))
+	assert.Equal(t, config.DefaultSlowQueryDatabase, pw.db)
+	assert.Equal(t, config.DefaultSlowQueryRetentionPolicy, pw.rp)
+	require.Equal(t, 1, len(pw.rows))
+
+	row := pw.rows[0]
+	assert.Equal(t, config.DefaultSlowQueryMeasurement, row.Name)
+	assert.Equal(t, influx.PointTags{
+		{Key: TagDatabase, Value: "db0"},
+		{Key: TagHostname, Value: "127.0.0.1:8086"},
+		{Key: TagUser, Value: "admin"},
+	}, row.Tags)
+
+	fields := make(map[string]influx.Field, len(row.Fields))
+	for _, f := range row.Fields {
+		fields[f.Key] = f
+	}
+	assert.Equal(t, "SELECT * FROM cpu WHERE host = '?'", fields[FieldQuery].StrValue)
+	assert.Equal(t, float64(2*time.Second), fields[FieldTotalDuration].NumValue)
+	assert.Equal(t, float64(100), fields[FieldPrepareDuration].NumValue)
+	assert.Equal(t, float64(3), fields[FieldRows].NumValue)
+	assert.Equal(t, float64(2), fields[FieldShards].NumValue)
+	assert.Equal(t, "HttpSenderTransform=1ms,RPCReaderTransform=2s", fields[FieldStageDurations].StrValue)
+	assert.True(t, sort.IsSorted(row.Fields))
+
+	// nothing pending, nothing written
+	s.handle()
+	assert.Equal(t, 1, len(pw.rows))
+}
+
+func TestService_CreateRetentionPolicy(t *testing.T) {
+	s, mc, _ := newTestService()
+	mc.databases[config.DefaultSlowQueryDatabase] = &meta.DatabaseInfo{
+		Name:              config.DefaultSlowQueryDatabase,
+		RetentionPolicies: map[string]*meta.RetentionPolicyInfo{},
+	}
+
+	require.NoError(t, s.createDatabaseIfNotExists())
+	rp := mc.databases[config.DefaultSlowQueryDatabase].RetentionPolicy(config.DefaultSlowQueryRetentionPolicy)
+	require.NotNil(t, rp)
+	assert.Equal(t, config.DefaultSlowQueryRetention, rp.Duration)
+}
+
+func TestService_RecordLimit(t *testing.T) {
+	s, _, _ := newTestService()
+	for i := 0; i < maxPendingRecords+10; i++ {
+		s.Record(statistics.NewSqlSlowQueryStatistics(), time.Minute)
+	}
+	assert.Equal(t, maxPendingRecords, len(s.pending))
+	assert.Equal(t, int64(10), s.dropped)
+}
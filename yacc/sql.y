@@ -20,6 +20,7 @@ package yacc
 import (
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 	"unsafe"
@@ -113,6 +114,8 @@ func deal_Fill (fill interface{})  (influxql.FillOption , interface{},bool) {
 %left  <int>  MUL DIV MOD BITWISE_AND
 %right UMINUS
 
+%token <str>    SLOW CODEC TTL COMPACT COMPACTIONS CANCEL MIGRATE DECOMMISSION NODE EVENTS PAUSE RESUME NODES
+
 %type <stmt>                        STATEMENT SHOW_DATABASES_STATEMENT CREATE_DATABASE_STATEMENT WITH_CLAUSES CREATE_USER_STATEMENT
                                     SELECT_STATEMENT SHOW_MEASUREMENTS_STATEMENT SHOW_RETENTION_POLICIES_STATEMENT
                                     CREATE_RENTRENTION_POLICY_STATEMENT RP_DURATION_OPTIONS SHOW_SERIES_STATEMENT
@@ -124,6 +127,11 @@ func deal_Fill (fill interface{})  (influxql.FillOption , interface{},bool) {
                                     SHOW_FIELD_KEY_CARDINALITY_STATEMENT CREATE_MEASUREMENT_STATEMENT DROP_SHARD_STATEMENT SET_PASSWORD_USER_STATEMENT
                                     SHOW_GRANTS_FOR_USER_STATEMENT SHOW_MEASUREMENT_CARDINALITY_STATEMENT SHOW_SERIES_CARDINALITY_STATEMENT SHOW_SHARDS_STATEMENT
                                     ALTER_SHARD_KEY_STATEMENT SHOW_SHARD_GROUPS_STATEMENT DROP_MEASUREMENT_STATEMENT
+                                    SHOW_SLOW_QUERIES_STATEMENT ALTER_MEASUREMENT_TTL_STATEMENT SHOW_COMPACTIONS_STATEMENT
+                                    COMPACT_STATEMENT CANCEL_COMPACTIONS_STATEMENT MIGRATE_PARTITION_STATEMENT
+                                    DECOMMISSION_NODE_STATEMENT SHOW_EVENTS_STATEMENT SHOW_STATS_STATEMENT
+                                    SHOW_DIAGNOSTICS_STATEMENT PAUSE_REPLICATION_STATEMENT RESUME_REPLICATION_STATEMENT
+                                    SHOW_DATA_NODES_STATEMENT
 %type <fields>                      COLUMN_CLAUSES IDENTS
 %type <field>                       COLUMN_CLAUSE
 %type <stmts>                       ALL_QUERIES ALL_QUERY
@@ -132,6 +140,7 @@ func deal_Fill (fill interface{})  (influxql.FillOption , interface{},bool) {
 %type <expr>                        WHERE_CLAUSE CONDITION OPERATION_EQUAL COLUMN_VAREF COLUMN CONDITION_COLUMN TAG_KEYS
 				    CASE_WHEN_CASE CASE_WHEN_CASES
 %type <int>                         CONDITION_OPERATOR
+%type <int64>                       ON_NODE
 %type <dataType>                    COLUMN_VAREF_TYPE
 %type <sortfs>                      SORTFIELDS ORDER_CLAUSES
 %type <sortf>                       SORTFIELD
@@ -139,8 +148,11 @@ func deal_Fill (fill interface{})  (influxql.FillOption , interface{},bool) {
 %type <dimen>                       DIMENSION_NAME
 %type <intSlice>                    OPTION_CLAUSES LIMIT_OFFSET_OPTION SLIMIT_SOFFSET_OPTION
 %type <inter>                       FILL_CLAUSE FILLCONTENT
+%type <tdur>                        TTL_CLAUSE
+%type <bool>                        FULL_OPTION
 %type <durations>                   SHARD_HOT_WARM_INDEX_DURATIONS SHARD_HOT_WARM_INDEX_DURATION CREAT_DATABASE_POLICY  CREAT_DATABASE_POLICYS
-%type <str>                         REGULAR_EXPRESSION TAG_KEY ON_DATABASE TYPE_CALUSE SHARD_KEY STRING_TYPE
+%type <str>                         REGULAR_EXPRESSION TAG_KEY ON_DATABASE TYPE_CALUSE CODEC_CLAUSE SHARD_KEY STRING_TYPE
+%type <str>                         IDENTIFIER KEYWORD_AS_IDENT
 %type <strSlice>                    SHARDKEYLIST INDEX_LIST
 %type <location>                    TIME_ZONE
 %type <indexType>                   INDEX_TYPE INDEX_TYPES
@@ -310,6 +322,54 @@ STATEMENT:
     {
         $$ = $1
     }
+    |ALTER_MEASUREMENT_TTL_STATEMENT
+    {
+        $$ = $1
+    }
+    |SHOW_COMPACTIONS_STATEMENT
+    {
+        $$ = $1
+    }
+    |COMPACT_STATEMENT
+    {
+        $$ = $1
+    }
+    |CANCEL_COMPACTIONS_STATEMENT
+    {
+        $$ = $1
+    }
+    |MIGRATE_PARTITION_STATEMENT
+    {
+        $$ = $1
+    }
+    |DECOMMISSION_NODE_STATEMENT
+    {
+        $$ = $1
+    }
+    |SHOW_EVENTS_STATEMENT
+    {
+        $$ = $1
+    }
+    |SHOW_DATA_NODES_STATEMENT
+    {
+        $$ = $1
+    }
+    |PAUSE_REPLICATION_STATEMENT
+    {
+        $$ = $1
+    }
+    |RESUME_REPLICATION_STATEMENT
+    {
+        $$ = $1
+    }
+    |SHOW_STATS_STATEMENT
+    {
+        $$ = $1
+    }
+    |SHOW_DIAGNOSTICS_STATEMENT
+    {
+        $$ = $1
+    }
     |SHOW_SHARD_GROUPS_STATEMENT
     {
         $$ = $1
@@ -318,6 +378,10 @@ STATEMENT:
     {
         $$ = $1
     }
+    |SHOW_SLOW_QUERIES_STATEMENT
+    {
+        $$ = $1
+    }
 
 
 
@@ -409,7 +473,7 @@ COLUMN_CLAUSE:
     {
         $$ = &influxql.Field{Expr: $1}
     }
-    |COLUMN AS IDENT
+    |COLUMN AS IDENTIFIER
     {
         $$ = &influxql.Field{Expr: $1, Alias:$3}
     }
@@ -441,11 +505,11 @@ CASE_WHEN_CASE:
     }
 
 IDENTS:
-   IDENT
+   IDENTIFIER
    {
        $$ = []*influxql.Field{&influxql.Field{Expr:&influxql.VarRef{Val:$1}}}
    }
-   |IDENT COMMA IDENTS
+   |IDENTIFIER COMMA IDENTS
    {
        $$ = append([]*influxql.Field{&influxql.Field{Expr:&influxql.VarRef{Val:$1}}},$3...)
    }
@@ -487,7 +551,7 @@ COLUMN:
     {
         $$ = $2
     }
-    |IDENT LPAREN COLUMN_CLAUSES RPAREN
+    |IDENTIFIER LPAREN COLUMN_CLAUSES RPAREN
     {
         cols := &influxql.Call{Name: strings.ToLower($1), Args: []influxql.Expr{}}
         for i := range $3{
@@ -495,7 +559,7 @@ COLUMN:
         }
         $$ = cols
     }
-    |IDENT LPAREN RPAREN
+    |IDENTIFIER LPAREN RPAREN
     {
         cols := &influxql.Call{Name: strings.ToLower($1)}
         $$ = cols
@@ -528,7 +592,7 @@ COLUMN:
     	c.Assigners = append(c.Assigners, $4)
     	$$ = c
     }
-    |CASE IDENT CASE_WHEN_CASES ELSE IDENT END
+    |CASE IDENTIFIER CASE_WHEN_CASES ELSE IDENTIFIER END
     {
     	$$ = &influxql.VarRef{}
     }
@@ -581,26 +645,26 @@ TABLE_NAME_WITH_OPTION:
     }
 
 TABLE_CASE:
-    IDENT DOT IDENT DOT TABLE_OPTION
+    IDENTIFIER DOT IDENTIFIER DOT TABLE_OPTION
     {
     	mst := $5
     	mst.Database = $1
     	mst.RetentionPolicy = $3
     	$$ = mst
     }
-    |DOT IDENT DOT TABLE_OPTION
+    |DOT IDENTIFIER DOT TABLE_OPTION
     {
     	mst := $4
     	mst.RetentionPolicy = $2
     	$$ = mst
     }
-    |IDENT DOT  DOT TABLE_OPTION
+    |IDENTIFIER DOT  DOT TABLE_OPTION
     {
     	mst := $4
     	mst.Database = $1
     	$$ = mst
     }
-    |IDENT DOT TABLE_OPTION
+    |IDENTIFIER DOT TABLE_OPTION
     {
     	mst := $3
     	mst.RetentionPolicy = $1
@@ -612,7 +676,7 @@ TABLE_CASE:
     }
 
 TABLE_OPTION:
-    IDENT
+    IDENTIFIER
     {
     	$$ = &influxql.Measurement{Name:$1}
     }
@@ -641,7 +705,7 @@ JOIN_CLAUSES:
     }
 
 JOIN_CLAUSE:
-    FULL OUTER JOIN IDENT ON IDENT CONDITION_OPERATOR IDENT
+    FULL OUTER JOIN IDENTIFIER ON IDENTIFIER CONDITION_OPERATOR IDENTIFIER
     {
     	$$ = &influxql.Measurement{}
     }
@@ -667,7 +731,7 @@ DIMENSION_NAMES:
     }
 
 STRING_TYPE:
-    IDENT
+    IDENTIFIER
     {
     	$$ = $1
     }
@@ -685,7 +749,7 @@ DIMENSION_NAME:
     {
         $$ = &influxql.Dimension{Expr:&influxql.VarRef{Val:$1}}
     }
-    |IDENT LPAREN DURATIONVAL RPAREN
+    |IDENTIFIER LPAREN DURATIONVAL RPAREN
     {
     	if strings.ToLower($1) != "time"{
     	    yylex.Error("Invalid group by combination for no-time tag and time duration")
@@ -693,7 +757,7 @@ DIMENSION_NAME:
 
     	$$ = &influxql.Dimension{Expr:&influxql.Call{Name:"time", Args:[]influxql.Expr{&influxql.DurationLiteral{Val: $3}}}}
     }
-    |IDENT LPAREN DURATIONVAL COMMA DURATIONVAL RPAREN
+    |IDENTIFIER LPAREN DURATIONVAL COMMA DURATIONVAL RPAREN
     {
         if strings.ToLower($1) != "time"{
                     yylex.Error("Invalid group by combination for no-time tag and time duration")
@@ -701,7 +765,7 @@ DIMENSION_NAME:
 
         $$ = &influxql.Dimension{Expr:&influxql.Call{Name:"time", Args:[]influxql.Expr{&influxql.DurationLiteral{Val: $3},&influxql.DurationLiteral{Val: $5}}}}
     }
-    |IDENT LPAREN DURATIONVAL COMMA SUB DURATIONVAL RPAREN
+    |IDENTIFIER LPAREN DURATIONVAL COMMA SUB DURATIONVAL RPAREN
     {
         if strings.ToLower($1) != "time"{
                     yylex.Error("Invalid group by combination for no-time tag and time duration")
@@ -728,7 +792,7 @@ DIMENSION_NAME:
 
 
 TIME_ZONE:
-    IDENT LPAREN STRING RPAREN
+    IDENTIFIER LPAREN STRING RPAREN
     {
         if strings.ToLower($1) != "tz"{
             yylex.Error("Expect tz")
@@ -755,7 +819,7 @@ FILL_CLAUSE:
     }
 
 FILLCONTENT:
-    IDENT
+    IDENTIFIER
     {
         $$ = $1
     }
@@ -795,11 +859,11 @@ CONDITION:
     {
         $$ = &influxql.BinaryExpr{Op:influxql.Token($2),LHS:$1,RHS:$3}
     }
-    |IDENT IN LPAREN IDENTS RPAREN
+    |IDENTIFIER IN LPAREN IDENTS RPAREN
     {
     	$$ = &influxql.BinaryExpr{}
     }
-    |IDENT IN LPAREN SELECT_STATEMENT RPAREN
+    |IDENTIFIER IN LPAREN SELECT_STATEMENT RPAREN
     {
     	$$ = &influxql.BinaryExpr{}
     }
@@ -807,11 +871,11 @@ CONDITION:
     {
     	$$ = &influxql.BinaryExpr{}
     }
-    |IDENT NOT IN LPAREN SELECT_STATEMENT RPAREN
+    |IDENTIFIER NOT IN LPAREN SELECT_STATEMENT RPAREN
     {
     	$$ = &influxql.BinaryExpr{}
     }
-    |IDENT NOT IN LPAREN IDENTS RPAREN
+    |IDENTIFIER NOT IN LPAREN IDENTS RPAREN
     {
     	$$ = &influxql.BinaryExpr{}
     }
@@ -884,11 +948,11 @@ REGULAR_EXPRESSION:
     }
 
 COLUMN_VAREF:
-    IDENT
+    IDENTIFIER
     {
         $$ = &influxql.VarRef{Val:$1}
     }
-    |IDENT DOUBLECOLON COLUMN_VAREF_TYPE
+    |IDENTIFIER DOUBLECOLON COLUMN_VAREF_TYPE
     {
     	$$ = &influxql.VarRef{Val:$1, Type:$3}
     }
@@ -922,7 +986,7 @@ COLUMN_VAREF:
     }
 
 COLUMN_VAREF_TYPE:
-    IDENT
+    IDENTIFIER
     {
     	switch strings.ToLower($1){
     	case "float":
@@ -973,15 +1037,15 @@ SORTFIELDS:
     }
 
 SORTFIELD:
-    IDENT
+    IDENTIFIER
     {
         $$ = &influxql.SortField{Name:$1,Ascending:true}
     }
-    |IDENT DESC
+    |IDENTIFIER DESC
     {
         $$ = &influxql.SortField{Name:$1,Ascending:false}
     }
-    |IDENT ASC
+    |IDENTIFIER ASC
     {
         $$ = &influxql.SortField{Name:$1,Ascending:true}
     }
@@ -1035,14 +1099,14 @@ SHOW_DATABASES_STATEMENT:
     }
 
 CREATE_DATABASE_STATEMENT:
-    CREATE DATABASE IDENT WITH_CLAUSES
+    CREATE DATABASE IDENTIFIER WITH_CLAUSES
     {
         sms := $4
 
         sms.(*influxql.CreateDatabaseStatement).Name = $3
         $$ = sms
     }
-    |CREATE DATABASE IDENT
+    |CREATE DATABASE IDENTIFIER
     {
         stmt := &influxql.CreateDatabaseStatement{}
         stmt.RetentionPolicyCreate = false
@@ -1194,7 +1258,7 @@ CREAT_DATABASE_POLICY:
         int_integer := *(*int)(unsafe.Pointer(&$2))
         $$ = &Durations{ShardGroupDuration: -1,HotDuration: -1,WarmDuration: -1,IndexGroupDuration: -1,Replication: &int_integer}
     }
-    |NAME IDENT
+    |NAME IDENTIFIER
     {
         $$ = &Durations{ShardGroupDuration: -1,HotDuration: -1,WarmDuration: -1,IndexGroupDuration: -1,PolicyName: $2}
     }
@@ -1241,11 +1305,11 @@ SHOW_MEASUREMENTS_STATEMENT:
 
 MEASUREMENT_WITH:
 
-    EQ IDENT
+    EQ IDENTIFIER
     {
         $$ = &influxql.Measurement{Name:$2}
     }
-    |NEQ IDENT
+    |NEQ IDENTIFIER
     {
         $$ = &influxql.Measurement{Name:$2}
     }
@@ -1269,7 +1333,7 @@ MEASUREMENT_WITH:
 
 
 SHOW_RETENTION_POLICIES_STATEMENT:
-    SHOW RETENTION POLICIES ON IDENT
+    SHOW RETENTION POLICIES ON IDENTIFIER
     {
         $$ = &influxql.ShowRetentionPoliciesStatement{
             Database: $5,
@@ -1282,14 +1346,14 @@ SHOW_RETENTION_POLICIES_STATEMENT:
 
 
 CREATE_RENTRENTION_POLICY_STATEMENT:
-    CREATE RETENTION POLICY IDENT ON IDENT RP_DURATION_OPTIONS
+    CREATE RETENTION POLICY IDENTIFIER ON IDENTIFIER RP_DURATION_OPTIONS
     {
         stmt := $7.(*influxql.CreateRetentionPolicyStatement)
         stmt.Name = $4
         stmt.Database = $6
         $$ = stmt
     }
-    |CREATE RETENTION POLICY IDENT ON IDENT RP_DURATION_OPTIONS DEFAULT
+    |CREATE RETENTION POLICY IDENTIFIER ON IDENTIFIER RP_DURATION_OPTIONS DEFAULT
     {
         stmt := $7.(*influxql.CreateRetentionPolicyStatement)
         stmt.Name = $4
@@ -1299,14 +1363,14 @@ CREATE_RENTRENTION_POLICY_STATEMENT:
     }
 
 CREATE_USER_STATEMENT:
-    CREATE USER IDENT WITH PASSWORD STRING
+    CREATE USER IDENTIFIER WITH PASSWORD STRING
     {
         stmt := &influxql.CreateUserStatement{}
         stmt.Name = $3
         stmt.Password = $6
         $$ = stmt
     }
-    |CREATE USER IDENT WITH PASSWORD STRING WITH ALL PRIVILEGES
+    |CREATE USER IDENTIFIER WITH PASSWORD STRING WITH ALL PRIVILEGES
     {
         stmt := &influxql.CreateUserStatement{}
         stmt.Name = $3
@@ -1314,7 +1378,7 @@ CREATE_USER_STATEMENT:
         stmt.Admin = true
         $$ = stmt
     }
-    |CREATE USER IDENT WITH PASSWORD STRING WITH PARTITION PRIVILEGES
+    |CREATE USER IDENTIFIER WITH PASSWORD STRING WITH PARTITION PRIVILEGES
     {
         stmt := &influxql.CreateUserStatement{}
         stmt.Name = $3
@@ -1464,7 +1528,7 @@ SHOW_USERS_STATEMENT:
     }
 
 DROP_DATABASE_STATEMENT:
-    DROP DATABASE IDENT
+    DROP DATABASE IDENTIFIER
     {
     	stmt := &influxql.DropDatabaseStatement{}
     	stmt.Name = $3
@@ -1503,7 +1567,7 @@ DELETE_SERIES_STATEMENT:
 
 
 ALTER_RENTRENTION_POLICY_STATEMENT:
-    ALTER RETENTION POLICY IDENT ON IDENT CREAT_DATABASE_POLICYS
+    ALTER RETENTION POLICY IDENTIFIER ON IDENTIFIER CREAT_DATABASE_POLICYS
     {
         stmt := &influxql.AlterRetentionPolicyStatement{}
         stmt.Name = $4
@@ -1542,7 +1606,7 @@ ALTER_RENTRENTION_POLICY_STATEMENT:
 
 
 DROP_RETENTION_POLICY_STATEMENT:
-    DROP RETENTION POLICY IDENT ON IDENT
+    DROP RETENTION POLICY IDENTIFIER ON IDENTIFIER
     {
         stmt := &influxql.DropRetentionPolicyStatement{}
         stmt.Name = $4
@@ -1551,7 +1615,7 @@ DROP_RETENTION_POLICY_STATEMENT:
     }
 
 GRANT_STATEMENT:
-    GRANT ALL ON IDENT TO IDENT
+    GRANT ALL ON IDENTIFIER TO IDENTIFIER
     {
     	stmt := &influxql.GrantStatement{}
     	stmt.Privilege = influxql.AllPrivileges
@@ -1559,7 +1623,7 @@ GRANT_STATEMENT:
     	stmt.User = $6
     	$$ = stmt
     }
-    |GRANT ALL PRIVILEGES ON IDENT TO IDENT
+    |GRANT ALL PRIVILEGES ON IDENTIFIER TO IDENTIFIER
     {
     	stmt := &influxql.GrantStatement{}
     	stmt.Privilege = influxql.AllPrivileges
@@ -1567,7 +1631,7 @@ GRANT_STATEMENT:
     	stmt.User = $7
     	$$ = stmt
     }
-    |GRANT IDENT ON IDENT TO IDENT
+    |GRANT IDENTIFIER ON IDENTIFIER TO IDENTIFIER
     {
     	stmt := &influxql.GrantStatement{}
     	switch strings.ToLower($2){
@@ -1584,17 +1648,17 @@ GRANT_STATEMENT:
     }
 
 GRANT_ADMIN_STATEMENT:
-    GRANT ALL PRIVILEGES TO IDENT
+    GRANT ALL PRIVILEGES TO IDENTIFIER
     {
     	$$ = &influxql.GrantAdminStatement{User: $5}
     }
-    |GRANT ALL TO IDENT
+    |GRANT ALL TO IDENTIFIER
     {
     	$$ = &influxql.GrantAdminStatement{User: $4}
     }
 
 REVOKE_STATEMENT:
-    REVOKE ALL ON IDENT FROM IDENT
+    REVOKE ALL ON IDENTIFIER FROM IDENTIFIER
     {
     	stmt := &influxql.RevokeStatement{}
     	stmt.Privilege = influxql.AllPrivileges
@@ -1602,7 +1666,7 @@ REVOKE_STATEMENT:
     	stmt.User = $6
     	$$ = stmt
     }
-    |REVOKE ALL PRIVILEGES ON IDENT FROM IDENT
+    |REVOKE ALL PRIVILEGES ON IDENTIFIER FROM IDENTIFIER
     {
     	stmt := &influxql.RevokeStatement{}
     	stmt.Privilege = influxql.AllPrivileges
@@ -1610,7 +1674,7 @@ REVOKE_STATEMENT:
     	stmt.User = $7
     	$$ = stmt
     }
-    |REVOKE IDENT ON IDENT FROM IDENT
+    |REVOKE IDENTIFIER ON IDENTIFIER FROM IDENTIFIER
     {
     	stmt := &influxql.RevokeStatement{}
     	switch strings.ToLower($2){
@@ -1627,17 +1691,17 @@ REVOKE_STATEMENT:
     }
 
 REVOKE_ADMIN_STATEMENT:
-    REVOKE ALL PRIVILEGES FROM IDENT
+    REVOKE ALL PRIVILEGES FROM IDENTIFIER
     {
     	$$ = &influxql.RevokeAdminStatement{User: $5}
     }
-    |REVOKE ALL FROM IDENT
+    |REVOKE ALL FROM IDENTIFIER
     {
     	$$ = &influxql.RevokeAdminStatement{User: $4}
     }
 
 DROP_USER_STATEMENT:
-    DROP USER IDENT
+    DROP USER IDENTIFIER
     {
     	$$ = &influxql.DropUserStatement{Name:$3}
     }
@@ -1671,7 +1735,7 @@ SHOW_TAG_KEYS_STATEMENT:
   }
 
 ON_DATABASE:
-  ON IDENT
+  ON IDENTIFIER
   {
       $$ = $2
   }
@@ -1787,7 +1851,7 @@ TAG_KEYS:
   }
 
 TAG_KEY:
-  IDENT
+  IDENTIFIER
   {
       $$ = $1
   }
@@ -1979,7 +2043,7 @@ SHOW_FIELD_KEY_CARDINALITY_STATEMENT:
 
 
 CREATE_MEASUREMENT_STATEMENT:
-    CREATE MEASUREMENT TABLE_CASE WITH INDEXTYPE INDEX_TYPES SHARDKEY SHARDKEYLIST TYPE_CALUSE
+    CREATE MEASUREMENT TABLE_CASE WITH INDEXTYPE INDEX_TYPES SHARDKEY SHARDKEYLIST TYPE_CALUSE CODEC_CLAUSE TTL_CLAUSE
     {
         stmt := &influxql.CreateMeasurementStatement{}
         stmt.Database = $3.Database
@@ -1992,9 +2056,11 @@ CREATE_MEASUREMENT_STATEMENT:
         stmt.ShardKey = $8
         sort.Strings(stmt.ShardKey)
         stmt.Type = $9
+        stmt.FloatCodec = $10
+        stmt.TTL = $11
         $$ = stmt
     }
-    |CREATE MEASUREMENT TABLE_CASE WITH SHARDKEY SHARDKEYLIST TYPE_CALUSE
+    |CREATE MEASUREMENT TABLE_CASE WITH SHARDKEY SHARDKEYLIST TYPE_CALUSE CODEC_CLAUSE TTL_CLAUSE
     {
         stmt := &influxql.CreateMeasurementStatement{}
         stmt.Database = $3.Database
@@ -2003,9 +2069,11 @@ CREATE_MEASUREMENT_STATEMENT:
         stmt.ShardKey = $6
         sort.Strings(stmt.ShardKey)
         stmt.Type = $7
+        stmt.FloatCodec = $8
+        stmt.TTL = $9
         $$ = stmt
     }
-    |CREATE MEASUREMENT TABLE_CASE WITH INDEXTYPE INDEX_TYPES
+    |CREATE MEASUREMENT TABLE_CASE WITH INDEXTYPE INDEX_TYPES CODEC_CLAUSE TTL_CLAUSE
     {
          stmt := &influxql.CreateMeasurementStatement{}
          stmt.Database = $3.Database
@@ -2015,8 +2083,31 @@ CREATE_MEASUREMENT_STATEMENT:
                stmt.IndexType = $6.types
                stmt.IndexList = $6.lists
           }
+          stmt.FloatCodec = $7
+          stmt.TTL = $8
           $$ = stmt
     }
+    |CREATE MEASUREMENT TABLE_CASE WITH CODEC IDENTIFIER TTL_CLAUSE
+    {
+        stmt := &influxql.CreateMeasurementStatement{}
+        stmt.Database = $3.Database
+        stmt.Name = $3.Name
+        stmt.RetentionPolicy = $3.RetentionPolicy
+        stmt.Type = "hash"
+        stmt.FloatCodec = $6
+        stmt.TTL = $7
+        $$ = stmt
+    }
+    |CREATE MEASUREMENT TABLE_CASE WITH TTL DURATIONVAL
+    {
+        stmt := &influxql.CreateMeasurementStatement{}
+        stmt.Database = $3.Database
+        stmt.Name = $3.Name
+        stmt.RetentionPolicy = $3.RetentionPolicy
+        stmt.Type = "hash"
+        stmt.TTL = $6
+        $$ = stmt
+    }
     |CREATE MEASUREMENT TABLE_CASE
     {
         stmt := &influxql.CreateMeasurementStatement{}
@@ -2027,6 +2118,163 @@ CREATE_MEASUREMENT_STATEMENT:
         $$ = stmt
     }
 
+SHOW_COMPACTIONS_STATEMENT:
+    SHOW COMPACTIONS
+    {
+        stmt := &influxql.ShowCompactionsStatement{}
+        $$ = stmt
+    }
+
+COMPACT_STATEMENT:
+    COMPACT SHARD INTEGER FULL_OPTION
+    {
+        stmt := &influxql.CompactStatement{}
+        stmt.ShardID = uint64($3)
+        stmt.Full = $4
+        $$ = stmt
+    }
+    |COMPACT MEASUREMENT TABLE_CASE FULL_OPTION
+    {
+        stmt := &influxql.CompactStatement{}
+        stmt.Database = $3.Database
+        stmt.RetentionPolicy = $3.RetentionPolicy
+        stmt.Name = $3.Name
+        stmt.Full = $4
+        $$ = stmt
+    }
+
+FULL_OPTION:
+    FULL
+    {
+        $$ = true
+    }
+    |
+    {
+        $$ = false
+    }
+
+CANCEL_COMPACTIONS_STATEMENT:
+    CANCEL COMPACTIONS
+    {
+        stmt := &influxql.CancelCompactionsStatement{}
+        $$ = stmt
+    }
+    |CANCEL COMPACTIONS SHARD INTEGER
+    {
+        stmt := &influxql.CancelCompactionsStatement{}
+        stmt.ShardID = uint64($4)
+        $$ = stmt
+    }
+    |CANCEL COMPACTIONS MEASUREMENT TABLE_CASE
+    {
+        stmt := &influxql.CancelCompactionsStatement{}
+        stmt.Database = $4.Database
+        stmt.RetentionPolicy = $4.RetentionPolicy
+        stmt.Name = $4.Name
+        $$ = stmt
+    }
+
+MIGRATE_PARTITION_STATEMENT:
+    MIGRATE PARTITION IDENTIFIER TO NODE INTEGER
+    {
+        // the scanner reads db.pt as one identifier
+        i := strings.LastIndexByte($3, '.')
+        var pt uint64
+        var err error
+        if i <= 0 {
+            yylex.Error("invalid partition " + $3 + ", expect db.pt")
+        } else if pt, err = strconv.ParseUint($3[i+1:], 10, 32); err != nil {
+            yylex.Error("invalid partition " + $3 + ", expect db.pt")
+        }
+        stmt := &influxql.MigratePartitionStatement{}
+        if i > 0 {
+            stmt.Database = $3[:i]
+        }
+        stmt.PtId = uint32(pt)
+        stmt.NodeID = uint64($6)
+        $$ = stmt
+    }
+
+DECOMMISSION_NODE_STATEMENT:
+    DECOMMISSION NODE INTEGER
+    {
+        stmt := &influxql.DecommissionNodeStatement{}
+        stmt.NodeID = uint64($3)
+        $$ = stmt
+    }
+
+SHOW_EVENTS_STATEMENT:
+    SHOW EVENTS
+    {
+        stmt := &influxql.ShowEventsStatement{}
+        $$ = stmt
+    }
+
+SHOW_DATA_NODES_STATEMENT:
+    SHOW IDENTIFIER NODES
+    {
+        // DATA is not a keyword, so that it is still a valid identifier
+        if strings.ToUpper($2) != "DATA" {
+            yylex.Error("expected DATA, found " + $2)
+        }
+        stmt := &influxql.ShowDataNodesStatement{}
+        $$ = stmt
+    }
+
+PAUSE_REPLICATION_STATEMENT:
+    PAUSE REPLICATION
+    {
+        stmt := &influxql.PauseReplicationStatement{}
+        $$ = stmt
+    }
+
+RESUME_REPLICATION_STATEMENT:
+    RESUME REPLICATION
+    {
+        stmt := &influxql.ResumeReplicationStatement{}
+        $$ = stmt
+    }
+
+SHOW_STATS_STATEMENT:
+    SHOW STATS ON_NODE
+    {
+        stmt := &influxql.ShowStatsStatement{}
+        stmt.NodeID = uint64($3)
+        $$ = stmt
+    }
+    |SHOW STATS FOR STRING ON_NODE
+    {
+        stmt := &influxql.ShowStatsStatement{}
+        stmt.Module = $4
+        stmt.NodeID = uint64($5)
+        $$ = stmt
+    }
+
+SHOW_DIAGNOSTICS_STATEMENT:
+    SHOW DIAGNOSTICS ON_NODE
+    {
+        stmt := &influxql.ShowDiagnosticsStatement{}
+        stmt.NodeID = uint64($3)
+        $$ = stmt
+    }
+    |SHOW DIAGNOSTICS FOR STRING ON_NODE
+    {
+        stmt := &influxql.ShowDiagnosticsStatement{}
+        stmt.Module = $4
+        stmt.NodeID = uint64($5)
+        $$ = stmt
+    }
+
+ON_NODE:
+    ON NODE INTEGER
+    {
+        $$ = $3
+    }
+    |
+    {
+        $$ = 0
+    }
+
 INDEX_TYPE:
     IDENT INDEXLIST INDEX_LIST
     {
@@ -2052,18 +2300,18 @@ INDEX_TYPES:
     }
 
 INDEX_LIST:
-    IDENT
+    IDENTIFIER
     {
         $$ = []string{$1}
     }
-    |IDENT COMMA INDEX_LIST
+    |IDENTIFIER COMMA INDEX_LIST
     {
 
         $$ = append([]string{$1}, $3...)
     }
 
 TYPE_CALUSE:
-    TYPE IDENT
+    TYPE IDENTIFIER
     {
         $$ = $2
     }
@@ -2072,6 +2320,26 @@ TYPE_CALUSE:
         $$ = "hash"
     }
 
+CODEC_CLAUSE:
+    CODEC IDENTIFIER
+    {
+        $$ = $2
+    }
+    |
+    {
+        $$ = ""
+    }
+
+TTL_CLAUSE:
+    TTL DURATIONVAL
+    {
+        $$ = $2
+    }
+    |
+    {
+        $$ = 0
+    }
+
 SHARDKEYLIST:
     SHARD_KEY
     {
@@ -2082,7 +2350,7 @@ SHARDKEYLIST:
         $$ = append($1,$3)
     }
 SHARD_KEY:
-    IDENT
+    IDENTIFIER
     {
         $$ = $1
     }
@@ -2096,7 +2364,7 @@ DROP_SHARD_STATEMENT:
     }
 
 SET_PASSWORD_USER_STATEMENT:
-    SET PASSWORD FOR IDENT EQ STRING
+    SET PASSWORD FOR IDENTIFIER EQ STRING
     {
         stmt := &influxql.SetPasswordUserStatement{}
         stmt.Name = $4
@@ -2107,7 +2375,7 @@ SET_PASSWORD_USER_STATEMENT:
 
 
 SHOW_GRANTS_FOR_USER_STATEMENT:
-    SHOW GRANTS FOR IDENT
+    SHOW GRANTS FOR IDENTIFIER
     {
         stmt := &influxql.ShowGrantsForUserStatement{}
         stmt.Name = $4
@@ -2242,6 +2510,17 @@ ALTER_SHARD_KEY_STATEMENT:
         $$ = stmt
     }
 
+ALTER_MEASUREMENT_TTL_STATEMENT:
+    ALTER MEASUREMENT TABLE_CASE WITH TTL DURATIONVAL
+    {
+        stmt := &influxql.AlterMeasurementTTLStatement{}
+        stmt.Database = $3.Database
+        stmt.Name = $3.Name
+        stmt.RetentionPolicy = $3.RetentionPolicy
+        stmt.TTL = $6
+        $$ = stmt
+    }
+
 
 
 
@@ -2253,7 +2532,7 @@ SHOW_SHARD_GROUPS_STATEMENT:
     }
 
 DROP_MEASUREMENT_STATEMENT:
-    DROP MEASUREMENT IDENT
+    DROP MEASUREMENT IDENTIFIER
     {
         stmt := &influxql.DropMeasurementStatement{}
         stmt.Name = $3
@@ -2262,4 +2541,82 @@ DROP_MEASUREMENT_STATEMENT:
 
 
 
+SHOW_SLOW_QUERIES_STATEMENT:
+    SHOW SLOW QUERIES
+    {
+        stmt := &influxql.ShowSlowQueriesStatement{}
+        $$ = stmt
+    }
+    |SHOW SLOW QUERIES LIMIT INTEGER
+    {
+        stmt := &influxql.ShowSlowQueriesStatement{}
+        stmt.Limit = int($5)
+        $$ = stmt
+    }
+
+IDENTIFIER:
+    IDENT
+    {
+        $$ = $1
+    }
+    |KEYWORD_AS_IDENT
+    {
+        $$ = $1
+    }
+
+// KEYWORD_AS_IDENT lists the keywords which are not reserved, they are still valid identifiers
+KEYWORD_AS_IDENT:
+    SLOW
+    {
+        $$ = $1
+    }
+    |CODEC
+    {
+        $$ = $1
+    }
+    |TTL
+    {
+        $$ = $1
+    }
+    |COMPACT
+    {
+        $$ = $1
+    }
+    |COMPACTIONS
+    {
+        $$ = $1
+    }
+    |CANCEL
+    {
+        $$ = $1
+    }
+    |MIGRATE
+    {
+        $$ = $1
+    }
+    |DECOMMISSION
+    {
+        $$ = $1
+    }
+    |NODE
+    {
+        $$ = $1
+    }
+    |EVENTS
+    {
+        $$ = $1
+    }
+    |PAUSE
+    {
+        $$ = $1
+    }
+    |RESUME
+    {
+        $$ = $1
+    }
+    |NODES
+    {
+        $$ = $1
+    }
+
 %%
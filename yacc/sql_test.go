@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/openGemini/openGemini/open_src/influx/influxql"
 	"github.com/openGemini/openGemini/yacc"
@@ -131,6 +132,12 @@ func init() {
 		"create measurement cpu with indextype text indexlist msg text1 indexlist msg1,msg2",
 		"create measurement TSDB_SIT_AlterMeasurement_BaseFunction_002 with shardkey tag1,tag2",
 		"create user xxxxx with password 'xxxx' with partition privileges", // add partition privileges.
+		"SHOW SLOW QUERIES",          // add show slow queries
+		"SHOW SLOW QUERIES LIMIT 10", // add show slow queries with limit
+		"create measurement cpu with shardkey hostname codec chimp", // add float codec
+		"create measurement cpu with codec alp",                     // add float codec
+		"create measurement cpu with ttl 1d",                        // add measurement ttl
+		"alter measurement cpu with ttl 12h",                        // add measurement ttl
 	}
 
 	benchCases = []string{
@@ -273,6 +280,79 @@ func TestParserResult(t *testing.T) {
 	}
 }
 
+func TestCreateMeasurementCodec(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for c, exp := range map[string]string{
+		"create measurement cpu with codec alp":                                      "alp",
+		"create measurement cpu with shardkey hostname type range codec chimp":       "chimp",
+		"create measurement cpu with indextype text indexlist msg codec gorilla":     "gorilla",
+		"create measurement cpu with indextype text indexlist msg shardkey hostname": "",
+	} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		stmt, ok := q.Statements[0].(*influxql.CreateMeasurementStatement)
+		if !ok {
+			t.Fatalf("unexpected statement %T of %s", q.Statements[0], c)
+		}
+		if stmt.FloatCodec != exp {
+			t.Fatalf("unexpected codec of %s, exp: %s, got: %s", c, exp, stmt.FloatCodec)
+		}
+	}
+}
+
+func TestMeasurementTTL(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for c, exp := range map[string]time.Duration{
+		"create measurement cpu with ttl 1d":                                            24 * time.Hour,
+		"create measurement cpu with codec alp ttl 2h":                                  2 * time.Hour,
+		"create measurement cpu with shardkey hostname type range ttl 30m":              30 * time.Minute,
+		"create measurement cpu with indextype text indexlist msg codec gorilla ttl 1w": 7 * 24 * time.Hour,
+		"create measurement cpu with shardkey hostname":                                 0,
+	} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		stmt, ok := q.Statements[0].(*influxql.CreateMeasurementStatement)
+		if !ok {
+			t.Fatalf("unexpected statement %T of %s", q.Statements[0], c)
+		}
+		if stmt.TTL != exp {
+			t.Fatalf("unexpected ttl of %s, exp: %v, got: %v", c, exp, stmt.TTL)
+		}
+	}
+
+	YyParser.Query = influxql.Query{}
+	YyParser.Scanner = influxql.NewScanner(strings.NewReader("alter measurement db0.rp0.cpu with ttl 0s"))
+	YyParser.ParseTokens()
+	q, err := YyParser.GetQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt, ok := q.Statements[0].(*influxql.AlterMeasurementTTLStatement)
+	if !ok {
+		t.Fatalf("unexpected statement %T", q.Statements[0])
+	}
+	if stmt.Database != "db0" || stmt.RetentionPolicy != "rp0" || stmt.Name != "cpu" || stmt.TTL != 0 {
+		t.Fatalf("unexpected statement %+v", stmt)
+	}
+	if got := stmt.String(); got != "ALTER MEASUREMENT db0.rp0.cpu WITH TTL 0s" {
+		t.Fatalf("unexpected string of statement: %s", got)
+	}
+}
+
 func TestSingleParser(t *testing.T) {
 	YyParser := &yacc.YyParser{
 		Query: influxql.Query{},
@@ -324,3 +404,222 @@ func BenchmarkPreviousParser(b *testing.B) {
 		}
 	}
 }
+
+func TestCompactionStatements(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for c, exp := range map[string]string{
+		"show compactions":                        "SHOW COMPACTIONS",
+		"compact shard 12":                        "COMPACT SHARD 12",
+		"compact shard 12 full":                   "COMPACT SHARD 12 FULL",
+		"compact measurement cpu":                 "COMPACT MEASUREMENT cpu",
+		"compact measurement db0.rp0.cpu full":    "COMPACT MEASUREMENT db0.rp0.cpu FULL",
+		"cancel compactions":                      "CANCEL COMPACTIONS",
+		"cancel compactions shard 12":             "CANCEL COMPACTIONS SHARD 12",
+		"cancel compactions measurement db0..cpu": "CANCEL COMPACTIONS MEASUREMENT db0.cpu",
+	} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		if got := q.Statements[0].String(); got != exp {
+			t.Fatalf("unexpected statement of %s, exp: %s, got: %s", c, exp, got)
+		}
+	}
+
+	YyParser.Query = influxql.Query{}
+	YyParser.Scanner = influxql.NewScanner(strings.NewReader("compact measurement db0.rp0.cpu full"))
+	YyParser.ParseTokens()
+	q, err := YyParser.GetQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmt, ok := q.Statements[0].(*influxql.CompactStatement)
+	if !ok {
+		t.Fatalf("unexpected statement %T", q.Statements[0])
+	}
+	if stmt.Database != "db0" || stmt.RetentionPolicy != "rp0" || stmt.Name != "cpu" || !stmt.Full || stmt.ShardID != 0 {
+		t.Fatalf("unexpected statement %+v", stmt)
+	}
+}
+
+func TestMigrationStatements(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for c, exp := range map[string]string{
+		"migrate partition db0.3 to node 4":  "MIGRATE PARTITION db0.3 TO NODE 4",
+		"migrate partition db0.10 to node 4": "MIGRATE PARTITION db0.10 TO NODE 4",
+		"decommission node 4":                "DECOMMISSION NODE 4",
+		"show events":                        "SHOW EVENTS",
+	} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		if got := q.Statements[0].String(); got != exp {
+			t.Fatalf("unexpected statement of %s, exp: %s, got: %s", c, exp, got)
+		}
+	}
+
+	YyParser.Query = influxql.Query{}
+	YyParser.Scanner = influxql.NewScanner(strings.NewReader("migrate partition db0 to node 4"))
+	YyParser.ParseTokens()
+	if _, err := YyParser.GetQuery(); err == nil {
+		t.Fatal("expect an error for the invalid partition")
+	}
+}
+
+func TestShowStatsStatements(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for c, exp := range map[string]string{
+		"show stats":                             "SHOW STATS",
+		"show stats for 'engine'":                "SHOW STATS FOR 'engine'",
+		"show stats on node 4":                   "SHOW STATS ON NODE 4",
+		"show stats for 'runtime' on node 4":     "SHOW STATS FOR 'runtime' ON NODE 4",
+		"show diagnostics":                       "SHOW DIAGNOSTICS",
+		"show diagnostics for 'build' on node 2": "SHOW DIAGNOSTICS FOR 'build' ON NODE 2",
+	} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		if got := q.Statements[0].String(); got != exp {
+			t.Fatalf("unexpected statement of %s, exp: %s, got: %s", c, exp, got)
+		}
+	}
+}
+
+func TestReplicationStatements(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for c, exp := range map[string]string{
+		"pause replication":  "PAUSE REPLICATION",
+		"RESUME REPLICATION": "RESUME REPLICATION",
+	} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		if got := q.Statements[0].String(); got != exp {
+			t.Fatalf("unexpected statement of %s, exp: %s, got: %s", c, exp, got)
+		}
+	}
+}
+
+func TestShowDataNodesStatement(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for _, c := range []string{"show data nodes", "SHOW DATA NODES"} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		if _, ok := q.Statements[0].(*influxql.ShowDataNodesStatement); !ok {
+			t.Fatalf("unexpected statement of %s: %T", c, q.Statements[0])
+		}
+	}
+
+	YyParser.Query = influxql.Query{}
+	YyParser.Scanner = influxql.NewScanner(strings.NewReader("SHOW META NODES"))
+	YyParser.ParseTokens()
+	if _, err := YyParser.GetQuery(); err == nil {
+		t.Fatal("expect error of SHOW META NODES")
+	}
+
+	// data is still a valid identifier
+	YyParser = &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	YyParser.Scanner = influxql.NewScanner(strings.NewReader("SELECT value FROM data"))
+	YyParser.ParseTokens()
+	if _, err := YyParser.GetQuery(); err != nil {
+		t.Fatalf("parse select from data failed: %v", err)
+	}
+}
+
+func TestKeywordsAsIdentifiers(t *testing.T) {
+	YyParser := &yacc.YyParser{
+		Query: influxql.Query{},
+	}
+	for c, exp := range map[string]string{
+		// field, tag and measurement names which were valid before the keywords were added
+		"SELECT slow FROM cpu":                                      "SELECT slow FROM cpu",
+		"SELECT value FROM cpu WHERE slow = 'a'":                    "SELECT value FROM cpu WHERE slow = 'a'",
+		"SELECT mean(value) FROM cpu GROUP BY slow":                 "SELECT mean(value) FROM cpu GROUP BY slow",
+		"SELECT * FROM slow":                                        "SELECT * FROM slow",
+		"SHOW SLOW QUERIES":                                         "SHOW SLOW QUERIES",
+		"SELECT codec FROM cpu":                                     "SELECT codec FROM cpu",
+		"SELECT value FROM cpu WHERE codec = 'a'":                   "SELECT value FROM cpu WHERE codec = 'a'",
+		"SELECT mean(value) FROM cpu GROUP BY codec":                "SELECT mean(value) FROM cpu GROUP BY codec",
+		"SELECT * FROM codec":                                       "SELECT * FROM codec",
+		"SELECT ttl FROM cpu":                                       "SELECT ttl FROM cpu",
+		"SELECT value FROM cpu WHERE ttl = 'a'":                     "SELECT value FROM cpu WHERE ttl = 'a'",
+		"SELECT mean(value) FROM cpu GROUP BY ttl":                  "SELECT mean(value) FROM cpu GROUP BY ttl",
+		"SELECT * FROM ttl":                                         "SELECT * FROM ttl",
+		"ALTER MEASUREMENT ttl WITH TTL 1h":                         "ALTER MEASUREMENT ttl WITH TTL 1h",
+		"SELECT compact, compactions, cancel FROM cpu":              "SELECT compact, compactions, cancel FROM cpu",
+		"SELECT value FROM cpu WHERE cancel = 'a'":                  "SELECT value FROM cpu WHERE cancel = 'a'",
+		"SELECT mean(value) FROM cpu GROUP BY compact, compactions": "SELECT mean(value) FROM cpu GROUP BY compact, compactions",
+		"SELECT * FROM compactions":                                 "SELECT * FROM compactions",
+		"SHOW COMPACTIONS":                                          "SHOW COMPACTIONS",
+		"COMPACT MEASUREMENT compact FULL":                          "COMPACT MEASUREMENT compact FULL",
+		"CANCEL COMPACTIONS MEASUREMENT cancel":                     "CANCEL COMPACTIONS MEASUREMENT cancel",
+		"SELECT value FROM cpu WHERE node = 'a'":                    "SELECT value FROM cpu WHERE node = 'a'",
+		"SELECT node, migrate, decommission, events FROM cpu":       "SELECT node, migrate, decommission, events FROM cpu",
+		"SELECT mean(value) FROM cpu GROUP BY node":                 "SELECT mean(value) FROM cpu GROUP BY node",
+		"SELECT * FROM events":                                      "SELECT * FROM events",
+		"SHOW EVENTS":                                               "SHOW EVENTS",
+		"DECOMMISSION NODE 2":                                       "DECOMMISSION NODE 2",
+		"MIGRATE PARTITION events.1 TO NODE 2":                      "MIGRATE PARTITION events.1 TO NODE 2",
+		"SHOW STATS ON NODE 2":                                      "SHOW STATS ON NODE 2",
+		"SELECT pause, resume FROM cpu":                             "SELECT pause, resume FROM cpu",
+		"SELECT value FROM cpu WHERE pause = 'a' GROUP BY resume":   "SELECT value FROM cpu WHERE pause = 'a' GROUP BY resume",
+		"PAUSE REPLICATION":                                         "PAUSE REPLICATION",
+		"RESUME REPLICATION":                                        "RESUME REPLICATION",
+		"SELECT nodes FROM cpu GROUP BY nodes":                      "SELECT nodes FROM cpu GROUP BY nodes",
+		"SELECT * FROM nodes WHERE nodes = 'a'":                     "SELECT * FROM nodes WHERE nodes = 'a'",
+		"SHOW DATA NODES":                                           "SHOW DATA NODES",
+	} {
+		YyParser.Query = influxql.Query{}
+		YyParser.Scanner = influxql.NewScanner(strings.NewReader(c))
+		YyParser.ParseTokens()
+		q, err := YyParser.GetQuery()
+		if err != nil {
+			t.Fatalf("parse %s failed: %v", c, err)
+		}
+		if got := q.Statements[0].String(); got != exp {
+			t.Fatalf("unexpected statement of %s, exp: %s, got: %s", c, exp, got)
+		}
+		if strings.HasPrefix(exp, "SELECT") {
+			// the statements are also parsed again by the previous parser, e.g. by the store nodes
+			q, err := influxql.NewParser(strings.NewReader(exp)).ParseQuery()
+			if err != nil {
+				t.Fatalf("previous parser failed to parse %s: %v", exp, err)
+			}
+			if got := q.Statements[0].String(); got != exp {
+				t.Fatalf("unexpected statement of %s by previous parser, got: %s", exp, got)
+			}
+		}
+	}
+}
@@ -1,4 +1,4 @@
-// Code generated by goyacc sql.y. DO NOT EDIT.
+// Code generated by goyacc -o y.go sql.y. DO NOT EDIT.
 
 //line sql.y:2
 /*
@@ -19,10 +19,14 @@ limitations under the License.
 
 package yacc
 
+import __yyfmt__ "fmt"
+
+//line sql.y:18
+
 import (
-	__yyfmt__ "fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 	"unsafe"
@@ -61,7 +65,7 @@ func deal_Fill(fill interface{}) (influxql.FillOption, interface{}, bool) {
 	}
 }
 
-//line sql.y:64
+//line sql.y:65
 type yySymType struct {
 	yys              int
 	stmt             influxql.Statement
@@ -212,6 +216,19 @@ const DIV = 57460
 const MOD = 57461
 const BITWISE_AND = 57462
 const UMINUS = 57463
+const SLOW = 57464
+const CODEC = 57465
+const TTL = 57466
+const COMPACT = 57467
+const COMPACTIONS = 57468
+const CANCEL = 57469
+const MIGRATE = 57470
+const DECOMMISSION = 57471
+const NODE = 57472
+const EVENTS = 57473
+const PAUSE = 57474
+const RESUME = 57475
+const NODES = 57476
 
 var yyToknames = [...]string{
 	"$end",
@@ -335,6 +352,19 @@ var yyToknames = [...]string{
 	"MOD",
 	"BITWISE_AND",
 	"UMINUS",
+	"SLOW",
+	"CODEC",
+	"TTL",
+	"COMPACT",
+	"COMPACTIONS",
+	"CANCEL",
+	"MIGRATE",
+	"DECOMMISSION",
+	"NODE",
+	"EVENTS",
+	"PAUSE",
+	"RESUME",
+	"NODES",
 }
 
 var yyStatenames = [...]string{}
@@ -343,394 +373,510 @@ const yyEofCode = 1
 const yyErrCode = 2
 const yyInitialStackSize = 16
 
-//line sql.y:2265
+//line sql.y:2622
 
 //line yacctab:1
-var yyExca = [...]int{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 333,
-	95, 125,
-	96, 125,
-	97, 125,
-	98, 125,
-	99, 125,
-	100, 125,
-	103, 125,
-	104, 125,
-	-2, 114,
+	-1, 110,
+	134, 325,
+	-2, 266,
+	-1, 111,
+	134, 330,
+	-2, 276,
+	-1, 409,
+	95, 138,
+	96, 138,
+	97, 138,
+	98, 138,
+	99, 138,
+	100, 138,
+	103, 138,
+	104, 138,
+	-2, 127,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 779
-
-var yyAct = [...]int{
-	364, 306, 632, 251, 281, 565, 465, 537, 572, 499,
-	363, 273, 417, 454, 399, 4, 435, 146, 398, 407,
-	304, 168, 239, 2, 197, 351, 624, 51, 157, 109,
-	110, 114, 115, 91, 61, 162, 67, 125, 169, 65,
-	66, 121, 68, 432, 111, 112, 116, 113, 109, 110,
-	114, 115, 61, 438, 625, 509, 441, 65, 66, 333,
-	406, 626, 279, 637, 101, 439, 243, 244, 56, 199,
-	68, 577, 561, 243, 244, 90, 492, 243, 244, 55,
-	105, 57, 63, 60, 64, 62, 56, 491, 68, 187,
-	58, 55, 188, 54, 490, 117, 161, 120, 68, 57,
-	63, 60, 64, 62, 52, 145, 489, 394, 58, 144,
-	128, 54, 147, 243, 244, 621, 180, 516, 517, 151,
-	148, 518, 610, 582, 156, 68, 68, 104, 526, 148,
-	108, 525, 148, 322, 184, 355, 413, 321, 182, 147,
-	147, 453, 202, 203, 40, 55, 198, 452, 189, 190,
-	191, 192, 193, 194, 195, 196, 397, 55, 395, 209,
-	207, 150, 213, 154, 205, 206, 359, 360, 215, 216,
-	217, 98, 222, 61, 362, 361, 227, 634, 65, 66,
-	96, 183, 201, 566, 235, 242, 111, 112, 116, 113,
-	109, 110, 114, 115, 68, 539, 573, 468, 124, 605,
-	246, 145, 456, 245, 212, 144, 419, 56, 147, 68,
-	567, 400, 513, 512, 502, 409, 446, 275, 285, 445,
-	57, 63, 60, 64, 62, 431, 522, 298, 429, 58,
-	428, 426, 54, 424, 111, 112, 116, 113, 109, 110,
-	114, 115, 419, 277, 122, 99, 284, 415, 405, 288,
-	290, 323, 286, 396, 97, 356, 348, 294, 347, 296,
-	344, 303, 300, 328, 301, 336, 326, 327, 466, 467,
-	148, 343, 283, 338, 148, 148, 470, 469, 68, 331,
-	332, 272, 271, 270, 267, 266, 369, 354, 265, 368,
-	262, 287, 289, 291, 260, 375, 520, 236, 297, 385,
-	373, 234, 384, 302, 111, 112, 116, 113, 109, 110,
-	114, 115, 230, 247, 248, 392, 225, 210, 357, 155,
-	371, 372, 153, 374, 149, 142, 393, 140, 118, 107,
-	383, 378, 411, 381, 388, 390, 391, 386, 119, 410,
-	412, 324, 414, 252, 253, 254, 255, 256, 257, 350,
-	418, 259, 258, 422, 233, 160, 433, 642, 636, 148,
-	425, 148, 370, 608, 635, 50, 607, 330, 614, 457,
-	379, 574, 382, 571, 461, 423, 387, 389, 245, 462,
-	442, 118, 606, 459, 460, 479, 463, 570, 508, 504,
-	440, 119, 444, 487, 503, 478, 421, 276, 447, 448,
-	483, 541, 485, 486, 458, 521, 434, 420, 337, 334,
-	249, 500, 50, 604, 587, 476, 477, 529, 530, 496,
-	481, 482, 528, 484, 519, 505, 488, 238, 497, 501,
-	237, 106, 498, 507, 562, 511, 506, 139, 126, 143,
-	341, 589, 320, 299, 514, 510, 295, 523, 148, 524,
-	488, 171, 319, 126, 471, 223, 224, 475, 220, 221,
-	532, 533, 480, 137, 138, 293, 226, 214, 546, 545,
-	531, 535, 578, 534, 40, 540, 134, 61, 135, 551,
-	474, 547, 65, 66, 555, 464, 557, 558, 549, 550,
-	563, 377, 576, 553, 554, 278, 556, 443, 204, 536,
-	131, 132, 133, 559, 218, 219, 542, 543, 124, 548,
-	600, 56, 181, 68, 552, 564, 185, 186, 569, 129,
-	130, 136, 3, 560, 57, 63, 60, 64, 62, 494,
-	404, 89, 584, 58, 579, 580, 575, 403, 100, 402,
-	401, 586, 583, 313, 316, 170, 314, 315, 152, 588,
-	594, 595, 590, 591, 597, 598, 440, 599, 141, 127,
-	593, 318, 87, 95, 596, 84, 102, 86, 544, 585,
-	93, 83, 88, 103, 603, 167, 166, 92, 495, 473,
-	92, 592, 85, 376, 261, 92, 232, 612, 609, 231,
-	611, 229, 208, 352, 619, 613, 615, 620, 250, 94,
-	263, 172, 82, 472, 618, 80, 380, 81, 61, 623,
-	622, 292, 627, 65, 66, 173, 335, 264, 174, 631,
-	427, 345, 342, 633, 616, 617, 416, 61, 629, 630,
-	639, 640, 65, 66, 329, 633, 641, 602, 601, 643,
-	638, 241, 164, 581, 68, 527, 178, 367, 176, 628,
-	450, 451, 77, 365, 366, 165, 63, 60, 64, 62,
-	92, 339, 177, 68, 58, 282, 353, 274, 282, 92,
-	93, 40, 568, 93, 57, 63, 60, 64, 62, 269,
-	126, 268, 340, 58, 73, 69, 325, 70, 71, 317,
-	211, 179, 175, 79, 309, 310, 280, 430, 349, 346,
-	92, 76, 408, 72, 437, 307, 311, 313, 316, 40,
-	314, 315, 74, 75, 538, 305, 308, 515, 449, 41,
-	42, 436, 455, 200, 123, 59, 78, 163, 358, 47,
-	158, 44, 240, 159, 1, 312, 53, 45, 39, 38,
-	37, 36, 35, 34, 33, 32, 31, 30, 29, 28,
-	46, 27, 26, 25, 49, 24, 23, 20, 19, 43,
-	21, 18, 22, 17, 16, 15, 13, 14, 12, 11,
-	493, 7, 48, 10, 9, 8, 228, 6, 5,
+const yyLast = 1466
+
+var yyAct = [...]int16{
+	76, 315, 380, 579, 442, 724, 585, 653, 623, 662,
+	517, 536, 583, 497, 441, 485, 429, 477, 303, 547,
+	222, 337, 2, 168, 476, 184, 378, 183, 211, 70,
+	214, 428, 216, 286, 200, 147, 215, 145, 102, 586,
+	580, 716, 351, 513, 88, 199, 417, 4, 394, 81,
+	578, 349, 201, 484, 343, 112, 717, 730, 393, 433,
+	137, 139, 287, 718, 285, 81, 89, 90, 91, 92,
+	93, 94, 95, 96, 97, 98, 99, 100, 101, 307,
+	308, 169, 89, 90, 91, 92, 93, 94, 95, 96,
+	97, 98, 99, 100, 101, 131, 418, 395, 396, 580,
+	595, 152, 307, 308, 155, 156, 157, 161, 162, 141,
+	158, 159, 163, 160, 156, 157, 161, 162, 307, 308,
+	409, 194, 667, 196, 198, 203, 657, 647, 207, 574,
+	209, 573, 572, 198, 219, 571, 198, 257, 307, 308,
+	187, 506, 490, 472, 712, 198, 701, 240, 672, 612,
+	608, 611, 535, 534, 197, 514, 247, 158, 159, 163,
+	160, 156, 157, 161, 162, 475, 225, 261, 202, 473,
+	377, 372, 219, 340, 270, 237, 241, 202, 550, 236,
+	202, 208, 487, 244, 606, 154, 491, 400, 234, 202,
+	288, 297, 427, 265, 266, 258, 294, 102, 172, 735,
+	298, 311, 312, 69, 267, 406, 165, 300, 158, 159,
+	163, 160, 156, 157, 161, 162, 166, 699, 729, 219,
+	698, 205, 728, 705, 664, 324, 210, 326, 661, 329,
+	330, 331, 53, 334, 335, 660, 336, 594, 306, 590,
+	198, 589, 54, 55, 501, 346, 69, 242, 697, 548,
+	549, 310, 60, 627, 57, 437, 438, 552, 551, 339,
+	58, 607, 516, 440, 439, 500, 413, 410, 269, 165,
+	341, 273, 353, 59, 313, 584, 694, 62, 677, 166,
+	615, 616, 56, 614, 202, 158, 159, 163, 160, 156,
+	157, 161, 162, 605, 591, 61, 570, 302, 397, 301,
+	299, 153, 198, 198, 146, 175, 648, 582, 219, 219,
+	192, 371, 193, 373, 570, 173, 355, 283, 284, 280,
+	281, 369, 173, 404, 365, 368, 190, 191, 420, 421,
+	402, 403, 399, 424, 425, 363, 414, 289, 274, 345,
+	407, 408, 202, 53, 3, 679, 202, 202, 632, 434,
+	63, 631, 64, 65, 66, 556, 546, 67, 68, 455,
+	447, 412, 649, 178, 179, 180, 668, 181, 354, 182,
+	666, 358, 360, 463, 525, 348, 275, 276, 277, 264,
+	282, 278, 279, 171, 474, 690, 376, 478, 176, 177,
+	290, 235, 483, 245, 246, 478, 489, 446, 149, 493,
+	432, 493, 495, 453, 148, 189, 471, 140, 646, 239,
+	462, 499, 186, 451, 150, 576, 219, 504, 478, 488,
+	507, 482, 186, 509, 510, 387, 390, 512, 388, 389,
+	481, 470, 520, 492, 480, 494, 144, 479, 527, 528,
+	224, 206, 505, 202, 195, 202, 538, 174, 503, 524,
+	238, 539, 449, 450, 135, 452, 543, 392, 498, 188,
+	123, 502, 461, 132, 142, 133, 466, 561, 545, 185,
+	468, 469, 515, 356, 132, 569, 522, 143, 364, 430,
+	366, 130, 132, 370, 529, 530, 630, 268, 374, 554,
+	134, 122, 493, 577, 120, 544, 121, 588, 555, 454,
+	458, 499, 581, 325, 541, 542, 296, 295, 362, 598,
+	293, 314, 599, 411, 593, 508, 560, 602, 597, 422,
+	226, 565, 327, 567, 568, 419, 587, 342, 496, 405,
+	600, 596, 526, 692, 227, 691, 202, 228, 232, 328,
+	230, 305, 338, 671, 540, 613, 618, 619, 592, 625,
+	625, 291, 151, 352, 231, 558, 559, 445, 626, 620,
+	563, 564, 456, 566, 459, 637, 621, 609, 464, 610,
+	641, 478, 643, 644, 532, 533, 633, 443, 444, 478,
+	431, 652, 132, 654, 344, 656, 651, 655, 645, 132,
+	352, 133, 499, 133, 53, 617, 628, 629, 333, 658,
+	332, 663, 173, 650, 416, 659, 520, 204, 401, 391,
+	186, 271, 233, 635, 636, 538, 665, 229, 639, 640,
+	350, 642, 674, 511, 669, 670, 673, 426, 625, 423,
+	132, 486, 82, 519, 243, 624, 678, 379, 601, 531,
+	684, 685, 518, 622, 687, 688, 537, 689, 260, 129,
+	522, 680, 681, 634, 170, 78, 217, 272, 638, 696,
+	383, 384, 695, 693, 436, 676, 212, 223, 304, 213,
+	663, 381, 385, 387, 390, 1, 388, 389, 625, 700,
+	127, 703, 382, 124, 72, 126, 704, 45, 710, 683,
+	128, 711, 47, 686, 46, 654, 49, 713, 715, 706,
+	125, 386, 714, 48, 44, 43, 42, 719, 41, 40,
+	39, 221, 220, 38, 723, 725, 727, 675, 702, 52,
+	726, 74, 51, 50, 37, 36, 732, 733, 725, 682,
+	35, 734, 34, 33, 32, 31, 736, 709, 30, 74,
+	29, 28, 27, 164, 80, 167, 26, 25, 24, 86,
+	87, 23, 20, 19, 21, 18, 22, 357, 359, 361,
+	17, 16, 721, 722, 367, 316, 317, 318, 319, 320,
+	321, 15, 375, 323, 322, 731, 707, 708, 218, 13,
+	102, 14, 12, 11, 575, 7, 10, 9, 8, 292,
+	6, 81, 84, 79, 85, 83, 5, 0, 0, 0,
+	77, 0, 720, 0, 0, 0, 0, 0, 89, 90,
+	91, 92, 93, 94, 95, 96, 97, 98, 99, 100,
+	101, 74, 0, 0, 249, 250, 251, 252, 253, 254,
+	255, 256, 0, 74, 116, 0, 0, 0, 448, 0,
+	0, 0, 0, 0, 0, 0, 457, 0, 460, 0,
+	0, 0, 465, 0, 0, 0, 467, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 107, 103, 0, 104,
+	105, 0, 0, 0, 0, 118, 0, 0, 0, 0,
+	0, 0, 0, 115, 0, 106, 309, 102, 0, 0,
+	0, 0, 0, 0, 108, 109, 0, 0, 81, 0,
+	0, 523, 0, 0, 0, 114, 0, 0, 117, 113,
+	521, 0, 0, 0, 0, 89, 90, 91, 92, 93,
+	94, 95, 96, 97, 98, 99, 100, 101, 0, 0,
+	80, 0, 347, 0, 81, 86, 87, 0, 553, 0,
+	0, 557, 0, 0, 0, 0, 562, 0, 0, 0,
+	0, 119, 90, 91, 92, 110, 94, 95, 96, 97,
+	111, 99, 100, 101, 75, 259, 102, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 81, 84, 79,
+	85, 83, 80, 0, 0, 0, 77, 86, 87, 73,
+	0, 0, 0, 0, 89, 90, 91, 92, 93, 94,
+	95, 96, 97, 98, 99, 100, 101, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 75, 435, 102, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 81,
+	84, 79, 85, 83, 71, 0, 0, 80, 77, 0,
+	0, 73, 86, 87, 0, 0, 89, 90, 91, 92,
+	93, 94, 95, 96, 97, 98, 99, 100, 101, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 75, 0, 102, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 309, 81, 84, 79, 85, 83, 80,
+	0, 0, 0, 77, 86, 87, 73, 0, 0, 0,
+	0, 89, 90, 91, 92, 93, 94, 95, 96, 97,
+	98, 99, 100, 101, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 75, 0, 102, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 81, 84, 79, 85,
+	83, 80, 0, 0, 0, 77, 86, 87, 0, 0,
+	0, 0, 0, 89, 90, 91, 92, 93, 94, 95,
+	96, 97, 98, 99, 100, 101, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 415, 0, 102, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 81, 84,
+	79, 85, 83, 0, 0, 0, 0, 77, 0, 0,
+	0, 0, 0, 0, 0, 89, 90, 91, 92, 93,
+	94, 95, 96, 97, 98, 99, 100, 101, 102, 0,
+	0, 0, 0, 0, 0, 398, 0, 0, 0, 81,
+	0, 102, 201, 53, 0, 0, 0, 0, 199, 0,
+	0, 0, 81, 0, 0, 201, 89, 90, 91, 92,
+	93, 94, 95, 96, 97, 98, 99, 100, 101, 89,
+	90, 91, 92, 93, 94, 95, 96, 97, 98, 99,
+	100, 101, 81, 603, 102, 0, 604, 262, 263, 0,
+	0, 0, 0, 0, 0, 81, 0, 0, 201, 89,
+	90, 91, 92, 93, 94, 95, 96, 97, 98, 99,
+	100, 101, 89, 90, 91, 92, 93, 94, 95, 96,
+	97, 98, 99, 100, 101, 0, 0, 81, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 171, 0,
+	138, 81, 0, 0, 89, 90, 91, 92, 93, 94,
+	95, 96, 97, 98, 99, 100, 101, 0, 89, 90,
+	91, 92, 93, 94, 95, 96, 97, 98, 99, 100,
+	101, 81, 136, 0, 248, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 81, 0, 0, 0, 89, 90,
+	91, 92, 93, 94, 95, 96, 97, 98, 99, 100,
+	101, 89, 90, 91, 92, 93, 94, 95, 96, 97,
+	98, 99, 100, 101, 81, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 89, 90, 91, 92, 93, 94, 95, 96, 97,
+	98, 99, 100, 101, 0, 0, 81, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 89, 90, 91, 92, 93, 94, 95,
+	96, 97, 98, 99, 100, 101,
 }
 
-var yyPact = [...]int{
-	702, -1000, 321, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-6, 647, 566, 526, 665, 558, 149, 140, 467, 534,
-	702, 696, 115, 341, 227, 121, 419, 236, 419, -1000,
-	-1000, 139, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	674, 517, 447, -1000, 433, 409, 468, 391, -1000, 354,
-	222, 515, 220, 100, 219, 665, 505, 217, 57, 214,
-	662, -1000, 4, 550, 502, 100, 595, 686, 642, 685,
-	664, -1000, 459, -1000, 662, 696, 115, 451, -16, 419,
-	419, 419, 419, 419, 419, 419, 419, -69, -24, 77,
-	-1000, 437, 449, 449, 550, 562, 212, 684, 665, 394,
-	674, 674, 432, 386, 674, 383, 211, 393, 674, -1000,
-	561, 207, 559, 556, 253, 196, -1000, -1000, -1000, -1000,
-	662, -1000, 192, -1000, -1000, -1000, -1000, -1000, 340, 337,
-	622, 702, -45, -1000, 550, 289, 318, 572, 248, 191,
-	189, 554, 185, 594, 183, 180, 179, 675, 178, 177,
-	-1000, 176, 657, 662, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -88, -88, -88, -1000, -1000, -88, -1000, 304, -1000,
-	-1000, -1000, -1000, -1000, 419, 434, -1000, 2, 691, 653,
-	-1000, 167, 662, 653, 674, 665, 665, 581, 392, 674,
-	373, 674, 656, 370, 674, -1000, 674, 665, -1000, 661,
-	683, 529, 368, 32, 240, -1000, 680, 4, 4, -1000,
-	622, 613, 274, 550, 550, -69, -34, 317, 592, 664,
-	316, 569, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	676, 366, 599, 166, 155, -1000, 598, 695, 153, 151,
-	-1000, 694, 254, 565, 655, 657, -1000, 73, 150, 419,
-	71, 640, 636, -1000, 653, 640, 665, 662, 657, 662,
-	653, 553, 422, 674, 576, 674, 665, 653, 640, 674,
-	665, 665, 662, 657, -1000, 661, -1000, 0, 52, 148,
-	50, -1000, 106, 496, 495, 493, 486, 143, -48, 110,
-	106, 231, 31, -1000, 31, 142, -1000, -1000, -1000, 604,
-	-1000, -1000, -1000, -1000, 137, 315, 303, 664, -1000, 550,
-	128, 106, 126, 597, -1000, 125, 123, 693, -1000, 120,
-	-65, 653, 314, -52, 565, -1000, 435, 191, 662, 114,
-	111, 184, 184, -1000, 635, 41, 35, 97, 640, -1000,
-	662, 657, 657, 640, 653, 640, 416, 173, 573, 549,
-	411, 665, 662, 657, 640, -1000, 665, 662, 657, 662,
-	657, 657, 640, -1000, -1000, -1000, -1000, -1000, 336, -1000,
-	-1000, -1, -13, -20, -31, 485, 548, 345, 110, 347,
-	360, 31, -1000, -1000, -1000, -1000, 109, 301, 296, 335,
-	137, -1000, 295, -38, 661, 360, -1000, 108, -1000, -1000,
-	107, -1000, -1000, 640, 12, -1000, 334, 194, 313, 124,
-	-1000, -1000, 653, -1000, 653, -1000, -1000, -1000, -1000, -1000,
-	25, 22, 631, -1000, -1000, 332, 329, -1000, 657, 640,
-	640, -1000, 640, -1000, 173, 662, 90, 90, 309, 184,
-	184, 538, 400, 399, 173, 662, 657, 657, 640, -1000,
-	662, 657, 657, 640, 657, 640, 640, -1000, 106, -1000,
-	-1000, -1000, -1000, 478, -35, 403, 106, -1000, 78, -1000,
-	105, -1000, 666, -1000, -1000, 101, 294, 280, -1000, -1000,
-	-1000, -1000, -1000, -1000, 91, 278, -1000, -1000, -1000, -52,
-	427, -36, 407, 640, 640, 627, -1000, 17, 97, -1000,
-	-1000, 640, -1000, -1000, -1000, 662, 653, -1000, 324, -1000,
-	-1000, 90, -1000, -1000, 372, 173, 173, 662, 657, 640,
-	640, -1000, 657, 640, 640, -1000, 640, -1000, -1000, -1000,
-	-1000, 455, 618, 617, 360, -1000, 323, -1000, 94, -1000,
-	-1000, -1000, -1000, 290, -1000, -1000, -1000, 273, -1000, 91,
-	-1000, 16, -1000, -1000, -1000, 653, 640, 90, 275, 173,
-	662, 662, 657, 640, -1000, -1000, 640, -1000, -1000, -1000,
-	9, -1000, -1000, -1000, 78, 248, -82, -1000, -53, -1000,
-	-1000, 640, -1000, -1000, -1000, 662, 657, 657, 640, -1000,
-	-1000, 497, -1000, 72, 271, 265, -44, -1000, 657, 640,
-	640, -1000, -1000, 497, -1000, -1000, -1000, 264, 640, -1000,
-	-1000, -1000, -1000, -1000,
+var yyPact = [...]int16{
+	225, -32768, 155, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, 924, 829, 455, 644, 585, 449, 1331,
+	1299, 336, 432, 431, -89, 217, -95, 349, 343, 225,
+	626, 979, 211, 83, 95, 1031, 114, 1031, -32768, -32768,
+	1269, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, 596, 405, 316, -32768, 296, 300,
+	-32768, -32768, -107, 416, 406, 352, 254, -32768, 227, 235,
+	-40, 401, -40, 1137, -40, 585, 398, -40, 75, -40,
+	583, -32768, -56, 686, 397, 1137, 514, 611, 534, 606,
+	587, -32768, 338, 73, 1137, 404, -40, 70, -32768, -32768,
+	-32768, 583, 626, 979, 328, 1256, 1031, 1031, 1031, 1031,
+	1031, 1031, 1031, 1031, 44, 872, 1212, -32768, 318, 324,
+	324, 686, 457, -40, 605, 585, 265, 596, 596, 309,
+	247, 596, 245, -32768, -32768, -44, -97, -32768, -46, -40,
+	264, 596, -32768, 538, 480, -40, 477, 476, 90, -40,
+	-32768, -32768, -32768, -32768, 583, -32768, -40, -32768, -32768, -32768,
+	-32768, -32768, 209, 207, 522, 225, -32, -32768, 686, 177,
+	182, 485, 670, 172, -40, 473, -40, 516, -40, -40,
+	-40, 594, -40, -40, -32768, -40, 523, 523, 67, 1137,
+	504, -32768, 574, 583, -32768, -32768, -32768, -32768, -32768, -32768,
+	-32768, -12, -12, -12, -32768, -32768, -12, -32768, 152, -32768,
+	-32768, -32768, -32768, -32768, 1031, 314, -32768, -9, 615, 541,
+	-32768, -40, 583, 541, 596, 585, 585, 478, 262, 596,
+	251, 596, 578, 248, 596, 604, 65, 604, -32768, 596,
+	585, 64, -32768, 627, 603, 425, -26, 1124, 86, -32768,
+	602, -56, -56, -32768, 522, 508, 112, 686, 686, 44,
+	27, 175, 489, 587, 174, 1083, -32768, -32768, -32768, -32768,
+	-32768, -32768, -32768, -32768, 598, -28, 502, -40, -40, -32768,
+	496, 625, -40, -40, -32768, 623, 97, -32768, -32768, -32768,
+	-32768, -32768, -99, 451, 569, 574, -32768, -3, -40, 1031,
+	160, 564, 546, -32768, 541, 564, 585, 583, 574, 583,
+	541, 469, 290, 596, 470, 596, 585, 541, 564, 596,
+	585, -32768, -32768, -32768, 585, 583, 574, -32768, -32768, 627,
+	-32768, 36, 63, -40, 59, -32768, -40, 393, 390, 386,
+	377, -40, -55, 77, -40, -40, 35, 85, 1180, -32768,
+	1180, -40, -32768, -32768, -32768, 506, -32768, -32768, -32768, -32768,
+	1226, 173, 151, 587, -32768, 686, -40, -40, 34, -40,
+	492, -32768, -40, -40, 619, -32768, -40, -65, 49, 541,
+	170, 793, 451, -32768, 312, 172, 583, -40, -40, 103,
+	103, -32768, 559, 47, 46, -40, 564, -32768, 583, 574,
+	574, 564, 541, 564, 287, 154, 459, 468, 286, 585,
+	583, 574, 564, -32768, 585, 583, 574, 583, 574, 574,
+	564, -32768, -32768, -32768, -32768, -32768, 206, -32768, -32768, 28,
+	25, 24, 22, 371, 463, -24, 77, 222, 224, -85,
+	-32768, 1180, -32768, -32768, -32768, -32768, -40, 148, 146, 204,
+	1226, -32768, 144, 7, 627, 224, -32768, -32768, -40, -32768,
+	-32768, -40, -32768, -32768, -32768, 564, 1167, -32768, 203, 82,
+	169, 48, -32768, -32768, 541, -32768, 541, -32768, -32768, -32768,
+	-32768, -32768, 45, 43, 531, -32768, -32768, 193, 192, -32768,
+	574, 564, 564, -32768, 564, -32768, 154, 583, -40, -40,
+	161, 103, 103, 456, 282, 279, 154, 583, 574, 574,
+	564, -32768, 583, 574, 574, 564, 574, 564, 564, -32768,
+	-40, -32768, -32768, -32768, -32768, 363, 20, 275, -40, -85,
+	-40, -32768, -40, -83, -40, -32768, 19, -32768, 593, -32768,
+	-32768, -40, 142, 135, -32768, -32768, -32768, -32768, -32768, -32768,
+	-40, 131, -32768, -32768, -32768, 793, 305, 15, 301, 564,
+	564, 527, -32768, 42, -40, -32768, -32768, 564, -32768, -32768,
+	-32768, 583, 541, -32768, 188, -32768, -32768, -40, -32768, -32768,
+	276, 154, 154, 583, 574, 564, 564, -32768, 574, 564,
+	564, -32768, 564, -32768, -32768, -32768, -32768, 330, 515, 513,
+	224, -32768, -32768, -32768, 186, -85, -32768, -32768, -40, -32768,
+	-32768, -32768, -32768, 156, -32768, -32768, -32768, 127, -32768, -40,
+	-32768, 40, -32768, -32768, -32768, 541, 564, -40, 130, 154,
+	583, 583, 574, 564, -32768, -32768, 564, -32768, -32768, -32768,
+	38, -32768, -32768, -83, -40, -32768, 670, -67, -32768, -51,
+	-32768, -32768, 564, -32768, -32768, -32768, 583, 574, 574, 564,
+	-32768, -32768, 379, -85, -32768, -40, 129, 125, -50, -32768,
+	574, 564, 564, -32768, -32768, 379, -32768, -32768, -32768, -32768,
+	106, 564, -32768, -32768, -32768, -32768, -32768,
 }
 
-var yyPgo = [...]int{
-	0, 522, 778, 777, 776, 775, 15, 774, 773, 771,
-	770, 769, 768, 767, 766, 765, 764, 763, 762, 761,
-	760, 758, 757, 756, 755, 753, 6, 752, 751, 749,
-	748, 747, 746, 745, 744, 743, 742, 741, 740, 739,
-	738, 27, 12, 736, 734, 23, 75, 28, 733, 17,
-	22, 732, 730, 355, 728, 33, 35, 727, 725, 38,
-	21, 7, 724, 41, 3, 723, 13, 4, 722, 11,
-	16, 721, 10, 0, 718, 25, 717, 2, 1, 715,
-	20, 36, 714, 37, 9, 14, 704, 18, 5, 8,
-	702, 19,
+var yyPgo = [...]int16{
+	0, 344, 796, 790, 789, 788, 47, 787, 786, 785,
+	784, 783, 782, 781, 779, 771, 761, 760, 756, 755,
+	754, 753, 752, 751, 748, 747, 19, 746, 742, 741,
+	740, 738, 735, 734, 733, 732, 730, 725, 724, 723,
+	722, 719, 713, 710, 709, 708, 706, 705, 704, 703,
+	696, 694, 692, 687, 29, 13, 684, 675, 22, 481,
+	28, 669, 34, 18, 668, 666, 30, 664, 95, 32,
+	656, 655, 667, 20, 8, 654, 23, 1, 25, 648,
+	11, 42, 646, 54, 10, 642, 14, 4, 639, 16,
+	638, 6, 21, 5, 2, 637, 26, 44, 635, 198,
+	12, 3, 17, 633, 0, 632, 24, 7, 9, 631,
+	15,
 }
 
-var yyR1 = [...]int{
-	0, 44, 45, 45, 45, 1, 1, 1, 1, 1,
+var yyR1 = [...]int8{
+	0, 57, 58, 58, 58, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 6, 6, 41, 41, 43, 43, 43, 43, 43,
-	43, 63, 63, 62, 42, 42, 59, 59, 59, 59,
-	59, 59, 59, 59, 59, 59, 59, 59, 59, 59,
-	59, 59, 46, 47, 47, 47, 47, 48, 52, 53,
-	53, 53, 53, 53, 49, 49, 49, 50, 50, 51,
-	69, 69, 70, 70, 86, 86, 71, 71, 71, 71,
-	71, 71, 71, 71, 89, 89, 75, 75, 76, 76,
-	76, 55, 55, 56, 56, 56, 56, 56, 56, 56,
-	56, 56, 56, 57, 60, 60, 64, 64, 64, 64,
-	64, 64, 64, 64, 81, 58, 58, 58, 58, 58,
-	58, 58, 58, 65, 65, 65, 67, 67, 66, 66,
-	68, 68, 68, 72, 73, 73, 73, 73, 74, 74,
-	74, 74, 2, 3, 3, 4, 80, 80, 79, 79,
-	79, 79, 79, 79, 79, 7, 7, 54, 54, 54,
-	54, 8, 8, 9, 9, 5, 5, 5, 10, 10,
-	77, 77, 78, 78, 78, 78, 11, 11, 12, 14,
-	13, 13, 15, 15, 16, 17, 19, 19, 19, 21,
-	21, 20, 20, 20, 22, 22, 18, 23, 23, 83,
-	83, 24, 24, 25, 25, 26, 26, 26, 26, 26,
-	61, 61, 82, 27, 27, 28, 28, 28, 28, 29,
-	29, 29, 29, 30, 30, 30, 30, 31, 31, 31,
-	31, 90, 91, 91, 88, 88, 84, 84, 87, 87,
-	85, 32, 33, 34, 35, 35, 35, 35, 36, 36,
-	36, 36, 37, 38, 38, 39, 40,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 6, 6, 54, 54, 56, 56,
+	56, 56, 56, 56, 76, 76, 75, 55, 55, 72,
+	72, 72, 72, 72, 72, 72, 72, 72, 72, 72,
+	72, 72, 72, 72, 72, 59, 60, 60, 60, 60,
+	61, 65, 66, 66, 66, 66, 66, 62, 62, 62,
+	63, 63, 64, 83, 83, 84, 84, 103, 103, 85,
+	85, 85, 85, 85, 85, 85, 85, 108, 108, 89,
+	89, 90, 90, 90, 68, 68, 69, 69, 69, 69,
+	69, 69, 69, 69, 69, 69, 70, 73, 73, 77,
+	77, 77, 77, 77, 77, 77, 77, 97, 71, 71,
+	71, 71, 71, 71, 71, 71, 79, 79, 79, 81,
+	81, 80, 80, 82, 82, 82, 86, 87, 87, 87,
+	87, 88, 88, 88, 88, 2, 3, 3, 4, 96,
+	96, 95, 95, 95, 95, 95, 95, 95, 7, 7,
+	67, 67, 67, 67, 8, 8, 9, 9, 5, 5,
+	5, 10, 10, 93, 93, 94, 94, 94, 94, 11,
+	11, 12, 14, 13, 13, 15, 15, 16, 17, 19,
+	19, 19, 21, 21, 20, 20, 20, 22, 22, 18,
+	23, 23, 99, 99, 24, 24, 25, 25, 26, 26,
+	26, 26, 26, 74, 74, 98, 27, 27, 28, 28,
+	28, 28, 29, 29, 29, 29, 30, 30, 30, 30,
+	31, 31, 31, 31, 31, 31, 43, 44, 44, 92,
+	92, 45, 45, 45, 46, 47, 48, 53, 51, 52,
+	49, 49, 50, 50, 78, 78, 109, 110, 110, 107,
+	107, 100, 100, 101, 101, 91, 91, 106, 106, 102,
+	32, 33, 34, 35, 35, 35, 35, 36, 36, 36,
+	36, 37, 38, 38, 42, 39, 40, 41, 41, 104,
+	104, 105, 105, 105, 105, 105, 105, 105, 105, 105,
+	105, 105, 105, 105,
 }
 
-var yyR2 = [...]int{
+var yyR2 = [...]int8{
 	0, 1, 1, 2, 3, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 9, 10, 1, 3, 1, 3, 3, 1, 3,
-	3, 1, 2, 4, 1, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 4, 3, 2, 1, 1,
-	5, 6, 2, 1, 3, 1, 3, 3, 2, 5,
-	4, 4, 3, 1, 1, 1, 1, 2, 0, 8,
-	3, 0, 1, 3, 1, 1, 1, 3, 4, 6,
-	7, 1, 3, 1, 4, 0, 4, 0, 1, 1,
-	1, 2, 0, 1, 3, 3, 3, 5, 5, 4,
-	6, 6, 5, 3, 1, 3, 1, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 3, 1, 1, 1,
-	1, 1, 1, 1, 1, 1, 3, 0, 1, 3,
-	1, 2, 2, 2, 4, 2, 2, 0, 4, 2,
-	2, 0, 2, 4, 3, 2, 1, 2, 1, 2,
-	2, 2, 2, 1, 2, 9, 6, 2, 2, 2,
-	2, 5, 3, 7, 8, 6, 9, 9, 5, 4,
-	1, 2, 3, 3, 3, 3, 7, 6, 2, 3,
-	4, 3, 3, 2, 7, 6, 6, 7, 6, 5,
-	4, 6, 7, 6, 5, 4, 3, 8, 7, 2,
-	0, 7, 6, 11, 10, 2, 2, 4, 2, 2,
-	1, 3, 1, 3, 2, 10, 9, 9, 8, 13,
-	12, 12, 11, 10, 9, 9, 8, 9, 7, 6,
-	3, 3, 2, 0, 1, 3, 2, 0, 1, 3,
-	1, 3, 6, 4, 9, 8, 8, 7, 9, 8,
-	8, 7, 2, 7, 3, 3, 3,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 9, 10, 1, 3, 1, 3,
+	3, 1, 3, 3, 1, 2, 4, 1, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 4, 3,
+	2, 1, 1, 5, 6, 2, 1, 3, 1, 3,
+	3, 2, 5, 4, 4, 3, 1, 1, 1, 1,
+	2, 0, 8, 3, 0, 1, 3, 1, 1, 1,
+	3, 4, 6, 7, 1, 3, 1, 4, 0, 4,
+	0, 1, 1, 1, 2, 0, 1, 3, 3, 3,
+	5, 5, 4, 6, 6, 5, 3, 1, 3, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 3,
+	0, 1, 3, 1, 2, 2, 2, 4, 2, 2,
+	0, 4, 2, 2, 0, 2, 4, 3, 2, 1,
+	2, 1, 2, 2, 2, 2, 1, 2, 9, 6,
+	2, 2, 2, 2, 5, 3, 7, 8, 6, 9,
+	9, 5, 4, 1, 2, 3, 3, 3, 3, 7,
+	6, 2, 3, 4, 3, 3, 2, 7, 6, 6,
+	7, 6, 5, 4, 6, 7, 6, 5, 4, 3,
+	8, 7, 2, 0, 7, 6, 11, 10, 2, 2,
+	4, 2, 2, 1, 3, 1, 3, 2, 10, 9,
+	9, 8, 13, 12, 12, 11, 10, 9, 9, 8,
+	11, 9, 8, 7, 6, 3, 2, 4, 4, 1,
+	0, 2, 4, 4, 6, 3, 2, 3, 2, 2,
+	3, 5, 3, 5, 3, 0, 3, 2, 0, 1,
+	3, 2, 0, 2, 0, 2, 0, 1, 3, 1,
+	3, 6, 4, 9, 8, 8, 7, 9, 8, 8,
+	7, 2, 7, 3, 6, 3, 3, 3, 5, 1,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1,
 }
 
-var yyChk = [...]int{
-	-1000, -44, -45, -1, -6, -2, -3, -9, -5, -7,
+var yyChk = [...]int16{
+	-32768, -57, -58, -1, -6, -2, -3, -9, -5, -7,
 	-8, -11, -12, -14, -13, -15, -16, -17, -19, -21,
 	-22, -20, -18, -23, -24, -25, -27, -28, -29, -30,
-	-31, -32, -33, -34, -35, -36, -37, -38, -39, -40,
-	7, 17, 18, 57, 29, 35, 48, 27, 70, 52,
-	91, -41, 110, -43, 117, -59, 92, 105, 114, -58,
-	107, 58, 109, 106, 108, 63, 64, -81, 94, 38,
-	40, 41, 56, 37, 65, 66, 54, 5, 79, 46,
+	-31, -32, -33, -34, -35, -36, -37, -38, -42, -43,
+	-44, -45, -46, -47, -48, -53, -51, -52, -49, -50,
+	-39, -40, -41, 7, 17, 18, 57, 29, 35, 48,
+	27, 70, 52, 125, 127, 128, 129, 132, 133, 91,
+	-54, 110, -56, 117, -72, 92, -104, 114, -71, 107,
+	58, 105, -105, 109, 106, 108, 63, 64, -97, 122,
+	123, 124, 125, 126, 127, 128, 129, 130, 131, 132,
+	133, 134, 94, 38, 40, 41, 56, 37, 65, 66,
+	126, 131, -104, 80, 76, 54, 5, 79, 46, 122,
 	39, 41, 36, 5, 39, 56, 41, 36, 46, 5,
-	-46, -55, 4, 8, 41, 5, 31, 105, 31, 105,
-	71, -6, 32, -1, -46, -41, 90, 102, 9, 117,
-	118, 113, 114, 116, 119, 120, 115, -59, 92, 102,
-	-59, -63, 105, -62, 59, -83, 6, 42, -83, 72,
-	73, 67, 68, 69, 67, 69, 53, 72, 73, 83,
-	105, 43, 105, -53, 105, 101, -49, 108, -81, 105,
-	-46, -55, 43, 105, 106, 105, -55, -47, -52, -48,
-	-53, 92, -56, -57, 92, 105, 26, 25, -60, -59,
-	43, -53, 6, 20, 23, 6, 6, 20, 4, 6,
-	-6, 53, -55, -46, -41, 65, 66, 105, 108, -59,
-	-59, -59, -59, -59, -59, -59, -59, 93, -41, 93,
-	-65, 105, 65, 66, 61, -63, -63, -56, 30, -55,
-	105, 6, -46, -55, 73, -83, -83, -83, 72, 73,
-	72, 73, -83, 72, 73, 105, 73, -83, -4, 30,
-	105, 30, 30, 101, 105, -55, 105, 90, 90, -50,
-	-51, 19, -45, 111, 112, -59, -56, 24, 25, 92,
-	26, -64, 95, 96, 97, 98, 99, 100, 104, 103,
-	105, 30, 105, 6, 23, 105, 105, 105, 6, 4,
-	105, 105, 105, -69, 10, -55, 93, -59, 61, 60,
-	5, -67, 12, 105, -55, -67, -83, -46, -55, -46,
-	-55, -46, 30, 73, -83, 73, -83, -46, -67, 73,
-	-83, -83, -46, -55, -80, -79, -78, 44, 55, 33,
-	34, 45, 74, 46, 49, 50, 47, 6, 32, 84,
-	74, 105, 101, -49, 101, 6, -47, -47, -50, 21,
-	93, -56, -56, 93, 92, 24, -6, 92, -60, 92,
-	6, 74, 23, 105, 105, 23, 4, 105, 105, 4,
-	95, -75, 28, 11, -69, 62, 105, -59, -54, 95,
-	96, 104, 103, -72, -73, 13, 14, 11, -67, -73,
-	-46, -55, -55, -69, -55, -67, 30, 69, -83, -46,
-	30, -83, -46, -55, -67, -73, -83, -46, -55, -46,
-	-55, -55, -69, -80, 107, 106, 105, 106, -87, -85,
-	105, 44, 44, 44, 44, 105, 108, -91, -90, 105,
-	-87, 101, -49, 105, -49, 105, 22, -42, -6, 105,
-	92, 93, -6, -56, 105, -87, 105, 23, 105, 105,
-	4, 105, 108, -67, 92, -70, -71, -86, 105, 117,
-	-81, 108, -75, 62, -55, 105, 105, -81, -81, -74,
-	15, 16, 106, 106, -66, -68, 105, -73, -55, -69,
-	-69, -73, -67, -72, 69, -26, 95, 96, 24, 104,
-	103, -46, 30, 30, 69, -46, -55, -55, -69, -73,
-	-46, -55, -55, -69, -55, -69, -69, -73, 90, 107,
-	107, 107, 107, -10, 44, 30, 74, -91, 85, -84,
-	51, -49, 105, 93, 93, 90, -6, -42, 93, 93,
-	-80, -84, 105, 105, -72, -76, 105, 106, 109, 90,
-	102, 92, 102, -67, -67, 106, 106, 14, 90, 88,
-	89, -69, -73, -73, -72, -26, -55, -61, -82, 105,
-	-61, 92, -81, -81, 30, 69, 69, -26, -55, -69,
-	-69, -73, -55, -69, -69, -73, -69, -73, -73, -85,
-	45, 107, 31, 87, -87, -88, 105, 105, 6, -42,
-	93, 93, -89, 105, 93, -70, 65, 107, 65, -72,
-	-72, 16, 106, -66, -73, -55, -67, 90, -61, 69,
-	-26, -26, -55, -69, -73, -73, -69, -73, -73, -73,
-	55, 20, 20, -84, 90, 105, 92, 93, 90, -89,
-	106, -67, -73, -61, 93, -26, -55, -55, -69, -73,
-	-73, 106, -88, -64, 108, 107, 114, -73, -55, -69,
-	-69, -73, -77, -78, 105, 93, 93, 107, -69, -73,
-	-73, -77, 93, -73,
+	-59, -68, 4, 8, 41, 5, 31, -104, 31, -104,
+	71, -6, 32, 46, 5, 126, 87, 130, 55, 55,
+	-1, -59, -54, 90, 102, 9, 117, 118, 113, 114,
+	116, 119, 120, 115, -72, 92, 102, -72, -76, -104,
+	-75, 59, -99, 6, 42, -99, 72, 73, 67, 68,
+	69, 67, 69, 134, -78, 53, 6, -78, 53, 53,
+	72, 73, 83, 77, -104, 43, -104, -66, -104, 101,
+	-62, 108, -97, -104, -59, -68, 43, -104, 106, -104,
+	-68, -60, -65, -61, -66, 92, -69, -70, 92, -104,
+	26, 25, -73, -72, 43, -66, 6, 20, 23, 6,
+	6, 20, 4, 6, -6, 53, 106, -66, 46, 5,
+	-104, 106, -68, -59, -54, 65, 66, -104, 108, -72,
+	-72, -72, -72, -72, -72, -72, -72, 93, -54, 93,
+	-79, -104, 65, 66, 61, -76, -76, -69, 30, -68,
+	-104, 6, -59, -68, 73, -99, -99, -99, 72, 73,
+	72, 73, -99, 72, 73, 108, 130, 108, -104, 73,
+	-99, 13, -4, 30, -104, 30, 30, 101, -104, -68,
+	-104, 90, 90, -63, -64, 19, -58, 111, 112, -72,
+	-69, 24, 25, 92, 26, -77, 95, 96, 97, 98,
+	99, 100, 104, 103, -104, 30, -104, 6, 23, -104,
+	-104, -104, 6, 4, -104, -104, -104, -92, 19, -92,
+	106, -66, 23, -83, 10, -68, 93, -72, 61, 60,
+	5, -81, 12, -104, -68, -81, -99, -59, -68, -59,
+	-68, -59, 30, 73, -99, 73, -99, -59, -81, 73,
+	-99, -78, 106, -78, -99, -59, -68, 106, -96, -95,
+	-94, 44, 55, 33, 34, 45, 74, 46, 49, 50,
+	47, 6, 32, 84, 74, 123, 124, -104, 101, -62,
+	101, 6, -60, -60, -63, 21, 93, -69, -69, 93,
+	92, 24, -6, 92, -73, 92, 6, 74, 124, 23,
+	-104, -104, 23, 4, -104, -104, 4, 95, 130, -89,
+	28, 11, -83, 62, -104, -72, -67, 95, 96, 104,
+	103, -86, -87, 13, 14, 11, -81, -87, -59, -68,
+	-68, -83, -68, -81, 30, 69, -99, -59, 30, -99,
+	-59, -68, -81, -87, -99, -59, -68, -59, -68, -68,
+	-83, -96, 107, 106, -104, 106, -106, -102, -104, 44,
+	44, 44, 44, -104, 108, -110, -109, 105, -106, -104,
+	107, 101, -62, -104, -62, -104, 22, -55, -6, -104,
+	92, 93, -6, -69, -104, -106, 107, -104, 23, -104,
+	-104, 4, -104, 108, 106, -81, 92, -84, -85, -103,
+	-104, 117, -97, 108, -89, 62, -68, -104, -104, -97,
+	-97, -88, 15, 16, 106, 106, -80, -82, -104, -87,
+	-68, -83, -83, -87, -81, -86, 69, -26, 95, 96,
+	24, 104, 103, -59, 30, 30, 69, -59, -68, -68,
+	-83, -87, -59, -68, -68, -83, -68, -83, -83, -87,
+	90, 107, 107, 107, 107, -10, 44, 30, 74, -101,
+	123, -110, 85, -100, 51, -91, 124, -62, -104, 93,
+	93, 90, -6, -55, 93, 93, -96, -100, -104, -104,
+	-86, -90, -104, 106, 109, 90, 102, 92, 102, -81,
+	-81, 106, 106, 14, 90, 88, 89, -83, -87, -87,
+	-86, -26, -68, -74, -98, -104, -74, 92, -97, -97,
+	30, 69, 69, -26, -68, -83, -83, -87, -68, -83,
+	-83, -87, -83, -87, -87, -102, 45, 107, 31, 87,
+	-106, -91, -104, -107, -104, -101, -104, 107, 6, -55,
+	93, 93, -108, -104, 93, -84, 65, 107, 65, -86,
+	-86, 16, 106, -80, -87, -68, -81, 90, -74, 69,
+	-26, -26, -68, -83, -87, -87, -83, -87, -87, -87,
+	55, 20, 20, -100, 90, -91, -104, 92, 93, 90,
+	-108, 106, -81, -87, -74, 93, -26, -68, -68, -83,
+	-87, -87, 106, -101, -107, -77, 108, 107, 114, -87,
+	-68, -83, -83, -87, -93, -94, -91, -104, 93, 93,
+	107, -83, -87, -87, -93, 93, -87,
 }
 
-var yyDef = [...]int{
+var yyDef = [...]int16{
 	0, -2, 1, 2, 5, 6, 7, 8, 9, 10,
 	11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
 	21, 22, 23, 24, 25, 26, 27, 28, 29, 30,
 	31, 32, 33, 34, 35, 36, 37, 38, 39, 40,
-	0, 0, 0, 0, 112, 0, 0, 0, 0, 0,
-	3, 0, 0, 43, 45, 48, 0, 135, 0, 68,
-	69, 0, 137, 138, 139, 140, 141, 142, 134, 162,
-	220, 0, 220, 198, 0, 0, 0, 0, 272, 0,
-	0, 0, 0, 0, 0, 112, 0, 0, 0, 0,
-	112, 203, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 234, 0, 4, 112, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	67, 0, 0, 51, 0, 112, 0, 182, 112, 0,
-	220, 220, 220, 0, 220, 0, 0, 0, 220, 275,
-	164, 0, 0, 250, 84, 0, 83, 85, 86, 199,
-	112, 201, 0, 216, 261, 276, 202, 72, 73, 75,
-	88, 0, 111, 113, 0, 135, 0, 0, 0, 124,
-	0, 274, 0, 0, 0, 0, 0, 0, 0, 0,
-	233, 0, 91, 112, 44, 46, 47, 49, 50, 56,
-	57, 58, 59, 60, 61, 62, 63, 64, 0, 66,
-	136, 143, 144, 145, 0, 0, 52, 0, 0, 147,
-	219, 0, 112, 147, 220, 112, 112, 0, 0, 220,
-	0, 220, 147, 0, 220, 263, 220, 112, 163, 0,
-	0, 0, 0, 0, 0, 200, 0, 0, 0, 78,
-	88, 0, 0, 0, 0, 124, 0, 0, 0, 0,
-	0, 0, 126, 127, 128, 129, 130, 131, 132, 133,
-	0, 0, 0, 0, 0, 210, 0, 0, 0, 0,
-	215, 0, 0, 107, 0, 91, 65, 0, 0, 0,
-	0, 157, 0, 181, 147, 157, 112, 112, 91, 112,
-	147, 0, 0, 220, 0, 220, 112, 147, 157, 220,
-	112, 112, 112, 91, 165, 166, 168, 0, 0, 0,
-	0, 173, 0, 0, 0, 0, 0, 0, 0, 253,
-	0, 84, 0, 82, 0, 0, 74, 76, 87, 0,
-	77, 115, 116, -2, 0, 0, 0, 0, 123, 0,
-	0, 0, 0, 0, 209, 0, 0, 0, 214, 0,
-	0, 147, 0, 0, 107, 70, 0, 53, 112, 0,
-	0, 0, 0, 176, 161, 0, 0, 0, 157, 197,
-	112, 91, 91, 157, 147, 157, 0, 0, 0, 0,
-	0, 112, 112, 91, 157, 222, 112, 112, 91, 112,
-	91, 91, 157, 167, 169, 170, 171, 172, 174, 258,
-	260, 0, 0, 0, 0, 0, 185, 249, 253, 0,
-	257, 0, 81, 84, 80, 205, 0, 0, 0, 54,
-	0, 119, 0, 0, 0, 257, 206, 0, 208, 211,
-	0, 213, 262, 157, 0, 90, 92, 96, 94, 101,
-	103, 95, 147, 71, 147, 177, 178, 179, 180, 153,
-	0, 0, 155, 156, 146, 148, 150, 196, 91, 157,
-	157, 271, 157, 218, 0, 112, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 112, 91, 91, 157, 221,
-	112, 91, 91, 157, 91, 157, 157, 267, 0, 192,
-	193, 194, 195, 183, 0, 0, 0, 252, 0, 248,
-	0, 79, 0, 117, 118, 0, 0, 0, 122, 125,
-	204, 273, 207, 212, 105, 0, 108, 109, 110, 0,
-	0, 0, 0, 157, 157, 159, 160, 0, 0, 151,
-	152, 157, 269, 270, 217, 112, 147, 225, 230, 232,
-	226, 0, 228, 229, 0, 0, 0, 112, 91, 157,
-	157, 238, 91, 157, 157, 246, 157, 265, 266, 259,
-	184, 0, 0, 0, 257, 251, 254, 256, 0, 55,
-	120, 121, 41, 0, 106, 93, 97, 0, 102, 105,
-	175, 0, 154, 149, 268, 147, 157, 0, 0, 0,
-	112, 112, 91, 157, 236, 237, 157, 244, 245, 264,
-	0, 186, 187, 247, 0, 0, 0, 98, 0, 42,
-	158, 157, 224, 231, 227, 112, 91, 91, 157, 235,
-	243, 189, 255, 0, 0, 0, 0, 223, 91, 157,
-	157, 242, 188, 190, 89, 104, 99, 0, 157, 240,
-	241, 191, 100, 239,
+	41, 42, 43, 44, 45, 46, 47, 48, 49, 50,
+	51, 52, 53, 0, 0, 0, 0, 125, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 3,
+	0, 0, 56, 58, 61, 0, 148, 0, 81, 82,
+	0, 319, 320, 150, 151, 152, 153, 154, 155, 321,
+	322, 323, 324, 325, 326, 327, 328, 329, 330, 331,
+	332, 333, 147, 175, 233, 0, 233, 211, 0, 0,
+	-2, -2, 0, 285, 285, 0, 0, 311, 0, 321,
+	0, 0, 0, 0, 0, 125, 0, 0, 0, 0,
+	125, 216, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 247, 0, 0, 0, 271, 0, 0, 278, 279,
+	4, 125, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 80, 0, 0,
+	64, 0, 125, 0, 195, 125, 0, 233, 233, 233,
+	0, 233, 0, 277, 280, 0, 0, 282, 0, 0,
+	0, 233, 315, 317, 177, 0, 0, 265, 97, 0,
+	96, 98, 99, 212, 125, 214, 0, 229, 300, 316,
+	215, 85, 86, 88, 101, 0, 124, 126, 0, 148,
+	0, 0, 0, 137, 0, 313, 0, 0, 0, 0,
+	0, 0, 0, 0, 246, 0, 270, 270, 0, 0,
+	0, 275, 104, 125, 57, 59, 60, 62, 63, 69,
+	70, 71, 72, 73, 74, 75, 76, 77, 0, 79,
+	149, 156, 157, 158, 0, 0, 65, 0, 0, 160,
+	232, 0, 125, 160, 233, 125, 125, 0, 0, 233,
+	0, 233, 160, 0, 233, 285, 0, 285, 302, 233,
+	125, 0, 176, 0, 0, 0, 0, 0, 0, 213,
+	0, 0, 0, 91, 101, 0, 0, 0, 0, 137,
+	0, 0, 0, 0, 0, 0, 139, 140, 141, 142,
+	143, 144, 145, 146, 0, 0, 0, 0, 0, 223,
+	0, 0, 0, 0, 228, 0, 0, 267, 269, 268,
+	272, 273, 0, 120, 0, 104, 78, 0, 0, 0,
+	0, 170, 0, 194, 160, 170, 125, 125, 104, 125,
+	160, 0, 0, 233, 0, 233, 125, 160, 170, 233,
+	125, 281, 284, 283, 125, 125, 104, 318, 178, 179,
+	181, 0, 0, 0, 0, 186, 0, 0, 0, 0,
+	0, 0, 0, 288, 0, 0, 0, 97, 0, 95,
+	0, 0, 87, 89, 100, 0, 90, 128, 129, -2,
+	0, 0, 0, 0, 136, 0, 0, 0, 0, 0,
+	0, 222, 0, 0, 0, 227, 0, 0, 0, 160,
+	0, 0, 120, 83, 0, 66, 125, 0, 0, 0,
+	0, 189, 174, 0, 0, 0, 170, 210, 125, 104,
+	104, 170, 160, 170, 0, 0, 0, 0, 0, 125,
+	125, 104, 170, 235, 125, 125, 104, 125, 104, 104,
+	170, 180, 182, 183, 184, 185, 187, 297, 299, 0,
+	0, 0, 0, 0, 198, 294, 288, 0, 292, 296,
+	264, 0, 94, 97, 93, 218, 0, 0, 0, 67,
+	0, 132, 0, 0, 0, 292, 314, 219, 0, 221,
+	224, 0, 226, 301, 274, 170, 0, 103, 105, 109,
+	107, 114, 116, 108, 160, 84, 160, 190, 191, 192,
+	193, 166, 0, 0, 168, 169, 159, 161, 163, 209,
+	104, 170, 170, 310, 170, 231, 0, 125, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 125, 104, 104,
+	170, 234, 125, 104, 104, 170, 104, 170, 170, 306,
+	0, 205, 206, 207, 208, 196, 0, 0, 0, 296,
+	0, 287, 0, 294, 0, 263, 0, 92, 0, 130,
+	131, 0, 0, 0, 135, 138, 217, 312, 220, 225,
+	118, 0, 121, 122, 123, 0, 0, 0, 0, 170,
+	170, 172, 173, 0, 0, 164, 165, 170, 308, 309,
+	230, 125, 160, 238, 243, 245, 239, 0, 241, 242,
+	0, 0, 0, 125, 104, 170, 170, 251, 104, 170,
+	170, 259, 170, 304, 305, 298, 197, 0, 0, 0,
+	292, 262, 293, 286, 289, 296, 291, 295, 0, 68,
+	133, 134, 54, 0, 119, 106, 110, 0, 115, 118,
+	188, 0, 167, 162, 307, 160, 170, 0, 0, 0,
+	125, 125, 104, 170, 249, 250, 170, 257, 258, 303,
+	0, 199, 200, 294, 0, 261, 0, 0, 111, 0,
+	55, 171, 170, 237, 244, 240, 125, 104, 104, 170,
+	248, 256, 202, 296, 290, 0, 0, 0, 0, 236,
+	104, 170, 170, 255, 201, 203, 260, 102, 117, 112,
+	0, 170, 253, 254, 204, 113, 252,
 }
 
-var yyTok1 = [...]int{
+var yyTok1 = [...]int8{
 	1,
 }
 
-var yyTok2 = [...]int{
+var yyTok2 = [...]uint8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
@@ -743,9 +889,11 @@ var yyTok2 = [...]int{
 	92, 93, 94, 95, 96, 97, 98, 99, 100, 101,
 	102, 103, 104, 105, 106, 107, 108, 109, 110, 111,
 	112, 113, 114, 115, 116, 117, 118, 119, 120, 121,
+	122, 123, 124, 125, 126, 127, 128, 129, 130, 131,
+	132, 133, 134,
 }
 
-var yyTok3 = [...]int{
+var yyTok3 = [...]int8{
 	0,
 }
 
@@ -761,7 +909,7 @@ var yyErrorMessages = [...]struct {
 
 var (
 	yyDebug        = 0
-	yyErrorVerbose = true
+	yyErrorVerbose = false
 )
 
 type yyLexer interface {
@@ -788,7 +936,7 @@ func yyNewParser() yyParser {
 	return &yyParserImpl{}
 }
 
-const yyFlag = -1000
+const yyFlag = -32768
 
 func yyTokname(c int) string {
 	if c >= 1 && c-1 < len(yyToknames) {
@@ -827,9 +975,9 @@ func yyErrorMessage(state, lookAhead int) string {
 	expected := make([]int, 0, 4)
 
 	// Look for shiftable tokens.
-	base := yyPact[state]
+	base := int(yyPact[state])
 	for tok := TOKSTART; tok-1 < len(yyToknames); tok++ {
-		if n := base + tok; n >= 0 && n < yyLast && yyChk[yyAct[n]] == tok {
+		if n := base + tok; n >= 0 && n < yyLast && int(yyChk[int(yyAct[n])]) == tok {
 			if len(expected) == cap(expected) {
 				return res
 			}
@@ -839,13 +987,13 @@ func yyErrorMessage(state, lookAhead int) string {
 
 	if yyDef[state] == -2 {
 		i := 0
-		for yyExca[i] != -1 || yyExca[i+1] != state {
+		for yyExca[i] != -1 || int(yyExca[i+1]) != state {
 			i += 2
 		}
 
 		// Look for tokens that we accept or reduce.
 		for i += 2; yyExca[i] >= 0; i += 2 {
-			tok := yyExca[i]
+			tok := int(yyExca[i])
 			if tok < TOKSTART || yyExca[i+1] == 0 {
 				continue
 			}
@@ -876,30 +1024,30 @@ func yylex1(lex yyLexer, lval *yySymType) (char, token int) {
 	token = 0
 	char = lex.Lex(lval)
 	if char <= 0 {
-		token = yyTok1[0]
+		token = int(yyTok1[0])
 		goto out
 	}
 	if char < len(yyTok1) {
-		token = yyTok1[char]
+		token = int(yyTok1[char])
 		goto out
 	}
 	if char >= yyPrivate {
 		if char < yyPrivate+len(yyTok2) {
-			token = yyTok2[char-yyPrivate]
+			token = int(yyTok2[char-yyPrivate])
 			goto out
 		}
 	}
 	for i := 0; i < len(yyTok3); i += 2 {
-		token = yyTok3[i+0]
+		token = int(yyTok3[i+0])
 		if token == char {
-			token = yyTok3[i+1]
+			token = int(yyTok3[i+1])
 			goto out
 		}
 	}
 
 out:
 	if token == 0 {
-		token = yyTok2[1] /* unknown char */
+		token = int(yyTok2[1]) /* unknown char */
 	}
 	if yyDebug >= 3 {
 		__yyfmt__.Printf("lex %s(%d)\n", yyTokname(token), uint(char))
@@ -954,7 +1102,7 @@ yystack:
 	yyS[yyp].yys = yystate
 
 yynewstate:
-	yyn = yyPact[yystate]
+	yyn = int(yyPact[yystate])
 	if yyn <= yyFlag {
 		goto yydefault /* simple state */
 	}
@@ -965,8 +1113,8 @@ yynewstate:
 	if yyn < 0 || yyn >= yyLast {
 		goto yydefault
 	}
-	yyn = yyAct[yyn]
-	if yyChk[yyn] == yytoken { /* valid shift */
+	yyn = int(yyAct[yyn])
+	if int(yyChk[yyn]) == yytoken { /* valid shift */
 		yyrcvr.char = -1
 		yytoken = -1
 		yyVAL = yyrcvr.lval
@@ -979,7 +1127,7 @@ yynewstate:
 
 yydefault:
 	/* default state action */
-	yyn = yyDef[yystate]
+	yyn = int(yyDef[yystate])
 	if yyn == -2 {
 		if yyrcvr.char < 0 {
 			yyrcvr.char, yytoken = yylex1(yylex, &yyrcvr.lval)
@@ -988,18 +1136,18 @@ yydefault:
 		/* look through exception table */
 		xi := 0
 		for {
-			if yyExca[xi+0] == -1 && yyExca[xi+1] == yystate {
+			if yyExca[xi+0] == -1 && int(yyExca[xi+1]) == yystate {
 				break
 			}
 			xi += 2
 		}
 		for xi += 2; ; xi += 2 {
-			yyn = yyExca[xi+0]
+			yyn = int(yyExca[xi+0])
 			if yyn < 0 || yyn == yytoken {
 				break
 			}
 		}
-		yyn = yyExca[xi+1]
+		yyn = int(yyExca[xi+1])
 		if yyn < 0 {
 			goto ret0
 		}
@@ -1021,10 +1169,10 @@ yydefault:
 
 			/* find a state where "error" is a legal shift action */
 			for yyp >= 0 {
-				yyn = yyPact[yyS[yyp].yys] + yyErrCode
+				yyn = int(yyPact[yyS[yyp].yys]) + yyErrCode
 				if yyn >= 0 && yyn < yyLast {
-					yystate = yyAct[yyn] /* simulate a shift of "error" */
-					if yyChk[yystate] == yyErrCode {
+					yystate = int(yyAct[yyn]) /* simulate a shift of "error" */
+					if int(yyChk[yystate]) == yyErrCode {
 						goto yystack
 					}
 				}
@@ -1060,7 +1208,7 @@ yydefault:
 	yypt := yyp
 	_ = yypt // guard against "declared and not used"
 
-	yyp -= yyR2[yyn]
+	yyp -= int(yyR2[yyn])
 	// yyp is now the index of $0. Perform the default action. Iff the
 	// reduced production is ε, $1 is possibly out of range.
 	if yyp+1 >= len(yyS) {
@@ -1071,16 +1219,16 @@ yydefault:
 	yyVAL = yyS[yyp+1]
 
 	/* consult goto table to find next state */
-	yyn = yyR1[yyn]
-	yyg := yyPgo[yyn]
+	yyn = int(yyR1[yyn])
+	yyg := int(yyPgo[yyn])
 	yyj := yyg + yyS[yyp].yys + 1
 
 	if yyj >= yyLast {
-		yystate = yyAct[yyg]
+		yystate = int(yyAct[yyg])
 	} else {
-		yystate = yyAct[yyj]
-		if yyChk[yystate] != -yyn {
-			yystate = yyAct[yyg]
+		yystate = int(yyAct[yyj])
+		if int(yyChk[yystate]) != -yyn {
+			yystate = int(yyAct[yyg])
 		}
 	}
 	// dummy call; replaced with literal code
@@ -1088,19 +1236,19 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:151
+//line sql.y:163
 		{
 			setParseTree(yylex, yyDollar[1].stmts)
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:157
+//line sql.y:169
 		{
 			yyVAL.stmts = []influxql.Statement{yyDollar[1].stmt}
 		}
 	case 3:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:161
+//line sql.y:173
 		{
 
 			if len(yyDollar[1].stmts) == 1 {
@@ -1111,229 +1259,307 @@ yydefault:
 		}
 	case 4:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:170
+//line sql.y:182
 		{
 			yyVAL.stmts = append(yyDollar[1].stmts, yyDollar[3].stmt)
 		}
 	case 5:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:178
+//line sql.y:190
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 6:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:182
+//line sql.y:194
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 7:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:186
+//line sql.y:198
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 8:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:190
+//line sql.y:202
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 9:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:194
+//line sql.y:206
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 10:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:198
+//line sql.y:210
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 11:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:202
+//line sql.y:214
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 12:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:206
+//line sql.y:218
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 13:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:210
+//line sql.y:222
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 14:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:214
+//line sql.y:226
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 15:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:218
+//line sql.y:230
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 16:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:222
+//line sql.y:234
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 17:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:226
+//line sql.y:238
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 18:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:230
+//line sql.y:242
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 19:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:234
+//line sql.y:246
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 20:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:238
+//line sql.y:250
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 21:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:242
+//line sql.y:254
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 22:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:246
+//line sql.y:258
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 23:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:250
+//line sql.y:262
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 24:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:254
+//line sql.y:266
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 25:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:258
+//line sql.y:270
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 26:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:262
+//line sql.y:274
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 27:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:266
+//line sql.y:278
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 28:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:270
+//line sql.y:282
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 29:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:274
+//line sql.y:286
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 30:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:278
+//line sql.y:290
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 31:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:282
+//line sql.y:294
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 32:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:286
+//line sql.y:298
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 33:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:290
+//line sql.y:302
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 34:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:294
+//line sql.y:306
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 35:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:298
+//line sql.y:310
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 36:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:302
+//line sql.y:314
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 37:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:306
+//line sql.y:318
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 38:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:310
+//line sql.y:322
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 39:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:314
+//line sql.y:326
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 40:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:318
+//line sql.y:330
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
 	case 41:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:334
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 42:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:338
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 43:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:342
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 44:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:346
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 45:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:350
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 46:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:354
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 47:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:358
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 48:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:362
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 49:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:366
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 50:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:370
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 51:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:374
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 52:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:378
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 53:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:382
+		{
+			yyVAL.stmt = yyDollar[1].stmt
+		}
+	case 54:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:326
+//line sql.y:390
 		{
 			stmt := &influxql.SelectStatement{}
 			stmt.Fields = yyDollar[2].fields
@@ -1361,9 +1587,9 @@ yydefault:
 			stmt.Location = yyDollar[9].location
 			yyVAL.stmt = stmt
 		}
-	case 42:
+	case 55:
 		yyDollar = yyS[yypt-10 : yypt+1]
-//line sql.y:354
+//line sql.y:418
 		{
 			stmt := &influxql.SelectStatement{}
 			stmt.Hints = yyDollar[2].hints
@@ -1392,147 +1618,147 @@ yydefault:
 			stmt.Location = yyDollar[10].location
 			yyVAL.stmt = stmt
 		}
-	case 43:
+	case 56:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:387
+//line sql.y:451
 		{
 			yyVAL.fields = []*influxql.Field{yyDollar[1].field}
 		}
-	case 44:
+	case 57:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:391
+//line sql.y:455
 		{
 			yyVAL.fields = append([]*influxql.Field{yyDollar[1].field}, yyDollar[3].fields...)
 		}
-	case 45:
+	case 58:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:397
+//line sql.y:461
 		{
 			yyVAL.field = &influxql.Field{Expr: &influxql.Wildcard{Type: influxql.Token(yyDollar[1].int)}}
 		}
-	case 46:
+	case 59:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:401
+//line sql.y:465
 		{
 			yyVAL.field = &influxql.Field{Expr: &influxql.Wildcard{Type: influxql.TAG}}
 		}
-	case 47:
+	case 60:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:405
+//line sql.y:469
 		{
 			yyVAL.field = &influxql.Field{Expr: &influxql.Wildcard{Type: influxql.FIELD}}
 		}
-	case 48:
+	case 61:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:409
+//line sql.y:473
 		{
 			yyVAL.field = &influxql.Field{Expr: yyDollar[1].expr}
 		}
-	case 49:
+	case 62:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:413
+//line sql.y:477
 		{
 			yyVAL.field = &influxql.Field{Expr: yyDollar[1].expr, Alias: yyDollar[3].str}
 		}
-	case 50:
+	case 63:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:417
+//line sql.y:481
 		{
 			yyVAL.field = &influxql.Field{Expr: yyDollar[1].expr, Alias: yyDollar[3].str}
 		}
-	case 51:
+	case 64:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:423
+//line sql.y:487
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 52:
+	case 65:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:427
+//line sql.y:491
 		{
 			c := yyDollar[1].expr.(*influxql.CaseWhenExpr)
 			c.Conditions = append(c.Conditions, yyDollar[2].expr.(*influxql.CaseWhenExpr).Conditions...)
 			c.Assigners = append(c.Assigners, yyDollar[2].expr.(*influxql.CaseWhenExpr).Assigners...)
 			yyVAL.expr = c
 		}
-	case 53:
+	case 66:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:436
+//line sql.y:500
 		{
 			c := &influxql.CaseWhenExpr{}
 			c.Conditions = []influxql.Expr{yyDollar[2].expr}
 			c.Assigners = []influxql.Expr{yyDollar[4].expr}
 			yyVAL.expr = c
 		}
-	case 54:
+	case 67:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:445
+//line sql.y:509
 		{
 			yyVAL.fields = []*influxql.Field{&influxql.Field{Expr: &influxql.VarRef{Val: yyDollar[1].str}}}
 		}
-	case 55:
+	case 68:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:449
+//line sql.y:513
 		{
 			yyVAL.fields = append([]*influxql.Field{&influxql.Field{Expr: &influxql.VarRef{Val: yyDollar[1].str}}}, yyDollar[3].fields...)
 		}
-	case 56:
+	case 69:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:455
+//line sql.y:519
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.MUL), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 57:
+	case 70:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:459
+//line sql.y:523
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.DIV), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 58:
+	case 71:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:463
+//line sql.y:527
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.ADD), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 59:
+	case 72:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:467
+//line sql.y:531
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.SUB), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 60:
+	case 73:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:471
+//line sql.y:535
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.BITWISE_XOR), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 61:
+	case 74:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:475
+//line sql.y:539
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.MOD), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 62:
+	case 75:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:479
+//line sql.y:543
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.BITWISE_AND), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 63:
+	case 76:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:483
+//line sql.y:547
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(influxql.BITWISE_OR), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 64:
+	case 77:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:487
+//line sql.y:551
 		{
 			yyVAL.expr = yyDollar[2].expr
 		}
-	case 65:
+	case 78:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:491
+//line sql.y:555
 		{
 			cols := &influxql.Call{Name: strings.ToLower(yyDollar[1].str), Args: []influxql.Expr{}}
 			for i := range yyDollar[3].fields {
@@ -1540,16 +1766,16 @@ yydefault:
 			}
 			yyVAL.expr = cols
 		}
-	case 66:
+	case 79:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:499
+//line sql.y:563
 		{
 			cols := &influxql.Call{Name: strings.ToLower(yyDollar[1].str)}
 			yyVAL.expr = cols
 		}
-	case 67:
+	case 80:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:504
+//line sql.y:568
 		{
 			switch s := yyDollar[2].expr.(type) {
 			case *influxql.NumberLiteral:
@@ -1563,66 +1789,66 @@ yydefault:
 			}
 
 		}
-	case 68:
+	case 81:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:518
+//line sql.y:582
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 69:
+	case 82:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:522
+//line sql.y:586
 		{
 			yyVAL.expr = &influxql.DurationLiteral{Val: yyDollar[1].tdur}
 		}
-	case 70:
+	case 83:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:526
+//line sql.y:590
 		{
 			c := yyDollar[2].expr.(*influxql.CaseWhenExpr)
 			c.Assigners = append(c.Assigners, yyDollar[4].expr)
 			yyVAL.expr = c
 		}
-	case 71:
+	case 84:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:532
+//line sql.y:596
 		{
 			yyVAL.expr = &influxql.VarRef{}
 		}
-	case 72:
+	case 85:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:538
+//line sql.y:602
 		{
 			yyVAL.sources = yyDollar[2].sources
 		}
-	case 73:
+	case 86:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:544
+//line sql.y:608
 		{
 			yyVAL.sources = []influxql.Source{yyDollar[1].ment}
 		}
-	case 74:
+	case 87:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:548
+//line sql.y:612
 		{
 			yyVAL.sources = append([]influxql.Source{yyDollar[1].ment}, yyDollar[3].sources...)
 		}
-	case 75:
+	case 88:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:552
+//line sql.y:616
 		{
 			yyVAL.sources = yyDollar[1].sources
 
 		}
-	case 76:
+	case 89:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:557
+//line sql.y:621
 		{
 			yyVAL.sources = append(yyDollar[1].sources, yyDollar[3].sources...)
 		}
-	case 77:
+	case 90:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:563
+//line sql.y:627
 		{
 			all_subquerys := []influxql.Source{}
 			for _, temp_stmt := range yyDollar[2].stmts {
@@ -1636,66 +1862,66 @@ yydefault:
 			}
 			yyVAL.sources = all_subquerys
 		}
-	case 78:
+	case 91:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:579
+//line sql.y:643
 		{
 			yyVAL.ment = yyDollar[1].ment
 		}
-	case 79:
+	case 92:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:585
+//line sql.y:649
 		{
 			mst := yyDollar[5].ment
 			mst.Database = yyDollar[1].str
 			mst.RetentionPolicy = yyDollar[3].str
 			yyVAL.ment = mst
 		}
-	case 80:
+	case 93:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:592
+//line sql.y:656
 		{
 			mst := yyDollar[4].ment
 			mst.RetentionPolicy = yyDollar[2].str
 			yyVAL.ment = mst
 		}
-	case 81:
+	case 94:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:598
+//line sql.y:662
 		{
 			mst := yyDollar[4].ment
 			mst.Database = yyDollar[1].str
 			yyVAL.ment = mst
 		}
-	case 82:
+	case 95:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:604
+//line sql.y:668
 		{
 			mst := yyDollar[3].ment
 			mst.RetentionPolicy = yyDollar[1].str
 			yyVAL.ment = mst
 		}
-	case 83:
+	case 96:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:610
+//line sql.y:674
 		{
 			yyVAL.ment = yyDollar[1].ment
 		}
-	case 84:
+	case 97:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:616
+//line sql.y:680
 		{
 			yyVAL.ment = &influxql.Measurement{Name: yyDollar[1].str}
 		}
-	case 85:
+	case 98:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:620
+//line sql.y:684
 		{
 			yyVAL.ment = &influxql.Measurement{Name: yyDollar[1].str}
 		}
-	case 86:
+	case 99:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:624
+//line sql.y:688
 		{
 			re, err := regexp.Compile(yyDollar[1].str)
 			if err != nil {
@@ -1704,75 +1930,75 @@ yydefault:
 
 			yyVAL.ment = &influxql.Measurement{Regex: &influxql.RegexLiteral{Val: re}}
 		}
-	case 87:
+	case 100:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:635
+//line sql.y:699
 		{
 			yyVAL.ment = &influxql.Measurement{}
 		}
-	case 88:
+	case 101:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:639
+//line sql.y:703
 		{
 			yyVAL.ment = &influxql.Measurement{}
 		}
-	case 89:
+	case 102:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:645
+//line sql.y:709
 		{
 			yyVAL.ment = &influxql.Measurement{}
 		}
-	case 90:
+	case 103:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:651
+//line sql.y:715
 		{
 			yyVAL.dimens = yyDollar[3].dimens
 		}
-	case 91:
+	case 104:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:655
+//line sql.y:719
 		{
 			yyVAL.dimens = nil
 		}
-	case 92:
+	case 105:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:661
+//line sql.y:725
 		{
 			yyVAL.dimens = []*influxql.Dimension{yyDollar[1].dimen}
 		}
-	case 93:
+	case 106:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:665
+//line sql.y:729
 		{
 			yyVAL.dimens = append([]*influxql.Dimension{yyDollar[1].dimen}, yyDollar[3].dimens...)
 		}
-	case 94:
+	case 107:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:671
+//line sql.y:735
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 95:
+	case 108:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:675
+//line sql.y:739
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 96:
+	case 109:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:681
+//line sql.y:745
 		{
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.VarRef{Val: yyDollar[1].str}}
 		}
-	case 97:
+	case 110:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:685
+//line sql.y:749
 		{
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.VarRef{Val: yyDollar[1].str}}
 		}
-	case 98:
+	case 111:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:689
+//line sql.y:753
 		{
 			if strings.ToLower(yyDollar[1].str) != "time" {
 				yylex.Error("Invalid group by combination for no-time tag and time duration")
@@ -1780,9 +2006,9 @@ yydefault:
 
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.Call{Name: "time", Args: []influxql.Expr{&influxql.DurationLiteral{Val: yyDollar[3].tdur}}}}
 		}
-	case 99:
+	case 112:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:697
+//line sql.y:761
 		{
 			if strings.ToLower(yyDollar[1].str) != "time" {
 				yylex.Error("Invalid group by combination for no-time tag and time duration")
@@ -1790,9 +2016,9 @@ yydefault:
 
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.Call{Name: "time", Args: []influxql.Expr{&influxql.DurationLiteral{Val: yyDollar[3].tdur}, &influxql.DurationLiteral{Val: yyDollar[5].tdur}}}}
 		}
-	case 100:
+	case 113:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:705
+//line sql.y:769
 		{
 			if strings.ToLower(yyDollar[1].str) != "time" {
 				yylex.Error("Invalid group by combination for no-time tag and time duration")
@@ -1800,21 +2026,21 @@ yydefault:
 
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.Call{Name: "time", Args: []influxql.Expr{&influxql.DurationLiteral{Val: yyDollar[3].tdur}, &influxql.DurationLiteral{Val: time.Duration(-yyDollar[6].tdur)}}}}
 		}
-	case 101:
+	case 114:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:713
+//line sql.y:777
 		{
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.Wildcard{Type: influxql.Token(yyDollar[1].int)}}
 		}
-	case 102:
+	case 115:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:717
+//line sql.y:781
 		{
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.Wildcard{Type: influxql.Token(yyDollar[1].int)}}
 		}
-	case 103:
+	case 116:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:721
+//line sql.y:785
 		{
 			re, err := regexp.Compile(yyDollar[1].str)
 			if err != nil {
@@ -1822,9 +2048,9 @@ yydefault:
 			}
 			yyVAL.dimen = &influxql.Dimension{Expr: &influxql.RegexLiteral{Val: re}}
 		}
-	case 104:
+	case 117:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:732
+//line sql.y:796
 		{
 			if strings.ToLower(yyDollar[1].str) != "tz" {
 				yylex.Error("Expect tz")
@@ -1835,117 +2061,117 @@ yydefault:
 			}
 			yyVAL.location = loc
 		}
-	case 105:
+	case 118:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:743
+//line sql.y:807
 		{
 			yyVAL.location = nil
 		}
-	case 106:
+	case 119:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:749
+//line sql.y:813
 		{
 			yyVAL.inter = yyDollar[3].inter
 		}
-	case 107:
+	case 120:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:753
+//line sql.y:817
 		{
 			yyVAL.inter = "null"
 		}
-	case 108:
+	case 121:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:759
+//line sql.y:823
 		{
 			yyVAL.inter = yyDollar[1].str
 		}
-	case 109:
+	case 122:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:763
+//line sql.y:827
 		{
 			yyVAL.inter = yyDollar[1].int64
 		}
-	case 110:
+	case 123:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:767
+//line sql.y:831
 		{
 			yyVAL.inter = yyDollar[1].float64
 		}
-	case 111:
+	case 124:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:773
+//line sql.y:837
 		{
 			yyVAL.expr = yyDollar[2].expr
 		}
-	case 112:
+	case 125:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:777
+//line sql.y:841
 		{
 			yyVAL.expr = nil
 		}
-	case 113:
+	case 126:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:783
+//line sql.y:847
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 114:
+	case 127:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:787
+//line sql.y:851
 		{
 			yyVAL.expr = &influxql.ParenExpr{Expr: yyDollar[2].expr}
 		}
-	case 115:
+	case 128:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:791
+//line sql.y:855
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(yyDollar[2].int), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 116:
+	case 129:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:795
+//line sql.y:859
 		{
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(yyDollar[2].int), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 117:
+	case 130:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:799
+//line sql.y:863
 		{
 			yyVAL.expr = &influxql.BinaryExpr{}
 		}
-	case 118:
+	case 131:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:803
+//line sql.y:867
 		{
 			yyVAL.expr = &influxql.BinaryExpr{}
 		}
-	case 119:
+	case 132:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:807
+//line sql.y:871
 		{
 			yyVAL.expr = &influxql.BinaryExpr{}
 		}
-	case 120:
+	case 133:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:811
+//line sql.y:875
 		{
 			yyVAL.expr = &influxql.BinaryExpr{}
 		}
-	case 121:
+	case 134:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:815
+//line sql.y:879
 		{
 			yyVAL.expr = &influxql.BinaryExpr{}
 		}
-	case 122:
+	case 135:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:819
+//line sql.y:883
 		{
 			yyVAL.expr = &influxql.BinaryExpr{}
 		}
-	case 123:
+	case 136:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:825
+//line sql.y:889
 		{
 			if yyDollar[2].int == influxql.NEQREGEX {
 				switch yyDollar[3].expr.(type) {
@@ -1956,117 +2182,117 @@ yydefault:
 			}
 			yyVAL.expr = &influxql.BinaryExpr{Op: influxql.Token(yyDollar[2].int), LHS: yyDollar[1].expr, RHS: yyDollar[3].expr}
 		}
-	case 124:
+	case 137:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:838
+//line sql.y:902
 		{
 			yyVAL.expr = yyDollar[1].expr
 		}
-	case 125:
+	case 138:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:842
+//line sql.y:906
 		{
 			yyVAL.expr = &influxql.ParenExpr{Expr: yyDollar[2].expr}
 		}
-	case 126:
+	case 139:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:848
+//line sql.y:912
 		{
 			yyVAL.int = influxql.EQ
 		}
-	case 127:
+	case 140:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:852
+//line sql.y:916
 		{
 			yyVAL.int = influxql.NEQ
 		}
-	case 128:
+	case 141:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:856
+//line sql.y:920
 		{
 			yyVAL.int = influxql.LT
 		}
-	case 129:
+	case 142:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:860
+//line sql.y:924
 		{
 			yyVAL.int = influxql.LTE
 		}
-	case 130:
+	case 143:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:864
+//line sql.y:928
 		{
 			yyVAL.int = influxql.GT
 		}
-	case 131:
+	case 144:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:868
+//line sql.y:932
 		{
 			yyVAL.int = influxql.GTE
 		}
-	case 132:
+	case 145:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:872
+//line sql.y:936
 		{
 			yyVAL.int = influxql.EQREGEX
 		}
-	case 133:
+	case 146:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:876
+//line sql.y:940
 		{
 			yyVAL.int = influxql.NEQREGEX
 		}
-	case 134:
+	case 147:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:882
+//line sql.y:946
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 135:
+	case 148:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:888
+//line sql.y:952
 		{
 			yyVAL.expr = &influxql.VarRef{Val: yyDollar[1].str}
 		}
-	case 136:
+	case 149:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:892
+//line sql.y:956
 		{
 			yyVAL.expr = &influxql.VarRef{Val: yyDollar[1].str, Type: yyDollar[3].dataType}
 		}
-	case 137:
+	case 150:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:896
+//line sql.y:960
 		{
 			yyVAL.expr = &influxql.NumberLiteral{Val: yyDollar[1].float64}
 		}
-	case 138:
+	case 151:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:900
+//line sql.y:964
 		{
 			yyVAL.expr = &influxql.IntegerLiteral{Val: yyDollar[1].int64}
 		}
-	case 139:
+	case 152:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:904
+//line sql.y:968
 		{
 			yyVAL.expr = &influxql.StringLiteral{Val: yyDollar[1].str}
 		}
-	case 140:
+	case 153:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:908
+//line sql.y:972
 		{
 			yyVAL.expr = &influxql.BooleanLiteral{Val: true}
 		}
-	case 141:
+	case 154:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:912
+//line sql.y:976
 		{
 			yyVAL.expr = &influxql.BooleanLiteral{Val: false}
 		}
-	case 142:
+	case 155:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:916
+//line sql.y:980
 		{
 			re, err := regexp.Compile(yyDollar[1].str)
 			if err != nil {
@@ -2074,9 +2300,9 @@ yydefault:
 			}
 			yyVAL.expr = &influxql.RegexLiteral{Val: re}
 		}
-	case 143:
+	case 156:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:926
+//line sql.y:990
 		{
 			switch strings.ToLower(yyDollar[1].str) {
 			case "float":
@@ -2097,141 +2323,141 @@ yydefault:
 				yylex.Error("wrong field dataType")
 			}
 		}
-	case 144:
+	case 157:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:947
+//line sql.y:1011
 		{
 			yyVAL.dataType = influxql.Tag
 		}
-	case 145:
+	case 158:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:951
+//line sql.y:1015
 		{
 			yyVAL.dataType = influxql.AnyField
 		}
-	case 146:
+	case 159:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:957
+//line sql.y:1021
 		{
 			yyVAL.sortfs = yyDollar[3].sortfs
 		}
-	case 147:
+	case 160:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:961
+//line sql.y:1025
 		{
 			yyVAL.sortfs = nil
 		}
-	case 148:
+	case 161:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:967
+//line sql.y:1031
 		{
 			yyVAL.sortfs = []*influxql.SortField{yyDollar[1].sortf}
 		}
-	case 149:
+	case 162:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:971
+//line sql.y:1035
 		{
 			yyVAL.sortfs = append([]*influxql.SortField{yyDollar[1].sortf}, yyDollar[3].sortfs...)
 		}
-	case 150:
+	case 163:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:977
+//line sql.y:1041
 		{
 			yyVAL.sortf = &influxql.SortField{Name: yyDollar[1].str, Ascending: true}
 		}
-	case 151:
+	case 164:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:981
+//line sql.y:1045
 		{
 			yyVAL.sortf = &influxql.SortField{Name: yyDollar[1].str, Ascending: false}
 		}
-	case 152:
+	case 165:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:985
+//line sql.y:1049
 		{
 			yyVAL.sortf = &influxql.SortField{Name: yyDollar[1].str, Ascending: true}
 		}
-	case 153:
+	case 166:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:991
+//line sql.y:1055
 		{
 			yyVAL.intSlice = append(yyDollar[1].intSlice, yyDollar[2].intSlice...)
 		}
-	case 154:
+	case 167:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:997
+//line sql.y:1061
 		{
 			yyVAL.intSlice = []int{int(yyDollar[2].int64), int(yyDollar[4].int64)}
 		}
-	case 155:
+	case 168:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1001
+//line sql.y:1065
 		{
 			yyVAL.intSlice = []int{int(yyDollar[2].int64), 0}
 		}
-	case 156:
+	case 169:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1005
+//line sql.y:1069
 		{
 			yyVAL.intSlice = []int{0, int(yyDollar[2].int64)}
 		}
-	case 157:
+	case 170:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:1009
+//line sql.y:1073
 		{
 			yyVAL.intSlice = []int{0, 0}
 		}
-	case 158:
+	case 171:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:1015
+//line sql.y:1079
 		{
 			yyVAL.intSlice = []int{int(yyDollar[2].int64), int(yyDollar[4].int64)}
 		}
-	case 159:
+	case 172:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1019
+//line sql.y:1083
 		{
 			yyVAL.intSlice = []int{int(yyDollar[2].int64), 0}
 		}
-	case 160:
+	case 173:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1023
+//line sql.y:1087
 		{
 			yyVAL.intSlice = []int{0, int(yyDollar[2].int64)}
 		}
-	case 161:
+	case 174:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:1027
+//line sql.y:1091
 		{
 			yyVAL.intSlice = []int{0, 0}
 		}
-	case 162:
+	case 175:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1033
+//line sql.y:1097
 		{
 			yyVAL.stmt = &influxql.ShowDatabasesStatement{}
 		}
-	case 163:
+	case 176:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:1039
+//line sql.y:1103
 		{
 			sms := yyDollar[4].stmt
 
 			sms.(*influxql.CreateDatabaseStatement).Name = yyDollar[3].str
 			yyVAL.stmt = sms
 		}
-	case 164:
+	case 177:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1046
+//line sql.y:1110
 		{
 			stmt := &influxql.CreateDatabaseStatement{}
 			stmt.RetentionPolicyCreate = false
 			stmt.Name = yyDollar[3].str
 			yyVAL.stmt = stmt
 		}
-	case 165:
+	case 178:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1055
+//line sql.y:1119
 		{
 			stmt := &influxql.CreateDatabaseStatement{}
 			stmt.RetentionPolicyCreate = true
@@ -2271,15 +2497,15 @@ yydefault:
 			stmt.ReplicaNum = yyDollar[2].durations.ReplicaNum
 			yyVAL.stmt = stmt
 		}
-	case 166:
+	case 179:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:1099
+//line sql.y:1163
 		{
 			yyVAL.durations = yyDollar[1].durations
 		}
-	case 167:
+	case 180:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1103
+//line sql.y:1167
 		{
 			if yyDollar[1].durations.ShardGroupDuration < 0 || yyDollar[2].durations.ShardGroupDuration < 0 {
 				if yyDollar[2].durations.ShardGroupDuration >= 0 {
@@ -2356,21 +2582,21 @@ yydefault:
 			}
 			yyVAL.durations = yyDollar[1].durations
 		}
-	case 168:
+	case 181:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:1182
+//line sql.y:1246
 		{
 			yyVAL.durations = yyDollar[1].durations
 		}
-	case 169:
+	case 182:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1186
+//line sql.y:1250
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: -1, PolicyDuration: &yyDollar[2].tdur}
 		}
-	case 170:
+	case 183:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1190
+//line sql.y:1254
 		{
 			if yyDollar[2].int64 < 1 || yyDollar[2].int64 > 2147483647 {
 				yylex.Error("REPLICATION must be 1 <= n <= 2147483647")
@@ -2378,36 +2604,36 @@ yydefault:
 			int_integer := *(*int)(unsafe.Pointer(&yyDollar[2].int64))
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: -1, Replication: &int_integer}
 		}
-	case 171:
+	case 184:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1198
+//line sql.y:1262
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: -1, PolicyName: yyDollar[2].str}
 		}
-	case 172:
+	case 185:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1202
+//line sql.y:1266
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: -1, ReplicaNum: uint32(yyDollar[2].int64)}
 		}
-	case 173:
+	case 186:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:1206
+//line sql.y:1270
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: -1, rpdefault: true}
 		}
-	case 174:
+	case 187:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1210
+//line sql.y:1274
 		{
 			if len(yyDollar[2].strSlice) == 0 {
 				yylex.Error("ShardKey should not be nil")
 			}
 			yyVAL.durations = &Durations{ShardKey: yyDollar[2].strSlice, ShardGroupDuration: -1, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: -1, rpdefault: false}
 		}
-	case 175:
+	case 188:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1221
+//line sql.y:1285
 		{
 			sms := &influxql.ShowMeasurementsStatement{}
 			sms.Database = yyDollar[3].str
@@ -2418,9 +2644,9 @@ yydefault:
 			sms.Offset = yyDollar[9].intSlice[1]
 			yyVAL.stmt = sms
 		}
-	case 176:
+	case 189:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1232
+//line sql.y:1296
 		{
 			sms := &influxql.ShowMeasurementsStatement{}
 			sms.Database = yyDollar[3].str
@@ -2430,21 +2656,21 @@ yydefault:
 			sms.Offset = yyDollar[6].intSlice[1]
 			yyVAL.stmt = sms
 		}
-	case 177:
+	case 190:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1245
+//line sql.y:1309
 		{
 			yyVAL.ment = &influxql.Measurement{Name: yyDollar[2].str}
 		}
-	case 178:
+	case 191:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1249
+//line sql.y:1313
 		{
 			yyVAL.ment = &influxql.Measurement{Name: yyDollar[2].str}
 		}
-	case 179:
+	case 192:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1253
+//line sql.y:1317
 		{
 			re, err := regexp.Compile(yyDollar[2].str)
 			if err != nil {
@@ -2452,9 +2678,9 @@ yydefault:
 			}
 			yyVAL.ment = &influxql.Measurement{Regex: &influxql.RegexLiteral{Val: re}}
 		}
-	case 180:
+	case 193:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1261
+//line sql.y:1325
 		{
 			re, err := regexp.Compile(yyDollar[2].str)
 			if err != nil {
@@ -2462,32 +2688,32 @@ yydefault:
 			}
 			yyVAL.ment = &influxql.Measurement{Regex: &influxql.RegexLiteral{Val: re}}
 		}
-	case 181:
+	case 194:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:1273
+//line sql.y:1337
 		{
 			yyVAL.stmt = &influxql.ShowRetentionPoliciesStatement{
 				Database: yyDollar[5].str,
 			}
 		}
-	case 182:
+	case 195:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1279
+//line sql.y:1343
 		{
 			yyVAL.stmt = &influxql.ShowRetentionPoliciesStatement{}
 		}
-	case 183:
+	case 196:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1286
+//line sql.y:1350
 		{
 			stmt := yyDollar[7].stmt.(*influxql.CreateRetentionPolicyStatement)
 			stmt.Name = yyDollar[4].str
 			stmt.Database = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 184:
+	case 197:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:1293
+//line sql.y:1357
 		{
 			stmt := yyDollar[7].stmt.(*influxql.CreateRetentionPolicyStatement)
 			stmt.Name = yyDollar[4].str
@@ -2495,18 +2721,18 @@ yydefault:
 			stmt.Default = true
 			yyVAL.stmt = stmt
 		}
-	case 185:
+	case 198:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1303
+//line sql.y:1367
 		{
 			stmt := &influxql.CreateUserStatement{}
 			stmt.Name = yyDollar[3].str
 			stmt.Password = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 186:
+	case 199:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1310
+//line sql.y:1374
 		{
 			stmt := &influxql.CreateUserStatement{}
 			stmt.Name = yyDollar[3].str
@@ -2514,9 +2740,9 @@ yydefault:
 			stmt.Admin = true
 			yyVAL.stmt = stmt
 		}
-	case 187:
+	case 200:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1318
+//line sql.y:1382
 		{
 			stmt := &influxql.CreateUserStatement{}
 			stmt.Name = yyDollar[3].str
@@ -2524,9 +2750,9 @@ yydefault:
 			stmt.Rwuser = true
 			yyVAL.stmt = stmt
 		}
-	case 188:
+	case 201:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:1329
+//line sql.y:1393
 		{
 			stmt := &influxql.CreateRetentionPolicyStatement{}
 			stmt.Duration = yyDollar[2].tdur
@@ -2561,9 +2787,9 @@ yydefault:
 
 			yyVAL.stmt = stmt
 		}
-	case 189:
+	case 202:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:1364
+//line sql.y:1428
 		{
 			stmt := &influxql.CreateRetentionPolicyStatement{}
 			stmt.Duration = yyDollar[2].tdur
@@ -2573,15 +2799,15 @@ yydefault:
 			stmt.Replication = int(yyDollar[4].int64)
 			yyVAL.stmt = stmt
 		}
-	case 190:
+	case 203:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:1377
+//line sql.y:1441
 		{
 			yyVAL.durations = yyDollar[1].durations
 		}
-	case 191:
+	case 204:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1381
+//line sql.y:1445
 		{
 			if yyDollar[1].durations.ShardGroupDuration < 0 || yyDollar[2].durations.ShardGroupDuration < 0 {
 				if yyDollar[2].durations.ShardGroupDuration >= 0 {
@@ -2616,33 +2842,33 @@ yydefault:
 			}
 			yyVAL.durations = yyDollar[1].durations
 		}
-	case 192:
+	case 205:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1419
+//line sql.y:1483
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: yyDollar[3].tdur, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: -1}
 		}
-	case 193:
+	case 206:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1423
+//line sql.y:1487
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: yyDollar[3].tdur, WarmDuration: -1, IndexGroupDuration: -1}
 		}
-	case 194:
+	case 207:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1427
+//line sql.y:1491
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: -1, WarmDuration: yyDollar[3].tdur, IndexGroupDuration: -1}
 		}
-	case 195:
+	case 208:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1431
+//line sql.y:1495
 		{
 			yyVAL.durations = &Durations{ShardGroupDuration: -1, HotDuration: -1, WarmDuration: -1, IndexGroupDuration: yyDollar[3].tdur}
 		}
-	case 196:
+	case 209:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1439
+//line sql.y:1503
 		{
 			stmt := &influxql.ShowSeriesStatement{}
 			stmt.Database = yyDollar[3].str
@@ -2653,9 +2879,9 @@ yydefault:
 			stmt.Offset = yyDollar[7].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 197:
+	case 210:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1450
+//line sql.y:1514
 		{
 			stmt := &influxql.ShowSeriesStatement{}
 			stmt.Database = yyDollar[3].str
@@ -2665,57 +2891,57 @@ yydefault:
 			stmt.Offset = yyDollar[6].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 198:
+	case 211:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1462
+//line sql.y:1526
 		{
 			yyVAL.stmt = &influxql.ShowUsersStatement{}
 		}
-	case 199:
+	case 212:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1468
+//line sql.y:1532
 		{
 			stmt := &influxql.DropDatabaseStatement{}
 			stmt.Name = yyDollar[3].str
 			yyVAL.stmt = stmt
 		}
-	case 200:
+	case 213:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:1476
+//line sql.y:1540
 		{
 			stmt := &influxql.DropSeriesStatement{}
 			stmt.Sources = yyDollar[3].sources
 			stmt.Condition = yyDollar[4].expr
 			yyVAL.stmt = stmt
 		}
-	case 201:
+	case 214:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1483
+//line sql.y:1547
 		{
 			stmt := &influxql.DropSeriesStatement{}
 			stmt.Condition = yyDollar[3].expr
 			yyVAL.stmt = stmt
 		}
-	case 202:
+	case 215:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1491
+//line sql.y:1555
 		{
 			stmt := &influxql.DeleteSeriesStatement{}
 			stmt.Sources = yyDollar[2].sources
 			stmt.Condition = yyDollar[3].expr
 			yyVAL.stmt = stmt
 		}
-	case 203:
+	case 216:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1498
+//line sql.y:1562
 		{
 			stmt := &influxql.DeleteSeriesStatement{}
 			stmt.Condition = yyDollar[2].expr
 			yyVAL.stmt = stmt
 		}
-	case 204:
+	case 217:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1507
+//line sql.y:1571
 		{
 			stmt := &influxql.AlterRetentionPolicyStatement{}
 			stmt.Name = yyDollar[4].str
@@ -2750,18 +2976,18 @@ yydefault:
 			yyVAL.stmt = stmt
 
 		}
-	case 205:
+	case 218:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1546
+//line sql.y:1610
 		{
 			stmt := &influxql.DropRetentionPolicyStatement{}
 			stmt.Name = yyDollar[4].str
 			stmt.Database = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 206:
+	case 219:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1555
+//line sql.y:1619
 		{
 			stmt := &influxql.GrantStatement{}
 			stmt.Privilege = influxql.AllPrivileges
@@ -2769,9 +2995,9 @@ yydefault:
 			stmt.User = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 207:
+	case 220:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1563
+//line sql.y:1627
 		{
 			stmt := &influxql.GrantStatement{}
 			stmt.Privilege = influxql.AllPrivileges
@@ -2779,9 +3005,9 @@ yydefault:
 			stmt.User = yyDollar[7].str
 			yyVAL.stmt = stmt
 		}
-	case 208:
+	case 221:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1571
+//line sql.y:1635
 		{
 			stmt := &influxql.GrantStatement{}
 			switch strings.ToLower(yyDollar[2].str) {
@@ -2796,21 +3022,21 @@ yydefault:
 			stmt.User = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 209:
+	case 222:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:1588
+//line sql.y:1652
 		{
 			yyVAL.stmt = &influxql.GrantAdminStatement{User: yyDollar[5].str}
 		}
-	case 210:
+	case 223:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:1592
+//line sql.y:1656
 		{
 			yyVAL.stmt = &influxql.GrantAdminStatement{User: yyDollar[4].str}
 		}
-	case 211:
+	case 224:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1598
+//line sql.y:1662
 		{
 			stmt := &influxql.RevokeStatement{}
 			stmt.Privilege = influxql.AllPrivileges
@@ -2818,9 +3044,9 @@ yydefault:
 			stmt.User = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 212:
+	case 225:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1606
+//line sql.y:1670
 		{
 			stmt := &influxql.RevokeStatement{}
 			stmt.Privilege = influxql.AllPrivileges
@@ -2828,9 +3054,9 @@ yydefault:
 			stmt.User = yyDollar[7].str
 			yyVAL.stmt = stmt
 		}
-	case 213:
+	case 226:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1614
+//line sql.y:1678
 		{
 			stmt := &influxql.RevokeStatement{}
 			switch strings.ToLower(yyDollar[2].str) {
@@ -2845,27 +3071,27 @@ yydefault:
 			stmt.User = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 214:
+	case 227:
 		yyDollar = yyS[yypt-5 : yypt+1]
-//line sql.y:1631
+//line sql.y:1695
 		{
 			yyVAL.stmt = &influxql.RevokeAdminStatement{User: yyDollar[5].str}
 		}
-	case 215:
+	case 228:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:1635
+//line sql.y:1699
 		{
 			yyVAL.stmt = &influxql.RevokeAdminStatement{User: yyDollar[4].str}
 		}
-	case 216:
+	case 229:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1641
+//line sql.y:1705
 		{
 			yyVAL.stmt = &influxql.DropUserStatement{Name: yyDollar[3].str}
 		}
-	case 217:
+	case 230:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:1647
+//line sql.y:1711
 		{
 			stmt := &influxql.ShowTagKeysStatement{}
 			stmt.Database = yyDollar[4].str
@@ -2879,9 +3105,9 @@ yydefault:
 			yyVAL.stmt = stmt
 
 		}
-	case 218:
+	case 231:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1661
+//line sql.y:1725
 		{
 			stmt := &influxql.ShowTagKeysStatement{}
 			stmt.Database = yyDollar[4].str
@@ -2893,21 +3119,21 @@ yydefault:
 			stmt.SOffset = yyDollar[7].intSlice[3]
 			yyVAL.stmt = stmt
 		}
-	case 219:
+	case 232:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1675
+//line sql.y:1739
 		{
 			yyVAL.str = yyDollar[2].str
 		}
-	case 220:
+	case 233:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:1679
+//line sql.y:1743
 		{
 			yyVAL.str = ""
 		}
-	case 221:
+	case 234:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1685
+//line sql.y:1749
 		{
 			stmt := &influxql.ShowFieldKeysStatement{}
 			stmt.Database = yyDollar[4].str
@@ -2917,9 +3143,9 @@ yydefault:
 			stmt.Offset = yyDollar[7].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 222:
+	case 235:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:1695
+//line sql.y:1759
 		{
 			stmt := &influxql.ShowFieldKeysStatement{}
 			stmt.Database = yyDollar[4].str
@@ -2928,9 +3154,9 @@ yydefault:
 			stmt.Offset = yyDollar[6].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 223:
+	case 236:
 		yyDollar = yyS[yypt-11 : yypt+1]
-//line sql.y:1707
+//line sql.y:1771
 		{
 			stmt := yyDollar[8].stmt.(*influxql.ShowTagValuesStatement)
 			stmt.TagKeyCondition = nil
@@ -2943,9 +3169,9 @@ yydefault:
 			yyVAL.stmt = stmt
 
 		}
-	case 224:
+	case 237:
 		yyDollar = yyS[yypt-10 : yypt+1]
-//line sql.y:1720
+//line sql.y:1784
 		{
 			stmt := yyDollar[7].stmt.(*influxql.ShowTagValuesStatement)
 			stmt.TagKeyCondition = nil
@@ -2956,36 +3182,36 @@ yydefault:
 			stmt.Offset = yyDollar[10].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 225:
+	case 238:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1733
+//line sql.y:1797
 		{
 			stmt := &influxql.ShowTagValuesStatement{}
 			stmt.Op = influxql.EQ
 			stmt.TagKeyExpr = yyDollar[2].expr.(*influxql.ListLiteral)
 			yyVAL.stmt = stmt
 		}
-	case 226:
+	case 239:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1740
+//line sql.y:1804
 		{
 			stmt := &influxql.ShowTagValuesStatement{}
 			stmt.Op = influxql.NEQ
 			stmt.TagKeyExpr = yyDollar[2].expr.(*influxql.ListLiteral)
 			yyVAL.stmt = stmt
 		}
-	case 227:
+	case 240:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:1747
+//line sql.y:1811
 		{
 			stmt := &influxql.ShowTagValuesStatement{}
 			stmt.Op = influxql.IN
 			stmt.TagKeyExpr = yyDollar[3].expr.(*influxql.ListLiteral)
 			yyVAL.stmt = stmt
 		}
-	case 228:
+	case 241:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1754
+//line sql.y:1818
 		{
 			stmt := &influxql.ShowTagValuesStatement{}
 			stmt.Op = influxql.EQREGEX
@@ -2996,9 +3222,9 @@ yydefault:
 			stmt.TagKeyExpr = &influxql.RegexLiteral{Val: re}
 			yyVAL.stmt = stmt
 		}
-	case 229:
+	case 242:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1765
+//line sql.y:1829
 		{
 			stmt := &influxql.ShowTagValuesStatement{}
 			stmt.Op = influxql.NEQREGEX
@@ -3009,47 +3235,47 @@ yydefault:
 			stmt.TagKeyExpr = &influxql.RegexLiteral{Val: re}
 			yyVAL.stmt = stmt
 		}
-	case 230:
+	case 243:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:1779
+//line sql.y:1843
 		{
 			temp := []string{yyDollar[1].str}
 			yyVAL.expr = &influxql.ListLiteral{Vals: temp}
 		}
-	case 231:
+	case 244:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1784
+//line sql.y:1848
 		{
 			yyDollar[3].expr.(*influxql.ListLiteral).Vals = append(yyDollar[3].expr.(*influxql.ListLiteral).Vals, yyDollar[1].str)
 			yyVAL.expr = yyDollar[3].expr
 		}
-	case 232:
+	case 245:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:1791
+//line sql.y:1855
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 233:
+	case 246:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:1799
+//line sql.y:1863
 		{
 			stmt := &influxql.ExplainStatement{}
 			stmt.Statement = yyDollar[3].stmt.(*influxql.SelectStatement)
 			stmt.Analyze = true
 			yyVAL.stmt = stmt
 		}
-	case 234:
+	case 247:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:1806
+//line sql.y:1870
 		{
 			stmt := &influxql.ExplainStatement{}
 			stmt.Statement = yyDollar[2].stmt.(*influxql.SelectStatement)
 			stmt.Analyze = false
 			yyVAL.stmt = stmt
 		}
-	case 235:
+	case 248:
 		yyDollar = yyS[yypt-10 : yypt+1]
-//line sql.y:1816
+//line sql.y:1880
 		{
 			stmt := &influxql.ShowTagKeyCardinalityStatement{}
 			stmt.Database = yyDollar[6].str
@@ -3061,9 +3287,9 @@ yydefault:
 			stmt.Offset = yyDollar[10].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 236:
+	case 249:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1828
+//line sql.y:1892
 		{
 			stmt := &influxql.ShowTagKeyCardinalityStatement{}
 			stmt.Database = yyDollar[6].str
@@ -3074,9 +3300,9 @@ yydefault:
 			stmt.Offset = yyDollar[9].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 237:
+	case 250:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1839
+//line sql.y:1903
 		{
 			stmt := &influxql.ShowTagKeyCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3088,9 +3314,9 @@ yydefault:
 			stmt.Offset = yyDollar[9].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 238:
+	case 251:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:1851
+//line sql.y:1915
 		{
 			stmt := &influxql.ShowTagKeyCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3101,9 +3327,9 @@ yydefault:
 			stmt.Offset = yyDollar[8].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 239:
+	case 252:
 		yyDollar = yyS[yypt-13 : yypt+1]
-//line sql.y:1867
+//line sql.y:1931
 		{
 			stmt := &influxql.ShowTagValuesCardinalityStatement{}
 			stmt.Database = yyDollar[6].str
@@ -3120,9 +3346,9 @@ yydefault:
 			yyVAL.stmt = stmt
 
 		}
-	case 240:
+	case 253:
 		yyDollar = yyS[yypt-12 : yypt+1]
-//line sql.y:1884
+//line sql.y:1948
 		{
 			stmt := &influxql.ShowTagValuesCardinalityStatement{}
 			stmt.Database = yyDollar[6].str
@@ -3137,9 +3363,9 @@ yydefault:
 			stmt.TagKeyCondition = nil
 			yyVAL.stmt = stmt
 		}
-	case 241:
+	case 254:
 		yyDollar = yyS[yypt-12 : yypt+1]
-//line sql.y:1899
+//line sql.y:1963
 		{
 			stmt := &influxql.ShowTagValuesCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3156,9 +3382,9 @@ yydefault:
 			yyVAL.stmt = stmt
 
 		}
-	case 242:
+	case 255:
 		yyDollar = yyS[yypt-11 : yypt+1]
-//line sql.y:1916
+//line sql.y:1980
 		{
 			stmt := &influxql.ShowTagValuesCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3173,9 +3399,9 @@ yydefault:
 			stmt.TagKeyCondition = nil
 			yyVAL.stmt = stmt
 		}
-	case 243:
+	case 256:
 		yyDollar = yyS[yypt-10 : yypt+1]
-//line sql.y:1934
+//line sql.y:1998
 		{
 			stmt := &influxql.ShowFieldKeyCardinalityStatement{}
 			stmt.Database = yyDollar[6].str
@@ -3187,9 +3413,9 @@ yydefault:
 			stmt.Offset = yyDollar[10].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 244:
+	case 257:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1946
+//line sql.y:2010
 		{
 			stmt := &influxql.ShowFieldKeyCardinalityStatement{}
 			stmt.Database = yyDollar[6].str
@@ -3200,9 +3426,9 @@ yydefault:
 			stmt.Offset = yyDollar[9].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 245:
+	case 258:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1957
+//line sql.y:2021
 		{
 			stmt := &influxql.ShowFieldKeyCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3214,9 +3440,9 @@ yydefault:
 			stmt.Offset = yyDollar[9].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 246:
+	case 259:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:1969
+//line sql.y:2033
 		{
 			stmt := &influxql.ShowFieldKeyCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3227,9 +3453,9 @@ yydefault:
 			stmt.Offset = yyDollar[8].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 247:
-		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:1983
+	case 260:
+		yyDollar = yyS[yypt-11 : yypt+1]
+//line sql.y:2047
 		{
 			stmt := &influxql.CreateMeasurementStatement{}
 			stmt.Database = yyDollar[3].ment.Database
@@ -3242,11 +3468,13 @@ yydefault:
 			stmt.ShardKey = yyDollar[8].strSlice
 			sort.Strings(stmt.ShardKey)
 			stmt.Type = yyDollar[9].str
+			stmt.FloatCodec = yyDollar[10].str
+			stmt.TTL = yyDollar[11].tdur
 			yyVAL.stmt = stmt
 		}
-	case 248:
-		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:1998
+	case 261:
+		yyDollar = yyS[yypt-9 : yypt+1]
+//line sql.y:2064
 		{
 			stmt := &influxql.CreateMeasurementStatement{}
 			stmt.Database = yyDollar[3].ment.Database
@@ -3255,11 +3483,13 @@ yydefault:
 			stmt.ShardKey = yyDollar[6].strSlice
 			sort.Strings(stmt.ShardKey)
 			stmt.Type = yyDollar[7].str
+			stmt.FloatCodec = yyDollar[8].str
+			stmt.TTL = yyDollar[9].tdur
 			yyVAL.stmt = stmt
 		}
-	case 249:
-		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:2009
+	case 262:
+		yyDollar = yyS[yypt-8 : yypt+1]
+//line sql.y:2077
 		{
 			stmt := &influxql.CreateMeasurementStatement{}
 			stmt.Database = yyDollar[3].ment.Database
@@ -3269,11 +3499,38 @@ yydefault:
 				stmt.IndexType = yyDollar[6].indexType.types
 				stmt.IndexList = yyDollar[6].indexType.lists
 			}
+			stmt.FloatCodec = yyDollar[7].str
+			stmt.TTL = yyDollar[8].tdur
 			yyVAL.stmt = stmt
 		}
-	case 250:
+	case 263:
+		yyDollar = yyS[yypt-7 : yypt+1]
+//line sql.y:2091
+		{
+			stmt := &influxql.CreateMeasurementStatement{}
+			stmt.Database = yyDollar[3].ment.Database
+			stmt.Name = yyDollar[3].ment.Name
+			stmt.RetentionPolicy = yyDollar[3].ment.RetentionPolicy
+			stmt.Type = "hash"
+			stmt.FloatCodec = yyDollar[6].str
+			stmt.TTL = yyDollar[7].tdur
+			yyVAL.stmt = stmt
+		}
+	case 264:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2102
+		{
+			stmt := &influxql.CreateMeasurementStatement{}
+			stmt.Database = yyDollar[3].ment.Database
+			stmt.Name = yyDollar[3].ment.Name
+			stmt.RetentionPolicy = yyDollar[3].ment.RetentionPolicy
+			stmt.Type = "hash"
+			stmt.TTL = yyDollar[6].tdur
+			yyVAL.stmt = stmt
+		}
+	case 265:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2021
+//line sql.y:2112
 		{
 			stmt := &influxql.CreateMeasurementStatement{}
 			stmt.Database = yyDollar[3].ment.Database
@@ -3282,18 +3539,189 @@ yydefault:
 			stmt.Type = "hash"
 			yyVAL.stmt = stmt
 		}
-	case 251:
+	case 266:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2123
+		{
+			stmt := &influxql.ShowCompactionsStatement{}
+			yyVAL.stmt = stmt
+		}
+	case 267:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2130
+		{
+			stmt := &influxql.CompactStatement{}
+			stmt.ShardID = uint64(yyDollar[3].int64)
+			stmt.Full = yyDollar[4].bool
+			yyVAL.stmt = stmt
+		}
+	case 268:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2137
+		{
+			stmt := &influxql.CompactStatement{}
+			stmt.Database = yyDollar[3].ment.Database
+			stmt.RetentionPolicy = yyDollar[3].ment.RetentionPolicy
+			stmt.Name = yyDollar[3].ment.Name
+			stmt.Full = yyDollar[4].bool
+			yyVAL.stmt = stmt
+		}
+	case 269:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2148
+		{
+			yyVAL.bool = true
+		}
+	case 270:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2152
+		{
+			yyVAL.bool = false
+		}
+	case 271:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2158
+		{
+			stmt := &influxql.CancelCompactionsStatement{}
+			yyVAL.stmt = stmt
+		}
+	case 272:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2163
+		{
+			stmt := &influxql.CancelCompactionsStatement{}
+			stmt.ShardID = uint64(yyDollar[4].int64)
+			yyVAL.stmt = stmt
+		}
+	case 273:
+		yyDollar = yyS[yypt-4 : yypt+1]
+//line sql.y:2169
+		{
+			stmt := &influxql.CancelCompactionsStatement{}
+			stmt.Database = yyDollar[4].ment.Database
+			stmt.RetentionPolicy = yyDollar[4].ment.RetentionPolicy
+			stmt.Name = yyDollar[4].ment.Name
+			yyVAL.stmt = stmt
+		}
+	case 274:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2179
+		{
+			// the scanner reads db.pt as one identifier
+			i := strings.LastIndexByte(yyDollar[3].str, '.')
+			var pt uint64
+			var err error
+			if i <= 0 {
+				yylex.Error("invalid partition " + yyDollar[3].str + ", expect db.pt")
+			} else if pt, err = strconv.ParseUint(yyDollar[3].str[i+1:], 10, 32); err != nil {
+				yylex.Error("invalid partition " + yyDollar[3].str + ", expect db.pt")
+			}
+			stmt := &influxql.MigratePartitionStatement{}
+			if i > 0 {
+				stmt.Database = yyDollar[3].str[:i]
+			}
+			stmt.PtId = uint32(pt)
+			stmt.NodeID = uint64(yyDollar[6].int64)
+			yyVAL.stmt = stmt
+		}
+	case 275:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2200
+		{
+			stmt := &influxql.DecommissionNodeStatement{}
+			stmt.NodeID = uint64(yyDollar[3].int64)
+			yyVAL.stmt = stmt
+		}
+	case 276:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2208
+		{
+			stmt := &influxql.ShowEventsStatement{}
+			yyVAL.stmt = stmt
+		}
+	case 277:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2215
+		{
+			// DATA is not a keyword, so that it is still a valid identifier
+			if strings.ToUpper(yyDollar[2].str) != "DATA" {
+				yylex.Error("expected DATA, found " + yyDollar[2].str)
+			}
+			stmt := &influxql.ShowDataNodesStatement{}
+			yyVAL.stmt = stmt
+		}
+	case 278:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2226
+		{
+			stmt := &influxql.PauseReplicationStatement{}
+			yyVAL.stmt = stmt
+		}
+	case 279:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2233
+		{
+			stmt := &influxql.ResumeReplicationStatement{}
+			yyVAL.stmt = stmt
+		}
+	case 280:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2240
+		{
+			stmt := &influxql.ShowStatsStatement{}
+			stmt.NodeID = uint64(yyDollar[3].int64)
+			yyVAL.stmt = stmt
+		}
+	case 281:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2246
+		{
+			stmt := &influxql.ShowStatsStatement{}
+			stmt.Module = yyDollar[4].str
+			stmt.NodeID = uint64(yyDollar[5].int64)
+			yyVAL.stmt = stmt
+		}
+	case 282:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2255
+		{
+			stmt := &influxql.ShowDiagnosticsStatement{}
+			stmt.NodeID = uint64(yyDollar[3].int64)
+			yyVAL.stmt = stmt
+		}
+	case 283:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2261
+		{
+			stmt := &influxql.ShowDiagnosticsStatement{}
+			stmt.Module = yyDollar[4].str
+			stmt.NodeID = uint64(yyDollar[5].int64)
+			yyVAL.stmt = stmt
+		}
+	case 284:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2032
+//line sql.y:2270
+		{
+			yyVAL.int64 = yyDollar[3].int64
+		}
+	case 285:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2274
+		{
+			yyVAL.int64 = 0
+		}
+	case 286:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2280
 		{
 			yyVAL.indexType = &IndexType{
 				types: []string{yyDollar[1].str},
 				lists: [][]string{yyDollar[3].strSlice},
 			}
 		}
-	case 252:
+	case 287:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:2041
+//line sql.y:2289
 		{
 			indextype := yyDollar[1].indexType
 			if yyDollar[2].indexType != nil {
@@ -3302,83 +3730,107 @@ yydefault:
 			}
 			yyVAL.indexType = indextype
 		}
-	case 253:
+	case 288:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:2050
+//line sql.y:2298
 		{
 			yyVAL.indexType = nil
 		}
-	case 254:
+	case 289:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:2056
+//line sql.y:2304
 		{
 			yyVAL.strSlice = []string{yyDollar[1].str}
 		}
-	case 255:
+	case 290:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2060
+//line sql.y:2308
 		{
 
 			yyVAL.strSlice = append([]string{yyDollar[1].str}, yyDollar[3].strSlice...)
 		}
-	case 256:
+	case 291:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:2067
+//line sql.y:2315
 		{
 			yyVAL.str = yyDollar[2].str
 		}
-	case 257:
+	case 292:
 		yyDollar = yyS[yypt-0 : yypt+1]
-//line sql.y:2071
+//line sql.y:2319
 		{
 			yyVAL.str = "hash"
 		}
-	case 258:
+	case 293:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2325
+		{
+			yyVAL.str = yyDollar[2].str
+		}
+	case 294:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2329
+		{
+			yyVAL.str = ""
+		}
+	case 295:
+		yyDollar = yyS[yypt-2 : yypt+1]
+//line sql.y:2335
+		{
+			yyVAL.tdur = yyDollar[2].tdur
+		}
+	case 296:
+		yyDollar = yyS[yypt-0 : yypt+1]
+//line sql.y:2339
+		{
+			yyVAL.tdur = 0
+		}
+	case 297:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:2077
+//line sql.y:2345
 		{
 			yyVAL.strSlice = []string{yyDollar[1].str}
 		}
-	case 259:
+	case 298:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2081
+//line sql.y:2349
 		{
 			yyVAL.strSlice = append(yyDollar[1].strSlice, yyDollar[3].str)
 		}
-	case 260:
+	case 299:
 		yyDollar = yyS[yypt-1 : yypt+1]
-//line sql.y:2086
+//line sql.y:2354
 		{
 			yyVAL.str = yyDollar[1].str
 		}
-	case 261:
+	case 300:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2092
+//line sql.y:2360
 		{
 			stmt := &influxql.DropShardStatement{}
 			stmt.ID = uint64(yyDollar[3].int64)
 			yyVAL.stmt = stmt
 		}
-	case 262:
+	case 301:
 		yyDollar = yyS[yypt-6 : yypt+1]
-//line sql.y:2100
+//line sql.y:2368
 		{
 			stmt := &influxql.SetPasswordUserStatement{}
 			stmt.Name = yyDollar[4].str
 			stmt.Password = yyDollar[6].str
 			yyVAL.stmt = stmt
 		}
-	case 263:
+	case 302:
 		yyDollar = yyS[yypt-4 : yypt+1]
-//line sql.y:2111
+//line sql.y:2379
 		{
 			stmt := &influxql.ShowGrantsForUserStatement{}
 			stmt.Name = yyDollar[4].str
 			yyVAL.stmt = stmt
 		}
-	case 264:
+	case 303:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:2119
+//line sql.y:2387
 		{
 			stmt := &influxql.ShowMeasurementCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3390,9 +3842,9 @@ yydefault:
 			stmt.Offset = yyDollar[9].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 265:
+	case 304:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:2131
+//line sql.y:2399
 		{
 			stmt := &influxql.ShowMeasurementCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3403,9 +3855,9 @@ yydefault:
 			stmt.Offset = yyDollar[8].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 266:
+	case 305:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:2142
+//line sql.y:2410
 		{
 			stmt := &influxql.ShowMeasurementCardinalityStatement{}
 			stmt.Database = yyDollar[4].str
@@ -3417,9 +3869,9 @@ yydefault:
 			stmt.Offset = yyDollar[8].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 267:
+	case 306:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:2154
+//line sql.y:2422
 		{
 			stmt := &influxql.ShowMeasurementCardinalityStatement{}
 			stmt.Database = yyDollar[4].str
@@ -3430,9 +3882,9 @@ yydefault:
 			stmt.Offset = yyDollar[7].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 268:
+	case 307:
 		yyDollar = yyS[yypt-9 : yypt+1]
-//line sql.y:2168
+//line sql.y:2436
 		{
 			stmt := &influxql.ShowSeriesCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3444,9 +3896,9 @@ yydefault:
 			stmt.Offset = yyDollar[9].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 269:
+	case 308:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:2180
+//line sql.y:2448
 		{
 			stmt := &influxql.ShowSeriesCardinalityStatement{}
 			stmt.Database = yyDollar[5].str
@@ -3457,9 +3909,9 @@ yydefault:
 			stmt.Offset = yyDollar[8].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 270:
+	case 309:
 		yyDollar = yyS[yypt-8 : yypt+1]
-//line sql.y:2191
+//line sql.y:2459
 		{
 			stmt := &influxql.ShowSeriesCardinalityStatement{}
 			stmt.Database = yyDollar[4].str
@@ -3471,9 +3923,9 @@ yydefault:
 			stmt.Offset = yyDollar[8].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 271:
+	case 310:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:2203
+//line sql.y:2471
 		{
 			stmt := &influxql.ShowSeriesCardinalityStatement{}
 			stmt.Database = yyDollar[4].str
@@ -3484,16 +3936,16 @@ yydefault:
 			stmt.Offset = yyDollar[7].intSlice[1]
 			yyVAL.stmt = stmt
 		}
-	case 272:
+	case 311:
 		yyDollar = yyS[yypt-2 : yypt+1]
-//line sql.y:2217
+//line sql.y:2485
 		{
 			stmt := &influxql.ShowShardsStatement{}
 			yyVAL.stmt = stmt
 		}
-	case 273:
+	case 312:
 		yyDollar = yyS[yypt-7 : yypt+1]
-//line sql.y:2225
+//line sql.y:2493
 		{
 			stmt := &influxql.AlterShardKeyStatement{}
 			stmt.Database = yyDollar[3].ment.Database
@@ -3504,9 +3956,9 @@ yydefault:
 			stmt.Type = yyDollar[7].str
 			yyVAL.stmt = stmt
 		}
-	case 274:
+	case 313:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2236
+//line sql.y:2504
 		{
 			stmt := &influxql.AlterShardKeyStatement{}
 			stmt.Database = yyDollar[3].ment.Database
@@ -3515,21 +3967,137 @@ yydefault:
 			stmt.Type = "hash"
 			yyVAL.stmt = stmt
 		}
-	case 275:
+	case 314:
+		yyDollar = yyS[yypt-6 : yypt+1]
+//line sql.y:2515
+		{
+			stmt := &influxql.AlterMeasurementTTLStatement{}
+			stmt.Database = yyDollar[3].ment.Database
+			stmt.Name = yyDollar[3].ment.Name
+			stmt.RetentionPolicy = yyDollar[3].ment.RetentionPolicy
+			stmt.TTL = yyDollar[6].tdur
+			yyVAL.stmt = stmt
+		}
+	case 315:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2250
+//line sql.y:2529
 		{
 			stmt := &influxql.ShowShardGroupsStatement{}
 			yyVAL.stmt = stmt
 		}
-	case 276:
+	case 316:
 		yyDollar = yyS[yypt-3 : yypt+1]
-//line sql.y:2257
+//line sql.y:2536
 		{
 			stmt := &influxql.DropMeasurementStatement{}
 			stmt.Name = yyDollar[3].str
 			yyVAL.stmt = stmt
 		}
+	case 317:
+		yyDollar = yyS[yypt-3 : yypt+1]
+//line sql.y:2546
+		{
+			stmt := &influxql.ShowSlowQueriesStatement{}
+			yyVAL.stmt = stmt
+		}
+	case 318:
+		yyDollar = yyS[yypt-5 : yypt+1]
+//line sql.y:2551
+		{
+			stmt := &influxql.ShowSlowQueriesStatement{}
+			stmt.Limit = int(yyDollar[5].int64)
+			yyVAL.stmt = stmt
+		}
+	case 319:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2559
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 320:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2563
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 321:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2570
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 322:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2574
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 323:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2578
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 324:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2582
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 325:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2586
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 326:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2590
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 327:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2594
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 328:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2598
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 329:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2602
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 330:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2606
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 331:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2610
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 332:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2614
+		{
+			yyVAL.str = yyDollar[1].str
+		}
+	case 333:
+		yyDollar = yyS[yypt-1 : yypt+1]
+//line sql.y:2618
+		{
+			yyVAL.str = yyDollar[1].str
+		}
 	}
 	goto yystack /* stack new state and value */
 }
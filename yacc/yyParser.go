@@ -110,8 +110,7 @@ func (p *YyParser) Lex(lval *yySymType) int {
 
 				var hints influxql.Hints
 				for _, l := range hitLits {
-					support, ok := influxql.SupportHit[l]
-					if support && ok {
+					if influxql.IsSupportHint(l) {
 						val := &influxql.StringLiteral{Val: l}
 						hints = append(hints, &influxql.Hint{Expr: val})
 					}